@@ -3,10 +3,15 @@ package api
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/alexherrero/sherwood/backend/config"
+	"github.com/alexherrero/sherwood/backend/realtime"
+	"github.com/alexherrero/sherwood/backend/strategies"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAuthMiddleware(t *testing.T) {
@@ -69,3 +74,49 @@ func TestAuthMiddleware(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 	})
 }
+
+// TestWebSocketAuth verifies WebSocket upgrades through the router require the
+// API key via header, query parameter or subprotocol.
+func TestWebSocketAuth(t *testing.T) {
+	cfg := &config.Config{APIKey: "secret123", AllowedOrigins: []string{"*"}}
+	wsManager := realtime.NewWebSocketManager()
+	go wsManager.Run()
+
+	server := httptest.NewServer(NewRouter(cfg, strategies.NewRegistry(), nil, nil, nil, wsManager, nil))
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+
+	t.Run("Missing key rejected", func(t *testing.T) {
+		conn, resp, err := websocket.DefaultDialer.Dial(wsURL, nil)
+		require.Error(t, err)
+		assert.Nil(t, conn)
+		require.NotNil(t, resp)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("Wrong query key rejected", func(t *testing.T) {
+		_, resp, err := websocket.DefaultDialer.Dial(wsURL+"?api_key=wrong", nil)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("Header key accepted", func(t *testing.T) {
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL, http.Header{"X-Sherwood-API-Key": {"secret123"}})
+		require.NoError(t, err)
+		conn.Close()
+	})
+
+	t.Run("Query key accepted", func(t *testing.T) {
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL+"?api_key=secret123", nil)
+		require.NoError(t, err)
+		conn.Close()
+	})
+
+	t.Run("Subprotocol key accepted", func(t *testing.T) {
+		dialer := websocket.Dialer{Subprotocols: []string{realtime.Subprotocol, "api-key.secret123"}}
+		conn, resp, err := dialer.Dial(wsURL, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+		assert.Equal(t, realtime.Subprotocol, resp.Header.Get("Sec-WebSocket-Protocol"))
+	})
+}
@@ -104,3 +104,53 @@ func TestCORSMiddleware(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 	})
 }
+
+// TestCORSMiddleware_Patterns verifies wildcard and regex origins echo back
+// only matching origins, and that reloaded origins take effect.
+func TestCORSMiddleware_Patterns(t *testing.T) {
+	cfg := &config.Config{
+		AllowedOrigins: []string{
+			"https://app.example.com",
+			"https://*.mycompany.com",
+			`regex:https://pr-\d+\.vercel\.app`,
+		},
+	}
+	handler := newCORSMiddleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	allowOrigin := func(origin string) string {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Origin", origin)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Header().Get("Access-Control-Allow-Origin")
+	}
+
+	for _, origin := range []string{
+		"https://app.example.com",
+		"https://dashboard.mycompany.com",
+		"https://eu.staging.mycompany.com",
+		"https://pr-123.vercel.app",
+	} {
+		assert.Equal(t, origin, allowOrigin(origin), origin)
+	}
+
+	for _, origin := range []string{
+		"https://mycompany.com",             // Wildcard needs a subdomain
+		"http://dashboard.mycompany.com",    // Scheme must match
+		"https://evilmycompany.com",         // Not a subdomain
+		"https://mycompany.com.evil.com",    // Suffix only
+		"https://pr-123.vercel.app.evil.io", // Regex is anchored
+		"https://my-app.vercel.app",
+		"https://other.example.com",
+		"null",
+	} {
+		assert.Empty(t, allowOrigin(origin), origin)
+	}
+
+	// A reload replacing the origins is picked up
+	cfg.AllowedOrigins = []string{"https://other.example.com"}
+	assert.Equal(t, "https://other.example.com", allowOrigin("https://other.example.com"))
+	assert.Empty(t, allowOrigin("https://app.example.com"))
+}
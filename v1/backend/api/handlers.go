@@ -1,7 +1,10 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"sync"
 	"time"
@@ -9,6 +12,7 @@ import (
 	"github.com/alexherrero/sherwood/backend/backtesting"
 	"github.com/alexherrero/sherwood/backend/config"
 	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/data/providers"
 	"github.com/alexherrero/sherwood/backend/engine"
 	"github.com/alexherrero/sherwood/backend/execution"
 	"github.com/alexherrero/sherwood/backend/notifications"
@@ -28,10 +32,22 @@ type Handler struct {
 	notificationManager *notifications.Manager
 	startTime           time.Time
 
-	// In-memory store for backtest results
-	// In production, this should be a persistent database
-	results map[string]*backtesting.BacktestResult
-	mu      sync.RWMutex
+	// Cached data provider probe for the health check
+	providerCheck   healthCheck
+	providerChecked time.Time
+	healthMu        sync.Mutex
+
+	// Backtest jobs and results (in-memory)
+	backtestJobs *backtesting.BacktestJobManager
+
+	// Most candles a single historical fetch may span
+	maxCandles int
+
+	// Ticker metadata cache in front of the data provider
+	tickerCache *data.CachedDataProvider
+
+	// User-defined symbol names accepted wherever a symbol is
+	symbolAliases *data.SymbolAliases
 }
 
 // NewHandler creates a new handler instance.
@@ -56,6 +72,24 @@ func NewHandler(
 	wsManager *realtime.WebSocketManager,
 	notificationManager *notifications.Manager,
 ) *Handler {
+	backtestWorkers := 0
+	maxCandles := defaultMaxHistoryCandles
+	var tickerTTL time.Duration
+	var aliases *data.SymbolAliases
+	if cfg != nil {
+		backtestWorkers = cfg.BacktestWorkers
+		tickerTTL = cfg.TickerCacheTTL
+		aliases = cfg.Aliases()
+		if cfg.MaxHistoryCandles > 0 {
+			maxCandles = cfg.MaxHistoryCandles
+		}
+	}
+	if provider != nil {
+		// Every historical fetch the API makes (history, backtests, sweeps,
+		// comparisons) is capped here, before the provider pages through it
+		provider = data.NewCandleLimitProvider(provider, maxCandles)
+	}
+
 	return &Handler{
 		registry:            registry,
 		provider:            provider,
@@ -65,10 +99,31 @@ func NewHandler(
 		wsManager:           wsManager,
 		notificationManager: notificationManager,
 		startTime:           time.Now(),
-		results:             make(map[string]*backtesting.BacktestResult),
+		backtestJobs:        backtesting.NewBacktestJobManager(backtestWorkers),
+		maxCandles:          maxCandles,
+		tickerCache:         data.NewCachedDataProvider(provider, data.NewMemoryCache(), tickerTTL),
+		symbolAliases:       aliases,
 	}
 }
 
+// Shutdown stops accepting backtests and waits for queued and running ones
+// to finish, or for ctx to end.
+//
+// Args:
+//   - ctx: Bounds the wait
+//
+// Returns:
+//   - error: If backtests were still running when ctx ended
+func (h *Handler) Shutdown(ctx context.Context) error {
+	return h.backtestJobs.Shutdown(ctx)
+}
+
+// canonicalSymbol normalizes a symbol from a request, resolving the
+// configured aliases.
+func (h *Handler) canonicalSymbol(symbol string) string {
+	return h.symbolAliases.Resolve(symbol)
+}
+
 // writeError writes a JSON error response.
 // The optional code argument allows specifying a machine-readable error code.
 // If code is not provided, it defaults to a generic error code based on status.
@@ -101,6 +156,44 @@ func writeError(w http.ResponseWriter, status int, message string, code ...strin
 	writeJSON(w, status, resp)
 }
 
+// writeProviderError writes a data provider failure, translating typed
+// provider errors into the matching HTTP status and error code. Untyped
+// errors fall back to 500.
+//
+// Args:
+//   - w: Response writer
+//   - message: Human-readable prefix for the error
+//   - err: Error returned by the data provider
+func writeProviderError(w http.ResponseWriter, message string, err error) {
+	status, code := providerErrorStatus(err)
+	writeError(w, status, fmt.Sprintf("%s: %v", message, err), code)
+}
+
+// providerErrorStatus maps a data provider failure to an HTTP status and
+// error code: typed provider errors by kind, a range over the candle limit
+// to 422, and anything else to 500.
+func providerErrorStatus(err error) (int, string) {
+	if errors.Is(err, data.ErrTooManyCandles) {
+		return http.StatusUnprocessableEntity, "TOO_MANY_CANDLES"
+	}
+
+	kind, ok := providers.ErrorKindOf(err)
+	if !ok {
+		return http.StatusInternalServerError, "INTERNAL_ERROR"
+	}
+
+	status := http.StatusBadGateway
+	switch kind {
+	case providers.KindNotFound:
+		status = http.StatusNotFound
+	case providers.KindRateLimited:
+		status = http.StatusTooManyRequests
+	case providers.KindBadInput:
+		status = http.StatusBadRequest
+	}
+	return status, "PROVIDER_" + string(kind)
+}
+
 // writeJSON writes a JSON response with the given status code.
 func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -1,16 +1,25 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/alexherrero/sherwood/backend/backtesting"
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/strategies"
 	"github.com/go-chi/chi/v5"
 	"github.com/rs/zerolog/log"
 )
 
+// defaultChartPoints is the most equity curve points a backtest result
+// returns for charting when max_points is not given.
+const defaultChartPoints = 1000
+
 // RunBacktestRequest defines the payload for starting a backtest.
 type RunBacktestRequest struct {
 	Strategy       string                 `json:"strategy" validate:"required,min=1,max=50"`
@@ -19,9 +28,20 @@ type RunBacktestRequest struct {
 	End            time.Time              `json:"end" validate:"required,gtfield=Start"`
 	InitialCapital float64                `json:"initial_capital" validate:"required,gt=0,lte=10000000"`
 	StrategyConfig map[string]interface{} `json:"strategy_config"`
+	// AllowPyramiding lets repeated buys add to a position and fractional
+	// sells scale out of it.
+	AllowPyramiding bool `json:"allow_pyramiding"`
+	// WarmupBars is the number of leading bars that only seed indicators.
+	WarmupBars int `json:"warmup_bars" validate:"min=0"`
+	// FillModel is "close" (default) to fill at the signal bar's close, or
+	// "next_open" to fill at the next bar's open.
+	FillModel string `json:"fill_model" validate:"omitempty,oneof=close next_open"`
 }
 
 // RunBacktestHandler starts a new backtest.
+// By default the backtest is queued on the worker pool and 202 is returned
+// with a job ID to poll. With ?sync=true it runs within the request and the
+// response includes the metrics.
 func (h *Handler) RunBacktestHandler(w http.ResponseWriter, r *http.Request) {
 	var req RunBacktestRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -36,81 +56,580 @@ func (h *Handler) RunBacktestHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get strategy
-	strategy, ok := h.registry.Get(req.Strategy)
-	if !ok {
+	if _, ok := h.registry.Get(req.Strategy); !ok {
 		http.Error(w, fmt.Sprintf("Strategy '%s' not found", req.Strategy), http.StatusBadRequest)
 		return
 	}
 
-	// Initialize strategy with config
-	if err := strategy.Init(req.StrategyConfig); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to initialize strategy: %v", err), http.StatusBadRequest)
+	h.startBacktest(w, r, req)
+}
+
+// StrategyBacktestRequest defines the payload for backtesting a strategy
+// named in the URL.
+type StrategyBacktestRequest struct {
+	Symbol         string                 `json:"symbol" validate:"required,min=1,max=20"`
+	Start          time.Time              `json:"start" validate:"required"`
+	End            time.Time              `json:"end" validate:"required,gtfield=Start"`
+	InitialCapital float64                `json:"initial_capital" validate:"required,gt=0,lte=10000000"`
+	StrategyConfig map[string]interface{} `json:"strategy_config"`
+	// AllowPyramiding is as in RunBacktestRequest.
+	AllowPyramiding bool `json:"allow_pyramiding"`
+	// WarmupBars is as in RunBacktestRequest.
+	WarmupBars int `json:"warmup_bars" validate:"min=0"`
+	// FillModel is as in RunBacktestRequest.
+	FillModel string `json:"fill_model" validate:"omitempty,oneof=close next_open"`
+}
+
+// RunStrategyBacktestHandler backtests the strategy named in the URL.
+// The strategy config is checked against the strategy's parameter
+// definitions first, and offending parameters are reported individually.
+// Supports ?sync=true like RunBacktestHandler.
+func (h *Handler) RunStrategyBacktestHandler(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	registered, ok := h.registry.Get(name)
+	if !ok {
+		http.Error(w, "Strategy not found", http.StatusNotFound)
 		return
 	}
 
-	// Fetch data
-	// Using "1d" interval for default backtesting
-	data, err := h.provider.GetHistoricalData(req.Symbol, req.Start, req.End, "1d")
+	var body StrategyBacktestRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if valErr := validateStruct(body); valErr != nil {
+		writeValidationError(w, valErr)
+		return
+	}
+
+	if details := strategies.ValidateParameters(registered.GetParameters(), body.StrategyConfig); len(details) > 0 {
+		writeValidationError(w, &ValidationError{
+			Error:   "Invalid strategy parameters",
+			Code:    "VALIDATION_ERROR",
+			Details: details,
+		})
+		return
+	}
+
+	h.startBacktest(w, r, RunBacktestRequest{
+		Strategy:        name,
+		Symbol:          body.Symbol,
+		Start:           body.Start,
+		End:             body.End,
+		InitialCapital:  body.InitialCapital,
+		StrategyConfig:  body.StrategyConfig,
+		AllowPyramiding: body.AllowPyramiding,
+		WarmupBars:      body.WarmupBars,
+		FillModel:       body.FillModel,
+	})
+}
+
+// startBacktest initializes a fresh strategy and either runs the backtest
+// within the request (?sync=true) or queues it on the worker pool.
+func (h *Handler) startBacktest(w http.ResponseWriter, r *http.Request, req RunBacktestRequest) {
+	req.Symbol = h.canonicalSymbol(req.Symbol)
+
+	// Each job gets its own instance so concurrent backtests (and the
+	// engine) never share strategy state
+	strategy, err := strategies.NewBacktestStrategy(req.Strategy)
 	if err != nil {
-		log.Error().Err(err).Str("symbol", req.Symbol).Msg("Failed to fetch historical data")
-		http.Error(w, "Failed to fetch historical data", http.StatusInternalServerError)
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Strategy '%s' cannot be backtested: %v", req.Strategy, err))
+		return
+	}
+
+	// Initialize strategy with config
+	if err := strategy.Init(req.StrategyConfig); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to initialize strategy: %v", err), http.StatusBadRequest)
 		return
 	}
 
 	// Configure backtest
 	btConfig := backtesting.BacktestConfig{
-		Symbol:         req.Symbol,
-		StartDate:      req.Start,
-		EndDate:        req.End,
-		InitialCapital: req.InitialCapital,
-		Commission:     0.001, // Default 0.1% commission
+		Symbol:          req.Symbol,
+		StartDate:       req.Start,
+		EndDate:         req.End,
+		InitialCapital:  req.InitialCapital,
+		Commission:      0.001, // Default flat fee per fill
+		AllowPyramiding: req.AllowPyramiding,
+		WarmupBars:      req.WarmupBars,
+		FillModel:       backtesting.FillModel(req.FillModel),
+		MinBars:         h.backtestMinBars(),
+	}
+
+	if r.URL.Query().Get("sync") == "true" {
+		h.runBacktestSync(r.Context(), w, strategy, btConfig)
+		return
 	}
 
-	// Run backtest (synchronous for now, could be async)
-	engine := backtesting.NewEngine()
-	result, err := engine.Run(strategy, data, btConfig)
+	job, err := h.backtestJobs.Submit(func() (*backtesting.BacktestResult, error) {
+		// Using "1d" interval for default backtesting
+		data, err := h.provider.GetHistoricalData(req.Symbol, req.Start, req.End, "1d")
+		if err != nil {
+			_, code := providerErrorStatus(err)
+			return nil, &backtesting.JobError{Code: code, Err: fmt.Errorf("failed to fetch historical data: %w", err)}
+		}
+		return backtesting.NewEngine().Run(strategy, data, btConfig)
+	})
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"id":      job.ID,
+		"status":  job.Status,
+		"message": "Backtest queued",
+	})
+}
+
+// runBacktestSync fetches data and runs a backtest within the request. The
+// fetch is abandoned if the client disconnects, and too few bars for the
+// strategy are rejected with 422.
+func (h *Handler) runBacktestSync(ctx context.Context, w http.ResponseWriter, strategy strategies.Strategy, btConfig backtesting.BacktestConfig) {
+	// Fetch data
+	// Using "1d" interval for default backtesting
+	bars, err := data.GetHistoricalDataContext(ctx, h.provider, btConfig.Symbol, btConfig.StartDate, btConfig.EndDate, "1d")
 	if err != nil {
-		log.Error().Err(err).Msg("Backtest execution failed")
-		http.Error(w, fmt.Sprintf("Backtest failed: %v", err), http.StatusInternalServerError)
+		log.Error().Err(err).Str("symbol", btConfig.Symbol).Msg("Failed to fetch historical data")
+		writeProviderError(w, "Failed to fetch historical data", err)
+		return
+	}
+	if required := backtesting.RequiredBars(strategy, btConfig.MinBars); len(bars) < required {
+		writeError(w, http.StatusUnprocessableEntity,
+			fmt.Sprintf("Not enough data: %s needs at least %d bars, but %s has %d in the requested range",
+				strategy.Name(), required, btConfig.Symbol, len(bars)), "INSUFFICIENT_DATA")
 		return
 	}
 
-	// Store result
-	h.mu.Lock()
-	h.results[result.ID] = result
-	h.mu.Unlock()
+	job := h.backtestJobs.RunSync(func() (*backtesting.BacktestResult, error) {
+		return backtesting.NewEngine().Run(strategy, bars, btConfig)
+	})
+	if job.Status == backtesting.JobFailed {
+		http.Error(w, fmt.Sprintf("Backtest failed: %s", job.Error), http.StatusInternalServerError)
+		return
+	}
 
 	writeJSON(w, http.StatusAccepted, map[string]interface{}{
-		"id":      result.ID,
-		"status":  "completed", // For sync execution
+		"id":      job.ID,
+		"status":  job.Status,
 		"message": "Backtest completed successfully",
-		"metrics": result.Metrics,
+		"metrics": job.Result.Metrics,
 	})
 }
 
-// GetBacktestResultHandler returns results for a completed backtest.
+// GetBacktestResultHandler returns a backtest's status, and its results once completed.
+// The equity curve in chart_data is downsampled to the max_points query
+// parameter (default 1000, 0 for the full curve); metrics always use the full
+// curve.
 func (h *Handler) GetBacktestResultHandler(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	h.mu.RLock()
-	result, ok := h.results[id]
-	h.mu.RUnlock()
+	maxPoints := getQueryInt(r, "max_points", defaultChartPoints)
+	if maxPoints < 0 {
+		writeError(w, http.StatusBadRequest, "max_points must be 0 (full curve) or greater")
+		return
+	}
 
+	job, ok := h.backtestJobs.Get(id)
 	if !ok {
 		http.Error(w, "Backtest not found", http.StatusNotFound)
 		return
 	}
 
+	if job.Status != backtesting.JobCompleted {
+		writeJSON(w, http.StatusOK, job)
+		return
+	}
+	if job.Sweep != nil {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id":     job.ID,
+			"status": "completed",
+			"sweep":  job.Sweep,
+		})
+		return
+	}
+	result := job.Result
+
 	// Generate report for summary
 	report := backtesting.NewReport(result)
 
 	writeJSON(w, http.StatusOK, map[string]interface{}{
-		"id":         result.ID,
-		"status":     "completed",
-		"strategy":   result.Strategy,
-		"config":     result.Config,
-		"metrics":    result.Metrics,
-		"summary":    report.Summary(),
-		"chart_data": result.EquityCurve, // For frontend plotting
+		"id":               result.ID,
+		"status":           "completed",
+		"strategy":         result.Strategy,
+		"config":           result.Config,
+		"metrics":          result.Metrics,
+		"summary":          report.Summary(),
+		"chart_data":       backtesting.DownsampleEquity(result.EquityCurve, maxPoints), // For frontend plotting
+		"chart_data_total": len(result.EquityCurve),
 	})
 }
+
+// GetBacktestReportHandler exports a completed backtest's report.
+// The format query parameter selects "json" (default) or "md" (Markdown).
+func (h *Handler) GetBacktestReportHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "md" {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid format '%s': use json or md", format))
+		return
+	}
+
+	job, ok := h.backtestJobs.Get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "Backtest not found")
+		return
+	}
+	if job.Status != backtesting.JobCompleted {
+		writeError(w, http.StatusConflict, fmt.Sprintf("Backtest is %s, report not available", job.Status), "BACKTEST_NOT_COMPLETED")
+		return
+	}
+	if job.Result == nil {
+		writeError(w, http.StatusUnprocessableEntity, "Sweeps have no single backtest report", "SWEEP_JOB")
+		return
+	}
+
+	report := backtesting.NewReport(job.Result)
+	if format == "md" {
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(report.Markdown()))
+		return
+	}
+
+	data, err := report.JSON()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to encode report: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// defaultMonteCarloIterations is the number of resampled paths when the
+// iterations query parameter is not given.
+const defaultMonteCarloIterations = 1000
+
+// GetBacktestMonteCarloHandler resamples a completed backtest's trades and
+// returns percentiles of final return and max drawdown. The iterations query
+// parameter (default 1000) is capped at backtesting.MaxMonteCarloIterations.
+func (h *Handler) GetBacktestMonteCarloHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	iterations := getQueryInt(r, "iterations", defaultMonteCarloIterations)
+	if iterations < 1 || iterations > backtesting.MaxMonteCarloIterations {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("iterations must be between 1 and %d", backtesting.MaxMonteCarloIterations))
+		return
+	}
+
+	job, ok := h.backtestJobs.Get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "Backtest not found")
+		return
+	}
+	if job.Status != backtesting.JobCompleted {
+		writeError(w, http.StatusConflict, fmt.Sprintf("Backtest is %s, Monte Carlo not available", job.Status), "BACKTEST_NOT_COMPLETED")
+		return
+	}
+	if job.Result == nil {
+		writeError(w, http.StatusUnprocessableEntity, "Sweeps have no trades to resample", "SWEEP_JOB")
+		return
+	}
+
+	result, err := backtesting.MonteCarlo(job.Result, iterations)
+	switch {
+	case errors.Is(err, backtesting.ErrNoTrades):
+		writeError(w, http.StatusUnprocessableEntity, "Backtest has no trades to resample")
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to run Monte Carlo: %v", err))
+	default:
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+// defaultMaxSweepCombinations caps sweeps when none is configured.
+const defaultMaxSweepCombinations = 100
+
+// SweepRequest defines the payload for a backtest parameter sweep.
+type SweepRequest struct {
+	Strategy       string                    `json:"strategy" validate:"required,min=1,max=50"`
+	Symbol         string                    `json:"symbol" validate:"required,min=1,max=20"`
+	Start          time.Time                 `json:"start" validate:"required"`
+	End            time.Time                 `json:"end" validate:"required,gtfield=Start"`
+	InitialCapital float64                   `json:"initial_capital" validate:"required,gt=0,lte=10000000"`
+	StrategyConfig map[string]interface{}    `json:"strategy_config"`
+	Parameters     backtesting.ParameterGrid `json:"parameters" validate:"required,min=1"`
+	RankBy         string                    `json:"rank_by"`
+}
+
+// SweepResponse is the ranked outcome of a parameter sweep.
+type SweepResponse = backtesting.SweepReport
+
+// RunSweepHandler backtests a strategy over every combination of the given
+// parameter values and ranks the results by a metric (default
+// sharpe_ratio). The sweep is queued as a single backtest job whose
+// combinations run one after another; poll GET /backtests/{id} for the
+// ranked report. Pass ?sync=true to run it within the request instead.
+func (h *Handler) RunSweepHandler(w http.ResponseWriter, r *http.Request) {
+	var req SweepRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if valErr := validateStruct(req); valErr != nil {
+		writeValidationError(w, valErr)
+		return
+	}
+	req.Symbol = h.canonicalSymbol(req.Symbol)
+
+	registered, ok := h.registry.Get(req.Strategy)
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Strategy '%s' not found", req.Strategy))
+		return
+	}
+	if req.RankBy == "" {
+		req.RankBy = backtesting.DefaultSweepRankMetric
+	}
+	if _, ok := backtesting.SweepRankMetrics[req.RankBy]; !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Unknown rank_by metric '%s'", req.RankBy))
+		return
+	}
+
+	if details := validateSweepParameters(registered.GetParameters(), req); len(details) > 0 {
+		writeValidationError(w, &ValidationError{
+			Error:   "Invalid sweep parameters",
+			Code:    "VALIDATION_ERROR",
+			Details: details,
+		})
+		return
+	}
+
+	maxCombinations := h.maxSweepCombinations()
+	combinations := req.Parameters.Size()
+	if combinations > maxCombinations {
+		writeError(w, http.StatusUnprocessableEntity,
+			fmt.Sprintf("Sweep has %d combinations, exceeding the maximum of %d", combinations, maxCombinations))
+		return
+	}
+	// The handler's provider caps the range at MAX_HISTORY_CANDLES; check it
+	// up front so an oversized sweep is rejected rather than queued
+	if err := data.CheckCandleSpan(req.Start, req.End, "1d", h.maxCandles); err != nil {
+		writeProviderError(w, "Failed to fetch historical data", err)
+		return
+	}
+
+	newStrategy, err := strategyFactory(req.Strategy)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	sweepConfig := backtesting.SweepConfig{
+		Backtest: backtesting.BacktestConfig{
+			Symbol:         req.Symbol,
+			StartDate:      req.Start,
+			EndDate:        req.End,
+			InitialCapital: req.InitialCapital,
+			Commission:     0.001, // Default flat fee per fill
+			MinBars:        h.backtestMinBars(),
+		},
+		Base: req.StrategyConfig,
+		Grid: req.Parameters,
+	}
+	// Every combination shares the same bars, so they are fetched once
+	sweep := func(ctx context.Context, bars []models.OHLCV) (*backtesting.SweepReport, error) {
+		results := h.backtestJobs.RunSweep(ctx, newStrategy, bars, sweepConfig)
+		if err := backtesting.RankSweep(results, req.RankBy); err != nil {
+			return nil, err
+		}
+		return &backtesting.SweepReport{
+			Strategy:     req.Strategy,
+			Symbol:       req.Symbol,
+			Combinations: combinations,
+			RankBy:       req.RankBy,
+			Results:      results,
+		}, nil
+	}
+
+	if r.URL.Query().Get("sync") == "true" {
+		bars, err := data.GetHistoricalDataContext(r.Context(), h.provider, req.Symbol, req.Start, req.End, "1d")
+		if err != nil {
+			writeProviderError(w, "Failed to fetch historical data", err)
+			return
+		}
+		job := h.backtestJobs.RunSweepSync(r.Context(), func(ctx context.Context) (*backtesting.SweepReport, error) {
+			return sweep(ctx, bars)
+		})
+		if job.Status == backtesting.JobFailed {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("Sweep failed: %s", job.Error))
+			return
+		}
+		writeJSON(w, http.StatusOK, job.Sweep)
+		return
+	}
+
+	job, err := h.backtestJobs.SubmitSweep(func(ctx context.Context) (*backtesting.SweepReport, error) {
+		bars, err := data.GetHistoricalDataContext(ctx, h.provider, req.Symbol, req.Start, req.End, "1d")
+		if err != nil {
+			_, code := providerErrorStatus(err)
+			return nil, &backtesting.JobError{Code: code, Err: fmt.Errorf("failed to fetch historical data: %w", err)}
+		}
+		return sweep(ctx, bars)
+	})
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"id":      job.ID,
+		"status":  job.Status,
+		"message": "Sweep queued",
+	})
+}
+
+// CompareStrategyRequest is one side of a backtest comparison.
+type CompareStrategyRequest struct {
+	Strategy       string                 `json:"strategy" validate:"required,min=1,max=50"`
+	StrategyConfig map[string]interface{} `json:"strategy_config"`
+}
+
+// CompareBacktestRequest defines the payload for comparing two strategies
+// on the same data.
+type CompareBacktestRequest struct {
+	A              CompareStrategyRequest `json:"a"`
+	B              CompareStrategyRequest `json:"b"`
+	Symbol         string                 `json:"symbol" validate:"required,min=1,max=20"`
+	Start          time.Time              `json:"start" validate:"required"`
+	End            time.Time              `json:"end" validate:"required,gtfield=Start"`
+	InitialCapital float64                `json:"initial_capital" validate:"required,gt=0,lte=10000000"`
+}
+
+// CompareBacktestResponse is the head-to-head outcome of a comparison.
+type CompareBacktestResponse struct {
+	Symbol string `json:"symbol"`
+	*backtesting.Comparison
+}
+
+// CompareBacktestHandler backtests two strategy configs on identical data
+// and returns both results, the difference in key metrics (B minus A) and
+// the overlaid equity curves. The bars are fetched once and both runs are
+// recorded as backtest jobs.
+func (h *Handler) CompareBacktestHandler(w http.ResponseWriter, r *http.Request) {
+	var req CompareBacktestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if valErr := validateStruct(req); valErr != nil {
+		writeValidationError(w, valErr)
+		return
+	}
+	req.Symbol = h.canonicalSymbol(req.Symbol)
+
+	sides := map[string]CompareStrategyRequest{"a": req.A, "b": req.B}
+	specs := make(map[string]backtesting.CompareSpec, len(sides))
+	details := make(map[string]string)
+	for _, side := range []string{"a", "b"} {
+		sideReq := sides[side]
+		registered, ok := h.registry.Get(sideReq.Strategy)
+		if !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Strategy '%s' not found", sideReq.Strategy))
+			return
+		}
+		newStrategy, err := strategyFactory(sideReq.Strategy)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for name, msg := range strategies.ValidateParameters(registered.GetParameters(), sideReq.StrategyConfig) {
+			details[side+"."+name] = msg
+		}
+		specs[side] = backtesting.CompareSpec{
+			NewStrategy: newStrategy,
+			Config:      sideReq.StrategyConfig,
+		}
+	}
+	if len(details) > 0 {
+		writeValidationError(w, &ValidationError{
+			Error:   "Invalid strategy parameters",
+			Code:    "VALIDATION_ERROR",
+			Details: details,
+		})
+		return
+	}
+
+	// Both strategies see the same bars, so fetch them once
+	bars, err := data.GetHistoricalDataContext(r.Context(), h.provider, req.Symbol, req.Start, req.End, "1d")
+	if err != nil {
+		writeProviderError(w, "Failed to fetch historical data", err)
+		return
+	}
+
+	comparison := h.backtestJobs.RunComparison(r.Context(), specs["a"], specs["b"], bars, backtesting.BacktestConfig{
+		Symbol:         req.Symbol,
+		StartDate:      req.Start,
+		EndDate:        req.End,
+		InitialCapital: req.InitialCapital,
+		Commission:     0.001, // Default flat fee per fill
+		MinBars:        h.backtestMinBars(),
+	})
+
+	writeJSON(w, http.StatusOK, CompareBacktestResponse{
+		Symbol:     req.Symbol,
+		Comparison: comparison,
+	})
+}
+
+// strategyFactory returns a factory of fresh, uninitialized instances of a
+// named strategy, so concurrent runs never share the registered instance.
+// The runner initializes each instance with its own config.
+func strategyFactory(name string) (backtesting.StrategyFactory, error) {
+	if _, err := strategies.NewBacktestStrategy(name); err != nil {
+		return nil, fmt.Errorf("strategy '%s' cannot be backtested: %w", name, err)
+	}
+	return func() strategies.Strategy {
+		strategy, _ := strategies.NewStrategyByName(name)
+		return strategy
+	}, nil
+}
+
+// validateSweepParameters checks the base config and every swept value
+// against the strategy's parameter definitions.
+func validateSweepParameters(params map[string]strategies.Parameter, req SweepRequest) map[string]string {
+	details := strategies.ValidateParameters(params, req.StrategyConfig)
+	for name, values := range req.Parameters {
+		if len(values) == 0 {
+			details[name] = "At least one value is required"
+			continue
+		}
+		for _, value := range values {
+			if errs := strategies.ValidateParameters(params, map[string]interface{}{name: value}); errs[name] != "" {
+				details[name] = errs[name]
+				break
+			}
+		}
+	}
+	return details
+}
+
+// maxSweepCombinations returns the configured sweep size cap.
+func (h *Handler) maxSweepCombinations() int {
+	if h.config != nil && h.config.MaxSweepCombinations > 0 {
+		return h.config.MaxSweepCombinations
+	}
+	return defaultMaxSweepCombinations
+}
+
+// backtestMinBars returns the configured floor on backtest bars.
+func (h *Handler) backtestMinBars() int {
+	if h.config != nil {
+		return h.config.BacktestMinBars
+	}
+	return 0
+}
@@ -2,9 +2,13 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/alexherrero/sherwood/backend/config"
+	"github.com/alexherrero/sherwood/backend/tracing"
 	"github.com/rs/zerolog/log"
 )
 
@@ -16,6 +20,8 @@ func (h *Handler) GetConfigHandler(w http.ResponseWriter, r *http.Request) {
 		"server_host":  h.config.ServerHost,
 		"trading_mode": h.config.TradingMode,
 		"log_level":    h.config.LogLevel,
+		// Canonical symbol to its display alias, for labelling
+		"symbol_names": h.symbolAliases.DisplayNames(),
 	}
 	writeJSON(w, http.StatusOK, safeConfig)
 }
@@ -78,22 +84,58 @@ func (h *Handler) GetConfigValidationHandler(w http.ResponseWriter, r *http.Requ
 	writeJSON(w, http.StatusOK, response)
 }
 
+// RotateAPIKeyResponse confirms a key rotation. The new key is only ever
+// returned here; the previous key is identified by fingerprint.
+type RotateAPIKeyResponse struct {
+	Status                 string    `json:"status"`
+	APIKey                 string    `json:"api_key"`
+	PreviousKeyFingerprint string    `json:"previous_key_fingerprint,omitempty"`
+	RotatedAt              time.Time `json:"rotated_at"`
+	Message                string    `json:"message"`
+}
+
 // RotateAPIKeyHandler generates a new API key and returns it.
+// The request must carry the current key, and rotations within
+// config.KeyRotationCooldown of each other are rejected with 429.
 func (h *Handler) RotateAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
-	newKey, err := h.config.RotateAPIKey()
+	if h.config.CurrentAPIKey() != "" && !validAPIKey(h.config, r.Header.Get("X-Sherwood-API-Key")) {
+		writeError(w, http.StatusUnauthorized, "Rotating the API key requires the current key", "UNAUTHORIZED")
+		return
+	}
+
+	newKey, oldKey, err := h.config.RotateAPIKey()
+	if errors.Is(err, config.ErrKeyRotationTooSoon) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(config.KeyRotationCooldown.Seconds())))
+		writeError(w, http.StatusTooManyRequests, "API key was rotated too recently, try again shortly", "RATE_LIMITED")
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "Failed to rotate API key")
 		log.Error().Err(err).Msg("Failed to rotate API key")
 		return
 	}
 
-	log.Info().Msg("API Key rotated successfully")
+	resp := RotateAPIKeyResponse{
+		Status:    "success",
+		APIKey:    newKey,
+		RotatedAt: time.Now().UTC(),
+		Message:   "API key rotated successfully. Please update your client configuration.",
+	}
+	if oldKey != "" {
+		resp.PreviousKeyFingerprint = keyFingerprint(oldKey)
+	}
+
+	// Audit log with requestor context; never log the keys themselves
+	logger := tracing.Logger(r.Context())
+	logger.Info().
+		Str("previous_key_id", resp.PreviousKeyFingerprint).
+		Str("new_key_id", keyFingerprint(newKey)).
+		Time("rotated_at", resp.RotatedAt).
+		Str("user_ip", AuditIPFromCtx(r.Context())).
+		Str("api_key_id", AuditKeyIDFromCtx(r.Context())).
+		Msg("API key rotated")
 
-	writeJSON(w, http.StatusOK, map[string]string{
-		"status":  "success",
-		"api_key": newKey,
-		"message": "API key rotated successfully. Please update your client configuration.",
-	})
+	writeJSON(w, http.StatusOK, resp)
 }
 
 // getProviderDescription returns a human-readable description for a provider.
@@ -117,7 +159,7 @@ func generateConfigWarnings(cfg *config.Config, enabledCount int) []string {
 		warnings = append(warnings, "No strategies enabled - engine will not execute any trades")
 	}
 
-	if cfg.IsLive() && cfg.APIKey == "" {
+	if cfg.IsLive() && cfg.CurrentAPIKey() == "" {
 		warnings = append(warnings, "Running in LIVE mode without API_KEY - this is insecure!")
 	}
 
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -32,6 +33,7 @@ func TestRotateAPIKeyHandler(t *testing.T) {
 
 	// Create Request
 	req := httptest.NewRequest("POST", "/api/v1/config/rotate-key", nil)
+	req.Header.Set("X-Sherwood-API-Key", "old-key")
 	w := httptest.NewRecorder()
 
 	// Execute
@@ -49,6 +51,9 @@ func TestRotateAPIKeyHandler(t *testing.T) {
 	newKey := response["api_key"]
 	assert.NotEmpty(t, newKey)
 	assert.NotEqual(t, "old-key", newKey)
+	assert.Equal(t, keyFingerprint("old-key"), response["previous_key_fingerprint"])
+	assert.Len(t, response["previous_key_fingerprint"], 8)
+	assert.NotEmpty(t, response["rotated_at"])
 
 	// Verify Config Update
 	assert.Equal(t, newKey, cfg.APIKey)
@@ -57,6 +62,32 @@ func TestRotateAPIKeyHandler(t *testing.T) {
 	content, err := os.ReadFile(tmpEnv)
 	require.NoError(t, err)
 	assert.Contains(t, string(content), "API_KEY="+newKey)
+	assert.NotContains(t, string(content), "old-key")
+
+	// A rapid second rotation is rejected
+	req = httptest.NewRequest("POST", "/api/v1/config/rotate-key", nil)
+	req.Header.Set("X-Sherwood-API-Key", newKey)
+	w = httptest.NewRecorder()
+	handler.RotateAPIKeyHandler(w, req)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	assert.Equal(t, newKey, cfg.APIKey)
+}
+
+// TestRotateAPIKeyHandler_RequiresCurrentKey verifies a stale or missing key
+// cannot rotate.
+func TestRotateAPIKeyHandler_RequiresCurrentKey(t *testing.T) {
+	cfg := &config.Config{APIKey: "current-key", EnvFile: filepath.Join(t.TempDir(), ".env")}
+	handler := NewHandler(nil, nil, cfg, nil, nil, nil, nil)
+
+	for _, key := range []string{"", "stale-key"} {
+		req := httptest.NewRequest("POST", "/api/v1/config/rotate-key", nil)
+		req.Header.Set("X-Sherwood-API-Key", key)
+		w := httptest.NewRecorder()
+		handler.RotateAPIKeyHandler(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	}
+	assert.Equal(t, "current-key", cfg.APIKey)
 }
 
 func TestGenerateConfigWarnings(t *testing.T) {
@@ -125,10 +156,11 @@ func TestGenerateConfigWarnings(t *testing.T) {
 // TestGetConfigHandler verifies config retrieval endpoint.
 func TestGetConfigHandler(t *testing.T) {
 	cfg := &config.Config{
-		TradingMode: "test",
-		ServerPort:  8080,
-		LogLevel:    "info",
-		APIKey:      "secret-key",
+		TradingMode:   "test",
+		ServerPort:    8080,
+		LogLevel:      "info",
+		APIKey:        "secret-key",
+		SymbolAliases: "BITCOIN:BTC-USD",
 	}
 	handler := NewHandler(nil, nil, cfg, nil, nil, nil, nil)
 
@@ -145,6 +177,7 @@ func TestGetConfigHandler(t *testing.T) {
 
 	assert.Equal(t, "test", response["trading_mode"])
 	assert.Equal(t, "info", response["log_level"])
+	assert.Equal(t, map[string]interface{}{"BTC-USD": "BITCOIN"}, response["symbol_names"])
 	assert.NotContains(t, response, "api_key", "Secrets should not be exposed")
 }
 
@@ -3,7 +3,10 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+
+	"github.com/alexherrero/sherwood/backend/engine"
 )
 
 // EngineControlRequest defines the payload for engine control.
@@ -32,6 +35,18 @@ func (h *Handler) StartEngineHandler(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]string{"status": "started"})
 }
 
+// EngineStatusHandler returns the trading engine's runtime state: whether it
+// is running, tick progress, per-symbol errors, signal and order counts, and
+// circuit breaker status. Monitoring can poll it to detect a stuck engine.
+func (h *Handler) EngineStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if h.engine == nil {
+		writeError(w, http.StatusServiceUnavailable, "Trading engine not available")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.engine.Status())
+}
+
 // StopEngineHandler stops the trading engine.
 func (h *Handler) StopEngineHandler(w http.ResponseWriter, r *http.Request) {
 	if h.engine == nil {
@@ -48,3 +63,82 @@ func (h *Handler) StopEngineHandler(w http.ResponseWriter, r *http.Request) {
 	h.engine.Stop()
 	writeJSON(w, http.StatusOK, map[string]string{"status": "stopped"})
 }
+
+// ResumeFetchingHandler lifts the pause the engine enters when every data
+// fetch keeps failing, without waiting for a provider probe to succeed.
+func (h *Handler) ResumeFetchingHandler(w http.ResponseWriter, r *http.Request) {
+	if h.engine == nil {
+		writeError(w, http.StatusServiceUnavailable, "Trading engine not available")
+		return
+	}
+
+	var req EngineControlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !req.Confirm {
+		writeError(w, http.StatusBadRequest, "Confirmation required: {\"confirm\": true}")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]bool{"resumed": h.engine.ResumeFetching()})
+}
+
+// EngineModeRequest defines the payload for changing the engine mode.
+type EngineModeRequest struct {
+	Mode string `json:"mode"`
+}
+
+// SetEngineModeHandler switches the engine between normal and close-only
+// mode. In close-only mode the engine keeps running and executes sells on
+// symbols with open positions, but blocks new buys.
+func (h *Handler) SetEngineModeHandler(w http.ResponseWriter, r *http.Request) {
+	if h.engine == nil {
+		writeError(w, http.StatusServiceUnavailable, "Trading engine not available")
+		return
+	}
+
+	var req EngineModeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	mode, err := engine.ParseMode(req.Mode)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.engine.SetMode(mode)
+	writeJSON(w, http.StatusOK, map[string]engine.Mode{"mode": mode})
+}
+
+// KillSwitchRequest defines the payload for the trading kill-switch.
+type KillSwitchRequest struct {
+	Confirm        bool  `json:"confirm"`
+	TradingEnabled *bool `json:"trading_enabled"`
+}
+
+// KillSwitchHandler enables or disables new order submission. The state is
+// persisted, so a disabled switch survives restarts until re-enabled here.
+// Closing sells during engine shutdown still go through.
+func (h *Handler) KillSwitchHandler(w http.ResponseWriter, r *http.Request) {
+	if h.orderManager == nil {
+		writeError(w, http.StatusServiceUnavailable, "Order manager not available")
+		return
+	}
+
+	var req KillSwitchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !req.Confirm {
+		writeError(w, http.StatusBadRequest, "Confirmation required: {\"confirm\": true}")
+		return
+	}
+	if req.TradingEnabled == nil {
+		writeError(w, http.StatusBadRequest, "trading_enabled is required")
+		return
+	}
+
+	if err := h.orderManager.SetTradingEnabled(*req.TradingEnabled); err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to update kill-switch: %v", err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]bool{"trading_enabled": *req.TradingEnabled})
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/alexherrero/sherwood/backend/config"
+	"github.com/alexherrero/sherwood/backend/data/providers"
 	"github.com/alexherrero/sherwood/backend/execution"
 	"github.com/alexherrero/sherwood/backend/strategies"
 	"github.com/stretchr/testify/assert"
@@ -42,6 +43,64 @@ func TestRunBacktestHandler_Errors(t *testing.T) {
 		assert.Contains(t, rec.Body.String(), "Validation failed")
 	})
 
+	t.Run("NegativeWarmupBars", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"strategy":        "ma_crossover",
+			"symbol":          "AAPL",
+			"start":           time.Now().Add(-24 * time.Hour),
+			"end":             time.Now(),
+			"initial_capital": 10000,
+			"warmup_bars":     -1,
+		}
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/backtests", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.RunBacktestHandler(rec, req)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "WarmupBars")
+	})
+
+	t.Run("UnknownFillModel", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"strategy":        "ma_crossover",
+			"symbol":          "AAPL",
+			"start":           time.Now().Add(-24 * time.Hour),
+			"end":             time.Now(),
+			"initial_capital": 10000,
+			"fill_model":      "vwap",
+		}
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/backtests", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.RunBacktestHandler(rec, req)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"field":"fill_model"`)
+	})
+
+	t.Run("EndBeforeStart", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"strategy":        "ma_crossover",
+			"symbol":          "AAPL",
+			"start":           time.Now(),
+			"end":             time.Now().Add(-24 * time.Hour),
+			"initial_capital": 10000,
+		}
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/backtests", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.RunBacktestHandler(rec, req)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+		var resp APIError
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, []FieldError{{
+			Field:   "end",
+			Rule:    "gtfield",
+			Param:   "start",
+			Message: "must be after start",
+		}}, resp.Fields)
+	})
+
 	t.Run("StrategyNotFound", func(t *testing.T) {
 		payload := map[string]interface{}{
 			"strategy":        "non_existent",
@@ -71,13 +130,33 @@ func TestRunBacktestHandler_Errors(t *testing.T) {
 			"initial_capital": 10000,
 		}
 		body, _ := json.Marshal(payload)
-		req := httptest.NewRequest(http.MethodPost, "/api/v1/backtests", bytes.NewReader(body))
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/backtests?sync=true", bytes.NewReader(body))
 		rec := httptest.NewRecorder()
 		handler.RunBacktestHandler(rec, req)
 
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
 		assert.Contains(t, rec.Body.String(), "Failed to fetch historical data")
 	})
+
+	t.Run("ProviderRateLimited", func(t *testing.T) {
+		mockProvider.On("GetHistoricalData", "BUSY", mock.Anything, mock.Anything, "1d").
+			Return(nil, &providers.ProviderError{Provider: "mock", Kind: providers.KindRateLimited, Message: "slow down"}).Once()
+
+		payload := map[string]interface{}{
+			"strategy":        "ma_crossover",
+			"symbol":          "BUSY",
+			"start":           time.Now().Add(-24 * time.Hour),
+			"end":             time.Now(),
+			"initial_capital": 10000,
+		}
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/backtests?sync=true", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.RunBacktestHandler(rec, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Contains(t, rec.Body.String(), "PROVIDER_RATE_LIMITED")
+	})
 }
 
 // TestGetOrderHandler_Errors tests error scenarios for getting a single order.
@@ -1,34 +1,212 @@
 package api
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"runtime"
 	"time"
+
+	"github.com/alexherrero/sherwood/backend/metrics"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// healthProbeTimeout bounds the data provider probe.
+	healthProbeTimeout = 2 * time.Second
+	// healthDegradedLatency marks a successful but slow probe as degraded.
+	healthDegradedLatency = 500 * time.Millisecond
+	// healthCacheTTL is how long a provider probe result is reused.
+	healthCacheTTL = 5 * time.Second
 )
 
+// Health check statuses.
+const (
+	healthOK       = "ok"
+	healthDegraded = "degraded"
+	healthDown     = "down"
+	healthDisabled = "disabled"
+)
+
+// healthCheck is the result of probing a single dependency.
+type healthCheck struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+	Message   string `json:"message,omitempty"`
+}
+
 // HealthHandler returns the health status of the API.
+// It combines the readiness checks with the trading mode into a
+// human-readable view. The overall status is "down" (HTTP 503) when any
+// critical dependency is down, "degraded" when one is slow, else "ok".
 func (h *Handler) HealthHandler(w http.ResponseWriter, r *http.Request) {
-	checks := make(map[string]string)
-	status := "ok"
+	checks := h.dependencyChecks(r.Context())
+	status := overallHealth(checks)
+
+	code := http.StatusOK
+	if status == healthDown {
+		code = http.StatusServiceUnavailable
+	}
+
+	writeJSON(w, code, map[string]interface{}{
+		"status":    status,
+		"mode":      string(h.config.TradingMode),
+		"timestamp": time.Now(),
+		"checks":    checks,
+	})
+}
+
+// LivezHandler is the liveness probe. It only confirms the process is
+// serving requests and never checks dependencies, so a failing provider
+// doesn't get the pod restarted.
+func (h *Handler) LivezHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": healthOK})
+}
+
+// ReadyzHandler is the readiness probe. It runs the same dependency checks
+// as HealthHandler and returns 503 when any of them is down. A degraded
+// dependency still counts as ready. The provider probe is cached, so this is
+// cheap enough to poll every few seconds.
+func (h *Handler) ReadyzHandler(w http.ResponseWriter, r *http.Request) {
+	checks := h.dependencyChecks(r.Context())
+
+	code := http.StatusOK
+	status := "ready"
+	if overallHealth(checks) == healthDown {
+		code = http.StatusServiceUnavailable
+		status = "not_ready"
+	}
 
-	// Check Broker
+	writeJSON(w, code, map[string]interface{}{
+		"status": status,
+		"checks": checks,
+	})
+}
+
+// dependencyChecks probes the broker connection, the order database and the
+// data provider.
+func (h *Handler) dependencyChecks(ctx context.Context) map[string]healthCheck {
+	checks := make(map[string]healthCheck)
+
+	// Check Broker and Database
 	if h.orderManager != nil {
-		checks["execution"] = "active"
+		checks["execution"] = h.checkBroker()
+		checks["database"] = h.checkDatabase(ctx)
 	} else {
-		checks["execution"] = "disabled"
+		checks["execution"] = healthCheck{Status: healthDisabled}
 	}
 
 	// Check Data Provider
 	if h.provider != nil {
-		checks["data_provider"] = h.provider.Name()
+		checks["data_provider"] = h.checkProvider()
 	}
+	return checks
+}
 
-	writeJSON(w, http.StatusOK, map[string]interface{}{
-		"status":    status,
-		"mode":      string(h.config.TradingMode),
-		"timestamp": time.Now(),
-		"checks":    checks,
-	})
+// overallHealth folds dependency checks into a single status: down if any
+// check is down, degraded if any is degraded, else ok.
+func overallHealth(checks map[string]healthCheck) string {
+	status := healthOK
+	for _, check := range checks {
+		switch check.Status {
+		case healthDown:
+			status = healthDown
+		case healthDegraded:
+			if status == healthOK {
+				status = healthDegraded
+			}
+		}
+	}
+	return status
+}
+
+// checkDatabase pings the order store's database with a short timeout.
+func (h *Handler) checkDatabase(ctx context.Context) healthCheck {
+	ctx, cancel := context.WithTimeout(ctx, healthProbeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	enabled, err := h.orderManager.PingStore(ctx)
+	check := healthCheck{Status: healthOK, LatencyMs: time.Since(start).Milliseconds()}
+	switch {
+	case !enabled:
+		check.Status = healthDisabled
+	case err != nil:
+		check.Status = healthDown
+		check.Message = err.Error()
+	}
+	return check
+}
+
+// checkBroker reports whether the broker connection is live.
+func (h *Handler) checkBroker() healthCheck {
+	start := time.Now()
+	connected := h.orderManager.IsBrokerConnected()
+	check := healthCheck{Status: healthOK, LatencyMs: time.Since(start).Milliseconds()}
+	if !connected {
+		check.Status = healthDown
+		check.Message = "broker not connected"
+	}
+	return check
+}
+
+// checkProvider returns the cached provider probe, refreshing it when stale.
+// When no canary symbol is configured the provider is reported without probing.
+func (h *Handler) checkProvider() healthCheck {
+	symbol := h.config.HealthCanarySymbol
+	if symbol == "" {
+		return healthCheck{Status: healthOK, Message: h.provider.Name() + " (not probed)"}
+	}
+
+	h.healthMu.Lock()
+	defer h.healthMu.Unlock()
+
+	if !h.providerChecked.IsZero() && time.Since(h.providerChecked) < healthCacheTTL {
+		return h.providerCheck
+	}
+
+	h.providerCheck = h.probeProvider(symbol)
+	h.providerChecked = time.Now()
+	return h.providerCheck
+}
+
+// probeProvider prices the canary symbol with a short timeout.
+func (h *Handler) probeProvider(symbol string) healthCheck {
+	type probeResult struct {
+		price float64
+		err   error
+	}
+
+	start := time.Now()
+	done := make(chan probeResult, 1)
+	go func() {
+		price, err := h.provider.GetLatestPrice(symbol)
+		done <- probeResult{price: price, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		latency := time.Since(start)
+		check := healthCheck{Status: healthOK, LatencyMs: latency.Milliseconds()}
+		switch {
+		case res.err != nil:
+			check.Status = healthDown
+			check.Message = res.err.Error()
+		case res.price <= 0:
+			check.Status = healthDegraded
+			check.Message = fmt.Sprintf("invalid price for %s", symbol)
+		case latency > healthDegradedLatency:
+			check.Status = healthDegraded
+			check.Message = "slow response"
+		}
+		return check
+	case <-time.After(healthProbeTimeout):
+		return healthCheck{
+			Status:    healthDown,
+			LatencyMs: time.Since(start).Milliseconds(),
+			Message:   fmt.Sprintf("timed out after %s", healthProbeTimeout),
+		}
+	}
 }
 
 // MetricsHandler returns basic runtime statistics.
@@ -47,6 +225,20 @@ func (h *Handler) MetricsHandler(w http.ResponseWriter, r *http.Request) {
 		"uptime_seconds": time.Since(h.startTime).Seconds(),
 		"timestamp":      time.Now(),
 	}
+	if h.wsManager != nil {
+		metrics["websocket"] = h.wsManager.Stats()
+	}
 
 	writeJSON(w, http.StatusOK, metrics)
 }
+
+// PrometheusMetricsHandler serves the process metrics (orders, signals, tick
+// and provider latency, runtime) in the Prometheus text exposition format for
+// scraping. MetricsHandler keeps serving JSON for the frontend.
+func (h *Handler) PrometheusMetricsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if err := metrics.Default.WritePrometheus(w); err != nil {
+		log.Error().Err(err).Msg("Failed to write metrics")
+	}
+}
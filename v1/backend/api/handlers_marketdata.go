@@ -1,14 +1,25 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/tracing"
 )
 
+// defaultMaxHistoryCandles caps historical requests when none is configured.
+const defaultMaxHistoryCandles = 5000
+
+// streamBufferSize is how many broadcast messages a slow stream client may lag
+// behind before messages are dropped.
+const streamBufferSize = 64
+
 // GetHistoricalDataHandler returns historical market data.
 func (h *Handler) GetHistoricalDataHandler(w http.ResponseWriter, r *http.Request) {
-	symbol := r.URL.Query().Get("symbol")
+	symbol := h.canonicalSymbol(r.URL.Query().Get("symbol"))
 	if symbol == "" {
 		writeError(w, http.StatusBadRequest, "Symbol is required")
 		return
@@ -25,23 +36,150 @@ func (h *Handler) GetHistoricalDataHandler(w http.ResponseWriter, r *http.Reques
 	// Default to last 30 days if not specified
 	end := time.Now()
 	if endStr != "" {
-		if parsed, err := time.Parse(time.RFC3339, endStr); err == nil {
-			end = parsed
+		parsed, err := time.Parse(time.RFC3339, endStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid end time: must be RFC3339")
+			return
 		}
+		end = parsed
 	}
 
 	start := end.AddDate(0, 0, -30)
 	if startStr != "" {
-		if parsed, err := time.Parse(time.RFC3339, startStr); err == nil {
-			start = parsed
+		parsed, err := time.Parse(time.RFC3339, startStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid start time: must be RFC3339")
+			return
 		}
+		start = parsed
+	}
+
+	if !start.Before(end) {
+		writeError(w, http.StatusBadRequest, "Start must be before end")
+		return
+	}
+
+	_, ok := data.IntervalDuration(interval)
+	if ip, isIP := h.provider.(data.IntervalProvider); ok && isIP {
+		ok = ip.SupportsInterval(interval)
+	}
+	if !ok {
+		writeError(w, http.StatusBadRequest,
+			fmt.Sprintf("Interval '%s' is not supported by this provider", interval))
+		return
+	}
+
+	bars, err := data.GetHistoricalDataContext(r.Context(), h.provider, symbol, start, end, interval)
+	if err != nil {
+		writeProviderError(w, "Failed to fetch data", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, bars)
+}
+
+// GetTickerHandler returns a symbol's metadata (name, asset type, exchange).
+// Results are cached for TICKER_CACHE_TTL since metadata rarely changes.
+func (h *Handler) GetTickerHandler(w http.ResponseWriter, r *http.Request) {
+	if h.provider == nil {
+		writeError(w, http.StatusServiceUnavailable, "Data provider not available")
+		return
+	}
+
+	symbol := h.canonicalSymbol(r.URL.Query().Get("symbol"))
+	if symbol == "" {
+		writeError(w, http.StatusBadRequest, "Symbol is required")
+		return
 	}
 
-	data, err := h.provider.GetHistoricalData(symbol, start, end, interval)
+	ticker, err := h.tickerCache.GetTickerContext(r.Context(), symbol)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to fetch data: %v", err))
+		writeProviderError(w, "Failed to fetch ticker", err)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, data)
+	writeJSON(w, http.StatusOK, ticker)
+}
+
+// StreamMarketDataHandler streams candles for a symbol as newline-delimited
+// JSON. Candles come from the engine's "market_data" broadcasts, the same
+// events sent to WebSocket clients, and each line is flushed as it is written.
+// The stream stays open until the client disconnects.
+//
+// Query params:
+//   - symbol: Ticker symbol (required)
+//   - interval: Only stream candles of this interval (optional)
+func (h *Handler) StreamMarketDataHandler(w http.ResponseWriter, r *http.Request) {
+	symbol := h.canonicalSymbol(r.URL.Query().Get("symbol"))
+	if symbol == "" {
+		writeError(w, http.StatusBadRequest, "Symbol is required")
+		return
+	}
+	interval := r.URL.Query().Get("interval")
+
+	if h.wsManager == nil {
+		writeError(w, http.StatusServiceUnavailable, "Market data streaming is not available")
+		return
+	}
+
+	// The server's write timeout would otherwise cut the stream short
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	messages, unsubscribe := h.wsManager.Subscribe(streamBufferSize)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		logger := tracing.Logger(r.Context())
+		logger.Error().Err(err).Msg("Market data stream cannot be flushed")
+		return
+	}
+
+	enc := json.NewEncoder(w)
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			if msg.Type != "market_data" {
+				continue
+			}
+			candle, ok := streamCandle(msg.Payload, symbol, interval)
+			if !ok {
+				continue
+			}
+			// Encode terminates each value with a newline
+			if err := enc.Encode(candle); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// streamCandle extracts the candle from a "market_data" broadcast payload if it
+// matches the requested symbol and interval (an empty interval matches any).
+func streamCandle(payload interface{}, symbol, interval string) (interface{}, bool) {
+	fields, ok := payload.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	if s, _ := fields["symbol"].(string); s != symbol {
+		return nil, false
+	}
+	if interval != "" {
+		if i, _ := fields["interval"].(string); i != interval {
+			return nil, false
+		}
+	}
+	candle, ok := fields["candle"]
+	return candle, ok
 }
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -8,7 +10,9 @@ import (
 	"time"
 
 	"github.com/alexherrero/sherwood/backend/config"
+	"github.com/alexherrero/sherwood/backend/data/providers"
 	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/realtime"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -63,4 +67,240 @@ func TestGetHistoricalDataHandler(t *testing.T) {
 
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
 	})
+
+	t.Run("TypedProviderErrors", func(t *testing.T) {
+		tests := []struct {
+			symbol string
+			kind   providers.ErrorKind
+			status int
+		}{
+			{"NOTFOUND", providers.KindNotFound, http.StatusNotFound},
+			{"THROTTLED", providers.KindRateLimited, http.StatusTooManyRequests},
+			{"NOAUTH", providers.KindUnauthorized, http.StatusBadGateway},
+			{"DOWN", providers.KindUnavailable, http.StatusBadGateway},
+			{"BADINPUT", providers.KindBadInput, http.StatusBadRequest},
+		}
+
+		for _, tt := range tests {
+			providerErr := &providers.ProviderError{Provider: "mock", Kind: tt.kind, Message: "failed"}
+			mockProvider.On("GetHistoricalData", tt.symbol, mock.Anything, mock.Anything, "1d").Return(nil, providerErr)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/market/history?symbol="+tt.symbol+"&interval=1d", nil)
+			rec := httptest.NewRecorder()
+
+			handler.GetHistoricalDataHandler(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code, tt.symbol)
+
+			var apiErr APIError
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &apiErr))
+			assert.Equal(t, "PROVIDER_"+string(tt.kind), apiErr.Code)
+		}
+	})
+
+	t.Run("StartAfterEnd", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet,
+			"/api/v1/market/history?symbol=AAPL&interval=1d&start=2024-02-01T00:00:00Z&end=2024-01-01T00:00:00Z", nil)
+		rec := httptest.NewRecorder()
+
+		handler.GetHistoricalDataHandler(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Start must be before end")
+	})
+
+	t.Run("InvalidTime", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/market/history?symbol=AAPL&start=yesterday", nil)
+		rec := httptest.NewRecorder()
+
+		handler.GetHistoricalDataHandler(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("UnknownInterval", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/market/history?symbol=AAPL&interval=7s", nil)
+		rec := httptest.NewRecorder()
+
+		handler.GetHistoricalDataHandler(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Interval '7s' is not supported")
+	})
+
+	t.Run("ExceedsDefaultCap", func(t *testing.T) {
+		// 30 days of 1m candles is 43200 candles, above the default 5000
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/market/history?symbol=AAPL&interval=1m", nil)
+		rec := httptest.NewRecorder()
+
+		handler.GetHistoricalDataHandler(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "maximum of 5000")
+	})
+}
+
+// TestGetHistoricalDataHandler_Limits verifies the configured cap and provider interval support.
+func TestGetHistoricalDataHandler_Limits(t *testing.T) {
+	cfg := &config.Config{TradingMode: "test", MaxHistoryCandles: 10}
+
+	t.Run("ExceedsConfiguredCap", func(t *testing.T) {
+		handler := NewHandler(nil, new(MockDataProvider), cfg, nil, nil, nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/api/v1/market/history?symbol=AAPL&interval=1d&start=2024-01-01T00:00:00Z&end=2024-01-31T00:00:00Z", nil)
+		rec := httptest.NewRecorder()
+
+		handler.GetHistoricalDataHandler(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "maximum of 10")
+	})
+
+	t.Run("WithinConfiguredCap", func(t *testing.T) {
+		mockProvider := new(MockDataProvider)
+		mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").Return([]models.OHLCV{}, nil)
+		handler := NewHandler(nil, mockProvider, cfg, nil, nil, nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/api/v1/market/history?symbol=AAPL&interval=1d&start=2024-01-01T00:00:00Z&end=2024-01-10T00:00:00Z", nil)
+		rec := httptest.NewRecorder()
+
+		handler.GetHistoricalDataHandler(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("ProviderRejectsInterval", func(t *testing.T) {
+		handler := NewHandler(nil, providers.NewTiingoProvider("test-key"), cfg, nil, nil, nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/market/history?symbol=AAPL&interval=1h", nil)
+		rec := httptest.NewRecorder()
+
+		handler.GetHistoricalDataHandler(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "not supported by this provider")
+	})
+}
+
+// TestStreamMarketDataHandler verifies matching candles are streamed as NDJSON
+// and the stream ends when the client disconnects.
+func TestStreamMarketDataHandler(t *testing.T) {
+	cfg := &config.Config{TradingMode: "test"}
+
+	t.Run("MissingSymbol", func(t *testing.T) {
+		handler := NewHandler(nil, nil, cfg, nil, nil, realtime.NewWebSocketManager(), nil)
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/data/stream", nil)
+		rec := httptest.NewRecorder()
+
+		handler.StreamMarketDataHandler(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("NoWebSocketManager", func(t *testing.T) {
+		handler := NewHandler(nil, nil, cfg, nil, nil, nil, nil)
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/data/stream?symbol=AAPL", nil)
+		rec := httptest.NewRecorder()
+
+		handler.StreamMarketDataHandler(rec, req)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+
+	t.Run("StreamsMatchingCandles", func(t *testing.T) {
+		wsManager := realtime.NewWebSocketManager()
+		go wsManager.Run()
+		handler := NewHandler(nil, nil, cfg, nil, nil, wsManager, nil)
+
+		server := httptest.NewServer(http.HandlerFunc(handler.StreamMarketDataHandler))
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"?symbol=AAPL&interval=1m", nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+		// The response headers are flushed after the handler subscribes
+		wsManager.Broadcast("market_data", map[string]interface{}{
+			"symbol": "MSFT", "interval": "1m", "candle": models.OHLCV{Symbol: "MSFT", Close: 1},
+		})
+		wsManager.Broadcast("market_data", map[string]interface{}{
+			"symbol": "AAPL", "interval": "1d", "candle": models.OHLCV{Symbol: "AAPL", Close: 2},
+		})
+		wsManager.Broadcast("signal", map[string]interface{}{"symbol": "AAPL", "interval": "1m"})
+		wsManager.Broadcast("market_data", map[string]interface{}{
+			"symbol": "AAPL", "interval": "1m", "candle": models.OHLCV{Symbol: "AAPL", Close: 3},
+		})
+
+		reader := bufio.NewReader(resp.Body)
+		line, err := reader.ReadBytes('\n')
+		require.NoError(t, err)
+
+		var candle models.OHLCV
+		require.NoError(t, json.Unmarshal(line, &candle))
+		assert.Equal(t, "AAPL", candle.Symbol)
+		assert.Equal(t, 3.0, candle.Close)
+
+		// Disconnecting stops the handler and removes its subscription
+		cancel()
+		assert.Eventually(t, func() bool {
+			return wsManager.SubscriberCount() == 0
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
+// TestGetTickerHandler verifies ticker metadata is returned, cached and
+// mapped to 404 when the provider doesn't know the symbol.
+func TestGetTickerHandler(t *testing.T) {
+	cfg := &config.Config{TradingMode: "test", TickerCacheTTL: time.Hour}
+	mockProvider := new(MockDataProvider)
+	handler := NewHandler(nil, mockProvider, cfg, nil, nil, nil, nil)
+
+	get := func(h *Handler, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/data/ticker"+query, nil)
+		rec := httptest.NewRecorder()
+		h.GetTickerHandler(rec, req)
+		return rec
+	}
+
+	t.Run("CachedSuccess", func(t *testing.T) {
+		ticker := &models.Ticker{Symbol: "BTC-USD", Name: "Bitcoin", AssetType: "crypto", Exchange: "CCC"}
+		mockProvider.On("GetTicker", "BTC-USD").Return(ticker, nil).Once()
+
+		for i := 0; i < 2; i++ {
+			rec := get(handler, "?symbol=btc-usd")
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var result models.Ticker
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+			assert.Equal(t, *ticker, result)
+		}
+		mockProvider.AssertNumberOfCalls(t, "GetTicker", 1)
+	})
+
+	t.Run("MissingSymbol", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get(handler, "").Code)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		providerErr := &providers.ProviderError{Provider: "mock", Kind: providers.KindNotFound, Message: "no quote data"}
+		mockProvider.On("GetTicker", "NOPE").Return((*models.Ticker)(nil), providerErr)
+
+		rec := get(handler, "?symbol=NOPE")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("CacheDisabled", func(t *testing.T) {
+		uncachedProvider := new(MockDataProvider)
+		uncachedProvider.On("GetTicker", "AAPL").Return(&models.Ticker{Symbol: "AAPL"}, nil)
+		uncached := NewHandler(nil, uncachedProvider, &config.Config{TradingMode: "test"}, nil, nil, nil, nil)
+
+		get(uncached, "?symbol=AAPL")
+		get(uncached, "?symbol=AAPL")
+		uncachedProvider.AssertNumberOfCalls(t, "GetTicker", 2)
+	})
 }
@@ -1,23 +1,39 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
+	"github.com/alexherrero/sherwood/backend/data"
 	"github.com/alexherrero/sherwood/backend/models"
 	"github.com/go-chi/chi/v5"
 )
 
-// GetNotificationsHandler retrieves recent notifications.
+// NotificationsResponse is a page of notifications. Unread counts every
+// unread notification, regardless of the filter, for the frontend badge.
+type NotificationsResponse struct {
+	Notifications []models.Notification `json:"notifications"`
+	Total         int                   `json:"total"`
+	Page          int                   `json:"page"`
+	Limit         int                   `json:"limit"`
+	Unread        int                   `json:"unread_count"`
+}
+
+// GetNotificationsHandler retrieves notifications, optionally filtered by type
+// and read status, as a paginated envelope with the unread count.
 //
 // @Summary      Get Notifications
-// @Description  Retrieves a list of recent system notifications.
+// @Description  Retrieves a page of system notifications, newest first.
 // @Tags         notifications
 // @Accept       json
 // @Produce      json
-// @Param        limit   query     int  false  "Limit (default 50)"
-// @Param        offset  query     int  false  "Offset (default 0)"
-// @Success      200  {array}   models.Notification
+// @Param        type    query     string  false  "Type (info, success, warning, error, trade)"
+// @Param        read    query     bool    false  "Read status"
+// @Param        limit   query     int     false  "Limit (default 50)"
+// @Param        page    query     int     false  "Page (default 1)"
+// @Success      200  {object}  NotificationsResponse
+// @Failure      400  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /notifications [get]
 func (h *Handler) GetNotificationsHandler(w http.ResponseWriter, r *http.Request) {
@@ -26,26 +42,66 @@ func (h *Handler) GetNotificationsHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	limit := getQueryInt(r, "limit", 50)
 	if limit <= 0 {
 		limit = 50
 	}
-	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
-	if offset < 0 {
-		offset = 0
+	page := getQueryInt(r, "page", 1)
+	if page < 1 {
+		page = 1
+	}
+
+	filter := data.NotificationFilter{
+		Type:   models.NotificationType(r.URL.Query().Get("type")),
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	}
+	if filter.Type != "" && !validNotificationType(filter.Type) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid notification type '%s'", filter.Type))
+		return
+	}
+	if readStr := r.URL.Query().Get("read"); readStr != "" {
+		read, err := strconv.ParseBool(readStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "read must be true or false")
+			return
+		}
+		filter.IsRead = &read
 	}
 
-	notifs, err := h.notificationManager.GetHistory(limit, offset)
+	notifs, total, err := h.notificationManager.Query(filter)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "Failed to retrieve notifications")
 		return
 	}
+	unread, err := h.notificationManager.UnreadCount()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to count unread notifications")
+		return
+	}
 
 	if notifs == nil {
 		notifs = []models.Notification{}
 	}
 
-	writeJSON(w, http.StatusOK, notifs)
+	writeJSON(w, http.StatusOK, NotificationsResponse{
+		Notifications: notifs,
+		Total:         total,
+		Page:          page,
+		Limit:         limit,
+		Unread:        unread,
+	})
+}
+
+// validNotificationType reports whether t is a known notification type.
+func validNotificationType(t models.NotificationType) bool {
+	switch t {
+	case models.NotificationInfo, models.NotificationSuccess, models.NotificationWarning,
+		models.NotificationError, models.NotificationTrade:
+		return true
+	default:
+		return false
+	}
 }
 
 // MarkNotificationReadHandler marks a single notification as read.
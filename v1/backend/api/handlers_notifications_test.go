@@ -0,0 +1,83 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexherrero/sherwood/backend/config"
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/notifications"
+	"github.com/alexherrero/sherwood/backend/strategies"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetNotificationsHandler verifies filtering, pagination and the unread count.
+func TestGetNotificationsHandler(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	manager := notifications.NewManager(data.NewNotificationStore(db), nil)
+	_, err = manager.Send(models.NotificationInfo, "Started", "Engine started", nil)
+	require.NoError(t, err)
+	errID, err := manager.Send(models.NotificationError, "Failed", "Order failed", nil)
+	require.NoError(t, err)
+	_, err = manager.Send(models.NotificationError, "Failed", "Order failed again", nil)
+	require.NoError(t, err)
+	require.NoError(t, manager.MarkAsRead(errID))
+
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost:3000"}}
+	router := NewRouter(cfg, strategies.NewRegistry(), new(MockDataProvider), nil, nil, nil, manager)
+
+	get := func(query string) (*httptest.ResponseRecorder, NotificationsResponse) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/notifications"+query, nil))
+		var resp NotificationsResponse
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		}
+		return rec, resp
+	}
+
+	t.Run("All", func(t *testing.T) {
+		rec, resp := get("")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 3, resp.Total)
+		assert.Len(t, resp.Notifications, 3)
+		assert.Equal(t, 1, resp.Page)
+		assert.Equal(t, 50, resp.Limit)
+		assert.Equal(t, 2, resp.Unread)
+	})
+
+	t.Run("FilterTypeAndRead", func(t *testing.T) {
+		rec, resp := get("?type=error&read=false")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 1, resp.Total)
+		require.Len(t, resp.Notifications, 1)
+		assert.Equal(t, "Order failed again", resp.Notifications[0].Message)
+		assert.Equal(t, 2, resp.Unread, "unread count ignores the filter")
+	})
+
+	t.Run("Paginated", func(t *testing.T) {
+		rec, resp := get("?limit=2&page=2")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 3, resp.Total)
+		assert.Len(t, resp.Notifications, 1)
+		assert.Equal(t, 2, resp.Page)
+	})
+
+	t.Run("InvalidType", func(t *testing.T) {
+		rec, _ := get("?type=urgent")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("InvalidRead", func(t *testing.T) {
+		rec, _ := get("?read=maybe")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -2,9 +2,12 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/alexherrero/sherwood/backend/execution"
 	"github.com/alexherrero/sherwood/backend/models"
@@ -25,14 +28,16 @@ func (h *Handler) GetOrdersHandler(w http.ResponseWriter, r *http.Request) {
 		page = 1
 	}
 	offset := (page - 1) * limit
-	symbol := r.URL.Query().Get("symbol")
+	symbol := h.canonicalSymbol(r.URL.Query().Get("symbol"))
 	statusStr := r.URL.Query().Get("status")
 
 	filter := execution.OrderFilter{
-		Limit:  limit,
-		Offset: offset,
-		Symbol: symbol,
-		Status: models.OrderStatus(statusStr),
+		Limit:    limit,
+		Offset:   offset,
+		Symbol:   symbol,
+		Status:   models.OrderStatus(statusStr),
+		Strategy: r.URL.Query().Get("strategy"),
+		Tag:      r.URL.Query().Get("tag"),
 	}
 
 	orders, total, err := h.orderManager.GetOrders(filter)
@@ -81,36 +86,20 @@ func (h *Handler) GetOrderHistoryHandler(w http.ResponseWriter, r *http.Request)
 	h.GetOrdersHandler(w, r)
 }
 
-// GetPortfolioSummaryHandler returns an aggregated portfolio summary.
+// GetPortfolioSummaryHandler returns an aggregated portfolio summary, with
+// positions converted to the configured base currency.
 func (h *Handler) GetPortfolioSummaryHandler(w http.ResponseWriter, r *http.Request) {
 	if h.orderManager == nil {
 		writeError(w, http.StatusServiceUnavailable, "Execution layer not available")
 		return
 	}
 
-	balance, err := h.orderManager.GetBalance()
-	if err != nil {
-		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get balance: %v", err))
-		return
-	}
-
-	positions, err := h.orderManager.GetPositions()
+	summary, err := h.orderManager.PortfolioSummary()
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get positions: %v", err))
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get portfolio summary: %v", err))
 		return
 	}
 
-	var totalUnrealizedPL float64
-	for _, p := range positions {
-		totalUnrealizedPL += p.UnrealizedPL
-	}
-
-	summary := map[string]interface{}{
-		"balance":             balance,
-		"total_unrealized_pl": totalUnrealizedPL,
-		"open_positions":      len(positions),
-	}
-
 	writeJSON(w, http.StatusOK, summary)
 }
 
@@ -149,6 +138,10 @@ type PlaceOrderRequest struct {
 	Type     string  `json:"type" validate:"required,oneof=market limit"`
 	Quantity float64 `json:"quantity" validate:"required,gt=0,lte=1000000"`
 	Price    float64 `json:"price" validate:"required_if=Type limit,omitempty,gt=0"`
+	// TimeInForce is gtc (default), day or ioc.
+	TimeInForce string `json:"time_in_force" validate:"omitempty,oneof=gtc day ioc"`
+	// Tags are free-form labels; "manual" is always added.
+	Tags []string `json:"tags" validate:"max=10,dive,min=1,max=50"`
 }
 
 // PlaceOrderHandler handles manual order placement.
@@ -164,6 +157,9 @@ func (h *Handler) PlaceOrderHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Accept DAY/GTC/IOC as well as lowercase
+	req.TimeInForce = strings.ToLower(req.TimeInForce)
+
 	// Validate request
 	if valErr := validateStruct(req); valErr != nil {
 		writeValidationError(w, valErr)
@@ -181,28 +177,102 @@ func (h *Handler) PlaceOrderHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var order *models.Order
-	var err error
+	newOrder := models.Order{
+		Symbol:      h.canonicalSymbol(req.Symbol),
+		Side:        side,
+		Quantity:    req.Quantity,
+		TimeInForce: models.TimeInForce(req.TimeInForce),
+		Tags:        manualOrderTags(req.Tags),
+		Status:      models.OrderStatusPending,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
 
 	// Create order based on type
 	switch req.Type {
 	case "market":
-		order, err = h.orderManager.CreateMarketOrder(r.Context(), req.Symbol, side, req.Quantity)
+		newOrder.Type = models.OrderTypeMarket
 	case "limit":
-		order, err = h.orderManager.CreateLimitOrder(r.Context(), req.Symbol, side, req.Quantity, req.Price)
+		newOrder.Type = models.OrderTypeLimit
+		newOrder.Price = req.Price
 	default:
 		writeError(w, http.StatusBadRequest, "Invalid type: must be 'market' or 'limit'")
 		return
 	}
 
+	order, err := h.orderManager.SubmitOrder(r.Context(), newOrder)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to place order: %v", err))
+		writeOrderError(w, err)
 		return
 	}
 
+	// Large live orders are staged until confirmed
+	if order.Status == models.OrderStatusPendingConfirmation {
+		writeJSON(w, http.StatusAccepted, order)
+		return
+	}
 	writeJSON(w, http.StatusOK, order)
 }
 
+// GetStagedOrdersHandler lists the orders staged for confirmation.
+func (h *Handler) GetStagedOrdersHandler(w http.ResponseWriter, r *http.Request) {
+	if h.orderManager == nil {
+		writeError(w, http.StatusServiceUnavailable, "Execution layer not available")
+		return
+	}
+	writeJSON(w, http.StatusOK, h.orderManager.StagedOrders())
+}
+
+// ConfirmOrderHandler submits an order staged for confirmation.
+func (h *Handler) ConfirmOrderHandler(w http.ResponseWriter, r *http.Request) {
+	if h.orderManager == nil {
+		writeError(w, http.StatusServiceUnavailable, "Execution layer not available")
+		return
+	}
+
+	order, err := h.orderManager.ConfirmOrder(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		if errors.Is(err, execution.ErrConfirmationNotFound) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		writeOrderError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, order)
+}
+
+// writeOrderError maps an order submission error to its status code.
+func writeOrderError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, execution.ErrTradingDisabled):
+		writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, execution.ErrBrokerPaused):
+		writeError(w, http.StatusServiceUnavailable, err.Error(), "BROKER_PAUSED")
+	case errors.Is(err, execution.ErrMaxNotionalExceeded), errors.Is(err, execution.ErrNotionalUnknown):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, execution.ErrSyntheticSymbol):
+		writeError(w, http.StatusUnprocessableEntity, err.Error(), "SYNTHETIC_SYMBOL")
+	case errors.Is(err, execution.ErrOrderInvalid):
+		writeError(w, http.StatusUnprocessableEntity, err.Error(), "ORDER_INVALID")
+	default:
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to place order: %v", err))
+	}
+}
+
+// manualOrderTags returns the tags for a hand-placed order: the requested
+// tags plus "manual".
+func manualOrderTags(tags []string) models.Tags {
+	result := models.Tags{models.TagManual}
+	for _, tag := range tags {
+		if !result.Has(tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
 // CancelOrderHandler handles order cancellation.
 func (h *Handler) CancelOrderHandler(w http.ResponseWriter, r *http.Request) {
 	if h.orderManager == nil {
@@ -262,7 +332,14 @@ func (h *Handler) ModifyOrderHandler(w http.ResponseWriter, r *http.Request) {
 
 	order, err := h.orderManager.ModifyOrder(r.Context(), id, req.Price, req.Quantity)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to modify order: %v", err))
+		switch {
+		case errors.Is(err, execution.ErrOrderNotModifiable):
+			writeError(w, http.StatusConflict, err.Error())
+		case errors.Is(err, execution.ErrQuantityBelowFilled), errors.Is(err, execution.ErrOrderInvalid):
+			writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to modify order: %v", err))
+		}
 		return
 	}
 
@@ -285,6 +362,110 @@ func (h *Handler) GetTradesHandler(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, trades)
 }
 
+// GetOrderTradesHandler returns the fills for a single order.
+func (h *Handler) GetOrderTradesHandler(w http.ResponseWriter, r *http.Request) {
+	if h.orderManager == nil {
+		writeError(w, http.StatusServiceUnavailable, "Execution layer not available")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if _, err := h.orderManager.GetOrder(id); err != nil {
+		writeError(w, http.StatusNotFound, "Order not found")
+		return
+	}
+
+	trades, err := h.orderManager.GetOrderTrades(id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get trades: %v", err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, trades)
+}
+
+// OrderNoteRequest defines the payload for adding a journal note to an order.
+type OrderNoteRequest struct {
+	Text string `json:"text" validate:"required,max=2000"`
+}
+
+// AddOrderNoteHandler attaches a journal note to an order.
+func (h *Handler) AddOrderNoteHandler(w http.ResponseWriter, r *http.Request) {
+	if h.orderManager == nil {
+		writeError(w, http.StatusServiceUnavailable, "Execution layer not available")
+		return
+	}
+
+	var req OrderNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if valErr := validateStruct(req); valErr != nil {
+		writeValidationError(w, valErr)
+		return
+	}
+
+	note, err := h.orderManager.AddOrderNote(chi.URLParam(r, "id"), req.Text)
+	if err != nil {
+		writeOrderNoteError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, note)
+}
+
+// GetOrderNotesHandler lists the journal notes on an order, oldest first.
+func (h *Handler) GetOrderNotesHandler(w http.ResponseWriter, r *http.Request) {
+	if h.orderManager == nil {
+		writeError(w, http.StatusServiceUnavailable, "Execution layer not available")
+		return
+	}
+
+	notes, err := h.orderManager.GetOrderNotes(chi.URLParam(r, "id"))
+	if err != nil {
+		writeOrderNoteError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, notes)
+}
+
+// writeOrderNoteError maps an order note failure to a response.
+func writeOrderNoteError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, execution.ErrOrderNotFound):
+		writeError(w, http.StatusNotFound, "Order not found")
+	case errors.Is(err, execution.ErrNotesUnsupported):
+		writeError(w, http.StatusNotImplemented, "Order notes not available")
+	case errors.Is(err, execution.ErrOrderInvalid):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to access order notes: %v", err))
+	}
+}
+
+// GetOrderEventsHandler returns an order's audit trail: each state change
+// with its actor and trace ID, oldest first.
+func (h *Handler) GetOrderEventsHandler(w http.ResponseWriter, r *http.Request) {
+	if h.orderManager == nil {
+		writeError(w, http.StatusServiceUnavailable, "Execution layer not available")
+		return
+	}
+
+	events, err := h.orderManager.GetOrderEvents(chi.URLParam(r, "id"))
+	switch {
+	case errors.Is(err, execution.ErrOrderNotFound):
+		writeError(w, http.StatusNotFound, "Order not found")
+	case errors.Is(err, execution.ErrEventsUnsupported):
+		writeError(w, http.StatusNotImplemented, "Order events not available")
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get order events: %v", err))
+	default:
+		writeJSON(w, http.StatusOK, events)
+	}
+}
+
 // getQueryInt parses a query parameter as an integer.
 func getQueryInt(r *http.Request, key string, defaultVal int) int {
 	valStr := r.URL.Query().Get(key)
@@ -297,3 +478,114 @@ func getQueryInt(r *http.Request, key string, defaultVal int) int {
 	}
 	return val
 }
+
+// PaperResetRequest defines the payload for resetting the paper account.
+type PaperResetRequest struct {
+	Confirm      bool    `json:"confirm"`
+	InitialCash  float64 `json:"initial_cash,omitempty" validate:"gte=0,lte=10000000"` // Defaults to INITIAL_CAPITAL
+	ClearHistory bool    `json:"clear_history,omitempty"`                              // Also delete persisted orders, trades and positions
+}
+
+// ResetPaperHandler wipes the paper account back to its starting cash. It is
+// only available in dry-run mode and while the engine is stopped.
+func (h *Handler) ResetPaperHandler(w http.ResponseWriter, r *http.Request) {
+	if h.config == nil || !h.config.IsDryRun() {
+		writeError(w, http.StatusForbidden, "Paper reset is only available in dry_run mode")
+		return
+	}
+	if h.orderManager == nil {
+		writeError(w, http.StatusServiceUnavailable, "Execution layer not available")
+		return
+	}
+
+	var req PaperResetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !req.Confirm {
+		writeError(w, http.StatusBadRequest, "Confirmation required: {\"confirm\": true}")
+		return
+	}
+	if valErr := validateStruct(req); valErr != nil {
+		writeValidationError(w, valErr)
+		return
+	}
+	if h.engine != nil && h.engine.IsRunning() {
+		writeError(w, http.StatusConflict, "Stop the trading engine before resetting the paper account")
+		return
+	}
+
+	initialCash := req.InitialCash
+	if initialCash == 0 {
+		initialCash = h.config.InitialCapital
+	}
+	if initialCash == 0 {
+		initialCash = execution.DefaultInitialCapital
+	}
+
+	reset, err := h.orderManager.ResetPaper(r.Context(), initialCash, req.ClearHistory)
+	if errors.Is(err, execution.ErrResetUnsupported) {
+		writeError(w, http.StatusForbidden, "Paper reset requires the paper broker")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to reset paper account: %v", err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, reset)
+}
+
+// PositionImportRequest defines the payload for importing positions.
+type PositionImportRequest struct {
+	Positions []ImportedPosition `json:"positions" validate:"required,min=1,max=500,dive"`
+}
+
+// ImportedPosition is a position held before trading with Sherwood.
+type ImportedPosition struct {
+	Symbol      string  `json:"symbol" validate:"required,min=1,max=20"`
+	Quantity    float64 `json:"quantity" validate:"gt=0"`
+	AverageCost float64 `json:"average_cost" validate:"gt=0"`
+}
+
+// ImportPositionsHandler seeds the paper account with positions held
+// elsewhere. Live positions come from the broker through reconciliation, so
+// it is only available in dry-run mode.
+func (h *Handler) ImportPositionsHandler(w http.ResponseWriter, r *http.Request) {
+	if h.config == nil || !h.config.IsDryRun() {
+		writeError(w, http.StatusForbidden, "Position import is only available in dry_run mode; live positions are reconciled from the broker")
+		return
+	}
+	if h.orderManager == nil {
+		writeError(w, http.StatusServiceUnavailable, "Execution layer not available")
+		return
+	}
+
+	var req PositionImportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if valErr := validateStruct(req); valErr != nil {
+		writeValidationError(w, valErr)
+		return
+	}
+
+	positions := make([]models.Position, len(req.Positions))
+	for i, pos := range req.Positions {
+		positions[i] = models.Position{
+			Symbol:      h.canonicalSymbol(pos.Symbol),
+			Quantity:    pos.Quantity,
+			AverageCost: pos.AverageCost,
+		}
+	}
+
+	held, err := h.orderManager.ImportPositions(r.Context(), positions)
+	switch {
+	case errors.Is(err, execution.ErrImportUnsupported):
+		writeError(w, http.StatusForbidden, "Position import requires the paper broker")
+	case errors.Is(err, execution.ErrInvalidImport):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to import positions: %v", err))
+	default:
+		writeJSON(w, http.StatusOK, held)
+	}
+}
@@ -2,13 +2,20 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/alexherrero/sherwood/backend/config"
+	"github.com/alexherrero/sherwood/backend/data"
 	"github.com/alexherrero/sherwood/backend/execution"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/strategies"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -100,6 +107,89 @@ func TestPlaceOrder_Errors(t *testing.T) {
 		handler.PlaceOrderHandler(rec, req)
 		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
 	})
+
+	t.Run("AboveMaxNotional", func(t *testing.T) {
+		orderManager.SetOrderSizing(execution.OrderSizing{MaxNotional: 100000})
+		defer orderManager.SetOrderSizing(execution.OrderSizing{})
+
+		payload := map[string]interface{}{
+			"symbol":   "AAPL",
+			"side":     "buy",
+			"type":     "limit",
+			"quantity": 1000,
+			"price":    150,
+		}
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.PlaceOrderHandler(rec, req)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "order value 150000.00 is above the maximum notional 100000.00")
+	})
+
+	t.Run("SyntheticSymbol", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"symbol":   "ratio:ETH-USD/SPY",
+			"side":     "buy",
+			"type":     "market",
+			"quantity": 1,
+		}
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.PlaceOrderHandler(rec, req)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "SYNTHETIC_SYMBOL", resp["code"])
+		assert.Contains(t, resp["error"], "trade its legs instead")
+	})
+
+	t.Run("BrokerPaused", func(t *testing.T) {
+		orderManager.SetBrokerPaused(true)
+		defer orderManager.SetBrokerPaused(false)
+
+		payload := map[string]interface{}{
+			"symbol":   "AAPL",
+			"side":     "buy",
+			"type":     "market",
+			"quantity": 1,
+		}
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.PlaceOrderHandler(rec, req)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "BROKER_PAUSED", resp["code"])
+	})
+
+	t.Run("BelowMinNotional", func(t *testing.T) {
+		orderManager.SetOrderSizing(execution.OrderSizing{MinNotional: 10})
+		defer orderManager.SetOrderSizing(execution.OrderSizing{})
+
+		payload := map[string]interface{}{
+			"symbol":   "AAPL",
+			"side":     "buy",
+			"type":     "limit",
+			"quantity": 0.01,
+			"price":    150,
+		}
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.PlaceOrderHandler(rec, req)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "ORDER_INVALID", resp["code"])
+		assert.Contains(t, resp["error"], "below the minimum notional 10.00")
+	})
 }
 
 func TestModifyOrder_Errors(t *testing.T) {
@@ -126,3 +216,313 @@ func TestModifyOrder_Errors(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
 	})
 }
+
+func TestGetOrderTradesHandler(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	broker := execution.NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 150.0)
+	orderManager := execution.NewOrderManager(broker, nil, data.NewOrderStore(db), nil)
+
+	filled, err := orderManager.CreateMarketOrder(context.Background(), "AAPL", models.OrderSideBuy, 3)
+	require.NoError(t, err)
+	resting, err := orderManager.CreateLimitOrder(context.Background(), "AAPL", models.OrderSideBuy, 1, 100.0)
+	require.NoError(t, err)
+
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost:3000"}}
+	router := NewRouter(cfg, strategies.NewRegistry(), new(MockDataProvider), orderManager, nil, nil, nil)
+
+	get := func(id string) (*httptest.ResponseRecorder, []models.Trade) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/execution/orders/"+id+"/trades", nil))
+		var trades []models.Trade
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &trades))
+		}
+		return rec, trades
+	}
+
+	t.Run("Filled", func(t *testing.T) {
+		rec, trades := get(filled.ID)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.Len(t, trades, 1)
+		assert.Equal(t, filled.ID, trades[0].OrderID)
+		assert.Equal(t, 3.0, trades[0].Quantity)
+	})
+
+	t.Run("NotFilled", func(t *testing.T) {
+		rec, _ := get(resting.ID)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, "[]", rec.Body.String())
+	})
+
+	t.Run("UnknownOrder", func(t *testing.T) {
+		rec, _ := get("missing")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+// TestOrderNotesHandlers verifies journal notes can be added to and listed
+// for stored orders, and that unknown orders are rejected.
+func TestOrderNotesHandlers(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	broker := execution.NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 150.0)
+	orderManager := execution.NewOrderManager(broker, nil, data.NewOrderStore(db), nil)
+	order, err := orderManager.CreateMarketOrder(context.Background(), "AAPL", models.OrderSideBuy, 3)
+	require.NoError(t, err)
+
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost:3000"}}
+	router := NewRouter(cfg, strategies.NewRegistry(), new(MockDataProvider), orderManager, nil, nil, nil)
+
+	serve := func(method, id, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, "/api/v1/execution/orders/"+id+"/notes", strings.NewReader(body)))
+		return rec
+	}
+
+	rec := serve(http.MethodGet, order.ID, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, "[]", rec.Body.String())
+
+	rec = serve(http.MethodPost, order.ID, `{"text": "took profit early, earnings tomorrow"}`)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var note models.OrderNote
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &note))
+	assert.NotZero(t, note.ID)
+	assert.Equal(t, order.ID, note.OrderID)
+	assert.False(t, note.CreatedAt.IsZero())
+
+	rec = serve(http.MethodPost, order.ID, `{"text": "  "}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = serve(http.MethodPost, order.ID, `{}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	rec = serve(http.MethodGet, order.ID, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var notes []models.OrderNote
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &notes))
+	require.Len(t, notes, 1)
+	assert.Equal(t, "took profit early, earnings tomorrow", notes[0].Text)
+
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "missing", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodPost, "missing", `{"text": "hi"}`).Code)
+}
+
+// TestOrderEventsHandler verifies the audit trail lists an order's state
+// changes in order, attributed to the engine or the API key that made them.
+func TestOrderEventsHandler(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	broker := execution.NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 150.0)
+	orderManager := execution.NewOrderManager(broker, nil, data.NewOrderStore(db), nil)
+
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost:3000"}}
+	router := NewRouter(cfg, strategies.NewRegistry(), new(MockDataProvider), orderManager, nil, nil, nil)
+
+	order, err := orderManager.CreateLimitOrder(execution.NewEngineContext(), "AAPL", models.OrderSideBuy, 3, 100)
+	require.NoError(t, err)
+
+	// Cancelled through the API, so attributed to the caller's key
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/execution/orders/"+order.ID, nil)
+	req.Header.Set("X-Sherwood-API-Key", "test-key")
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/execution/orders/"+order.ID+"/events", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var events []models.OrderEvent
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &events))
+	require.Len(t, events, 2)
+	assert.Equal(t, models.OrderActionSubmit, events[0].Action)
+	assert.Equal(t, "system", events[0].Actor)
+	assert.Equal(t, models.OrderActionCancel, events[1].Action)
+	assert.Equal(t, models.OrderStatusCancelled, events[1].ToStatus)
+	assert.Equal(t, keyFingerprint("test-key"), events[1].Actor)
+	assert.NotEmpty(t, events[1].TraceID)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/execution/orders/missing/events", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// TestResetPaperHandler verifies the paper reset is confirm-gated, refused in
+// live mode, and restores the configured starting cash.
+func TestResetPaperHandler(t *testing.T) {
+	post := func(handler *Handler, payload any) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/execution/reset", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.ResetPaperHandler(rec, req)
+		return rec
+	}
+	newPaper := func(cfg *config.Config) (*Handler, *execution.PaperBroker) {
+		broker := execution.NewPaperBroker(10000)
+		require.NoError(t, broker.Connect())
+		broker.SetPrice("AAPL", 100)
+		orderManager := execution.NewOrderManager(broker, nil, nil, nil)
+		_, err := orderManager.CreateMarketOrder(context.Background(), "AAPL", models.OrderSideBuy, 10)
+		require.NoError(t, err)
+		return NewHandler(nil, nil, cfg, orderManager, nil, nil, nil), broker
+	}
+
+	t.Run("LiveModeForbidden", func(t *testing.T) {
+		handler, _ := newPaper(&config.Config{TradingMode: config.ModeLive})
+		rec := post(handler, map[string]bool{"confirm": true})
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("WithoutConfirmation", func(t *testing.T) {
+		handler, _ := newPaper(&config.Config{TradingMode: config.ModeDryRun})
+		rec := post(handler, map[string]bool{"confirm": false})
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("InvalidCash", func(t *testing.T) {
+		handler, _ := newPaper(&config.Config{TradingMode: config.ModeDryRun})
+		rec := post(handler, map[string]any{"confirm": true, "initial_cash": -5})
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("NonPaperBroker", func(t *testing.T) {
+		orderManager := execution.NewOrderManager(new(MockBroker), nil, nil, nil)
+		handler := NewHandler(nil, nil, &config.Config{TradingMode: config.ModeDryRun}, orderManager, nil, nil, nil)
+		rec := post(handler, map[string]bool{"confirm": true})
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		handler, broker := newPaper(&config.Config{TradingMode: config.ModeDryRun, InitialCapital: 50000})
+		rec := post(handler, map[string]bool{"confirm": true})
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"initial_cash": 50000, "history_cleared": false}`, rec.Body.String())
+
+		balance, err := broker.GetBalance()
+		require.NoError(t, err)
+		assert.Equal(t, 50000.0, balance.Cash)
+		positions, err := broker.GetPositions()
+		require.NoError(t, err)
+		assert.Empty(t, positions)
+
+		rec = post(handler, map[string]any{"confirm": true, "initial_cash": 2500})
+		require.Equal(t, http.StatusOK, rec.Code)
+		balance, err = broker.GetBalance()
+		require.NoError(t, err)
+		assert.Equal(t, 2500.0, balance.Cash)
+	})
+}
+
+// TestImportPositionsHandler verifies positions are imported in dry-run mode
+// only and invalid positions are rejected.
+func TestImportPositionsHandler(t *testing.T) {
+	post := func(handler *Handler, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/execution/positions/import", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.ImportPositionsHandler(rec, req)
+		return rec
+	}
+	newPaper := func(cfg *config.Config) (*Handler, *execution.PaperBroker) {
+		broker := execution.NewPaperBroker(10000)
+		require.NoError(t, broker.Connect())
+		return NewHandler(nil, nil, cfg, execution.NewOrderManager(broker, nil, nil, nil), nil, nil, nil), broker
+	}
+	valid := `{"positions": [{"symbol": "btc/usd", "quantity": 0.5, "average_cost": 30000}]}`
+
+	t.Run("LiveModeForbidden", func(t *testing.T) {
+		handler, _ := newPaper(&config.Config{TradingMode: config.ModeLive})
+		rec := post(handler, valid)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Body.String(), "reconciled from the broker")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		handler, _ := newPaper(&config.Config{TradingMode: config.ModeDryRun})
+		assert.Equal(t, http.StatusUnprocessableEntity, post(handler, `{"positions": []}`).Code)
+		assert.Equal(t, http.StatusUnprocessableEntity,
+			post(handler, `{"positions": [{"symbol": "AAPL", "quantity": -1, "average_cost": 10}]}`).Code)
+		assert.Equal(t, http.StatusBadRequest,
+			post(handler, `{"positions": [{"symbol": "AAPL", "quantity": 1, "average_cost": 10}, {"symbol": "aapl", "quantity": 2, "average_cost": 10}]}`).Code)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		handler, broker := newPaper(&config.Config{TradingMode: config.ModeDryRun})
+		rec := post(handler, valid)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var held []models.Position
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &held))
+		require.Len(t, held, 1)
+		assert.Equal(t, "BTC-USD", held[0].Symbol)
+
+		position, err := broker.GetPosition("BTC-USD")
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, position.Quantity)
+		assert.Equal(t, 30000.0, position.AverageCost)
+	})
+}
+
+// TestConfirmOrderHandler verifies a large order is staged with 202 and
+// placed by the confirm endpoint, and that a used token is not found.
+func TestConfirmOrderHandler(t *testing.T) {
+	broker := execution.NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 150.0)
+	orderManager := execution.NewOrderManager(broker, nil, nil, nil)
+	orderManager.SetOrderConfirmation(execution.OrderConfirmation{Threshold: 1000, Window: time.Minute})
+
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost:3000"}}
+	router := NewRouter(cfg, strategies.NewRegistry(), new(MockDataProvider), orderManager, nil, nil, nil)
+
+	serve := func(path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/execution"+path, strings.NewReader(body)))
+		return rec
+	}
+
+	rec := serve("/orders", `{"symbol": "AAPL", "side": "buy", "type": "market", "quantity": 1}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve("/orders", `{"symbol": "AAPL", "side": "buy", "type": "market", "quantity": 10}`)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	var staged models.Order
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &staged))
+	assert.Equal(t, models.OrderStatusPendingConfirmation, staged.Status)
+	require.NotEmpty(t, staged.ConfirmationToken)
+	assert.False(t, staged.ConfirmBy.IsZero())
+
+	list := func() []models.Order {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/execution/orders/pending-confirmation", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var pending []models.Order
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &pending))
+		return pending
+	}
+	pending := list()
+	require.Len(t, pending, 1)
+	assert.Equal(t, staged.ConfirmationToken, pending[0].ConfirmationToken)
+
+	rec = serve("/orders/"+staged.ConfirmationToken+"/confirm", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, list())
+	var placed models.Order
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &placed))
+	assert.NotEmpty(t, placed.ID)
+	assert.Equal(t, models.OrderStatusFilled, placed.Status)
+
+	rec = serve("/orders/"+staged.ConfirmationToken+"/confirm", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -156,6 +156,9 @@ func TestPerformanceSummary(t *testing.T) {
 		{Symbol: "AAPL", UnrealizedPL: 5000},
 		{Symbol: "MSFT", UnrealizedPL: 5000},
 	}, nil)
+	mockBroker.On("GetTrades").Return([]models.Trade{
+		{Symbol: "AAPL", Side: models.OrderSideBuy, Quantity: 10, Price: 100, Commission: 2.5},
+	}, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/portfolio/summary", nil)
 	rec := httptest.NewRecorder()
@@ -167,4 +170,42 @@ func TestPerformanceSummary(t *testing.T) {
 
 	assert.Equal(t, float64(10000), resp["total_unrealized_pl"])
 	assert.Equal(t, float64(2), resp["open_positions"])
+	assert.Equal(t, "USD", resp["base_currency"])
+	assert.Equal(t, 2.5, resp["total_fees"])
+	assert.Equal(t, float64(1), resp["fee_trades"])
+	assert.Equal(t, -2.5, resp["net_realized_pl"])
+}
+
+// TestStrategyPerformanceHandler verifies rows per strategy and the date
+// range validation.
+func TestStrategyPerformanceHandler(t *testing.T) {
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost:3000"}}
+	mockBroker := new(MockBroker)
+	orderManager := execution.NewOrderManager(mockBroker, nil, nil, nil)
+	handler := NewHandler(strategies.NewRegistry(), new(MockDataProvider), cfg, orderManager, nil, nil, nil)
+
+	mockBroker.On("GetPositions").Return([]models.Position{}, nil)
+	mockBroker.On("GetTrades").Return([]models.Trade{
+		{Symbol: "AAPL", Side: models.OrderSideBuy, Quantity: 10, Price: 100},
+		{Symbol: "AAPL", Side: models.OrderSideSell, Quantity: 10, Price: 90, Commission: 1},
+	}, nil)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.GetStrategyPerformanceHandler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/portfolio/by-strategy"+query, nil))
+		return rec
+	}
+
+	rec := get("")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp StrategyPerformanceResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Strategies, 1)
+	assert.Equal(t, "manual", resp.Strategies[0].Strategy)
+	assert.Equal(t, 1, resp.Strategies[0].Trades)
+	assert.Equal(t, -100.0, resp.Strategies[0].TotalPnL)
+	assert.Equal(t, 1.0, resp.Strategies[0].TotalFees)
+
+	assert.Equal(t, http.StatusBadRequest, get("?start=yesterday").Code)
+	assert.Equal(t, http.StatusBadRequest, get("?start=2024-02-01T00:00:00Z&end=2024-01-01T00:00:00Z").Code)
 }
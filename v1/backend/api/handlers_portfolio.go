@@ -3,21 +3,46 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/alexherrero/sherwood/backend/analysis"
+	"github.com/alexherrero/sherwood/backend/execution"
+	"github.com/alexherrero/sherwood/backend/models"
 )
 
+// defaultEquityCurveWindow is how far back the equity curve reaches when no
+// start time is given.
+const defaultEquityCurveWindow = 30 * 24 * time.Hour
+
+// PerformanceResponse is the performance metrics plus recorded equity history.
+type PerformanceResponse struct {
+	analysis.PerformanceMetrics
+	EquityCurve []models.EquitySnapshot `json:"equity_curve"`
+}
+
 // GetPortfolioPerformanceHandler returns aggregate performance metrics.
 //
 // @Summary      Get Performance Metrics
-// @Description  Calculates and returns performance metrics based on trade history.
+// @Description  Calculates performance metrics from trade history and returns the recorded equity curve.
 // @Tags         portfolio
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}  analysis.PerformanceMetrics
+// @Param        since  query     string  false  "Start of the equity curve (RFC3339, default 30 days ago)"
+// @Success      200  {object}  PerformanceResponse
+// @Failure      400  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /portfolio/performance [get]
 func (h *Handler) GetPortfolioPerformanceHandler(w http.ResponseWriter, r *http.Request) {
+	since := time.Now().Add(-defaultEquityCurveWindow)
+	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
+		parsed, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid since time: must be RFC3339")
+			return
+		}
+		since = parsed
+	}
+
 	// 1. Get all orders (including filled ones)
 	// We use GetAllOrders because we want to analyze the entire history
 	// In a real system, we might want date range filtering, but for now global metrics.
@@ -28,7 +53,7 @@ func (h *Handler) GetPortfolioPerformanceHandler(w http.ResponseWriter, r *http.
 	}
 
 	// 2. Determine initial capital base for equity curve
-	initialCapital := 100000.0 // Default fallback for paper trading
+	initialCapital := execution.DefaultInitialCapital // Fallback when none is recorded
 
 	storedCapital, err := h.orderManager.GetInitialCapital()
 	if err == nil && storedCapital > 0 {
@@ -38,6 +63,75 @@ func (h *Handler) GetPortfolioPerformanceHandler(w http.ResponseWriter, r *http.
 	// 3. Calculate metrics
 	metrics := analysis.CalculateMetrics(orders, initialCapital)
 
-	// 4. Return JSON
-	writeJSON(w, http.StatusOK, metrics)
+	// 4. Load the recorded equity curve
+	snapshots, err := h.orderManager.GetEquitySnapshots(since)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to retrieve equity history: %v", err))
+		return
+	}
+	if snapshots == nil {
+		snapshots = []models.EquitySnapshot{}
+	}
+
+	// 5. Return JSON
+	writeJSON(w, http.StatusOK, PerformanceResponse{
+		PerformanceMetrics: metrics,
+		EquityCurve:        snapshots,
+	})
+}
+
+// StrategyPerformanceResponse lists live trading performance per strategy.
+type StrategyPerformanceResponse struct {
+	Strategies []execution.StrategyPerformance `json:"strategies"`
+}
+
+// GetStrategyPerformanceHandler returns realized performance and open
+// exposure for each strategy, with hand-placed orders grouped as "manual".
+//
+// @Summary      Get Performance by Strategy
+// @Description  Aggregates the trade history per strategy: trades, win rate, total and average P&L, fees and open exposure.
+// @Tags         portfolio
+// @Accept       json
+// @Produce      json
+// @Param        start  query     string  false  "Earliest fill counted (RFC3339)"
+// @Param        end    query     string  false  "Latest fill counted (RFC3339)"
+// @Success      200  {object}  StrategyPerformanceResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /portfolio/by-strategy [get]
+func (h *Handler) GetStrategyPerformanceHandler(w http.ResponseWriter, r *http.Request) {
+	if h.orderManager == nil {
+		writeError(w, http.StatusServiceUnavailable, "Execution layer not available")
+		return
+	}
+
+	var start, end time.Time
+	if startStr := r.URL.Query().Get("start"); startStr != "" {
+		parsed, err := time.Parse(time.RFC3339, startStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid start time: must be RFC3339")
+			return
+		}
+		start = parsed
+	}
+	if endStr := r.URL.Query().Get("end"); endStr != "" {
+		parsed, err := time.Parse(time.RFC3339, endStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid end time: must be RFC3339")
+			return
+		}
+		end = parsed
+	}
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		writeError(w, http.StatusBadRequest, "Start must not be after end")
+		return
+	}
+
+	rows, err := h.orderManager.StrategyPerformance(start, end)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to compute strategy performance: %v", err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, StrategyPerformanceResponse{Strategies: rows})
 }
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/engine"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/strategies"
+	"github.com/alexherrero/sherwood/backend/tracing"
+)
+
+// SignalsResponse is a page of logged strategy signals.
+type SignalsResponse struct {
+	Signals []models.SignalRecord `json:"signals"`
+	Total   int                   `json:"total"`
+	Page    int                   `json:"page"`
+	Limit   int                   `json:"limit"`
+}
+
+// GetSignalsHandler retrieves logged strategy signals, optionally filtered by
+// symbol, strategy and time range, as a paginated envelope.
+//
+// @Summary      Get Signals
+// @Description  Retrieves a page of logged strategy signals, newest first. Signals are recorded when LOG_SIGNALS is enabled.
+// @Tags         signals
+// @Accept       json
+// @Produce      json
+// @Param        symbol    query     string  false  "Symbol"
+// @Param        strategy  query     string  false  "Strategy name"
+// @Param        start     query     string  false  "Earliest signal time (RFC3339)"
+// @Param        end       query     string  false  "Latest signal time (RFC3339)"
+// @Param        limit     query     int     false  "Limit (default 50)"
+// @Param        page      query     int     false  "Page (default 1)"
+// @Success      200  {object}  SignalsResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /signals [get]
+func (h *Handler) GetSignalsHandler(w http.ResponseWriter, r *http.Request) {
+	if h.engine == nil {
+		writeError(w, http.StatusServiceUnavailable, "Trading engine not available")
+		return
+	}
+
+	limit := getQueryInt(r, "limit", 50)
+	if limit <= 0 {
+		limit = 50
+	}
+	page := getQueryInt(r, "page", 1)
+	if page < 1 {
+		page = 1
+	}
+
+	filter := data.SignalFilter{
+		Strategy: r.URL.Query().Get("strategy"),
+		Limit:    limit,
+		Offset:   (page - 1) * limit,
+	}
+	if symbol := r.URL.Query().Get("symbol"); symbol != "" {
+		filter.Symbol = h.canonicalSymbol(symbol)
+	}
+	if startStr := r.URL.Query().Get("start"); startStr != "" {
+		parsed, err := time.Parse(time.RFC3339, startStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid start time: must be RFC3339")
+			return
+		}
+		filter.Start = parsed
+	}
+	if endStr := r.URL.Query().Get("end"); endStr != "" {
+		parsed, err := time.Parse(time.RFC3339, endStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid end time: must be RFC3339")
+			return
+		}
+		filter.End = parsed
+	}
+	if !filter.Start.IsZero() && !filter.End.IsZero() && filter.End.Before(filter.Start) {
+		writeError(w, http.StatusBadRequest, "Start must not be after end")
+		return
+	}
+
+	signals, total, err := h.engine.QuerySignals(filter)
+	if errors.Is(err, engine.ErrSignalLogUnavailable) {
+		writeError(w, http.StatusNotImplemented, "Signal log not initialized")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve signals")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, SignalsResponse{
+		Signals: signals,
+		Total:   total,
+		Page:    page,
+		Limit:   limit,
+	})
+}
+
+// SignalIngestRequest defines the payload for a signal from an external
+// system. A price makes the order a limit order; a quantity overrides the
+// engine's default size.
+type SignalIngestRequest struct {
+	Symbol   string  `json:"symbol" validate:"required,min=1,max=20"`
+	Side     string  `json:"side" validate:"required,oneof=buy sell"`
+	Price    float64 `json:"price,omitempty" validate:"gte=0"`
+	Quantity float64 `json:"quantity,omitempty" validate:"gte=0,lte=1000000"`
+	Tag      string  `json:"tag,omitempty" validate:"max=50"` // Added to the order's tags, e.g. the external model's name
+	Reason   string  `json:"reason,omitempty" validate:"max=200"`
+}
+
+// SignalIngestResponse acknowledges a queued external signal.
+type SignalIngestResponse struct {
+	Signal models.Signal `json:"signal"`
+	// Queued is the number of signals waiting for the symbol, including this one.
+	Queued int `json:"queued"`
+}
+
+// IngestSignalHandler queues a buy or sell from an external system for the
+// webhook_signals strategy, which hands it to the engine on the symbol's next
+// tick. It requires API_KEY to be set, even though other routes allow
+// unauthenticated access without one, since it places orders.
+//
+// @Summary      Ingest Signal
+// @Description  Queues an external buy or sell signal for execution on the symbol's next engine tick.
+// @Tags         signals
+// @Accept       json
+// @Produce      json
+// @Param        signal  body      SignalIngestRequest  true  "Signal"
+// @Success      202  {object}  SignalIngestResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Failure      429  {object}  ErrorResponse
+// @Router       /signals/ingest [post]
+func (h *Handler) IngestSignalHandler(w http.ResponseWriter, r *http.Request) {
+	if h.config == nil || h.config.CurrentAPIKey() == "" {
+		writeError(w, http.StatusForbidden, "Signal ingest requires API_KEY to be set")
+		return
+	}
+	if h.engine == nil {
+		writeError(w, http.StatusServiceUnavailable, "Trading engine not available")
+		return
+	}
+
+	var req SignalIngestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if valErr := validateStruct(req); valErr != nil {
+		writeValidationError(w, valErr)
+		return
+	}
+
+	// A limit signal's value is known now, so an oversized one is refused
+	// here rather than dropped by order sizing on the next tick
+	if maxNotional := h.config.OrderMaxNotional; maxNotional > 0 && req.Price*req.Quantity > maxNotional {
+		writeError(w, http.StatusUnprocessableEntity,
+			fmt.Sprintf("Signal value %.2f exceeds ORDER_MAX_NOTIONAL %.2f", req.Price*req.Quantity, maxNotional))
+		return
+	}
+
+	symbol := h.canonicalSymbol(req.Symbol)
+	if !h.engine.Trades(symbol) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Symbol %s is not traded by the engine", symbol))
+		return
+	}
+
+	strategy, ok := h.registry.Get(strategies.WebhookSignalsName)
+	webhook, isWebhook := strategy.(*strategies.WebhookSignalStrategy)
+	if !ok || !isWebhook || !h.registry.Enabled(strategies.WebhookSignalsName) {
+		writeError(w, http.StatusConflict, "The webhook_signals strategy is not enabled", "STRATEGY_NOT_ENABLED")
+		return
+	}
+
+	tags := models.Tags{models.TagExternalSignal}
+	if req.Tag != "" {
+		tags = append(tags, req.Tag)
+	}
+	reason := req.Reason
+	if reason == "" {
+		reason = "External signal"
+	}
+	signal := models.Signal{
+		Symbol:   symbol,
+		Type:     models.SignalType(req.Side),
+		Strength: models.SignalStrengthStrong,
+		Price:    req.Price,
+		Quantity: req.Quantity,
+		Reason:   reason,
+		Tags:     tags,
+	}
+	queued, err := webhook.Enqueue(signal)
+	if errors.Is(err, strategies.ErrSignalQueueFull) {
+		writeError(w, http.StatusTooManyRequests, err.Error(), "SIGNAL_QUEUE_FULL")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	logger := tracing.Logger(r.Context())
+	logger.Info().
+		Str("symbol", symbol).
+		Str("side", req.Side).
+		Float64("price", req.Price).
+		Float64("quantity", req.Quantity).
+		Str("tag", req.Tag).
+		Int("queued", queued).
+		Msg("External signal queued")
+
+	signal.StrategyName = webhook.Name()
+	writeJSON(w, http.StatusAccepted, SignalIngestResponse{Signal: signal, Queued: queued})
+}
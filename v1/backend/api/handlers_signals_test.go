@@ -0,0 +1,168 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/config"
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/engine"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/strategies"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetSignalsHandler verifies filtering, pagination and error handling.
+func TestGetSignalsHandler(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	store := data.NewSignalStore(db)
+	base := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	require.NoError(t, store.SaveSignals([]models.SignalRecord{
+		{Timestamp: base, Symbol: "AAPL", Type: models.SignalBuy, StrategyName: "ma_crossover", Executed: true},
+		{Timestamp: base.Add(time.Hour), Symbol: "BTC-USD", Type: models.SignalHold, StrategyName: "ma_crossover"},
+		{Timestamp: base.Add(2 * time.Hour), Symbol: "AAPL", Type: models.SignalSell, StrategyName: "rsi_momentum"},
+	}))
+
+	registry := strategies.NewRegistry()
+	eng := engine.NewTradingEngine(new(MockDataProvider), registry, nil, nil, nil, time.Hour, time.Hour, false)
+	eng.SetSignalLog(store, false)
+
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost:3000"}}
+	router := NewRouter(cfg, registry, new(MockDataProvider), nil, eng, nil, nil)
+
+	get := func(query string) (*httptest.ResponseRecorder, SignalsResponse) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/signals"+query, nil))
+		var resp SignalsResponse
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		}
+		return rec, resp
+	}
+
+	t.Run("All", func(t *testing.T) {
+		rec, resp := get("")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 3, resp.Total)
+		assert.Equal(t, 50, resp.Limit)
+		require.Len(t, resp.Signals, 3)
+		assert.Equal(t, models.SignalSell, resp.Signals[0].Type)
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		_, resp := get("?symbol=aapl&strategy=ma_crossover")
+		assert.Equal(t, 1, resp.Total)
+		require.Len(t, resp.Signals, 1)
+		assert.True(t, resp.Signals[0].Executed)
+
+		_, resp = get("?start=2026-03-02T15:30:00Z&end=2026-03-02T16:30:00Z")
+		assert.Equal(t, 1, resp.Total)
+		require.Len(t, resp.Signals, 1)
+		assert.Equal(t, "BTC-USD", resp.Signals[0].Symbol)
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		_, resp := get("?limit=2&page=2")
+		assert.Equal(t, 3, resp.Total)
+		assert.Equal(t, 2, resp.Page)
+		require.Len(t, resp.Signals, 1)
+		assert.Equal(t, models.SignalBuy, resp.Signals[0].Type)
+	})
+
+	t.Run("InvalidTime", func(t *testing.T) {
+		rec, _ := get("?start=yesterday")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		rec, _ = get("?start=2026-03-03T00:00:00Z&end=2026-03-02T00:00:00Z")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		bare := engine.NewTradingEngine(new(MockDataProvider), registry, nil, nil, nil, time.Hour, time.Hour, false)
+		rec := httptest.NewRecorder()
+		NewRouter(cfg, registry, new(MockDataProvider), nil, bare, nil, nil).
+			ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/signals", nil))
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+}
+
+// TestIngestSignalHandler verifies external signals are queued on the
+// webhook strategy and the endpoint's guards.
+func TestIngestSignalHandler(t *testing.T) {
+	registry := strategies.NewRegistry()
+	webhook := strategies.NewWebhookSignalStrategy()
+	require.NoError(t, webhook.Init(map[string]interface{}{"max_queued": 1}))
+	eng := engine.NewTradingEngine(new(MockDataProvider), registry, nil, nil, []string{"AAPL"}, time.Hour, time.Hour, false)
+
+	cfg := &config.Config{APIKey: "secret123", AllowedOrigins: []string{"http://localhost:3000"}, OrderMaxNotional: 100000}
+	router := NewRouter(cfg, registry, new(MockDataProvider), nil, eng, nil, nil)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/signals/ingest", strings.NewReader(body))
+		req.Header.Set("X-Sherwood-API-Key", "secret123")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	buy := `{"symbol":"aapl","side":"buy","quantity":5,"tag":"tv-alert"}`
+
+	t.Run("StrategyNotRegistered", func(t *testing.T) {
+		rec := post(buy)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Contains(t, rec.Body.String(), "STRATEGY_NOT_ENABLED")
+	})
+
+	require.NoError(t, registry.Register(webhook))
+
+	t.Run("StrategyDisabled", func(t *testing.T) {
+		require.True(t, registry.DisableStrategy(strategies.WebhookSignalsName))
+		defer registry.EnableStrategy(strategies.WebhookSignalsName)
+		assert.Equal(t, http.StatusConflict, post(buy).Code)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, post(`{`).Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, post(`{"symbol":"AAPL","side":"hold"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, post(`{"symbol":"MSFT","side":"buy"}`).Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, post(`{"symbol":"AAPL","side":"buy","quantity":2000000}`).Code)
+
+		rec := post(`{"symbol":"AAPL","side":"buy","quantity":1000,"price":150}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "ORDER_MAX_NOTIONAL")
+		assert.Empty(t, webhook.Pending())
+	})
+
+	t.Run("Queued", func(t *testing.T) {
+		rec := post(buy)
+		require.Equal(t, http.StatusAccepted, rec.Code)
+		var resp SignalIngestResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, 1, resp.Queued)
+		assert.Equal(t, "AAPL", resp.Signal.Symbol)
+		assert.Equal(t, models.SignalBuy, resp.Signal.Type)
+		assert.Equal(t, strategies.WebhookSignalsName, resp.Signal.StrategyName)
+		assert.Equal(t, models.Tags{models.TagExternalSignal, "tv-alert"}, resp.Signal.Tags)
+		assert.Equal(t, map[string]int{"AAPL": 1}, webhook.Pending())
+	})
+
+	t.Run("QueueFull", func(t *testing.T) {
+		rec := post(buy)
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Contains(t, rec.Body.String(), "SIGNAL_QUEUE_FULL")
+	})
+
+	t.Run("RequiresAPIKey", func(t *testing.T) {
+		open := NewRouter(&config.Config{AllowedOrigins: []string{"http://localhost:3000"}}, registry, new(MockDataProvider), nil, eng, nil, nil)
+		rec := httptest.NewRecorder()
+		open.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/signals/ingest", strings.NewReader(buy)))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
@@ -1,9 +1,14 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 
+	"github.com/alexherrero/sherwood/backend/strategies"
 	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
 )
 
 // ListStrategiesHandler returns all available trading strategies.
@@ -17,6 +22,7 @@ func (h *Handler) ListStrategiesHandler(w http.ResponseWriter, r *http.Request)
 				"name":        strategy.Name(),
 				"description": strategy.Description(),
 				"parameters":  strategy.GetParameters(),
+				"enabled":     h.registry.Enabled(name),
 			})
 		}
 	}
@@ -39,5 +45,110 @@ func (h *Handler) GetStrategyHandler(w http.ResponseWriter, r *http.Request) {
 		"name":        strategy.Name(),
 		"description": strategy.Description(),
 		"parameters":  strategy.GetParameters(),
+		"enabled":     h.registry.Enabled(name),
+	})
+}
+
+// UpdateStrategyRequest defines the payload for enabling or disabling a strategy.
+type UpdateStrategyRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// UpdateStrategyHandler enables or disables a strategy at runtime. A disabled
+// strategy stays registered and listed but the engine skips it, so it
+// produces no signals. The state is not persisted.
+func (h *Handler) UpdateStrategyHandler(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+
+	var req UpdateStrategyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Enabled == nil {
+		writeError(w, http.StatusBadRequest, "enabled is required")
+		return
+	}
+
+	var found bool
+	if *req.Enabled {
+		found = h.registry.EnableStrategy(name)
+	} else {
+		found = h.registry.DisableStrategy(name)
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, "Strategy not found")
+		return
+	}
+
+	log.Info().Str("strategy", name).Bool("enabled", *req.Enabled).Msg("Strategy state changed")
+	writeJSON(w, http.StatusOK, map[string]interface{}{"name": name, "enabled": *req.Enabled})
+}
+
+// ValidateStrategyRequest is the payload for checking a strategy config.
+type ValidateStrategyRequest struct {
+	StrategyConfig map[string]interface{} `json:"strategy_config"`
+}
+
+// ValidateStrategyResponse reports a valid config and the parameter values
+// it resolves to, defaults included.
+type ValidateStrategyResponse struct {
+	Valid           bool                   `json:"valid"`
+	Strategy        string                 `json:"strategy"`
+	EffectiveParams map[string]interface{} `json:"effective_params"`
+}
+
+// ValidateStrategyHandler checks a strategy config without running a
+// backtest. The config is checked against the parameter schema, then a fresh
+// instance is initialized and validated so cross-parameter rules (such as
+// short_period < long_period) are applied too. No data is fetched. An empty
+// body validates the defaults.
+func (h *Handler) ValidateStrategyHandler(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	registered, ok := h.registry.Get(name)
+	if !ok {
+		writeError(w, http.StatusNotFound, "Strategy not found")
+		return
+	}
+
+	var req ValidateStrategyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	params := registered.GetParameters()
+	if details := strategies.ValidateParameters(params, req.StrategyConfig); len(details) > 0 {
+		writeJSON(w, http.StatusBadRequest, APIError{
+			Error:   "Invalid strategy parameters",
+			Code:    "VALIDATION_ERROR",
+			Details: details,
+		})
+		return
+	}
+
+	// Never Init the registered instance: it may be the one the engine runs
+	strategy, err := strategies.NewStrategyByName(name)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Strategy cannot be instantiated for validation")
+		return
+	}
+	err = strategy.Init(req.StrategyConfig)
+	if err == nil {
+		err = strategy.Validate()
+	}
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, APIError{
+			Error:   "Invalid strategy config",
+			Code:    "VALIDATION_ERROR",
+			Details: map[string]string{"strategy_config": err.Error()},
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ValidateStrategyResponse{
+		Valid:           true,
+		Strategy:        name,
+		EffectiveParams: strategies.EffectiveParameters(params, req.StrategyConfig),
 	})
 }
@@ -2,18 +2,25 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/alexherrero/sherwood/backend/backtesting"
 	"github.com/alexherrero/sherwood/backend/config"
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/data/providers"
 	"github.com/alexherrero/sherwood/backend/engine"
 	"github.com/alexherrero/sherwood/backend/execution"
 	"github.com/alexherrero/sherwood/backend/models"
 	"github.com/alexherrero/sherwood/backend/strategies"
+	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -92,6 +99,144 @@ func TestHealthHandler(t *testing.T) {
 	assert.Contains(t, response, "timestamp")
 }
 
+// TestHealthHandler_ProbesProvider verifies the canary probe and its cache.
+func TestHealthHandler_ProbesProvider(t *testing.T) {
+	cfg := &config.Config{TradingMode: "test", HealthCanarySymbol: "SPY"}
+	mockProvider := new(MockDataProvider)
+	mockProvider.On("GetLatestPrice", "SPY").Return(450.0, nil).Once()
+
+	handler := NewHandler(nil, mockProvider, cfg, nil, nil, nil, nil)
+
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		handler.HealthHandler(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response struct {
+			Status string                 `json:"status"`
+			Checks map[string]healthCheck `json:"checks"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "ok", response.Status)
+		assert.Equal(t, "ok", response.Checks["data_provider"].Status)
+		assert.Equal(t, "disabled", response.Checks["execution"].Status)
+	}
+
+	// Probe result is cached between polls
+	mockProvider.AssertNumberOfCalls(t, "GetLatestPrice", 1)
+}
+
+// TestHealthHandler_Down verifies a failed dependency returns 503.
+func TestHealthHandler_Down(t *testing.T) {
+	t.Run("ProviderError", func(t *testing.T) {
+		cfg := &config.Config{TradingMode: "test", HealthCanarySymbol: "SPY"}
+		mockProvider := new(MockDataProvider)
+		mockProvider.On("GetLatestPrice", "SPY").Return(0.0, fmt.Errorf("upstream unavailable"))
+
+		handler := NewHandler(nil, mockProvider, cfg, nil, nil, nil, nil)
+
+		rec := httptest.NewRecorder()
+		handler.HealthHandler(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+		var response struct {
+			Status string                 `json:"status"`
+			Checks map[string]healthCheck `json:"checks"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "down", response.Status)
+		assert.Equal(t, "down", response.Checks["data_provider"].Status)
+		assert.Equal(t, "upstream unavailable", response.Checks["data_provider"].Message)
+	})
+
+	t.Run("BrokerDisconnected", func(t *testing.T) {
+		cfg := &config.Config{TradingMode: "test"}
+		broker := execution.NewPaperBroker(10000)
+		orderManager := execution.NewOrderManager(broker, nil, nil, nil)
+
+		handler := NewHandler(nil, nil, cfg, orderManager, nil, nil, nil)
+
+		rec := httptest.NewRecorder()
+		handler.HealthHandler(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+		require.NoError(t, broker.Connect())
+		rec = httptest.NewRecorder()
+		handler.HealthHandler(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+// TestLivezHandler verifies liveness ignores dependency failures and needs no auth.
+func TestLivezHandler(t *testing.T) {
+	cfg := &config.Config{
+		TradingMode:        "test",
+		APIKey:             "test-api-key",
+		HealthCanarySymbol: "SPY",
+		AllowedOrigins:     []string{"http://localhost:3000"},
+	}
+	mockProvider := new(MockDataProvider)
+	router := NewRouter(cfg, nil, mockProvider, nil, nil, nil, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+
+	// The provider is never probed
+	mockProvider.AssertNotCalled(t, "GetLatestPrice", mock.Anything)
+}
+
+// TestReadyzHandler verifies readiness reflects the broker, database and provider.
+func TestReadyzHandler(t *testing.T) {
+	type readyResponse struct {
+		Status string                 `json:"status"`
+		Checks map[string]healthCheck `json:"checks"`
+	}
+
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	broker := execution.NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	orderManager := execution.NewOrderManager(broker, nil, data.NewOrderStore(db), nil)
+
+	cfg := &config.Config{
+		TradingMode:        "test",
+		APIKey:             "test-api-key",
+		HealthCanarySymbol: "SPY",
+		AllowedOrigins:     []string{"http://localhost:3000"},
+	}
+	mockProvider := new(MockDataProvider)
+	mockProvider.On("GetLatestPrice", "SPY").Return(450.0, nil)
+	router := NewRouter(cfg, nil, mockProvider, orderManager, nil, nil, nil)
+
+	// Public route, no API key
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var response readyResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "ready", response.Status)
+	assert.Equal(t, "ok", response.Checks["database"].Status)
+	assert.Equal(t, "ok", response.Checks["execution"].Status)
+	assert.Equal(t, "ok", response.Checks["data_provider"].Status)
+
+	// An unusable database makes the instance not ready
+	require.NoError(t, db.Close())
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "not_ready", response.Status)
+	assert.Equal(t, "down", response.Checks["database"].Status)
+
+	// Health reports the same checks
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"database"`)
+}
+
 // TestMetricsHandler verifies metrics endpoint.
 func TestMetricsHandler(t *testing.T) {
 	cfg := &config.Config{TradingMode: "test"}
@@ -118,6 +263,27 @@ func TestMetricsHandler(t *testing.T) {
 	assert.Contains(t, memory, "num_gc")
 }
 
+// TestPrometheusMetricsHandler verifies the scrape endpoint requires the API
+// key and serves the text exposition format.
+func TestPrometheusMetricsHandler(t *testing.T) {
+	cfg := &config.Config{TradingMode: "test", APIKey: "test-key", AllowedOrigins: []string{"http://localhost:3000"}}
+	router := NewRouter(cfg, strategies.NewRegistry(), new(MockDataProvider), nil, nil, nil, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("X-Sherwood-API-Key", "test-key")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "# TYPE sherwood_orders_placed_total counter")
+	assert.Contains(t, rec.Body.String(), "# TYPE go_goroutines gauge")
+}
+
 // TestListStrategiesHandler verifies strategies list endpoint.
 func TestListStrategiesHandler(t *testing.T) {
 	handler, _, _ := setupTestHandler(t)
@@ -161,6 +327,67 @@ func TestGetStrategyHandler(t *testing.T) {
 	err = json.Unmarshal(rec.Body.Bytes(), &response)
 	require.NoError(t, err)
 	assert.Equal(t, "ma_crossover", response["name"])
+
+	// Parameters carry the schema forms are built from
+	params := response["parameters"].(map[string]interface{})
+	shortPeriod := params["short_period"].(map[string]interface{})
+	assert.Equal(t, 2.0, shortPeriod["min"])
+	assert.Equal(t, 50.0, shortPeriod["max"])
+	assert.Equal(t, 1.0, shortPeriod["step"])
+	assert.Equal(t, true, response["enabled"])
+}
+
+// TestUpdateStrategyHandler verifies strategies can be disabled and enabled
+// at runtime and stay listed while disabled.
+func TestUpdateStrategyHandler(t *testing.T) {
+	cfg := &config.Config{
+		AllowedOrigins: []string{"http://localhost:3000"},
+	}
+	registry := strategies.NewRegistry()
+	require.NoError(t, registry.Register(strategies.NewMACrossover()))
+	router := NewRouter(cfg, registry, new(MockDataProvider), nil, nil, nil, nil)
+
+	patch := func(name, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/api/v1/strategies/"+name, strings.NewReader(body)))
+		return rec
+	}
+
+	rec := patch("ma_crossover", `{"enabled": false}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"name": "ma_crossover", "enabled": false}`, rec.Body.String())
+	assert.False(t, registry.Enabled("ma_crossover"))
+
+	list := httptest.NewRecorder()
+	router.ServeHTTP(list, httptest.NewRequest(http.MethodGet, "/api/v1/strategies", nil))
+	require.Equal(t, http.StatusOK, list.Code)
+	var response struct {
+		Strategies []struct {
+			Name    string `json:"name"`
+			Enabled bool   `json:"enabled"`
+		} `json:"strategies"`
+	}
+	require.NoError(t, json.Unmarshal(list.Body.Bytes(), &response))
+	require.Len(t, response.Strategies, 1)
+	assert.False(t, response.Strategies[0].Enabled)
+
+	rec = patch("ma_crossover", `{"enabled": true}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, registry.Enabled("ma_crossover"))
+
+	assert.Equal(t, http.StatusBadRequest, patch("ma_crossover", `{}`).Code)
+	assert.Equal(t, http.StatusBadRequest, patch("ma_crossover", `not json`).Code)
+	assert.Equal(t, http.StatusNotFound, patch("missing", `{"enabled": false}`).Code)
+}
+
+// backtestBars returns n daily bars, enough for the default strategies to
+// run when n exceeds their longest period.
+func backtestBars(n int) []models.OHLCV {
+	bars := make([]models.OHLCV, n)
+	for i := range bars {
+		bars[i] = models.OHLCV{Timestamp: time.Now().AddDate(0, 0, i-n), Symbol: "AAPL", Close: 100 + float64(i%10)}
+	}
+	return bars
 }
 
 // TestRunBacktestHandler verifies backtest submission endpoint.
@@ -168,10 +395,7 @@ func TestRunBacktestHandler(t *testing.T) {
 	handler, mockProvider, _ := setupTestHandler(t)
 
 	// Mock data provider response
-	mockData := []models.OHLCV{
-		{Timestamp: time.Now(), Close: 100},
-		{Timestamp: time.Now().Add(time.Hour), Close: 101},
-	}
+	mockData := backtestBars(40)
 	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").Return(mockData, nil)
 
 	payload := RunBacktestRequest{
@@ -182,7 +406,7 @@ func TestRunBacktestHandler(t *testing.T) {
 		InitialCapital: 10000,
 	}
 	body, _ := json.Marshal(payload)
-	req := httptest.NewRequest(http.MethodPost, "/api/v1/backtests", bytes.NewReader(body))
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/backtests?sync=true", bytes.NewReader(body))
 	rec := httptest.NewRecorder()
 
 	handler.RunBacktestHandler(rec, req)
@@ -197,6 +421,43 @@ func TestRunBacktestHandler(t *testing.T) {
 	mockProvider.AssertExpectations(t)
 }
 
+// TestRunBacktestHandler_InsufficientData verifies data too short for the
+// strategy's periods, or the configured floor, is rejected with 422.
+func TestRunBacktestHandler_InsufficientData(t *testing.T) {
+	handler, mockProvider, _ := setupTestHandler(t)
+	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").Return(backtestBars(15), nil)
+
+	run := func(strategyConfig map[string]interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(RunBacktestRequest{
+			Strategy:       "ma_crossover",
+			Symbol:         "AAPL",
+			Start:          time.Now().AddDate(0, -1, 0),
+			End:            time.Now(),
+			InitialCapital: 10000,
+			StrategyConfig: strategyConfig,
+		})
+		rec := httptest.NewRecorder()
+		handler.RunBacktestHandler(rec, httptest.NewRequest(http.MethodPost, "/api/v1/backtests?sync=true", bytes.NewReader(body)))
+		return rec
+	}
+
+	// The default long period of 20 needs 21 bars
+	rec := run(nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	var apiErr APIError
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &apiErr))
+	assert.Equal(t, "INSUFFICIENT_DATA", apiErr.Code)
+	assert.Equal(t, "Not enough data: ma_crossover needs at least 21 bars, but AAPL has 15 in the requested range", apiErr.Error)
+
+	rec = run(map[string]interface{}{"short_period": 5, "long_period": 10})
+	assert.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+
+	handler.config = &config.Config{BacktestMinBars: 30}
+	rec = run(map[string]interface{}{"short_period": 5, "long_period": 10})
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "needs at least 30 bars")
+}
+
 // TestGetBacktestResultHandler verifies backtest result endpoint.
 func TestGetBacktestResultHandler(t *testing.T) {
 	// Handle URL params via router integration or manual setup
@@ -216,7 +477,7 @@ func TestGetBacktestResultHandler(t *testing.T) {
 	// Or we can just run a backtest first then get it.
 
 	// Mock data for run
-	mockData := []models.OHLCV{{Timestamp: time.Now(), Close: 100}}
+	mockData := backtestBars(40)
 	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").Return(mockData, nil)
 
 	// 1. Run backtest
@@ -232,7 +493,7 @@ func TestGetBacktestResultHandler(t *testing.T) {
 	require.NoError(t, err)
 
 	body, _ := json.Marshal(payload)
-	runReq := httptest.NewRequest(http.MethodPost, "/api/v1/backtests", bytes.NewReader(body))
+	runReq := httptest.NewRequest(http.MethodPost, "/api/v1/backtests?sync=true", bytes.NewReader(body))
 	runRec := httptest.NewRecorder()
 
 	router.ServeHTTP(runRec, runReq)
@@ -257,6 +518,651 @@ func TestGetBacktestResultHandler(t *testing.T) {
 	assert.Equal(t, id, getResp["id"])
 }
 
+// TestGetBacktestResultHandler_MaxPoints verifies the equity curve is
+// downsampled for charting while the full curve's length is reported.
+func TestGetBacktestResultHandler_MaxPoints(t *testing.T) {
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost:3000"}}
+	registry := strategies.NewRegistry()
+	require.NoError(t, registry.Register(strategies.NewMACrossover()))
+	mockProvider := new(MockDataProvider)
+	router := NewRouter(cfg, registry, mockProvider, nil, nil, nil, nil)
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	bars := make([]models.OHLCV, 3000)
+	for i := range bars {
+		price := 100 + float64(i%50)
+		bars[i] = models.OHLCV{Timestamp: start.AddDate(0, 0, i), Symbol: "AAPL", Open: price, High: price, Low: price, Close: price}
+	}
+	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").Return(bars, nil)
+
+	payload := RunBacktestRequest{
+		Strategy:       "ma_crossover",
+		Symbol:         "AAPL",
+		Start:          start,
+		End:            start.AddDate(0, 0, len(bars)),
+		InitialCapital: 10000,
+	}
+	body, _ := json.Marshal(payload)
+	runRec := httptest.NewRecorder()
+	router.ServeHTTP(runRec, httptest.NewRequest(http.MethodPost, "/api/v1/backtests?sync=true", bytes.NewReader(body)))
+	require.Equal(t, http.StatusAccepted, runRec.Code, runRec.Body.String())
+	var runResp map[string]interface{}
+	require.NoError(t, json.Unmarshal(runRec.Body.Bytes(), &runResp))
+	id := runResp["id"].(string)
+
+	get := func(query string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/backtests/"+id+query, nil))
+		var resp map[string]interface{}
+		_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+		return rec, resp
+	}
+
+	rec, resp := get("?max_points=100")
+	require.Equal(t, http.StatusOK, rec.Code)
+	total := int(resp["chart_data_total"].(float64))
+	assert.Greater(t, total, 1000)
+	assert.Len(t, resp["chart_data"], 100)
+
+	rec, resp = get("")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, resp["chart_data"], 1000)
+
+	rec, resp = get("?max_points=0")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, resp["chart_data"], total)
+
+	rec, _ = get("?max_points=-1")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// TestGetBacktestReportHandler verifies report export in JSON and Markdown.
+func TestGetBacktestReportHandler(t *testing.T) {
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost:3000"}}
+	registry := strategies.NewRegistry()
+	require.NoError(t, registry.Register(strategies.NewMACrossover()))
+	mockProvider := new(MockDataProvider)
+	router := NewRouter(cfg, registry, mockProvider, nil, nil, nil, nil)
+
+	mockData := backtestBars(40)
+	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").Return(mockData, nil)
+
+	payload := RunBacktestRequest{
+		Strategy:       "ma_crossover",
+		Symbol:         "AAPL",
+		Start:          time.Now().Add(-24 * time.Hour),
+		End:            time.Now(),
+		InitialCapital: 10000,
+	}
+	body, _ := json.Marshal(payload)
+	runRec := httptest.NewRecorder()
+	router.ServeHTTP(runRec, httptest.NewRequest(http.MethodPost, "/api/v1/backtests?sync=true", bytes.NewReader(body)))
+	require.Equal(t, http.StatusAccepted, runRec.Code, runRec.Body.String())
+
+	var runResp map[string]interface{}
+	require.NoError(t, json.Unmarshal(runRec.Body.Bytes(), &runResp))
+	id := runResp["id"].(string)
+
+	t.Run("JSON", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/backtests/"+id+"/report", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, id, resp["id"])
+		assert.Contains(t, resp, "metrics")
+		assert.Contains(t, resp, "trades")
+		assert.Contains(t, resp, "equity_curve")
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/backtests/"+id+"/report?format=md", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/markdown; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), "# Backtest Report: "+id)
+		assert.Contains(t, rec.Body.String(), "| Symbol | AAPL |")
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/backtests/"+id+"/report?format=pdf", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/backtests/bt-missing/report", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+// TestGetBacktestMonteCarloHandler verifies Monte Carlo resampling of a
+// completed backtest and the iterations bound.
+func TestGetBacktestMonteCarloHandler(t *testing.T) {
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost:3000"}}
+	h := NewHandler(strategies.NewRegistry(), nil, cfg, nil, nil, nil, nil)
+	router := chi.NewRouter()
+	router.Get("/backtests/{id}/montecarlo", h.GetBacktestMonteCarloHandler)
+
+	job := h.backtestJobs.RunSync(func() (*backtesting.BacktestResult, error) {
+		return &backtesting.BacktestResult{
+			Config: backtesting.BacktestConfig{Symbol: "AAPL", InitialCapital: 10000},
+			Trades: []backtesting.SimulatedTrade{{NetPnL: 500}, {NetPnL: -200}, {NetPnL: 300}},
+		}, nil
+	})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/backtests/"+path, nil))
+		return rec
+	}
+
+	rec := get(job.ID + "/montecarlo?iterations=200")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp backtesting.MonteCarloResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, job.ID, resp.BacktestID)
+	assert.Equal(t, 200, resp.Iterations)
+	assert.Equal(t, 3, resp.Trades)
+	assert.LessOrEqual(t, resp.FinalReturn.P5, resp.FinalReturn.P95)
+
+	rec = get(job.ID + "/montecarlo")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, defaultMonteCarloIterations, resp.Iterations)
+
+	assert.Equal(t, http.StatusBadRequest, get(job.ID+"/montecarlo?iterations=0").Code)
+	assert.Equal(t, http.StatusBadRequest, get(job.ID+"/montecarlo?iterations=10001").Code)
+	assert.Equal(t, http.StatusNotFound, get("bt-missing/montecarlo").Code)
+
+	empty := h.backtestJobs.RunSync(func() (*backtesting.BacktestResult, error) {
+		return &backtesting.BacktestResult{Config: backtesting.BacktestConfig{InitialCapital: 10000}}, nil
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, get(empty.ID+"/montecarlo").Code)
+
+	release := make(chan struct{})
+	defer close(release)
+	pending, err := h.backtestJobs.Submit(func() (*backtesting.BacktestResult, error) {
+		<-release
+		return &backtesting.BacktestResult{}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, get(pending.ID+"/montecarlo").Code)
+}
+
+// TestRunSweepHandler verifies parameter sweeps run every combination, rank
+// the results, run as one job and enforce the combination and candle caps.
+func TestRunSweepHandler(t *testing.T) {
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost:3000"}, MaxSweepCombinations: 4, MaxHistoryCandles: 100}
+	registry := strategies.NewRegistry()
+	require.NoError(t, registry.Register(strategies.NewMACrossover()))
+	mockProvider := new(MockDataProvider)
+	router := NewRouter(cfg, registry, mockProvider, nil, nil, nil, nil)
+
+	mockData := make([]models.OHLCV, 60)
+	for i := range mockData {
+		mockData[i] = models.OHLCV{Timestamp: time.Now().AddDate(0, 0, i-60), Symbol: "AAPL", Close: 100 + float64(i%10)}
+	}
+	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").Return(mockData, nil)
+
+	postTo := func(path string, payload SweepRequest) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body)))
+		return rec
+	}
+	post := func(payload SweepRequest) *httptest.ResponseRecorder {
+		return postTo("/api/v1/backtests/sweep?sync=true", payload)
+	}
+	base := SweepRequest{
+		Strategy:       "ma_crossover",
+		Symbol:         "aapl",
+		Start:          time.Now().AddDate(0, 0, -60),
+		End:            time.Now(),
+		InitialCapital: 10000,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		req := base
+		req.Parameters = map[string][]interface{}{
+			"short_period": {3, 5},
+			"long_period":  {10, 20},
+		}
+		req.RankBy = "total_return"
+		rec := post(req)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var resp SweepResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "AAPL", resp.Symbol)
+		assert.Equal(t, 4, resp.Combinations)
+		assert.Equal(t, "total_return", resp.RankBy)
+		require.Len(t, resp.Results, 4)
+		for i, result := range resp.Results {
+			assert.Equal(t, i+1, result.Rank)
+			require.NotNil(t, result.Metrics)
+			assert.Contains(t, result.Params, "short_period")
+			if i > 0 {
+				assert.GreaterOrEqual(t, resp.Results[i-1].Metrics.TotalReturn, result.Metrics.TotalReturn)
+			}
+		}
+	})
+
+	t.Run("Async", func(t *testing.T) {
+		req := base
+		req.Parameters = map[string][]interface{}{"short_period": {3, 5}}
+		rec := postTo("/api/v1/backtests/sweep", req)
+		require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+
+		var queued map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &queued))
+		id, _ := queued["id"].(string)
+		require.NotEmpty(t, id)
+
+		var polled struct {
+			Status string         `json:"status"`
+			Sweep  *SweepResponse `json:"sweep"`
+		}
+		require.Eventually(t, func() bool {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/backtests/"+id, nil))
+			return json.Unmarshal(rec.Body.Bytes(), &polled) == nil && polled.Status == "completed"
+		}, 5*time.Second, 10*time.Millisecond)
+		require.NotNil(t, polled.Sweep)
+		assert.Len(t, polled.Sweep.Results, 2, "the whole sweep is one job")
+
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/backtests/"+id+"/report", nil))
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("TooManyCandles", func(t *testing.T) {
+		req := base
+		req.Start = req.End.AddDate(0, 0, -200)
+		req.Parameters = map[string][]interface{}{"short_period": {3}}
+		rec := postTo("/api/v1/backtests/sweep", req)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "TOO_MANY_CANDLES")
+	})
+
+	t.Run("TooManyCombinations", func(t *testing.T) {
+		req := base
+		req.Parameters = map[string][]interface{}{
+			"short_period": {3, 5, 7},
+			"long_period":  {10, 20},
+		}
+		rec := post(req)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("UnknownParameter", func(t *testing.T) {
+		req := base
+		req.Parameters = map[string][]interface{}{"window": {3, 5}}
+		rec := post(req)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "window")
+	})
+
+	t.Run("InvalidValue", func(t *testing.T) {
+		req := base
+		req.Parameters = map[string][]interface{}{"short_period": {3, "fast"}}
+		rec := post(req)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "short_period")
+	})
+
+	t.Run("UnknownRankMetric", func(t *testing.T) {
+		req := base
+		req.Parameters = map[string][]interface{}{"short_period": {3}}
+		req.RankBy = "luck"
+		rec := post(req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+// renamedStrategy registers a strategy under a name the factory can't build.
+type renamedStrategy struct {
+	strategies.Strategy
+	name string
+}
+
+func (s renamedStrategy) Name() string { return s.name }
+
+// TestCompareBacktestHandler verifies two strategies are backtested on one
+// fetch of the same data and compared.
+func TestCompareBacktestHandler(t *testing.T) {
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost:3000"}}
+	registry := strategies.NewRegistry()
+	require.NoError(t, registry.Register(strategies.NewMACrossover()))
+	require.NoError(t, registry.Register(strategies.NewRSIStrategy()))
+	mockProvider := new(MockDataProvider)
+	router := NewRouter(cfg, registry, mockProvider, nil, nil, nil, nil)
+
+	mockData := make([]models.OHLCV, 60)
+	for i := range mockData {
+		mockData[i] = models.OHLCV{Timestamp: time.Now().AddDate(0, 0, i-60), Symbol: "AAPL", Close: 100 + float64(i%10)}
+	}
+	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").Return(mockData, nil)
+
+	post := func(payload CompareBacktestRequest) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/backtests/compare", bytes.NewReader(body)))
+		return rec
+	}
+	base := CompareBacktestRequest{
+		A:              CompareStrategyRequest{Strategy: "ma_crossover", StrategyConfig: map[string]interface{}{"short_period": 3, "long_period": 10}},
+		B:              CompareStrategyRequest{Strategy: "rsi_momentum"},
+		Symbol:         "aapl",
+		Start:          time.Now().AddDate(0, 0, -60),
+		End:            time.Now(),
+		InitialCapital: 10000,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		rec := post(base)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var resp struct {
+			Symbol       string                        `json:"symbol"`
+			A            backtesting.ComparedRun       `json:"a"`
+			B            backtesting.ComparedRun       `json:"b"`
+			Diff         *backtesting.MetricsDiff      `json:"diff"`
+			EquityCurves []backtesting.ComparisonPoint `json:"equity_curves"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "AAPL", resp.Symbol)
+		assert.Equal(t, "ma_crossover", resp.A.Strategy)
+		assert.Equal(t, "rsi_momentum", resp.B.Strategy)
+		require.NotNil(t, resp.A.Metrics)
+		require.NotNil(t, resp.B.Metrics)
+		require.NotNil(t, resp.Diff)
+		assert.Equal(t, resp.B.Metrics.TotalTrades-resp.A.Metrics.TotalTrades, resp.Diff.TotalTrades)
+		assert.Len(t, resp.EquityCurves, len(mockData)-1)
+
+		// Data is fetched once for both strategies
+		mockProvider.AssertNumberOfCalls(t, "GetHistoricalData", 1)
+	})
+
+	t.Run("UnknownStrategy", func(t *testing.T) {
+		req := base
+		req.B = CompareStrategyRequest{Strategy: "nonexistent"}
+		rec := post(req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "nonexistent")
+	})
+
+	t.Run("InvalidParameters", func(t *testing.T) {
+		req := base
+		req.B = CompareStrategyRequest{Strategy: "ma_crossover", StrategyConfig: map[string]interface{}{"short_period": -1}}
+		rec := post(req)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "b.short_period")
+	})
+
+	t.Run("MissingStrategy", func(t *testing.T) {
+		req := base
+		req.A = CompareStrategyRequest{}
+		rec := post(req)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("StrategyWithoutFactory", func(t *testing.T) {
+		// Registered but not constructible: never fall back to sharing the registered instance
+		require.NoError(t, registry.Register(renamedStrategy{Strategy: strategies.NewMACrossover(), name: "custom"}))
+		req := base
+		req.B = CompareStrategyRequest{Strategy: "custom"}
+		rec := post(req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "cannot be backtested")
+
+		// Webhook signals only arrive live, so they can't be replayed
+		require.NoError(t, registry.Register(strategies.NewWebhookSignalStrategy()))
+		req.B = CompareStrategyRequest{Strategy: strategies.WebhookSignalsName}
+		rec = post(req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "cannot be backtested")
+	})
+
+	t.Run("RangeOverCandleLimit", func(t *testing.T) {
+		req := base
+		req.Start = time.Now().AddDate(-20, 0, 0)
+		rec := post(req)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "TOO_MANY_CANDLES")
+		mockProvider.AssertNumberOfCalls(t, "GetHistoricalData", 1)
+	})
+}
+
+// TestRunBacktestHandler_Async verifies queued backtests can be polled to completion.
+func TestRunBacktestHandler_Async(t *testing.T) {
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost:3000"}}
+	registry := strategies.NewRegistry()
+	require.NoError(t, registry.Register(strategies.NewMACrossover()))
+	mockProvider := new(MockDataProvider)
+	router := NewRouter(cfg, registry, mockProvider, nil, nil, nil, nil)
+
+	mockData := backtestBars(40)
+	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").Return(mockData, nil)
+	mockProvider.On("GetHistoricalData", "FAIL", mock.Anything, mock.Anything, "1d").Return(nil, fmt.Errorf("network error"))
+	mockProvider.On("GetHistoricalData", "GONE", mock.Anything, mock.Anything, "1d").
+		Return(nil, &providers.ProviderError{Provider: "mock", Kind: providers.KindNotFound, Message: "no data"})
+
+	submit := func(symbol string) string {
+		payload := RunBacktestRequest{
+			Strategy:       "ma_crossover",
+			Symbol:         symbol,
+			Start:          time.Now().Add(-24 * time.Hour),
+			End:            time.Now(),
+			InitialCapital: 10000,
+		}
+		body, _ := json.Marshal(payload)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/backtests", bytes.NewReader(body)))
+		require.Equal(t, http.StatusAccepted, rec.Code)
+
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "pending", resp["status"])
+		return resp["id"].(string)
+	}
+
+	poll := func(id string) map[string]interface{} {
+		var resp map[string]interface{}
+		require.Eventually(t, func() bool {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/backtests/"+id, nil))
+			require.Equal(t, http.StatusOK, rec.Code)
+			resp = map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			return resp["status"] == "completed" || resp["status"] == "failed"
+		}, 2*time.Second, 10*time.Millisecond)
+		return resp
+	}
+
+	okID := submit("AAPL")
+	failID := submit("FAIL")
+	assert.NotEqual(t, okID, failID)
+
+	done := poll(okID)
+	assert.Equal(t, "completed", done["status"])
+	assert.Equal(t, okID, done["id"])
+	assert.NotNil(t, done["metrics"])
+
+	failed := poll(failID)
+	assert.Equal(t, "failed", failed["status"])
+	assert.Contains(t, failed["error"], "network error")
+	assert.Equal(t, "INTERNAL_ERROR", failed["error_code"])
+
+	// Provider errors carry the same code a synchronous request would return
+	gone := poll(submit("GONE"))
+	assert.Equal(t, "failed", gone["status"])
+	assert.Equal(t, "PROVIDER_NOT_FOUND", gone["error_code"])
+
+	require.NoError(t, router.Shutdown(context.Background()))
+	body, _ := json.Marshal(RunBacktestRequest{Strategy: "ma_crossover", Symbol: "AAPL",
+		Start: time.Now().Add(-24 * time.Hour), End: time.Now(), InitialCapital: 10000})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/backtests", bytes.NewReader(body)))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "no backtests are queued after shutdown")
+}
+
+// TestValidateStrategyHandler verifies configs are checked against the
+// schema and the strategy's own rules, without fetching data.
+func TestValidateStrategyHandler(t *testing.T) {
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost:3000"}}
+	registry := strategies.NewRegistry()
+	require.NoError(t, registry.Register(strategies.NewMACrossover()))
+	mockProvider := new(MockDataProvider)
+	router := NewRouter(cfg, registry, mockProvider, nil, nil, nil, nil)
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+		return rec
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		rec := post("/api/v1/strategies/ma_crossover/validate", `{"strategy_config": {"short_period": 5}}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var resp ValidateStrategyResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.True(t, resp.Valid)
+		assert.Equal(t, "ma_crossover", resp.Strategy)
+		assert.Equal(t, 5.0, resp.EffectiveParams["short_period"])
+		assert.Equal(t, 20.0, resp.EffectiveParams["long_period"])
+		mockProvider.AssertNotCalled(t, "GetHistoricalData", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("EmptyBodyUsesDefaults", func(t *testing.T) {
+		rec := post("/api/v1/strategies/ma_crossover/validate", "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), `"short_period":10`)
+	})
+
+	t.Run("SchemaViolation", func(t *testing.T) {
+		rec := post("/api/v1/strategies/ma_crossover/validate", `{"strategy_config": {"short_period": "fast", "bogus": 1}}`)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "VALIDATION_ERROR", resp["code"])
+		details := resp["details"].(map[string]interface{})
+		assert.Contains(t, details, "short_period")
+		assert.Equal(t, "Unknown parameter", details["bogus"])
+	})
+
+	t.Run("StrategyRuleViolation", func(t *testing.T) {
+		rec := post("/api/v1/strategies/ma_crossover/validate", `{"strategy_config": {"short_period": 30, "long_period": 20}}`)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "must be less than long_period")
+	})
+
+	t.Run("InvalidBody", func(t *testing.T) {
+		rec := post("/api/v1/strategies/ma_crossover/validate", "{")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("StrategyNotFound", func(t *testing.T) {
+		rec := post("/api/v1/strategies/unknown/validate", "{}")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+// TestRunStrategyBacktestHandler verifies the per-strategy backtest endpoint.
+func TestRunStrategyBacktestHandler(t *testing.T) {
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost:3000"}}
+	registry := strategies.NewRegistry()
+	require.NoError(t, registry.Register(strategies.NewMACrossover()))
+	mockProvider := new(MockDataProvider)
+	router := NewRouter(cfg, registry, mockProvider, nil, nil, nil, nil)
+
+	mockData := backtestBars(40)
+	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").Return(mockData, nil)
+
+	post := func(path string, payload interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body)))
+		return rec
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		rec := post("/api/v1/strategies/ma_crossover/backtest?sync=true", StrategyBacktestRequest{
+			Symbol:         "AAPL",
+			Start:          time.Now().Add(-24 * time.Hour),
+			End:            time.Now(),
+			InitialCapital: 10000,
+			StrategyConfig: map[string]interface{}{"short_period": 5, "long_period": 30},
+		})
+		require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "completed", resp["status"])
+		assert.NotNil(t, resp["metrics"])
+	})
+
+	t.Run("WarmupBars", func(t *testing.T) {
+		// A warm-up covering every bar leaves nothing to trade
+		rec := post("/api/v1/strategies/ma_crossover/backtest?sync=true", StrategyBacktestRequest{
+			Symbol:         "AAPL",
+			Start:          time.Now().Add(-24 * time.Hour),
+			End:            time.Now(),
+			InitialCapital: 10000,
+			WarmupBars:     len(mockData),
+		})
+		assert.Contains(t, rec.Body.String(), "warm-up of 40 bars")
+	})
+
+	t.Run("Async", func(t *testing.T) {
+		rec := post("/api/v1/strategies/ma_crossover/backtest", StrategyBacktestRequest{
+			Symbol:         "AAPL",
+			Start:          time.Now().Add(-24 * time.Hour),
+			End:            time.Now(),
+			InitialCapital: 10000,
+		})
+		require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), "pending")
+	})
+
+	t.Run("StrategyNotFound", func(t *testing.T) {
+		rec := post("/api/v1/strategies/unknown/backtest", StrategyBacktestRequest{})
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("InvalidBody", func(t *testing.T) {
+		rec := post("/api/v1/strategies/ma_crossover/backtest", map[string]interface{}{"symbol": "AAPL"})
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "InitialCapital")
+	})
+
+	t.Run("InvalidParameters", func(t *testing.T) {
+		rec := post("/api/v1/strategies/ma_crossover/backtest", StrategyBacktestRequest{
+			Symbol:         "AAPL",
+			Start:          time.Now().Add(-24 * time.Hour),
+			End:            time.Now(),
+			InitialCapital: 10000,
+			StrategyConfig: map[string]interface{}{"short_period": 1, "window": 3},
+		})
+		require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+		var apiErr APIError
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &apiErr))
+		assert.Equal(t, "Invalid strategy parameters", apiErr.Error)
+		details := apiErr.Details.(map[string]interface{})
+		assert.Contains(t, details["short_period"], "greater than or equal to 2")
+		assert.Equal(t, "Unknown parameter", details["window"])
+	})
+}
+
 // TestRouterIntegration verifies router with dependencies.
 func TestRouterIntegration(t *testing.T) {
 	cfg := &config.Config{
@@ -471,7 +1377,8 @@ func TestPlaceOrderHandler(t *testing.T) {
 			Status:   models.OrderStatusFilled,
 		}
 		mockBroker.On("PlaceOrder", mock.MatchedBy(func(o models.Order) bool {
-			return o.Symbol == "AAPL" && o.Side == models.OrderSideBuy && o.Type == models.OrderTypeMarket && o.Quantity == 10
+			return o.Symbol == "AAPL" && o.Side == models.OrderSideBuy && o.Type == models.OrderTypeMarket && o.Quantity == 10 &&
+				o.Tags.Has(models.TagManual)
 		})).Return(expectedOrder, nil).Once()
 
 		payload := map[string]interface{}{
@@ -491,6 +1398,71 @@ func TestPlaceOrderHandler(t *testing.T) {
 		err := json.Unmarshal(rec.Body.Bytes(), &order)
 		require.NoError(t, err)
 		assert.Equal(t, "test-order-1", order.ID)
+		assert.Equal(t, models.Tags{models.TagManual}, order.Tags)
+	})
+
+	t.Run("Tags", func(t *testing.T) {
+		mockBroker.On("PlaceOrder", mock.MatchedBy(func(o models.Order) bool {
+			return o.Symbol == "MSFT"
+		})).Return(&models.Order{ID: "test-order-3", Symbol: "MSFT"}, nil).Once()
+
+		payload := map[string]interface{}{
+			"symbol":   "MSFT",
+			"side":     "buy",
+			"type":     "market",
+			"quantity": 1,
+			"tags":     []string{"hedge", "manual"},
+		}
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/execution/orders", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.PlaceOrderHandler(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var order models.Order
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &order))
+		assert.Equal(t, models.Tags{models.TagManual, "hedge"}, order.Tags)
+	})
+
+	t.Run("LimitWithTimeInForce", func(t *testing.T) {
+		mockBroker.On("PlaceOrder", mock.MatchedBy(func(o models.Order) bool {
+			return o.Type == models.OrderTypeLimit && o.Price == 150 && o.TimeInForce == models.TimeInForceDay
+		})).Return(&models.Order{ID: "test-order-2", TimeInForce: models.TimeInForceDay}, nil).Once()
+
+		payload := map[string]interface{}{
+			"symbol":        "AAPL",
+			"side":          "buy",
+			"type":          "limit",
+			"quantity":      10,
+			"price":         150,
+			"time_in_force": "DAY",
+		}
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/execution/orders", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.PlaceOrderHandler(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"time_in_force":"day"`)
+	})
+
+	t.Run("InvalidTimeInForce", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"symbol":        "AAPL",
+			"side":          "buy",
+			"type":          "market",
+			"quantity":      10,
+			"time_in_force": "fok",
+		}
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/execution/orders", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		handler.PlaceOrderHandler(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
 	})
 
 	t.Run("InvalidInput", func(t *testing.T) {
@@ -549,6 +1521,33 @@ func TestModifyOrderHandler(t *testing.T) {
 		assert.Equal(t, 155.0, order.Price)
 	})
 
+	t.Run("ClosedOrderConflict", func(t *testing.T) {
+		mockBroker.On("ModifyOrder", "test-order-2", 0.0, 5.0).
+			Return(nil, fmt.Errorf("%w: order test-order-2 is filled", execution.ErrOrderNotModifiable)).Once()
+
+		body, _ := json.Marshal(map[string]interface{}{"quantity": 5.0})
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/execution/orders/test-order-2", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("QuantityBelowFilled", func(t *testing.T) {
+		mockBroker.On("ModifyOrder", "test-order-3", 0.0, 2.0).
+			Return(nil, fmt.Errorf("%w: requested 2 but 3 already filled", execution.ErrQuantityBelowFilled)).Once()
+
+		body, _ := json.Marshal(map[string]interface{}{"quantity": 2.0})
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/execution/orders/test-order-3", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "already filled")
+	})
+
 	t.Run("InvalidInput", func(t *testing.T) {
 		// Empty payload (no price or quantity)
 		payload := map[string]interface{}{}
@@ -704,6 +1703,40 @@ func TestStartEngineHandler(t *testing.T) {
 	})
 }
 
+// TestEngineStatusHandler verifies the engine runtime status endpoint.
+func TestEngineStatusHandler(t *testing.T) {
+	cfg := &config.Config{TradingMode: "test"}
+	registry := strategies.NewRegistry()
+	mockProvider := new(MockDataProvider)
+
+	t.Run("EngineNotAvailable", func(t *testing.T) {
+		handler := NewHandler(registry, mockProvider, cfg, nil, nil, nil, nil)
+
+		rec := httptest.NewRecorder()
+		handler.EngineStatusHandler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/engine/status", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+
+	t.Run("Stopped", func(t *testing.T) {
+		orderManager := execution.NewOrderManager(new(MockBroker), nil, nil, nil)
+		testEngine := engine.NewTradingEngine(mockProvider, registry, orderManager, nil, []string{"AAPL", "BTC-USD"}, time.Minute, 24*time.Hour, false)
+		handler := NewHandler(registry, mockProvider, cfg, nil, testEngine, nil, nil)
+
+		rec := httptest.NewRecorder()
+		handler.EngineStatusHandler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/engine/status", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var status engine.EngineStatus
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		assert.False(t, status.Running)
+		assert.Equal(t, "1m0s", status.Interval)
+		assert.Equal(t, []string{"AAPL", "BTC-USD"}, status.Symbols)
+		assert.Zero(t, status.TickCount)
+		assert.False(t, status.CircuitBreaker.Enabled)
+	})
+}
+
 // TestStopEngineHandler verifies engine stop endpoint.
 func TestStopEngineHandler(t *testing.T) {
 	cfg := &config.Config{
@@ -752,6 +1785,125 @@ func TestStopEngineHandler(t *testing.T) {
 	})
 }
 
+// TestResumeFetchingHandler verifies resuming requires confirmation and
+// reports whether the engine was paused.
+func TestResumeFetchingHandler(t *testing.T) {
+	cfg := &config.Config{TradingMode: "test"}
+	registry := strategies.NewRegistry()
+	mockProvider := new(MockDataProvider)
+	orderManager := execution.NewOrderManager(new(MockBroker), nil, nil, nil)
+	testEngine := engine.NewTradingEngine(mockProvider, registry, orderManager, nil, []string{"AAPL"}, time.Minute, 24*time.Hour, false)
+
+	post := func(handler *Handler, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ResumeFetchingHandler(rec, httptest.NewRequest(http.MethodPost, "/api/v1/engine/resume-fetching", strings.NewReader(body)))
+		return rec
+	}
+
+	rec := post(NewHandler(registry, mockProvider, cfg, nil, nil, nil, nil), `{"confirm": true}`)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	handler := NewHandler(registry, mockProvider, cfg, nil, testEngine, nil, nil)
+	rec = post(handler, `{}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = post(handler, `{"confirm": true}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"resumed": false}`, rec.Body.String())
+}
+
+// TestSetEngineModeHandler verifies switching the engine into close-only
+// mode and rejecting unknown modes.
+func TestSetEngineModeHandler(t *testing.T) {
+	cfg := &config.Config{TradingMode: "test"}
+	registry := strategies.NewRegistry()
+	mockProvider := new(MockDataProvider)
+	orderManager := execution.NewOrderManager(new(MockBroker), nil, nil, nil)
+	testEngine := engine.NewTradingEngine(mockProvider, registry, orderManager, nil, []string{"AAPL"}, time.Minute, 24*time.Hour, false)
+	handler := NewHandler(registry, mockProvider, cfg, nil, testEngine, nil, nil)
+
+	patch := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.SetEngineModeHandler(rec, httptest.NewRequest(http.MethodPatch, "/api/v1/engine/mode", strings.NewReader(body)))
+		return rec
+	}
+
+	rec := patch(`{"mode": "close_only"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"mode": "close_only"}`, rec.Body.String())
+	assert.Equal(t, engine.ModeCloseOnly, testEngine.Status().Mode)
+
+	rec = patch(`{"mode": "paused"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, engine.ModeCloseOnly, testEngine.Mode())
+
+	rec = patch(`{"mode": "normal"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, engine.ModeNormal, testEngine.Mode())
+}
+
+// TestKillSwitchHandler verifies the kill-switch requires confirmation and
+// blocks order placement until re-enabled.
+func TestKillSwitchHandler(t *testing.T) {
+	cfg := &config.Config{
+		TradingMode:    "test",
+		AllowedOrigins: []string{"http://localhost:3000"},
+		APIKey:         "test-key",
+	}
+	registry := strategies.NewRegistry()
+	mockProvider := new(MockDataProvider)
+
+	post := func(handler *Handler, payload any) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/engine/kill-switch", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.KillSwitchHandler(rec, req)
+		return rec
+	}
+
+	t.Run("OrderManagerNotAvailable", func(t *testing.T) {
+		handler := NewHandler(registry, mockProvider, cfg, nil, nil, nil, nil)
+		rec := post(handler, map[string]bool{"confirm": true, "trading_enabled": false})
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		orderManager := execution.NewOrderManager(execution.NewPaperBroker(10000), nil, nil, nil)
+		handler := NewHandler(registry, mockProvider, cfg, orderManager, nil, nil, nil)
+
+		rec := post(handler, map[string]bool{"confirm": false, "trading_enabled": false})
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Confirmation required")
+
+		rec = post(handler, map[string]bool{"confirm": true})
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.True(t, orderManager.TradingEnabled())
+	})
+
+	t.Run("Toggle", func(t *testing.T) {
+		broker := execution.NewPaperBroker(10000)
+		require.NoError(t, broker.Connect())
+		broker.SetPrice("AAPL", 100)
+		orderManager := execution.NewOrderManager(broker, nil, nil, nil)
+		handler := NewHandler(registry, mockProvider, cfg, orderManager, nil, nil, nil)
+
+		rec := post(handler, map[string]bool{"confirm": true, "trading_enabled": false})
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"trading_enabled": false}`, rec.Body.String())
+
+		body, _ := json.Marshal(map[string]any{"symbol": "AAPL", "side": "buy", "type": "market", "quantity": 1})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/execution/orders", bytes.NewReader(body))
+		orderRec := httptest.NewRecorder()
+		handler.PlaceOrderHandler(orderRec, req)
+		assert.Equal(t, http.StatusConflict, orderRec.Code)
+		assert.Contains(t, orderRec.Body.String(), "kill-switch")
+
+		rec = post(handler, map[string]bool{"confirm": true, "trading_enabled": true})
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, orderManager.TradingEnabled())
+	})
+}
+
 // TestGetConfigValidationHandler verifies config validation endpoint.
 func TestGetConfigValidationHandler(t *testing.T) {
 	cfg := &config.Config{
@@ -803,15 +1955,53 @@ func TestGetConfigValidationHandler(t *testing.T) {
 func TestReloadConfigHandler(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		cfg := &config.Config{
-			ServerPort:        8099,
-			ServerHost:        "0.0.0.0",
-			TradingMode:       config.ModeDryRun,
-			DatabasePath:      "./data/sherwood.db",
-			LogLevel:          "info",
-			DataProvider:      "yahoo",
-			EnabledStrategies: []string{"ma_crossover"},
-			AllowedOrigins:    []string{"http://localhost:3000", "http://localhost:8080"},
-			EnvFile:           ".env.nonexistent_test",
+			ServerPort:                8099,
+			ServerHost:                "0.0.0.0",
+			TradingMode:               config.ModeDryRun,
+			DatabasePath:              "./data/sherwood.db",
+			DBBusyTimeout:             5 * time.Second,
+			DBMaxOpenConns:            4,
+			LogLevel:                  "info",
+			DataProvider:              "yahoo",
+			BacktestWorkers:           2,
+			MaxSweepCombinations:      100,
+			OrderRetryAttempts:        3,
+			InitialCapital:            100000,
+			TradingCalendar:           "us_equity",
+			OrderRetryDelay:           500 * time.Millisecond,
+			OrderEquityLotSize:        1,
+			MaxHistoryCandles:         5000,
+			TickerCacheTTL:            24 * time.Hour,
+			EnginePrimeTimeout:        30 * time.Second,
+			OrderConfirmWindow:        2 * time.Minute,
+			EngineFetchFailures:       5,
+			OrderMaxNotional:          1000000,
+			EngineHeartbeat:           true,
+			StaleCryptoIntervals:      3,
+			StaleEquityIntervals:      5,
+			BrokerCheckInterval:       30 * time.Second,
+			BrokerReconnectBackoff:    time.Second,
+			BrokerReconnectMaxBackoff: time.Minute,
+			RateLimitReads:            300,
+			RateLimitBacktests:        10,
+			RateLimitOrders:           30,
+			RateLimitWrites:           60,
+			CompressionMinBytes:       1024,
+			EquitySnapshotInterval:    5 * time.Minute,
+			DataGapPolicy:             "log",
+			CandleTimezone:            "America/New_York",
+			ProviderTimeout:           30 * time.Second,
+			BaseCurrency:              "USD",
+			WSMaxClients:              100,
+			WSBroadcastThrottle:       "market_data:1s",
+			NotificationDedupWindow:   time.Minute,
+			NotificationRateLimit:     20,
+			NotificationQuietTZ:       "UTC",
+			OrderFillNotify:           true,
+			EnabledStrategies:         []string{"ma_crossover"},
+			TradingSymbols:            []string{"SPY", "BTC-USD", "ETH-USD", "AAPL", "MSFT"},
+			AllowedOrigins:            []string{"http://localhost:3000", "http://localhost:8080"},
+			EnvFile:                   ".env.nonexistent_test",
 		}
 		handler := NewHandler(nil, nil, cfg, nil, nil, nil, nil)
 
@@ -878,3 +2068,80 @@ func TestReloadConfigHandler(t *testing.T) {
 		assert.Equal(t, "info", cfg.LogLevel)
 	})
 }
+
+// TestSymbolAliases_RoundTrip verifies an aliased symbol is resolved to its
+// canonical symbol for orders and backtests, and that the provider is asked
+// for the configured provider symbol.
+func TestSymbolAliases_RoundTrip(t *testing.T) {
+	cfg := &config.Config{
+		AllowedOrigins:  []string{"http://localhost:3000"},
+		SymbolAliases:   "BITCOIN:BTC-USD",
+		ProviderSymbols: "BTC-USD:XBTUSD",
+	}
+	require.NotNil(t, cfg.Aliases())
+
+	mockProvider := new(MockDataProvider)
+	mockProvider.On("GetHistoricalData", "XBTUSD", mock.Anything, mock.Anything, "1d").Return(backtestBars(40), nil)
+	provider := data.NewNormalizedProvider(mockProvider, cfg.Aliases().Normalizer(data.YahooSymbols{}))
+
+	registry := strategies.NewRegistry()
+	require.NoError(t, registry.Register(strategies.NewMACrossover()))
+
+	broker := execution.NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("BTC-USD", 100.0)
+	orderManager := execution.NewOrderManager(broker, nil, nil, nil)
+
+	router := NewRouter(cfg, registry, provider, orderManager, nil, nil, nil)
+	post := func(path string, payload interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body)))
+		return rec
+	}
+
+	t.Run("Order", func(t *testing.T) {
+		rec := post("/api/v1/execution/orders", PlaceOrderRequest{Symbol: "bitcoin", Side: "buy", Type: "market", Quantity: 1})
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var order models.Order
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &order))
+		assert.Equal(t, "BTC-USD", order.Symbol)
+		assert.Equal(t, models.OrderStatusFilled, order.Status)
+
+		// Filtering by the alias finds it too
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/execution/orders?symbol=Bitcoin", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var page struct {
+			Orders []models.Order `json:"orders"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+		require.Len(t, page.Orders, 1)
+		assert.Equal(t, order.ID, page.Orders[0].ID)
+	})
+
+	t.Run("Backtest", func(t *testing.T) {
+		rec := post("/api/v1/backtests?sync=true", RunBacktestRequest{
+			Strategy:       "ma_crossover",
+			Symbol:         "BITCOIN",
+			Start:          time.Now().AddDate(0, -2, 0),
+			End:            time.Now(),
+			InitialCapital: 10000,
+		})
+		require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+		var job struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/backtests/"+job.ID, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var result struct {
+			Config struct{ Symbol string } `json:"config"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		assert.Equal(t, "BTC-USD", result.Config.Symbol)
+		mockProvider.AssertExpectations(t)
+	})
+}
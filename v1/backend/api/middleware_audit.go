@@ -5,6 +5,8 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"net/http"
+
+	"github.com/alexherrero/sherwood/backend/execution"
 )
 
 // contextKey is a private type for context keys to avoid collisions.
@@ -33,15 +35,23 @@ func AuditMiddleware(next http.Handler) http.Handler {
 		apiKey := r.Header.Get("X-Sherwood-API-Key")
 		keyID := "dev-mode"
 		if apiKey != "" {
-			hash := sha256.Sum256([]byte(apiKey))
-			keyID = fmt.Sprintf("%x", hash[:4]) // First 8 hex chars
+			keyID = keyFingerprint(apiKey)
 		}
 		ctx = context.WithValue(ctx, auditKeyIDKey, keyID)
+		// The execution layer reads its own keys for order logs and events
+		ctx = execution.WithAuditContext(ctx, ip, keyID)
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// keyFingerprint returns the first 8 hex chars of a key's SHA-256 hash, which
+// identifies the key in logs without exposing it.
+func keyFingerprint(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("%x", hash[:4])
+}
+
 // AuditIPFromCtx extracts the requestor IP from context.
 // Returns "unknown" if not present.
 func AuditIPFromCtx(ctx context.Context) string {
@@ -3,11 +3,17 @@ package api
 import (
 	"crypto/subtle"
 	"net/http"
+	"strings"
 
 	"github.com/alexherrero/sherwood/backend/config"
+	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog/log"
 )
 
+// wsKeyProtocolPrefix marks the Sec-WebSocket-Protocol entry carrying the API
+// key, e.g. "api-key.<key>", for browsers that cannot set custom headers.
+const wsKeyProtocolPrefix = "api-key."
+
 // AuthMiddleware creates a middleware that checks for a valid API Key.
 // It requires the X-Sherwood-API-Key header to match the configured APIKey.
 // Uses constant-time comparison to prevent timing attacks.
@@ -16,7 +22,7 @@ func AuthMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// If no API key is configured, allow all requests (dev mode)
 			// In production, API_KEY should always be set
-			if cfg.APIKey == "" {
+			if cfg.CurrentAPIKey() == "" {
 				log.Warn().Msg("No API key configured - authentication disabled (dev mode only)")
 				next.ServeHTTP(w, r)
 				return
@@ -24,10 +30,7 @@ func AuthMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
 
 			apiKey := r.Header.Get("X-Sherwood-API-Key")
 
-			// Use constant-time comparison to prevent timing attacks
-			// This prevents attackers from determining API key length/content
-			// by measuring response time differences
-			if subtle.ConstantTimeCompare([]byte(apiKey), []byte(cfg.APIKey)) != 1 {
+			if !validAPIKey(cfg, apiKey) {
 				log.Warn().
 					Str("ip", r.RemoteAddr).
 					Str("path", r.URL.Path).
@@ -40,3 +43,55 @@ func AuthMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// WebSocketAuthMiddleware authenticates WebSocket upgrades. Browsers cannot set
+// custom headers on a WebSocket, so besides X-Sherwood-API-Key the key is
+// accepted from the api_key query parameter or a Sec-WebSocket-Protocol entry
+// of the form "api-key.<key>". Requests without a valid key are rejected with
+// 401 before the connection is upgraded.
+func WebSocketAuthMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Same dev mode as AuthMiddleware
+			if cfg.CurrentAPIKey() == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !validAPIKey(cfg, webSocketAPIKey(r)) {
+				log.Warn().
+					Str("ip", r.RemoteAddr).
+					Str("path", r.URL.Path).
+					Msg("Unauthorized WebSocket connection attempt: invalid API key")
+				writeError(w, http.StatusUnauthorized, "Unauthorized", "UNAUTHORIZED")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// webSocketAPIKey extracts the API key from a WebSocket upgrade request.
+func webSocketAPIKey(r *http.Request) string {
+	if key := r.Header.Get("X-Sherwood-API-Key"); key != "" {
+		return key
+	}
+	if key := r.URL.Query().Get("api_key"); key != "" {
+		return key
+	}
+	for _, protocol := range websocket.Subprotocols(r) {
+		if strings.HasPrefix(protocol, wsKeyProtocolPrefix) {
+			return strings.TrimPrefix(protocol, wsKeyProtocolPrefix)
+		}
+	}
+	return ""
+}
+
+// validAPIKey reports whether key matches the configured API key.
+func validAPIKey(cfg *config.Config, key string) bool {
+	// Use constant-time comparison to prevent timing attacks
+	// This prevents attackers from determining API key length/content
+	// by measuring response time differences
+	return subtle.ConstantTimeCompare([]byte(key), []byte(cfg.CurrentAPIKey())) == 1
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alexherrero/sherwood/backend/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveCompressed runs a handler behind the compression middleware.
+func serveCompressed(t *testing.T, minBytes int, acceptEncoding string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	compressResponses(minBytes)(handler).ServeHTTP(rec, req)
+	return rec
+}
+
+// TestCompressResponses verifies which responses are compressed and that
+// compressed bodies decode to the original.
+func TestCompressResponses(t *testing.T) {
+	large := `{"data":"` + strings.Repeat("a", 2000) + `"}`
+	writeBody := func(contentType, body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, body)
+		}
+	}
+
+	t.Run("GzipAboveThreshold", func(t *testing.T) {
+		rec := serveCompressed(t, 1024, "deflate, gzip", writeBody("application/json", large))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+		assert.Less(t, rec.Body.Len(), len(large))
+		zr, err := gzip.NewReader(rec.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		assert.Equal(t, large, string(body))
+	})
+
+	t.Run("Deflate", func(t *testing.T) {
+		rec := serveCompressed(t, 1024, "gzip;q=0, deflate", writeBody("text/csv", large))
+
+		assert.Equal(t, "deflate", rec.Header().Get("Content-Encoding"))
+		body, err := io.ReadAll(flate.NewReader(rec.Body))
+		require.NoError(t, err)
+		assert.Equal(t, large, string(body))
+	})
+
+	t.Run("BelowThreshold", func(t *testing.T) {
+		rec := serveCompressed(t, 1024, "gzip", writeBody("application/json", `{"ok":true}`))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, `{"ok":true}`, rec.Body.String())
+	})
+
+	t.Run("NotAccepted", func(t *testing.T) {
+		rec := serveCompressed(t, 1024, "", writeBody("application/json", large))
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, large, rec.Body.String())
+
+		rec = serveCompressed(t, 1024, "br", writeBody("application/json", large))
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	})
+
+	t.Run("NotCompressibleType", func(t *testing.T) {
+		rec := serveCompressed(t, 1024, "gzip", writeBody("image/png", large))
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, large, rec.Body.String())
+	})
+
+	t.Run("AlreadyEncoded", func(t *testing.T) {
+		rec := serveCompressed(t, 1024, "gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Encoding", "br")
+			writeBody("application/json", large)(w, r)
+		})
+		assert.Equal(t, "br", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, large, rec.Body.String())
+	})
+
+	t.Run("FlushedStream", func(t *testing.T) {
+		rec := serveCompressed(t, 1024, "gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			rc := http.NewResponseController(w)
+			for range 100 {
+				_, _ = io.WriteString(w, `{"line":1}`+"\n")
+				require.NoError(t, rc.Flush())
+			}
+		})
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.True(t, rec.Flushed)
+		assert.Equal(t, strings.Repeat(`{"line":1}`+"\n", 100), rec.Body.String())
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		rec := serveCompressed(t, 0, "gzip", writeBody("application/json", large))
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Empty(t, rec.Header().Get("Vary"))
+		assert.Equal(t, large, rec.Body.String())
+	})
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := map[string]string{
+		"":                      "",
+		"gzip":                  "gzip",
+		"deflate, gzip;q=0.5":   "gzip",
+		"GZIP;q=0, deflate":     "deflate",
+		"br, identity":          "",
+		"gzip;q=0, deflate;q=0": "",
+		" deflate ;q=1.0 ":      "deflate",
+	}
+	for header, want := range tests {
+		assert.Equal(t, want, negotiateEncoding(header), header)
+	}
+}
+
+// TestNewRouter_CompressesResponses verifies the router compresses large
+// responses when enabled in config.
+func TestNewRouter_CompressesResponses(t *testing.T) {
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost:3000"}, CompressionMinBytes: 1024}
+	router := NewRouter(cfg, nil, nil, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	zr, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"openapi"`)
+}
@@ -0,0 +1,694 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/backtesting"
+	"github.com/alexherrero/sherwood/backend/config"
+	"github.com/alexherrero/sherwood/backend/engine"
+	"github.com/alexherrero/sherwood/backend/execution"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/realtime"
+	"github.com/alexherrero/sherwood/backend/strategies"
+	"github.com/go-chi/chi/v5"
+)
+
+// openAPIVersion is the OpenAPI version the spec is written against.
+const openAPIVersion = "3.0.3"
+
+// apiKeyScheme names the API key security scheme in the spec.
+const apiKeyScheme = "ApiKeyAuth"
+
+// openAPIOperation documents one route. Request and Response are zero
+// values of the Go types that are decoded and encoded; their JSON schemas
+// are derived from the struct fields and their json and validate tags.
+type openAPIOperation struct {
+	ID          string         // operationId, for generated client method names
+	Tag         string         // Groups operations in tooling
+	Summary     string         // One-line description
+	Description string         // Optional longer description
+	Query       []openAPIParam // Query parameters
+	Request     interface{}    // JSON request body type, nil for none
+	Response    interface{}    // Success body type, nil for an untyped body
+	Status      int            // Success status (default 200)
+	ContentType string         // Success content type (default application/json, "-" for no body)
+	Public      bool           // Served without the API key
+}
+
+// openAPIParam is a query parameter.
+type openAPIParam struct {
+	Name        string
+	Type        string // string, integer, number or boolean
+	Format      string // e.g. date-time
+	Description string
+	Required    bool
+}
+
+// Shared query parameters.
+var (
+	pageParams = []openAPIParam{
+		{Name: "limit", Type: "integer", Description: "Page size (default 50)"},
+		{Name: "page", Type: "integer", Description: "Page number, from 1 (default 1)"},
+	}
+	rangeParams = []openAPIParam{
+		{Name: "start", Type: "string", Format: "date-time", Description: "Earliest time (RFC3339)"},
+		{Name: "end", Type: "string", Format: "date-time", Description: "Latest time (RFC3339)"},
+	}
+)
+
+// Response shapes of handlers that write ad-hoc maps.
+type (
+	statusResponse struct {
+		Status string `json:"status"`
+	}
+	backtestJobResponse struct {
+		ID      string                `json:"id"`
+		Status  backtesting.JobStatus `json:"status"`
+		Message string                `json:"message"`
+		// Metrics is only present for ?sync=true runs.
+		Metrics *backtesting.Metrics `json:"metrics,omitempty"`
+	}
+	backtestResultResponse struct {
+		ID             string                      `json:"id"`
+		Status         backtesting.JobStatus       `json:"status"`
+		Error          string                      `json:"error,omitempty"`
+		Strategy       string                      `json:"strategy,omitempty"`
+		Config         *backtesting.BacktestConfig `json:"config,omitempty"`
+		Metrics        *backtesting.Metrics        `json:"metrics,omitempty"`
+		Summary        string                      `json:"summary,omitempty"`
+		ChartData      []backtesting.EquityPoint   `json:"chart_data,omitempty"`
+		ChartDataTotal int                         `json:"chart_data_total,omitempty"`
+		SubmittedAt    time.Time                   `json:"submitted_at,omitempty"`
+	}
+	strategyResponse struct {
+		Name        string                          `json:"name"`
+		Description string                          `json:"description"`
+		Parameters  map[string]strategies.Parameter `json:"parameters"`
+		Enabled     bool                            `json:"enabled"`
+	}
+	strategyListResponse struct {
+		Strategies []strategyResponse `json:"strategies"`
+	}
+	strategyEnabledResponse struct {
+		Name    string `json:"name"`
+		Enabled bool   `json:"enabled"`
+	}
+	ordersResponse struct {
+		Orders []models.Order `json:"orders"`
+		Total  int            `json:"total"`
+		Page   int            `json:"page"`
+		Limit  int            `json:"limit"`
+	}
+	cancelOrderResponse struct {
+		Status string `json:"status"`
+		ID     string `json:"id"`
+	}
+	systemConfigRequest struct {
+		InitialCapital *float64 `json:"initial_capital"`
+	}
+	healthResponse struct {
+		Status    string                 `json:"status"`
+		Mode      string                 `json:"mode,omitempty"`
+		Timestamp time.Time              `json:"timestamp,omitempty"`
+		Checks    map[string]healthCheck `json:"checks,omitempty"`
+	}
+	serviceResponse struct {
+		Service string `json:"service"`
+		Version string `json:"version"`
+		Status  string `json:"status"`
+	}
+	apiStatusResponse struct {
+		Mode   string `json:"mode"`
+		Status string `json:"status"`
+	}
+)
+
+// openAPIOperations documents every route, keyed by method and path as
+// chi.Walk reports them (without a trailing slash). A route missing here is
+// still listed, with only its path and method.
+var openAPIOperations = map[string]openAPIOperation{
+	"GET /": {ID: "getService", Tag: "system", Summary: "Service name and version",
+		Response: serviceResponse{}, Public: true},
+	"GET /openapi.json": {ID: "getOpenAPISpec", Tag: "system", Summary: "This OpenAPI specification",
+		Public: true},
+	"GET /ws": {ID: "connectWebSocket", Tag: "system", Summary: "Real-time updates over WebSocket",
+		Description: "Besides the header, the API key is accepted as the api_key query parameter or an \"api-key.<key>\" Sec-WebSocket-Protocol entry.",
+		Query:       []openAPIParam{{Name: "api_key", Type: "string", Description: "API key, for clients that cannot set headers"}},
+		Status:      http.StatusSwitchingProtocols, ContentType: "-"},
+	"GET /health": {ID: "getHealth", Tag: "system", Summary: "Combined health of the API and its dependencies",
+		Description: "Returns 503 when a critical dependency is down.",
+		Response:    healthResponse{}, Public: true},
+	"GET /livez": {ID: "getLiveness", Tag: "system", Summary: "Liveness probe",
+		Response: statusResponse{}, Public: true},
+	"GET /readyz": {ID: "getReadiness", Tag: "system", Summary: "Readiness probe",
+		Description: "Returns 503 when a dependency is down.",
+		Response:    healthResponse{}, Public: true},
+	"GET /metrics": {ID: "getPrometheusMetrics", Tag: "system", Summary: "Prometheus metrics",
+		ContentType: "text/plain"},
+
+	"GET /api/v1/strategies": {ID: "listStrategies", Tag: "strategies", Summary: "List strategies",
+		Response: strategyListResponse{}},
+	"GET /api/v1/strategies/{name}": {ID: "getStrategy", Tag: "strategies", Summary: "Get a strategy",
+		Response: strategyResponse{}},
+	"PATCH /api/v1/strategies/{name}": {ID: "updateStrategy", Tag: "strategies", Summary: "Enable or disable a strategy",
+		Request: UpdateStrategyRequest{}, Response: strategyEnabledResponse{}},
+	"POST /api/v1/strategies/{name}/validate": {ID: "validateStrategy", Tag: "strategies", Summary: "Validate a strategy config",
+		Request: ValidateStrategyRequest{}, Response: ValidateStrategyResponse{}},
+	"POST /api/v1/strategies/{name}/backtest": {ID: "runStrategyBacktest", Tag: "backtests", Summary: "Backtest the named strategy",
+		Query:   []openAPIParam{{Name: "sync", Type: "boolean", Description: "Run within the request instead of queueing"}},
+		Request: StrategyBacktestRequest{}, Response: backtestJobResponse{}, Status: http.StatusAccepted},
+
+	"POST /api/v1/backtests": {ID: "runBacktest", Tag: "backtests", Summary: "Run a backtest",
+		Description: "Queued by default; poll GET /api/v1/backtests/{id}. Returns 422 INSUFFICIENT_DATA with ?sync=true when the range has too few bars.",
+		Query:       []openAPIParam{{Name: "sync", Type: "boolean", Description: "Run within the request instead of queueing"}},
+		Request:     RunBacktestRequest{}, Response: backtestJobResponse{}, Status: http.StatusAccepted},
+	"POST /api/v1/backtests/sweep": {ID: "runSweep", Tag: "backtests", Summary: "Backtest every combination of a parameter grid",
+		Request: SweepRequest{}, Response: SweepResponse{}},
+	"POST /api/v1/backtests/compare": {ID: "compareBacktests", Tag: "backtests", Summary: "Compare two strategies on the same data",
+		Request: CompareBacktestRequest{}, Response: CompareBacktestResponse{}},
+	"GET /api/v1/backtests/{id}": {ID: "getBacktest", Tag: "backtests", Summary: "Get a backtest's status and results",
+		Query:    []openAPIParam{{Name: "max_points", Type: "integer", Description: "Most equity curve points (default 1000, 0 for all)"}},
+		Response: backtestResultResponse{}},
+	"GET /api/v1/backtests/{id}/report": {ID: "getBacktestReport", Tag: "backtests", Summary: "Export a completed backtest's report",
+		Description: "format=md returns text/markdown instead.",
+		Query:       []openAPIParam{{Name: "format", Type: "string", Description: "json (default) or md"}},
+		Response:    backtesting.ReportExport{}},
+	"GET /api/v1/backtests/{id}/montecarlo": {ID: "getBacktestMonteCarlo", Tag: "backtests", Summary: "Resample a completed backtest's trades",
+		Description: "Bootstraps the trades' net P&L and returns the 5th, 50th and 95th percentiles of final return and max drawdown.",
+		Query:       []openAPIParam{{Name: "iterations", Type: "integer", Description: "Resampled paths, 1-10000 (default 1000)"}},
+		Response:    backtesting.MonteCarloResult{}},
+
+	"GET /api/v1/execution/orders": {ID: "listOrders", Tag: "execution", Summary: "List orders",
+		Query: append([]openAPIParam{
+			{Name: "symbol", Type: "string", Description: "Symbol"},
+			{Name: "status", Type: "string", Description: "Order status"},
+			{Name: "strategy", Type: "string", Description: "Strategy name"},
+			{Name: "tag", Type: "string", Description: "Tag"},
+		}, pageParams...),
+		Response: ordersResponse{}},
+	"POST /api/v1/execution/orders": {ID: "placeOrder", Tag: "execution", Summary: "Place an order",
+		Description: "Orders above the confirmation threshold are staged and returned with 202 and a confirmation_token.",
+		Request:     PlaceOrderRequest{}, Response: models.Order{}},
+	"GET /api/v1/execution/orders/pending-confirmation": {ID: "getStagedOrders", Tag: "execution", Summary: "List orders awaiting confirmation",
+		Description: "Staged orders with their confirmation_token and confirm_by, soonest to expire first. Held in memory, so a restart discards them.",
+		Response:    []models.Order{}},
+	"POST /api/v1/execution/orders/{token}/confirm": {ID: "confirmOrder", Tag: "execution", Summary: "Confirm a staged order",
+		Response: models.Order{}},
+	"GET /api/v1/execution/orders/{id}": {ID: "getOrder", Tag: "execution", Summary: "Get an order",
+		Response: models.Order{}},
+	"PATCH /api/v1/execution/orders/{id}": {ID: "modifyOrder", Tag: "execution", Summary: "Modify an open order",
+		Request: ModifyOrderRequest{}, Response: models.Order{}},
+	"DELETE /api/v1/execution/orders/{id}": {ID: "cancelOrder", Tag: "execution", Summary: "Cancel an open order",
+		Response: cancelOrderResponse{}},
+	"GET /api/v1/execution/orders/{id}/trades": {ID: "listOrderTrades", Tag: "execution", Summary: "List an order's fills",
+		Response: []models.Trade{}},
+	"GET /api/v1/execution/orders/{id}/notes": {ID: "listOrderNotes", Tag: "execution", Summary: "List an order's journal notes",
+		Response: []models.OrderNote{}},
+	"POST /api/v1/execution/orders/{id}/notes": {ID: "addOrderNote", Tag: "execution", Summary: "Add a journal note to an order",
+		Request: OrderNoteRequest{}, Response: models.OrderNote{}, Status: http.StatusCreated},
+	"GET /api/v1/execution/orders/{id}/events": {ID: "listOrderEvents", Tag: "execution", Summary: "List an order's audit trail",
+		Response: []models.OrderEvent{}},
+	"GET /api/v1/execution/history": {ID: "getOrderHistory", Tag: "execution", Summary: "List orders (alias of GET /execution/orders)",
+		Query: pageParams, Response: ordersResponse{}},
+	"GET /api/v1/execution/trades": {ID: "listTrades", Tag: "execution", Summary: "List fills",
+		Response: []models.Trade{}},
+	"GET /api/v1/execution/positions": {ID: "listPositions", Tag: "execution", Summary: "List open positions",
+		Response: []models.Position{}},
+	"POST /api/v1/execution/positions/import": {ID: "importPositions", Tag: "execution", Summary: "Import positions held elsewhere",
+		Description: "Only in dry_run mode. Each position replaces any held for its symbol; cash is unchanged.",
+		Request:     PositionImportRequest{}, Response: []models.Position{}},
+	"GET /api/v1/execution/balance": {ID: "getBalance", Tag: "execution", Summary: "Get the account balance",
+		Response: models.Balance{}},
+	"POST /api/v1/execution/reset": {ID: "resetPaper", Tag: "execution", Summary: "Reset the paper account",
+		Description: "Only in dry_run mode, with the engine stopped.",
+		Request:     PaperResetRequest{}, Response: execution.PaperReset{}},
+
+	"GET /api/v1/portfolio/summary": {ID: "getPortfolioSummary", Tag: "portfolio", Summary: "Portfolio summary in the base currency",
+		Response: execution.PortfolioSummary{}},
+	"GET /api/v1/portfolio/performance": {ID: "getPortfolioPerformance", Tag: "portfolio", Summary: "Performance metrics and equity curve",
+		Query:    []openAPIParam{{Name: "since", Type: "string", Format: "date-time", Description: "Start of the equity curve (default 30 days ago)"}},
+		Response: PerformanceResponse{}},
+	"GET /api/v1/portfolio/by-strategy": {ID: "getStrategyPerformance", Tag: "portfolio", Summary: "Performance per strategy",
+		Query: rangeParams, Response: StrategyPerformanceResponse{}},
+
+	"GET /api/v1/data/history": {ID: "getHistoricalData", Tag: "data", Summary: "Historical candles",
+		Query: append([]openAPIParam{
+			{Name: "symbol", Type: "string", Description: "Symbol", Required: true},
+			{Name: "interval", Type: "string", Description: "Candle interval, e.g. 1d"},
+		}, rangeParams...),
+		Response: []models.OHLCV{}},
+	"GET /api/v1/data/ticker": {ID: "getTicker", Tag: "data", Summary: "Latest quote",
+		Query:    []openAPIParam{{Name: "symbol", Type: "string", Description: "Symbol", Required: true}},
+		Response: models.Ticker{}},
+	"GET /api/v1/data/stream": {ID: "streamMarketData", Tag: "data", Summary: "Stream candles as newline-delimited JSON",
+		Query: []openAPIParam{
+			{Name: "symbol", Type: "string", Description: "Symbol", Required: true},
+			{Name: "interval", Type: "string", Description: "Only candles of this interval"},
+		},
+		Response: models.OHLCV{}, ContentType: "application/x-ndjson"},
+
+	"GET /api/v1/engine/status": {ID: "getEngineStatus", Tag: "engine", Summary: "Engine status",
+		Response: engine.EngineStatus{}},
+	"POST /api/v1/engine/start": {ID: "startEngine", Tag: "engine", Summary: "Start the engine",
+		Request: EngineControlRequest{}, Response: statusResponse{}},
+	"POST /api/v1/engine/stop": {ID: "stopEngine", Tag: "engine", Summary: "Stop the engine",
+		Request: EngineControlRequest{}, Response: statusResponse{}},
+	"PATCH /api/v1/engine/mode": {ID: "setEngineMode", Tag: "engine", Summary: "Switch between normal and close-only",
+		Request: EngineModeRequest{}, Response: EngineModeRequest{}},
+	"POST /api/v1/engine/resume-fetching": {ID: "resumeFetching", Tag: "engine", Summary: "Resume data fetching after repeated failures",
+		Request: EngineControlRequest{}, Response: struct {
+			Resumed bool `json:"resumed"`
+		}{}},
+	"POST /api/v1/engine/kill-switch": {ID: "setKillSwitch", Tag: "engine", Summary: "Enable or disable all trading",
+		Request: KillSwitchRequest{}, Response: struct {
+			TradingEnabled bool `json:"trading_enabled"`
+		}{}},
+
+	"GET /api/v1/signals": {ID: "listSignals", Tag: "signals", Summary: "List logged strategy signals",
+		Query: append(append([]openAPIParam{
+			{Name: "symbol", Type: "string", Description: "Symbol"},
+			{Name: "strategy", Type: "string", Description: "Strategy name"},
+		}, rangeParams...), pageParams...),
+		Response: SignalsResponse{}},
+	"POST /api/v1/signals/ingest": {ID: "ingestSignal", Tag: "signals", Summary: "Queue an external signal for execution",
+		Description: "Requires API_KEY and the webhook_signals strategy. Returns 202; the signal executes on the symbol's next engine tick.",
+		Request:     SignalIngestRequest{}, Response: SignalIngestResponse{}},
+
+	"GET /api/v1/notifications": {ID: "listNotifications", Tag: "notifications", Summary: "List notifications",
+		Query: append([]openAPIParam{
+			{Name: "type", Type: "string", Description: "info, success, warning, error or trade"},
+			{Name: "read", Type: "boolean", Description: "Read status"},
+		}, pageParams...),
+		Response: NotificationsResponse{}},
+	"PUT /api/v1/notifications/read-all": {ID: "markAllNotificationsRead", Tag: "notifications", Summary: "Mark every notification read",
+		Response: statusResponse{}},
+	"PUT /api/v1/notifications/{id}/read": {ID: "markNotificationRead", Tag: "notifications", Summary: "Mark a notification read",
+		Response: statusResponse{}},
+
+	"GET /api/v1/config": {ID: "getConfig", Tag: "config", Summary: "Non-secret configuration",
+		Response: map[string]interface{}{}},
+	"GET /api/v1/config/metrics": {ID: "getRuntimeMetrics", Tag: "config", Summary: "Runtime statistics",
+		Response: struct {
+			Goroutines    int                      `json:"goroutines"`
+			Memory        map[string]uint64        `json:"memory"`
+			UptimeSeconds float64                  `json:"uptime_seconds"`
+			Timestamp     time.Time                `json:"timestamp"`
+			WebSocket     *realtime.WebSocketStats `json:"websocket,omitempty"`
+		}{}},
+	"GET /api/v1/config/validation": {ID: "getConfigValidation", Tag: "config", Summary: "Configuration validation status",
+		Response: map[string]interface{}{}},
+	"PATCH /api/v1/config/system": {ID: "updateSystemConfig", Tag: "config", Summary: "Update persisted system settings",
+		Request: systemConfigRequest{}, Response: statusResponse{}},
+	"POST /api/v1/config/rotate-key": {ID: "rotateAPIKey", Tag: "config", Summary: "Rotate the API key",
+		Response: RotateAPIKeyResponse{}},
+	"POST /api/v1/config/reload": {ID: "reloadConfig", Tag: "config", Summary: "Reload configuration from the environment",
+		Response: config.ReloadResult{}},
+
+	"GET /api/v1/status": {ID: "getStatus", Tag: "system", Summary: "Trading mode",
+		Response: apiStatusResponse{}},
+}
+
+// openAPIHandler serves the OpenAPI spec for the routes of router. The spec
+// is built on the first request, once every route is registered.
+func openAPIHandler(router chi.Routes) http.HandlerFunc {
+	var (
+		once sync.Once
+		spec map[string]interface{}
+	)
+	return func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() { spec = buildOpenAPISpec(router) })
+		writeJSON(w, http.StatusOK, spec)
+	}
+}
+
+// pathParamPattern matches chi path parameters, with an optional regexp.
+var pathParamPattern = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// buildOpenAPISpec builds an OpenAPI 3 document from the router's routes
+// and openAPIOperations.
+func buildOpenAPISpec(router chi.Routes) map[string]interface{} {
+	schemas := newSchemaRegistry()
+	errorSchema := schemas.schemaFor(reflect.TypeOf(APIError{}))
+
+	paths := make(map[string]interface{})
+	_ = chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		route = openAPIRoute(route)
+		op, ok := openAPIOperations[method+" "+route]
+		if !ok {
+			op = openAPIOperation{Summary: method + " " + route}
+		}
+		path := pathParamPattern.ReplaceAllString(route, "{$1}")
+
+		operation := map[string]interface{}{
+			"summary":   op.Summary,
+			"responses": openAPIResponses(op, schemas, errorSchema),
+		}
+		if op.ID != "" {
+			operation["operationId"] = op.ID
+		}
+		if op.Tag != "" {
+			operation["tags"] = []string{op.Tag}
+		}
+		if op.Description != "" {
+			operation["description"] = op.Description
+		}
+		if op.Public {
+			operation["security"] = []interface{}{}
+		}
+		if params := openAPIParams(route, op.Query); len(params) > 0 {
+			operation["parameters"] = params
+		}
+		if op.Request != nil {
+			operation["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": schemas.schemaFor(reflect.TypeOf(op.Request)),
+					},
+				},
+			}
+		}
+
+		item, ok := paths[path].(map[string]interface{})
+		if !ok {
+			item = make(map[string]interface{})
+			paths[path] = item
+		}
+		item[strings.ToLower(method)] = operation
+		return nil
+	})
+
+	return map[string]interface{}{
+		"openapi": openAPIVersion,
+		"info": map[string]interface{}{
+			"title":       "Sherwood API",
+			"version":     "1.0.0",
+			"description": "REST API for the Sherwood trading engine.",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas.components,
+			"securitySchemes": map[string]interface{}{
+				apiKeyScheme: map[string]interface{}{
+					"type": "apiKey",
+					"in":   "header",
+					"name": "X-Sherwood-API-Key",
+				},
+			},
+		},
+		"security": []interface{}{map[string]interface{}{apiKeyScheme: []string{}}},
+	}
+}
+
+// openAPIRoute normalizes a route from chi.Walk: sub-router roots end in a
+// slash, which clients do not send.
+func openAPIRoute(route string) string {
+	route = strings.TrimSuffix(route, "/*")
+	if len(route) > 1 {
+		route = strings.TrimSuffix(route, "/")
+	}
+	return route
+}
+
+// openAPIParams lists a route's path parameters followed by its query
+// parameters.
+func openAPIParams(route string, query []openAPIParam) []interface{} {
+	var params []interface{}
+	for _, match := range pathParamPattern.FindAllStringSubmatch(route, -1) {
+		params = append(params, map[string]interface{}{
+			"name":     match[1],
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]interface{}{"type": "string"},
+		})
+	}
+	for _, q := range query {
+		schema := map[string]interface{}{"type": q.Type}
+		if q.Format != "" {
+			schema["format"] = q.Format
+		}
+		param := map[string]interface{}{
+			"name":   q.Name,
+			"in":     "query",
+			"schema": schema,
+		}
+		if q.Description != "" {
+			param["description"] = q.Description
+		}
+		if q.Required {
+			param["required"] = true
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// openAPIResponses documents an operation's success response, plus the
+// APIError body every other status uses.
+func openAPIResponses(op openAPIOperation, schemas *schemaRegistry, errorSchema map[string]interface{}) map[string]interface{} {
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	contentType := op.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	success := map[string]interface{}{"description": http.StatusText(status)}
+	if contentType != "-" {
+		schema := map[string]interface{}{"type": "string"}
+		if contentType == "application/json" {
+			schema = map[string]interface{}{"type": "object"}
+		}
+		if op.Response != nil {
+			schema = schemas.schemaFor(reflect.TypeOf(op.Response))
+		}
+		success["content"] = map[string]interface{}{
+			contentType: map[string]interface{}{"schema": schema},
+		}
+	}
+
+	responses := map[string]interface{}{
+		strconv.Itoa(status): success,
+		"default": map[string]interface{}{
+			"description": "Error",
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": errorSchema},
+			},
+		},
+	}
+	if op.Request != nil {
+		responses[strconv.Itoa(http.StatusBadRequest)] = map[string]interface{}{
+			"description": "Invalid request body or failed validation; fields lists each failed rule",
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": errorSchema},
+			},
+		}
+	}
+	if !op.Public {
+		responses[strconv.Itoa(http.StatusUnauthorized)] = map[string]interface{}{
+			"description": "Missing or invalid API key",
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": errorSchema},
+			},
+		}
+	}
+	return responses
+}
+
+// schemaRegistry derives JSON schemas from Go types. Named struct types
+// become components referenced by $ref; anything else is inlined.
+type schemaRegistry struct {
+	components map[string]interface{}
+	names      map[reflect.Type]string
+}
+
+// newSchemaRegistry returns an empty registry.
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		components: make(map[string]interface{}),
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// schemaFor returns the schema of values of type t as encoding/json writes
+// them.
+func (s *schemaRegistry) schemaFor(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t {
+	case timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case durationType:
+		return map[string]interface{}{"type": "integer", "format": "int64", "description": "Nanoseconds"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Int64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": s.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": s.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + s.component(t)}
+	}
+	// Interfaces hold any JSON value
+	return map[string]interface{}{}
+}
+
+// component registers a named struct type and returns its component name.
+// Names are the capitalized Go type names, prefixed with the package name
+// when two types share one.
+func (s *schemaRegistry) component(t reflect.Type) string {
+	if name, ok := s.names[t]; ok {
+		return name
+	}
+	name := strings.ToUpper(t.Name()[:1]) + t.Name()[1:]
+	if _, taken := s.components[name]; taken {
+		pkg := t.PkgPath()
+		name = pkg[strings.LastIndex(pkg, "/")+1:] + name
+	}
+	s.names[t] = name
+	s.components[name] = map[string]interface{}{} // placeholder for recursive types
+	s.components[name] = s.structSchema(t)
+	return name
+}
+
+// structSchema returns an object schema with a property per exported field.
+func (s *schemaRegistry) structSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	var required []string
+	s.addFields(t, properties, &required)
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// addFields adds t's fields to properties, flattening embedded structs the
+// way encoding/json does.
+func (s *schemaRegistry) addFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				s.addFields(embedded, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema := s.schemaFor(field.Type)
+		if applyValidateTag(schema, field.Type, field.Tag.Get("validate")) {
+			*required = append(*required, name)
+		}
+		properties[name] = schema
+	}
+}
+
+// applyValidateTag copies the constraints of a validate tag that JSON
+// schema can express onto schema, and reports whether the field is
+// required. Rules after "dive" apply to elements and are left out.
+func applyValidateTag(schema map[string]interface{}, t reflect.Type, tag string) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	_, isRef := schema["$ref"]
+
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		key, param, _ := strings.Cut(rule, "=")
+		if key == "dive" {
+			break
+		}
+		if key == "required" {
+			required = true
+			continue
+		}
+		if isRef {
+			continue
+		}
+		bound, err := strconv.ParseFloat(param, 64)
+		switch {
+		case key == "oneof":
+			values := strings.Fields(param)
+			enum := make([]interface{}, len(values))
+			for i, v := range values {
+				enum[i] = v
+			}
+			schema["enum"] = enum
+		case err != nil:
+		case (key == "gt" || key == "gte") && isNumber(t):
+			schema["minimum"] = bound
+			schema["exclusiveMinimum"] = key == "gt"
+		case (key == "lt" || key == "lte") && isNumber(t):
+			schema["maximum"] = bound
+			schema["exclusiveMaximum"] = key == "lt"
+		case key != "min" && key != "max":
+		case t.Kind() == reflect.String:
+			schema[key+"Length"] = int(bound)
+		case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+			schema[key+"Items"] = int(bound)
+		case isNumber(t):
+			schema[key+"imum"] = bound
+		}
+	}
+	return required
+}
+
+// isNumber reports whether t encodes as a JSON number.
+func isNumber(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alexherrero/sherwood/backend/config"
+	"github.com/alexherrero/sherwood/backend/realtime"
+	"github.com/alexherrero/sherwood/backend/strategies"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOpenAPIRouter returns a router with every optional route registered.
+func newOpenAPIRouter(t *testing.T) *Router {
+	t.Helper()
+	cfg := &config.Config{APIKey: "secret123", AllowedOrigins: []string{"*"}}
+	return NewRouter(cfg, strategies.NewRegistry(), new(MockDataProvider), nil, nil, realtime.NewWebSocketManager(), nil)
+}
+
+// TestOpenAPIHandler verifies the spec is served without the API key and
+// describes routes, request schemas and the auth scheme.
+func TestOpenAPIHandler(t *testing.T) {
+	router := newOpenAPIRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var spec struct {
+		OpenAPI    string                                       `json:"openapi"`
+		Paths      map[string]map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas         map[string]map[string]interface{} `json:"schemas"`
+			SecuritySchemes map[string]map[string]interface{} `json:"securitySchemes"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec))
+	assert.Equal(t, openAPIVersion, spec.OpenAPI)
+	assert.Equal(t, "X-Sherwood-API-Key", spec.Components.SecuritySchemes[apiKeyScheme]["name"])
+
+	// Sub-router roots are listed without their trailing slash
+	placeOrder := spec.Paths["/api/v1/execution/orders"]["post"]
+	require.NotNil(t, placeOrder)
+	assert.Equal(t, "placeOrder", placeOrder["operationId"])
+	body := placeOrder["requestBody"].(map[string]interface{})["content"].(map[string]interface{})["application/json"]
+	assert.Equal(t, "#/components/schemas/PlaceOrderRequest", body.(map[string]interface{})["schema"].(map[string]interface{})["$ref"])
+	assert.Contains(t, spec.Paths, "/api/v1/strategies")
+	assert.Contains(t, spec.Paths["/api/v1/backtests/{id}"], "get")
+
+	// Public routes opt out of the global security requirement
+	assert.Equal(t, []interface{}{}, spec.Paths["/health"]["get"]["security"])
+	assert.NotContains(t, spec.Paths["/api/v1/engine/status"]["get"], "security")
+
+	// Schemas follow the json and validate tags
+	order := spec.Components.Schemas["PlaceOrderRequest"]
+	assert.ElementsMatch(t, []interface{}{"symbol", "side", "type", "quantity"}, order["required"])
+	props := order["properties"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"buy", "sell"}, props["side"].(map[string]interface{})["enum"])
+	assert.Equal(t, 20.0, props["symbol"].(map[string]interface{})["maxLength"])
+	assert.Equal(t, true, props["quantity"].(map[string]interface{})["exclusiveMinimum"])
+	assert.Equal(t, 10.0, props["tags"].(map[string]interface{})["maxItems"])
+	assert.Contains(t, spec.Components.Schemas, "APIError")
+	assert.Equal(t, "date-time",
+		spec.Components.Schemas["RunBacktestRequest"]["properties"].(map[string]interface{})["start"].(map[string]interface{})["format"])
+}
+
+// TestOpenAPIOperations_CoverRoutes verifies every route is documented and
+// every documented route exists.
+func TestOpenAPIOperations_CoverRoutes(t *testing.T) {
+	router := newOpenAPIRouter(t).Handler.(chi.Routes)
+
+	routes := make(map[string]bool)
+	require.NoError(t, chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		key := method + " " + openAPIRoute(route)
+		routes[key] = true
+		assert.Contains(t, openAPIOperations, key, "route is not documented in openAPIOperations")
+		return nil
+	}))
+	for key, op := range openAPIOperations {
+		assert.True(t, routes[key], "documented route %s does not exist", key)
+		assert.NotEmpty(t, op.ID, key)
+		assert.False(t, strings.HasSuffix(key, "/") && key != "GET /", key)
+	}
+}
@@ -1,13 +1,17 @@
 package api
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/alexherrero/sherwood/backend/config"
+	"github.com/alexherrero/sherwood/backend/strategies"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestRateLimiting verifies that rate limiting is enforced.
@@ -89,3 +93,100 @@ func TestRateLimiting(t *testing.T) {
 		t.Logf("After recovery wait, status code: %d", w.Code)
 	})
 }
+
+// TestRateLimiting_Response verifies a limited request gets guidance headers
+// and an APIError body.
+func TestRateLimiting_Response(t *testing.T) {
+	cfg := &config.Config{
+		APIKey:         "test-api-key",
+		AllowedOrigins: []string{"http://localhost:3000"},
+	}
+	router := NewRouter(cfg, nil, nil, nil, nil, nil, nil)
+
+	var limited *httptest.ResponseRecorder
+	for i := 0; i < 50 && limited == nil; i++ {
+		req := httptest.NewRequest("GET", "/health", nil)
+		req.RemoteAddr = "192.168.1.150:12345"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code == http.StatusOK {
+			assert.NotEmpty(t, w.Header().Get("X-RateLimit-Limit"))
+			assert.NotEmpty(t, w.Header().Get("X-RateLimit-Remaining"))
+		}
+		if w.Code == http.StatusTooManyRequests {
+			limited = w
+		}
+	}
+	require.NotNil(t, limited, "expected the burst limiter to trigger")
+
+	assert.Equal(t, "20", limited.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", limited.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "1", limited.Header().Get("Retry-After"))
+	assert.Equal(t, "application/json", limited.Header().Get("Content-Type"))
+
+	var body APIError
+	require.NoError(t, json.Unmarshal(limited.Body.Bytes(), &body))
+	assert.Equal(t, "RATE_LIMITED", body.Code)
+	assert.Contains(t, body.Error, "retry after 1 seconds")
+}
+
+// TestRateLimiting_PerRoute verifies route groups have independent limits.
+func TestRateLimiting_PerRoute(t *testing.T) {
+	cfg := &config.Config{
+		AllowedOrigins:     []string{"http://localhost:3000"},
+		RateLimitReads:     3,
+		RateLimitBacktests: 1,
+		RateLimitOrders:    2,
+		RateLimitWrites:    2,
+	}
+	router := NewRouter(cfg, strategies.NewRegistry(), nil, nil, nil, nil, nil)
+
+	send := func(method, path, ip string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+		req.RemoteAddr = ip + ":12345"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("backtests_share_a_budget", func(t *testing.T) {
+		ip := "10.0.0.1"
+		assert.NotEqual(t, http.StatusTooManyRequests, send("POST", "/api/v1/backtests", ip))
+		assert.Equal(t, http.StatusTooManyRequests, send("POST", "/api/v1/backtests", ip))
+		assert.Equal(t, http.StatusTooManyRequests, send("POST", "/api/v1/strategies/ma_crossover/backtest", ip))
+
+		// Other groups are unaffected
+		assert.NotEqual(t, http.StatusTooManyRequests, send("POST", "/api/v1/execution/orders", ip))
+		assert.NotEqual(t, http.StatusTooManyRequests, send("GET", "/api/v1/strategies", ip))
+	})
+
+	t.Run("orders", func(t *testing.T) {
+		ip := "10.0.0.2"
+		assert.NotEqual(t, http.StatusTooManyRequests, send("POST", "/api/v1/execution/orders", ip))
+		assert.NotEqual(t, http.StatusTooManyRequests, send("POST", "/api/v1/execution/orders", ip))
+		assert.Equal(t, http.StatusTooManyRequests, send("POST", "/api/v1/execution/orders", ip))
+	})
+
+	t.Run("writes_share_a_budget", func(t *testing.T) {
+		ip := "10.0.0.4"
+		assert.NotEqual(t, http.StatusTooManyRequests, send("POST", "/api/v1/engine/stop", ip))
+		assert.NotEqual(t, http.StatusTooManyRequests, send("POST", "/api/v1/engine/kill-switch", ip))
+		assert.Equal(t, http.StatusTooManyRequests, send("POST", "/api/v1/config/reload", ip))
+		assert.Equal(t, http.StatusTooManyRequests, send("POST", "/api/v1/execution/positions/import", ip))
+
+		// Orders keep their own budget
+		assert.NotEqual(t, http.StatusTooManyRequests, send("POST", "/api/v1/execution/orders", ip))
+	})
+
+	t.Run("reads", func(t *testing.T) {
+		ip := "10.0.0.3"
+		for i := 0; i < 3; i++ {
+			assert.NotEqual(t, http.StatusTooManyRequests, send("GET", "/api/v1/strategies", ip))
+		}
+		assert.Equal(t, http.StatusTooManyRequests, send("GET", "/api/v1/strategies", ip))
+
+		// Writes do not count against the read limit
+		assert.NotEqual(t, http.StatusTooManyRequests, send("POST", "/api/v1/execution/orders", ip))
+	})
+}
@@ -3,7 +3,11 @@
 package api
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/alexherrero/sherwood/backend/config"
@@ -19,6 +23,25 @@ import (
 	"github.com/go-chi/httprate"
 )
 
+// Router is the API's HTTP handler. Shutdown drains the background work
+// its handlers started, such as queued backtests.
+type Router struct {
+	http.Handler
+	handler *Handler
+}
+
+// Shutdown stops accepting background work and waits for it to finish, or
+// for ctx to end. Call it after the HTTP server has stopped.
+//
+// Args:
+//   - ctx: Bounds the wait
+//
+// Returns:
+//   - error: If work was still running when ctx ended
+func (rt *Router) Shutdown(ctx context.Context) error {
+	return rt.handler.Shutdown(ctx)
+}
+
 // NewRouter creates and configures the main HTTP router.
 //
 // Args:
@@ -30,7 +53,7 @@ import (
 //   - wsManager: WebSocket manager for real-time updates
 //
 // Returns:
-//   - http.Handler: The configured router
+//   - *Router: The configured router
 func NewRouter(
 	cfg *config.Config,
 	registry *strategies.Registry,
@@ -39,7 +62,7 @@ func NewRouter(
 	engine *engine.TradingEngine,
 	wsManager *realtime.WebSocketManager,
 	notificationManager *notifications.Manager,
-) http.Handler {
+) *Router {
 	r := chi.NewRouter()
 
 	// Middleware stack
@@ -47,14 +70,15 @@ func NewRouter(
 	r.Use(TraceMiddleware)
 	r.Use(middleware.RealIP)
 	r.Use(zerologLogger)
+	// Outside Recoverer and the timeout so their error responses are encoded too
+	r.Use(compressResponses(cfg.CompressionMinBytes))
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
+	r.Use(requestTimeout(60*time.Second, streamMarketDataPath))
 
 	// Rate limiting - prevent abuse
-	// Global: 100 requests per minute per IP (protects against basic DoS)
-	r.Use(httprate.LimitByIP(100, 1*time.Minute))
-	// Burst protection: 20 requests per second per IP
-	r.Use(httprate.LimitByIP(20, 1*time.Second))
+	// Burst protection: 20 requests per second per IP, a backstop for every route.
+	// Per-minute limits are applied per route group below.
+	r.Use(httprate.LimitBy(20, 1*time.Second, httprate.KeyByIP, httprate.WithLimitHandler(rateLimitExceeded)))
 
 	// Request body size limit - prevent memory exhaustion attacks
 	r.Use(func(next http.Handler) http.Handler {
@@ -93,66 +117,107 @@ func NewRouter(
 		})
 	})
 
+	// OpenAPI spec of every route, public so tooling can fetch it
+	r.Get("/openapi.json", openAPIHandler(r))
+
 	// WebSocket endpoint (only if wsManager is available)
 	if wsManager != nil {
-		r.Get("/ws", h.wsManager.HandleWebSocket)
+		r.With(WebSocketAuthMiddleware(cfg)).Get("/ws", h.wsManager.HandleWebSocket)
 	}
 
-	// Health check endpoint
+	// Health check endpoints: combined view, liveness and readiness probes
 	r.Get("/health", h.HealthHandler)
+	r.Get("/livez", h.LivezHandler)
+	r.Get("/readyz", h.ReadyzHandler)
+
+	// Prometheus scrape endpoint, protected like the API
+	r.With(AuthMiddleware(cfg)).Get("/metrics", h.PrometheusMetricsHandler)
+
+	// Per-route-group limits. Both backtest endpoints share one budget, and
+	// every other mutating route shares the writes budget.
+	backtestLimit := rateLimitPerMinute(cfg.RateLimitBacktests)
+	orderLimit := rateLimitPerMinute(cfg.RateLimitOrders)
+	writeLimit := rateLimitPerMinute(cfg.RateLimitWrites)
 
 	// API v1 routes (protected)
 	r.Route("/api/v1", func(r chi.Router) {
 		r.Use(AuthMiddleware(cfg))
 		r.Use(AuditMiddleware)
+		r.Use(readsOnly(rateLimitPerMinute(cfg.RateLimitReads)))
 
 		// Strategies routes
 		r.Route("/strategies", func(r chi.Router) {
 			r.Get("/", h.ListStrategiesHandler)
 			r.Get("/{name}", h.GetStrategyHandler)
+			r.With(writeLimit).Patch("/{name}", h.UpdateStrategyHandler)
+			r.With(writeLimit).Post("/{name}/validate", h.ValidateStrategyHandler)
+			r.With(backtestLimit).Post("/{name}/backtest", h.RunStrategyBacktestHandler)
 		})
 
 		// Backtest routes
 		r.Route("/backtests", func(r chi.Router) {
-			r.Post("/", h.RunBacktestHandler)
+			r.With(backtestLimit).Post("/", h.RunBacktestHandler)
+			r.With(backtestLimit).Post("/sweep", h.RunSweepHandler)
+			r.With(backtestLimit).Post("/compare", h.CompareBacktestHandler)
 			r.Get("/{id}", h.GetBacktestResultHandler)
+			r.Get("/{id}/report", h.GetBacktestReportHandler)
+			r.Get("/{id}/montecarlo", h.GetBacktestMonteCarloHandler)
 		})
 
 		// Execution routes
 		r.Route("/execution", func(r chi.Router) {
 			r.Get("/orders", h.GetOrdersHandler)
-			r.Post("/orders", h.PlaceOrderHandler)
+			r.With(orderLimit).Post("/orders", h.PlaceOrderHandler)
+			r.Get("/orders/pending-confirmation", h.GetStagedOrdersHandler)
+			r.With(orderLimit).Post("/orders/{token}/confirm", h.ConfirmOrderHandler)
 			r.Get("/orders/{id}", h.GetOrderHandler)
-			r.Patch("/orders/{id}", h.ModifyOrderHandler) // New route
-			r.Delete("/orders/{id}", h.CancelOrderHandler)
+			r.With(writeLimit).Patch("/orders/{id}", h.ModifyOrderHandler)
+			r.With(writeLimit).Delete("/orders/{id}", h.CancelOrderHandler)
+			r.Get("/orders/{id}/trades", h.GetOrderTradesHandler)
+			r.Get("/orders/{id}/notes", h.GetOrderNotesHandler)
+			r.With(writeLimit).Post("/orders/{id}/notes", h.AddOrderNoteHandler)
+			r.Get("/orders/{id}/events", h.GetOrderEventsHandler)
 			r.Get("/history", h.GetOrderHistoryHandler) // Alias/wrapper for GetOrders
 			r.Get("/trades", h.GetTradesHandler)        // New route
 			r.Get("/positions", h.GetPositionsHandler)
+			r.With(writeLimit).Post("/positions/import", h.ImportPositionsHandler)
 			r.Get("/balance", h.GetBalanceHandler)
+			r.With(writeLimit).Post("/reset", h.ResetPaperHandler)
 		})
 
 		// Portfolio routes
 		r.Route("/portfolio", func(r chi.Router) {
 			r.Get("/summary", h.GetPortfolioSummaryHandler)
 			r.Get("/performance", h.GetPortfolioPerformanceHandler)
+			r.Get("/by-strategy", h.GetStrategyPerformanceHandler)
 		})
 
 		// Market Data routes
 		r.Route("/data", func(r chi.Router) {
 			r.Get("/history", h.GetHistoricalDataHandler)
+			r.Get("/ticker", h.GetTickerHandler)
+			r.Get("/stream", h.StreamMarketDataHandler)
 		})
 
 		// Engine routes
 		r.Route("/engine", func(r chi.Router) {
-			r.Post("/start", h.StartEngineHandler)
-			r.Post("/stop", h.StopEngineHandler)
+			r.Get("/status", h.EngineStatusHandler)
+			r.With(writeLimit).Post("/start", h.StartEngineHandler)
+			r.With(writeLimit).Post("/stop", h.StopEngineHandler)
+			r.With(writeLimit).Patch("/mode", h.SetEngineModeHandler)
+			r.With(writeLimit).Post("/resume-fetching", h.ResumeFetchingHandler)
+			r.With(writeLimit).Post("/kill-switch", h.KillSwitchHandler)
 		})
 
+		// Signal log routes
+		r.Get("/signals", h.GetSignalsHandler)
+		r.With(orderLimit).Post("/signals/ingest", h.IngestSignalHandler)
+
 		// Notification routes
 		r.Route("/notifications", func(r chi.Router) {
 			r.Get("/", h.GetNotificationsHandler)
-			r.Put("/read-all", h.MarkAllReadHandler)
-			r.Put("/{id}/read", h.MarkNotificationReadHandler)
+			r.With(writeLimit).Put("/read-all", h.MarkAllReadHandler)
+			r.With(writeLimit).Put("/{id}/read", h.MarkNotificationReadHandler)
 		})
 
 		// Config routes
@@ -160,9 +225,9 @@ func NewRouter(
 			r.Get("/", h.GetConfigHandler)
 			r.Get("/metrics", h.MetricsHandler)
 			r.Get("/validation", h.GetConfigValidationHandler)
-			r.Patch("/system", h.UpdateSystemConfigHandler)
-			r.Post("/rotate-key", h.RotateAPIKeyHandler)
-			r.Post("/reload", h.ReloadConfigHandler)
+			r.With(writeLimit).Patch("/system", h.UpdateSystemConfigHandler)
+			r.With(writeLimit).Post("/rotate-key", h.RotateAPIKeyHandler)
+			r.With(writeLimit).Post("/reload", h.ReloadConfigHandler)
 		})
 
 		// Status endpoint
@@ -178,7 +243,64 @@ func NewRouter(
 		})
 	})
 
-	return r
+	return &Router{Handler: r, handler: h}
+}
+
+// rateLimitExceeded responds to a rate-limited request with a JSON APIError.
+// httprate has already set X-RateLimit-Limit, X-RateLimit-Reset and
+// Retry-After (the limiter's window in seconds); Remaining is clamped to zero.
+func rateLimitExceeded(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-RateLimit-Remaining", "0")
+	retryAfter := w.Header().Get("Retry-After")
+	if retryAfter == "" {
+		retryAfter = "1"
+		w.Header().Set("Retry-After", retryAfter)
+	}
+	writeError(w, http.StatusTooManyRequests,
+		fmt.Sprintf("Rate limit exceeded, retry after %s seconds", retryAfter), "RATE_LIMITED")
+}
+
+// rateLimitPerMinute returns a per-IP limiter allowing limit requests per
+// minute, or a pass-through middleware if limit is 0.
+func rateLimitPerMinute(limit int) func(http.Handler) http.Handler {
+	if limit <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	return httprate.LimitBy(limit, time.Minute, httprate.KeyByIP, httprate.WithLimitHandler(rateLimitExceeded))
+}
+
+// readsOnly applies a limiter to GET and HEAD requests only.
+func readsOnly(limiter func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		limited := limiter(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				limited.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// streamMarketDataPath is the long-lived NDJSON stream, exempt from the request timeout.
+const streamMarketDataPath = "/api/v1/data/stream"
+
+// requestTimeout applies chi's Timeout middleware to every request except the
+// given streaming paths, which stay open until the client disconnects.
+func requestTimeout(timeout time.Duration, streamingPaths ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		timed := middleware.Timeout(timeout)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, path := range streamingPaths {
+				if r.URL.Path == path {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			timed.ServeHTTP(w, r)
+		})
+	}
 }
 
 // zerologLogger is middleware that logs requests using zerolog.
@@ -199,19 +321,30 @@ func zerologLogger(next http.Handler) http.Handler {
 }
 
 // newCORSMiddleware creates CORS middleware with origin whitelisting.
+// Allowed origins may be exact or patterns (see config.OriginMatcher); only
+// a request origin that matches is echoed back. The origins are re-parsed
+// when a config reload changes them.
 func newCORSMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
+	var (
+		mu      sync.Mutex
+		origins []string
+		matcher *config.OriginMatcher
+	)
+	currentMatcher := func() *config.OriginMatcher {
+		mu.Lock()
+		defer mu.Unlock()
+		if matcher == nil || !slices.Equal(origins, cfg.AllowedOrigins) {
+			origins = cfg.AllowedOrigins
+			// Reload validates origins, so errors here are already reported
+			matcher, _ = config.NewOriginMatcher(origins)
+		}
+		return matcher
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			origin := r.Header.Get("Origin")
-
-			// Check if origin is in allowed list
-			allowed := false
-			for _, allowedOrigin := range cfg.AllowedOrigins {
-				if origin == allowedOrigin {
-					allowed = true
-					break
-				}
-			}
+			allowed := currentMatcher().Allows(origin)
 
 			// Set CORS headers if origin is allowed
 			if allowed {
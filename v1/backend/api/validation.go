@@ -2,7 +2,11 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -12,20 +16,41 @@ var validate *validator.Validate
 
 func init() {
 	validate = validator.New()
+	// Report fields by their JSON names, as clients send them
+	validate.RegisterTagNameFunc(jsonFieldName)
 }
 
 // APIError represents a standard API error response.
 type APIError struct {
-	Error   string      `json:"error"`
-	Code    string      `json:"code"`
-	Details interface{} `json:"details,omitempty"`
+	Error   string       `json:"error"`
+	Code    string       `json:"code"`
+	Details interface{}  `json:"details,omitempty"`
+	Fields  []FieldError `json:"fields,omitempty"`
 }
 
 // ValidationError represents a validation error response.
 type ValidationError struct {
 	Error   string            `json:"error"`
 	Code    string            `json:"code"`
-	Details map[string]string `json:"details,omitempty"`
+	Details map[string]string `json:"details,omitempty"` // Message per Go field name
+	Fields  []FieldError      `json:"fields,omitempty"`
+}
+
+// FieldError describes one failed validation rule on a request field.
+type FieldError struct {
+	Field   string `json:"field"`           // JSON path, e.g. "end" or "tags[2]"
+	Rule    string `json:"rule"`            // Validator tag, e.g. "gtfield"
+	Param   string `json:"param,omitempty"` // Rule parameter, e.g. "start" or "10000000"
+	Message string `json:"message"`         // Readable reason, e.g. "must be after start"
+}
+
+// jsonFieldName returns the name a struct field has in JSON.
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
 }
 
 // validateStruct validates a struct and returns a ValidationError if invalid.
@@ -43,9 +68,10 @@ func validateStruct(s interface{}) *ValidationError {
 
 	// Extract field-level errors
 	details := make(map[string]string)
+	var fields []FieldError
 	if validationErrors, ok := err.(validator.ValidationErrors); ok {
 		for _, fieldError := range validationErrors {
-			field := fieldError.Field()
+			field := fieldError.StructField()
 			tag := fieldError.Tag()
 
 			// Create human-readable error message
@@ -74,6 +100,7 @@ func validateStruct(s interface{}) *ValidationError {
 			}
 
 			details[field] = message
+			fields = append(fields, newFieldError(fieldError, s))
 		}
 	}
 
@@ -81,6 +108,102 @@ func validateStruct(s interface{}) *ValidationError {
 		Error:   "Validation failed",
 		Code:    "VALIDATION_ERROR",
 		Details: details,
+		Fields:  fields,
+	}
+}
+
+// newFieldError describes a validator failure by JSON path and rule.
+// Parameters naming another field (gtfield and the like) are translated to
+// that field's JSON name.
+func newFieldError(fe validator.FieldError, s interface{}) FieldError {
+	// The namespace starts with the request type's name
+	_, path, _ := strings.Cut(fe.Namespace(), ".")
+	if path == "" {
+		path = fe.Field()
+	}
+
+	param := fe.Param()
+	if strings.HasSuffix(fe.Tag(), "field") {
+		t := reflect.TypeOf(s)
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Struct {
+			if sf, ok := t.FieldByName(param); ok {
+				param = jsonFieldName(sf)
+			}
+		}
+	}
+
+	return FieldError{
+		Field:   path,
+		Rule:    fe.Tag(),
+		Param:   param,
+		Message: fieldMessage(fe, param),
+	}
+}
+
+// fieldMessage returns why a field failed a rule, phrased to follow the
+// field name ("end must be after start").
+func fieldMessage(fe validator.FieldError, param string) string {
+	kind := fe.Kind()
+	sized := kind == reflect.String || kind == reflect.Slice || kind == reflect.Map || kind == reflect.Array
+	unit := "items"
+	if kind == reflect.String {
+		unit = "characters"
+	}
+	_, isTime := fe.Value().(time.Time)
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "required_if":
+		condition, value, _ := strings.Cut(param, " ")
+		return fmt.Sprintf("is required when %s is %s", strings.ToLower(condition), value)
+	case "min", "gte":
+		if sized {
+			return fmt.Sprintf("must have at least %s %s", param, unit)
+		}
+		return "must be at least " + param
+	case "max", "lte":
+		if sized {
+			return fmt.Sprintf("must have at most %s %s", param, unit)
+		}
+		return "must be at most " + param
+	case "gt":
+		if sized {
+			return fmt.Sprintf("must have more than %s %s", param, unit)
+		}
+		return "must be greater than " + param
+	case "lt":
+		if sized {
+			return fmt.Sprintf("must have fewer than %s %s", param, unit)
+		}
+		return "must be less than " + param
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(param), ", ")
+	case "gtfield":
+		if isTime {
+			return "must be after " + param
+		}
+		return "must be greater than " + param
+	case "gtefield":
+		if isTime {
+			return "must not be before " + param
+		}
+		return "must be at least " + param
+	case "ltfield":
+		if isTime {
+			return "must be before " + param
+		}
+		return "must be less than " + param
+	case "ltefield":
+		if isTime {
+			return "must not be after " + param
+		}
+		return "must be at most " + param
+	default:
+		return "failed the " + fe.Tag() + " rule"
 	}
 }
 
@@ -97,6 +220,7 @@ func writeValidationError(w http.ResponseWriter, err *ValidationError) {
 		Error:   err.Error,
 		Code:    err.Code,
 		Details: err.Details,
+		Fields:  err.Fields,
 	}
 	json.NewEncoder(w).Encode(resp)
 }
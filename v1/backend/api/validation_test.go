@@ -5,8 +5,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type TestStruct struct {
@@ -178,3 +180,61 @@ func TestWriteValidationError(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, "bar", details["foo"])
 }
+
+type fieldErrorRequest struct {
+	Name  string    `json:"name" validate:"required"`
+	Side  string    `json:"side" validate:"oneof=buy sell"`
+	Tags  []string  `json:"tags" validate:"max=2,dive,min=2"`
+	Count int       `json:"count" validate:"gt=0"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end" validate:"gtfield=Start"`
+	Note  string    `validate:"max=3"`
+}
+
+func TestValidateStruct_Fields(t *testing.T) {
+	now := time.Now()
+	err := validateStruct(fieldErrorRequest{
+		Side:  "hold",
+		Tags:  []string{"ok", "x"},
+		Start: now,
+		End:   now.Add(-time.Hour),
+		Note:  "too long",
+	})
+	require.NotNil(t, err)
+
+	assert.Equal(t, []FieldError{
+		{Field: "name", Rule: "required", Message: "is required"},
+		{Field: "side", Rule: "oneof", Param: "buy sell", Message: "must be one of: buy, sell"},
+		{Field: "tags[1]", Rule: "min", Param: "2", Message: "must have at least 2 characters"},
+		{Field: "count", Rule: "gt", Param: "0", Message: "must be greater than 0"},
+		{Field: "end", Rule: "gtfield", Param: "start", Message: "must be after start"},
+		{Field: "Note", Rule: "max", Param: "3", Message: "must have at most 3 characters"},
+	}, err.Fields)
+
+	// Details stay keyed by Go field name
+	assert.Equal(t, "This field is required", err.Details["Name"])
+	assert.Contains(t, err.Details, "End")
+}
+
+func TestWriteValidationError_Fields(t *testing.T) {
+	err := &ValidationError{
+		Error:  "Validation failed",
+		Code:   "VALIDATION_ERROR",
+		Fields: []FieldError{{Field: "end", Rule: "gtfield", Param: "start", Message: "must be after start"}},
+	}
+
+	recorder := httptest.NewRecorder()
+	writeValidationError(recorder, err)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	fields, ok := resp["fields"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, fields, 1)
+	assert.Equal(t, map[string]interface{}{
+		"field":   "end",
+		"rule":    "gtfield",
+		"param":   "start",
+		"message": "must be after start",
+	}, fields[0])
+}
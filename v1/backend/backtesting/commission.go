@@ -0,0 +1,96 @@
+// Package backtesting provides commission models for simulated fills.
+package backtesting
+
+import "fmt"
+
+// CommissionModel computes the fee charged for a simulated fill.
+// The engine calls it once on entry and once on exit.
+type CommissionModel interface {
+	// Commission returns the fee for filling quantity units at price.
+	//
+	// Args:
+	//   - price: Fill price per unit
+	//   - quantity: Number of units filled
+	//
+	// Returns:
+	//   - float64: Fee in account currency
+	Commission(price, quantity float64) float64
+
+	// String describes the model for reports.
+	String() string
+}
+
+// FlatCommission charges a fixed fee per fill, regardless of size.
+type FlatCommission struct {
+	// Fee is the amount charged per fill.
+	Fee float64
+}
+
+// Commission returns the flat fee.
+func (c FlatCommission) Commission(price, quantity float64) float64 {
+	return c.Fee
+}
+
+// String describes the model.
+func (c FlatCommission) String() string {
+	return fmt.Sprintf("$%.2f per trade", c.Fee)
+}
+
+// PercentCommission charges a fraction of the fill's notional value.
+type PercentCommission struct {
+	// Rate is the fraction of notional charged (0.001 = 0.1%).
+	Rate float64
+	// Minimum is the smallest fee charged per fill.
+	Minimum float64
+}
+
+// Commission returns Rate of the notional, but at least Minimum.
+func (c PercentCommission) Commission(price, quantity float64) float64 {
+	fee := price * quantity * c.Rate
+	if fee < c.Minimum {
+		return c.Minimum
+	}
+	return fee
+}
+
+// String describes the model.
+func (c PercentCommission) String() string {
+	if c.Minimum > 0 {
+		return fmt.Sprintf("%.3f%% of notional (min $%.2f)", c.Rate*100, c.Minimum)
+	}
+	return fmt.Sprintf("%.3f%% of notional", c.Rate*100)
+}
+
+// PerShareCommission charges a fee for every unit filled.
+type PerShareCommission struct {
+	// PerShare is the fee per unit.
+	PerShare float64
+	// Minimum is the smallest fee charged per fill.
+	Minimum float64
+}
+
+// Commission returns PerShare times quantity, but at least Minimum.
+func (c PerShareCommission) Commission(price, quantity float64) float64 {
+	fee := quantity * c.PerShare
+	if fee < c.Minimum {
+		return c.Minimum
+	}
+	return fee
+}
+
+// String describes the model.
+func (c PerShareCommission) String() string {
+	if c.Minimum > 0 {
+		return fmt.Sprintf("$%.4f per share (min $%.2f)", c.PerShare, c.Minimum)
+	}
+	return fmt.Sprintf("$%.4f per share", c.PerShare)
+}
+
+// commissionModel returns the configured model, defaulting to a flat fee of
+// Commission per trade.
+func (c BacktestConfig) commissionModel() CommissionModel {
+	if c.CommissionModel != nil {
+		return c.CommissionModel
+	}
+	return FlatCommission{Fee: c.Commission}
+}
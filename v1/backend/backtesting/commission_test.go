@@ -0,0 +1,96 @@
+package backtesting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/strategies"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedStrategy emits fixed signals keyed by the index of the latest bar.
+type scriptedStrategy struct {
+	*strategies.BaseStrategy
+	signals map[int]models.SignalType
+}
+
+func (s *scriptedStrategy) OnData(data []models.OHLCV) models.Signal {
+	if signal, ok := s.signals[len(data)-1]; ok {
+		return models.Signal{Type: signal}
+	}
+	return models.Signal{Type: models.SignalHold}
+}
+
+func (s *scriptedStrategy) Validate() error { return nil }
+
+func (s *scriptedStrategy) GetParameters() map[string]strategies.Parameter { return nil }
+
+// TestCommissionModels verifies each model's fee calculation.
+func TestCommissionModels(t *testing.T) {
+	tests := []struct {
+		name     string
+		model    CommissionModel
+		price    float64
+		quantity float64
+		expected float64
+	}{
+		{"Flat", FlatCommission{Fee: 1.5}, 100, 10, 1.5},
+		{"Percent", PercentCommission{Rate: 0.001}, 100, 10, 1.0},
+		{"PercentMinimum", PercentCommission{Rate: 0.001, Minimum: 2}, 100, 10, 2.0},
+		{"PerShare", PerShareCommission{PerShare: 0.005}, 100, 1000, 5.0},
+		{"PerShareMinimum", PerShareCommission{PerShare: 0.005, Minimum: 1}, 100, 10, 1.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, tt.model.Commission(tt.price, tt.quantity), 1e-9)
+		})
+	}
+}
+
+// TestEngine_Run_CommissionModel verifies fees are charged on entry and exit
+// and reported in the metrics.
+func TestEngine_Run_CommissionModel(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	prices := []float64{100, 100, 110, 110}
+	data := make([]models.OHLCV, len(prices))
+	for i, price := range prices {
+		data[i] = models.OHLCV{Timestamp: start.AddDate(0, 0, i), Symbol: "TEST", Close: price}
+	}
+	strategy := &scriptedStrategy{
+		BaseStrategy: strategies.NewBaseStrategy("scripted", "Scripted signals"),
+		signals:      map[int]models.SignalType{1: models.SignalBuy, 2: models.SignalSell},
+	}
+
+	t.Run("Percent", func(t *testing.T) {
+		result, err := NewEngine().Run(strategy, data, BacktestConfig{
+			Symbol:          "TEST",
+			InitialCapital:  10000,
+			PositionSize:    1000,
+			CommissionModel: PercentCommission{Rate: 0.01},
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Trades, 1)
+
+		// Entry: 10 shares @ 100 + $10 fee; exit: 10 @ 110 - $11 fee
+		assert.InDelta(t, 79.0, result.Trades[0].PnL, 1e-9)
+		assert.InDelta(t, 21.0, result.Metrics.TotalCommissions, 1e-9)
+		assert.InDelta(t, 10079.0, result.Metrics.FinalEquity, 1e-9)
+	})
+
+	t.Run("DefaultsToFlat", func(t *testing.T) {
+		result, err := NewEngine().Run(strategy, data, BacktestConfig{
+			Symbol:         "TEST",
+			InitialCapital: 10000,
+			PositionSize:   1000,
+			Commission:     2.0,
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Trades, 1)
+
+		assert.InDelta(t, 96.0, result.Trades[0].PnL, 1e-9)
+		assert.InDelta(t, 4.0, result.Metrics.TotalCommissions, 1e-9)
+	})
+}
@@ -0,0 +1,160 @@
+// Package backtesting provides head-to-head strategy comparisons.
+package backtesting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// CompareSpec describes one side of a strategy comparison.
+type CompareSpec struct {
+	// NewStrategy creates a fresh, uninitialized strategy instance.
+	NewStrategy StrategyFactory
+	// Config is the strategy configuration passed to Init.
+	Config map[string]interface{}
+}
+
+// ComparedRun is one side's outcome in a comparison.
+type ComparedRun struct {
+	JobID    string                 `json:"job_id"`
+	Strategy string                 `json:"strategy"`
+	Params   map[string]interface{} `json:"params"`
+	Metrics  *Metrics               `json:"metrics,omitempty"`
+	Error    string                 `json:"error,omitempty"`
+}
+
+// MetricsDiff holds key metrics of B minus A, so positive values mean B
+// scored higher (for max drawdown, higher is worse).
+type MetricsDiff struct {
+	TotalReturn float64 `json:"total_return"`
+	SharpeRatio float64 `json:"sharpe_ratio"`
+	MaxDrawdown float64 `json:"max_drawdown"`
+	TotalTrades int     `json:"total_trades"`
+}
+
+// ComparisonPoint is both equity curves at one timestamp. A side with no
+// point yet at the timestamp reports its initial capital.
+type ComparisonPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	EquityA   float64   `json:"equity_a"`
+	EquityB   float64   `json:"equity_b"`
+}
+
+// Comparison is the head-to-head outcome of two strategies backtested on
+// the same data.
+type Comparison struct {
+	A ComparedRun `json:"a"`
+	B ComparedRun `json:"b"`
+	// Diff is nil unless both runs succeeded.
+	Diff         *MetricsDiff      `json:"diff,omitempty"`
+	EquityCurves []ComparisonPoint `json:"equity_curves"`
+}
+
+// RunComparison backtests two strategies on the same bars in parallel on
+// the worker pool. Each run is recorded as a job, and a run that fails is
+// reported on its side rather than aborting the comparison.
+//
+// Args:
+//   - ctx: Cancels runs that have not been queued yet
+//   - a: First strategy
+//   - b: Second strategy
+//   - data: Historical OHLCV data (oldest first), shared by both runs
+//   - config: Backtest configuration shared by both runs
+//
+// Returns:
+//   - *Comparison: Both results, their metric diff and overlaid equity curves
+func (m *BacktestJobManager) RunComparison(ctx context.Context, a, b CompareSpec, data []models.OHLCV, config BacktestConfig) *Comparison {
+	specs := []CompareSpec{a, b}
+	runs := make([]JobFunc, len(specs))
+	names := make([]string, len(specs))
+	for i, spec := range specs {
+		strategy := spec.NewStrategy()
+		names[i] = strategy.Name()
+		runs[i] = func() (*BacktestResult, error) {
+			if err := strategy.Init(spec.Config); err != nil {
+				return nil, fmt.Errorf("failed to initialize strategy: %w", err)
+			}
+			return NewEngine().Run(strategy, data, config)
+		}
+	}
+
+	jobs := m.RunBatch(ctx, runs)
+	sides := make([]ComparedRun, len(jobs))
+	results := make([]*BacktestResult, len(jobs))
+	for i, job := range jobs {
+		sides[i] = ComparedRun{JobID: job.ID, Strategy: names[i], Params: specs[i].Config, Error: job.Error}
+		if job.Result != nil {
+			sides[i].Metrics = job.Result.Metrics
+			results[i] = job.Result
+		}
+	}
+
+	comparison := &Comparison{A: sides[0], B: sides[1]}
+	if results[0] != nil && results[1] != nil {
+		comparison.Diff = DiffMetrics(results[0].Metrics, results[1].Metrics)
+	}
+	comparison.EquityCurves = overlayEquity(results[0], results[1], config.InitialCapital)
+	return comparison
+}
+
+// DiffMetrics returns the key metrics of b minus a.
+//
+// Args:
+//   - a: Baseline metrics
+//   - b: Compared metrics
+//
+// Returns:
+//   - *MetricsDiff: The differences
+func DiffMetrics(a, b *Metrics) *MetricsDiff {
+	return &MetricsDiff{
+		TotalReturn: b.TotalReturn - a.TotalReturn,
+		SharpeRatio: b.SharpeRatio - a.SharpeRatio,
+		MaxDrawdown: b.MaxDrawdown - a.MaxDrawdown,
+		TotalTrades: b.TotalTrades - a.TotalTrades,
+	}
+}
+
+// overlayEquity merges two equity curves on their timestamps, carrying each
+// side's last equity forward. A failed run (nil result) contributes no
+// points and stays at the initial capital.
+func overlayEquity(a, b *BacktestResult, initialCapital float64) []ComparisonPoint {
+	var curveA, curveB []EquityPoint
+	if a != nil {
+		curveA = a.EquityCurve
+	}
+	if b != nil {
+		curveB = b.EquityCurve
+	}
+
+	seen := make(map[time.Time]bool, len(curveA)+len(curveB))
+	var timestamps []time.Time
+	for _, curve := range [][]EquityPoint{curveA, curveB} {
+		for _, p := range curve {
+			if !seen[p.Timestamp] {
+				seen[p.Timestamp] = true
+				timestamps = append(timestamps, p.Timestamp)
+			}
+		}
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i].Before(timestamps[j]) })
+
+	points := make([]ComparisonPoint, 0, len(timestamps))
+	equityA, equityB := initialCapital, initialCapital
+	i, j := 0, 0
+	for _, ts := range timestamps {
+		for i < len(curveA) && !curveA[i].Timestamp.After(ts) {
+			equityA = curveA[i].Equity
+			i++
+		}
+		for j < len(curveB) && !curveB[j].Timestamp.After(ts) {
+			equityB = curveB[j].Equity
+			j++
+		}
+		points = append(points, ComparisonPoint{Timestamp: ts, EquityA: equityA, EquityB: equityB})
+	}
+	return points
+}
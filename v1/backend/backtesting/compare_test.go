@@ -0,0 +1,84 @@
+package backtesting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBacktestJobManager_RunComparison verifies both strategies run on the
+// same data and their results are diffed and overlaid.
+func TestBacktestJobManager_RunComparison(t *testing.T) {
+	m := NewBacktestJobManager(2)
+	data := generateTestOHLCVData(60, "TEST")
+	config := BacktestConfig{Symbol: "TEST", InitialCapital: 10000}
+
+	comparison := m.RunComparison(context.Background(),
+		CompareSpec{NewStrategy: newMACrossover, Config: map[string]interface{}{"short_period": 3, "long_period": 10}},
+		CompareSpec{NewStrategy: newMACrossover, Config: map[string]interface{}{"short_period": 5, "long_period": 20}},
+		data, config)
+
+	require.NotNil(t, comparison.A.Metrics)
+	require.NotNil(t, comparison.B.Metrics)
+	assert.Equal(t, "ma_crossover", comparison.A.Strategy)
+	assert.NotEqual(t, comparison.A.JobID, comparison.B.JobID)
+	for _, id := range []string{comparison.A.JobID, comparison.B.JobID} {
+		job, ok := m.Get(id)
+		require.True(t, ok)
+		assert.Equal(t, JobCompleted, job.Status)
+	}
+
+	require.NotNil(t, comparison.Diff)
+	a, b := comparison.A.Metrics, comparison.B.Metrics
+	assert.InDelta(t, b.TotalReturn-a.TotalReturn, comparison.Diff.TotalReturn, 1e-12)
+	assert.InDelta(t, b.SharpeRatio-a.SharpeRatio, comparison.Diff.SharpeRatio, 1e-12)
+	assert.InDelta(t, b.MaxDrawdown-a.MaxDrawdown, comparison.Diff.MaxDrawdown, 1e-12)
+	assert.Equal(t, b.TotalTrades-a.TotalTrades, comparison.Diff.TotalTrades)
+
+	// Same bars, so one overlaid point per bar after the first
+	require.Len(t, comparison.EquityCurves, len(data)-1)
+	last := comparison.EquityCurves[len(comparison.EquityCurves)-1]
+	assert.Equal(t, data[len(data)-1].Timestamp, last.Timestamp)
+}
+
+// TestBacktestJobManager_RunComparison_FailedSide verifies a strategy that
+// rejects its config fails its side only.
+func TestBacktestJobManager_RunComparison_FailedSide(t *testing.T) {
+	m := NewBacktestJobManager(1)
+	data := generateTestOHLCVData(30, "TEST")
+
+	comparison := m.RunComparison(context.Background(),
+		CompareSpec{NewStrategy: newMACrossover},
+		CompareSpec{NewStrategy: newMACrossover, Config: map[string]interface{}{"short_period": 30, "long_period": 10}},
+		data, BacktestConfig{Symbol: "TEST", InitialCapital: 10000})
+
+	assert.NotNil(t, comparison.A.Metrics)
+	assert.Nil(t, comparison.B.Metrics)
+	assert.Contains(t, comparison.B.Error, "failed to initialize strategy")
+	assert.Nil(t, comparison.Diff)
+	require.NotEmpty(t, comparison.EquityCurves)
+	for _, p := range comparison.EquityCurves {
+		assert.Equal(t, 10000.0, p.EquityB)
+	}
+}
+
+// TestOverlayEquity verifies curves with different timestamps carry forward.
+func TestOverlayEquity(t *testing.T) {
+	t0 := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	a := &BacktestResult{EquityCurve: []EquityPoint{
+		{Timestamp: t0, Equity: 100},
+		{Timestamp: t0.Add(2 * time.Hour), Equity: 120},
+	}}
+	b := &BacktestResult{EquityCurve: []EquityPoint{
+		{Timestamp: t0.Add(time.Hour), Equity: 90},
+	}}
+
+	assert.Equal(t, []ComparisonPoint{
+		{Timestamp: t0, EquityA: 100, EquityB: 50},
+		{Timestamp: t0.Add(time.Hour), EquityA: 100, EquityB: 90},
+		{Timestamp: t0.Add(2 * time.Hour), EquityA: 120, EquityB: 90},
+	}, overlayEquity(a, b, 50))
+}
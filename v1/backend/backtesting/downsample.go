@@ -0,0 +1,72 @@
+// Package backtesting provides equity curve downsampling for charting.
+package backtesting
+
+import "math"
+
+// DownsampleEquity reduces an equity curve to at most maxPoints points using
+// Largest-Triangle-Three-Buckets, which keeps the curve's visual shape,
+// including peaks and drawdown troughs, far better than taking every nth
+// point. The first and last points are always kept. The input is not
+// modified; metrics should still be computed from the full curve.
+//
+// Args:
+//   - curve: Equity points sorted by timestamp
+//   - maxPoints: Target point count (<= 0 or >= len(curve) returns curve as is; values below 3 are raised to 3)
+//
+// Returns:
+//   - []EquityPoint: The downsampled curve
+func DownsampleEquity(curve []EquityPoint, maxPoints int) []EquityPoint {
+	if maxPoints <= 0 || len(curve) <= maxPoints {
+		return curve
+	}
+	maxPoints = max(maxPoints, 3)
+
+	// x is seconds since the first point, so uneven spacing (weekends,
+	// gaps) is weighed correctly
+	x := func(i int) float64 {
+		return curve[i].Timestamp.Sub(curve[0].Timestamp).Seconds()
+	}
+
+	sampled := make([]EquityPoint, 0, maxPoints)
+	sampled = append(sampled, curve[0])
+
+	// Every point but the first and last falls into one of maxPoints-2
+	// buckets; each bucket contributes the point forming the largest
+	// triangle with the previously kept point and the next bucket's average.
+	buckets := maxPoints - 2
+	size := float64(len(curve)-2) / float64(buckets)
+	bound := func(i int) int {
+		return int(float64(i)*size) + 1
+	}
+
+	kept := 0
+	for b := 0; b < buckets; b++ {
+		start, end := bound(b), bound(b+1)
+		nextStart, nextEnd := end, bound(b+2)
+		if b == buckets-1 {
+			nextStart, nextEnd = len(curve)-1, len(curve)
+		}
+
+		var avgX, avgY float64
+		for i := nextStart; i < nextEnd; i++ {
+			avgX += x(i)
+			avgY += curve[i].Equity
+		}
+		n := float64(nextEnd - nextStart)
+		avgX /= n
+		avgY /= n
+
+		keptX, keptY := x(kept), curve[kept].Equity
+		best, bestArea := start, -1.0
+		for i := start; i < end; i++ {
+			area := math.Abs((keptX-avgX)*(curve[i].Equity-keptY) - (keptX-x(i))*(avgY-keptY))
+			if area > bestArea {
+				best, bestArea = i, area
+			}
+		}
+		sampled = append(sampled, curve[best])
+		kept = best
+	}
+
+	return append(sampled, curve[len(curve)-1])
+}
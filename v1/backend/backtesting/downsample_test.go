@@ -0,0 +1,63 @@
+package backtesting
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// minuteCurve builds a curve of n one-minute points from equity(i).
+func minuteCurve(n int, equity func(i int) float64) []EquityPoint {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	curve := make([]EquityPoint, n)
+	for i := range curve {
+		curve[i] = EquityPoint{Timestamp: start.Add(time.Duration(i) * time.Minute), Equity: equity(i)}
+	}
+	return curve
+}
+
+// TestDownsampleEquity verifies the curve is reduced to the target size
+// while keeping its endpoints, order and extremes.
+func TestDownsampleEquity(t *testing.T) {
+	curve := minuteCurve(100000, func(i int) float64 {
+		return 10000 + 500*math.Sin(float64(i)/5000)
+	})
+	// A one-minute crash that a stride would almost certainly skip
+	curve[61234].Equity = 7000
+
+	sampled := DownsampleEquity(curve, 500)
+	require.Len(t, sampled, 500)
+	assert.Equal(t, curve[0], sampled[0])
+	assert.Equal(t, curve[len(curve)-1], sampled[len(sampled)-1])
+	for i := 1; i < len(sampled); i++ {
+		assert.True(t, sampled[i].Timestamp.After(sampled[i-1].Timestamp), "points must stay in order")
+	}
+
+	lowest := sampled[0].Equity
+	for _, p := range sampled {
+		lowest = min(lowest, p.Equity)
+	}
+	assert.Equal(t, 7000.0, lowest, "the drawdown trough should survive downsampling")
+
+	// The full curve is untouched
+	assert.Len(t, curve, 100000)
+}
+
+// TestDownsampleEquity_NoOp verifies short curves and disabled targets are
+// returned as is.
+func TestDownsampleEquity_NoOp(t *testing.T) {
+	curve := minuteCurve(10, func(i int) float64 { return float64(i) })
+
+	assert.Equal(t, curve, DownsampleEquity(curve, 0))
+	assert.Equal(t, curve, DownsampleEquity(curve, 10))
+	assert.Equal(t, curve, DownsampleEquity(curve, 50))
+	assert.Empty(t, DownsampleEquity(nil, 100))
+
+	small := DownsampleEquity(curve, 1)
+	require.Len(t, small, 3)
+	assert.Equal(t, curve[0], small[0])
+	assert.Equal(t, curve[9], small[2])
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/alexherrero/sherwood/backend/models"
 	"github.com/alexherrero/sherwood/backend/strategies"
+	"github.com/alexherrero/sherwood/backend/utils/ids"
 	"github.com/rs/zerolog/log"
 )
 
@@ -22,8 +23,35 @@ type BacktestConfig struct {
 	InitialCapital float64
 	// PositionSize is the fixed position size (0 = use all capital).
 	PositionSize float64
-	// Commission is the commission per trade.
+	// Commission is the flat fee per trade, used when CommissionModel is nil.
 	Commission float64
+	// CommissionModel computes fees on entry and exit (nil = FlatCommission{Fee: Commission}).
+	CommissionModel CommissionModel
+	// WarmupBars is the number of leading bars used only to seed strategy
+	// indicators (0 = none). The strategy still sees the full history, but
+	// no trades or equity are recorded before bar WarmupBars, so the equity
+	// curve and metrics cover only the tradeable period. Bar 0 is never
+	// traded since a signal needs a prior bar, so 0 and 1 are equivalent.
+	WarmupBars int
+	// AllowPyramiding lets buy signals add to an open position and sell
+	// signals with a SizeFraction scale out of it. By default a buy only
+	// enters when flat and a sell always closes the whole position.
+	AllowPyramiding bool
+	// FillModel sets the price signals fill at ("" = CloseOfSignalBar).
+	// OpenOfNextBar avoids filling at a close the strategy already saw.
+	FillModel FillModel
+	// MinBars is the fewest bars a backtest runs on, whatever the strategy
+	// (0 = only the strategy's own requirement; see RequiredBars).
+	MinBars int
+}
+
+// signalFraction returns the signal's size fraction, treating anything
+// outside (0, 1] as the full size.
+func signalFraction(signal models.Signal) float64 {
+	if signal.SizeFraction <= 0 || signal.SizeFraction > 1 {
+		return 1
+	}
+	return signal.SizeFraction
 }
 
 // BacktestResult holds the results of a backtest run.
@@ -55,8 +83,18 @@ type SimulatedTrade struct {
 	EntryPrice float64          `json:"entry_price"`
 	ExitPrice  float64          `json:"exit_price"`
 	Quantity   float64          `json:"quantity"`
-	PnL        float64          `json:"pnl"`
+	PnL        float64          `json:"pnl"` // Same as NetPnL
 	PnLPercent float64          `json:"pnl_percent"`
+	// GrossPnL is the P&L before commissions: (ExitPrice - EntryPrice) * Quantity.
+	GrossPnL float64 `json:"gross_pnl"`
+	// Commission is the round-trip fee: this quantity's share of the entry
+	// fees plus the exit fee.
+	Commission float64 `json:"commission"`
+	// NetPnL is GrossPnL minus Commission.
+	NetPnL float64 `json:"net_pnl"`
+	// BreakEvenPrice is the exit price at which NetPnL would have been zero
+	// with the same fees: EntryPrice plus Commission per unit.
+	BreakEvenPrice float64 `json:"break_even_price"`
 }
 
 // EquityPoint represents equity at a point in time.
@@ -67,7 +105,7 @@ type EquityPoint struct {
 
 // Engine runs backtests for trading strategies.
 type Engine struct {
-	idCounter int
+	ids ids.Generator
 }
 
 // NewEngine creates a new backtest engine.
@@ -75,10 +113,20 @@ type Engine struct {
 // Returns:
 //   - *Engine: The backtest engine
 func NewEngine() *Engine {
-	return &Engine{idCounter: 0}
+	return &Engine{ids: ids.NewTimeOrdered("bt-")}
 }
 
-// Run executes a backtest for a strategy against historical data.
+// SetIDGenerator replaces the source of result IDs, e.g. with a deterministic
+// generator in tests.
+//
+// Args:
+//   - gen: ID generator for new results
+func (e *Engine) SetIDGenerator(gen ids.Generator) {
+	e.ids = gen
+}
+
+// Run executes a backtest for a strategy against historical data. Data too
+// short for the strategy to ever signal fails with *InsufficientDataError.
 //
 // Args:
 //   - strategy: The trading strategy to test
@@ -92,10 +140,22 @@ func (e *Engine) Run(strategy strategies.Strategy, data []models.OHLCV, config B
 	if len(data) == 0 {
 		return nil, fmt.Errorf("no data provided for backtest")
 	}
+	if required := RequiredBars(strategy, config.MinBars); len(data) < required {
+		return nil, &InsufficientDataError{Required: required, Provided: len(data)}
+	}
+	if config.WarmupBars < 0 {
+		return nil, fmt.Errorf("warm-up bars must not be negative, got %d", config.WarmupBars)
+	}
+	if config.WarmupBars >= len(data) {
+		return nil, fmt.Errorf("warm-up of %d bars leaves none of the %d bars to trade", config.WarmupBars, len(data))
+	}
+	fillModel, err := config.fillModel()
+	if err != nil {
+		return nil, err
+	}
 
-	e.idCounter++
 	result := &BacktestResult{
-		ID:          fmt.Sprintf("bt-%06d", e.idCounter),
+		ID:          e.ids.NewID(),
 		Config:      config,
 		Strategy:    strategy.Name(),
 		Trades:      []SimulatedTrade{},
@@ -106,116 +166,158 @@ func (e *Engine) Run(strategy strategies.Strategy, data []models.OHLCV, config B
 	capital := config.InitialCapital
 	cash := capital
 	position := 0.0
-	positionCost := 0.0
+	positionCost := 0.0 // Cost of the open position, entry fees included
 	var entryTime time.Time
-	var entryPrice float64
+	var entryPrice float64 // Quantity-weighted average entry price
+	commission := config.commissionModel()
+	totalCommission := 0.0
 
-	log.Info().
-		Str("strategy", strategy.Name()).
-		Str("symbol", config.Symbol).
-		Int("data_points", len(data)).
-		Msg("Starting backtest")
+	// exit sells quantity at exitPrice during the bar, releasing its share
+	// of the position's cost, and records the trade.
+	exit := func(quantity float64, bar models.OHLCV, exitPrice float64) float64 {
+		fee := commission.Commission(exitPrice, quantity)
+		totalCommission += fee
+		proceeds := quantity*exitPrice - fee
+		cost := positionCost
+		if quantity < position {
+			cost = positionCost * quantity / position
+		}
+		pnl := proceeds - cost
+		// cost is the entry notional at the average price plus entry fees
+		fees := cost - quantity*entryPrice + fee
 
-	// Iterate through data
-	for i := 1; i < len(data); i++ {
-		// Get signal from strategy using data up to current bar
-		signal := strategy.OnData(data[:i+1])
-		bar := data[i]
+		result.Trades = append(result.Trades, SimulatedTrade{
+			EntryTime:      entryTime,
+			ExitTime:       bar.Timestamp,
+			Symbol:         config.Symbol,
+			Side:           models.OrderSideBuy,
+			EntryPrice:     entryPrice,
+			ExitPrice:      exitPrice,
+			Quantity:       quantity,
+			PnL:            pnl,
+			PnLPercent:     (exitPrice - entryPrice) / entryPrice * 100,
+			GrossPnL:       (exitPrice - entryPrice) * quantity,
+			Commission:     fees,
+			NetPnL:         pnl,
+			BreakEvenPrice: entryPrice + fees/quantity,
+		})
 
-		// Record equity
-		currentEquity := cash
-		if position > 0 {
-			currentEquity += position * bar.Close
+		cash += proceeds
+		if quantity < position {
+			position -= quantity
+			positionCost -= cost
+		} else {
+			position = 0
+			positionCost = 0
 		}
-		result.EquityCurve = append(result.EquityCurve, EquityPoint{
-			Timestamp: bar.Timestamp,
-			Equity:    currentEquity,
-		})
+		return pnl
+	}
 
-		// Process signals
+	// execute acts on a signal, filling at price during the bar.
+	execute := func(signal models.Signal, bar models.OHLCV, price float64) {
 		switch signal.Type {
 		case models.SignalBuy:
-			if position == 0 { // Only enter if flat
+			// Only enter if flat, unless adding to the position is allowed
+			if position == 0 || config.AllowPyramiding {
 				positionSize := config.PositionSize
 				if positionSize == 0 {
 					positionSize = cash * 0.95 // Use 95% of capital
 				}
-				quantity := positionSize / bar.Close
-				cost := quantity*bar.Close + config.Commission
-
-				if cost <= cash {
-					position = quantity
-					positionCost = cost
-					entryPrice = bar.Close
-					entryTime = bar.Timestamp
+				if config.AllowPyramiding {
+					positionSize *= signalFraction(signal)
+				}
+				quantity := positionSize / price
+				fee := commission.Commission(price, quantity)
+				cost := quantity*price + fee
+
+				if quantity > 0 && cost <= cash {
+					totalCommission += fee
+					if position == 0 {
+						entryTime = bar.Timestamp
+					}
+					entryPrice = (entryPrice*position + price*quantity) / (position + quantity)
+					position += quantity
+					positionCost += cost
 					cash -= cost
 
 					log.Debug().
 						Time("time", bar.Timestamp).
-						Float64("price", bar.Close).
+						Float64("price", price).
 						Float64("quantity", quantity).
+						Float64("position", position).
 						Msg("BUY signal executed")
 				}
 			}
 
 		case models.SignalSell:
 			if position > 0 { // Only exit if have position
-				exitPrice := bar.Close
-				proceeds := position*exitPrice - config.Commission
-				pnl := proceeds - positionCost
-				pnlPercent := (exitPrice - entryPrice) / entryPrice * 100
-
-				trade := SimulatedTrade{
-					EntryTime:  entryTime,
-					ExitTime:   bar.Timestamp,
-					Symbol:     config.Symbol,
-					Side:       models.OrderSideBuy,
-					EntryPrice: entryPrice,
-					ExitPrice:  exitPrice,
-					Quantity:   position,
-					PnL:        pnl,
-					PnLPercent: pnlPercent,
+				quantity := position
+				if config.AllowPyramiding {
+					quantity = position * signalFraction(signal)
 				}
-				result.Trades = append(result.Trades, trade)
-
-				cash += proceeds
-				position = 0
-				positionCost = 0
+				pnl := exit(quantity, bar, price)
 
 				log.Debug().
 					Time("time", bar.Timestamp).
-					Float64("price", bar.Close).
+					Float64("price", price).
+					Float64("quantity", quantity).
 					Float64("pnl", pnl).
 					Msg("SELL signal executed")
 			}
 		}
 	}
 
+	log.Info().
+		Str("strategy", strategy.Name()).
+		Str("symbol", config.Symbol).
+		Int("data_points", len(data)).
+		Msg("Starting backtest")
+
+	// Iterate through data. Bar 0 only seeds the strategy; trading starts at
+	// bar 1, or at WarmupBars when that is later
+	var pending models.Signal // Signal awaiting the next bar's open
+	for i := 1; i < len(data); i++ {
+		// Get signal from strategy using data up to current bar
+		signal := strategy.OnData(data[:i+1])
+		bar := data[i]
+
+		// Warm-up bars only feed the strategy
+		if i < config.WarmupBars {
+			continue
+		}
+
+		// Fill the previous bar's signal at the open, before this bar moves
+		if fillModel == OpenOfNextBar {
+			execute(pending, bar, bar.Open)
+		}
+
+		// Record equity
+		currentEquity := cash
+		if position > 0 {
+			currentEquity += position * bar.Close
+		}
+		result.EquityCurve = append(result.EquityCurve, EquityPoint{
+			Timestamp: bar.Timestamp,
+			Equity:    currentEquity,
+		})
+
+		// Signals fill at this bar's close, or at the next bar's open
+		if fillModel == OpenOfNextBar {
+			pending = signal
+		} else {
+			execute(signal, bar, bar.Close)
+		}
+	}
+
 	// Close any open position at end
 	if position > 0 {
-		lastBar := data[len(data)-1]
-		exitPrice := lastBar.Close
-		proceeds := position*exitPrice - config.Commission
-		pnl := proceeds - positionCost
-		pnlPercent := (exitPrice - entryPrice) / entryPrice * 100
-
-		trade := SimulatedTrade{
-			EntryTime:  entryTime,
-			ExitTime:   lastBar.Timestamp,
-			Symbol:     config.Symbol,
-			Side:       models.OrderSideBuy,
-			EntryPrice: entryPrice,
-			ExitPrice:  exitPrice,
-			Quantity:   position,
-			PnL:        pnl,
-			PnLPercent: pnlPercent,
-		}
-		result.Trades = append(result.Trades, trade)
-		cash += proceeds
+		last := data[len(data)-1]
+		exit(position, last, last.Close)
 	}
 
 	// Calculate metrics
 	result.Metrics = CalculateMetrics(result.Trades, result.EquityCurve, config.InitialCapital)
+	result.Metrics.TotalCommissions = totalCommission
 	result.CompletedAt = time.Now()
 
 	log.Info().
@@ -30,6 +30,41 @@ func TestEngine_Run_EmptyData(t *testing.T) {
 	assert.Contains(t, err.Error(), "no data provided")
 }
 
+// TestEngine_Run_InsufficientData verifies data shorter than the strategy's
+// longest period, or the configured floor, is rejected.
+func TestEngine_Run_InsufficientData(t *testing.T) {
+	engine := NewEngine()
+	strategy := strategies.NewMACrossover()
+	require.NoError(t, strategy.Init(map[string]interface{}{
+		"short_period": 3,
+		"long_period":  5,
+	}))
+	config := BacktestConfig{Symbol: "TEST", InitialCapital: 10000}
+
+	_, err := engine.Run(strategy, generateTestOHLCVData(5, "TEST"), config)
+	var insufficient *InsufficientDataError
+	require.ErrorAs(t, err, &insufficient)
+	assert.Equal(t, 6, insufficient.Required)
+	assert.Equal(t, 5, insufficient.Provided)
+
+	_, err = engine.Run(strategy, generateTestOHLCVData(6, "TEST"), config)
+	assert.NoError(t, err)
+
+	config.MinBars = 10
+	_, err = engine.Run(strategy, generateTestOHLCVData(6, "TEST"), config)
+	require.ErrorAs(t, err, &insufficient)
+	assert.Equal(t, 10, insufficient.Required)
+}
+
+// TestRequiredBars verifies the requirement follows configured periods and
+// falls back to parameter defaults.
+func TestRequiredBars(t *testing.T) {
+	assert.Equal(t, 21, RequiredBars(strategies.NewMACrossover(), 0), "default long_period of 20")
+	assert.Equal(t, 27, RequiredBars(strategies.NewMACDStrategy(), 0), "default slow_period of 26")
+	assert.Equal(t, 2, RequiredBars(&sizedStrategy{}, 0), "no period parameters")
+	assert.Equal(t, 50, RequiredBars(&sizedStrategy{}, 50))
+}
+
 // TestEngine_Run_BasicBacktest verifies basic backtest execution.
 func TestEngine_Run_BasicBacktest(t *testing.T) {
 	engine := NewEngine()
@@ -108,6 +143,45 @@ func TestEngine_Run_EquityCurve(t *testing.T) {
 	}
 }
 
+// TestEngine_Run_WarmupBars verifies warm-up bars feed the strategy but are
+// excluded from the equity curve and trades.
+func TestEngine_Run_WarmupBars(t *testing.T) {
+	engine := NewEngine()
+	data := generateTestOHLCVData(10, "TEST")
+	strategy := &sizedStrategy{
+		BaseStrategy: strategies.NewBaseStrategy("sized", "Sized signals"),
+		signals: map[int]models.Signal{
+			2: {Type: models.SignalBuy},
+			3: {Type: models.SignalSell},
+			5: {Type: models.SignalBuy},
+			7: {Type: models.SignalSell},
+		},
+	}
+	config := BacktestConfig{Symbol: "TEST", InitialCapital: 10000, WarmupBars: 4}
+
+	result, err := engine.Run(strategy, data, config)
+	require.NoError(t, err)
+	require.Len(t, result.EquityCurve, len(data)-4)
+	assert.Equal(t, data[4].Timestamp, result.EquityCurve[0].Timestamp)
+	require.Len(t, result.Trades, 1, "the round trip during warm-up is not traded")
+	assert.Equal(t, data[5].Timestamp, result.Trades[0].EntryTime)
+
+	// 0 and 1 both start at bar 1
+	for _, warmup := range []int{0, 1} {
+		config.WarmupBars = warmup
+		result, err = engine.Run(strategy, data, config)
+		require.NoError(t, err)
+		assert.Len(t, result.EquityCurve, len(data)-1)
+	}
+
+	config.WarmupBars = -1
+	_, err = engine.Run(strategy, data, config)
+	assert.Error(t, err)
+	config.WarmupBars = len(data)
+	_, err = engine.Run(strategy, data, config)
+	assert.Error(t, err)
+}
+
 // TestEngine_Run_ResultContainsConfig verifies config is stored in result.
 func TestEngine_Run_ResultContainsConfig(t *testing.T) {
 	engine := NewEngine()
@@ -240,3 +314,186 @@ func generateTrendingData() []models.OHLCV {
 
 	return data
 }
+
+// sizedStrategy emits fixed signals, with size fractions, keyed by the index
+// of the latest bar.
+type sizedStrategy struct {
+	*strategies.BaseStrategy
+	signals map[int]models.Signal
+}
+
+func (s *sizedStrategy) OnData(data []models.OHLCV) models.Signal {
+	if signal, ok := s.signals[len(data)-1]; ok {
+		return signal
+	}
+	return models.Signal{Type: models.SignalHold}
+}
+
+func (s *sizedStrategy) Validate() error { return nil }
+
+func (s *sizedStrategy) GetParameters() map[string]strategies.Parameter { return nil }
+
+// TestEngine_Run_Pyramiding verifies repeated buys add to the position at a
+// weighted-average entry, fractional sells scale out, and the remainder is
+// closed at the end.
+func TestEngine_Run_Pyramiding(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	prices := []float64{100, 100, 120, 150, 150, 160}
+	data := make([]models.OHLCV, len(prices))
+	for i, price := range prices {
+		data[i] = models.OHLCV{Timestamp: start.AddDate(0, 0, i), Symbol: "TEST", Close: price}
+	}
+	strategy := &sizedStrategy{
+		BaseStrategy: strategies.NewBaseStrategy("sized", "Sized signals"),
+		signals: map[int]models.Signal{
+			1: {Type: models.SignalBuy},
+			2: {Type: models.SignalBuy, SizeFraction: 0.5},
+			4: {Type: models.SignalSell, SizeFraction: 0.5},
+		},
+	}
+	config := BacktestConfig{
+		Symbol:          "TEST",
+		InitialCapital:  10000,
+		PositionSize:    1200,
+		Commission:      1.0,
+		AllowPyramiding: true,
+	}
+
+	t.Run("Enabled", func(t *testing.T) {
+		result, err := NewEngine().Run(strategy, data, config)
+		require.NoError(t, err)
+		require.Len(t, result.Trades, 2)
+
+		// 12 @ 100 ($1201) then 5 @ 120 ($601): 17 units costing $1802
+		entry := (12*100.0 + 5*120.0) / 17
+		scaleOut := result.Trades[0]
+		assert.InDelta(t, 8.5, scaleOut.Quantity, 1e-9)
+		assert.InDelta(t, entry, scaleOut.EntryPrice, 1e-9)
+		assert.Equal(t, data[1].Timestamp, scaleOut.EntryTime)
+		assert.InDelta(t, 8.5*150-1-1802.0/2, scaleOut.PnL, 1e-9)
+		// Half of the two entry fees plus the exit fee
+		assert.InDelta(t, 2.0, scaleOut.Commission, 1e-9)
+		assert.InDelta(t, scaleOut.GrossPnL-2, scaleOut.NetPnL, 1e-9)
+
+		final := result.Trades[1]
+		assert.InDelta(t, 8.5, final.Quantity, 1e-9)
+		assert.Equal(t, data[5].Timestamp, final.ExitTime)
+		assert.InDelta(t, 8.5*160-1-1802.0/2, final.PnL, 1e-9)
+
+		// Equity before the final bar marks the full 17 units, then 8.5
+		assert.InDelta(t, 10000-1802+17*150, result.EquityCurve[2].Equity, 1e-9)
+		cashAfterScaleOut := 10000 - 1802 + 8.5*150 - 1
+		assert.InDelta(t, cashAfterScaleOut+8.5*160, result.EquityCurve[4].Equity, 1e-9)
+		assert.InDelta(t, 4.0, result.Metrics.TotalCommissions, 1e-9)
+	})
+
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		config := config
+		config.AllowPyramiding = false
+		result, err := NewEngine().Run(strategy, data, config)
+		require.NoError(t, err)
+		require.Len(t, result.Trades, 1)
+
+		// Second buy ignored and the sell closes everything
+		trade := result.Trades[0]
+		assert.InDelta(t, 12.0, trade.Quantity, 1e-9)
+		assert.InDelta(t, 100.0, trade.EntryPrice, 1e-9)
+		assert.Equal(t, data[4].Timestamp, trade.ExitTime)
+	})
+}
+
+// TestEngine_Run_BreakEven verifies trades report gross and net P&L, the
+// round-trip commission and the break-even exit price.
+func TestEngine_Run_BreakEven(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	prices := []float64{100, 100, 110, 120}
+	data := make([]models.OHLCV, len(prices))
+	for i, price := range prices {
+		data[i] = models.OHLCV{Timestamp: start.AddDate(0, 0, i), Symbol: "TEST", Close: price}
+	}
+	strategy := &sizedStrategy{
+		BaseStrategy: strategies.NewBaseStrategy("sized", "Sized signals"),
+		signals: map[int]models.Signal{
+			1: {Type: models.SignalBuy},
+			3: {Type: models.SignalSell},
+		},
+	}
+	config := BacktestConfig{
+		Symbol:         "TEST",
+		InitialCapital: 10000,
+		PositionSize:   1000,
+		Commission:     5.0,
+	}
+
+	result, err := NewEngine().Run(strategy, data, config)
+	require.NoError(t, err)
+	require.Len(t, result.Trades, 1)
+
+	// 10 @ 100, sold @ 120, $5 fee each way
+	trade := result.Trades[0]
+	assert.InDelta(t, 200.0, trade.GrossPnL, 1e-9)
+	assert.InDelta(t, 10.0, trade.Commission, 1e-9)
+	assert.InDelta(t, 190.0, trade.NetPnL, 1e-9)
+	assert.Equal(t, trade.NetPnL, trade.PnL)
+	assert.InDelta(t, 101.0, trade.BreakEvenPrice, 1e-9)
+
+	markdown := NewReport(result).Markdown()
+	assert.Contains(t, markdown, "| Break-even | Gross P&L | Fees | Net P&L |")
+	assert.Contains(t, markdown, "| $101.00 | $+200.00 | $10.00 | $+190.00 |")
+}
+
+// TestEngine_Run_FillModel verifies next-bar fills use the following bar's
+// open, while the default fills at the signal bar's close.
+func TestEngine_Run_FillModel(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Each bar gaps from its open to its close
+	opens := []float64{100, 101, 111, 121, 131, 141}
+	closes := []float64{100, 110, 120, 130, 140, 150}
+	data := make([]models.OHLCV, len(opens))
+	for i := range opens {
+		data[i] = models.OHLCV{Timestamp: start.AddDate(0, 0, i), Symbol: "TEST", Open: opens[i], Close: closes[i]}
+	}
+	strategy := &sizedStrategy{
+		BaseStrategy: strategies.NewBaseStrategy("sized", "Sized signals"),
+		signals: map[int]models.Signal{
+			1: {Type: models.SignalBuy},
+			3: {Type: models.SignalSell},
+			5: {Type: models.SignalBuy}, // Last bar: never filled at the next open
+		},
+	}
+	config := BacktestConfig{Symbol: "TEST", InitialCapital: 10000, PositionSize: 1000}
+
+	t.Run("CloseOfSignalBar", func(t *testing.T) {
+		result, err := NewEngine().Run(strategy, data, config)
+		require.NoError(t, err)
+		require.Len(t, result.Trades, 2)
+		assert.Equal(t, 110.0, result.Trades[0].EntryPrice)
+		assert.Equal(t, data[1].Timestamp, result.Trades[0].EntryTime)
+		assert.Equal(t, 130.0, result.Trades[0].ExitPrice)
+		assert.Equal(t, data[3].Timestamp, result.Trades[0].ExitTime)
+	})
+
+	t.Run("OpenOfNextBar", func(t *testing.T) {
+		config := config
+		config.FillModel = OpenOfNextBar
+		result, err := NewEngine().Run(strategy, data, config)
+		require.NoError(t, err)
+		require.Len(t, result.Trades, 1)
+		trade := result.Trades[0]
+		assert.Equal(t, 111.0, trade.EntryPrice)
+		assert.Equal(t, data[2].Timestamp, trade.EntryTime)
+		assert.Equal(t, 131.0, trade.ExitPrice)
+		assert.Equal(t, data[4].Timestamp, trade.ExitTime)
+
+		// The entry bar's equity already holds the position, marked at its close
+		quantity := 1000.0 / 111
+		assert.InDelta(t, 10000-1000+quantity*120, result.EquityCurve[1].Equity, 1e-9)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		config := config
+		config.FillModel = "vwap"
+		_, err := NewEngine().Run(strategy, data, config)
+		assert.ErrorContains(t, err, `unknown fill model "vwap"`)
+	})
+}
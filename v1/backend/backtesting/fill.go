@@ -0,0 +1,41 @@
+// Package backtesting provides fill models for simulated orders.
+package backtesting
+
+import "fmt"
+
+// FillModel decides the price a signal is filled at.
+type FillModel string
+
+const (
+	// CloseOfSignalBar fills a signal at the close of the bar that produced
+	// it. The strategy has seen that close before deciding, so this assumes
+	// an order placed at the very instant the bar closes; results are
+	// optimistic whenever that price would not be attainable in practice.
+	CloseOfSignalBar FillModel = "close"
+	// OpenOfNextBar defers a signal by one bar and fills it at the next
+	// bar's open, the first price available after the signal is known. A
+	// signal on the final bar is never filled.
+	OpenOfNextBar FillModel = "next_open"
+)
+
+// String describes the model for reports.
+func (m FillModel) String() string {
+	switch m {
+	case OpenOfNextBar:
+		return "next bar's open"
+	default:
+		return "signal bar's close"
+	}
+}
+
+// fillModel returns the configured model, defaulting to CloseOfSignalBar.
+func (c BacktestConfig) fillModel() (FillModel, error) {
+	switch c.FillModel {
+	case "", CloseOfSignalBar:
+		return CloseOfSignalBar, nil
+	case OpenOfNextBar:
+		return OpenOfNextBar, nil
+	default:
+		return "", fmt.Errorf("unknown fill model %q", string(c.FillModel))
+	}
+}
@@ -0,0 +1,451 @@
+// Package backtesting provides an asynchronous backtest job queue.
+package backtesting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/utils/ids"
+	"github.com/rs/zerolog/log"
+)
+
+// JobStatus is the lifecycle state of a backtest job.
+type JobStatus string
+
+const (
+	// JobPending means the job is queued and waiting for a worker.
+	JobPending JobStatus = "pending"
+	// JobRunning means a worker is executing the job.
+	JobRunning JobStatus = "running"
+	// JobCompleted means the backtest finished successfully.
+	JobCompleted JobStatus = "completed"
+	// JobFailed means the backtest returned an error.
+	JobFailed JobStatus = "failed"
+)
+
+// DefaultBacktestWorkers is the worker pool size used when none is configured.
+const DefaultBacktestWorkers = 2
+
+// backtestQueuePerWorker bounds queued jobs relative to the pool size.
+const backtestQueuePerWorker = 10
+
+// maxRetainedJobs bounds how many jobs are kept; beyond it the oldest
+// finished jobs are evicted as new ones are registered.
+const maxRetainedJobs = 1000
+
+// ErrJobManagerClosed is returned for jobs submitted after Shutdown.
+var ErrJobManagerClosed = errors.New("backtest job manager is shut down")
+
+// JobFunc performs a backtest and returns its result.
+type JobFunc func() (*BacktestResult, error)
+
+// SweepJobFunc performs a parameter sweep and returns its ranked report.
+// The context ends when the sweep should stop running combinations.
+type SweepJobFunc func(ctx context.Context) (*SweepReport, error)
+
+// JobError is a job failure with a machine-readable code, such as the data
+// provider error that stopped it. The code is recorded on the job.
+type JobError struct {
+	Code string
+	Err  error
+}
+
+// Error returns the underlying error's message.
+func (e *JobError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *JobError) Unwrap() error {
+	return e.Err
+}
+
+// BacktestJob tracks a submitted backtest.
+type BacktestJob struct {
+	ID          string          `json:"id"`
+	Status      JobStatus       `json:"status"`
+	Error       string          `json:"error,omitempty"`
+	ErrorCode   string          `json:"error_code,omitempty"`
+	Result      *BacktestResult `json:"-"`
+	Sweep       *SweepReport    `json:"-"` // Set instead of Result for a sweep
+	SubmittedAt time.Time       `json:"submitted_at"`
+	StartedAt   time.Time       `json:"started_at,omitempty"`
+	CompletedAt time.Time       `json:"completed_at,omitempty"`
+
+	run      JobFunc
+	runSweep func() (*SweepReport, error)
+	done     chan struct{} // closed once the job has finished
+}
+
+// BacktestJobManager runs backtests on a bounded worker pool and holds job
+// state. Finished jobs are evicted oldest first once more than
+// maxRetainedJobs are held.
+type BacktestJobManager struct {
+	workers   int
+	queue     chan *BacktestJob
+	sweeps    chan struct{} // Slots for sweeps running outside the pool
+	jobs      map[string]*BacktestJob
+	order     []string // Job IDs in registration order, for eviction
+	maxJobs   int
+	ids       ids.Generator
+	mu        sync.RWMutex
+	startOnce sync.Once
+	wg        sync.WaitGroup
+
+	closeMu sync.RWMutex // Held to send on queue; write-locked to close it
+	closed  bool
+
+	// Cancels running sweeps when Shutdown gives up waiting
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewBacktestJobManager creates a job manager. Workers start on the first
+// asynchronous submission.
+//
+// Args:
+//   - workers: Worker pool size (<= 0 uses DefaultBacktestWorkers)
+//
+// Returns:
+//   - *BacktestJobManager: The job manager
+func NewBacktestJobManager(workers int) *BacktestJobManager {
+	if workers <= 0 {
+		workers = DefaultBacktestWorkers
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &BacktestJobManager{
+		workers: workers,
+		queue:   make(chan *BacktestJob, workers*backtestQueuePerWorker),
+		sweeps:  make(chan struct{}, workers*backtestQueuePerWorker),
+		jobs:    make(map[string]*BacktestJob),
+		maxJobs: maxRetainedJobs,
+		ids:     ids.NewTimeOrdered("bt-"),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// SetIDGenerator replaces the source of job IDs, e.g. with a deterministic
+// generator in tests.
+//
+// Args:
+//   - gen: ID generator for new jobs
+func (m *BacktestJobManager) SetIDGenerator(gen ids.Generator) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ids = gen
+}
+
+// Submit queues a backtest for asynchronous execution.
+//
+// Args:
+//   - run: The backtest to execute
+//
+// Returns:
+//   - BacktestJob: Snapshot of the pending job
+//   - error: If the queue is full or the manager is shut down
+func (m *BacktestJobManager) Submit(run JobFunc) (BacktestJob, error) {
+	return m.enqueue(run, nil)
+}
+
+// SubmitSweep starts a parameter sweep as a single job, whose report is
+// stored on the job. The sweep runs in its own goroutine rather than on a
+// worker, so its combinations can fan out across the whole pool with
+// RunSweep. Its context is cancelled if Shutdown gives up waiting.
+//
+// Args:
+//   - run: The sweep to execute
+//
+// Returns:
+//   - BacktestJob: Snapshot of the pending job
+//   - error: If too many sweeps are running or the manager is shut down
+func (m *BacktestJobManager) SubmitSweep(run SweepJobFunc) (BacktestJob, error) {
+	m.startOnce.Do(m.startWorkers)
+
+	m.closeMu.RLock()
+	defer m.closeMu.RUnlock()
+	if m.closed {
+		return BacktestJob{}, ErrJobManagerClosed
+	}
+	select {
+	case m.sweeps <- struct{}{}:
+	default:
+		return BacktestJob{}, fmt.Errorf("backtest queue is full (%d sweeps running)", cap(m.sweeps))
+	}
+
+	job := m.newJob(nil, func() (*SweepReport, error) { return run(m.ctx) })
+	snapshot := m.snapshot(job)
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		defer func() { <-m.sweeps }()
+		m.execute(job)
+	}()
+	return snapshot, nil
+}
+
+// enqueue registers a job and queues it without blocking.
+func (m *BacktestJobManager) enqueue(run JobFunc, runSweep func() (*SweepReport, error)) (BacktestJob, error) {
+	m.startOnce.Do(m.startWorkers)
+
+	m.closeMu.RLock()
+	defer m.closeMu.RUnlock()
+	if m.closed {
+		return BacktestJob{}, ErrJobManagerClosed
+	}
+
+	job := m.newJob(run, runSweep)
+	select {
+	case m.queue <- job:
+		return m.snapshot(job), nil
+	default:
+		m.mu.Lock()
+		delete(m.jobs, job.ID)
+		m.mu.Unlock()
+		return BacktestJob{}, fmt.Errorf("backtest queue is full (%d pending)", cap(m.queue))
+	}
+}
+
+// RunSync executes a backtest in the caller's goroutine and records it as a job.
+//
+// Args:
+//   - run: The backtest to execute
+//
+// Returns:
+//   - BacktestJob: Snapshot of the finished job
+func (m *BacktestJobManager) RunSync(run JobFunc) BacktestJob {
+	job := m.newJob(run, nil)
+	m.execute(job)
+	return m.snapshot(job)
+}
+
+// RunSweepSync executes a parameter sweep in the caller's goroutine and
+// records it as a job.
+//
+// Args:
+//   - ctx: Passed to the sweep, e.g. the request context
+//   - run: The sweep to execute
+//
+// Returns:
+//   - BacktestJob: Snapshot of the finished job
+func (m *BacktestJobManager) RunSweepSync(ctx context.Context, run SweepJobFunc) BacktestJob {
+	job := m.newJob(nil, func() (*SweepReport, error) { return run(ctx) })
+	m.execute(job)
+	return m.snapshot(job)
+}
+
+// RunBatch queues backtests on the worker pool and waits for all of them,
+// so a batch runs in parallel across workers. Unlike Submit it waits for
+// queue space instead of failing when the queue is full.
+//
+// Args:
+//   - ctx: Cancels jobs that have not been queued yet
+//   - runs: The backtests to execute
+//
+// Returns:
+//   - []BacktestJob: Snapshots of the finished jobs, in the order of runs
+func (m *BacktestJobManager) RunBatch(ctx context.Context, runs []JobFunc) []BacktestJob {
+	m.startOnce.Do(m.startWorkers)
+
+	m.closeMu.RLock()
+	jobs := make([]*BacktestJob, len(runs))
+	for i, run := range runs {
+		job := m.newJob(run, nil)
+		jobs[i] = job
+		if m.closed {
+			m.fail(job, ErrJobManagerClosed)
+			continue
+		}
+		select {
+		case m.queue <- job:
+		case <-ctx.Done():
+			m.fail(job, ctx.Err())
+		}
+	}
+	m.closeMu.RUnlock()
+
+	snapshots := make([]BacktestJob, len(jobs))
+	for i, job := range jobs {
+		<-job.done
+		snapshots[i] = m.snapshot(job)
+	}
+	return snapshots
+}
+
+// Get returns a snapshot of a job by ID.
+//
+// Args:
+//   - id: Job ID
+//
+// Returns:
+//   - BacktestJob: The job snapshot
+//   - bool: False if no job has that ID
+func (m *BacktestJobManager) Get(id string) (BacktestJob, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return BacktestJob{}, false
+	}
+	return *job, true
+}
+
+// Shutdown stops accepting jobs and waits for the workers to finish the
+// queued and running ones, or for ctx to end. Running sweeps are cancelled
+// if ctx ends first.
+//
+// Args:
+//   - ctx: Bounds the wait
+//
+// Returns:
+//   - error: If ctx ended before the workers finished
+func (m *BacktestJobManager) Shutdown(ctx context.Context) error {
+	m.closeMu.Lock()
+	if !m.closed {
+		m.closed = true
+		close(m.queue)
+	}
+	m.closeMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		m.cancel()
+		log.Info().Msg("Backtest worker pool stopped")
+		return nil
+	case <-ctx.Done():
+		m.cancel()
+		return fmt.Errorf("backtest jobs still running at shutdown: %w", ctx.Err())
+	}
+}
+
+// newJob registers a pending job, evicting the oldest finished jobs beyond
+// the retention limit.
+func (m *BacktestJobManager) newJob(run JobFunc, runSweep func() (*SweepReport, error)) *BacktestJob {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job := &BacktestJob{
+		ID:          m.ids.NewID(),
+		Status:      JobPending,
+		SubmittedAt: time.Now(),
+		run:         run,
+		runSweep:    runSweep,
+		done:        make(chan struct{}),
+	}
+	m.jobs[job.ID] = job
+	m.order = append(m.order, job.ID)
+	m.evictLocked()
+	return job
+}
+
+// evictLocked drops the oldest finished jobs while more than maxJobs are
+// held. Pending and running jobs are never evicted. Callers hold m.mu.
+func (m *BacktestJobManager) evictLocked() {
+	if len(m.jobs) <= m.maxJobs {
+		return
+	}
+	kept := m.order[:0]
+	for _, id := range m.order {
+		job, ok := m.jobs[id]
+		if !ok {
+			continue
+		}
+		finished := job.Status == JobCompleted || job.Status == JobFailed
+		if finished && len(m.jobs) > m.maxJobs {
+			delete(m.jobs, id)
+			continue
+		}
+		kept = append(kept, id)
+	}
+	m.order = kept
+}
+
+// snapshot copies a job under the read lock.
+func (m *BacktestJobManager) snapshot(job *BacktestJob) BacktestJob {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return *job
+}
+
+// startWorkers launches the worker pool.
+func (m *BacktestJobManager) startWorkers() {
+	for i := 0; i < m.workers; i++ {
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			for job := range m.queue {
+				m.execute(job)
+			}
+		}()
+	}
+	log.Info().Int("workers", m.workers).Msg("Backtest worker pool started")
+}
+
+// execute runs a job and records its outcome.
+func (m *BacktestJobManager) execute(job *BacktestJob) {
+	m.mu.Lock()
+	job.Status = JobRunning
+	job.StartedAt = time.Now()
+	run, runSweep := job.run, job.runSweep
+	m.mu.Unlock()
+
+	var result *BacktestResult
+	var sweep *SweepReport
+	var err error
+	if runSweep != nil {
+		sweep, err = safeRun(runSweep)
+	} else {
+		result, err = safeRun(run)
+	}
+
+	if err != nil {
+		m.fail(job, err)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	defer close(job.done)
+	job.CompletedAt = time.Now()
+	job.run, job.runSweep = nil, nil
+	if result != nil {
+		result.ID = job.ID
+	}
+	job.Status = JobCompleted
+	job.Result = result
+	job.Sweep = sweep
+}
+
+// fail records a job as failed, with the code of a JobError.
+func (m *BacktestJobManager) fail(job *BacktestJob, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	defer close(job.done)
+	job.CompletedAt = time.Now()
+	job.run, job.runSweep = nil, nil
+	job.Status = JobFailed
+	job.Error = err.Error()
+	var jobErr *JobError
+	if errors.As(err, &jobErr) {
+		job.ErrorCode = jobErr.Code
+	}
+	log.Error().Err(err).Str("job_id", job.ID).Msg("Backtest job failed")
+}
+
+// safeRun executes a job, converting a panic into an error so a bad
+// strategy cannot take down a worker.
+func safeRun[T any](run func() (T, error)) (result T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("backtest panicked: %v", r)
+		}
+	}()
+	return run()
+}
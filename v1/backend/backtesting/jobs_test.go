@@ -0,0 +1,280 @@
+package backtesting
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/utils/ids"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBacktestJobManager_RunSync verifies synchronous jobs are recorded.
+func TestBacktestJobManager_RunSync(t *testing.T) {
+	m := NewBacktestJobManager(1)
+
+	job := m.RunSync(func() (*BacktestResult, error) {
+		return &BacktestResult{Metrics: &Metrics{TotalTrades: 3}}, nil
+	})
+	assert.Equal(t, JobCompleted, job.Status)
+	require.NotNil(t, job.Result)
+	assert.Equal(t, job.ID, job.Result.ID)
+
+	failed := m.RunSync(func() (*BacktestResult, error) {
+		return nil, fmt.Errorf("boom")
+	})
+	assert.Equal(t, JobFailed, failed.Status)
+	assert.Equal(t, "boom", failed.Error)
+	assert.Empty(t, failed.ErrorCode)
+
+	coded := m.RunSync(func() (*BacktestResult, error) {
+		return nil, &JobError{Code: "PROVIDER_NOT_FOUND", Err: fmt.Errorf("no data")}
+	})
+	assert.Equal(t, "no data", coded.Error)
+	assert.Equal(t, "PROVIDER_NOT_FOUND", coded.ErrorCode)
+
+	panicked := m.RunSync(func() (*BacktestResult, error) {
+		panic("bad strategy")
+	})
+	assert.Equal(t, JobFailed, panicked.Status)
+	assert.Contains(t, panicked.Error, "bad strategy")
+
+	stored, ok := m.Get(job.ID)
+	assert.True(t, ok)
+	assert.Equal(t, JobCompleted, stored.Status)
+
+	_, ok = m.Get("missing")
+	assert.False(t, ok)
+}
+
+// TestBacktestJobManager_IDs verifies job IDs are unique across managers and
+// the generator can be injected.
+func TestBacktestJobManager_IDs(t *testing.T) {
+	run := func() (*BacktestResult, error) { return &BacktestResult{}, nil }
+
+	first := NewBacktestJobManager(1).RunSync(run)
+	second := NewBacktestJobManager(1).RunSync(run)
+	assert.NotEqual(t, first.ID, second.ID)
+	assert.Contains(t, first.ID, "bt-")
+
+	m := NewBacktestJobManager(1)
+	m.SetIDGenerator(ids.NewSequential("bt-"))
+	assert.Equal(t, "bt-000001", m.RunSync(run).ID)
+	assert.Equal(t, "bt-000002", m.RunSync(run).ID)
+}
+
+// TestBacktestJobManager_Submit verifies jobs move through pending, running and completed.
+func TestBacktestJobManager_Submit(t *testing.T) {
+	m := NewBacktestJobManager(1)
+	release := make(chan struct{})
+
+	job, err := m.Submit(func() (*BacktestResult, error) {
+		<-release
+		return &BacktestResult{}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, JobPending, job.Status)
+
+	require.Eventually(t, func() bool {
+		j, _ := m.Get(job.ID)
+		return j.Status == JobRunning
+	}, time.Second, 5*time.Millisecond)
+
+	close(release)
+	require.Eventually(t, func() bool {
+		j, _ := m.Get(job.ID)
+		return j.Status == JobCompleted
+	}, time.Second, 5*time.Millisecond)
+}
+
+// TestBacktestJobManager_BoundedWorkers verifies concurrency never exceeds the pool size.
+func TestBacktestJobManager_BoundedWorkers(t *testing.T) {
+	m := NewBacktestJobManager(2)
+
+	var running, peak int32
+	ids := make([]string, 0, 6)
+	for i := 0; i < 6; i++ {
+		job, err := m.Submit(func() (*BacktestResult, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return &BacktestResult{}, nil
+		})
+		require.NoError(t, err)
+		ids = append(ids, job.ID)
+	}
+
+	require.Eventually(t, func() bool {
+		for _, id := range ids {
+			if j, _ := m.Get(id); j.Status != JobCompleted {
+				return false
+			}
+		}
+		return true
+	}, 2*time.Second, 5*time.Millisecond)
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+}
+
+// TestBacktestJobManager_QueueFull verifies submissions are rejected when the queue is full.
+func TestBacktestJobManager_QueueFull(t *testing.T) {
+	m := NewBacktestJobManager(1)
+	release := make(chan struct{})
+	defer close(release)
+
+	block := func() (*BacktestResult, error) {
+		<-release
+		return &BacktestResult{}, nil
+	}
+
+	// One running plus a full queue
+	var err error
+	for i := 0; i <= 1+backtestQueuePerWorker && err == nil; i++ {
+		_, err = m.Submit(block)
+	}
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "queue is full")
+}
+
+// TestBacktestJobManager_RunBatch verifies a batch larger than the queue runs
+// in parallel and returns results in order.
+func TestBacktestJobManager_RunBatch(t *testing.T) {
+	m := NewBacktestJobManager(2)
+
+	var running, peak int32
+	runs := make([]JobFunc, 3*2*backtestQueuePerWorker)
+	for i := range runs {
+		i := i
+		runs[i] = func() (*BacktestResult, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			if i == 1 {
+				return nil, fmt.Errorf("bad config")
+			}
+			return &BacktestResult{Metrics: &Metrics{TotalTrades: i}}, nil
+		}
+	}
+
+	jobs := m.RunBatch(context.Background(), runs)
+	require.Len(t, jobs, len(runs))
+	for i, job := range jobs {
+		if i == 1 {
+			assert.Equal(t, JobFailed, job.Status)
+			assert.Equal(t, "bad config", job.Error)
+			continue
+		}
+		require.Equal(t, JobCompleted, job.Status)
+		assert.Equal(t, i, job.Result.Metrics.TotalTrades)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&peak))
+}
+
+// TestBacktestJobManager_RunBatch_Cancelled verifies unqueued jobs fail when the context ends.
+func TestBacktestJobManager_RunBatch_Cancelled(t *testing.T) {
+	m := NewBacktestJobManager(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// With the context already cancelled, jobs only run if the queue has room
+	runs := make([]JobFunc, backtestQueuePerWorker+5)
+	for i := range runs {
+		runs[i] = func() (*BacktestResult, error) { return &BacktestResult{}, nil }
+	}
+
+	jobs := m.RunBatch(ctx, runs)
+	require.Len(t, jobs, len(runs))
+	failed := 0
+	for _, job := range jobs {
+		if job.Status == JobFailed {
+			failed++
+			assert.Equal(t, context.Canceled.Error(), job.Error)
+		}
+	}
+	assert.Greater(t, failed, 0)
+}
+
+// TestBacktestJobManager_Eviction verifies the oldest finished jobs are
+// dropped beyond the retention limit, but unfinished ones are kept.
+func TestBacktestJobManager_Eviction(t *testing.T) {
+	m := NewBacktestJobManager(1)
+	m.maxJobs = 2
+
+	release := make(chan struct{})
+	running, err := m.Submit(func() (*BacktestResult, error) {
+		<-release
+		return &BacktestResult{}, nil
+	})
+	require.NoError(t, err)
+
+	run := func() (*BacktestResult, error) { return &BacktestResult{}, nil }
+	first := m.RunSync(run)
+	second := m.RunSync(run)
+	third := m.RunSync(run)
+
+	_, ok := m.Get(running.ID)
+	assert.True(t, ok, "unfinished jobs are never evicted")
+	_, ok = m.Get(first.ID)
+	assert.False(t, ok)
+	_, ok = m.Get(second.ID)
+	assert.False(t, ok)
+	_, ok = m.Get(third.ID)
+	assert.True(t, ok)
+	close(release)
+}
+
+// TestBacktestJobManager_Shutdown verifies queued jobs finish before
+// Shutdown returns and later submissions are refused.
+func TestBacktestJobManager_Shutdown(t *testing.T) {
+	m := NewBacktestJobManager(1)
+
+	var finished atomic.Int32
+	for i := 0; i < 3; i++ {
+		_, err := m.Submit(func() (*BacktestResult, error) {
+			time.Sleep(10 * time.Millisecond)
+			finished.Add(1)
+			return &BacktestResult{}, nil
+		})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, m.Shutdown(context.Background()))
+	assert.Equal(t, int32(3), finished.Load())
+
+	_, err := m.Submit(func() (*BacktestResult, error) { return &BacktestResult{}, nil })
+	assert.ErrorIs(t, err, ErrJobManagerClosed)
+	batch := m.RunBatch(context.Background(), []JobFunc{func() (*BacktestResult, error) { return &BacktestResult{}, nil }})
+	assert.Equal(t, JobFailed, batch[0].Status)
+	assert.NoError(t, m.Shutdown(context.Background()), "shutting down twice is harmless")
+}
+
+// TestBacktestJobManager_Shutdown_Deadline verifies Shutdown gives up when
+// its context ends first.
+func TestBacktestJobManager_Shutdown_Deadline(t *testing.T) {
+	m := NewBacktestJobManager(1)
+	release := make(chan struct{})
+	defer close(release)
+	_, err := m.Submit(func() (*BacktestResult, error) {
+		<-release
+		return &BacktestResult{}, nil
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, m.Shutdown(ctx), context.DeadlineExceeded)
+}
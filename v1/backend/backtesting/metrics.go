@@ -33,10 +33,22 @@ type Metrics struct {
 	AverageLoss float64 `json:"average_loss"`
 	// ProfitFactor is the ratio of gross profits to gross losses.
 	ProfitFactor float64 `json:"profit_factor"`
+	// AverageTradePnL is the mean P&L across all trades.
+	AverageTradePnL float64 `json:"average_trade_pnl"`
+	// LargestWin is the profit on the best single trade.
+	LargestWin float64 `json:"largest_win"`
+	// LargestLoss is the loss on the worst single trade, as a positive amount.
+	LargestLoss float64 `json:"largest_loss"`
+	// MaxConsecutiveWins is the longest run of winning trades.
+	MaxConsecutiveWins int `json:"max_consecutive_wins"`
+	// MaxConsecutiveLosses is the longest run of losing trades.
+	MaxConsecutiveLosses int `json:"max_consecutive_losses"`
 	// Volatility is the standard deviation of returns.
 	Volatility float64 `json:"volatility"`
 	// FinalEquity is the ending equity.
 	FinalEquity float64 `json:"final_equity"`
+	// TotalCommissions is the sum of fees paid on entries and exits.
+	TotalCommissions float64 `json:"total_commissions"`
 }
 
 // CalculateMetrics computes performance metrics from backtest results.
@@ -84,25 +96,39 @@ func CalculateMetrics(trades []SimulatedTrade, equityCurve []EquityPoint, initia
 	m.MaxDrawdown = maxDD
 	m.MaxDrawdownAbs = maxDDAbs
 
-	// Trade statistics
+	// Trade statistics. A break-even trade ends both win and loss streaks.
 	var wins, losses float64
 	grossProfit := 0.0
 	grossLoss := 0.0
+	totalPnL := 0.0
+	winStreak, lossStreak := 0, 0
 
 	for _, trade := range trades {
+		totalPnL += trade.PnL
 		if trade.PnL > 0 {
 			m.WinningTrades++
 			wins += trade.PnL
 			grossProfit += trade.PnL
+			m.LargestWin = math.Max(m.LargestWin, trade.PnL)
+			winStreak++
+			lossStreak = 0
 		} else if trade.PnL < 0 {
 			m.LosingTrades++
 			losses += math.Abs(trade.PnL)
 			grossLoss += math.Abs(trade.PnL)
+			m.LargestLoss = math.Max(m.LargestLoss, math.Abs(trade.PnL))
+			lossStreak++
+			winStreak = 0
+		} else {
+			winStreak, lossStreak = 0, 0
 		}
+		m.MaxConsecutiveWins = max(m.MaxConsecutiveWins, winStreak)
+		m.MaxConsecutiveLosses = max(m.MaxConsecutiveLosses, lossStreak)
 	}
 
 	if m.TotalTrades > 0 {
 		m.WinRate = float64(m.WinningTrades) / float64(m.TotalTrades) * 100
+		m.AverageTradePnL = totalPnL / float64(m.TotalTrades)
 	}
 	if m.WinningTrades > 0 {
 		m.AverageWin = wins / float64(m.WinningTrades)
@@ -69,6 +69,40 @@ func TestCalculateMetrics_TradeStatistics(t *testing.T) {
 	assert.InDelta(t, 75.0, m.AverageLoss, 0.01) // (50+100)/2
 }
 
+// TestCalculateMetrics_Streaks verifies consecutive win/loss runs, the
+// extreme trades and the average trade P&L.
+func TestCalculateMetrics_Streaks(t *testing.T) {
+	trades := []SimulatedTrade{
+		{PnL: 50},   // W1
+		{PnL: 120},  // W2
+		{PnL: -30},  // L1
+		{PnL: -80},  // L2
+		{PnL: -10},  // L3
+		{PnL: 0},    // Break-even ends the streak
+		{PnL: -40},  // L1
+		{PnL: 20},   // W1
+		{PnL: 10},   // W2
+		{PnL: 60},   // W3
+		{PnL: -200}, // L1
+	}
+	equityCurve := []EquityPoint{{Equity: 9900}}
+
+	m := CalculateMetrics(trades, equityCurve, 10000)
+
+	assert.Equal(t, 3, m.MaxConsecutiveWins)
+	assert.Equal(t, 3, m.MaxConsecutiveLosses)
+	assert.Equal(t, 120.0, m.LargestWin)
+	assert.Equal(t, 200.0, m.LargestLoss)
+	assert.InDelta(t, -100.0/11, m.AverageTradePnL, 1e-9)
+
+	// A single losing run
+	m = CalculateMetrics([]SimulatedTrade{{PnL: -1}, {PnL: -2}, {PnL: -3}, {PnL: -4}}, equityCurve, 10000)
+	assert.Equal(t, 0, m.MaxConsecutiveWins)
+	assert.Equal(t, 4, m.MaxConsecutiveLosses)
+	assert.Equal(t, 0.0, m.LargestWin)
+	assert.Equal(t, 4.0, m.LargestLoss)
+}
+
 // TestCalculateMetrics_ProfitFactor verifies profit factor calculation.
 func TestCalculateMetrics_ProfitFactor(t *testing.T) {
 	trades := []SimulatedTrade{
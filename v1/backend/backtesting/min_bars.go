@@ -0,0 +1,69 @@
+// Package backtesting provides the minimum data a strategy needs to trade.
+package backtesting
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexherrero/sherwood/backend/strategies"
+)
+
+// InsufficientDataError reports that a backtest has too few bars for its
+// strategy to ever produce a signal.
+type InsufficientDataError struct {
+	// Required is the fewest bars the backtest needs.
+	Required int
+	// Provided is the number of bars given.
+	Provided int
+}
+
+// Error implements the error interface.
+func (e *InsufficientDataError) Error() string {
+	return fmt.Sprintf("not enough data: the strategy needs at least %d bars, got %d", e.Required, e.Provided)
+}
+
+// configuredInt is implemented by strategies embedding BaseStrategy, which
+// report the configured value of a parameter.
+type configuredInt interface {
+	GetConfigInt(key string, defaultValue int) int
+}
+
+// RequiredBars returns the fewest bars a strategy needs to produce a signal:
+// its longest period parameter (an int parameter whose name ends in
+// "period", e.g. "long_period" or "slowPeriod") plus one, since a signal
+// compares the latest bar's indicator with the previous one. Configured values are used where the strategy
+// reports them, and parameter defaults otherwise.
+//
+// Args:
+//   - strategy: The initialized strategy
+//   - floor: Minimum result regardless of the strategy's periods (0 for none)
+//
+// Returns:
+//   - int: Bars required, at least 2 and at least floor
+func RequiredBars(strategy strategies.Strategy, floor int) int {
+	longest := 1
+	configured, hasConfig := strategy.(configuredInt)
+	for name, param := range strategy.GetParameters() {
+		if param.Type != "int" || !strings.HasSuffix(strings.ToLower(name), "period") {
+			continue
+		}
+		period := paramInt(param.Default)
+		if hasConfig {
+			period = configured.GetConfigInt(name, period)
+		}
+		longest = max(longest, period)
+	}
+	return max(longest+1, floor)
+}
+
+// paramInt converts a parameter default to an int (0 if it isn't numeric).
+func paramInt(v interface{}) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	default:
+		return 0
+	}
+}
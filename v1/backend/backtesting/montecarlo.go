@@ -0,0 +1,116 @@
+// Package backtesting provides Monte Carlo resampling of backtest trades.
+package backtesting
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"sort"
+)
+
+// MaxMonteCarloIterations caps the resampled paths in one simulation.
+const MaxMonteCarloIterations = 10000
+
+// ErrNoTrades is returned when a backtest has no trades to resample.
+var ErrNoTrades = errors.New("backtest has no trades")
+
+// PercentileStats is a distribution summarized by its 5th, 50th and 95th
+// percentiles.
+type PercentileStats struct {
+	P5  float64 `json:"p5"`
+	P50 float64 `json:"p50"`
+	P95 float64 `json:"p95"`
+}
+
+// MonteCarloResult is the distribution of outcomes over resampled trade
+// sequences.
+type MonteCarloResult struct {
+	BacktestID string `json:"backtest_id"`
+	Iterations int    `json:"iterations"`
+	Trades     int    `json:"trades"`
+	// FinalReturn is the total percentage return of each path.
+	FinalReturn PercentileStats `json:"final_return"`
+	// MaxDrawdown is the largest peak-to-trough decline of each path, as a
+	// percentage.
+	MaxDrawdown PercentileStats `json:"max_drawdown"`
+}
+
+// MonteCarlo bootstraps a backtest's trades to show how much its outcome
+// depends on trade order and luck. Each iteration draws as many trades as
+// the backtest made, with replacement, and applies their net P&L in turn to
+// the initial capital. Equity is only marked at trade exits, so drawdowns
+// can be shallower than the backtest's own, which also counts open
+// positions.
+//
+// The random source is seeded from the backtest ID, so the same backtest
+// always yields the same distribution.
+//
+// Args:
+//   - result: Completed backtest result
+//   - iterations: Number of resampled paths (1 to MaxMonteCarloIterations)
+//
+// Returns:
+//   - *MonteCarloResult: Percentiles of final return and max drawdown
+//   - error: If iterations is out of range or there is nothing to resample
+func MonteCarlo(result *BacktestResult, iterations int) (*MonteCarloResult, error) {
+	if iterations < 1 || iterations > MaxMonteCarloIterations {
+		return nil, fmt.Errorf("iterations must be between 1 and %d", MaxMonteCarloIterations)
+	}
+	if result == nil || len(result.Trades) == 0 {
+		return nil, ErrNoTrades
+	}
+	capital := result.Config.InitialCapital
+	if capital <= 0 {
+		return nil, errors.New("backtest has no initial capital")
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(result.ID))
+	seed := h.Sum64()
+	rng := rand.New(rand.NewPCG(seed, seed))
+
+	n := len(result.Trades)
+	returns := make([]float64, iterations)
+	drawdowns := make([]float64, iterations)
+	for i := range iterations {
+		equity, peak, maxDD := capital, capital, 0.0
+		for range n {
+			equity += result.Trades[rng.IntN(n)].NetPnL
+			peak = max(peak, equity)
+			maxDD = max(maxDD, (peak-equity)/peak*100)
+		}
+		returns[i] = (equity - capital) / capital * 100
+		drawdowns[i] = maxDD
+	}
+
+	return &MonteCarloResult{
+		BacktestID:  result.ID,
+		Iterations:  iterations,
+		Trades:      n,
+		FinalReturn: percentileStats(returns),
+		MaxDrawdown: percentileStats(drawdowns),
+	}, nil
+}
+
+// percentileStats sorts values in place and summarizes them.
+func percentileStats(values []float64) PercentileStats {
+	sort.Float64s(values)
+	return PercentileStats{
+		P5:  percentile(values, 5),
+		P50: percentile(values, 50),
+		P95: percentile(values, 95),
+	}
+}
+
+// percentile returns the p-th percentile of sorted values, interpolating
+// linearly between the nearest ranks.
+func percentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(rank)
+	if lo+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	frac := rank - float64(lo)
+	return sorted[lo] + frac*(sorted[lo+1]-sorted[lo])
+}
@@ -0,0 +1,62 @@
+package backtesting
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMonteCarlo verifies resampled paths bracket the backtest and are
+// reproducible for the same backtest.
+func TestMonteCarlo(t *testing.T) {
+	result := &BacktestResult{
+		ID:     "bt-1",
+		Config: BacktestConfig{InitialCapital: 1000},
+		Trades: []SimulatedTrade{{NetPnL: 100}, {NetPnL: -50}, {NetPnL: 30}, {NetPnL: -20}},
+	}
+
+	mc, err := MonteCarlo(result, 2000)
+	require.NoError(t, err)
+	assert.Equal(t, "bt-1", mc.BacktestID)
+	assert.Equal(t, 2000, mc.Iterations)
+	assert.Equal(t, 4, mc.Trades)
+
+	// Four draws from the trades return between -20% and +40%
+	assert.LessOrEqual(t, mc.FinalReturn.P5, mc.FinalReturn.P50)
+	assert.LessOrEqual(t, mc.FinalReturn.P50, mc.FinalReturn.P95)
+	assert.GreaterOrEqual(t, mc.FinalReturn.P5, -20.0)
+	assert.LessOrEqual(t, mc.FinalReturn.P95, 40.0)
+	assert.Less(t, mc.FinalReturn.P5, 6.0)
+	assert.Greater(t, mc.FinalReturn.P95, 6.0)
+
+	assert.GreaterOrEqual(t, mc.MaxDrawdown.P5, 0.0)
+	assert.LessOrEqual(t, mc.MaxDrawdown.P50, mc.MaxDrawdown.P95)
+	assert.LessOrEqual(t, mc.MaxDrawdown.P95, 20.0)
+
+	again, err := MonteCarlo(result, 2000)
+	require.NoError(t, err)
+	assert.Equal(t, mc, again)
+}
+
+func TestMonteCarlo_Invalid(t *testing.T) {
+	result := &BacktestResult{ID: "bt-1", Config: BacktestConfig{InitialCapital: 1000}, Trades: []SimulatedTrade{{NetPnL: 1}}}
+
+	_, err := MonteCarlo(result, 0)
+	assert.Error(t, err)
+	_, err = MonteCarlo(result, MaxMonteCarloIterations+1)
+	assert.Error(t, err)
+
+	_, err = MonteCarlo(&BacktestResult{Config: BacktestConfig{InitialCapital: 1000}}, 10)
+	assert.True(t, errors.Is(err, ErrNoTrades))
+}
+
+func TestPercentile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5}
+	assert.Equal(t, 1.0, percentile(values, 0))
+	assert.Equal(t, 3.0, percentile(values, 50))
+	assert.Equal(t, 5.0, percentile(values, 100))
+	assert.InDelta(t, 1.2, percentile(values, 5), 1e-9)
+	assert.Equal(t, 7.0, percentile([]float64{7}, 95))
+}
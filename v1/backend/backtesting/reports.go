@@ -49,7 +49,8 @@ func (r *Report) Summary() string {
 	sb.WriteString(fmt.Sprintf("  Period:          %s to %s\n",
 		c.StartDate.Format("2006-01-02"), c.EndDate.Format("2006-01-02")))
 	sb.WriteString(fmt.Sprintf("  Initial Capital: $%.2f\n", c.InitialCapital))
-	sb.WriteString(fmt.Sprintf("  Commission:      $%.2f per trade\n", c.Commission))
+	sb.WriteString(fmt.Sprintf("  Commission:      %s\n", c.commissionModel()))
+	sb.WriteString(fmt.Sprintf("  Fills:           %s\n", c.FillModel))
 	sb.WriteString("\n")
 
 	sb.WriteString("PERFORMANCE METRICS\n")
@@ -70,6 +71,12 @@ func (r *Report) Summary() string {
 	sb.WriteString(fmt.Sprintf("  Average Win:     $%.2f\n", m.AverageWin))
 	sb.WriteString(fmt.Sprintf("  Average Loss:    $%.2f\n", m.AverageLoss))
 	sb.WriteString(fmt.Sprintf("  Profit Factor:   %.2f\n", m.ProfitFactor))
+	sb.WriteString(fmt.Sprintf("  Avg Trade P&L:   $%+.2f\n", m.AverageTradePnL))
+	sb.WriteString(fmt.Sprintf("  Largest Win:     $%.2f\n", m.LargestWin))
+	sb.WriteString(fmt.Sprintf("  Largest Loss:    $%.2f\n", m.LargestLoss))
+	sb.WriteString(fmt.Sprintf("  Max Win Streak:  %d\n", m.MaxConsecutiveWins))
+	sb.WriteString(fmt.Sprintf("  Max Loss Streak: %d\n", m.MaxConsecutiveLosses))
+	sb.WriteString(fmt.Sprintf("  Commissions:     $%.2f\n", m.TotalCommissions))
 	sb.WriteString("\n")
 
 	sb.WriteString("═══════════════════════════════════════════════════════════════\n")
@@ -114,13 +121,169 @@ func (r *Report) TradeList() string {
 	return sb.String()
 }
 
-// JSON returns the result as JSON.
+// ReportConfig is the backtest configuration as exported in a report.
+type ReportConfig struct {
+	Symbol         string    `json:"symbol"`
+	StartDate      time.Time `json:"start_date"`
+	EndDate        time.Time `json:"end_date"`
+	InitialCapital float64   `json:"initial_capital"`
+	PositionSize   float64   `json:"position_size"`
+	Commission     string    `json:"commission"`
+	WarmupBars     int       `json:"warmup_bars"`
+	FillModel      FillModel `json:"fill_model"`
+}
+
+// ReportExport is the full structured result of a backtest, as exported by
+// Report.JSON.
+type ReportExport struct {
+	ID          string           `json:"id"`
+	Strategy    string           `json:"strategy"`
+	Config      ReportConfig     `json:"config"`
+	Metrics     *Metrics         `json:"metrics"`
+	Trades      []SimulatedTrade `json:"trades"`
+	EquityCurve []EquityPoint    `json:"equity_curve"`
+	StartedAt   time.Time        `json:"started_at"`
+	CompletedAt time.Time        `json:"completed_at"`
+}
+
+// Export returns the result in its exported form. The commission model is
+// rendered as its description, since models are not serializable.
 //
 // Returns:
-//   - []byte: JSON-encoded result
+//   - *ReportExport: The structured result (nil if there is no result)
+func (r *Report) Export() *ReportExport {
+	if r.Result == nil {
+		return nil
+	}
+
+	c := r.Result.Config
+	fillModel, _ := c.fillModel() // Run has already rejected unknown models
+	trades := r.Result.Trades
+	if trades == nil {
+		trades = []SimulatedTrade{}
+	}
+	curve := r.Result.EquityCurve
+	if curve == nil {
+		curve = []EquityPoint{}
+	}
+
+	return &ReportExport{
+		ID:       r.Result.ID,
+		Strategy: r.Result.Strategy,
+		Config: ReportConfig{
+			Symbol:         c.Symbol,
+			StartDate:      c.StartDate,
+			EndDate:        c.EndDate,
+			InitialCapital: c.InitialCapital,
+			PositionSize:   c.PositionSize,
+			Commission:     c.commissionModel().String(),
+			WarmupBars:     c.WarmupBars,
+			FillModel:      fillModel,
+		},
+		Metrics:     r.Result.Metrics,
+		Trades:      trades,
+		EquityCurve: curve,
+		StartedAt:   r.Result.StartedAt,
+		CompletedAt: r.Result.CompletedAt,
+	}
+}
+
+// JSON returns the full structured result as JSON: configuration, metrics,
+// trades and equity curve.
+//
+// Returns:
+//   - []byte: JSON-encoded report ("{}" if there is no result)
 //   - error: Any encoding error
 func (r *Report) JSON() ([]byte, error) {
-	return json.MarshalIndent(r.Result, "", "  ")
+	export := r.Export()
+	if export == nil {
+		return []byte("{}"), nil
+	}
+	return json.MarshalIndent(export, "", "  ")
+}
+
+// Markdown returns the report as Markdown, with configuration and metrics
+// tables followed by the trade list, ready to paste into a PR or notes.
+//
+// Returns:
+//   - string: Markdown report
+func (r *Report) Markdown() string {
+	if r.Result == nil || r.Result.Metrics == nil {
+		return "No backtest results available.\n"
+	}
+
+	m := r.Result.Metrics
+	c := r.Result.Config
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Backtest Report: %s\n\n", r.Result.ID))
+
+	sb.WriteString("## Configuration\n\n")
+	sb.WriteString("| Setting | Value |\n")
+	sb.WriteString("| --- | --- |\n")
+	sb.WriteString(fmt.Sprintf("| Strategy | %s |\n", r.Result.Strategy))
+	sb.WriteString(fmt.Sprintf("| Symbol | %s |\n", c.Symbol))
+	sb.WriteString(fmt.Sprintf("| Period | %s to %s |\n",
+		c.StartDate.Format("2006-01-02"), c.EndDate.Format("2006-01-02")))
+	sb.WriteString(fmt.Sprintf("| Initial Capital | $%.2f |\n", c.InitialCapital))
+	sb.WriteString(fmt.Sprintf("| Commission | %s |\n", c.commissionModel()))
+	sb.WriteString(fmt.Sprintf("| Fills | %s |\n", c.FillModel))
+	sb.WriteString("\n")
+
+	sb.WriteString("## Performance Metrics\n\n")
+	sb.WriteString("| Metric | Value |\n")
+	sb.WriteString("| --- | ---: |\n")
+	sb.WriteString(fmt.Sprintf("| Total Return | %+.2f%% ($%+.2f) |\n", m.TotalReturn, m.TotalReturnAbs))
+	sb.WriteString(fmt.Sprintf("| Final Equity | $%.2f |\n", m.FinalEquity))
+	sb.WriteString(fmt.Sprintf("| Annualized Return | %+.2f%% |\n", m.AnnualizedReturn))
+	sb.WriteString(fmt.Sprintf("| Sharpe Ratio | %.2f |\n", m.SharpeRatio))
+	sb.WriteString(fmt.Sprintf("| Max Drawdown | -%.2f%% ($%.2f) |\n", m.MaxDrawdown, m.MaxDrawdownAbs))
+	sb.WriteString(fmt.Sprintf("| Volatility | %.2f%% |\n", m.Volatility))
+	sb.WriteString("\n")
+
+	sb.WriteString("## Trade Statistics\n\n")
+	sb.WriteString("| Statistic | Value |\n")
+	sb.WriteString("| --- | ---: |\n")
+	sb.WriteString(fmt.Sprintf("| Total Trades | %d |\n", m.TotalTrades))
+	sb.WriteString(fmt.Sprintf("| Winning Trades | %d (%.1f%%) |\n", m.WinningTrades, m.WinRate))
+	sb.WriteString(fmt.Sprintf("| Losing Trades | %d |\n", m.LosingTrades))
+	sb.WriteString(fmt.Sprintf("| Average Win | $%.2f |\n", m.AverageWin))
+	sb.WriteString(fmt.Sprintf("| Average Loss | $%.2f |\n", m.AverageLoss))
+	sb.WriteString(fmt.Sprintf("| Profit Factor | %.2f |\n", m.ProfitFactor))
+	sb.WriteString(fmt.Sprintf("| Average Trade P&L | $%+.2f |\n", m.AverageTradePnL))
+	sb.WriteString(fmt.Sprintf("| Largest Win | $%.2f |\n", m.LargestWin))
+	sb.WriteString(fmt.Sprintf("| Largest Loss | $%.2f |\n", m.LargestLoss))
+	sb.WriteString(fmt.Sprintf("| Max Consecutive Wins | %d |\n", m.MaxConsecutiveWins))
+	sb.WriteString(fmt.Sprintf("| Max Consecutive Losses | %d |\n", m.MaxConsecutiveLosses))
+	sb.WriteString(fmt.Sprintf("| Commissions | $%.2f |\n", m.TotalCommissions))
+	sb.WriteString("\n")
+
+	sb.WriteString("## Trades\n\n")
+	if len(r.Result.Trades) == 0 {
+		sb.WriteString("No trades executed.\n")
+		return sb.String()
+	}
+	sb.WriteString("| # | Entry Date | Exit Date | Side | Quantity | Entry | Exit | Break-even | Gross P&L | Fees | Net P&L | P&L % |\n")
+	sb.WriteString("| ---: | --- | --- | --- | ---: | ---: | ---: | ---: | ---: | ---: | ---: | ---: |\n")
+	for i, t := range r.Result.Trades {
+		sb.WriteString(fmt.Sprintf("| %d | %s | %s | %s | %.4g | $%.2f | $%.2f | $%.2f | $%+.2f | $%.2f | $%+.2f | %+.2f%% |\n",
+			i+1,
+			t.EntryTime.Format("2006-01-02"),
+			t.ExitTime.Format("2006-01-02"),
+			t.Side,
+			t.Quantity,
+			t.EntryPrice,
+			t.ExitPrice,
+			t.BreakEvenPrice,
+			t.GrossPnL,
+			t.Commission,
+			t.NetPnL,
+			t.PnLPercent,
+		))
+	}
+
+	return sb.String()
 }
 
 // MetricsJSON returns just the metrics as JSON.
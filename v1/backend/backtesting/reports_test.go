@@ -48,6 +48,9 @@ func TestReport_Summary(t *testing.T) {
 			AverageWin:     150.0,
 			AverageLoss:    80.0,
 			ProfitFactor:   1.8,
+
+			MaxConsecutiveLosses: 4,
+			LargestLoss:          321.5,
 		},
 	}
 
@@ -62,6 +65,8 @@ func TestReport_Summary(t *testing.T) {
 	assert.Contains(t, summary, "PERFORMANCE METRICS")
 	assert.Contains(t, summary, "TRADE STATISTICS")
 	assert.Contains(t, summary, "15.5") // Total return
+	assert.Contains(t, summary, "Max Loss Streak: 4")
+	assert.Contains(t, summary, "Largest Loss:    $321.50")
 }
 
 // TestReport_Summary_NilResult verifies handling of nil result.
@@ -141,12 +146,23 @@ func TestReport_TradeList_NilResult(t *testing.T) {
 	assert.Equal(t, "No trades executed.", tradeList)
 }
 
-// TestReport_JSON verifies JSON export.
+// TestReport_JSON verifies JSON export of the full structured result.
 func TestReport_JSON(t *testing.T) {
 	result := &BacktestResult{
 		ID:       "bt-001",
 		Strategy: "ma_crossover",
-		Metrics:  &Metrics{TotalReturn: 10.0},
+		Config: BacktestConfig{
+			Symbol:          "AAPL",
+			InitialCapital:  100000,
+			CommissionModel: PercentCommission{Rate: 0.001},
+		},
+		Metrics: &Metrics{TotalReturn: 10.0},
+		Trades: []SimulatedTrade{
+			{Symbol: "AAPL", Side: models.OrderSideBuy, EntryPrice: 150.0, ExitPrice: 160.0, Quantity: 10, PnL: 100.0},
+		},
+		EquityCurve: []EquityPoint{
+			{Timestamp: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), Equity: 100000},
+		},
 	}
 
 	report := NewReport(result)
@@ -155,11 +171,87 @@ func TestReport_JSON(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, jsonData)
 
-	// Verify it's valid JSON
-	var parsed map[string]interface{}
+	var parsed ReportExport
 	err = json.Unmarshal(jsonData, &parsed)
 	require.NoError(t, err)
-	assert.Equal(t, "bt-001", parsed["ID"])
+	assert.Equal(t, "bt-001", parsed.ID)
+	assert.Equal(t, "ma_crossover", parsed.Strategy)
+	assert.Equal(t, "AAPL", parsed.Config.Symbol)
+	assert.Equal(t, "0.100% of notional", parsed.Config.Commission)
+	require.NotNil(t, parsed.Metrics)
+	assert.Equal(t, 10.0, parsed.Metrics.TotalReturn)
+	require.Len(t, parsed.Trades, 1)
+	assert.Equal(t, 100.0, parsed.Trades[0].PnL)
+	require.Len(t, parsed.EquityCurve, 1)
+
+	var raw map[string]interface{}
+	require.NoError(t, json.Unmarshal(jsonData, &raw))
+	assert.Contains(t, raw, "equity_curve")
+	assert.Contains(t, raw, "completed_at")
+}
+
+// TestReport_JSON_NilResult verifies handling of nil result.
+func TestReport_JSON_NilResult(t *testing.T) {
+	report := NewReport(nil)
+	jsonData, err := report.JSON()
+
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(jsonData))
+}
+
+// TestReport_Markdown verifies Markdown export.
+func TestReport_Markdown(t *testing.T) {
+	result := &BacktestResult{
+		ID:       "bt-001",
+		Strategy: "ma_crossover",
+		Config: BacktestConfig{
+			Symbol:         "AAPL",
+			StartDate:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:        time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+			InitialCapital: 100000,
+		},
+		Metrics: &Metrics{TotalReturn: 15.5, TotalTrades: 1, WinningTrades: 1, WinRate: 100},
+		Trades: []SimulatedTrade{
+			{
+				EntryTime:      time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+				ExitTime:       time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+				Symbol:         "AAPL",
+				Side:           models.OrderSideBuy,
+				EntryPrice:     150.0,
+				ExitPrice:      160.0,
+				Quantity:       10,
+				PnL:            98.0,
+				PnLPercent:     6.67,
+				GrossPnL:       100.0,
+				Commission:     2.0,
+				NetPnL:         98.0,
+				BreakEvenPrice: 150.2,
+			},
+		},
+	}
+
+	md := NewReport(result).Markdown()
+
+	assert.Contains(t, md, "# Backtest Report: bt-001")
+	assert.Contains(t, md, "| Strategy | ma_crossover |")
+	assert.Contains(t, md, "| Period | 2024-01-01 to 2024-06-30 |")
+	assert.Contains(t, md, "| Total Return | +15.50% ($+0.00) |")
+	assert.Contains(t, md, "## Trades")
+	assert.Contains(t, md, "| 1 | 2024-01-15 | 2024-01-20 | buy | 10 | $150.00 | $160.00 | $150.20 | $+100.00 | $2.00 | $+98.00 | +6.67% |")
+}
+
+// TestReport_Markdown_NoTrades verifies the trade section without trades.
+func TestReport_Markdown_NoTrades(t *testing.T) {
+	result := &BacktestResult{ID: "bt-001", Metrics: &Metrics{}}
+
+	md := NewReport(result).Markdown()
+
+	assert.Contains(t, md, "## Trades\n\nNo trades executed.")
+}
+
+// TestReport_Markdown_NilResult verifies handling of nil result.
+func TestReport_Markdown_NilResult(t *testing.T) {
+	assert.Equal(t, "No backtest results available.\n", NewReport(nil).Markdown())
 }
 
 // TestReport_MetricsJSON verifies metrics JSON export.
@@ -0,0 +1,153 @@
+// Package backtesting provides parameter sweeps over strategy configurations.
+package backtesting
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// SweepRankMetrics are the objectives a sweep can be ranked by, keyed by
+// metric name. Max drawdown is negated so that smaller drawdowns rank higher.
+var SweepRankMetrics = map[string]Objective{
+	"total_return":  func(m *Metrics) float64 { return m.TotalReturn },
+	"sharpe_ratio":  SharpeObjective,
+	"max_drawdown":  func(m *Metrics) float64 { return -m.MaxDrawdown },
+	"win_rate":      func(m *Metrics) float64 { return m.WinRate },
+	"profit_factor": func(m *Metrics) float64 { return m.ProfitFactor },
+	"final_equity":  func(m *Metrics) float64 { return m.FinalEquity },
+}
+
+// DefaultSweepRankMetric is the metric sweeps are ranked by when none is given.
+const DefaultSweepRankMetric = "sharpe_ratio"
+
+// SweepConfig holds configuration for a parameter sweep.
+type SweepConfig struct {
+	// Backtest is the configuration shared by every combination.
+	Backtest BacktestConfig
+	// Base is strategy config applied under every combination.
+	Base map[string]interface{}
+	// Grid holds the parameter values to sweep.
+	Grid ParameterGrid
+}
+
+// SweepResult is one parameter combination's outcome in a sweep.
+type SweepResult struct {
+	Rank    int                    `json:"rank"`
+	Params  map[string]interface{} `json:"params"`
+	Metrics *Metrics               `json:"metrics,omitempty"`
+	Error   string                 `json:"error,omitempty"`
+}
+
+// SweepReport is a finished sweep, stored as a single job: every
+// combination's outcome, ranked.
+type SweepReport struct {
+	Strategy     string        `json:"strategy"`
+	Symbol       string        `json:"symbol"`
+	Combinations int           `json:"combinations"`
+	RankBy       string        `json:"rank_by"`
+	Results      []SweepResult `json:"results"`
+}
+
+// Size returns the number of parameter combinations in the grid.
+//
+// Returns:
+//   - int: Product of the value counts (0 if any parameter has no values,
+//     math.MaxInt if the product overflows)
+func (g ParameterGrid) Size() int {
+	size := 1
+	for _, values := range g {
+		if len(values) == 0 {
+			return 0
+		}
+		if size > math.MaxInt/len(values) {
+			return math.MaxInt
+		}
+		size *= len(values)
+	}
+	return size
+}
+
+// RunSweep backtests every parameter combination in the grid in parallel
+// on the worker pool, recording each as a job. Combinations the strategy
+// rejects are reported as failed rather than aborting the sweep, and once
+// ctx ends the ones not yet started fail with its error.
+//
+// Args:
+//   - ctx: Cancels the combinations not yet run
+//   - newStrategy: Factory for fresh strategy instances
+//   - data: Historical OHLCV data (oldest first), shared by every combination
+//   - config: Sweep configuration
+//
+// Returns:
+//   - []SweepResult: One unranked result per combination, in grid order
+func (m *BacktestJobManager) RunSweep(ctx context.Context, newStrategy StrategyFactory, data []models.OHLCV, config SweepConfig) []SweepResult {
+	combos := expandGrid(config.Grid)
+	results := make([]SweepResult, len(combos))
+	runs := make([]JobFunc, len(combos))
+	for i, combo := range combos {
+		merged := make(map[string]interface{}, len(config.Base)+len(combo))
+		for k, v := range config.Base {
+			merged[k] = v
+		}
+		for k, v := range combo {
+			merged[k] = v
+		}
+		results[i].Params = merged
+
+		runs[i] = func() (*BacktestResult, error) {
+			// Queued before ctx ended; don't start it now
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			strategy := newStrategy()
+			if err := strategy.Init(merged); err != nil {
+				return nil, fmt.Errorf("failed to initialize strategy: %w", err)
+			}
+			return NewEngine().Run(strategy, data, config.Backtest)
+		}
+	}
+
+	for i, job := range m.RunBatch(ctx, runs) {
+		if job.Result == nil {
+			results[i].Error = job.Error
+			continue
+		}
+		results[i].Metrics = job.Result.Metrics
+	}
+	return results
+}
+
+// RankSweep sorts sweep results best first by a metric and assigns ranks.
+// Failed combinations sort last, unranked.
+//
+// Args:
+//   - results: Sweep results to sort in place
+//   - metric: Key of SweepRankMetrics
+//
+// Returns:
+//   - error: If the metric is unknown
+func RankSweep(results []SweepResult, metric string) error {
+	objective, ok := SweepRankMetrics[metric]
+	if !ok {
+		return fmt.Errorf("unknown rank metric '%s'", metric)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i].Metrics, results[j].Metrics
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return objective(a) > objective(b)
+	})
+	for i := range results {
+		results[i].Rank = 0
+		if results[i].Metrics != nil {
+			results[i].Rank = i + 1
+		}
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package backtesting
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/strategies"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParameterGrid_Size verifies combination counting.
+func TestParameterGrid_Size(t *testing.T) {
+	assert.Equal(t, 1, ParameterGrid(nil).Size())
+	assert.Equal(t, 6, ParameterGrid{
+		"short_period": {5, 10, 15},
+		"long_period":  {20, 50},
+	}.Size())
+	assert.Equal(t, 0, ParameterGrid{
+		"short_period": {5, 10},
+		"long_period":  {},
+	}.Size())
+
+	huge := ParameterGrid{}
+	values := make([]interface{}, 1000)
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		huge[name] = values
+	}
+	assert.Equal(t, math.MaxInt, huge.Size())
+}
+
+// TestRunSweep verifies every combination runs merged over the base config,
+// and invalid combinations fail individually.
+func TestRunSweep(t *testing.T) {
+	m := NewBacktestJobManager(2)
+	data := generateTestOHLCVData(60, "TEST")
+
+	results := m.RunSweep(context.Background(), newMACrossover, data, SweepConfig{
+		Backtest: BacktestConfig{Symbol: "TEST", InitialCapital: 10000},
+		Base:     map[string]interface{}{"long_period": 20},
+		Grid: ParameterGrid{
+			"short_period": {3, 5, 20},
+		},
+	})
+
+	require.Len(t, results, 3)
+	for i, short := range []int{3, 5, 20} {
+		assert.Equal(t, map[string]interface{}{"short_period": short, "long_period": 20}, results[i].Params)
+	}
+	require.NotNil(t, results[0].Metrics)
+	require.NotNil(t, results[1].Metrics)
+	// short == long is rejected by the strategy
+	assert.Nil(t, results[2].Metrics)
+	assert.Contains(t, results[2].Error, "failed to initialize strategy")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cancelled := m.RunSweep(ctx, newMACrossover, data, SweepConfig{
+		Backtest: BacktestConfig{Symbol: "TEST", InitialCapital: 10000},
+		Grid:     ParameterGrid{"short_period": {3, 5}},
+	})
+	require.Len(t, cancelled, 2)
+	assert.Equal(t, context.Canceled.Error(), cancelled[1].Error)
+}
+
+// initGate is a strategy whose Init blocks until released, counting the
+// combinations in progress.
+type initGate struct {
+	strategies.Strategy
+	entered *atomic.Int32
+	release chan struct{}
+}
+
+// Init waits for the gate to open before initializing the strategy.
+func (s initGate) Init(config map[string]interface{}) error {
+	s.entered.Add(1)
+	<-s.release
+	return s.Strategy.Init(config)
+}
+
+// TestRunSweep_Parallel verifies combinations run concurrently on the pool.
+func TestRunSweep_Parallel(t *testing.T) {
+	m := NewBacktestJobManager(2)
+	data := generateTestOHLCVData(60, "TEST")
+	var entered atomic.Int32
+	release := make(chan struct{})
+	newStrategy := func() strategies.Strategy {
+		return initGate{Strategy: newMACrossover(), entered: &entered, release: release}
+	}
+
+	done := make(chan []SweepResult)
+	go func() {
+		done <- m.RunSweep(context.Background(), newStrategy, data, SweepConfig{
+			Backtest: BacktestConfig{Symbol: "TEST", InitialCapital: 10000},
+			Grid:     ParameterGrid{"short_period": {3, 5}},
+		})
+	}()
+
+	assert.Eventually(t, func() bool { return entered.Load() == 2 }, time.Second, 5*time.Millisecond,
+		"both combinations run at once")
+	close(release)
+	results := <-done
+	require.Len(t, results, 2)
+	assert.NotNil(t, results[0].Metrics)
+	assert.NotNil(t, results[1].Metrics)
+}
+
+// TestBacktestJobManager_SubmitSweep verifies a sweep is stored as one job
+// holding its report.
+func TestBacktestJobManager_SubmitSweep(t *testing.T) {
+	m := NewBacktestJobManager(1)
+	data := generateTestOHLCVData(60, "TEST")
+	report := &SweepReport{Strategy: "ma_crossover", Combinations: 2}
+
+	// The sweep doesn't hold a worker, so its combinations can use the only one
+	job, err := m.SubmitSweep(func(ctx context.Context) (*SweepReport, error) {
+		report.Results = m.RunSweep(ctx, newMACrossover, data, SweepConfig{
+			Backtest: BacktestConfig{Symbol: "TEST", InitialCapital: 10000},
+			Grid:     ParameterGrid{"short_period": {3, 5}},
+		})
+		return report, nil
+	})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		got, _ := m.Get(job.ID)
+		return got.Status == JobCompleted
+	}, time.Second, 5*time.Millisecond)
+
+	got, ok := m.Get(job.ID)
+	require.True(t, ok)
+	assert.Same(t, report, got.Sweep)
+	assert.Nil(t, got.Result)
+	require.Len(t, got.Sweep.Results, 2)
+	assert.NotNil(t, got.Sweep.Results[1].Metrics)
+
+	failed := m.RunSweepSync(context.Background(), func(context.Context) (*SweepReport, error) { panic("bad grid") })
+	assert.Equal(t, JobFailed, failed.Status)
+	assert.Contains(t, failed.Error, "bad grid")
+}
+
+// TestBacktestJobManager_SubmitSweep_Shutdown verifies a running sweep is
+// cancelled once Shutdown stops waiting for it.
+func TestBacktestJobManager_SubmitSweep_Shutdown(t *testing.T) {
+	m := NewBacktestJobManager(1)
+	job, err := m.SubmitSweep(func(ctx context.Context) (*SweepReport, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Error(t, m.Shutdown(ctx))
+	require.Eventually(t, func() bool {
+		got, _ := m.Get(job.ID)
+		return got.Status == JobFailed
+	}, time.Second, 5*time.Millisecond)
+
+	_, err = m.SubmitSweep(func(context.Context) (*SweepReport, error) { return nil, nil })
+	assert.ErrorIs(t, err, ErrJobManagerClosed)
+}
+
+// TestRankSweep verifies ranking by metric, with failures last.
+func TestRankSweep(t *testing.T) {
+	results := []SweepResult{
+		{Params: map[string]interface{}{"id": "a"}, Metrics: &Metrics{SharpeRatio: 0.5, MaxDrawdown: 10}},
+		{Params: map[string]interface{}{"id": "b"}, Error: "failed"},
+		{Params: map[string]interface{}{"id": "c"}, Metrics: &Metrics{SharpeRatio: 1.5, MaxDrawdown: 20}},
+		{Params: map[string]interface{}{"id": "d"}, Metrics: &Metrics{SharpeRatio: 1.0, MaxDrawdown: 5}},
+	}
+
+	require.NoError(t, RankSweep(results, "sharpe_ratio"))
+	assert.Equal(t, []string{"c", "d", "a", "b"}, sweepIDs(results))
+	assert.Equal(t, 1, results[0].Rank)
+	assert.Equal(t, 0, results[3].Rank, "failed combinations are unranked")
+
+	require.NoError(t, RankSweep(results, "max_drawdown"))
+	assert.Equal(t, []string{"d", "a", "c", "b"}, sweepIDs(results), "smaller drawdown ranks first")
+
+	assert.Error(t, RankSweep(results, "luck"))
+}
+
+// sweepIDs returns the "id" parameter of results in order.
+func sweepIDs(results []SweepResult) []string {
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.Params["id"].(string)
+	}
+	return ids
+}
@@ -0,0 +1,224 @@
+// Package backtesting provides walk-forward optimization.
+package backtesting
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/strategies"
+)
+
+// StrategyFactory creates a fresh, uninitialized strategy instance.
+type StrategyFactory func() strategies.Strategy
+
+// ParameterGrid maps a strategy parameter name to the values to sweep.
+type ParameterGrid map[string][]interface{}
+
+// Objective scores backtest metrics; higher is better.
+type Objective func(m *Metrics) float64
+
+// SharpeObjective ranks parameter sets by Sharpe ratio.
+func SharpeObjective(m *Metrics) float64 {
+	return m.SharpeRatio
+}
+
+// WalkForwardConfig holds configuration for a walk-forward analysis.
+type WalkForwardConfig struct {
+	// Backtest holds the per-run settings (symbol, capital, commission).
+	Backtest BacktestConfig
+	// Grid is the parameter grid swept on each in-sample window.
+	Grid ParameterGrid
+	// InSampleBars is the number of bars used to optimize parameters.
+	InSampleBars int
+	// OutOfSampleBars is the number of bars used to evaluate the chosen parameters.
+	OutOfSampleBars int
+	// StepBars is how far each window advances (0 = OutOfSampleBars). It
+	// can't be less than OutOfSampleBars, or out-of-sample windows would
+	// overlap and their trades be counted twice.
+	StepBars int
+	// Objective ranks in-sample results (nil = SharpeObjective).
+	Objective Objective
+}
+
+// WalkForwardWindow holds the outcome of one in-sample/out-of-sample window.
+type WalkForwardWindow struct {
+	InSampleStart      time.Time              `json:"in_sample_start"`
+	InSampleEnd        time.Time              `json:"in_sample_end"`
+	OutOfSampleStart   time.Time              `json:"out_of_sample_start"`
+	OutOfSampleEnd     time.Time              `json:"out_of_sample_end"`
+	Parameters         map[string]interface{} `json:"parameters"`
+	InSampleScore      float64                `json:"in_sample_score"`
+	OutOfSampleMetrics *Metrics               `json:"out_of_sample_metrics"`
+}
+
+// WalkForwardResult holds the results of a walk-forward analysis.
+type WalkForwardResult struct {
+	// Strategy is the name of the strategy tested.
+	Strategy string `json:"strategy"`
+	// Windows holds the chosen parameters and out-of-sample metrics per window.
+	Windows []WalkForwardWindow `json:"windows"`
+	// Metrics aggregates all out-of-sample windows, compounding capital
+	// from one window to the next.
+	Metrics *Metrics `json:"metrics"`
+	// Trades are the out-of-sample trades across all windows.
+	Trades []SimulatedTrade `json:"trades"`
+	// EquityCurve is the stitched out-of-sample equity curve.
+	EquityCurve []EquityPoint `json:"equity_curve"`
+}
+
+// WalkForward runs a walk-forward analysis. The data is split into sequential
+// in-sample/out-of-sample windows; on each in-sample window every parameter
+// combination in the grid is backtested and the best by the objective is kept,
+// then evaluated on the following out-of-sample window. The in-sample bars
+// seed indicators for the out-of-sample run but do not trade.
+//
+// Args:
+//   - newStrategy: Factory for fresh strategy instances
+//   - data: Historical OHLCV data (oldest first)
+//   - config: Walk-forward configuration
+//
+// Returns:
+//   - *WalkForwardResult: Per-window parameters and aggregated out-of-sample metrics
+//   - error: Any error encountered
+func (e *Engine) WalkForward(newStrategy StrategyFactory, data []models.OHLCV, config WalkForwardConfig) (*WalkForwardResult, error) {
+	if config.InSampleBars < 2 || config.OutOfSampleBars < 1 {
+		return nil, fmt.Errorf("in-sample must be at least 2 bars and out-of-sample at least 1 bar")
+	}
+	if config.StepBars > 0 && config.StepBars < config.OutOfSampleBars {
+		return nil, fmt.Errorf("step of %d bars would overlap out-of-sample windows of %d bars",
+			config.StepBars, config.OutOfSampleBars)
+	}
+	if len(data) < config.InSampleBars+config.OutOfSampleBars {
+		return nil, fmt.Errorf("need at least %d bars for one walk-forward window, got %d",
+			config.InSampleBars+config.OutOfSampleBars, len(data))
+	}
+
+	step := config.StepBars
+	if step <= 0 {
+		step = config.OutOfSampleBars
+	}
+	objective := config.Objective
+	if objective == nil {
+		objective = SharpeObjective
+	}
+
+	combos := expandGrid(config.Grid)
+	result := &WalkForwardResult{
+		Strategy:    newStrategy().Name(),
+		Windows:     []WalkForwardWindow{},
+		Trades:      []SimulatedTrade{},
+		EquityCurve: []EquityPoint{},
+	}
+	capital := config.Backtest.InitialCapital
+	commissions := 0.0
+
+	for start := 0; start+config.InSampleBars+config.OutOfSampleBars <= len(data); start += step {
+		isEnd := start + config.InSampleBars
+		oosEnd := isEnd + config.OutOfSampleBars
+		inSample := data[start:isEnd]
+
+		// Optimize on the in-sample window
+		var best map[string]interface{}
+		bestScore := 0.0
+		for _, params := range combos {
+			strategy := newStrategy()
+			if err := strategy.Init(params); err != nil {
+				continue // Invalid combination (e.g. short >= long period)
+			}
+
+			btConfig := config.Backtest
+			btConfig.StartDate = inSample[0].Timestamp
+			btConfig.EndDate = inSample[len(inSample)-1].Timestamp
+			run, err := e.Run(strategy, inSample, btConfig)
+			var insufficient *InsufficientDataError
+			if errors.As(err, &insufficient) {
+				continue // Periods too long for the in-sample window
+			}
+			if err != nil {
+				return nil, fmt.Errorf("in-sample backtest failed: %w", err)
+			}
+
+			if score := objective(run.Metrics); best == nil || score > bestScore {
+				best = params
+				bestScore = score
+			}
+		}
+		if best == nil {
+			return nil, fmt.Errorf("no valid parameter combination for window starting %s",
+				inSample[0].Timestamp.Format(time.RFC3339))
+		}
+
+		// Evaluate on the out-of-sample window, warming up on the in-sample bars
+		strategy := newStrategy()
+		if err := strategy.Init(best); err != nil {
+			return nil, fmt.Errorf("failed to initialize strategy: %w", err)
+		}
+
+		btConfig := config.Backtest
+		btConfig.InitialCapital = capital
+		btConfig.StartDate = data[isEnd].Timestamp
+		btConfig.EndDate = data[oosEnd-1].Timestamp
+		btConfig.WarmupBars = config.InSampleBars
+		run, err := e.Run(strategy, data[start:oosEnd], btConfig)
+		if err != nil {
+			return nil, fmt.Errorf("out-of-sample backtest failed: %w", err)
+		}
+
+		result.Windows = append(result.Windows, WalkForwardWindow{
+			InSampleStart:      inSample[0].Timestamp,
+			InSampleEnd:        inSample[len(inSample)-1].Timestamp,
+			OutOfSampleStart:   data[isEnd].Timestamp,
+			OutOfSampleEnd:     data[oosEnd-1].Timestamp,
+			Parameters:         best,
+			InSampleScore:      bestScore,
+			OutOfSampleMetrics: run.Metrics,
+		})
+		result.Trades = append(result.Trades, run.Trades...)
+		result.EquityCurve = append(result.EquityCurve, run.EquityCurve...)
+		commissions += run.Metrics.TotalCommissions
+		if len(run.EquityCurve) > 0 {
+			capital = run.Metrics.FinalEquity
+		}
+	}
+
+	result.Metrics = CalculateMetrics(result.Trades, result.EquityCurve, config.Backtest.InitialCapital)
+	result.Metrics.TotalCommissions = commissions
+	return result, nil
+}
+
+// expandGrid returns every parameter combination in the grid.
+// Keys are iterated in sorted order so results are deterministic.
+// An empty grid yields a single empty combination (strategy defaults).
+//
+// Args:
+//   - grid: Parameter grid
+//
+// Returns:
+//   - []map[string]interface{}: All parameter combinations
+func expandGrid(grid ParameterGrid) []map[string]interface{} {
+	keys := make([]string, 0, len(grid))
+	for k := range grid {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	combos := []map[string]interface{}{{}}
+	for _, key := range keys {
+		var next []map[string]interface{}
+		for _, combo := range combos {
+			for _, value := range grid[key] {
+				params := make(map[string]interface{}, len(combo)+1)
+				for k, v := range combo {
+					params[k] = v
+				}
+				params[key] = value
+				next = append(next, params)
+			}
+		}
+		combos = next
+	}
+	return combos
+}
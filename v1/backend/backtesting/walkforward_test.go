@@ -0,0 +1,138 @@
+package backtesting
+
+import (
+	"testing"
+
+	"github.com/alexherrero/sherwood/backend/strategies"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMACrossover() strategies.Strategy {
+	return strategies.NewMACrossover()
+}
+
+// TestExpandGrid verifies exhaustive parameter combinations.
+func TestExpandGrid(t *testing.T) {
+	combos := expandGrid(ParameterGrid{
+		"short_period": {3, 5},
+		"long_period":  {10, 20, 30},
+	})
+	require.Len(t, combos, 6)
+	assert.Equal(t, map[string]interface{}{"long_period": 10, "short_period": 3}, combos[0])
+	assert.Equal(t, map[string]interface{}{"long_period": 30, "short_period": 5}, combos[5])
+
+	// Empty grid runs the strategy defaults once
+	assert.Equal(t, []map[string]interface{}{{}}, expandGrid(nil))
+}
+
+// TestEngine_WalkForward verifies windows, chosen parameters, and aggregation.
+func TestEngine_WalkForward(t *testing.T) {
+	engine := NewEngine()
+	data := generateTestOHLCVData(100, "TEST")
+
+	result, err := engine.WalkForward(newMACrossover, data, WalkForwardConfig{
+		Backtest: BacktestConfig{Symbol: "TEST", InitialCapital: 10000, Commission: 1},
+		Grid: ParameterGrid{
+			"short_period": {2, 3},
+			"long_period":  {3, 5},
+		},
+		InSampleBars:    40,
+		OutOfSampleBars: 20,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "ma_crossover", result.Strategy)
+	// Windows start at bars 0, 20, 40 (60 bars each)
+	require.Len(t, result.Windows, 3)
+	assert.Equal(t, data[40].Timestamp, result.Windows[0].OutOfSampleStart)
+	assert.Equal(t, data[59].Timestamp, result.Windows[0].OutOfSampleEnd)
+	assert.Equal(t, data[20].Timestamp, result.Windows[1].InSampleStart)
+
+	commissions := 0.0
+	for _, w := range result.Windows {
+		require.NotNil(t, w.OutOfSampleMetrics)
+		// short 3 / long 3 is invalid and never chosen
+		assert.Less(t, w.Parameters["short_period"], w.Parameters["long_period"])
+		commissions += w.OutOfSampleMetrics.TotalCommissions
+	}
+
+	// Equity curve covers only out-of-sample bars
+	assert.Len(t, result.EquityCurve, 60)
+	assert.Equal(t, data[40].Timestamp, result.EquityCurve[0].Timestamp)
+	require.NotNil(t, result.Metrics)
+	assert.Equal(t, len(result.Trades), result.Metrics.TotalTrades)
+	assert.Positive(t, result.Metrics.TotalCommissions)
+	assert.InDelta(t, commissions, result.Metrics.TotalCommissions, 1e-9)
+}
+
+// TestEngine_WalkForward_CustomObjectiveAndStep verifies the objective and step are honored.
+func TestEngine_WalkForward_CustomObjectiveAndStep(t *testing.T) {
+	engine := NewEngine()
+	data := generateTestOHLCVData(60, "TEST")
+
+	// Prefer the fewest trades
+	fewestTrades := func(m *Metrics) float64 { return -float64(m.TotalTrades) }
+
+	result, err := engine.WalkForward(newMACrossover, data, WalkForwardConfig{
+		Backtest:        BacktestConfig{Symbol: "TEST", InitialCapital: 10000},
+		Grid:            ParameterGrid{"short_period": {2}, "long_period": {3, 25}},
+		InSampleBars:    30,
+		OutOfSampleBars: 5,
+		StepBars:        10,
+		Objective:       fewestTrades,
+	})
+	require.NoError(t, err)
+
+	// Windows start at bars 0, 10, 20
+	assert.Len(t, result.Windows, 3)
+	for _, w := range result.Windows {
+		assert.Equal(t, 25, w.Parameters["long_period"])
+	}
+}
+
+// TestEngine_WalkForward_SkipsLongPeriods verifies combinations needing more
+// bars than the in-sample window are skipped rather than failing the run.
+func TestEngine_WalkForward_SkipsLongPeriods(t *testing.T) {
+	engine := NewEngine()
+	data := generateTestOHLCVData(100, "TEST")
+
+	result, err := engine.WalkForward(newMACrossover, data, WalkForwardConfig{
+		Backtest:        BacktestConfig{Symbol: "TEST", InitialCapital: 10000},
+		Grid:            ParameterGrid{"short_period": {2}, "long_period": {5, 50}},
+		InSampleBars:    40,
+		OutOfSampleBars: 20,
+	})
+	require.NoError(t, err)
+
+	require.NotEmpty(t, result.Windows)
+	for _, w := range result.Windows {
+		assert.Equal(t, 5, w.Parameters["long_period"])
+	}
+}
+
+// TestEngine_WalkForward_Errors verifies configuration validation.
+func TestEngine_WalkForward_Errors(t *testing.T) {
+	engine := NewEngine()
+	data := generateTestOHLCVData(30, "TEST")
+
+	_, err := engine.WalkForward(newMACrossover, data, WalkForwardConfig{InSampleBars: 0, OutOfSampleBars: 10})
+	assert.Error(t, err)
+
+	_, err = engine.WalkForward(newMACrossover, data, WalkForwardConfig{InSampleBars: 25, OutOfSampleBars: 10})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "need at least 35 bars")
+
+	_, err = engine.WalkForward(newMACrossover, data, WalkForwardConfig{InSampleBars: 10, OutOfSampleBars: 10, StepBars: 5})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "overlap")
+
+	_, err = engine.WalkForward(newMACrossover, data, WalkForwardConfig{
+		Backtest:        BacktestConfig{InitialCapital: 10000},
+		Grid:            ParameterGrid{"short_period": {10}, "long_period": {5}},
+		InSampleBars:    20,
+		OutOfSampleBars: 10,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no valid parameter combination")
+}
@@ -0,0 +1,46 @@
+// Package config provides parsing of WebSocket broadcast throttles.
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultBroadcastThrottle coalesces market data to one candle per symbol
+// per second, so fast engine ticks don't flood clients.
+const DefaultBroadcastThrottle = "market_data:1s"
+
+// ParseBroadcastThrottles parses per-type broadcast intervals from a
+// comma-separated list of TYPE:DURATION entries, e.g.
+// "market_data:1s,heartbeat:10s". A 0 duration leaves the type unthrottled.
+//
+// Args:
+//   - s: The throttle list (empty for none)
+//
+// Returns:
+//   - map[string]time.Duration: Intervals keyed by message type
+//   - error: If an entry is malformed or a duration is negative
+func ParseBroadcastThrottles(s string) (map[string]time.Duration, error) {
+	throttles := make(map[string]time.Duration)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		msgType, value, ok := strings.Cut(entry, ":")
+		msgType = strings.TrimSpace(msgType)
+		if !ok || msgType == "" {
+			return nil, fmt.Errorf("invalid broadcast throttle %q: must be TYPE:DURATION", entry)
+		}
+		interval, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || interval < 0 {
+			return nil, fmt.Errorf("invalid broadcast throttle %q: duration must be 0 or greater (e.g. 1s)", entry)
+		}
+		if _, dup := throttles[msgType]; dup {
+			return nil, fmt.Errorf("invalid broadcast throttle %q: %s is already throttled", entry, msgType)
+		}
+		throttles[msgType] = interval
+	}
+	return throttles, nil
+}
@@ -5,6 +5,7 @@ package config
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -12,6 +13,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/engine"
+	"github.com/alexherrero/sherwood/backend/notifications"
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -36,7 +40,23 @@ var validLogLevels = map[string]bool{
 
 // validProviders is the set of accepted data provider names.
 var validProviders = map[string]bool{
-	"yahoo": true, "tiingo": true, "binance": true,
+	"yahoo": true, "tiingo": true, "binance": true, "coingecko": true, "replay": true,
+}
+
+// defaultTradingSymbols is the symbol universe traded when TRADING_SYMBOLS is unset.
+const defaultTradingSymbols = "SPY,BTC-USD,ETH-USD,AAPL,MSFT"
+
+// maxSymbolLength bounds a TRADING_SYMBOLS entry; ratio specs are the longest.
+const maxSymbolLength = 32
+
+// validCalendars lists the accepted TRADING_CALENDAR values ("" disables).
+var validCalendars = map[string]bool{
+	"": true, "none": true, "us_equity": true,
+}
+
+// validGapPolicies lists the accepted DATA_GAP_POLICY values ("" logs).
+var validGapPolicies = map[string]bool{
+	"": true, "log": true, "drop": true, "fill": true,
 }
 
 // validStrategies is the set of accepted strategy names.
@@ -46,6 +66,7 @@ var validStrategies = map[string]bool{
 	"bb_mean_reversion":   true,
 	"macd_trend_follower": true,
 	"nyc_close_open":      true,
+	"webhook_signals":     true,
 }
 
 // ValidationError holds multiple configuration validation errors.
@@ -87,6 +108,11 @@ type ReloadResult struct {
 type Config struct {
 	mu sync.RWMutex // protects hot-reloadable fields during concurrent access
 
+	lastKeyRotation time.Time // when RotateAPIKey last succeeded
+
+	aliases       *data.SymbolAliases // SymbolAliases and ProviderSymbols, parsed by Validate
+	aliasesParsed bool                // whether aliases reflects the current lists
+
 	// Server settings
 	ServerPort int
 	ServerHost string
@@ -94,13 +120,15 @@ type Config struct {
 	APIKey string
 
 	// CORS settings
-	AllowedOrigins []string // Comma-separated list of allowed origins for CORS
+	AllowedOrigins []string // Comma-separated list of allowed CORS origins: exact, https://*.example.com or regex:<expr>
 
 	// Trading settings
 	TradingMode TradingMode
 
 	// Database settings
-	DatabasePath string
+	DatabasePath   string
+	DBBusyTimeout  time.Duration // How long SQLite waits on a locked database, 0 fails immediately (default: 5s)
+	DBMaxOpenConns int           // SQLite connection pool limit, 0 is unlimited (default: 4)
 
 	// Redis settings (optional)
 	RedisURL string
@@ -118,15 +146,124 @@ type Config struct {
 	BinanceAPISecret string
 	UseBinanceUS     bool   // Set to true for US users (geo-restricted from binance.com)
 	TiingoAPIKey     string // Tiingo API key (get free at tiingo.com)
+	YahooAdjusted    bool   // If true, Yahoo returns split/dividend adjusted OHLC (default: false)
+	ReplayDataDir    string // Directory of recorded candles served by the replay provider
 
 	// Dynamic Configuration (Phase 2)
-	DataProvider      string   // Selected data provider (yahoo, tiingo, binance)
+	DataProvider      string   // Selected data provider (yahoo, tiingo, binance, coingecko, replay)
 	EnabledStrategies []string // List of enabled strategy names
+	TradingSymbols    []string // Symbols the engine trades, in canonical form (default: SPY, BTC-USD, ETH-USD, AAPL, MSFT)
+	SymbolAliases     string   // User-defined symbol names, NAME:CANONICAL comma-separated (e.g. "BTCUSDT:BTC-USD")
+	ProviderSymbols   string   // Provider symbol overrides, CANONICAL:PROVIDER_SYMBOL comma-separated (e.g. "BTC-USD:XBTUSD")
 
 	// Shutdown settings
 	CloseOnShutdown bool          // If true, close all positions on graceful shutdown
 	ShutdownTimeout time.Duration // Maximum time for graceful shutdown (default: 30s)
 
+	// Engine settings
+	EngineAlignTicks     bool          // If true, align engine ticks to interval boundaries (e.g. :00 of each minute)
+	EngineWarmupTicks    int           // Number of ticks strategies run before signals are executed (default: 0)
+	EngineConcurrency    int           // Max symbols processed at once per tick; 0 processes all at once (default: 0)
+	EnginePrimeData      bool          // If true, fetch each symbol's history sequentially at engine start
+	EnginePrimeTimeout   time.Duration // Time allowed for start-up priming; 0 means no limit (default: 30s)
+	StaleCryptoIntervals int           // Skip crypto symbols whose latest candle is this many intervals old; 0 disables (default: 3)
+	StaleEquityIntervals int           // Same for equities, not counting weekends; 0 disables (default: 5)
+	StaleDataNotify      bool          // If true, notify when a symbol is skipped for stale data
+	LogSignals           bool          // If true, record every strategy signal (including holds) in the database
+	EngineHeartbeat      bool          // If true, broadcast a heartbeat WebSocket event after every engine tick (default: true)
+	EngineOrderThrottle  time.Duration // Minimum time between engine orders on the same symbol; 0 disables (default: 0)
+	EngineFetchFailures  int           // Consecutive ticks with every data fetch failing before the engine pauses; 0 disables (default: 5)
+	SymbolIntervals      string        // Per-symbol candle intervals, SYMBOL:INTERVAL comma-separated (e.g. "BTC-USD:1h"); others use the strategy timeframe
+
+	// Auto-exit settings
+	AutoExitTakeProfitPct float64 // Close a position once its unrealized gain reaches this percent of cost; 0 disables (default: 0)
+	AutoExitStopLossPct   float64 // Close a position once its unrealized loss reaches this percent of cost; 0 disables (default: 0)
+	AutoExitOverrides     string  // Per-symbol levels, SYMBOL:TAKE_PROFIT_PCT:STOP_LOSS_PCT comma-separated (e.g. "BTC-USD:15:8")
+
+	// Order retry settings
+	OrderRetryAttempts int           // Total submission attempts for retryable order failures (default: 3)
+	OrderRetryDelay    time.Duration // Wait before the first retry; doubles per attempt (default: 500ms)
+
+	// Order sizing settings
+	OrderMinNotional   float64 // Orders worth less than this are rejected (default: 0, disabled)
+	OrderMaxNotional   float64 // Orders worth more than this are rejected as likely mistakes (default: 1000000; 0 = no cap)
+	OrderEquityLotSize float64 // Equity quantities are rounded down to a multiple of this (default: 1, whole shares; 0 = no rounding)
+	OrderCryptoLotSize float64 // Crypto quantities are rounded down to a multiple of this (default: 0, no rounding)
+
+	// Live order confirmation
+	OrderConfirmThreshold float64       // Live orders worth more than this are staged until confirmed (default: 0, disabled)
+	OrderConfirmWindow    time.Duration // How long a staged order waits for confirmation before it is discarded (default: 2m)
+
+	// Risk limits
+	MaxDailyTrades    int     // Filled orders each strategy may make per UTC day before its orders are rejected (default: 0, disabled)
+	MaxDailyLoss      float64 // Realized loss per UTC day, net of fees, that trips the circuit breaker (default: 0, disabled)
+	MinCashReserve    float64 // Cash a buy may not spend below (default: 0, disabled)
+	MinCashReservePct float64 // Cash reserve as a fraction of equity, e.g. 0.05; the larger reserve applies (default: 0, disabled)
+
+	// Account baseline
+	InitialCapital float64 // Paper starting cash and first-run performance baseline, also used by the reset endpoint; 0 means 100000 (default: 100000)
+
+	// Paper broker fill simulation
+	PaperSpreadBps   float64 // Bid-ask spread applied to paper market fills, in basis points (default: 0)
+	PaperDepthImpact float64 // Fractional price impact per unit of notional for paper market fills (default: 0)
+	PaperFeePerOrder float64 // Flat commission charged on each paper fill (default: 0)
+	PaperFeeBps      float64 // Commission on each paper fill's notional, in basis points (default: 0)
+	PaperFeeMinimum  float64 // Smallest commission charged on a paper fill (default: 0)
+
+	// Portfolio settings
+	BaseCurrency string // Currency portfolio totals are converted to; empty means USD (default: USD)
+
+	// Trading calendar settings
+	TradingCalendar         string // Market hours applied to engine execution: us_equity or none (default: us_equity)
+	CalendarFetchWhenClosed bool   // If true, fetch and broadcast data for symbols whose market is closed
+
+	// Reconciliation settings
+	ReconcileOnStart  bool          // If true, reconcile orders and positions with the broker when the engine starts
+	ReconcileInterval time.Duration // How often to reconcile with the broker while running (default: 0, disabled)
+
+	// Broker connection settings
+	BrokerCheckInterval       time.Duration // How often the engine checks the broker connection (default: 30s, 0 disables)
+	BrokerReconnectBackoff    time.Duration // Wait before the first reconnect retry, doubled after each failure (default: 1s)
+	BrokerReconnectMaxBackoff time.Duration // Upper bound on the reconnect retry wait (default: 1m)
+
+	// Performance history settings
+	EquitySnapshotInterval time.Duration // How often the engine records account equity (default: 5m, 0 disables)
+
+	// Backtest settings
+	BacktestWorkers      int // Size of the async backtest worker pool (default: 2)
+	BacktestMinBars      int // Fewest bars a backtest runs on, even if the strategy's longest period needs fewer (default: 0)
+	MaxSweepCombinations int // Maximum parameter combinations in one backtest sweep (default: 100)
+
+	// Market data settings
+	MaxHistoryCandles int           // Maximum candles a historical data request may span (default: 5000)
+	TickerCacheTTL    time.Duration // How long ticker metadata is cached by the API, 0 disables (default: 24h)
+	DataGapPolicy     string        // How missing bars are handled: log, drop (incomplete trailing bars) or fill (default: log)
+	ProviderTimeout   time.Duration // Per-request timeout for data provider calls, 0 disables (default: 30s)
+	CandleTimezone    string        // IANA timezone candle timestamps are converted to before strategies see them; empty leaves them as the provider returns them (default: America/New_York)
+
+	// Per-route rate limits, in requests per minute per client IP (0 disables)
+	RateLimitReads     int // GET endpoints under /api/v1 (default: 300)
+	RateLimitBacktests int // Backtest submissions (default: 10)
+	RateLimitOrders    int // Manual order placement (default: 30)
+	RateLimitWrites    int // Other mutating endpoints under /api/v1, e.g. engine, config and kill switch (default: 60)
+
+	CompressionMinBytes int // Smallest response body gzip- or deflate-encoded for clients that accept it (default: 1024, 0 disables)
+
+	// WebSocket settings
+	WSMaxClients        int    // Maximum concurrent WebSocket clients; more are rejected with 503 (default: 100, 0 = unlimited)
+	WSBroadcastThrottle string // Minimum interval between broadcasts per message type, TYPE:DURATION comma-separated (default: "market_data:1s")
+
+	// Notification settings
+	NotificationDedupWindow time.Duration // Identical notifications within this window are collapsed into one (default: 1m, 0 disables)
+	NotificationRateLimit   int           // Maximum notifications per type per minute; more are dropped (default: 20, 0 = unlimited)
+	NotificationQuietStart  string        // Start of daily quiet hours, HH:MM; non-error notifications are held until they end (empty disables)
+	NotificationQuietEnd    string        // End of daily quiet hours, HH:MM; a summary of held notifications is sent then
+	NotificationQuietTZ     string        // IANA timezone quiet hours are in (default: UTC)
+	OrderFillNotify         bool          // If true, send a notification for every order fill (default: true)
+
+	// Health check settings
+	HealthCanarySymbol string // Symbol priced by /health to probe the data provider (default: first trading symbol; empty disables the probe)
+
 	// Internal settings
 	EnvFile string // Path to .env file (default: .env)
 }
@@ -150,6 +287,10 @@ func Load() (*Config, error) {
 		RedisURL:     getEnv("REDIS_URL", ""),
 		LogLevel:     getEnv("LOG_LEVEL", "info"),
 
+		// SQLite connection tuning
+		DBBusyTimeout:  getEnvDuration("DB_BUSY_TIMEOUT", 5*time.Second),
+		DBMaxOpenConns: getEnvInt("DB_MAX_OPEN_CONNS", 4),
+
 		// CORS settings - default to allow localhost for development
 		AllowedOrigins: parseStrategies(getEnv("ALLOWED_ORIGINS", "http://localhost:3000,http://localhost:8080")),
 
@@ -166,16 +307,122 @@ func Load() (*Config, error) {
 		// Tiingo credentials
 		TiingoAPIKey: os.Getenv("TIINGO_API_KEY"),
 
+		YahooAdjusted: getEnv("YAHOO_ADJUSTED", "false") == "true",
+		ReplayDataDir: getEnv("REPLAY_DATA_DIR", ""),
+
 		// Dynamic Configuration (Phase 2)
 		DataProvider:      getEnv("DATA_PROVIDER", "yahoo"),
 		EnabledStrategies: parseStrategies(getEnv("ENABLED_STRATEGIES", "ma_crossover")),
+		TradingSymbols:    parseSymbols(getEnv("TRADING_SYMBOLS", defaultTradingSymbols)),
+		SymbolAliases:     getEnv("SYMBOL_ALIASES", ""),
+		ProviderSymbols:   getEnv("PROVIDER_SYMBOLS", ""),
 
 		EnvFile: ".env",
 
 		// Shutdown settings
 		CloseOnShutdown: getEnv("CLOSE_ON_SHUTDOWN", "false") == "true",
 		ShutdownTimeout: getEnvDuration("SHUTDOWN_TIMEOUT", 30*time.Second),
+
+		// Engine settings
+		EngineAlignTicks:     getEnv("ENGINE_ALIGN_TICKS", "false") == "true",
+		EngineWarmupTicks:    getEnvInt("ENGINE_WARMUP_TICKS", 0),
+		EngineConcurrency:    getEnvInt("ENGINE_SYMBOL_CONCURRENCY", 0),
+		EnginePrimeData:      getEnv("ENGINE_PRIME_DATA", "false") == "true",
+		EnginePrimeTimeout:   getEnvDuration("ENGINE_PRIME_TIMEOUT", 30*time.Second),
+		StaleCryptoIntervals: getEnvInt("STALE_DATA_CRYPTO_INTERVALS", 3),
+		StaleEquityIntervals: getEnvInt("STALE_DATA_EQUITY_INTERVALS", 5),
+		StaleDataNotify:      getEnv("STALE_DATA_NOTIFY", "false") == "true",
+		LogSignals:           getEnv("LOG_SIGNALS", "false") == "true",
+		EngineHeartbeat:      getEnv("ENGINE_HEARTBEAT", "true") == "true",
+		EngineOrderThrottle:  getEnvDuration("ENGINE_ORDER_THROTTLE", 0),
+		EngineFetchFailures:  getEnvInt("ENGINE_FETCH_FAILURE_LIMIT", 5),
+		SymbolIntervals:      getEnv("SYMBOL_INTERVALS", ""),
+
+		// Auto-exit settings
+		AutoExitTakeProfitPct: getEnvFloat("AUTO_EXIT_TAKE_PROFIT_PCT", 0),
+		AutoExitStopLossPct:   getEnvFloat("AUTO_EXIT_STOP_LOSS_PCT", 0),
+		AutoExitOverrides:     getEnv("AUTO_EXIT_OVERRIDES", ""),
+
+		// Order retry settings
+		OrderRetryAttempts: getEnvInt("ORDER_RETRY_ATTEMPTS", 3),
+		OrderRetryDelay:    getEnvDuration("ORDER_RETRY_DELAY", 500*time.Millisecond),
+
+		// Order sizing settings
+		OrderMinNotional:   getEnvFloat("ORDER_MIN_NOTIONAL", 0),
+		OrderMaxNotional:   getEnvFloat("ORDER_MAX_NOTIONAL", 1000000),
+		OrderEquityLotSize: getEnvFloat("ORDER_EQUITY_LOT_SIZE", 1),
+		OrderCryptoLotSize: getEnvFloat("ORDER_CRYPTO_LOT_SIZE", 0),
+
+		// Live order confirmation
+		OrderConfirmThreshold: getEnvFloat("ORDER_CONFIRM_THRESHOLD", 0),
+		OrderConfirmWindow:    getEnvDuration("ORDER_CONFIRM_WINDOW", 2*time.Minute),
+
+		// Risk limits
+		MaxDailyTrades:    getEnvInt("MAX_DAILY_TRADES", 0),
+		MaxDailyLoss:      getEnvFloat("MAX_DAILY_LOSS", 0),
+		MinCashReserve:    getEnvFloat("MIN_CASH_RESERVE", 0),
+		MinCashReservePct: getEnvFloat("MIN_CASH_RESERVE_PCT", 0),
+
+		// Paper broker fill simulation
+		InitialCapital:   getEnvFloat("INITIAL_CAPITAL", 100000),
+		PaperSpreadBps:   getEnvFloat("PAPER_SPREAD_BPS", 0),
+		PaperDepthImpact: getEnvFloat("PAPER_DEPTH_IMPACT", 0),
+		PaperFeePerOrder: getEnvFloat("PAPER_FEE_PER_ORDER", 0),
+		PaperFeeBps:      getEnvFloat("PAPER_FEE_BPS", 0),
+		PaperFeeMinimum:  getEnvFloat("PAPER_FEE_MIN", 0),
+
+		// Portfolio settings
+		BaseCurrency: strings.ToUpper(getEnv("BASE_CURRENCY", "USD")),
+
+		// Trading calendar settings
+		TradingCalendar:         getEnv("TRADING_CALENDAR", "us_equity"),
+		CalendarFetchWhenClosed: getEnv("CALENDAR_FETCH_WHEN_CLOSED", "false") == "true",
+
+		// Reconciliation settings
+		ReconcileOnStart:  getEnv("RECONCILE_ON_START", "false") == "true",
+		ReconcileInterval: getEnvDuration("RECONCILE_INTERVAL", 0),
+
+		// Broker connection settings
+		BrokerCheckInterval:       getEnvDuration("BROKER_CHECK_INTERVAL", 30*time.Second),
+		BrokerReconnectBackoff:    getEnvDuration("BROKER_RECONNECT_BACKOFF", time.Second),
+		BrokerReconnectMaxBackoff: getEnvDuration("BROKER_RECONNECT_MAX_BACKOFF", time.Minute),
+
+		// Performance history settings
+		EquitySnapshotInterval: getEnvDuration("EQUITY_SNAPSHOT_INTERVAL", 5*time.Minute),
+
+		// Backtest settings
+		BacktestWorkers:      getEnvInt("BACKTEST_WORKERS", 2),
+		BacktestMinBars:      getEnvInt("BACKTEST_MIN_BARS", 0),
+		MaxSweepCombinations: getEnvInt("MAX_SWEEP_COMBINATIONS", 100),
+
+		// Market data settings
+		MaxHistoryCandles: getEnvInt("MAX_HISTORY_CANDLES", 5000),
+		TickerCacheTTL:    getEnvDuration("TICKER_CACHE_TTL", 24*time.Hour),
+		DataGapPolicy:     getEnv("DATA_GAP_POLICY", "log"),
+		ProviderTimeout:   getEnvDuration("PROVIDER_TIMEOUT", 30*time.Second),
+		CandleTimezone:    getEnv("CANDLE_TIMEZONE", "America/New_York"),
+
+		// Rate limit settings
+		RateLimitReads:     getEnvInt("RATE_LIMIT_READS", 300),
+		RateLimitBacktests: getEnvInt("RATE_LIMIT_BACKTESTS", 10),
+		RateLimitOrders:    getEnvInt("RATE_LIMIT_ORDERS", 30),
+		RateLimitWrites:    getEnvInt("RATE_LIMIT_WRITES", 60),
+
+		CompressionMinBytes: getEnvInt("COMPRESSION_MIN_BYTES", 1024),
+
+		// WebSocket settings
+		WSMaxClients:        getEnvInt("WS_MAX_CLIENTS", 100),
+		WSBroadcastThrottle: getEnv("WS_BROADCAST_THROTTLE", DefaultBroadcastThrottle),
+
+		// Notification settings
+		NotificationDedupWindow: getEnvDuration("NOTIFICATION_DEDUP_WINDOW", time.Minute),
+		NotificationRateLimit:   getEnvInt("NOTIFICATION_RATE_LIMIT", 20),
+		NotificationQuietStart:  getEnv("NOTIFICATION_QUIET_START", ""),
+		NotificationQuietEnd:    getEnv("NOTIFICATION_QUIET_END", ""),
+		NotificationQuietTZ:     getEnv("NOTIFICATION_QUIET_TIMEZONE", "UTC"),
+		OrderFillNotify:         getEnv("ORDER_FILL_NOTIFY", "true") == "true",
 	}
+	config.HealthCanarySymbol = healthCanarySymbol(config.TradingSymbols)
 
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
@@ -193,7 +440,7 @@ func Load() (*Config, error) {
 //   - Trading mode must be "dry_run" or "live"
 //   - Server port must be 1-65535
 //   - Log level must be a valid zerolog level
-//   - Data provider must be "yahoo", "tiingo", or "binance"
+//   - Data provider must be "yahoo", "tiingo", "binance", "coingecko", or "replay"
 //   - Tiingo requires TIINGO_API_KEY
 //   - Binance requires BINANCE_API_KEY and BINANCE_API_SECRET
 //   - Live mode requires API_KEY and broker credentials (RH_USERNAME, RH_PASSWORD)
@@ -204,6 +451,7 @@ func Load() (*Config, error) {
 //   - error: ValidationError if any checks fail, nil otherwise
 func (c *Config) Validate() error {
 	var errs []string
+	aliases, aliasErr := c.parseAliases()
 
 	// --- Core settings ---
 	if c.TradingMode != ModeDryRun && c.TradingMode != ModeLive {
@@ -221,6 +469,245 @@ func (c *Config) Validate() error {
 			"DATABASE_PATH is empty: set DATABASE_PATH in .env (e.g., DATABASE_PATH=./data/sherwood.db)")
 	}
 
+	if c.DBBusyTimeout < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid DB_BUSY_TIMEOUT %s: must not be negative", c.DBBusyTimeout))
+	}
+
+	if c.DBMaxOpenConns < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid DB_MAX_OPEN_CONNS %d: must be 0 or greater", c.DBMaxOpenConns))
+	}
+
+	if c.EngineWarmupTicks < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid ENGINE_WARMUP_TICKS %d: must be 0 or greater", c.EngineWarmupTicks))
+	}
+
+	if c.EngineConcurrency < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid ENGINE_SYMBOL_CONCURRENCY %d: must be 0 (unlimited) or greater", c.EngineConcurrency))
+	}
+
+	if c.EnginePrimeTimeout < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid ENGINE_PRIME_TIMEOUT %s: must not be negative", c.EnginePrimeTimeout))
+	}
+
+	if c.EngineOrderThrottle < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid ENGINE_ORDER_THROTTLE %s: must be 0 (disabled) or greater", c.EngineOrderThrottle))
+	}
+
+	if c.EngineFetchFailures < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid ENGINE_FETCH_FAILURE_LIMIT %d: must be 0 (disabled) or greater", c.EngineFetchFailures))
+	}
+
+	if c.AutoExitTakeProfitPct < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid AUTO_EXIT_TAKE_PROFIT_PCT %g: must be 0 (disabled) or greater", c.AutoExitTakeProfitPct))
+	}
+	if c.AutoExitStopLossPct < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid AUTO_EXIT_STOP_LOSS_PCT %g: must be 0 (disabled) or greater", c.AutoExitStopLossPct))
+	}
+	if _, err := engine.ParseSymbolIntervals(c.SymbolIntervals, aliases); err != nil {
+		errs = append(errs, fmt.Sprintf("invalid SYMBOL_INTERVALS: %v", err))
+	}
+	if _, err := engine.ParseAutoExitOverrides(c.AutoExitOverrides, aliases); err != nil {
+		errs = append(errs, fmt.Sprintf("invalid AUTO_EXIT_OVERRIDES: %v", err))
+	}
+
+	if c.StaleCryptoIntervals < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid STALE_DATA_CRYPTO_INTERVALS %d: must be 0 (disabled) or greater", c.StaleCryptoIntervals))
+	}
+
+	if c.StaleEquityIntervals < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid STALE_DATA_EQUITY_INTERVALS %d: must be 0 (disabled) or greater", c.StaleEquityIntervals))
+	}
+
+	if c.OrderRetryAttempts < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid ORDER_RETRY_ATTEMPTS %d: must be 0 or greater", c.OrderRetryAttempts))
+	}
+
+	if c.OrderRetryDelay < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid ORDER_RETRY_DELAY %s: must not be negative", c.OrderRetryDelay))
+	}
+
+	orderSizes := []struct {
+		name  string
+		value float64
+	}{
+		{"ORDER_MIN_NOTIONAL", c.OrderMinNotional},
+		{"ORDER_MAX_NOTIONAL", c.OrderMaxNotional},
+		{"ORDER_EQUITY_LOT_SIZE", c.OrderEquityLotSize},
+		{"ORDER_CRYPTO_LOT_SIZE", c.OrderCryptoLotSize},
+		{"ORDER_CONFIRM_THRESHOLD", c.OrderConfirmThreshold},
+		{"MAX_DAILY_LOSS", c.MaxDailyLoss},
+		{"MIN_CASH_RESERVE", c.MinCashReserve},
+		{"MIN_CASH_RESERVE_PCT", c.MinCashReservePct},
+		{"PAPER_SPREAD_BPS", c.PaperSpreadBps},
+		{"PAPER_DEPTH_IMPACT", c.PaperDepthImpact},
+		{"PAPER_FEE_PER_ORDER", c.PaperFeePerOrder},
+		{"PAPER_FEE_BPS", c.PaperFeeBps},
+		{"PAPER_FEE_MIN", c.PaperFeeMinimum},
+	}
+	for _, size := range orderSizes {
+		if size.value < 0 {
+			errs = append(errs,
+				fmt.Sprintf("invalid %s %g: must be 0 (disabled) or greater", size.name, size.value))
+		}
+	}
+
+	if c.OrderMaxNotional > 0 && c.OrderMaxNotional < c.OrderMinNotional {
+		errs = append(errs,
+			fmt.Sprintf("invalid ORDER_MAX_NOTIONAL %g: must not be below ORDER_MIN_NOTIONAL %g",
+				c.OrderMaxNotional, c.OrderMinNotional))
+	}
+
+	if c.MaxDailyTrades < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid MAX_DAILY_TRADES %d: must be 0 (disabled) or greater", c.MaxDailyTrades))
+	}
+
+	if c.MinCashReservePct >= 1 {
+		errs = append(errs,
+			fmt.Sprintf("invalid MIN_CASH_RESERVE_PCT %g: must be a fraction of equity below 1 (e.g. 0.05 for 5%%)", c.MinCashReservePct))
+	}
+
+	if c.OrderConfirmThreshold > 0 && c.OrderConfirmWindow <= 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid ORDER_CONFIRM_WINDOW %s: must be positive when ORDER_CONFIRM_THRESHOLD is set", c.OrderConfirmWindow))
+	}
+
+	if c.InitialCapital < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid INITIAL_CAPITAL %g: must be positive, or 0 for the default", c.InitialCapital))
+	}
+
+	if c.BaseCurrency != "" && !data.IsUSDPegged(c.BaseCurrency) {
+		errs = append(errs,
+			fmt.Sprintf("invalid BASE_CURRENCY '%s': must be one of %s, the currencies with known exchange rates",
+				c.BaseCurrency, strings.Join(data.USDPeggedCurrencies(), ", ")))
+	}
+
+	if !validCalendars[c.TradingCalendar] {
+		errs = append(errs,
+			fmt.Sprintf("invalid TRADING_CALENDAR '%s': must be one of us_equity, none", c.TradingCalendar))
+	}
+
+	if c.ReconcileInterval < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid RECONCILE_INTERVAL %s: must not be negative", c.ReconcileInterval))
+	}
+
+	if c.BrokerCheckInterval < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid BROKER_CHECK_INTERVAL %s: must not be negative", c.BrokerCheckInterval))
+	}
+
+	if c.BrokerReconnectBackoff < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid BROKER_RECONNECT_BACKOFF %s: must not be negative", c.BrokerReconnectBackoff))
+	}
+
+	if c.BrokerReconnectMaxBackoff < c.BrokerReconnectBackoff {
+		errs = append(errs,
+			fmt.Sprintf("invalid BROKER_RECONNECT_MAX_BACKOFF %s: must be at least BROKER_RECONNECT_BACKOFF (%s)",
+				c.BrokerReconnectMaxBackoff, c.BrokerReconnectBackoff))
+	}
+
+	if c.EquitySnapshotInterval < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid EQUITY_SNAPSHOT_INTERVAL %s: must not be negative", c.EquitySnapshotInterval))
+	}
+
+	if c.BacktestWorkers < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid BACKTEST_WORKERS %d: must be 0 (default) or greater", c.BacktestWorkers))
+	}
+	if c.BacktestMinBars < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid BACKTEST_MIN_BARS %d: must be 0 (strategy requirement only) or greater", c.BacktestMinBars))
+	}
+	if c.MaxSweepCombinations < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid MAX_SWEEP_COMBINATIONS %d: must be 0 (default) or greater", c.MaxSweepCombinations))
+	}
+
+	if c.MaxHistoryCandles < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid MAX_HISTORY_CANDLES %d: must be 0 (default) or greater", c.MaxHistoryCandles))
+	}
+
+	if c.TickerCacheTTL < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid TICKER_CACHE_TTL %s: must not be negative", c.TickerCacheTTL))
+	}
+
+	if !validGapPolicies[c.DataGapPolicy] {
+		errs = append(errs,
+			fmt.Sprintf("invalid DATA_GAP_POLICY '%s': must be one of log, drop, fill", c.DataGapPolicy))
+	}
+
+	if c.CandleTimezone != "" {
+		if _, err := time.LoadLocation(c.CandleTimezone); err != nil {
+			errs = append(errs,
+				fmt.Sprintf("invalid CANDLE_TIMEZONE '%s': must be an IANA timezone name", c.CandleTimezone))
+		}
+	}
+
+	if c.ProviderTimeout < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid PROVIDER_TIMEOUT %s: must not be negative", c.ProviderTimeout))
+	}
+
+	rateLimits := []struct {
+		name  string
+		limit int
+	}{
+		{"RATE_LIMIT_READS", c.RateLimitReads},
+		{"RATE_LIMIT_BACKTESTS", c.RateLimitBacktests},
+		{"RATE_LIMIT_ORDERS", c.RateLimitOrders},
+		{"RATE_LIMIT_WRITES", c.RateLimitWrites},
+	}
+	for _, rl := range rateLimits {
+		if rl.limit < 0 {
+			errs = append(errs,
+				fmt.Sprintf("invalid %s %d: must be 0 (disabled) or greater", rl.name, rl.limit))
+		}
+	}
+
+	if c.CompressionMinBytes < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid COMPRESSION_MIN_BYTES %d: must be 0 (disabled) or greater", c.CompressionMinBytes))
+	}
+	if c.WSMaxClients < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid WS_MAX_CLIENTS %d: must be 0 (unlimited) or greater", c.WSMaxClients))
+	}
+	if _, err := ParseBroadcastThrottles(c.WSBroadcastThrottle); err != nil {
+		errs = append(errs, fmt.Sprintf("invalid WS_BROADCAST_THROTTLE: %v", err))
+	}
+
+	if c.NotificationDedupWindow < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid NOTIFICATION_DEDUP_WINDOW %s: must not be negative", c.NotificationDedupWindow))
+	}
+	if c.NotificationRateLimit < 0 {
+		errs = append(errs,
+			fmt.Sprintf("invalid NOTIFICATION_RATE_LIMIT %d: must be 0 (unlimited) or greater", c.NotificationRateLimit))
+	}
+	if _, err := notifications.ParseQuietHours(c.NotificationQuietStart, c.NotificationQuietEnd, c.NotificationQuietTZ); err != nil {
+		errs = append(errs,
+			fmt.Sprintf("invalid NOTIFICATION_QUIET_START/NOTIFICATION_QUIET_END/NOTIFICATION_QUIET_TIMEZONE: %v", err))
+	}
+
 	// --- Log level ---
 	if !validLogLevels[strings.ToLower(c.LogLevel)] {
 		errs = append(errs,
@@ -230,7 +717,7 @@ func (c *Config) Validate() error {
 	// --- Data provider validation ---
 	if !validProviders[c.DataProvider] {
 		errs = append(errs,
-			fmt.Sprintf("invalid DATA_PROVIDER '%s': must be one of yahoo, tiingo, binance", c.DataProvider))
+			fmt.Sprintf("invalid DATA_PROVIDER '%s': must be one of yahoo, tiingo, binance, coingecko, replay", c.DataProvider))
 	} else {
 		errs = append(errs, c.validateProvider()...)
 	}
@@ -238,6 +725,13 @@ func (c *Config) Validate() error {
 	// --- Strategy validation ---
 	errs = append(errs, c.validateStrategies()...)
 
+	// --- Symbol validation ---
+	errs = append(errs, c.validateSymbols(aliases, aliasErr)...)
+
+	if _, err := NewOriginMatcher(c.AllowedOrigins); err != nil {
+		errs = append(errs, strings.Split(err.Error(), "\n")...)
+	}
+
 	// --- Mode-specific validation ---
 	errs = append(errs, c.validateMode()...)
 
@@ -271,8 +765,16 @@ func (c *Config) validateProvider() []string {
 			errs = append(errs,
 				"Binance provider requires BINANCE_API_SECRET: set BINANCE_API_SECRET in .env")
 		}
+	case "replay":
+		if c.ReplayDataDir == "" {
+			errs = append(errs,
+				"Replay provider requires REPLAY_DATA_DIR: set it to a directory of recorded candles in .env")
+		} else if info, err := os.Stat(c.ReplayDataDir); err != nil || !info.IsDir() {
+			errs = append(errs,
+				fmt.Sprintf("invalid REPLAY_DATA_DIR '%s': not a directory", c.ReplayDataDir))
+		}
 	}
-	// yahoo requires no credentials
+	// yahoo and coingecko require no credentials
 
 	return errs
 }
@@ -298,6 +800,123 @@ func (c *Config) validateStrategies() []string {
 	return errs
 }
 
+// validateSymbols checks that the symbol aliases parsed without collisions,
+// and that each traded symbol is a plausible ticker and appears only once,
+// counting an alias as the symbol it names.
+//
+// Args:
+//   - aliases: The parsed aliases (nil if none or invalid)
+//   - aliasErr: The error parsing them, if any
+//
+// Returns:
+//   - []string: List of error messages (empty if valid)
+func (c *Config) validateSymbols(aliases *data.SymbolAliases, aliasErr error) []string {
+	var errs []string
+
+	if aliasErr != nil {
+		errs = append(errs, fmt.Sprintf("invalid SYMBOL_ALIASES or PROVIDER_SYMBOLS: %v", aliasErr))
+	}
+
+	seen := make(map[string]bool, len(c.TradingSymbols))
+	for _, symbol := range c.TradingSymbols {
+		switch {
+		case symbol == "":
+			errs = append(errs, "invalid TRADING_SYMBOLS: symbols must not be empty")
+		case len(symbol) > maxSymbolLength:
+			errs = append(errs,
+				fmt.Sprintf("invalid TRADING_SYMBOLS entry '%s': must be at most %d characters", symbol, maxSymbolLength))
+		case strings.ContainsAny(symbol, " \t"):
+			errs = append(errs,
+				fmt.Sprintf("invalid TRADING_SYMBOLS entry '%s': must not contain whitespace", symbol))
+		default:
+			canonical := aliases.Resolve(symbol)
+			if seen[canonical] {
+				errs = append(errs,
+					fmt.Sprintf("invalid TRADING_SYMBOLS: %s is listed more than once", canonical))
+			}
+			seen[canonical] = true
+		}
+	}
+
+	return errs
+}
+
+// parseAliases parses SYMBOL_ALIASES and PROVIDER_SYMBOLS and keeps the
+// result for Aliases, so the lists are parsed once when the config is
+// validated rather than on every lookup.
+func (c *Config) parseAliases() (*data.SymbolAliases, error) {
+	aliases, err := data.ParseSymbolAliases(c.SymbolAliases, c.ProviderSymbols)
+	if err != nil || aliases.Empty() {
+		aliases = nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.aliases = aliases
+	c.aliasesParsed = true
+	return aliases, err
+}
+
+// Aliases returns the parsed SYMBOL_ALIASES and PROVIDER_SYMBOLS. They are
+// parsed by Validate, or on first use for a config that was never
+// validated. Invalid lists, which Validate rejects, yield no aliases.
+//
+// Returns:
+//   - *data.SymbolAliases: The aliases (nil if none or invalid)
+func (c *Config) Aliases() *data.SymbolAliases {
+	c.mu.RLock()
+	aliases, parsed := c.aliases, c.aliasesParsed
+	c.mu.RUnlock()
+	if parsed {
+		return aliases
+	}
+	aliases, _ = c.parseAliases()
+	return aliases
+}
+
+// CoinGeckoMaxSymbols is how many symbols the coingecko provider can fetch
+// in each one-minute engine tick within its keyless limit of about ten
+// requests a minute. Ticks over it overrun into the next one.
+const CoinGeckoMaxSymbols = 10
+
+// Warnings reports settings that are valid but likely to misbehave, such as
+// traded symbols the selected data provider can't serve: Tiingo serves only
+// equities and Binance only crypto. Unlike Validate, these don't prevent
+// startup.
+//
+// Returns:
+//   - []string: Warning messages (empty if none)
+func (c *Config) Warnings() []string {
+	var warnings []string
+
+	fetched := 0
+	for _, symbol := range c.Aliases().ResolveAll(c.TradingSymbols) {
+		legs := []string{symbol}
+		if spec, ok := data.ParseSyntheticSymbol(symbol); ok {
+			legs = []string{spec.Numerator, spec.Denominator}
+		}
+		fetched += len(legs)
+		for _, leg := range legs {
+			crypto := data.IsCryptoSymbol(leg)
+			switch {
+			case crypto && c.DataProvider == "tiingo":
+				warnings = append(warnings,
+					fmt.Sprintf("TRADING_SYMBOLS includes crypto pair %s, but the tiingo provider serves equities only", leg))
+			case !crypto && (c.DataProvider == "binance" || c.DataProvider == "coingecko"):
+				warnings = append(warnings,
+					fmt.Sprintf("TRADING_SYMBOLS includes equity %s, but the %s provider serves crypto only", leg, c.DataProvider))
+			}
+		}
+	}
+	if c.DataProvider == "coingecko" && fetched > CoinGeckoMaxSymbols {
+		warnings = append(warnings,
+			fmt.Sprintf("TRADING_SYMBOLS needs %d fetches a tick, but the coingecko provider's rate limit allows %d a minute",
+				fetched, CoinGeckoMaxSymbols))
+	}
+
+	return warnings
+}
+
 // validateMode checks mode-specific requirements.
 // Live mode requires authentication and broker credentials.
 //
@@ -336,7 +955,11 @@ func (c *Config) IsLive() bool {
 
 // Reload re-reads configuration from environment variables and .env files,
 // applying only hot-reloadable fields to the live config. Structural fields
-// (server port, trading mode, data provider, enabled strategies, database path)
+// (server port, trading mode, data provider and replay directory, enabled strategies, trading symbols and aliases, database path and connection tuning,
+// engine tick alignment, warm-up, symbol concurrency, data priming, stale data guard, signal logging and heartbeats, order throttle, fetch failure limit, symbol intervals, auto-exit levels, retry, sizing and confirmation, risk limits, paper starting cash, fill simulation and fees, base currency, trading calendar,
+// reconciliation, broker reconnects, equity snapshot interval, backtest workers, minimum bars and sweep size,
+// max history candles, ticker cache TTL, data gap policy, provider timeout, candle timezone, rate limits, response compression, WebSocket client cap and broadcast throttle,
+// notification throttling, quiet hours and fill notifications)
 // are detected but NOT applied — the caller receives a RestartRequired advisory.
 //
 // Hot-reloadable fields:
@@ -359,27 +982,99 @@ func (c *Config) Reload() (*ReloadResult, error) {
 
 	// Build a fresh config from current environment
 	newCfg := &Config{
-		ServerPort:        getEnvInt("PORT", 8099),
-		ServerHost:        getEnv("HOST", "0.0.0.0"),
-		APIKey:            os.Getenv("API_KEY"),
-		TradingMode:       TradingMode(getEnv("TRADING_MODE", "dry_run")),
-		DatabasePath:      getEnv("DATABASE_PATH", "./data/sherwood.db"),
-		RedisURL:          getEnv("REDIS_URL", ""),
-		LogLevel:          getEnv("LOG_LEVEL", "info"),
-		AllowedOrigins:    parseStrategies(getEnv("ALLOWED_ORIGINS", "http://localhost:3000,http://localhost:8080")),
-		RobinhoodUsername: os.Getenv("RH_USERNAME"),
-		RobinhoodPassword: os.Getenv("RH_PASSWORD"),
-		RobinhoodMFACode:  os.Getenv("RH_MFA_CODE"),
-		BinanceAPIKey:     os.Getenv("BINANCE_API_KEY"),
-		BinanceAPISecret:  os.Getenv("BINANCE_API_SECRET"),
-		UseBinanceUS:      getEnv("BINANCE_USE_US", "true") == "true",
-		TiingoAPIKey:      os.Getenv("TIINGO_API_KEY"),
-		DataProvider:      getEnv("DATA_PROVIDER", "yahoo"),
-		EnabledStrategies: parseStrategies(getEnv("ENABLED_STRATEGIES", "ma_crossover")),
-		CloseOnShutdown:   getEnv("CLOSE_ON_SHUTDOWN", "false") == "true",
-		ShutdownTimeout:   getEnvDuration("SHUTDOWN_TIMEOUT", 30*time.Second),
-		EnvFile:           envFile,
+		ServerPort:                getEnvInt("PORT", 8099),
+		ServerHost:                getEnv("HOST", "0.0.0.0"),
+		APIKey:                    os.Getenv("API_KEY"),
+		TradingMode:               TradingMode(getEnv("TRADING_MODE", "dry_run")),
+		DatabasePath:              getEnv("DATABASE_PATH", "./data/sherwood.db"),
+		DBBusyTimeout:             getEnvDuration("DB_BUSY_TIMEOUT", 5*time.Second),
+		DBMaxOpenConns:            getEnvInt("DB_MAX_OPEN_CONNS", 4),
+		RedisURL:                  getEnv("REDIS_URL", ""),
+		LogLevel:                  getEnv("LOG_LEVEL", "info"),
+		AllowedOrigins:            parseStrategies(getEnv("ALLOWED_ORIGINS", "http://localhost:3000,http://localhost:8080")),
+		RobinhoodUsername:         os.Getenv("RH_USERNAME"),
+		RobinhoodPassword:         os.Getenv("RH_PASSWORD"),
+		RobinhoodMFACode:          os.Getenv("RH_MFA_CODE"),
+		BinanceAPIKey:             os.Getenv("BINANCE_API_KEY"),
+		BinanceAPISecret:          os.Getenv("BINANCE_API_SECRET"),
+		UseBinanceUS:              getEnv("BINANCE_USE_US", "true") == "true",
+		TiingoAPIKey:              os.Getenv("TIINGO_API_KEY"),
+		YahooAdjusted:             getEnv("YAHOO_ADJUSTED", "false") == "true",
+		ReplayDataDir:             getEnv("REPLAY_DATA_DIR", ""),
+		DataProvider:              getEnv("DATA_PROVIDER", "yahoo"),
+		EnabledStrategies:         parseStrategies(getEnv("ENABLED_STRATEGIES", "ma_crossover")),
+		TradingSymbols:            parseSymbols(getEnv("TRADING_SYMBOLS", defaultTradingSymbols)),
+		SymbolAliases:             getEnv("SYMBOL_ALIASES", ""),
+		ProviderSymbols:           getEnv("PROVIDER_SYMBOLS", ""),
+		CloseOnShutdown:           getEnv("CLOSE_ON_SHUTDOWN", "false") == "true",
+		ShutdownTimeout:           getEnvDuration("SHUTDOWN_TIMEOUT", 30*time.Second),
+		EngineAlignTicks:          getEnv("ENGINE_ALIGN_TICKS", "false") == "true",
+		EngineWarmupTicks:         getEnvInt("ENGINE_WARMUP_TICKS", 0),
+		EngineConcurrency:         getEnvInt("ENGINE_SYMBOL_CONCURRENCY", 0),
+		EnginePrimeData:           getEnv("ENGINE_PRIME_DATA", "false") == "true",
+		EnginePrimeTimeout:        getEnvDuration("ENGINE_PRIME_TIMEOUT", 30*time.Second),
+		StaleCryptoIntervals:      getEnvInt("STALE_DATA_CRYPTO_INTERVALS", 3),
+		StaleEquityIntervals:      getEnvInt("STALE_DATA_EQUITY_INTERVALS", 5),
+		StaleDataNotify:           getEnv("STALE_DATA_NOTIFY", "false") == "true",
+		LogSignals:                getEnv("LOG_SIGNALS", "false") == "true",
+		EngineHeartbeat:           getEnv("ENGINE_HEARTBEAT", "true") == "true",
+		EngineOrderThrottle:       getEnvDuration("ENGINE_ORDER_THROTTLE", 0),
+		EngineFetchFailures:       getEnvInt("ENGINE_FETCH_FAILURE_LIMIT", 5),
+		SymbolIntervals:           getEnv("SYMBOL_INTERVALS", ""),
+		AutoExitTakeProfitPct:     getEnvFloat("AUTO_EXIT_TAKE_PROFIT_PCT", 0),
+		AutoExitStopLossPct:       getEnvFloat("AUTO_EXIT_STOP_LOSS_PCT", 0),
+		AutoExitOverrides:         getEnv("AUTO_EXIT_OVERRIDES", ""),
+		OrderRetryAttempts:        getEnvInt("ORDER_RETRY_ATTEMPTS", 3),
+		OrderRetryDelay:           getEnvDuration("ORDER_RETRY_DELAY", 500*time.Millisecond),
+		OrderMinNotional:          getEnvFloat("ORDER_MIN_NOTIONAL", 0),
+		OrderMaxNotional:          getEnvFloat("ORDER_MAX_NOTIONAL", 1000000),
+		OrderEquityLotSize:        getEnvFloat("ORDER_EQUITY_LOT_SIZE", 1),
+		OrderCryptoLotSize:        getEnvFloat("ORDER_CRYPTO_LOT_SIZE", 0),
+		OrderConfirmThreshold:     getEnvFloat("ORDER_CONFIRM_THRESHOLD", 0),
+		OrderConfirmWindow:        getEnvDuration("ORDER_CONFIRM_WINDOW", 2*time.Minute),
+		MaxDailyTrades:            getEnvInt("MAX_DAILY_TRADES", 0),
+		MaxDailyLoss:              getEnvFloat("MAX_DAILY_LOSS", 0),
+		MinCashReserve:            getEnvFloat("MIN_CASH_RESERVE", 0),
+		MinCashReservePct:         getEnvFloat("MIN_CASH_RESERVE_PCT", 0),
+		InitialCapital:            getEnvFloat("INITIAL_CAPITAL", 100000),
+		PaperSpreadBps:            getEnvFloat("PAPER_SPREAD_BPS", 0),
+		PaperDepthImpact:          getEnvFloat("PAPER_DEPTH_IMPACT", 0),
+		PaperFeePerOrder:          getEnvFloat("PAPER_FEE_PER_ORDER", 0),
+		PaperFeeBps:               getEnvFloat("PAPER_FEE_BPS", 0),
+		PaperFeeMinimum:           getEnvFloat("PAPER_FEE_MIN", 0),
+		BaseCurrency:              strings.ToUpper(getEnv("BASE_CURRENCY", "USD")),
+		TradingCalendar:           getEnv("TRADING_CALENDAR", "us_equity"),
+		CalendarFetchWhenClosed:   getEnv("CALENDAR_FETCH_WHEN_CLOSED", "false") == "true",
+		ReconcileOnStart:          getEnv("RECONCILE_ON_START", "false") == "true",
+		ReconcileInterval:         getEnvDuration("RECONCILE_INTERVAL", 0),
+		BrokerCheckInterval:       getEnvDuration("BROKER_CHECK_INTERVAL", 30*time.Second),
+		BrokerReconnectBackoff:    getEnvDuration("BROKER_RECONNECT_BACKOFF", time.Second),
+		BrokerReconnectMaxBackoff: getEnvDuration("BROKER_RECONNECT_MAX_BACKOFF", time.Minute),
+		EquitySnapshotInterval:    getEnvDuration("EQUITY_SNAPSHOT_INTERVAL", 5*time.Minute),
+		BacktestWorkers:           getEnvInt("BACKTEST_WORKERS", 2),
+		BacktestMinBars:           getEnvInt("BACKTEST_MIN_BARS", 0),
+		MaxSweepCombinations:      getEnvInt("MAX_SWEEP_COMBINATIONS", 100),
+		MaxHistoryCandles:         getEnvInt("MAX_HISTORY_CANDLES", 5000),
+		TickerCacheTTL:            getEnvDuration("TICKER_CACHE_TTL", 24*time.Hour),
+		DataGapPolicy:             getEnv("DATA_GAP_POLICY", "log"),
+		CandleTimezone:            getEnv("CANDLE_TIMEZONE", "America/New_York"),
+		ProviderTimeout:           getEnvDuration("PROVIDER_TIMEOUT", 30*time.Second),
+		RateLimitReads:            getEnvInt("RATE_LIMIT_READS", 300),
+		RateLimitBacktests:        getEnvInt("RATE_LIMIT_BACKTESTS", 10),
+		RateLimitOrders:           getEnvInt("RATE_LIMIT_ORDERS", 30),
+		RateLimitWrites:           getEnvInt("RATE_LIMIT_WRITES", 60),
+		CompressionMinBytes:       getEnvInt("COMPRESSION_MIN_BYTES", 1024),
+		WSMaxClients:              getEnvInt("WS_MAX_CLIENTS", 100),
+		WSBroadcastThrottle:       getEnv("WS_BROADCAST_THROTTLE", DefaultBroadcastThrottle),
+		NotificationDedupWindow:   getEnvDuration("NOTIFICATION_DEDUP_WINDOW", time.Minute),
+		NotificationRateLimit:     getEnvInt("NOTIFICATION_RATE_LIMIT", 20),
+		NotificationQuietStart:    getEnv("NOTIFICATION_QUIET_START", ""),
+		NotificationQuietEnd:      getEnv("NOTIFICATION_QUIET_END", ""),
+		NotificationQuietTZ:       getEnv("NOTIFICATION_QUIET_TIMEZONE", "UTC"),
+		OrderFillNotify:           getEnv("ORDER_FILL_NOTIFY", "true") == "true",
+		EnvFile:                   envFile,
 	}
+	newCfg.HealthCanarySymbol = healthCanarySymbol(newCfg.TradingSymbols)
 
 	// Validate the new configuration before applying anything
 	if err := newCfg.Validate(); err != nil {
@@ -399,7 +1094,78 @@ func (c *Config) Reload() (*ReloadResult, error) {
 	c.detectRestartChange(result, "ServerHost", c.ServerHost, newCfg.ServerHost)
 	c.detectRestartChange(result, "TradingMode", string(c.TradingMode), string(newCfg.TradingMode))
 	c.detectRestartChange(result, "DataProvider", c.DataProvider, newCfg.DataProvider)
+	c.detectRestartChange(result, "YahooAdjusted", c.YahooAdjusted, newCfg.YahooAdjusted)
+	c.detectRestartChange(result, "ReplayDataDir", c.ReplayDataDir, newCfg.ReplayDataDir)
 	c.detectRestartChange(result, "DatabasePath", c.DatabasePath, newCfg.DatabasePath)
+	c.detectRestartChange(result, "DBBusyTimeout", c.DBBusyTimeout, newCfg.DBBusyTimeout)
+	c.detectRestartChange(result, "DBMaxOpenConns", c.DBMaxOpenConns, newCfg.DBMaxOpenConns)
+	c.detectRestartChange(result, "EngineAlignTicks", c.EngineAlignTicks, newCfg.EngineAlignTicks)
+	c.detectRestartChange(result, "EngineWarmupTicks", c.EngineWarmupTicks, newCfg.EngineWarmupTicks)
+	c.detectRestartChange(result, "EngineConcurrency", c.EngineConcurrency, newCfg.EngineConcurrency)
+	c.detectRestartChange(result, "EnginePrimeData", c.EnginePrimeData, newCfg.EnginePrimeData)
+	c.detectRestartChange(result, "EnginePrimeTimeout", c.EnginePrimeTimeout, newCfg.EnginePrimeTimeout)
+	c.detectRestartChange(result, "StaleCryptoIntervals", c.StaleCryptoIntervals, newCfg.StaleCryptoIntervals)
+	c.detectRestartChange(result, "StaleEquityIntervals", c.StaleEquityIntervals, newCfg.StaleEquityIntervals)
+	c.detectRestartChange(result, "StaleDataNotify", c.StaleDataNotify, newCfg.StaleDataNotify)
+	c.detectRestartChange(result, "LogSignals", c.LogSignals, newCfg.LogSignals)
+	c.detectRestartChange(result, "EngineHeartbeat", c.EngineHeartbeat, newCfg.EngineHeartbeat)
+	c.detectRestartChange(result, "EngineOrderThrottle", c.EngineOrderThrottle, newCfg.EngineOrderThrottle)
+	c.detectRestartChange(result, "EngineFetchFailures", c.EngineFetchFailures, newCfg.EngineFetchFailures)
+	c.detectRestartChange(result, "SymbolIntervals", c.SymbolIntervals, newCfg.SymbolIntervals)
+	c.detectRestartChange(result, "AutoExitTakeProfitPct", c.AutoExitTakeProfitPct, newCfg.AutoExitTakeProfitPct)
+	c.detectRestartChange(result, "AutoExitStopLossPct", c.AutoExitStopLossPct, newCfg.AutoExitStopLossPct)
+	c.detectRestartChange(result, "AutoExitOverrides", c.AutoExitOverrides, newCfg.AutoExitOverrides)
+	c.detectRestartChange(result, "OrderRetryAttempts", c.OrderRetryAttempts, newCfg.OrderRetryAttempts)
+	c.detectRestartChange(result, "OrderRetryDelay", c.OrderRetryDelay, newCfg.OrderRetryDelay)
+	c.detectRestartChange(result, "OrderMinNotional", c.OrderMinNotional, newCfg.OrderMinNotional)
+	c.detectRestartChange(result, "OrderMaxNotional", c.OrderMaxNotional, newCfg.OrderMaxNotional)
+	c.detectRestartChange(result, "OrderEquityLotSize", c.OrderEquityLotSize, newCfg.OrderEquityLotSize)
+	c.detectRestartChange(result, "OrderCryptoLotSize", c.OrderCryptoLotSize, newCfg.OrderCryptoLotSize)
+	c.detectRestartChange(result, "OrderConfirmThreshold", c.OrderConfirmThreshold, newCfg.OrderConfirmThreshold)
+	c.detectRestartChange(result, "OrderConfirmWindow", c.OrderConfirmWindow, newCfg.OrderConfirmWindow)
+	c.detectRestartChange(result, "MaxDailyTrades", c.MaxDailyTrades, newCfg.MaxDailyTrades)
+	c.detectRestartChange(result, "MaxDailyLoss", c.MaxDailyLoss, newCfg.MaxDailyLoss)
+	c.detectRestartChange(result, "MinCashReserve", c.MinCashReserve, newCfg.MinCashReserve)
+	c.detectRestartChange(result, "MinCashReservePct", c.MinCashReservePct, newCfg.MinCashReservePct)
+	c.detectRestartChange(result, "InitialCapital", c.InitialCapital, newCfg.InitialCapital)
+	c.detectRestartChange(result, "PaperSpreadBps", c.PaperSpreadBps, newCfg.PaperSpreadBps)
+	c.detectRestartChange(result, "PaperDepthImpact", c.PaperDepthImpact, newCfg.PaperDepthImpact)
+	c.detectRestartChange(result, "PaperFeePerOrder", c.PaperFeePerOrder, newCfg.PaperFeePerOrder)
+	c.detectRestartChange(result, "PaperFeeBps", c.PaperFeeBps, newCfg.PaperFeeBps)
+	c.detectRestartChange(result, "PaperFeeMinimum", c.PaperFeeMinimum, newCfg.PaperFeeMinimum)
+	c.detectRestartChange(result, "BaseCurrency", c.BaseCurrency, newCfg.BaseCurrency)
+	c.detectRestartChange(result, "TradingCalendar", c.TradingCalendar, newCfg.TradingCalendar)
+	c.detectRestartChange(result, "CalendarFetchWhenClosed", c.CalendarFetchWhenClosed, newCfg.CalendarFetchWhenClosed)
+	c.detectRestartChange(result, "ReconcileOnStart", c.ReconcileOnStart, newCfg.ReconcileOnStart)
+	c.detectRestartChange(result, "ReconcileInterval", c.ReconcileInterval, newCfg.ReconcileInterval)
+	c.detectRestartChange(result, "BrokerCheckInterval", c.BrokerCheckInterval, newCfg.BrokerCheckInterval)
+	c.detectRestartChange(result, "BrokerReconnectBackoff", c.BrokerReconnectBackoff, newCfg.BrokerReconnectBackoff)
+	c.detectRestartChange(result, "BrokerReconnectMaxBackoff", c.BrokerReconnectMaxBackoff, newCfg.BrokerReconnectMaxBackoff)
+	c.detectRestartChange(result, "EquitySnapshotInterval", c.EquitySnapshotInterval, newCfg.EquitySnapshotInterval)
+	c.detectRestartChange(result, "BacktestWorkers", c.BacktestWorkers, newCfg.BacktestWorkers)
+	c.detectRestartChange(result, "BacktestMinBars", c.BacktestMinBars, newCfg.BacktestMinBars)
+	c.detectRestartChange(result, "MaxSweepCombinations", c.MaxSweepCombinations, newCfg.MaxSweepCombinations)
+	c.detectRestartChange(result, "MaxHistoryCandles", c.MaxHistoryCandles, newCfg.MaxHistoryCandles)
+	c.detectRestartChange(result, "TickerCacheTTL", c.TickerCacheTTL, newCfg.TickerCacheTTL)
+	c.detectRestartChange(result, "DataGapPolicy", c.DataGapPolicy, newCfg.DataGapPolicy)
+	c.detectRestartChange(result, "CandleTimezone", c.CandleTimezone, newCfg.CandleTimezone)
+	c.detectRestartChange(result, "ProviderTimeout", c.ProviderTimeout, newCfg.ProviderTimeout)
+	c.detectRestartChange(result, "RateLimitReads", c.RateLimitReads, newCfg.RateLimitReads)
+	c.detectRestartChange(result, "RateLimitBacktests", c.RateLimitBacktests, newCfg.RateLimitBacktests)
+	c.detectRestartChange(result, "RateLimitOrders", c.RateLimitOrders, newCfg.RateLimitOrders)
+	c.detectRestartChange(result, "RateLimitWrites", c.RateLimitWrites, newCfg.RateLimitWrites)
+	c.detectRestartChange(result, "CompressionMinBytes", c.CompressionMinBytes, newCfg.CompressionMinBytes)
+	c.detectRestartChange(result, "WSMaxClients", c.WSMaxClients, newCfg.WSMaxClients)
+	c.detectRestartChange(result, "WSBroadcastThrottle", c.WSBroadcastThrottle, newCfg.WSBroadcastThrottle)
+	c.detectRestartChange(result, "NotificationDedupWindow", c.NotificationDedupWindow, newCfg.NotificationDedupWindow)
+	c.detectRestartChange(result, "NotificationRateLimit", c.NotificationRateLimit, newCfg.NotificationRateLimit)
+	c.detectRestartChange(result, "NotificationQuietStart", c.NotificationQuietStart, newCfg.NotificationQuietStart)
+	c.detectRestartChange(result, "NotificationQuietEnd", c.NotificationQuietEnd, newCfg.NotificationQuietEnd)
+	c.detectRestartChange(result, "NotificationQuietTZ", c.NotificationQuietTZ, newCfg.NotificationQuietTZ)
+	c.detectRestartChange(result, "OrderFillNotify", c.OrderFillNotify, newCfg.OrderFillNotify)
+	c.detectRestartChange(result, "TradingSymbols", c.TradingSymbols, newCfg.TradingSymbols)
+	c.detectRestartChange(result, "SymbolAliases", c.SymbolAliases, newCfg.SymbolAliases)
+	c.detectRestartChange(result, "ProviderSymbols", c.ProviderSymbols, newCfg.ProviderSymbols)
 	if !stringSlicesEqual(c.EnabledStrategies, newCfg.EnabledStrategies) {
 		result.Changes = append(result.Changes, ReloadChange{
 			Field:    "EnabledStrategies",
@@ -504,6 +1270,20 @@ func stringSlicesEqual(a, b []string) bool {
 	return true
 }
 
+// healthCanarySymbol reads HEALTH_CANARY_SYMBOL. When unset it defaults to
+// the first traded symbol, which the provider is known to serve, rather than a
+// fixed equity that crypto-only providers reject. Set but empty disables the
+// probe.
+func healthCanarySymbol(tradingSymbols []string) string {
+	if value, ok := os.LookupEnv("HEALTH_CANARY_SYMBOL"); ok {
+		return strings.TrimSpace(value)
+	}
+	if len(tradingSymbols) > 0 {
+		return tradingSymbols[0]
+	}
+	return ""
+}
+
 // getEnv retrieves an environment variable or returns a default value.
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -522,6 +1302,16 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvFloat retrieves an environment variable as a float64 or returns a default.
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatVal
+		}
+	}
+	return defaultValue
+}
+
 // getEnvDuration retrieves an environment variable as a time.Duration or returns a default.
 // The value should be a Go duration string (e.g., "30s", "5m", "1h").
 func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
@@ -533,6 +1323,20 @@ func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+// parseSymbols parses a comma-separated list of symbols into canonical form
+// (see data.CanonicalSymbol), falling back to the default universe when the
+// list is empty.
+func parseSymbols(symbolsStr string) []string {
+	symbols := parseStrategies(symbolsStr)
+	if len(symbols) == 0 {
+		symbols = parseStrategies(defaultTradingSymbols)
+	}
+	for i, symbol := range symbols {
+		symbols[i] = data.CanonicalSymbol(symbol)
+	}
+	return symbols
+}
+
 // parseStrategies parses a comma-separated list of strategy names.
 func parseStrategies(strategiesStr string) []string {
 	if strategiesStr == "" {
@@ -588,48 +1392,88 @@ func GenerateAPIKey() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
+// KeyRotationCooldown is the minimum time between API key rotations, so two
+// rapid requests cannot race writing the .env file.
+const KeyRotationCooldown = 10 * time.Second
+
+// ErrKeyRotationTooSoon is returned when the API key was rotated less than
+// KeyRotationCooldown ago.
+var ErrKeyRotationTooSoon = errors.New("API key was rotated too recently")
+
+// CurrentAPIKey returns the API key, synchronized with RotateAPIKey. Code
+// that can run while the server is up reads the key through it rather than
+// the APIKey field.
+//
+// Returns:
+//   - string: The API key (empty if none is set)
+func (c *Config) CurrentAPIKey() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.APIKey
+}
+
 // RotateAPIKey generates a new API key, updates the config, and saves it to the .env file.
-func (c *Config) RotateAPIKey() (string, error) {
+//
+// Returns:
+//   - string: The new API key
+//   - string: The key it replaced (empty if none was set)
+//   - error: ErrKeyRotationTooSoon within the cooldown, or a write failure
+func (c *Config) RotateAPIKey() (string, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.lastKeyRotation.IsZero() && time.Since(c.lastKeyRotation) < KeyRotationCooldown {
+		return "", "", ErrKeyRotationTooSoon
+	}
+
 	newKey, err := GenerateAPIKey()
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	c.APIKey = newKey
-
 	// Update .env file
 	envFile := c.EnvFile
 	if envFile == "" {
 		envFile = ".env"
 	}
 
+	if err := writeEnvAPIKey(envFile, newKey); err != nil {
+		return "", "", err
+	}
+
+	oldKey := c.APIKey
+	c.APIKey = newKey
+	c.lastKeyRotation = time.Now()
+	return newKey, oldKey, nil
+}
+
+// writeEnvAPIKey sets API_KEY in an .env file, creating the file if needed.
+func writeEnvAPIKey(envFile, key string) error {
 	content, err := os.ReadFile(envFile)
 	if err != nil {
 		// If .env doesn't exist, create it
 		if os.IsNotExist(err) {
-			return newKey, os.WriteFile(envFile, []byte("API_KEY="+newKey+"\n"), 0644)
+			return os.WriteFile(envFile, []byte("API_KEY="+key+"\n"), 0644)
 		}
-		return "", err
+		return err
 	}
 
 	lines := strings.Split(string(content), "\n")
 	found := false
 	for i, line := range lines {
 		if strings.HasPrefix(line, "API_KEY=") {
-			lines[i] = "API_KEY=" + newKey
+			lines[i] = "API_KEY=" + key
 			found = true
 			break
 		}
 	}
 
 	if !found {
-		lines = append(lines, "API_KEY="+newKey)
+		lines = append(lines, "API_KEY="+key)
 	}
 
-	err = os.WriteFile(envFile, []byte(strings.Join(lines, "\n")), 0644)
-	if err != nil {
-		return "", fmt.Errorf("failed to write .env file: %w", err)
+	if err := os.WriteFile(envFile, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return fmt.Errorf("failed to write .env file: %w", err)
 	}
-
-	return newKey, nil
+	return nil
 }
@@ -3,7 +3,9 @@ package config
 import (
 	"errors"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -127,6 +129,106 @@ func TestConfigLoad_EnabledStrategies(t *testing.T) {
 	}
 }
 
+// TestConfigLoad_TradingSymbols tests TRADING_SYMBOLS parsing, canonical
+// form and its default.
+func TestConfigLoad_TradingSymbols(t *testing.T) {
+	t.Setenv("TRADING_SYMBOLS", "")
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SPY", "BTC-USD", "ETH-USD", "AAPL", "MSFT"}, cfg.TradingSymbols)
+
+	t.Setenv("TRADING_SYMBOLS", " QQQ , sol-usd ,, eth/usdt,ratio:ETH-USD/BTC-USD")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"QQQ", "SOL-USD", "ETH-USDT", "ratio:ETH-USD/BTC-USD"}, cfg.TradingSymbols)
+}
+
+// TestConfigLoad_YahooAdjusted tests adjusted Yahoo prices are opt-in.
+func TestConfigLoad_YahooAdjusted(t *testing.T) {
+	t.Setenv("YAHOO_ADJUSTED", "")
+	require.NoError(t, os.Unsetenv("YAHOO_ADJUSTED"))
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.YahooAdjusted)
+
+	t.Setenv("YAHOO_ADJUSTED", "true")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.YahooAdjusted)
+}
+
+// TestConfigLoad_HealthCanarySymbol tests the canary defaults to the first
+// traded symbol, so crypto-only providers aren't probed with an equity.
+func TestConfigLoad_HealthCanarySymbol(t *testing.T) {
+	t.Setenv("HEALTH_CANARY_SYMBOL", "")
+	require.NoError(t, os.Unsetenv("HEALTH_CANARY_SYMBOL"))
+	t.Setenv("TRADING_SYMBOLS", "BTC-USD,ETH-USD")
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "BTC-USD", cfg.HealthCanarySymbol)
+
+	t.Setenv("HEALTH_CANARY_SYMBOL", "QQQ")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "QQQ", cfg.HealthCanarySymbol)
+
+	t.Setenv("HEALTH_CANARY_SYMBOL", "")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.HealthCanarySymbol, "set but empty disables the probe")
+}
+
+// TestValidate_TradingSymbols tests that implausible and duplicate symbols are caught.
+func TestValidate_TradingSymbols(t *testing.T) {
+	cfg := &Config{
+		TradingMode:       ModeDryRun,
+		ServerPort:        8099,
+		DatabasePath:      "./data/sherwood.db",
+		LogLevel:          "info",
+		DataProvider:      "yahoo",
+		EnabledStrategies: []string{"ma_crossover"},
+		TradingSymbols:    []string{"AAPL", "ratio:ETH-USD/BTC-USD"},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.TradingSymbols = []string{"AAPL", "THIS-SYMBOL-IS-FAR-TOO-LONG-TO-BE-REAL", "BRK B", "btc/usd", "BTC-USD"}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at most 32 characters")
+	assert.Contains(t, err.Error(), "'BRK B': must not contain whitespace")
+	assert.Contains(t, err.Error(), "BTC-USD is listed more than once")
+}
+
+// TestConfig_Warnings tests that symbols the provider can't serve are flagged.
+func TestConfig_Warnings(t *testing.T) {
+	cfg := &Config{DataProvider: "yahoo", TradingSymbols: []string{"SPY", "BTC-USD"}}
+	assert.Empty(t, cfg.Warnings())
+
+	cfg.DataProvider = "tiingo"
+	cfg.TradingSymbols = []string{"SPY", "BTC-USD", "ratio:SPY/ETH-USD"}
+	warnings := cfg.Warnings()
+	require.Len(t, warnings, 2)
+	assert.Contains(t, warnings[0], "crypto pair BTC-USD")
+	assert.Contains(t, warnings[1], "crypto pair ETH-USD")
+
+	cfg.DataProvider = "binance"
+	cfg.TradingSymbols = []string{"SPY", "BTC-USD"}
+	warnings = cfg.Warnings()
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "equity SPY")
+
+	cfg.DataProvider = "coingecko"
+	warnings = cfg.Warnings()
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "coingecko provider serves crypto only")
+
+	cfg.TradingSymbols = []string{"BTC-USD", "ETH-USD", "SOL-USD", "ADA-USD", "DOT-USD",
+		"LTC-USD", "XRP-USD", "BCH-USD", "LINK-USD", "ratio:ETH-USD/BTC-USD"}
+	warnings = cfg.Warnings()
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "needs 11 fetches a tick")
+}
+
 // TestConfigLoad_Full tests loading with all standard env vars set.
 func TestConfigLoad_Full(t *testing.T) {
 	t.Setenv("PORT", "9090")
@@ -150,6 +252,26 @@ func TestConfigLoad_Full(t *testing.T) {
 	assert.Equal(t, "secret-key", cfg.APIKey)
 }
 
+// TestConfigLoad_InitialCapital verifies INITIAL_CAPITAL sets the starting
+// cash and the older PAPER_INITIAL_CASH is no longer read.
+func TestConfigLoad_InitialCapital(t *testing.T) {
+	t.Setenv("PAPER_INITIAL_CASH", "25000")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 100000.0, cfg.InitialCapital)
+
+	t.Setenv("INITIAL_CAPITAL", "50000")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 50000.0, cfg.InitialCapital)
+
+	t.Setenv("INITIAL_CAPITAL", "-1")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INITIAL_CAPITAL")
+}
+
 // TestRotateAPIKey tests rotating the API key in the .env file.
 func TestRotateAPIKey(t *testing.T) {
 	// Create temp .env file
@@ -169,10 +291,11 @@ func TestRotateAPIKey(t *testing.T) {
 	}
 
 	// Rotate key
-	newKey, err := cfg.RotateAPIKey()
+	newKey, oldKey, err := cfg.RotateAPIKey()
 	require.NoError(t, err)
 	assert.NotEmpty(t, newKey)
 	assert.NotEqual(t, "old-key", newKey)
+	assert.Equal(t, "old-key", oldKey)
 	assert.Equal(t, newKey, cfg.APIKey)
 
 	// Verify file content
@@ -181,6 +304,11 @@ func TestRotateAPIKey(t *testing.T) {
 	contentStr := string(content)
 	assert.Contains(t, contentStr, "API_KEY="+newKey)
 	assert.Contains(t, contentStr, "PORT=8080")
+
+	// A second rotation inside the cooldown is rejected and changes nothing
+	_, _, err = cfg.RotateAPIKey()
+	assert.ErrorIs(t, err, ErrKeyRotationTooSoon)
+	assert.Equal(t, newKey, cfg.APIKey)
 }
 
 // --- Enhanced Validation Tests ---
@@ -245,6 +373,195 @@ func TestValidate_InvalidPort(t *testing.T) {
 	assert.Contains(t, err.Error(), "PORT")
 }
 
+// TestValidate_InvalidWarmupTicks tests that a negative warm-up count is caught.
+func TestValidate_InvalidWarmupTicks(t *testing.T) {
+	cfg := &Config{
+		TradingMode:       ModeDryRun,
+		ServerPort:        8099,
+		DatabasePath:      "./data/sherwood.db",
+		LogLevel:          "info",
+		DataProvider:      "yahoo",
+		EnabledStrategies: []string{"ma_crossover"},
+		EngineWarmupTicks: -1,
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ENGINE_WARMUP_TICKS")
+}
+
+// TestValidate_InvalidEngineConcurrency tests that a negative symbol concurrency is caught.
+func TestValidate_InvalidEngineConcurrency(t *testing.T) {
+	cfg := &Config{
+		TradingMode:       ModeDryRun,
+		ServerPort:        8099,
+		DatabasePath:      "./data/sherwood.db",
+		LogLevel:          "info",
+		DataProvider:      "yahoo",
+		EnabledStrategies: []string{"ma_crossover"},
+		EngineConcurrency: -1,
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ENGINE_SYMBOL_CONCURRENCY")
+}
+
+// TestValidate_InvalidPrimeTimeout tests that a negative priming timeout is caught.
+func TestValidate_InvalidPrimeTimeout(t *testing.T) {
+	cfg := &Config{
+		TradingMode:        ModeDryRun,
+		ServerPort:         8099,
+		DatabasePath:       "./data/sherwood.db",
+		LogLevel:           "info",
+		DataProvider:       "yahoo",
+		EnabledStrategies:  []string{"ma_crossover"},
+		EnginePrimeTimeout: -1,
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ENGINE_PRIME_TIMEOUT")
+}
+
+// TestValidate_InvalidRiskLimits tests that negative risk limits are caught.
+func TestValidate_InvalidRiskLimits(t *testing.T) {
+	cfg := &Config{
+		TradingMode:       ModeDryRun,
+		ServerPort:        8099,
+		DatabasePath:      "./data/sherwood.db",
+		LogLevel:          "info",
+		DataProvider:      "yahoo",
+		EnabledStrategies: []string{"ma_crossover"},
+		MaxDailyTrades:    -1,
+		MaxDailyLoss:      -500,
+		MinCashReserve:    -100,
+		MinCashReservePct: 5,
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MAX_DAILY_TRADES")
+	assert.Contains(t, err.Error(), "MAX_DAILY_LOSS")
+	assert.Contains(t, err.Error(), "MIN_CASH_RESERVE ")
+	assert.Contains(t, err.Error(), "MIN_CASH_RESERVE_PCT")
+
+	cfg.MaxDailyTrades = 5
+	cfg.MaxDailyLoss = 500
+	cfg.MinCashReserve = 1000
+	cfg.MinCashReservePct = 0.05
+	assert.NoError(t, cfg.Validate())
+}
+
+// TestValidate_InvalidOrderSizing tests that negative order sizing settings are caught.
+func TestValidate_InvalidOrderSizing(t *testing.T) {
+	cfg := &Config{
+		TradingMode:        ModeDryRun,
+		ServerPort:         8099,
+		DatabasePath:       "./data/sherwood.db",
+		LogLevel:           "info",
+		DataProvider:       "yahoo",
+		EnabledStrategies:  []string{"ma_crossover"},
+		OrderMinNotional:   -1,
+		OrderCryptoLotSize: -0.01,
+		PaperDepthImpact:   -0.5,
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ORDER_MIN_NOTIONAL")
+	assert.Contains(t, err.Error(), "ORDER_CRYPTO_LOT_SIZE")
+	assert.Contains(t, err.Error(), "PAPER_DEPTH_IMPACT")
+	assert.NotContains(t, err.Error(), "PAPER_SPREAD_BPS")
+	assert.NotContains(t, err.Error(), "ORDER_EQUITY_LOT_SIZE")
+}
+
+// TestValidate_InvalidOrderConfirmation tests that a confirmation threshold
+// without a window is caught.
+func TestValidate_InvalidOrderConfirmation(t *testing.T) {
+	cfg := &Config{
+		TradingMode:           ModeDryRun,
+		ServerPort:            8099,
+		DatabasePath:          "./data/sherwood.db",
+		LogLevel:              "info",
+		DataProvider:          "yahoo",
+		EnabledStrategies:     []string{"ma_crossover"},
+		OrderConfirmThreshold: 50000,
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ORDER_CONFIRM_WINDOW")
+
+	cfg.OrderConfirmWindow = 60 * 1000000000 // 1m in nanoseconds
+	assert.NoError(t, cfg.Validate())
+
+	cfg.OrderConfirmThreshold = -1
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ORDER_CONFIRM_THRESHOLD")
+}
+
+// TestValidate_InvalidBaseCurrency tests that a BASE_CURRENCY without known
+// exchange rates is caught.
+func TestValidate_InvalidBaseCurrency(t *testing.T) {
+	for _, code := range []string{"US", "usd", "EURO12", "US$", "EUR"} {
+		cfg := &Config{
+			TradingMode:       ModeDryRun,
+			ServerPort:        8099,
+			DatabasePath:      "./data/sherwood.db",
+			LogLevel:          "info",
+			DataProvider:      "yahoo",
+			EnabledStrategies: []string{"ma_crossover"},
+			BaseCurrency:      code,
+		}
+		err := cfg.Validate()
+		require.Error(t, err, code)
+		assert.Contains(t, err.Error(), "BASE_CURRENCY")
+	}
+
+	cfg := &Config{
+		TradingMode:       ModeDryRun,
+		ServerPort:        8099,
+		DatabasePath:      "./data/sherwood.db",
+		LogLevel:          "info",
+		DataProvider:      "yahoo",
+		EnabledStrategies: []string{"ma_crossover"},
+		BaseCurrency:      "USDT",
+	}
+	assert.NoError(t, cfg.Validate())
+}
+
+// TestValidate_InvalidGapPolicy tests that an unknown DATA_GAP_POLICY is caught.
+func TestValidate_InvalidGapPolicy(t *testing.T) {
+	cfg := &Config{
+		TradingMode:       ModeDryRun,
+		ServerPort:        8099,
+		DatabasePath:      "./data/sherwood.db",
+		LogLevel:          "info",
+		DataProvider:      "yahoo",
+		EnabledStrategies: []string{"ma_crossover"},
+		DataGapPolicy:     "interpolate",
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DATA_GAP_POLICY")
+}
+
+// TestValidate_InvalidCandleTimezone tests that an unknown timezone is caught.
+func TestValidate_InvalidCandleTimezone(t *testing.T) {
+	cfg := &Config{
+		TradingMode:       ModeDryRun,
+		ServerPort:        8099,
+		DatabasePath:      "./data/sherwood.db",
+		LogLevel:          "info",
+		DataProvider:      "yahoo",
+		EnabledStrategies: []string{"ma_crossover"},
+		DataGapPolicy:     "log",
+		CandleTimezone:    "America/Gotham",
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "CANDLE_TIMEZONE")
+
+	cfg.CandleTimezone = "UTC"
+	assert.NoError(t, cfg.Validate())
+}
+
 // TestValidate_InvalidLogLevel tests that an invalid log level is caught.
 func TestValidate_InvalidLogLevel(t *testing.T) {
 	cfg := &Config{
@@ -364,6 +681,43 @@ func TestValidate_BinanceWithCredentials(t *testing.T) {
 	require.NoError(t, cfg.Validate())
 }
 
+// TestValidate_CoinGeckoNoCredentials tests CoinGecko needs no API keys.
+func TestValidate_CoinGeckoNoCredentials(t *testing.T) {
+	cfg := &Config{
+		TradingMode:       ModeDryRun,
+		ServerPort:        8099,
+		DatabasePath:      "./data/sherwood.db",
+		LogLevel:          "info",
+		DataProvider:      "coingecko",
+		EnabledStrategies: []string{"ma_crossover"},
+	}
+	require.NoError(t, cfg.Validate())
+}
+
+// TestValidate_ReplayDataDir tests that the replay provider needs an existing
+// data directory.
+func TestValidate_ReplayDataDir(t *testing.T) {
+	cfg := &Config{
+		TradingMode:       ModeDryRun,
+		ServerPort:        8099,
+		DatabasePath:      "./data/sherwood.db",
+		LogLevel:          "info",
+		DataProvider:      "replay",
+		EnabledStrategies: []string{"ma_crossover"},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "REPLAY_DATA_DIR")
+
+	cfg.ReplayDataDir = filepath.Join(t.TempDir(), "missing")
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a directory")
+
+	cfg.ReplayDataDir = t.TempDir()
+	require.NoError(t, cfg.Validate())
+}
+
 // TestValidate_InvalidStrategy tests that unknown strategy names are caught.
 func TestValidate_InvalidStrategy(t *testing.T) {
 	cfg := &Config{
@@ -390,7 +744,7 @@ func TestValidate_AllValidStrategies(t *testing.T) {
 		DataProvider: "yahoo",
 		EnabledStrategies: []string{
 			"ma_crossover", "rsi_momentum", "bb_mean_reversion",
-			"macd_trend_follower", "nyc_close_open",
+			"macd_trend_follower", "nyc_close_open", "webhook_signals",
 		},
 	}
 	require.NoError(t, cfg.Validate())
@@ -453,6 +807,24 @@ func TestValidate_EmptyDatabasePath(t *testing.T) {
 	assert.Contains(t, err.Error(), "DATABASE_PATH")
 }
 
+// TestValidate_InvalidDBSettings tests that negative SQLite tuning is caught.
+func TestValidate_InvalidDBSettings(t *testing.T) {
+	cfg := &Config{
+		TradingMode:       ModeDryRun,
+		ServerPort:        8099,
+		DatabasePath:      "./data/sherwood.db",
+		DBBusyTimeout:     -1,
+		DBMaxOpenConns:    -1,
+		LogLevel:          "info",
+		DataProvider:      "yahoo",
+		EnabledStrategies: []string{"ma_crossover"},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DB_BUSY_TIMEOUT")
+	assert.Contains(t, err.Error(), "DB_MAX_OPEN_CONNS")
+}
+
 // TestValidate_MultipleErrors tests that all errors are aggregated.
 func TestValidate_MultipleErrors(t *testing.T) {
 	cfg := &Config{
@@ -529,17 +901,55 @@ func TestValidate_EmptyStrategiesOK(t *testing.T) {
 // newTestConfig returns a valid Config struct suitable for reload tests.
 func newTestConfig() *Config {
 	return &Config{
-		ServerPort:        8099,
-		ServerHost:        "0.0.0.0",
-		TradingMode:       ModeDryRun,
-		DatabasePath:      "./data/sherwood.db",
-		LogLevel:          "info",
-		DataProvider:      "yahoo",
-		EnabledStrategies: []string{"ma_crossover"},
-		CloseOnShutdown:   false,
-		ShutdownTimeout:   30 * 1000000000, // 30s in nanoseconds
-		AllowedOrigins:    []string{"http://localhost:3000", "http://localhost:8080"},
-		EnvFile:           ".env.nonexistent_for_test", // prevent reading real .env
+		ServerPort:                8099,
+		ServerHost:                "0.0.0.0",
+		TradingMode:               ModeDryRun,
+		DatabasePath:              "./data/sherwood.db",
+		DBBusyTimeout:             5 * 1000000000,
+		DBMaxOpenConns:            4,
+		LogLevel:                  "info",
+		DataProvider:              "yahoo",
+		BacktestWorkers:           2,
+		MaxSweepCombinations:      100,
+		OrderRetryAttempts:        3,
+		InitialCapital:            100000,
+		TradingCalendar:           "us_equity",
+		OrderRetryDelay:           500 * 1000000, // 500ms in nanoseconds
+		OrderEquityLotSize:        1,
+		MaxHistoryCandles:         5000,
+		TickerCacheTTL:            24 * 3600 * 1000000000,
+		EnginePrimeTimeout:        30 * 1000000000,
+		OrderConfirmWindow:        2 * 60 * 1000000000,
+		EngineFetchFailures:       5,
+		OrderMaxNotional:          1000000,
+		EngineHeartbeat:           true,
+		StaleCryptoIntervals:      3,
+		StaleEquityIntervals:      5,
+		BrokerCheckInterval:       30 * 1000000000,
+		BrokerReconnectBackoff:    1000000000,
+		BrokerReconnectMaxBackoff: 60 * 1000000000,
+		RateLimitReads:            300,
+		RateLimitBacktests:        10,
+		RateLimitOrders:           30,
+		RateLimitWrites:           60,
+		CompressionMinBytes:       1024,
+		EquitySnapshotInterval:    5 * 60 * 1000000000,
+		DataGapPolicy:             "log",
+		CandleTimezone:            "America/New_York",
+		ProviderTimeout:           30 * 1000000000,
+		BaseCurrency:              "USD",
+		WSMaxClients:              100,
+		WSBroadcastThrottle:       "market_data:1s",
+		NotificationDedupWindow:   60 * 1000000000,
+		NotificationRateLimit:     20,
+		NotificationQuietTZ:       "UTC",
+		OrderFillNotify:           true,
+		EnabledStrategies:         []string{"ma_crossover"},
+		TradingSymbols:            []string{"SPY", "BTC-USD", "ETH-USD", "AAPL", "MSFT"},
+		CloseOnShutdown:           false,
+		ShutdownTimeout:           30 * 1000000000, // 30s in nanoseconds
+		AllowedOrigins:            []string{"http://localhost:3000", "http://localhost:8080"},
+		EnvFile:                   ".env.nonexistent_for_test", // prevent reading real .env
 	}
 }
 
@@ -738,3 +1148,193 @@ func TestStringSlicesEqual(t *testing.T) {
 		})
 	}
 }
+
+// TestValidate_InvalidStaleIntervals tests that negative staleness thresholds are caught.
+func TestValidate_InvalidStaleIntervals(t *testing.T) {
+	cfg := &Config{
+		TradingMode:          ModeDryRun,
+		ServerPort:           8099,
+		DatabasePath:         "./data/sherwood.db",
+		LogLevel:             "info",
+		DataProvider:         "yahoo",
+		EnabledStrategies:    []string{"ma_crossover"},
+		StaleCryptoIntervals: -1,
+		StaleEquityIntervals: -1,
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "STALE_DATA_CRYPTO_INTERVALS")
+	assert.Contains(t, err.Error(), "STALE_DATA_EQUITY_INTERVALS")
+}
+
+// TestValidate_InvalidQuietHours tests that malformed quiet hours are caught.
+func TestValidate_InvalidQuietHours(t *testing.T) {
+	cfg := &Config{
+		TradingMode:            ModeDryRun,
+		ServerPort:             8099,
+		DatabasePath:           "./data/sherwood.db",
+		LogLevel:               "info",
+		DataProvider:           "yahoo",
+		EnabledStrategies:      []string{"ma_crossover"},
+		NotificationQuietStart: "22:00",
+		NotificationQuietEnd:   "7am",
+		NotificationQuietTZ:    "UTC",
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "NOTIFICATION_QUIET_START")
+
+	cfg.NotificationQuietEnd = "07:00"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.NotificationQuietTZ = "Mars/Olympus"
+	assert.Error(t, cfg.Validate())
+}
+
+// TestValidate_SymbolAliases tests that colliding aliases are caught and
+// that an alias counts as its symbol when checking TRADING_SYMBOLS.
+func TestValidate_SymbolAliases(t *testing.T) {
+	cfg := &Config{
+		TradingMode:       ModeDryRun,
+		ServerPort:        8099,
+		DatabasePath:      "./data/sherwood.db",
+		LogLevel:          "info",
+		DataProvider:      "yahoo",
+		EnabledStrategies: []string{"ma_crossover"},
+		TradingSymbols:    []string{"BTCUSDT", "SPY"},
+		SymbolAliases:     "BTCUSDT:BTC-USD,btcusdt:ETH-USD",
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SYMBOL_ALIASES")
+	assert.Nil(t, cfg.Aliases())
+
+	cfg.SymbolAliases = "BTCUSDT:BTC-USD"
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, []string{"BTC-USD", "SPY"}, cfg.Aliases().ResolveAll(cfg.TradingSymbols))
+	assert.Empty(t, cfg.Warnings())
+	assert.Same(t, cfg.Aliases(), cfg.Aliases(), "parsed once by Validate")
+
+	// Overrides may name a symbol by its alias
+	cfg.SymbolIntervals = "btcusdt:1h,BTC-USD:4h"
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SYMBOL_INTERVALS")
+	cfg.SymbolIntervals = ""
+
+	cfg.TradingSymbols = []string{"BTCUSDT", "BTC-USD"}
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "BTC-USD is listed more than once")
+}
+
+// TestValidate_InvalidAutoExit tests that negative auto-exit levels and
+// malformed overrides are caught.
+func TestValidate_InvalidAutoExit(t *testing.T) {
+	cfg := &Config{
+		TradingMode:         ModeDryRun,
+		ServerPort:          8099,
+		DatabasePath:        "./data/sherwood.db",
+		LogLevel:            "info",
+		DataProvider:        "yahoo",
+		EnabledStrategies:   []string{"ma_crossover"},
+		AutoExitStopLossPct: -5,
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AUTO_EXIT_STOP_LOSS_PCT")
+
+	cfg.AutoExitStopLossPct = 5
+	cfg.AutoExitOverrides = "BTC-USD:15"
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AUTO_EXIT_OVERRIDES")
+
+	cfg.AutoExitOverrides = "BTC-USD:15:8"
+	assert.NoError(t, cfg.Validate())
+}
+
+// TestValidate_InvalidSymbolIntervals tests that malformed per-symbol
+// intervals are caught.
+func TestValidate_InvalidSymbolIntervals(t *testing.T) {
+	cfg := &Config{
+		TradingMode:       ModeDryRun,
+		ServerPort:        8099,
+		DatabasePath:      "./data/sherwood.db",
+		LogLevel:          "info",
+		DataProvider:      "yahoo",
+		EnabledStrategies: []string{"ma_crossover"},
+		SymbolIntervals:   "BTC-USD:7m",
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SYMBOL_INTERVALS")
+
+	cfg.SymbolIntervals = "BTC-USD:1h,ETH-USD:4h"
+	assert.NoError(t, cfg.Validate())
+}
+
+// TestValidate_InvalidBroadcastThrottle tests that malformed WebSocket
+// broadcast throttles are caught.
+func TestValidate_InvalidBroadcastThrottle(t *testing.T) {
+	cfg := &Config{
+		TradingMode:         ModeDryRun,
+		ServerPort:          8099,
+		DatabasePath:        "./data/sherwood.db",
+		LogLevel:            "info",
+		DataProvider:        "yahoo",
+		EnabledStrategies:   []string{"ma_crossover"},
+		WSBroadcastThrottle: "market_data:soon",
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "WS_BROADCAST_THROTTLE")
+
+	cfg.WSBroadcastThrottle = "market_data:500ms,heartbeat:0s"
+	assert.NoError(t, cfg.Validate())
+}
+
+// TestValidate_InvalidAllowedOrigins tests that malformed origin patterns are caught.
+func TestValidate_InvalidAllowedOrigins(t *testing.T) {
+	cfg := &Config{
+		TradingMode:       ModeDryRun,
+		ServerPort:        8099,
+		DatabasePath:      "./data/sherwood.db",
+		LogLevel:          "info",
+		DataProvider:      "yahoo",
+		EnabledStrategies: []string{"ma_crossover"},
+		AllowedOrigins: []string{
+			"https://app.example.com",
+			"https://*.example.com",
+			"*",
+			"https://app.*.example.com",
+			"regex:https://(",
+		},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "'https://*.example.com'")
+	assert.Contains(t, err.Error(), "ALLOWED_ORIGINS entry '*'")
+	assert.Contains(t, err.Error(), "ALLOWED_ORIGINS entry 'https://app.*.example.com'")
+	assert.Contains(t, err.Error(), "ALLOWED_ORIGINS entry 'regex:https://('")
+}
+
+// TestParseBroadcastThrottles tests the TYPE:DURATION list format.
+func TestParseBroadcastThrottles(t *testing.T) {
+	throttles, err := ParseBroadcastThrottles(" market_data:1s, heartbeat:250ms ,,provider_status:0s")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{
+		"market_data":     time.Second,
+		"heartbeat":       250 * time.Millisecond,
+		"provider_status": 0,
+	}, throttles)
+
+	throttles, err = ParseBroadcastThrottles("")
+	require.NoError(t, err)
+	assert.Empty(t, throttles)
+
+	for _, invalid := range []string{"market_data", ":1s", "market_data:fast", "market_data:-1s", "market_data:1s,market_data:2s"} {
+		_, err := ParseBroadcastThrottles(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
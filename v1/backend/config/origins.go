@@ -0,0 +1,96 @@
+// Package config provides allowed-origin matching for CORS.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// originRegexPrefix marks an ALLOWED_ORIGINS entry as a regular expression.
+const originRegexPrefix = "regex:"
+
+// OriginMatcher decides whether a request Origin is allowed. Entries are
+// exact origins ("https://app.example.com"), wildcard subdomains
+// ("https://*.example.com", where * stands for one or more subdomain labels)
+// or regular expressions prefixed with "regex:" ("regex:https://pr-\d+\.vercel\.app").
+// Patterns always match the whole origin.
+type OriginMatcher struct {
+	exact    map[string]bool
+	patterns []*regexp.Regexp
+}
+
+// NewOriginMatcher parses allowed origins. Invalid entries are reported in
+// the error and left out of the returned matcher, which is never nil.
+//
+// Args:
+//   - origins: ALLOWED_ORIGINS entries
+//
+// Returns:
+//   - *OriginMatcher: Matcher for the valid entries
+//   - error: Every invalid entry, or nil
+func NewOriginMatcher(origins []string) (*OriginMatcher, error) {
+	m := &OriginMatcher{exact: make(map[string]bool)}
+	var errs []error
+	for _, origin := range origins {
+		switch {
+		case strings.HasPrefix(origin, originRegexPrefix):
+			expr := strings.TrimPrefix(origin, originRegexPrefix)
+			re, err := regexp.Compile(`^(?:` + expr + `)$`)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid ALLOWED_ORIGINS entry '%s': %w", origin, err))
+				continue
+			}
+			m.patterns = append(m.patterns, re)
+		case strings.Contains(origin, "*"):
+			re, err := wildcardOrigin(origin)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid ALLOWED_ORIGINS entry '%s': %w", origin, err))
+				continue
+			}
+			m.patterns = append(m.patterns, re)
+		default:
+			m.exact[origin] = true
+		}
+	}
+	return m, errors.Join(errs...)
+}
+
+// wildcardOrigin compiles "scheme://*.domain[:port]" into a regular
+// expression. The wildcard must be the leading host label, so it can only
+// ever match subdomains of the given domain.
+func wildcardOrigin(origin string) (*regexp.Regexp, error) {
+	scheme, host, ok := strings.Cut(origin, "://")
+	if !ok || scheme == "" || strings.Contains(scheme, "*") {
+		return nil, errors.New("wildcard origins must look like https://*.example.com")
+	}
+	domain, ok := strings.CutPrefix(host, "*.")
+	if !ok || domain == "" || strings.Contains(domain, "*") {
+		return nil, errors.New("* must be the first label of the host, e.g. https://*.example.com")
+	}
+	return regexp.Compile(`^` + regexp.QuoteMeta(scheme+"://") +
+		`[a-zA-Z0-9-]+(?:\.[a-zA-Z0-9-]+)*\.` + regexp.QuoteMeta(domain) + `$`)
+}
+
+// Allows reports whether origin matches an allowed entry.
+//
+// Args:
+//   - origin: The request's Origin header
+//
+// Returns:
+//   - bool: true if the origin may make cross-origin requests
+func (m *OriginMatcher) Allows(origin string) bool {
+	if origin == "" {
+		return false
+	}
+	if m.exact[origin] {
+		return true
+	}
+	for _, re := range m.patterns {
+		if re.MatchString(origin) {
+			return true
+		}
+	}
+	return false
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/alexherrero/sherwood/backend/models"
@@ -22,10 +23,17 @@ type Cache interface {
 	Delete(ctx context.Context, key string) error
 }
 
-// MemoryCache is a simple in-memory cache implementation.
-// For production, use Redis via the RedisCache implementation.
+// DefaultMemoryCacheEntries is the most entries NewMemoryCache holds.
+const DefaultMemoryCacheEntries = 1000
+
+// MemoryCache is a simple in-memory cache implementation, safe for
+// concurrent use. It holds at most maxEntries entries: when full, expired
+// entries are swept and, if none have expired, the entry closest to expiry
+// is evicted. For production, use Redis via the RedisCache implementation.
 type MemoryCache struct {
-	data map[string]cacheEntry
+	data       map[string]cacheEntry
+	maxEntries int
+	mu         sync.Mutex
 }
 
 type cacheEntry struct {
@@ -33,13 +41,26 @@ type cacheEntry struct {
 	expiresAt time.Time
 }
 
-// NewMemoryCache creates a new in-memory cache.
+// NewMemoryCache creates a new in-memory cache holding at most
+// DefaultMemoryCacheEntries entries.
 //
 // Returns:
 //   - *MemoryCache: The cache instance
 func NewMemoryCache() *MemoryCache {
+	return NewMemoryCacheWithLimit(DefaultMemoryCacheEntries)
+}
+
+// NewMemoryCacheWithLimit creates a new in-memory cache with an entry limit.
+//
+// Args:
+//   - maxEntries: Most entries held at once (0 or less is unbounded)
+//
+// Returns:
+//   - *MemoryCache: The cache instance
+func NewMemoryCacheWithLimit(maxEntries int) *MemoryCache {
 	return &MemoryCache{
-		data: make(map[string]cacheEntry),
+		data:       make(map[string]cacheEntry),
+		maxEntries: maxEntries,
 	}
 }
 
@@ -53,6 +74,9 @@ func NewMemoryCache() *MemoryCache {
 //   - []byte: Cached value
 //   - error: Error if key not found or expired
 func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	entry, exists := c.data[key]
 	if !exists {
 		return nil, fmt.Errorf("key not found: %s", key)
@@ -77,13 +101,41 @@ func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, error) {
 // Returns:
 //   - error: Any error encountered
 func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if _, exists := c.data[key]; !exists && c.maxEntries > 0 && len(c.data) >= c.maxEntries {
+		c.evict(now)
+	}
 	c.data[key] = cacheEntry{
 		value:     value,
-		expiresAt: time.Now().Add(expiration),
+		expiresAt: now.Add(expiration),
 	}
 	return nil
 }
 
+// evict makes room for one entry: it sweeps expired entries and, if none had
+// expired, removes the entry closest to expiry. Callers must hold c.mu.
+func (c *MemoryCache) evict(now time.Time) {
+	var soonest string
+	var soonestAt time.Time
+	swept := false
+	for key, entry := range c.data {
+		if now.After(entry.expiresAt) {
+			delete(c.data, key)
+			swept = true
+			continue
+		}
+		if soonest == "" || entry.expiresAt.Before(soonestAt) {
+			soonest, soonestAt = key, entry.expiresAt
+		}
+	}
+	if !swept && soonest != "" {
+		delete(c.data, soonest)
+	}
+}
+
 // Delete removes a value from the cache.
 //
 // Args:
@@ -93,6 +145,9 @@ func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, expirat
 // Returns:
 //   - error: Any error encountered
 func (c *MemoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	delete(c.data, key)
 	return nil
 }
@@ -135,7 +190,12 @@ func (c *CachedDataProvider) Name() string {
 //   - float64: Current price
 //   - error: Any error encountered
 func (c *CachedDataProvider) GetLatestPrice(symbol string) (float64, error) {
-	ctx := context.Background()
+	return c.GetLatestPriceContext(context.Background(), symbol)
+}
+
+// GetLatestPriceContext fetches price with caching, bounding any provider
+// request by ctx.
+func (c *CachedDataProvider) GetLatestPriceContext(ctx context.Context, symbol string) (float64, error) {
 	key := fmt.Sprintf("price:%s", symbol)
 
 	// Try cache first
@@ -147,7 +207,7 @@ func (c *CachedDataProvider) GetLatestPrice(symbol string) (float64, error) {
 	}
 
 	// Fetch from provider
-	price, err := c.provider.GetLatestPrice(symbol)
+	price, err := GetLatestPriceContext(ctx, c.provider, symbol)
 	if err != nil {
 		return 0, err
 	}
@@ -162,12 +222,22 @@ func (c *CachedDataProvider) GetLatestPrice(symbol string) (float64, error) {
 
 // GetHistoricalData fetches historical data (not cached as it's typically large).
 func (c *CachedDataProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
-	return c.provider.GetHistoricalData(symbol, start, end, interval)
+	return c.GetHistoricalDataContext(context.Background(), symbol, start, end, interval)
+}
+
+// GetHistoricalDataContext fetches historical data bound to ctx.
+func (c *CachedDataProvider) GetHistoricalDataContext(ctx context.Context, symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	return GetHistoricalDataContext(ctx, c.provider, symbol, start, end, interval)
 }
 
 // GetTicker fetches ticker info with caching.
 func (c *CachedDataProvider) GetTicker(symbol string) (*models.Ticker, error) {
-	ctx := context.Background()
+	return c.GetTickerContext(context.Background(), symbol)
+}
+
+// GetTickerContext fetches ticker info with caching, bounding any provider
+// request by ctx.
+func (c *CachedDataProvider) GetTickerContext(ctx context.Context, symbol string) (*models.Ticker, error) {
 	key := fmt.Sprintf("ticker:%s", symbol)
 
 	// Try cache first
@@ -179,7 +249,7 @@ func (c *CachedDataProvider) GetTicker(symbol string) (*models.Ticker, error) {
 	}
 
 	// Fetch from provider
-	ticker, err := c.provider.GetTicker(symbol)
+	ticker, err := GetTickerContext(ctx, c.provider, symbol)
 	if err != nil {
 		return nil, err
 	}
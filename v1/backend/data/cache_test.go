@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -92,6 +93,34 @@ func TestMemoryCache_Overwrite(t *testing.T) {
 	assert.Equal(t, []byte("value2"), value)
 }
 
+// TestMemoryCache_Limit verifies a full cache sweeps expired entries first,
+// then evicts the entry closest to expiry.
+func TestMemoryCache_Limit(t *testing.T) {
+	cache := NewMemoryCacheWithLimit(2)
+	ctx := context.Background()
+
+	require.NoError(t, cache.Set(ctx, "expired", []byte("1"), -time.Second))
+	require.NoError(t, cache.Set(ctx, "long", []byte("2"), time.Hour))
+	require.NoError(t, cache.Set(ctx, "short", []byte("3"), time.Minute))
+	assert.Len(t, cache.data, 2)
+	assert.NotContains(t, cache.data, "expired", "expired entries go first")
+
+	require.NoError(t, cache.Set(ctx, "new", []byte("4"), time.Hour))
+	assert.Len(t, cache.data, 2)
+	assert.NotContains(t, cache.data, "short", "then the entry closest to expiry")
+
+	// Overwriting an existing key never evicts
+	require.NoError(t, cache.Set(ctx, "long", []byte("5"), time.Hour))
+	assert.Len(t, cache.data, 2)
+	assert.Contains(t, cache.data, "new")
+
+	unbounded := NewMemoryCacheWithLimit(0)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, unbounded.Set(ctx, fmt.Sprint(i), nil, time.Hour))
+	}
+	assert.Len(t, unbounded.data, 10)
+}
+
 // mockDataProvider is a simple mock for testing CachedDataProvider.
 type mockDataProvider struct {
 	priceCallCount  int
@@ -0,0 +1,107 @@
+// Package data provides a cap on the size of historical data requests.
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// ErrTooManyCandles is returned when a historical data request spans more
+// candles than a CandleLimitProvider allows.
+var ErrTooManyCandles = errors.New("requested range exceeds the candle limit")
+
+// CandleLimitProvider wraps a DataProvider and rejects historical requests
+// whose range spans more candles of the requested interval than the limit,
+// before the wrapped provider is called. This bounds the work a single
+// request can cause, including providers that page through long ranges.
+// Unknown intervals are passed through for the provider to reject.
+type CandleLimitProvider struct {
+	provider   DataProvider
+	maxCandles int
+}
+
+// NewCandleLimitProvider creates a candle-capped provider.
+//
+// Args:
+//   - provider: The underlying data provider
+//   - maxCandles: Most candles a request may span (0 or less disables the cap)
+//
+// Returns:
+//   - *CandleLimitProvider: The wrapped provider
+func NewCandleLimitProvider(provider DataProvider, maxCandles int) *CandleLimitProvider {
+	return &CandleLimitProvider{provider: provider, maxCandles: maxCandles}
+}
+
+// CheckCandleSpan reports whether a range of interval candles fits within
+// maxCandles. The error wraps ErrTooManyCandles.
+//
+// Args:
+//   - start: Start of the range
+//   - end: End of the range
+//   - interval: Candle interval (e.g., "1d", "1m")
+//   - maxCandles: Most candles allowed (0 or less allows any)
+//
+// Returns:
+//   - error: Nil if the range fits or the interval is unknown
+func CheckCandleSpan(start, end time.Time, interval string, maxCandles int) error {
+	candle, ok := IntervalDuration(interval)
+	if !ok || maxCandles <= 0 {
+		return nil
+	}
+	if candles := int64(end.Sub(start) / candle); candles > int64(maxCandles) {
+		return fmt.Errorf("%w: range spans %d %s candles, exceeding the maximum of %d",
+			ErrTooManyCandles, candles, interval, maxCandles)
+	}
+	return nil
+}
+
+// Name returns the wrapped provider's name.
+func (p *CandleLimitProvider) Name() string {
+	return p.provider.Name()
+}
+
+// GetHistoricalData fetches bars if the range is within the limit.
+func (p *CandleLimitProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	return p.GetHistoricalDataContext(context.Background(), symbol, start, end, interval)
+}
+
+// GetHistoricalDataContext fetches bars bound to ctx if the range is within
+// the limit.
+func (p *CandleLimitProvider) GetHistoricalDataContext(ctx context.Context, symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	if err := CheckCandleSpan(start, end, interval, p.maxCandles); err != nil {
+		return nil, err
+	}
+	return GetHistoricalDataContext(ctx, p.provider, symbol, start, end, interval)
+}
+
+// GetLatestPrice fetches the current price.
+func (p *CandleLimitProvider) GetLatestPrice(symbol string) (float64, error) {
+	return p.GetLatestPriceContext(context.Background(), symbol)
+}
+
+// GetLatestPriceContext fetches the current price bound to ctx.
+func (p *CandleLimitProvider) GetLatestPriceContext(ctx context.Context, symbol string) (float64, error) {
+	return GetLatestPriceContext(ctx, p.provider, symbol)
+}
+
+// GetTicker fetches ticker information.
+func (p *CandleLimitProvider) GetTicker(symbol string) (*models.Ticker, error) {
+	return p.GetTickerContext(context.Background(), symbol)
+}
+
+// GetTickerContext fetches ticker information bound to ctx.
+func (p *CandleLimitProvider) GetTickerContext(ctx context.Context, symbol string) (*models.Ticker, error) {
+	return GetTickerContext(ctx, p.provider, symbol)
+}
+
+// SupportsInterval reports whether the wrapped provider serves the interval.
+func (p *CandleLimitProvider) SupportsInterval(interval string) bool {
+	if ip, ok := p.provider.(IntervalProvider); ok {
+		return ip.SupportsInterval(interval)
+	}
+	return true
+}
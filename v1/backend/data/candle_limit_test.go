@@ -0,0 +1,34 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCandleLimitProvider verifies ranges over the limit are rejected per
+// interval without reaching the provider.
+func TestCandleLimitProvider(t *testing.T) {
+	inner := &recordingProvider{}
+	provider := NewCandleLimitProvider(inner, 100)
+	end := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// 30 days is 30 daily candles but 720 hourly ones
+	_, err := provider.GetHistoricalData("AAPL", end.AddDate(0, 0, -30), end, "1d")
+	require.NoError(t, err)
+
+	_, err = provider.GetHistoricalData("AAPL", end.AddDate(0, 0, -30), end, "1h")
+	require.ErrorIs(t, err, ErrTooManyCandles)
+	assert.Contains(t, err.Error(), "720 1h candles, exceeding the maximum of 100")
+	assert.Equal(t, []string{"AAPL"}, inner.symbols, "rejected requests never reach the provider")
+
+	// Unknown intervals are left to the provider
+	_, err = provider.GetHistoricalData("AAPL", end.AddDate(-10, 0, 0), end, "7x")
+	assert.NoError(t, err)
+
+	unlimited := NewCandleLimitProvider(inner, 0)
+	_, err = unlimited.GetHistoricalData("AAPL", end.AddDate(0, 0, -30), end, "1m")
+	assert.NoError(t, err)
+}
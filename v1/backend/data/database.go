@@ -3,8 +3,10 @@ package data
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/alexherrero/sherwood/backend/models"
@@ -18,7 +20,27 @@ type DB struct {
 	*sqlx.DB
 }
 
-// NewDB creates a new database connection.
+// Default connection settings for NewDB.
+const (
+	// DefaultBusyTimeout is how long a connection waits on a locked database.
+	DefaultBusyTimeout = 5 * time.Second
+	// DefaultMaxOpenConns bounds the connection pool. WAL allows concurrent
+	// readers, while writers queue on the busy timeout.
+	DefaultMaxOpenConns = 4
+)
+
+// DBOptions tunes the SQLite connection.
+type DBOptions struct {
+	BusyTimeout  time.Duration // Wait on a locked database before failing, 0 fails immediately
+	MaxOpenConns int           // Connection pool limit, 0 is unlimited
+}
+
+// DefaultDBOptions returns the connection settings used by NewDB.
+func DefaultDBOptions() DBOptions {
+	return DBOptions{BusyTimeout: DefaultBusyTimeout, MaxOpenConns: DefaultMaxOpenConns}
+}
+
+// NewDB creates a new database connection with the default options.
 //
 // Args:
 //   - databasePath: Path to the SQLite database file
@@ -27,18 +49,52 @@ type DB struct {
 //   - *DB: Database wrapper
 //   - error: Any error encountered
 func NewDB(databasePath string) (*DB, error) {
-	// Ensure the data directory exists
-	dir := filepath.Dir(databasePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	return OpenDB(databasePath, DefaultDBOptions())
+}
+
+// OpenDB creates a new database connection. File databases use WAL
+// journaling so readers don't block the writer, and every connection waits
+// up to the busy timeout for a lock instead of failing with "database is
+// locked". Transactions take the write lock when they begin, so the busy
+// timeout also covers them. In-memory databases are private to a
+// connection, so they are limited to one.
+//
+// Args:
+//   - databasePath: Path to the SQLite database file, or ":memory:"
+//   - opts: Busy timeout and pool size
+//
+// Returns:
+//   - *DB: Database wrapper
+//   - error: Any error encountered
+func OpenDB(databasePath string, opts DBOptions) (*DB, error) {
+	inMemory := databasePath == ":memory:"
+	if !inMemory {
+		// Ensure the data directory exists
+		dir := filepath.Dir(databasePath)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create database directory: %w", err)
+		}
 	}
 
-	db, err := sqlx.Connect("sqlite", databasePath)
+	db, err := sqlx.Connect("sqlite", sqliteDSN(databasePath, opts, inMemory))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	log.Info().Str("path", databasePath).Msg("Connected to database")
+	maxOpen := opts.MaxOpenConns
+	if inMemory {
+		maxOpen = 1
+	}
+	db.SetMaxOpenConns(maxOpen)
+	if maxOpen > 0 {
+		db.SetMaxIdleConns(maxOpen)
+	}
+
+	log.Info().
+		Str("path", databasePath).
+		Dur("busy_timeout", opts.BusyTimeout).
+		Int("max_open_conns", maxOpen).
+		Msg("Connected to database")
 
 	wrapper := &DB{db}
 	if err := wrapper.Migrate(); err != nil {
@@ -48,6 +104,23 @@ func NewDB(databasePath string) (*DB, error) {
 	return wrapper, nil
 }
 
+// sqliteDSN appends connection pragmas to the database path. The driver
+// applies them to every new connection in the pool.
+func sqliteDSN(databasePath string, opts DBOptions, inMemory bool) string {
+	params := url.Values{}
+	params.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", opts.BusyTimeout.Milliseconds()))
+	if !inMemory {
+		params.Add("_pragma", "journal_mode(WAL)")
+	}
+	params.Set("_txlock", "immediate")
+
+	sep := "?"
+	if strings.Contains(databasePath, "?") {
+		sep = "&"
+	}
+	return databasePath + sep + params.Encode()
+}
+
 // Migrate runs database migrations to ensure schema is up to date.
 //
 // Returns:
@@ -87,7 +160,11 @@ func (db *DB) Migrate() error {
 		filled_quantity REAL DEFAULT 0,
 		average_price REAL DEFAULT 0,
 		created_at DATETIME NOT NULL,
-		updated_at DATETIME NOT NULL
+		updated_at DATETIME NOT NULL,
+		strategy_name TEXT NOT NULL DEFAULT '',
+		tags TEXT NOT NULL DEFAULT '[]',
+		parent_id TEXT NOT NULL DEFAULT '',
+		linked_order_id TEXT NOT NULL DEFAULT ''
 	);
 
 	CREATE TABLE IF NOT EXISTS trades (
@@ -97,10 +174,13 @@ func (db *DB) Migrate() error {
 		side TEXT NOT NULL,
 		quantity REAL NOT NULL,
 		price REAL NOT NULL,
+		commission REAL NOT NULL DEFAULT 0,
 		executed_at DATETIME NOT NULL,
 		FOREIGN KEY (order_id) REFERENCES orders(id)
 	);
 
+	CREATE INDEX IF NOT EXISTS idx_trades_order_id ON trades(order_id);
+
 	CREATE TABLE IF NOT EXISTS positions (
 		symbol TEXT PRIMARY KEY,
 		quantity REAL NOT NULL,
@@ -124,6 +204,55 @@ func (db *DB) Migrate() error {
 	);
 	
 	CREATE INDEX IF NOT EXISTS idx_notifications_created_at ON notifications(created_at);
+
+	CREATE TABLE IF NOT EXISTS equity_snapshots (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		timestamp DATETIME NOT NULL,
+		cash REAL NOT NULL,
+		equity REAL NOT NULL,
+		portfolio_value REAL NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_equity_snapshots_timestamp ON equity_snapshots(timestamp);
+
+	CREATE TABLE IF NOT EXISTS signals (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		timestamp DATETIME NOT NULL,
+		symbol TEXT NOT NULL,
+		type TEXT NOT NULL,
+		strength TEXT NOT NULL DEFAULT '',
+		price REAL NOT NULL DEFAULT 0,
+		strategy_name TEXT NOT NULL DEFAULT '',
+		reason TEXT NOT NULL DEFAULT '',
+		executed BOOLEAN NOT NULL DEFAULT FALSE
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_signals_timestamp ON signals(timestamp);
+	CREATE INDEX IF NOT EXISTS idx_signals_symbol ON signals(symbol);
+
+	CREATE TABLE IF NOT EXISTS notes (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		order_id TEXT NOT NULL,
+		text TEXT NOT NULL,
+		created_at DATETIME NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_notes_order_id ON notes(order_id);
+
+	CREATE TABLE IF NOT EXISTS order_events (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		order_id TEXT NOT NULL,
+		action TEXT NOT NULL,
+		from_status TEXT NOT NULL DEFAULT '',
+		to_status TEXT NOT NULL,
+		quantity REAL NOT NULL,
+		price REAL NOT NULL DEFAULT 0,
+		actor TEXT NOT NULL,
+		trace_id TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_order_events_order_id ON order_events(order_id);
 	`
 
 	_, err := db.Exec(schema)
@@ -131,10 +260,42 @@ func (db *DB) Migrate() error {
 		return fmt.Errorf("schema migration failed: %w", err)
 	}
 
+	// Columns added after a table was first created. CREATE TABLE IF NOT
+	// EXISTS leaves existing tables alone, so older databases gain them here.
+	columns := []struct{ table, column, definition string }{
+		{"orders", "strategy_name", "TEXT NOT NULL DEFAULT ''"},
+		{"orders", "tags", "TEXT NOT NULL DEFAULT '[]'"},
+		{"orders", "parent_id", "TEXT NOT NULL DEFAULT ''"},
+		{"orders", "linked_order_id", "TEXT NOT NULL DEFAULT ''"},
+		{"trades", "commission", "REAL NOT NULL DEFAULT 0"},
+	}
+	for _, c := range columns {
+		if err := db.addColumnIfMissing(c.table, c.column, c.definition); err != nil {
+			return fmt.Errorf("schema migration failed: %w", err)
+		}
+	}
+
 	log.Info().Msg("Database migrations complete")
 	return nil
 }
 
+// addColumnIfMissing adds a column to a table unless it already exists.
+func (db *DB) addColumnIfMissing(table, column, definition string) error {
+	var count int
+	query := `SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`
+	if err := db.Get(&count, query, table, column); err != nil {
+		return fmt.Errorf("failed to inspect %s: %w", table, err)
+	}
+	if count > 0 {
+		return nil
+	}
+	if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("failed to add %s.%s: %w", table, column, err)
+	}
+	log.Info().Str("table", table).Str("column", column).Msg("Added database column")
+	return nil
+}
+
 // SaveOHLCV stores OHLCV data in the database.
 //
 // Args:
@@ -1,12 +1,16 @@
 package data
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -41,6 +45,96 @@ func TestNewDB_CreatesDirectory(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// TestOpenDB_Pragmas verifies WAL mode, the busy timeout and the pool limit
+// are applied to every connection.
+func TestOpenDB_Pragmas(t *testing.T) {
+	db, err := OpenDB(filepath.Join(t.TempDir(), "test.db"), DBOptions{BusyTimeout: 2 * time.Second, MaxOpenConns: 3})
+	require.NoError(t, err)
+	defer db.Close()
+
+	assert.Equal(t, 3, db.Stats().MaxOpenConnections)
+
+	// Hold one connection so the queries below open others
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	for i := 0; i < 2; i++ {
+		var mode string
+		require.NoError(t, db.Get(&mode, "PRAGMA journal_mode"))
+		assert.Equal(t, "wal", mode)
+
+		var timeout int
+		require.NoError(t, db.Get(&timeout, "PRAGMA busy_timeout"))
+		assert.Equal(t, 2000, timeout)
+	}
+}
+
+// TestOpenDB_InMemory verifies in-memory databases use a single connection
+// so every query sees the migrated schema.
+func TestOpenDB_InMemory(t *testing.T) {
+	db, err := OpenDB(":memory:", DefaultDBOptions())
+	require.NoError(t, err)
+	defer db.Close()
+
+	assert.Equal(t, 1, db.Stats().MaxOpenConnections)
+
+	var count int
+	require.NoError(t, db.Get(&count, "SELECT COUNT(*) FROM orders"))
+	assert.Zero(t, count)
+}
+
+// TestDB_ConcurrentWrites verifies concurrent order writes alongside
+// transactional signal logging don't fail with lock errors.
+func TestDB_ConcurrentWrites(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	orders := NewOrderStore(db)
+	signals := NewSignalStore(db)
+
+	const writers = 8
+	const perWriter = 25
+	errs := make(chan error, writers*perWriter*2)
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				now := time.Now()
+				errs <- orders.SaveOrder(models.Order{
+					ID:        fmt.Sprintf("order-%d-%d", w, i),
+					Symbol:    "AAPL",
+					Side:      models.OrderSideBuy,
+					Type:      models.OrderTypeMarket,
+					Quantity:  1,
+					Status:    models.OrderStatusFilled,
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+				errs <- signals.SaveSignals([]models.SignalRecord{{
+					Timestamp:    now,
+					Symbol:       "AAPL",
+					Type:         models.SignalBuy,
+					StrategyName: "ma_crossover",
+				}})
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	all, err := orders.GetAllOrders()
+	require.NoError(t, err)
+	assert.Len(t, all, writers*perWriter)
+}
+
 // TestDB_Migrate verifies schema creation.
 func TestDB_Migrate(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -57,6 +151,47 @@ func TestDB_Migrate(t *testing.T) {
 	assert.Equal(t, 5, count) // All 5 tables should exist
 }
 
+// TestDB_Migrate_AddsOrderColumns verifies an orders table created before
+// strategy attribution gains the new columns and keeps its rows.
+func TestDB_Migrate_AddsOrderColumns(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	legacy, err := sqlx.Connect("sqlite", dbPath)
+	require.NoError(t, err)
+	_, err = legacy.Exec(`
+		CREATE TABLE orders (
+			id TEXT PRIMARY KEY,
+			symbol TEXT NOT NULL,
+			side TEXT NOT NULL,
+			type TEXT NOT NULL,
+			quantity REAL NOT NULL,
+			price REAL NOT NULL,
+			status TEXT NOT NULL,
+			filled_quantity REAL DEFAULT 0,
+			average_price REAL DEFAULT 0,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL
+		);
+		INSERT INTO orders (id, symbol, side, type, quantity, price, status, created_at, updated_at)
+		VALUES ('old-1', 'AAPL', 'buy', 'market', 1, 0, 'filled', '2024-01-01 00:00:00', '2024-01-01 00:00:00');
+	`)
+	require.NoError(t, err)
+	require.NoError(t, legacy.Close())
+
+	db, err := NewDB(dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Running migrations again is a no-op
+	require.NoError(t, db.Migrate())
+
+	order, err := NewOrderStore(db).GetOrder("old-1")
+	require.NoError(t, err)
+	assert.Equal(t, "AAPL", order.Symbol)
+	assert.Empty(t, order.StrategyName)
+	assert.Empty(t, order.Tags)
+}
+
 // TestDB_SaveOHLCV verifies saving OHLCV data.
 func TestDB_SaveOHLCV(t *testing.T) {
 	tmpDir := t.TempDir()
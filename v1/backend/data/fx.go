@@ -0,0 +1,116 @@
+// Package data provides currency conversion for valuing mixed portfolios.
+package data
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// usdPegged are the currencies treated as equal to one US dollar.
+var usdPegged = []string{"USD", "USDT", "USDC", "BUSD"}
+
+// USDPeggedCurrencies returns the currencies treated as equal to one US
+// dollar, which are the only ones NewStaticFXRates can convert between.
+//
+// Returns:
+//   - []string: Currency codes (USD first)
+func USDPeggedCurrencies() []string {
+	return append([]string(nil), usdPegged...)
+}
+
+// IsUSDPegged reports whether code is one of the USDPeggedCurrencies.
+func IsUSDPegged(code string) bool {
+	for _, c := range usdPegged {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// FXRateSource provides exchange rates between currencies.
+type FXRateSource interface {
+	// Rate returns how many units of to one unit of from is worth.
+	//
+	// Args:
+	//   - from: Currency to convert from (e.g., "USDT")
+	//   - to: Currency to convert to (e.g., "USD")
+	//
+	// Returns:
+	//   - float64: The exchange rate
+	//   - error: If no rate is known for the pair
+	Rate(from, to string) (float64, error)
+}
+
+// StaticFXRates is an FXRateSource backed by fixed rates. It starts with the
+// US dollar and its stablecoins at 1:1; other pairs are added with SetRate.
+type StaticFXRates struct {
+	rates map[string]float64 // Keyed by "FROM/TO"
+	mu    sync.RWMutex
+}
+
+// NewStaticFXRates creates a rate source with USD, USDT, USDC and BUSD at 1:1.
+//
+// Returns:
+//   - *StaticFXRates: The rate source
+func NewStaticFXRates() *StaticFXRates {
+	r := &StaticFXRates{rates: make(map[string]float64)}
+	for _, from := range usdPegged {
+		for _, to := range usdPegged {
+			if from != to {
+				r.rates[from+"/"+to] = 1
+			}
+		}
+	}
+	return r
+}
+
+// SetRate sets the rate from one currency to another, and its inverse.
+//
+// Args:
+//   - from: Currency to convert from
+//   - to: Currency to convert to
+//   - rate: Units of to per unit of from (must be positive)
+func (r *StaticFXRates) SetRate(from, to string, rate float64) {
+	if rate <= 0 {
+		return
+	}
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rates[from+"/"+to] = rate
+	r.rates[to+"/"+from] = 1 / rate
+}
+
+// Rate returns the rate from one currency to another. Converting a currency
+// to itself is always 1.
+func (r *StaticFXRates) Rate(from, to string) (float64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return 1, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if rate, ok := r.rates[from+"/"+to]; ok {
+		return rate, nil
+	}
+	return 0, fmt.Errorf("no exchange rate from %s to %s", from, to)
+}
+
+// QuoteCurrency returns the currency a symbol is priced in: the quote of a
+// crypto pair ("USDT" for "BTC-USDT"), or "USD" for equities.
+//
+// Args:
+//   - symbol: Ticker symbol
+//
+// Returns:
+//   - string: The quote currency
+func QuoteCurrency(symbol string) string {
+	if _, quote, ok := splitPair(CanonicalSymbol(symbol)); ok {
+		return quote
+	}
+	return "USD"
+}
@@ -0,0 +1,55 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStaticFXRates verifies the default pegs, custom rates and their inverses.
+func TestStaticFXRates(t *testing.T) {
+	rates := NewStaticFXRates()
+
+	rate, err := rates.Rate("USDT", "USD")
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, rate)
+
+	rate, err = rates.Rate("eur", "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, rate)
+
+	_, err = rates.Rate("EUR", "USD")
+	assert.ErrorContains(t, err, "no exchange rate from EUR to USD")
+
+	rates.SetRate("EUR", "USD", 1.25)
+	rate, err = rates.Rate("EUR", "USD")
+	require.NoError(t, err)
+	assert.Equal(t, 1.25, rate)
+
+	rate, err = rates.Rate("usd", "eur")
+	require.NoError(t, err)
+	assert.Equal(t, 0.8, rate)
+
+	// Non-positive rates are ignored.
+	rates.SetRate("EUR", "USD", 0)
+	rate, _ = rates.Rate("EUR", "USD")
+	assert.Equal(t, 1.25, rate)
+}
+
+// TestIsUSDPegged verifies only the dollar and its stablecoins are pegged.
+func TestIsUSDPegged(t *testing.T) {
+	for _, code := range USDPeggedCurrencies() {
+		assert.True(t, IsUSDPegged(code), code)
+	}
+	assert.False(t, IsUSDPegged("EUR"))
+	assert.False(t, IsUSDPegged("usd"))
+}
+
+// TestQuoteCurrency verifies the quote currency is derived from the symbol.
+func TestQuoteCurrency(t *testing.T) {
+	assert.Equal(t, "USD", QuoteCurrency("AAPL"))
+	assert.Equal(t, "USD", QuoteCurrency("BTC-USD"))
+	assert.Equal(t, "USDT", QuoteCurrency("eth/usdt"))
+	assert.Equal(t, "EUR", QuoteCurrency("BTC-EUR"))
+}
@@ -0,0 +1,279 @@
+// Package data provides gap detection for provider bar series.
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/rs/zerolog/log"
+)
+
+// GapPolicy controls how GapCheckedProvider treats missing bars.
+type GapPolicy string
+
+const (
+	// GapPolicyLog detects and logs gaps but returns bars unchanged.
+	GapPolicyLog GapPolicy = "log"
+	// GapPolicyDrop logs gaps and drops trailing bars whose period has not closed.
+	GapPolicyDrop GapPolicy = "drop"
+	// GapPolicyFill logs gaps and forward-fills them with the previous close.
+	GapPolicyFill GapPolicy = "fill"
+)
+
+// IsValid reports whether the policy is known.
+func (p GapPolicy) IsValid() bool {
+	switch p {
+	case GapPolicyLog, GapPolicyDrop, GapPolicyFill:
+		return true
+	default:
+		return false
+	}
+}
+
+// TradingDays reports which days a symbol's market trades, so market
+// holidays are not counted as gaps.
+type TradingDays interface {
+	// IsTradingDay reports whether the symbol's market trades on a day.
+	//
+	// Args:
+	//   - symbol: Ticker symbol
+	//   - day: Any time on the day, read in its own location
+	//
+	// Returns:
+	//   - bool: True if a daily bar is expected for the day
+	IsTradingDay(symbol string, day time.Time) bool
+}
+
+// missingBetween returns the start times of bars expected between prev and
+// next. Crypto pairs expect a bar every interval. Equities only expect bars on
+// trading days for daily data (weekdays when days is nil), and within the
+// same day for intraday data, so weekends, holidays and overnight sessions
+// are not gaps. Intervals longer than a day are not checked.
+func missingBetween(prev, next models.OHLCV, d time.Duration, days TradingDays) []time.Time {
+	const day = 24 * time.Hour
+	if d <= 0 || d > day {
+		return nil
+	}
+
+	var missing []time.Time
+	crypto := IsCryptoSymbol(prev.Symbol)
+
+	if d == day && !crypto {
+		last := next.Timestamp.In(prev.Timestamp.Location()).Format("2006-01-02")
+		for t := prev.Timestamp.AddDate(0, 0, 1); t.Format("2006-01-02") < last; t = t.AddDate(0, 0, 1) {
+			if isTradingDay(days, prev.Symbol, t) {
+				missing = append(missing, t)
+			}
+		}
+		return missing
+	}
+
+	if !crypto && !sameDate(prev.Timestamp, next.Timestamp) {
+		return nil
+	}
+	// Allow half an interval of jitter in provider timestamps
+	for t := prev.Timestamp.Add(d); next.Timestamp.Sub(t) >= d/2; t = t.Add(d) {
+		missing = append(missing, t)
+	}
+	return missing
+}
+
+// isTradingDay reports whether a daily bar is expected on t's day, falling
+// back to weekdays without a calendar.
+func isTradingDay(days TradingDays, symbol string, t time.Time) bool {
+	if days != nil {
+		return days.IsTradingDay(symbol, t)
+	}
+	return t.Weekday() != time.Saturday && t.Weekday() != time.Sunday
+}
+
+// sameDate reports whether two times fall on the same calendar day in a's location.
+func sameDate(a, b time.Time) bool {
+	return a.Format("2006-01-02") == b.In(a.Location()).Format("2006-01-02")
+}
+
+// CountGaps returns how many bars are missing from a series.
+//
+// Args:
+//   - bars: Bars sorted by timestamp
+//   - interval: Bar interval (e.g., "1d", "5m")
+//   - days: Trading days for daily equity bars (nil means weekdays)
+//
+// Returns:
+//   - int: Number of missing bars (0 for unknown intervals)
+func CountGaps(bars []models.OHLCV, interval string, days TradingDays) int {
+	d, ok := IntervalDuration(interval)
+	if !ok {
+		return 0
+	}
+
+	count := 0
+	for i := 1; i < len(bars); i++ {
+		count += len(missingBetween(bars[i-1], bars[i], d, days))
+	}
+	return count
+}
+
+// FillGaps inserts a bar for every missing period, with open, high, low and
+// close set to the previous close and zero volume.
+//
+// Args:
+//   - bars: Bars sorted by timestamp
+//   - interval: Bar interval (e.g., "1d", "5m")
+//   - days: Trading days for daily equity bars (nil means weekdays)
+//
+// Returns:
+//   - []models.OHLCV: Contiguous bars (the input if nothing is missing)
+func FillGaps(bars []models.OHLCV, interval string, days TradingDays) []models.OHLCV {
+	d, ok := IntervalDuration(interval)
+	if !ok || len(bars) < 2 {
+		return bars
+	}
+
+	filled := make([]models.OHLCV, 0, len(bars))
+	filled = append(filled, bars[0])
+	for i := 1; i < len(bars); i++ {
+		prev := bars[i-1]
+		for _, t := range missingBetween(prev, bars[i], d, days) {
+			filled = append(filled, models.OHLCV{
+				Timestamp: t,
+				Symbol:    prev.Symbol,
+				Open:      prev.Close,
+				High:      prev.Close,
+				Low:       prev.Close,
+				Close:     prev.Close,
+			})
+		}
+		filled = append(filled, bars[i])
+	}
+	return filled
+}
+
+// DropIncomplete removes trailing bars whose period has not closed by now,
+// such as today's daily bar during the session.
+//
+// Args:
+//   - bars: Bars sorted by timestamp
+//   - interval: Bar interval (e.g., "1d", "5m")
+//   - now: Current time
+//
+// Returns:
+//   - []models.OHLCV: Bars whose periods have all closed
+func DropIncomplete(bars []models.OHLCV, interval string, now time.Time) []models.OHLCV {
+	d, ok := IntervalDuration(interval)
+	if !ok {
+		return bars
+	}
+
+	end := len(bars)
+	for end > 0 && bars[end-1].Timestamp.Add(d).After(now) {
+		end--
+	}
+	return bars[:end]
+}
+
+// GapCheckedProvider wraps a DataProvider and checks every historical series
+// for missing bars, applying a GapPolicy.
+type GapCheckedProvider struct {
+	provider DataProvider
+	policy   GapPolicy
+	days     TradingDays
+	now      func() time.Time
+}
+
+// NewGapCheckedProvider creates a gap-checking provider.
+//
+// Args:
+//   - provider: The underlying data provider
+//   - policy: How to treat gaps (unknown policies only log)
+//
+// Returns:
+//   - *GapCheckedProvider: The wrapped provider
+func NewGapCheckedProvider(provider DataProvider, policy GapPolicy) *GapCheckedProvider {
+	return &GapCheckedProvider{
+		provider: provider,
+		policy:   policy,
+		now:      time.Now,
+	}
+}
+
+// SetTradingDays sets the calendar used to skip market holidays when
+// checking daily equity bars. Must be called before the provider is shared.
+//
+// Args:
+//   - days: Trading day calendar (nil checks weekdays only)
+func (p *GapCheckedProvider) SetTradingDays(days TradingDays) {
+	p.days = days
+}
+
+// Name returns the wrapped provider's name.
+func (p *GapCheckedProvider) Name() string {
+	return p.provider.Name()
+}
+
+// GetHistoricalData fetches bars and applies the gap policy.
+func (p *GapCheckedProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	return p.GetHistoricalDataContext(context.Background(), symbol, start, end, interval)
+}
+
+// GetHistoricalDataContext fetches bars bound to ctx and applies the gap
+// policy.
+func (p *GapCheckedProvider) GetHistoricalDataContext(ctx context.Context, symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	bars, err := GetHistoricalDataContext(ctx, p.provider, symbol, start, end, interval)
+	if err != nil {
+		return bars, err
+	}
+
+	gaps := CountGaps(bars, interval, p.days)
+	dropped := 0
+	switch p.policy {
+	case GapPolicyFill:
+		bars = FillGaps(bars, interval, p.days)
+	case GapPolicyDrop:
+		complete := DropIncomplete(bars, interval, p.now())
+		dropped = len(bars) - len(complete)
+		bars = complete
+	}
+
+	if gaps > 0 || dropped > 0 {
+		log.Debug().
+			Str("symbol", symbol).
+			Str("interval", interval).
+			Str("policy", string(p.policy)).
+			Int("gaps", gaps).
+			Int("dropped", dropped).
+			Msg("Data gap check")
+	}
+	return bars, nil
+}
+
+// GetLatestPrice fetches the current price from the wrapped provider.
+func (p *GapCheckedProvider) GetLatestPrice(symbol string) (float64, error) {
+	return p.provider.GetLatestPrice(symbol)
+}
+
+// GetLatestPriceContext fetches the current price from the wrapped provider,
+// bound to ctx.
+func (p *GapCheckedProvider) GetLatestPriceContext(ctx context.Context, symbol string) (float64, error) {
+	return GetLatestPriceContext(ctx, p.provider, symbol)
+}
+
+// GetTicker fetches ticker information from the wrapped provider.
+func (p *GapCheckedProvider) GetTicker(symbol string) (*models.Ticker, error) {
+	return p.provider.GetTicker(symbol)
+}
+
+// GetTickerContext fetches ticker information from the wrapped provider,
+// bound to ctx.
+func (p *GapCheckedProvider) GetTickerContext(ctx context.Context, symbol string) (*models.Ticker, error) {
+	return GetTickerContext(ctx, p.provider, symbol)
+}
+
+// SupportsInterval reports whether the wrapped provider can serve the interval.
+func (p *GapCheckedProvider) SupportsInterval(interval string) bool {
+	if ip, ok := p.provider.(IntervalProvider); ok {
+		return ip.SupportsInterval(interval)
+	}
+	return true
+}
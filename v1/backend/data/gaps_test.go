@@ -0,0 +1,142 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// barsAt builds bars for a symbol at the given times, closing at 100 + index.
+func barsAt(symbol string, times ...time.Time) []models.OHLCV {
+	bars := make([]models.OHLCV, len(times))
+	for i, t := range times {
+		bars[i] = models.OHLCV{Timestamp: t, Symbol: symbol, Close: 100 + float64(i), Volume: 1000}
+	}
+	return bars
+}
+
+// TestCountGaps_DailyEquity verifies weekends are not gaps but missing weekdays are.
+func TestCountGaps_DailyEquity(t *testing.T) {
+	fri := time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)
+	mon := fri.AddDate(0, 0, 3)
+	wed := fri.AddDate(0, 0, 5)
+
+	assert.Zero(t, CountGaps(barsAt("AAPL", fri, mon), "1d", nil))
+	assert.Equal(t, 1, CountGaps(barsAt("AAPL", fri, mon, wed), "1d", nil))
+}
+
+// holidays is a TradingDays that closes on weekends and listed dates.
+type holidays map[string]bool
+
+func (h holidays) IsTradingDay(symbol string, day time.Time) bool {
+	return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday && !h[day.Format("2006-01-02")]
+}
+
+// TestCountGaps_TradingDays verifies market holidays are not gaps when a
+// calendar is set.
+func TestCountGaps_TradingDays(t *testing.T) {
+	// Good Friday 2026 is April 3
+	thu := time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC)
+	mon := thu.AddDate(0, 0, 4)
+	days := holidays{"2026-04-03": true}
+
+	assert.Equal(t, 1, CountGaps(barsAt("AAPL", thu, mon), "1d", nil), "without a calendar the holiday is a gap")
+	assert.Zero(t, CountGaps(barsAt("AAPL", thu, mon), "1d", days))
+	assert.Len(t, FillGaps(barsAt("AAPL", thu, mon), "1d", days), 2)
+}
+
+// TestCountGaps_Intraday verifies overnight breaks are ignored for equities
+// but every missing interval counts for crypto.
+func TestCountGaps_Intraday(t *testing.T) {
+	open := time.Date(2026, 3, 6, 14, 30, 0, 0, time.UTC)
+	nextOpen := open.AddDate(0, 0, 3)
+
+	assert.Zero(t, CountGaps(barsAt("AAPL", open, nextOpen), "1h", nil))
+	assert.Equal(t, 2, CountGaps(barsAt("AAPL", open, open.Add(3*time.Hour)), "1h", nil))
+	assert.Equal(t, 71, CountGaps(barsAt("BTC-USD", open, nextOpen), "1h", nil))
+
+	// Small timestamp jitter is not a gap
+	assert.Zero(t, CountGaps(barsAt("BTC-USD", open, open.Add(time.Hour+time.Second)), "1h", nil))
+
+	// Longer and unknown intervals are not checked
+	assert.Zero(t, CountGaps(barsAt("BTC-USD", open, open.AddDate(0, 2, 0)), "1mo", nil))
+	assert.Zero(t, CountGaps(barsAt("BTC-USD", open, open.AddDate(0, 2, 0)), "bogus", nil))
+}
+
+// TestFillGaps verifies missing bars are forward-filled with the previous close.
+func TestFillGaps(t *testing.T) {
+	start := time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)
+	bars := barsAt("BTC-USD", start, start.Add(3*24*time.Hour))
+
+	filled := FillGaps(bars, "1d", nil)
+	require.Len(t, filled, 4)
+	for i, bar := range filled {
+		assert.Equal(t, start.AddDate(0, 0, i), bar.Timestamp)
+		assert.Equal(t, "BTC-USD", bar.Symbol)
+	}
+	assert.Equal(t, 100.0, filled[1].Open)
+	assert.Equal(t, 100.0, filled[2].Close)
+	assert.Zero(t, filled[2].Volume)
+	assert.Equal(t, 101.0, filled[3].Close)
+	assert.Zero(t, CountGaps(filled, "1d", nil))
+}
+
+// TestDropIncomplete verifies only trailing bars still forming are dropped.
+func TestDropIncomplete(t *testing.T) {
+	start := time.Date(2026, 3, 6, 14, 0, 0, 0, time.UTC)
+	bars := barsAt("AAPL", start, start.Add(time.Hour), start.Add(2*time.Hour))
+
+	complete := DropIncomplete(bars, "1h", start.Add(2*time.Hour+30*time.Minute))
+	require.Len(t, complete, 2)
+	assert.Equal(t, start.Add(time.Hour), complete[1].Timestamp)
+
+	assert.Len(t, DropIncomplete(bars, "1h", start.Add(3*time.Hour)), 3)
+}
+
+// gappyProvider returns a fixed series for any request.
+type gappyProvider struct {
+	mockDataProvider
+	bars []models.OHLCV
+}
+
+func (p *gappyProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	return append([]models.OHLCV(nil), p.bars...), nil
+}
+
+// TestGapCheckedProvider verifies each policy is applied to fetched bars.
+func TestGapCheckedProvider(t *testing.T) {
+	start := time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)
+	inner := &gappyProvider{bars: barsAt("BTC-USD", start, start.AddDate(0, 0, 2), start.AddDate(0, 0, 3))}
+	now := start.AddDate(0, 0, 3).Add(time.Hour)
+
+	tests := []struct {
+		policy GapPolicy
+		want   int
+	}{
+		{GapPolicyLog, 3},
+		{GapPolicyFill, 4},
+		{GapPolicyDrop, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			provider := NewGapCheckedProvider(inner, tt.policy)
+			provider.now = func() time.Time { return now }
+
+			bars, err := provider.GetHistoricalData("BTC-USD", start, now, "1d")
+			require.NoError(t, err)
+			assert.Len(t, bars, tt.want)
+		})
+	}
+}
+
+// TestGapPolicy_IsValid verifies policy validation.
+func TestGapPolicy_IsValid(t *testing.T) {
+	assert.True(t, GapPolicyLog.IsValid())
+	assert.True(t, GapPolicyDrop.IsValid())
+	assert.True(t, GapPolicyFill.IsValid())
+	assert.False(t, GapPolicy("skip").IsValid())
+}
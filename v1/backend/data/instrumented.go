@@ -0,0 +1,94 @@
+// Package data provides latency and error metrics for data provider requests.
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/metrics"
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// InstrumentedProvider wraps a DataProvider and records each request's
+// latency and failures in the process metrics, labelled by provider name and
+// method. Wrap the raw provider so only upstream requests are measured.
+type InstrumentedProvider struct {
+	provider DataProvider
+}
+
+// NewInstrumentedProvider creates a metrics-recording provider.
+//
+// Args:
+//   - provider: The underlying data provider
+//
+// Returns:
+//   - *InstrumentedProvider: The wrapped provider
+func NewInstrumentedProvider(provider DataProvider) *InstrumentedProvider {
+	return &InstrumentedProvider{provider: provider}
+}
+
+// Name returns the wrapped provider's name.
+func (p *InstrumentedProvider) Name() string {
+	return p.provider.Name()
+}
+
+// GetHistoricalData fetches bars, recording the request.
+func (p *InstrumentedProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	return p.GetHistoricalDataContext(context.Background(), symbol, start, end, interval)
+}
+
+// GetHistoricalDataContext fetches bars bound to ctx, recording the request.
+func (p *InstrumentedProvider) GetHistoricalDataContext(ctx context.Context, symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	defer p.observe("historical_data", time.Now())
+	bars, err := GetHistoricalDataContext(ctx, p.provider, symbol, start, end, interval)
+	p.recordError("historical_data", err)
+	return bars, err
+}
+
+// GetLatestPrice fetches the current price, recording the request.
+func (p *InstrumentedProvider) GetLatestPrice(symbol string) (float64, error) {
+	return p.GetLatestPriceContext(context.Background(), symbol)
+}
+
+// GetLatestPriceContext fetches the current price bound to ctx, recording
+// the request.
+func (p *InstrumentedProvider) GetLatestPriceContext(ctx context.Context, symbol string) (float64, error) {
+	defer p.observe("latest_price", time.Now())
+	price, err := GetLatestPriceContext(ctx, p.provider, symbol)
+	p.recordError("latest_price", err)
+	return price, err
+}
+
+// GetTicker fetches ticker information, recording the request.
+func (p *InstrumentedProvider) GetTicker(symbol string) (*models.Ticker, error) {
+	return p.GetTickerContext(context.Background(), symbol)
+}
+
+// GetTickerContext fetches ticker information bound to ctx, recording the
+// request.
+func (p *InstrumentedProvider) GetTickerContext(ctx context.Context, symbol string) (*models.Ticker, error) {
+	defer p.observe("ticker", time.Now())
+	ticker, err := GetTickerContext(ctx, p.provider, symbol)
+	p.recordError("ticker", err)
+	return ticker, err
+}
+
+// SupportsInterval reports whether the wrapped provider serves the interval.
+func (p *InstrumentedProvider) SupportsInterval(interval string) bool {
+	if ip, ok := p.provider.(IntervalProvider); ok {
+		return ip.SupportsInterval(interval)
+	}
+	return true
+}
+
+// observe records a request's latency.
+func (p *InstrumentedProvider) observe(method string, start time.Time) {
+	metrics.ProviderRequestDuration.Observe(time.Since(start).Seconds(), p.provider.Name(), method)
+}
+
+// recordError counts a failed request.
+func (p *InstrumentedProvider) recordError(method string, err error) {
+	if err != nil {
+		metrics.ProviderErrors.Inc(p.provider.Name(), method)
+	}
+}
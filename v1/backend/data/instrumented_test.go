@@ -0,0 +1,37 @@
+package data
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInstrumentedProvider verifies requests are timed and failures counted
+// per method.
+func TestInstrumentedProvider(t *testing.T) {
+	inner := &legProvider{prices: map[string]float64{"AAPL": 150}}
+	provider := NewInstrumentedProvider(inner)
+	assert.Equal(t, "mock", provider.Name())
+
+	requests := metrics.ProviderRequestDuration.Count("mock", "historical_data")
+	failures := metrics.ProviderErrors.Value("mock", "historical_data")
+
+	bars, err := provider.GetHistoricalData("AAPL", time.Now().Add(-time.Hour), time.Now(), "1h")
+	require.NoError(t, err)
+	require.Len(t, bars, 1)
+
+	inner.err = errors.New("provider down")
+	_, err = provider.GetHistoricalData("AAPL", time.Now().Add(-time.Hour), time.Now(), "1h")
+	require.Error(t, err)
+
+	assert.Equal(t, requests+2, metrics.ProviderRequestDuration.Count("mock", "historical_data"))
+	assert.Equal(t, failures+1, metrics.ProviderErrors.Value("mock", "historical_data"))
+
+	prices := metrics.ProviderRequestDuration.Count("mock", "latest_price")
+	_, _ = provider.GetLatestPrice("AAPL")
+	assert.Equal(t, prices+1, metrics.ProviderRequestDuration.Count("mock", "latest_price"))
+}
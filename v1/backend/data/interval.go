@@ -0,0 +1,43 @@
+package data
+
+import "time"
+
+// intervalDurations maps interval strings to candle lengths.
+// Months are approximated as 30 days.
+var intervalDurations = map[string]time.Duration{
+	"1m":    time.Minute,
+	"2m":    2 * time.Minute,
+	"3m":    3 * time.Minute,
+	"5m":    5 * time.Minute,
+	"15m":   15 * time.Minute,
+	"30m":   30 * time.Minute,
+	"60m":   time.Hour,
+	"1h":    time.Hour,
+	"2h":    2 * time.Hour,
+	"4h":    4 * time.Hour,
+	"6h":    6 * time.Hour,
+	"8h":    8 * time.Hour,
+	"12h":   12 * time.Hour,
+	"1d":    24 * time.Hour,
+	"daily": 24 * time.Hour,
+	"3d":    3 * 24 * time.Hour,
+	"5d":    5 * 24 * time.Hour,
+	"1w":    7 * 24 * time.Hour,
+	"1wk":   7 * 24 * time.Hour,
+	"1M":    30 * 24 * time.Hour,
+	"1mo":   30 * 24 * time.Hour,
+	"3mo":   90 * 24 * time.Hour,
+}
+
+// IntervalDuration returns the length of one candle for an interval.
+//
+// Args:
+//   - interval: Time interval (e.g., "1d", "1h", "5m")
+//
+// Returns:
+//   - time.Duration: Candle length
+//   - bool: False if the interval is unknown
+func IntervalDuration(interval string) (time.Duration, bool) {
+	d, ok := intervalDurations[interval]
+	return d, ok
+}
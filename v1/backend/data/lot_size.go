@@ -0,0 +1,97 @@
+// Package data provides exchange-reported quantity precision.
+package data
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrLotSizeUnsupported is returned by providers that do not report lot sizes.
+var ErrLotSizeUnsupported = errors.New("provider does not report lot sizes")
+
+// LotSizeProvider is implemented by providers that can report the quantity
+// step an exchange accepts for a symbol (e.g., Binance's LOT_SIZE filter).
+type LotSizeProvider interface {
+	// GetLotSize returns the quantity step for a symbol.
+	//
+	// Args:
+	//   - ctx: Context bounding the request
+	//   - symbol: Ticker symbol
+	//
+	// Returns:
+	//   - float64: Quantity step (e.g., 0.00001)
+	//   - error: ErrLotSizeUnsupported, or any error fetching it
+	GetLotSize(ctx context.Context, symbol string) (float64, error)
+}
+
+// LotSizeCache remembers the lot sizes reported by a provider. Lot sizes
+// rarely change, so each symbol is fetched once; failed lookups are retried
+// on the next call unless the provider does not report lot sizes at all.
+// Lookups run without the cache lock held, so a slow fetch for one symbol
+// never blocks orders for another.
+type LotSizeCache struct {
+	provider    LotSizeProvider
+	timeout     time.Duration
+	steps       map[string]float64
+	unsupported bool
+	mu          sync.Mutex
+}
+
+// NewLotSizeCache creates a lot size cache backed by provider.
+//
+// Args:
+//   - provider: Provider reporting exchange lot sizes
+//   - timeout: Bound on each lookup (0 for none)
+//
+// Returns:
+//   - *LotSizeCache: The cache
+func NewLotSizeCache(provider LotSizeProvider, timeout time.Duration) *LotSizeCache {
+	return &LotSizeCache{
+		provider: provider,
+		timeout:  timeout,
+		steps:    make(map[string]float64),
+	}
+}
+
+// LotSize returns the exchange's quantity step for a symbol.
+//
+// Args:
+//   - ctx: Context bounding a lookup, further bounded by the cache's timeout
+//   - symbol: Ticker symbol
+//
+// Returns:
+//   - float64: Quantity step
+//   - bool: False if the provider could not report one
+func (c *LotSizeCache) LotSize(ctx context.Context, symbol string) (float64, bool) {
+	c.mu.Lock()
+	step, ok := c.steps[symbol]
+	unsupported := c.unsupported
+	c.mu.Unlock()
+	if ok {
+		return step, true
+	}
+	if unsupported {
+		return 0, false
+	}
+
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	step, err := c.provider.GetLotSize(ctx, symbol)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if errors.Is(err, ErrLotSizeUnsupported) {
+		c.unsupported = true
+		return 0, false
+	}
+	if err != nil || step <= 0 {
+		return 0, false
+	}
+	c.steps[symbol] = step
+	return step, true
+}
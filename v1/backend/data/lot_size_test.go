@@ -0,0 +1,93 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lotSizeProvider reports fixed lot sizes and counts lookups.
+type lotSizeProvider struct {
+	mockDataProvider
+	steps map[string]float64
+	err   error
+	calls int
+}
+
+func (p *lotSizeProvider) GetLotSize(ctx context.Context, symbol string) (float64, error) {
+	p.calls++
+	if p.err != nil {
+		return 0, p.err
+	}
+	step, ok := p.steps[symbol]
+	if !ok {
+		return 0, errors.New("unknown symbol")
+	}
+	return step, nil
+}
+
+// TestLotSizeCache verifies lot sizes are fetched once per symbol and failed
+// lookups are retried.
+func TestLotSizeCache(t *testing.T) {
+	provider := &lotSizeProvider{steps: map[string]float64{"BTCUSDT": 0.00001}}
+	cache := NewLotSizeCache(NewNormalizedProvider(provider, BinanceSymbols{}), 0)
+
+	step, ok := cache.LotSize(context.Background(), "BTC-USD")
+	assert.True(t, ok)
+	assert.Equal(t, 0.00001, step)
+	_, _ = cache.LotSize(context.Background(), "BTC-USD")
+	assert.Equal(t, 1, provider.calls)
+
+	_, ok = cache.LotSize(context.Background(), "DOGE-USD")
+	assert.False(t, ok)
+	_, _ = cache.LotSize(context.Background(), "DOGE-USD")
+	assert.Equal(t, 3, provider.calls)
+}
+
+// TestLotSizeCache_Unsupported verifies providers without lot sizes are only
+// asked once.
+func TestLotSizeCache_Unsupported(t *testing.T) {
+	cache := NewLotSizeCache(NewNormalizedProvider(&mockDataProvider{}, YahooSymbols{}), 0)
+
+	_, ok := cache.LotSize(context.Background(), "AAPL")
+	assert.False(t, ok)
+	assert.True(t, cache.unsupported)
+}
+
+// blockingLotSizeProvider waits for its caller's context on "SLOW" lookups.
+type blockingLotSizeProvider struct {
+	started chan struct{}
+}
+
+func (p *blockingLotSizeProvider) GetLotSize(ctx context.Context, symbol string) (float64, error) {
+	if symbol == "SLOW" {
+		close(p.started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+	return 0.01, nil
+}
+
+// TestLotSizeCache_LookupOutsideLock verifies a slow lookup neither holds
+// up other symbols nor outlives its caller's context.
+func TestLotSizeCache_LookupOutsideLock(t *testing.T) {
+	provider := &blockingLotSizeProvider{started: make(chan struct{})}
+	cache := NewLotSizeCache(provider, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan bool)
+	go func() {
+		_, ok := cache.LotSize(ctx, "SLOW")
+		done <- ok
+	}()
+	<-provider.started
+
+	step, ok := cache.LotSize(context.Background(), "ETH-USD")
+	assert.True(t, ok)
+	assert.Equal(t, 0.01, step)
+
+	cancel()
+	assert.False(t, <-done)
+}
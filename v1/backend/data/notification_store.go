@@ -11,11 +11,22 @@ import (
 type NotificationStore interface {
 	SaveNotification(n models.Notification) error
 	GetNotifications(limit, offset int) ([]models.Notification, error)
+	QueryNotifications(filter NotificationFilter) ([]models.Notification, int, error)
+	CountUnread() (int, error)
+	UpdateMetadata(id string, metadata map[string]interface{}) error
 	MarkAsRead(id string) error
 	MarkAllAsRead() error
 	DeleteOlderThan(d time.Duration) error
 }
 
+// NotificationFilter narrows a notification query. Zero values match everything.
+type NotificationFilter struct {
+	Type   models.NotificationType
+	IsRead *bool // nil = read and unread
+	Limit  int   // 0 = no limit
+	Offset int
+}
+
 // SQLNotificationStore implements NotificationStore using SQLite.
 type SQLNotificationStore struct {
 	db *DB
@@ -76,6 +87,71 @@ func (s *SQLNotificationStore) GetNotifications(limit, offset int) ([]models.Not
 	return notifications, nil
 }
 
+// QueryNotifications returns notifications matching the filter, newest first.
+//
+// Args:
+//   - filter: Type, read status and pagination
+//
+// Returns:
+//   - []models.Notification: The requested page of notifications
+//   - int: Total count of matching notifications (before pagination)
+//   - error: Any error encountered
+func (s *SQLNotificationStore) QueryNotifications(filter NotificationFilter) ([]models.Notification, int, error) {
+	where := " WHERE 1=1"
+	var args []interface{}
+	if filter.Type != "" {
+		where += " AND type = ?"
+		args = append(args, filter.Type)
+	}
+	if filter.IsRead != nil {
+		where += " AND is_read = ?"
+		args = append(args, *filter.IsRead)
+	}
+
+	var total int
+	if err := s.db.Get(&total, "SELECT COUNT(*) FROM notifications"+where, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = -1 // SQLite: no limit
+	}
+	query := `SELECT id, type, title, message, created_at, is_read, metadata FROM notifications` +
+		where + ` ORDER BY created_at DESC LIMIT ? OFFSET ?`
+
+	notifications := []models.Notification{}
+	if err := s.db.Select(&notifications, query, append(args, limit, filter.Offset)...); err != nil {
+		return nil, 0, fmt.Errorf("failed to query notifications: %w", err)
+	}
+	for i := range notifications {
+		_ = notifications[i].PostLoad()
+	}
+
+	return notifications, total, nil
+}
+
+// CountUnread returns the number of unread notifications.
+func (s *SQLNotificationStore) CountUnread() (int, error) {
+	var count int
+	if err := s.db.Get(&count, `SELECT COUNT(*) FROM notifications WHERE is_read = FALSE`); err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// UpdateMetadata replaces a notification's metadata.
+func (s *SQLNotificationStore) UpdateMetadata(id string, metadata map[string]interface{}) error {
+	n := models.Notification{Metadata: metadata}
+	if err := n.PrepareForSave(); err != nil {
+		return fmt.Errorf("metadata serialization failed: %w", err)
+	}
+	if _, err := s.db.Exec(`UPDATE notifications SET metadata = ? WHERE id = ?`, n.MetadataJSON, id); err != nil {
+		return fmt.Errorf("failed to update notification metadata: %w", err)
+	}
+	return nil
+}
+
 // MarkAsRead marks a single notification as read.
 func (s *SQLNotificationStore) MarkAsRead(id string) error {
 	query := `UPDATE notifications SET is_read = TRUE WHERE id = ?`
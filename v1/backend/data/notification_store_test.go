@@ -0,0 +1,74 @@
+package data
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNotificationStore_QueryNotifications verifies filtering, pagination and counts.
+func TestNotificationStore_QueryNotifications(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	store := NewNotificationStore(db)
+
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	seed := []models.Notification{
+		{ID: "n1", Type: models.NotificationInfo, Title: "one", CreatedAt: base},
+		{ID: "n2", Type: models.NotificationError, Title: "two", CreatedAt: base.Add(time.Minute)},
+		{ID: "n3", Type: models.NotificationError, Title: "three", CreatedAt: base.Add(2 * time.Minute), IsRead: true},
+		{ID: "n4", Type: models.NotificationTrade, Title: "four", CreatedAt: base.Add(3 * time.Minute)},
+	}
+	for _, n := range seed {
+		require.NoError(t, store.SaveNotification(n))
+	}
+
+	t.Run("All", func(t *testing.T) {
+		notifs, total, err := store.QueryNotifications(NotificationFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, 4, total)
+		require.Len(t, notifs, 4)
+		assert.Equal(t, "n4", notifs[0].ID, "newest first")
+	})
+
+	t.Run("ByType", func(t *testing.T) {
+		notifs, total, err := store.QueryNotifications(NotificationFilter{Type: models.NotificationError})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		require.Len(t, notifs, 2)
+		assert.Equal(t, "n3", notifs[0].ID)
+	})
+
+	t.Run("ByReadStatus", func(t *testing.T) {
+		unread := false
+		notifs, total, err := store.QueryNotifications(NotificationFilter{IsRead: &unread})
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		for _, n := range notifs {
+			assert.False(t, n.IsRead)
+		}
+	})
+
+	t.Run("Paginated", func(t *testing.T) {
+		notifs, total, err := store.QueryNotifications(NotificationFilter{Limit: 3, Offset: 3})
+		require.NoError(t, err)
+		assert.Equal(t, 4, total)
+		require.Len(t, notifs, 1)
+		assert.Equal(t, "n1", notifs[0].ID)
+	})
+
+	count, err := store.CountUnread()
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	require.NoError(t, store.MarkAllAsRead())
+	count, err = store.CountUnread()
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
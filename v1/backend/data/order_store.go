@@ -2,7 +2,9 @@
 package data
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/alexherrero/sherwood/backend/models"
 )
@@ -82,11 +84,86 @@ type OrderStore interface {
 	//   - error: Any error encountered during save
 	SaveTrade(trade models.Trade) error
 
+	// GetTradesByOrder retrieves the fills recorded for an order.
+	//
+	// Args:
+	//   - orderID: ID of the order
+	//
+	// Returns:
+	//   - []models.Trade: The order's trades, oldest first (empty if none)
+	//   - error: Any error encountered
+	GetTradesByOrder(orderID string) ([]models.Trade, error)
+
+	// GetAllTrades retrieves every recorded fill.
+	//
+	// Returns:
+	//   - []models.Trade: All trades, oldest first (empty if none)
+	//   - error: Any error encountered
+	GetAllTrades() ([]models.Trade, error)
+
 	// GetSystemConfig retrieves a system configuration value.
 	GetSystemConfig(key string) (string, error)
 
 	// SetSystemConfig sets a system configuration value.
 	SetSystemConfig(key, value string) error
+
+	// SaveEquitySnapshot records the account's equity at a point in time.
+	//
+	// Args:
+	//   - snapshot: The snapshot to record
+	//
+	// Returns:
+	//   - error: Any error encountered during save
+	SaveEquitySnapshot(snapshot models.EquitySnapshot) error
+
+	// GetEquitySnapshots retrieves snapshots taken at or after a time, oldest first.
+	//
+	// Args:
+	//   - since: Earliest snapshot time to include
+	//
+	// Returns:
+	//   - []models.EquitySnapshot: Matching snapshots
+	//   - error: Any error encountered
+	GetEquitySnapshots(since time.Time) ([]models.EquitySnapshot, error)
+
+	// SaveOrderNote records a journal note on an order.
+	//
+	// Args:
+	//   - note: The note to record; its ID is assigned by the store
+	//
+	// Returns:
+	//   - *models.OrderNote: The saved note with its ID
+	//   - error: Any error encountered during save
+	SaveOrderNote(note models.OrderNote) (*models.OrderNote, error)
+
+	// GetOrderNotes retrieves the notes on an order.
+	//
+	// Args:
+	//   - orderID: ID of the order
+	//
+	// Returns:
+	//   - []models.OrderNote: The order's notes, oldest first (empty if none)
+	//   - error: Any error encountered
+	GetOrderNotes(orderID string) ([]models.OrderNote, error)
+
+	// SaveOrderEvent records a state change of an order.
+	//
+	// Args:
+	//   - event: The event to record; its ID is assigned by the store
+	//
+	// Returns:
+	//   - error: Any error encountered during save
+	SaveOrderEvent(event models.OrderEvent) error
+
+	// GetOrderEvents retrieves the state changes of an order.
+	//
+	// Args:
+	//   - orderID: ID of the order
+	//
+	// Returns:
+	//   - []models.OrderEvent: The order's events, oldest first (empty if none)
+	//   - error: Any error encountered
+	GetOrderEvents(orderID string) ([]models.OrderEvent, error)
 }
 
 // SQLOrderStore implements OrderStore using SQLite.
@@ -105,11 +182,22 @@ func NewOrderStore(db *DB) *SQLOrderStore {
 	return &SQLOrderStore{db: db}
 }
 
+// Ping verifies the database connection is usable.
+//
+// Args:
+//   - ctx: Context bounding the check
+//
+// Returns:
+//   - error: If the database cannot be reached
+func (s *SQLOrderStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
 // SaveOrder persists an order to the database.
 func (s *SQLOrderStore) SaveOrder(order models.Order) error {
 	query := `
-		INSERT OR REPLACE INTO orders (id, symbol, side, type, quantity, price, status, filled_quantity, average_price, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT OR REPLACE INTO orders (id, symbol, side, type, quantity, price, status, filled_quantity, average_price, created_at, updated_at, strategy_name, tags, parent_id, linked_order_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	_, err := s.db.Exec(query,
 		order.ID,
@@ -123,6 +211,10 @@ func (s *SQLOrderStore) SaveOrder(order models.Order) error {
 		order.AveragePrice,
 		order.CreatedAt,
 		order.UpdatedAt,
+		order.StrategyName,
+		order.Tags,
+		order.ParentID,
+		order.LinkedOrderID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to save order: %w", err)
@@ -134,7 +226,7 @@ func (s *SQLOrderStore) SaveOrder(order models.Order) error {
 func (s *SQLOrderStore) GetOrder(orderID string) (*models.Order, error) {
 	var order models.Order
 	query := `
-		SELECT id, symbol, side, type, quantity, price, status, filled_quantity, average_price, created_at, updated_at
+		SELECT id, symbol, side, type, quantity, price, status, filled_quantity, average_price, created_at, updated_at, strategy_name, tags, parent_id, linked_order_id
 		FROM orders
 		WHERE id = ?
 	`
@@ -149,9 +241,9 @@ func (s *SQLOrderStore) GetOrder(orderID string) (*models.Order, error) {
 func (s *SQLOrderStore) GetAllOrders() ([]models.Order, error) {
 	var orders []models.Order
 	query := `
-		SELECT id, symbol, side, type, quantity, price, status, filled_quantity, average_price, created_at, updated_at
+		SELECT id, symbol, side, type, quantity, price, status, filled_quantity, average_price, created_at, updated_at, strategy_name, tags, parent_id, linked_order_id
 		FROM orders
-		ORDER BY created_at DESC
+		ORDER BY created_at DESC, id DESC
 	`
 	err := s.db.Select(&orders, query)
 	if err != nil {
@@ -160,6 +252,32 @@ func (s *SQLOrderStore) GetAllOrders() ([]models.Order, error) {
 	return orders, nil
 }
 
+// ClearTradingHistory deletes every persisted order, trade, position, equity
+// snapshot, order note and order event in one transaction. It exists for resetting a paper
+// account and must never be called against a live account.
+//
+// Returns:
+//   - error: Any error encountered; nothing is deleted on failure
+func (s *SQLOrderStore) ClearTradingHistory() error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin clear: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Trades, notes and events reference orders, so they go first
+	for _, table := range []string{"trades", "notes", "order_events", "orders", "positions", "equity_snapshots"} {
+		if _, err := tx.Exec("DELETE FROM " + table); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", table, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit clear: %w", err)
+	}
+	return nil
+}
+
 // DeleteOrder removes an order from the database.
 func (s *SQLOrderStore) DeleteOrder(orderID string) error {
 	query := `DELETE FROM orders WHERE id = ?`
@@ -221,8 +339,8 @@ func (s *SQLOrderStore) GetAllPositions() ([]models.Position, error) {
 // SaveTrade records a trade execution.
 func (s *SQLOrderStore) SaveTrade(trade models.Trade) error {
 	query := `
-		INSERT OR REPLACE INTO trades (id, order_id, symbol, side, quantity, price, executed_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT OR REPLACE INTO trades (id, order_id, symbol, side, quantity, price, commission, executed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	_, err := s.db.Exec(query,
 		trade.ID,
@@ -231,6 +349,7 @@ func (s *SQLOrderStore) SaveTrade(trade models.Trade) error {
 		trade.Side,
 		trade.Quantity,
 		trade.Price,
+		trade.Commission,
 		trade.ExecutedAt,
 	)
 	if err != nil {
@@ -239,6 +358,35 @@ func (s *SQLOrderStore) SaveTrade(trade models.Trade) error {
 	return nil
 }
 
+// GetTradesByOrder retrieves the fills recorded for an order, oldest first.
+func (s *SQLOrderStore) GetTradesByOrder(orderID string) ([]models.Trade, error) {
+	trades := []models.Trade{}
+	query := `
+		SELECT id, order_id, symbol, side, quantity, price, commission, executed_at
+		FROM trades
+		WHERE order_id = ?
+		ORDER BY executed_at ASC, id ASC
+	`
+	if err := s.db.Select(&trades, query, orderID); err != nil {
+		return nil, fmt.Errorf("failed to get trades for order %s: %w", orderID, err)
+	}
+	return trades, nil
+}
+
+// GetAllTrades retrieves every recorded fill, oldest first.
+func (s *SQLOrderStore) GetAllTrades() ([]models.Trade, error) {
+	trades := []models.Trade{}
+	query := `
+		SELECT id, order_id, symbol, side, quantity, price, commission, executed_at
+		FROM trades
+		ORDER BY executed_at ASC, id ASC
+	`
+	if err := s.db.Select(&trades, query); err != nil {
+		return nil, fmt.Errorf("failed to get trades: %w", err)
+	}
+	return trades, nil
+}
+
 // GetSystemConfig retrieves a system configuration value.
 func (s *SQLOrderStore) GetSystemConfig(key string) (string, error) {
 	var value string
@@ -259,3 +407,100 @@ func (s *SQLOrderStore) SetSystemConfig(key, value string) error {
 	}
 	return nil
 }
+
+// SaveEquitySnapshot records the account's equity at a point in time.
+func (s *SQLOrderStore) SaveEquitySnapshot(snapshot models.EquitySnapshot) error {
+	query := `
+		INSERT INTO equity_snapshots (timestamp, cash, equity, portfolio_value)
+		VALUES (?, ?, ?, ?)
+	`
+	// Stored in UTC so timestamps compare correctly as text
+	_, err := s.db.Exec(query,
+		snapshot.Timestamp.UTC(),
+		snapshot.Cash,
+		snapshot.Equity,
+		snapshot.PortfolioValue,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save equity snapshot: %w", err)
+	}
+	return nil
+}
+
+// GetEquitySnapshots retrieves snapshots taken at or after since, oldest first.
+func (s *SQLOrderStore) GetEquitySnapshots(since time.Time) ([]models.EquitySnapshot, error) {
+	var snapshots []models.EquitySnapshot
+	query := `
+		SELECT timestamp, cash, equity, portfolio_value
+		FROM equity_snapshots
+		WHERE timestamp >= ?
+		ORDER BY timestamp ASC
+	`
+	err := s.db.Select(&snapshots, query, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get equity snapshots: %w", err)
+	}
+	return snapshots, nil
+}
+
+// SaveOrderNote records a journal note on an order.
+func (s *SQLOrderStore) SaveOrderNote(note models.OrderNote) (*models.OrderNote, error) {
+	query := `
+		INSERT INTO notes (order_id, text, created_at)
+		VALUES (?, ?, ?)
+	`
+	// Stored in UTC so timestamps compare correctly as text
+	note.CreatedAt = note.CreatedAt.UTC()
+	result, err := s.db.Exec(query, note.OrderID, note.Text, note.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save order note: %w", err)
+	}
+	if note.ID, err = result.LastInsertId(); err != nil {
+		return nil, fmt.Errorf("failed to read order note id: %w", err)
+	}
+	return &note, nil
+}
+
+// GetOrderNotes retrieves the notes on an order, oldest first.
+func (s *SQLOrderStore) GetOrderNotes(orderID string) ([]models.OrderNote, error) {
+	notes := []models.OrderNote{}
+	query := `
+		SELECT id, order_id, text, created_at
+		FROM notes
+		WHERE order_id = ?
+		ORDER BY created_at ASC, id ASC
+	`
+	if err := s.db.Select(&notes, query, orderID); err != nil {
+		return nil, fmt.Errorf("failed to get order notes: %w", err)
+	}
+	return notes, nil
+}
+
+// SaveOrderEvent records a state change of an order.
+func (s *SQLOrderStore) SaveOrderEvent(event models.OrderEvent) error {
+	query := `
+		INSERT INTO order_events (order_id, action, from_status, to_status, quantity, price, actor, trace_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := s.db.Exec(query, event.OrderID, event.Action, event.FromStatus, event.ToStatus,
+		event.Quantity, event.Price, event.Actor, event.TraceID, event.CreatedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to save order event: %w", err)
+	}
+	return nil
+}
+
+// GetOrderEvents retrieves the state changes of an order, oldest first.
+func (s *SQLOrderStore) GetOrderEvents(orderID string) ([]models.OrderEvent, error) {
+	events := []models.OrderEvent{}
+	query := `
+		SELECT id, order_id, action, from_status, to_status, quantity, price, actor, trace_id, created_at
+		FROM order_events
+		WHERE order_id = ?
+		ORDER BY created_at ASC, id ASC
+	`
+	if err := s.db.Select(&events, query, orderID); err != nil {
+		return nil, fmt.Errorf("failed to get order events: %w", err)
+	}
+	return events, nil
+}
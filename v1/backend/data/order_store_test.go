@@ -33,6 +33,8 @@ func TestOrderStore_SaveOrder(t *testing.T) {
 		AveragePrice:   50100.0,
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
+		StrategyName:   "ma_crossover",
+		Tags:           models.Tags{"swing", "crypto"},
 	}
 
 	err = store.SaveOrder(order)
@@ -45,6 +47,8 @@ func TestOrderStore_SaveOrder(t *testing.T) {
 	assert.Equal(t, order.Symbol, retrieved.Symbol)
 	assert.Equal(t, order.Side, retrieved.Side)
 	assert.Equal(t, order.Quantity, retrieved.Quantity)
+	assert.Equal(t, "ma_crossover", retrieved.StrategyName)
+	assert.Equal(t, models.Tags{"swing", "crypto"}, retrieved.Tags)
 }
 
 // TestOrderStore_SaveOrder_Update verifies upsert behavior.
@@ -321,6 +325,65 @@ func TestOrderStore_SaveTrade(t *testing.T) {
 	assert.Equal(t, 1, count)
 }
 
+// TestOrderStore_GetTradesByOrder verifies trades are returned per order, oldest first.
+func TestOrderStore_GetTradesByOrder(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	store := NewOrderStore(db)
+	now := time.Now().UTC().Truncate(time.Second)
+	trades := []models.Trade{
+		{ID: "t2", OrderID: "order-1", Symbol: "AAPL", Side: models.OrderSideBuy, Quantity: 4, Price: 151, ExecutedAt: now.Add(time.Minute)},
+		{ID: "t1", OrderID: "order-1", Symbol: "AAPL", Side: models.OrderSideBuy, Quantity: 6, Price: 150, ExecutedAt: now},
+		{ID: "t3", OrderID: "order-2", Symbol: "MSFT", Side: models.OrderSideSell, Quantity: 1, Price: 400, ExecutedAt: now},
+	}
+	for _, trade := range trades {
+		require.NoError(t, store.SaveTrade(trade))
+	}
+
+	retrieved, err := store.GetTradesByOrder("order-1")
+	require.NoError(t, err)
+	require.Len(t, retrieved, 2)
+	assert.Equal(t, "t1", retrieved[0].ID)
+	assert.Equal(t, "t2", retrieved[1].ID)
+	assert.Equal(t, 151.0, retrieved[1].Price)
+
+	retrieved, err = store.GetTradesByOrder("order-3")
+	require.NoError(t, err)
+	assert.NotNil(t, retrieved)
+	assert.Empty(t, retrieved)
+}
+
+// TestOrderStore_GetAllTrades verifies every trade is returned oldest first,
+// with its commission.
+func TestOrderStore_GetAllTrades(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	store := NewOrderStore(db)
+	retrieved, err := store.GetAllTrades()
+	require.NoError(t, err)
+	assert.NotNil(t, retrieved)
+	assert.Empty(t, retrieved)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, store.SaveTrade(models.Trade{
+		ID: "t2", OrderID: "order-2", Symbol: "AAPL", Side: models.OrderSideSell, Quantity: 5, Price: 160, Commission: 1.5, ExecutedAt: now.Add(time.Hour),
+	}))
+	require.NoError(t, store.SaveTrade(models.Trade{
+		ID: "t1", OrderID: "order-1", Symbol: "AAPL", Side: models.OrderSideBuy, Quantity: 5, Price: 150, Commission: 1, ExecutedAt: now,
+	}))
+
+	retrieved, err = store.GetAllTrades()
+	require.NoError(t, err)
+	require.Len(t, retrieved, 2)
+	assert.Equal(t, "t1", retrieved[0].ID)
+	assert.Equal(t, 1.0, retrieved[0].Commission)
+	assert.Equal(t, 1.5, retrieved[1].Commission)
+}
+
 // TestOrderStore_EmptyDatabase verifies empty query results.
 func TestOrderStore_EmptyDatabase(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -342,3 +405,114 @@ func TestOrderStore_EmptyDatabase(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, positions)
 }
+
+// TestOrderStore_EquitySnapshots verifies snapshots are stored and filtered by time.
+func TestOrderStore_EquitySnapshots(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	store := NewOrderStore(db)
+
+	base := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, store.SaveEquitySnapshot(models.EquitySnapshot{
+			Timestamp:      base.Add(time.Duration(i) * time.Hour),
+			Cash:           1000,
+			Equity:         1000 + float64(i),
+			PortfolioValue: 1000 + float64(i),
+		}))
+	}
+
+	all, err := store.GetEquitySnapshots(time.Time{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, 1000.0, all[0].Equity)
+	assert.True(t, all[0].Timestamp.Equal(base))
+
+	// A non-UTC cutoff is compared correctly
+	ny := time.FixedZone("EST", -5*60*60)
+	recent, err := store.GetEquitySnapshots(base.Add(time.Hour).In(ny))
+	require.NoError(t, err)
+	require.Len(t, recent, 2)
+	assert.Equal(t, 1001.0, recent[0].Equity)
+}
+
+// TestOrderStore_OrderNotes verifies notes are stored per order, oldest
+// first, and cleared with the trading history.
+func TestOrderStore_OrderNotes(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	store := NewOrderStore(db)
+
+	base := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	first, err := store.SaveOrderNote(models.OrderNote{OrderID: "order-1", Text: "entry on breakout", CreatedAt: base})
+	require.NoError(t, err)
+	assert.NotZero(t, first.ID)
+	_, err = store.SaveOrderNote(models.OrderNote{OrderID: "order-1", Text: "took profit early", CreatedAt: base.Add(time.Hour)})
+	require.NoError(t, err)
+	_, err = store.SaveOrderNote(models.OrderNote{OrderID: "order-2", Text: "other order", CreatedAt: base})
+	require.NoError(t, err)
+
+	notes, err := store.GetOrderNotes("order-1")
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	assert.Equal(t, "entry on breakout", notes[0].Text)
+	assert.Equal(t, "took profit early", notes[1].Text)
+	assert.True(t, notes[0].CreatedAt.Equal(base))
+
+	notes, err = store.GetOrderNotes("order-3")
+	require.NoError(t, err)
+	assert.Empty(t, notes)
+
+	require.NoError(t, store.ClearTradingHistory())
+	notes, err = store.GetOrderNotes("order-1")
+	require.NoError(t, err)
+	assert.Empty(t, notes)
+}
+
+// TestOrderStore_OrderEvents verifies events are stored per order, oldest
+// first, and cleared with the trading history.
+func TestOrderStore_OrderEvents(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	store := NewOrderStore(db)
+
+	base := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	require.NoError(t, store.SaveOrderEvent(models.OrderEvent{
+		OrderID: "order-1", Action: models.OrderActionSubmit,
+		FromStatus: models.OrderStatusPending, ToStatus: models.OrderStatusSubmitted,
+		Quantity: 10, Price: 150, Actor: "key-1", TraceID: "trace-1", CreatedAt: base,
+	}))
+	require.NoError(t, store.SaveOrderEvent(models.OrderEvent{
+		OrderID: "order-1", Action: models.OrderActionCancel,
+		FromStatus: models.OrderStatusSubmitted, ToStatus: models.OrderStatusCancelled,
+		Quantity: 10, Price: 150, Actor: "system", CreatedAt: base.Add(time.Minute),
+	}))
+	require.NoError(t, store.SaveOrderEvent(models.OrderEvent{
+		OrderID: "order-2", Action: models.OrderActionSubmit, ToStatus: models.OrderStatusFilled,
+		Quantity: 1, Actor: "key-1", CreatedAt: base,
+	}))
+
+	events, err := store.GetOrderEvents("order-1")
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.NotZero(t, events[0].ID)
+	assert.Equal(t, models.OrderActionSubmit, events[0].Action)
+	assert.Equal(t, models.OrderStatusPending, events[0].FromStatus)
+	assert.Equal(t, "key-1", events[0].Actor)
+	assert.Equal(t, "trace-1", events[0].TraceID)
+	assert.True(t, events[0].CreatedAt.Equal(base))
+	assert.Equal(t, models.OrderStatusCancelled, events[1].ToStatus)
+
+	events, err = store.GetOrderEvents("order-3")
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	require.NoError(t, store.ClearTradingHistory())
+	events, err = store.GetOrderEvents("order-1")
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
@@ -2,6 +2,7 @@
 package data
 
 import (
+	"context"
 	"time"
 
 	"github.com/alexherrero/sherwood/backend/models"
@@ -73,3 +74,127 @@ type StreamingProvider interface {
 	//   - error: Any error encountered
 	Unsubscribe(symbol string) error
 }
+
+// IntervalProvider is implemented by providers that can report which
+// candle intervals they support.
+type IntervalProvider interface {
+	// SupportsInterval reports whether the provider can serve the interval.
+	//
+	// Args:
+	//   - interval: Time interval (e.g., "1d", "1h", "5m")
+	//
+	// Returns:
+	//   - bool: True if the interval is supported
+	SupportsInterval(interval string) bool
+}
+
+// TickAdvancer is implemented by providers whose data moves forward with the
+// trading engine rather than the clock, such as replayed recordings.
+type TickAdvancer interface {
+	// AdvanceTick moves the provider on by one engine tick.
+	AdvanceTick()
+}
+
+// ContextProvider is implemented by providers whose requests can be bounded
+// by a context, so a slow upstream call is abandoned when the caller gives up.
+type ContextProvider interface {
+	// GetHistoricalDataContext is GetHistoricalData bound to ctx.
+	GetHistoricalDataContext(ctx context.Context, symbol string, start, end time.Time, interval string) ([]models.OHLCV, error)
+
+	// GetLatestPriceContext is GetLatestPrice bound to ctx.
+	GetLatestPriceContext(ctx context.Context, symbol string) (float64, error)
+
+	// GetTickerContext is GetTicker bound to ctx.
+	GetTickerContext(ctx context.Context, symbol string) (*models.Ticker, error)
+}
+
+// GetHistoricalDataContext fetches historical data from provider, returning
+// early with ctx.Err() once ctx is done. Providers implementing
+// ContextProvider cancel the underlying request; others are left to finish
+// in the background.
+//
+// Args:
+//   - ctx: Context bounding the request
+//   - provider: Data provider to query
+//   - symbol: Ticker symbol
+//   - start: Start of the date range
+//   - end: End of the date range
+//   - interval: Time interval (e.g., "1d", "1h", "5m")
+//
+// Returns:
+//   - []models.OHLCV: Historical price data
+//   - error: Any error encountered, or ctx.Err() on cancellation
+func GetHistoricalDataContext(ctx context.Context, provider DataProvider, symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	if cp, ok := provider.(ContextProvider); ok {
+		return cp.GetHistoricalDataContext(ctx, symbol, start, end, interval)
+	}
+	return callWithContext(ctx, func() ([]models.OHLCV, error) {
+		return provider.GetHistoricalData(symbol, start, end, interval)
+	})
+}
+
+// GetLatestPriceContext fetches the latest price from provider, returning
+// early with ctx.Err() once ctx is done.
+//
+// Args:
+//   - ctx: Context bounding the request
+//   - provider: Data provider to query
+//   - symbol: Ticker symbol
+//
+// Returns:
+//   - float64: Current price
+//   - error: Any error encountered, or ctx.Err() on cancellation
+func GetLatestPriceContext(ctx context.Context, provider DataProvider, symbol string) (float64, error) {
+	if cp, ok := provider.(ContextProvider); ok {
+		return cp.GetLatestPriceContext(ctx, symbol)
+	}
+	return callWithContext(ctx, func() (float64, error) {
+		return provider.GetLatestPrice(symbol)
+	})
+}
+
+// GetTickerContext fetches ticker information from provider, returning early
+// with ctx.Err() once ctx is done.
+//
+// Args:
+//   - ctx: Context bounding the request
+//   - provider: Data provider to query
+//   - symbol: Ticker symbol
+//
+// Returns:
+//   - *models.Ticker: Ticker information
+//   - error: Any error encountered, or ctx.Err() on cancellation
+func GetTickerContext(ctx context.Context, provider DataProvider, symbol string) (*models.Ticker, error) {
+	if cp, ok := provider.(ContextProvider); ok {
+		return cp.GetTickerContext(ctx, symbol)
+	}
+	return callWithContext(ctx, func() (*models.Ticker, error) {
+		return provider.GetTicker(symbol)
+	})
+}
+
+// callWithContext runs fn in a goroutine and returns its result, or
+// ctx.Err() if ctx is done first.
+func callWithContext[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		value, err := fn()
+		done <- result{value, err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.value, res.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
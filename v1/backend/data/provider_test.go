@@ -0,0 +1,95 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingProvider never answers until released.
+type blockingProvider struct {
+	mockDataProvider
+	release chan struct{}
+}
+
+func (p *blockingProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	<-p.release
+	return p.mockDataProvider.GetHistoricalData(symbol, start, end, interval)
+}
+
+// contextProvider records the context it was called with.
+type contextProvider struct {
+	mockDataProvider
+	ctx context.Context
+}
+
+func (p *contextProvider) GetHistoricalDataContext(ctx context.Context, symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	p.ctx = ctx
+	return p.mockDataProvider.GetHistoricalData(symbol, start, end, interval)
+}
+
+func (p *contextProvider) GetLatestPriceContext(ctx context.Context, symbol string) (float64, error) {
+	p.ctx = ctx
+	return p.mockDataProvider.GetLatestPrice(symbol)
+}
+
+func (p *contextProvider) GetTickerContext(ctx context.Context, symbol string) (*models.Ticker, error) {
+	p.ctx = ctx
+	return p.mockDataProvider.GetTicker(symbol)
+}
+
+// TestGetHistoricalDataContext_Cancelled verifies a provider without context
+// support is abandoned once the context is done.
+func TestGetHistoricalDataContext_Cancelled(t *testing.T) {
+	provider := &blockingProvider{release: make(chan struct{})}
+	defer close(provider.release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := GetHistoricalDataContext(ctx, provider, "AAPL", time.Now().Add(-time.Hour), time.Now(), "1d")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// TestGetLatestPriceContext_Fallback verifies providers without context
+// support are called normally while the context is live.
+func TestGetLatestPriceContext_Fallback(t *testing.T) {
+	provider := &mockDataProvider{}
+
+	price, err := GetLatestPriceContext(context.Background(), provider, "AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, 150.0, price)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = GetTickerContext(ctx, provider, "AAPL")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, provider.tickerCallCount)
+}
+
+// TestContextProvider_ThroughWrappers verifies the caller's context reaches
+// a ContextProvider through the normalizing, gap-checking and caching wrappers.
+func TestContextProvider_ThroughWrappers(t *testing.T) {
+	inner := &contextProvider{}
+	provider := NewCachedDataProvider(
+		NewGapCheckedProvider(NewNormalizedProvider(inner, YahooSymbols{}), GapPolicyLog),
+		NewMemoryCache(), time.Minute)
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "tick")
+
+	_, err := GetHistoricalDataContext(ctx, provider, "AAPL", time.Now().Add(-time.Hour), time.Now(), "1d")
+	require.NoError(t, err)
+	require.NotNil(t, inner.ctx)
+	assert.Equal(t, "tick", inner.ctx.Value(ctxKey{}))
+
+	inner.ctx = nil
+	_, err = GetLatestPriceContext(ctx, provider, "AAPL")
+	require.NoError(t, err)
+	require.NotNil(t, inner.ctx)
+	assert.Equal(t, "tick", inner.ctx.Value(ctxKey{}))
+}
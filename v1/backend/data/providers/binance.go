@@ -3,21 +3,23 @@ package providers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 
 	binance "github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/common"
 
+	"github.com/alexherrero/sherwood/backend/data"
 	"github.com/alexherrero/sherwood/backend/models"
 )
 
 // BinanceAPI defines the interface for Binance API calls.
 type BinanceAPI interface {
-	GetKlines(symbol, interval string, start, end int64, limit int) ([]*binance.Kline, error)
-	GetPrices(symbol string) ([]*binance.SymbolPrice, error)
-	GetExchangeInfo(symbol string) (*binance.ExchangeInfo, error)
+	GetKlines(ctx context.Context, symbol, interval string, start, end int64, limit int) ([]*binance.Kline, error)
+	GetPrices(ctx context.Context, symbol string) ([]*binance.SymbolPrice, error)
+	GetExchangeInfo(ctx context.Context, symbol string) (*binance.ExchangeInfo, error)
 }
 
 // defaultBinanceAPI implements BinanceAPI using the official library.
@@ -25,7 +27,7 @@ type defaultBinanceAPI struct {
 	client *binance.Client
 }
 
-func (api *defaultBinanceAPI) GetKlines(symbol, interval string, start, end int64, limit int) ([]*binance.Kline, error) {
+func (api *defaultBinanceAPI) GetKlines(ctx context.Context, symbol, interval string, start, end int64, limit int) ([]*binance.Kline, error) {
 	service := api.client.NewKlinesService().
 		Symbol(symbol).
 		Interval(interval).
@@ -38,19 +40,19 @@ func (api *defaultBinanceAPI) GetKlines(symbol, interval string, start, end int6
 		service = service.EndTime(end)
 	}
 
-	return service.Do(context.Background())
+	return service.Do(ctx)
 }
 
-func (api *defaultBinanceAPI) GetPrices(symbol string) ([]*binance.SymbolPrice, error) {
+func (api *defaultBinanceAPI) GetPrices(ctx context.Context, symbol string) ([]*binance.SymbolPrice, error) {
 	return api.client.NewListPricesService().
 		Symbol(symbol).
-		Do(context.Background())
+		Do(ctx)
 }
 
-func (api *defaultBinanceAPI) GetExchangeInfo(symbol string) (*binance.ExchangeInfo, error) {
+func (api *defaultBinanceAPI) GetExchangeInfo(ctx context.Context, symbol string) (*binance.ExchangeInfo, error) {
 	return api.client.NewExchangeInfoService().
 		Symbol(symbol).
-		Do(context.Background())
+		Do(ctx)
 }
 
 // BinanceProvider fetches cryptocurrency data from Binance exchange.
@@ -58,6 +60,7 @@ func (api *defaultBinanceAPI) GetExchangeInfo(symbol string) (*binance.ExchangeI
 // Supports both Binance.com (international) and Binance.US (for US users).
 type BinanceProvider struct {
 	api         BinanceAPI
+	timeout     time.Duration
 	rateLimiter time.Time
 	minInterval time.Duration
 	useUS       bool
@@ -75,6 +78,7 @@ func NewBinanceProvider(apiKey, apiSecret string) *BinanceProvider {
 	client := binance.NewClient(apiKey, apiSecret)
 	return &BinanceProvider{
 		api:         &defaultBinanceAPI{client: client},
+		timeout:     DefaultRequestTimeout,
 		rateLimiter: time.Time{},
 		minInterval: 100 * time.Millisecond, // ~10 requests/second max
 		useUS:       false,
@@ -95,6 +99,7 @@ func NewBinanceUSProvider(apiKey, apiSecret string) *BinanceProvider {
 	client.BaseURL = "https://api.binance.us"
 	return &BinanceProvider{
 		api:         &defaultBinanceAPI{client: client},
+		timeout:     DefaultRequestTimeout,
 		rateLimiter: time.Time{},
 		minInterval: 100 * time.Millisecond,
 		useUS:       true,
@@ -106,6 +111,18 @@ func (p *BinanceProvider) Name() string {
 	return "binance"
 }
 
+// SupportsInterval reports whether Binance can serve the interval.
+func (p *BinanceProvider) SupportsInterval(interval string) bool {
+	_, err := mapBinanceInterval(interval)
+	return err == nil
+}
+
+// SetTimeout sets the per-request timeout. Zero disables it, leaving requests
+// bounded only by the caller's context.
+func (p *BinanceProvider) SetTimeout(timeout time.Duration) {
+	p.timeout = timeout
+}
+
 // rateLimit ensures we don't exceed API rate limits.
 func (p *BinanceProvider) rateLimit() {
 	if !p.rateLimiter.IsZero() {
@@ -117,6 +134,45 @@ func (p *BinanceProvider) rateLimit() {
 	p.rateLimiter = time.Now()
 }
 
+// GetLotSize returns the quantity step from the symbol's LOT_SIZE filter.
+//
+// Args:
+//   - ctx: Context bounding the request
+//   - symbol: Trading pair
+//
+// Returns:
+//   - float64: Quantity step (e.g., 0.00001 for BTCUSDT)
+//   - error: Any error encountered
+func (p *BinanceProvider) GetLotSize(ctx context.Context, symbol string) (float64, error) {
+	p.rateLimit()
+
+	binanceSymbol := convertSymbol(symbol)
+
+	ctx, cancel := withRequestTimeout(ctx, p.timeout)
+	defer cancel()
+	info, err := p.api.GetExchangeInfo(ctx, binanceSymbol)
+
+	if err != nil {
+		return 0, mapBinanceError(err, "failed to fetch exchange info for %s", binanceSymbol)
+	}
+
+	if len(info.Symbols) == 0 {
+		return 0, newProviderError("binance", KindNotFound, nil, "no symbol info returned for %s", symbol)
+	}
+
+	filter := info.Symbols[0].LotSizeFilter()
+	if filter == nil {
+		return 0, newProviderError("binance", KindNotFound, nil, "no LOT_SIZE filter for %s", symbol)
+	}
+
+	step, err := strconv.ParseFloat(filter.StepSize, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse lot size for %s: %w", symbol, err)
+	}
+
+	return step, nil
+}
+
 // convertSymbol converts standard trading pair format to Binance format.
 // e.g., "BTC/USD" -> "BTCUSDT", "ETH/BTC" -> "ETHBTC"
 //
@@ -126,15 +182,7 @@ func (p *BinanceProvider) rateLimit() {
 // Returns:
 //   - string: Binance-compatible symbol
 func convertSymbol(symbol string) string {
-	// Uppercase first to handle lowercase input
-	symbol = strings.ToUpper(symbol)
-	// Remove slash
-	symbol = strings.ReplaceAll(symbol, "/", "")
-	// Convert USD to USDT for Binance (but avoid USDTT)
-	if strings.HasSuffix(symbol, "USD") && !strings.HasSuffix(symbol, "USDT") {
-		symbol = symbol + "T"
-	}
-	return symbol
+	return data.BinanceSymbols{}.ToProvider(symbol)
 }
 
 // mapBinanceInterval converts standard interval strings to Binance interval format.
@@ -178,10 +226,40 @@ func mapBinanceInterval(interval string) (string, error) {
 	case "1M", "1mo":
 		return "1M", nil
 	default:
-		return "", fmt.Errorf("unsupported interval: %s", interval)
+		return "", newProviderError("binance", KindBadInput, nil, "unsupported interval: %s", interval)
 	}
 }
 
+// mapBinanceError classifies a Binance API error by its error code.
+// See https://developers.binance.com/docs/binance-spot-api-docs/errors.
+//
+// Args:
+//   - err: Error returned by the Binance client
+//   - format: Message format string
+//   - args: Format arguments
+//
+// Returns:
+//   - *ProviderError: The typed error
+func mapBinanceError(err error, format string, args ...interface{}) *ProviderError {
+	kind := KindUnavailable
+
+	var apiErr *common.APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.Code == -1121: // Invalid symbol
+			kind = KindNotFound
+		case apiErr.Code == -1003 || apiErr.Code == -1015: // Too many requests / orders
+			kind = KindRateLimited
+		case apiErr.Code == -1002 || apiErr.Code == -1022 || apiErr.Code == -2014 || apiErr.Code == -2015:
+			kind = KindUnauthorized
+		case apiErr.Code <= -1100 && apiErr.Code >= -1199: // Request parameter errors
+			kind = KindBadInput
+		}
+	}
+
+	return newProviderError("binance", kind, err, format, args...)
+}
+
 // GetHistoricalData fetches OHLCV data from Binance.
 // Supports pagination for large date ranges (max 1000 candles per request).
 //
@@ -195,6 +273,12 @@ func mapBinanceInterval(interval string) (string, error) {
 //   - []models.OHLCV: Historical data
 //   - error: Any error encountered
 func (p *BinanceProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	return p.GetHistoricalDataContext(context.Background(), symbol, start, end, interval)
+}
+
+// GetHistoricalDataContext fetches OHLCV data from Binance, bound to ctx.
+// The request timeout applies to each page separately.
+func (p *BinanceProvider) GetHistoricalDataContext(ctx context.Context, symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
 	binanceSymbol := convertSymbol(symbol)
 	binanceInterval, err := mapBinanceInterval(interval)
 	if err != nil {
@@ -208,10 +292,12 @@ func (p *BinanceProvider) GetHistoricalData(symbol string, start, end time.Time,
 	for currentStart.Before(end) {
 		p.rateLimit()
 
-		klines, err := p.api.GetKlines(binanceSymbol, binanceInterval, currentStart.UnixMilli(), end.UnixMilli(), 1000)
+		reqCtx, cancel := withRequestTimeout(ctx, p.timeout)
+		klines, err := p.api.GetKlines(reqCtx, binanceSymbol, binanceInterval, currentStart.UnixMilli(), end.UnixMilli(), 1000)
+		cancel()
 
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch klines for %s: %w", binanceSymbol, err)
+			return nil, mapBinanceError(err, "failed to fetch klines for %s", binanceSymbol)
 		}
 
 		if len(klines) == 0 {
@@ -219,35 +305,9 @@ func (p *BinanceProvider) GetHistoricalData(symbol string, start, end time.Time,
 		}
 
 		for _, k := range klines {
-			open, err := strconv.ParseFloat(k.Open, 64)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse open price: %w", err)
-			}
-			high, err := strconv.ParseFloat(k.High, 64)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse high price: %w", err)
-			}
-			low, err := strconv.ParseFloat(k.Low, 64)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse low price: %w", err)
-			}
-			closePrice, err := strconv.ParseFloat(k.Close, 64)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse close price: %w", err)
-			}
-			volume, err := strconv.ParseFloat(k.Volume, 64)
+			ohlcv, err := parseKline(symbol, k)
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse volume: %w", err)
-			}
-
-			ohlcv := models.OHLCV{
-				Timestamp: time.UnixMilli(k.OpenTime),
-				Symbol:    symbol,
-				Open:      open,
-				High:      high,
-				Low:       low,
-				Close:     closePrice,
-				Volume:    volume,
+				return nil, err
 			}
 			allKlines = append(allKlines, ohlcv)
 		}
@@ -263,12 +323,47 @@ func (p *BinanceProvider) GetHistoricalData(symbol string, start, end time.Time,
 	}
 
 	if len(allKlines) == 0 {
-		return nil, fmt.Errorf("no data returned for symbol %s", symbol)
+		return nil, newProviderError("binance", KindNotFound, nil, "no data returned for symbol %s", symbol)
 	}
 
 	return allKlines, nil
 }
 
+// parseKline converts a Binance kline to a candle. The client decodes klines
+// positionally, so a change in Binance's format shows up as missing times or
+// empty prices, which are reported as KindBadResponse.
+func parseKline(symbol string, k *binance.Kline) (models.OHLCV, error) {
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"open", k.Open}, {"high", k.High}, {"low", k.Low}, {"close", k.Close}, {"volume", k.Volume},
+	}
+	values := make([]float64, len(fields))
+	var err error
+	for i, field := range fields {
+		if values[i], err = strconv.ParseFloat(field.value, 64); err != nil {
+			return models.OHLCV{}, newBadResponseError("binance", fmt.Appendf(nil, "%+v", *k), err,
+				"failed to parse kline %s for %s", field.name, symbol)
+		}
+	}
+
+	candle := models.OHLCV{
+		Timestamp: time.UnixMilli(k.OpenTime),
+		Symbol:    symbol,
+		Open:      values[0],
+		High:      values[1],
+		Low:       values[2],
+		Close:     values[3],
+		Volume:    values[4],
+	}
+	if k.OpenTime <= 0 || candle.Close <= 0 {
+		return models.OHLCV{}, newBadResponseError("binance", fmt.Appendf(nil, "%+v", *k), nil,
+			"no usable kline fields in response for %s", symbol)
+	}
+	return candle, nil
+}
+
 // GetLatestPrice fetches the current price from Binance.
 //
 // Args:
@@ -278,18 +373,25 @@ func (p *BinanceProvider) GetHistoricalData(symbol string, start, end time.Time,
 //   - float64: Current price
 //   - error: Any error encountered
 func (p *BinanceProvider) GetLatestPrice(symbol string) (float64, error) {
+	return p.GetLatestPriceContext(context.Background(), symbol)
+}
+
+// GetLatestPriceContext fetches the current price from Binance, bound to ctx.
+func (p *BinanceProvider) GetLatestPriceContext(ctx context.Context, symbol string) (float64, error) {
 	p.rateLimit()
 
 	binanceSymbol := convertSymbol(symbol)
 
-	prices, err := p.api.GetPrices(binanceSymbol)
+	ctx, cancel := withRequestTimeout(ctx, p.timeout)
+	defer cancel()
+	prices, err := p.api.GetPrices(ctx, binanceSymbol)
 
 	if err != nil {
-		return 0.0, fmt.Errorf("failed to fetch price for %s: %w", binanceSymbol, err)
+		return 0.0, mapBinanceError(err, "failed to fetch price for %s", binanceSymbol)
 	}
 
 	if len(prices) == 0 {
-		return 0.0, fmt.Errorf("no price data returned for %s", symbol)
+		return 0.0, newProviderError("binance", KindNotFound, nil, "no price data returned for %s", symbol)
 	}
 
 	price, err := strconv.ParseFloat(prices[0].Price, 64)
@@ -309,18 +411,25 @@ func (p *BinanceProvider) GetLatestPrice(symbol string) (float64, error) {
 //   - *models.Ticker: Ticker information
 //   - error: Any error encountered
 func (p *BinanceProvider) GetTicker(symbol string) (*models.Ticker, error) {
+	return p.GetTickerContext(context.Background(), symbol)
+}
+
+// GetTickerContext fetches ticker information from Binance, bound to ctx.
+func (p *BinanceProvider) GetTickerContext(ctx context.Context, symbol string) (*models.Ticker, error) {
 	p.rateLimit()
 
 	binanceSymbol := convertSymbol(symbol)
 
-	info, err := p.api.GetExchangeInfo(binanceSymbol)
+	ctx, cancel := withRequestTimeout(ctx, p.timeout)
+	defer cancel()
+	info, err := p.api.GetExchangeInfo(ctx, binanceSymbol)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch exchange info for %s: %w", binanceSymbol, err)
+		return nil, mapBinanceError(err, "failed to fetch exchange info for %s", binanceSymbol)
 	}
 
 	if len(info.Symbols) == 0 {
-		return nil, fmt.Errorf("no symbol info returned for %s", symbol)
+		return nil, newProviderError("binance", KindNotFound, nil, "no symbol info returned for %s", symbol)
 	}
 
 	symbolInfo := info.Symbols[0]
@@ -1,6 +1,7 @@
 package providers
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -13,9 +14,12 @@ import (
 // MockBinanceAPI implements BinanceAPI interface for testing
 type MockBinanceAPI struct {
 	mock.Mock
+	// ctx is the context of the most recent call.
+	ctx context.Context
 }
 
-func (m *MockBinanceAPI) GetKlines(symbol, interval string, start, end int64, limit int) ([]*binance.Kline, error) {
+func (m *MockBinanceAPI) GetKlines(ctx context.Context, symbol, interval string, start, end int64, limit int) ([]*binance.Kline, error) {
+	m.ctx = ctx
 	args := m.Called(symbol, interval, start, end, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -23,7 +27,8 @@ func (m *MockBinanceAPI) GetKlines(symbol, interval string, start, end int64, li
 	return args.Get(0).([]*binance.Kline), args.Error(1)
 }
 
-func (m *MockBinanceAPI) GetPrices(symbol string) ([]*binance.SymbolPrice, error) {
+func (m *MockBinanceAPI) GetPrices(ctx context.Context, symbol string) ([]*binance.SymbolPrice, error) {
+	m.ctx = ctx
 	args := m.Called(symbol)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -31,7 +36,8 @@ func (m *MockBinanceAPI) GetPrices(symbol string) ([]*binance.SymbolPrice, error
 	return args.Get(0).([]*binance.SymbolPrice), args.Error(1)
 }
 
-func (m *MockBinanceAPI) GetExchangeInfo(symbol string) (*binance.ExchangeInfo, error) {
+func (m *MockBinanceAPI) GetExchangeInfo(ctx context.Context, symbol string) (*binance.ExchangeInfo, error) {
+	m.ctx = ctx
 	args := m.Called(symbol)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -110,3 +116,55 @@ func TestBinanceProvider_GetTicker_Mock(t *testing.T) {
 	assert.Equal(t, "BTC/USDT", ticker.Name)
 	assert.Equal(t, "crypto", ticker.AssetType)
 }
+
+func TestBinanceProvider_GetLotSize(t *testing.T) {
+	mockAPI := new(MockBinanceAPI)
+	p := NewBinanceProvider("", "")
+	p.api = mockAPI
+
+	expectedInfo := &binance.ExchangeInfo{
+		Symbols: []binance.Symbol{
+			{
+				Symbol: "BTCUSDT",
+				Filters: []map[string]interface{}{
+					{"filterType": "LOT_SIZE", "minQty": "0.00001000", "maxQty": "9000.00000000", "stepSize": "0.00001000"},
+				},
+			},
+		},
+	}
+	mockAPI.On("GetExchangeInfo", "BTCUSDT").Return(expectedInfo, nil)
+
+	step, err := p.GetLotSize(context.Background(), "BTC/USD")
+	require.NoError(t, err)
+	assert.Equal(t, 0.00001, step)
+
+	// Symbols without a LOT_SIZE filter report not found
+	mockAPI.On("GetExchangeInfo", "ETHUSDT").Return(&binance.ExchangeInfo{
+		Symbols: []binance.Symbol{{Symbol: "ETHUSDT"}},
+	}, nil)
+	_, err = p.GetLotSize(context.Background(), "ETH/USD")
+	require.Error(t, err)
+	kind, ok := ErrorKindOf(err)
+	require.True(t, ok)
+	assert.Equal(t, KindNotFound, kind)
+}
+
+func TestBinanceProvider_RequestContext(t *testing.T) {
+	mockAPI := new(MockBinanceAPI)
+	p := NewBinanceProvider("", "")
+	p.api = mockAPI
+	p.SetTimeout(5 * time.Second)
+
+	mockAPI.On("GetPrices", "BTCUSDT").
+		Return([]*binance.SymbolPrice{{Symbol: "BTCUSDT", Price: "50000.0"}}, nil)
+
+	_, err := p.GetLatestPriceContext(context.Background(), "BTC/USD")
+	require.NoError(t, err)
+
+	// The request context carries the timeout and is released after the call
+	require.NotNil(t, mockAPI.ctx)
+	deadline, ok := mockAPI.ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(5*time.Second), deadline, time.Second)
+	assert.ErrorIs(t, mockAPI.ctx.Err(), context.Canceled)
+}
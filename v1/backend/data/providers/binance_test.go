@@ -82,6 +82,15 @@ func TestBinanceProvider_GetHistoricalData_InvalidInterval(t *testing.T) {
 	assert.Contains(t, err.Error(), "unsupported interval")
 }
 
+// TestBinanceProvider_SupportsInterval verifies interval support mirrors the kline mapping.
+func TestBinanceProvider_SupportsInterval(t *testing.T) {
+	p := NewBinanceProvider("", "")
+	assert.True(t, p.SupportsInterval("1m"))
+	assert.True(t, p.SupportsInterval("4h"))
+	assert.True(t, p.SupportsInterval("1w"))
+	assert.False(t, p.SupportsInterval("invalid_interval"))
+}
+
 // TestNewExchangeProvider verifies exchange provider factory.
 func TestNewExchangeProvider(t *testing.T) {
 	// Valid exchange
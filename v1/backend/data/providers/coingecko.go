@@ -0,0 +1,415 @@
+// Package providers contains data provider implementations.
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+const (
+	coinGeckoBaseURL = "https://api.coingecko.com/api/v3"
+)
+
+// coinGeckoIDs maps common base assets to CoinGecko coin ids. Other assets
+// are looked up in CoinGecko's coin list.
+var coinGeckoIDs = map[string]string{
+	"BTC":   "bitcoin",
+	"ETH":   "ethereum",
+	"USDT":  "tether",
+	"USDC":  "usd-coin",
+	"BNB":   "binancecoin",
+	"SOL":   "solana",
+	"XRP":   "ripple",
+	"ADA":   "cardano",
+	"DOGE":  "dogecoin",
+	"TRX":   "tron",
+	"DOT":   "polkadot",
+	"AVAX":  "avalanche-2",
+	"LINK":  "chainlink",
+	"MATIC": "matic-network",
+	"LTC":   "litecoin",
+	"BCH":   "bitcoin-cash",
+	"ETC":   "ethereum-classic",
+	"XLM":   "stellar",
+	"ATOM":  "cosmos",
+	"UNI":   "uniswap",
+	"SHIB":  "shiba-inu",
+	"NEAR":  "near",
+	"ALGO":  "algorand",
+	"AAVE":  "aave",
+}
+
+// coinGeckoVsCurrencies maps quote assets to CoinGecko vs-currencies.
+// CoinGecko has no stablecoin vs-currencies, so they are priced in USD.
+var coinGeckoVsCurrencies = map[string]string{
+	"USD":  "usd",
+	"USDT": "usd",
+	"USDC": "usd",
+	"BUSD": "usd",
+	"EUR":  "eur",
+	"BTC":  "btc",
+	"ETH":  "eth",
+}
+
+// CoinGeckoProvider fetches crypto market data from the CoinGecko public API.
+// It needs no API key and covers far more coins than a single exchange, but
+// the keyless tier allows only a few requests per minute.
+type CoinGeckoProvider struct {
+	httpClient *http.Client
+	timeout    time.Duration
+	// mu guards the rate limiter's schedule; it is never held while waiting.
+	mu          sync.Mutex
+	lastRequest time.Time
+	minInterval time.Duration
+	// listMu guards coinList, which maps lowercase coin symbols to ids and
+	// is loaded on first use.
+	listMu   sync.Mutex
+	coinList map[string][]string
+	// now is the clock used to pick the sample granularity (overridable in tests).
+	now func() time.Time
+}
+
+// NewCoinGeckoProvider creates a new CoinGeckoProvider instance.
+//
+// Returns:
+//   - *CoinGeckoProvider: The provider instance
+func NewCoinGeckoProvider() *CoinGeckoProvider {
+	return &CoinGeckoProvider{
+		httpClient:  &http.Client{},
+		timeout:     DefaultRequestTimeout,
+		minInterval: 6 * time.Second, // ~10 requests/minute, the keyless limit
+		now:         time.Now,
+	}
+}
+
+// Name returns the provider name.
+func (p *CoinGeckoProvider) Name() string {
+	return "coingecko"
+}
+
+// SupportsInterval reports whether CoinGecko bars can be built for the
+// interval: whole multiples of 5 minutes up to a week. Whether a given range
+// can serve it depends on the range (see GetHistoricalData).
+func (p *CoinGeckoProvider) SupportsInterval(interval string) bool {
+	d, ok := data.IntervalDuration(interval)
+	return ok && d%(5*time.Minute) == 0 && d <= 7*24*time.Hour
+}
+
+// SetTimeout sets the per-request timeout. Zero disables it, leaving requests
+// bounded only by the caller's context.
+func (p *CoinGeckoProvider) SetTimeout(timeout time.Duration) {
+	p.timeout = timeout
+}
+
+// rateLimit ensures we don't exceed API rate limits. It reserves the next
+// request slot under p.mu, then waits for it without the lock, so callers
+// queue in order and a cancelled ctx stops the wait at once.
+func (p *CoinGeckoProvider) rateLimit(ctx context.Context) error {
+	p.mu.Lock()
+	now := time.Now()
+	slot := now
+	if next := p.lastRequest.Add(p.minInterval); !p.lastRequest.IsZero() && next.After(now) {
+		slot = next
+	}
+	p.lastRequest = slot
+	p.mu.Unlock()
+
+	wait := slot.Sub(now)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return newProviderError("coingecko", KindUnavailable, ctx.Err(), "cancelled waiting for rate limit")
+	case <-timer.C:
+		return nil
+	}
+}
+
+// doRequest performs an HTTP request to the CoinGecko API.
+func (p *CoinGeckoProvider) doRequest(ctx context.Context, endpoint string, params url.Values) ([]byte, error) {
+	if err := p.rateLimit(ctx); err != nil {
+		return nil, err
+	}
+
+	reqURL := fmt.Sprintf("%s%s", coinGeckoBaseURL, endpoint)
+	if params != nil {
+		reqURL = fmt.Sprintf("%s?%s", reqURL, params.Encode())
+	}
+
+	ctx, cancel := withRequestTimeout(ctx, p.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, newProviderError("coingecko", KindUnavailable, err, "request failed")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newProviderError("coingecko", KindUnavailable, err, "failed to read response")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newProviderError("coingecko", KindFromHTTPStatus(resp.StatusCode), nil,
+			"API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
+
+// coinGeckoCoin is an entry of CoinGecko's coin list and coin details.
+type coinGeckoCoin struct {
+	ID     string `json:"id"`
+	Symbol string `json:"symbol"`
+	Name   string `json:"name"`
+}
+
+// coinGeckoMarketChart is CoinGecko's market chart response; each point is
+// [unix milliseconds, value].
+type coinGeckoMarketChart struct {
+	Prices [][2]float64 `json:"prices"`
+}
+
+// resolve maps a canonical pair such as "BTC-USD" to a CoinGecko coin id and
+// vs-currency.
+func (p *CoinGeckoProvider) resolve(ctx context.Context, symbol string) (id, vsCurrency string, err error) {
+	base, quote, ok := strings.Cut(data.CanonicalSymbol(symbol), "-")
+	if !ok || !data.IsCryptoSymbol(symbol) {
+		return "", "", newProviderError("coingecko", KindBadInput, nil, "%s is not a crypto pair (e.g. BTC-USD)", symbol)
+	}
+	vsCurrency, ok = coinGeckoVsCurrencies[quote]
+	if !ok {
+		return "", "", newProviderError("coingecko", KindBadInput, nil, "unsupported quote currency %s", quote)
+	}
+	if id, ok := coinGeckoIDs[base]; ok {
+		return id, vsCurrency, nil
+	}
+
+	p.listMu.Lock()
+	defer p.listMu.Unlock()
+	if p.coinList == nil {
+		body, err := p.doRequest(ctx, "/coins/list", nil)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to fetch coin list: %w", err)
+		}
+		var coins []coinGeckoCoin
+		if err := json.Unmarshal(body, &coins); err != nil {
+			return "", "", newProviderError("coingecko", KindUnavailable, err, "failed to parse coin list")
+		}
+		p.coinList = make(map[string][]string, len(coins))
+		for _, coin := range coins {
+			key := strings.ToLower(coin.Symbol)
+			p.coinList[key] = append(p.coinList[key], coin.ID)
+		}
+	}
+
+	switch ids := p.coinList[strings.ToLower(base)]; len(ids) {
+	case 0:
+		return "", "", newProviderError("coingecko", KindNotFound, nil, "no CoinGecko coin for %s", base)
+	case 1:
+		return ids[0], vsCurrency, nil
+	default:
+		return "", "", newProviderError("coingecko", KindNotFound, nil,
+			"%s matches %d CoinGecko coins (%s)", base, len(ids), strings.Join(ids, ", "))
+	}
+}
+
+// coinGeckoGranularity returns the spacing of CoinGecko's market chart
+// samples for a range: 5-minute for ranges within the last day, hourly up to
+// 90 days, and daily beyond.
+func coinGeckoGranularity(start, end, now time.Time) string {
+	switch {
+	case end.Sub(start) <= 24*time.Hour && now.Sub(start) <= 24*time.Hour:
+		return "5m"
+	case end.Sub(start) <= 90*24*time.Hour:
+		return "1h"
+	default:
+		return "1d"
+	}
+}
+
+// GetHistoricalData fetches OHLCV data from CoinGecko.
+//
+// CoinGecko serves price samples whose spacing depends on the range (see
+// coinGeckoGranularity); they are aggregated into bars of the interval, so
+// the interval must be a whole multiple of that spacing. Volumes are rolling
+// 24-hour totals rather than per-bar, so bars carry zero volume.
+//
+// Args:
+//   - symbol: Canonical crypto pair (e.g., "BTC-USD")
+//   - start: Start time
+//   - end: End time
+//   - interval: Time interval (e.g., "5m", "1h", "1d")
+//
+// Returns:
+//   - []models.OHLCV: Historical data
+//   - error: Any error encountered
+func (p *CoinGeckoProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	return p.GetHistoricalDataContext(context.Background(), symbol, start, end, interval)
+}
+
+// GetHistoricalDataContext fetches OHLCV data from CoinGecko, bound to ctx.
+func (p *CoinGeckoProvider) GetHistoricalDataContext(ctx context.Context, symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	if !p.SupportsInterval(interval) {
+		return nil, newProviderError("coingecko", KindBadInput, nil, "unsupported interval: %s", interval)
+	}
+	granularity := coinGeckoGranularity(start, end, p.now())
+	want, _ := data.IntervalDuration(interval)
+	step, _ := data.IntervalDuration(granularity)
+	if want%step != 0 {
+		return nil, newProviderError("coingecko", KindBadInput, nil,
+			"CoinGecko returns %s prices for this range, too coarse for %s bars "+
+				"(5m data covers at most the last day, hourly at most 90 days)", granularity, interval)
+	}
+
+	id, vsCurrency, err := p.resolve(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("vs_currency", vsCurrency)
+	params.Set("from", strconv.FormatInt(start.Unix(), 10))
+	params.Set("to", strconv.FormatInt(end.Unix(), 10))
+
+	body, err := p.doRequest(ctx, fmt.Sprintf("/coins/%s/market_chart/range", id), params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch historical data for %s: %w", symbol, err)
+	}
+
+	var chart coinGeckoMarketChart
+	if err := json.Unmarshal(body, &chart); err != nil {
+		return nil, newProviderError("coingecko", KindUnavailable, err, "failed to parse response for %s", symbol)
+	}
+
+	samples := make([]models.OHLCV, 0, len(chart.Prices))
+	for _, point := range chart.Prices {
+		timestamp := time.UnixMilli(int64(point[0])).UTC()
+		if timestamp.Before(start) || timestamp.After(end) {
+			continue
+		}
+		price := point[1]
+		samples = append(samples, models.OHLCV{
+			Timestamp: timestamp,
+			Symbol:    symbol,
+			Open:      price,
+			High:      price,
+			Low:       price,
+			Close:     price,
+		})
+	}
+	if len(samples) == 0 {
+		return nil, newProviderError("coingecko", KindNotFound, nil, "no data returned for symbol %s", symbol)
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].Timestamp.Before(samples[j].Timestamp) })
+
+	bars, err := data.Resample(samples, granularity, interval)
+	if err != nil {
+		return nil, newProviderError("coingecko", KindBadInput, err, "failed to build %s bars for %s", interval, symbol)
+	}
+	return bars, nil
+}
+
+// GetLatestPrice fetches the current price from CoinGecko.
+//
+// Args:
+//   - symbol: Canonical crypto pair (e.g., "BTC-USD")
+//
+// Returns:
+//   - float64: Latest price in the pair's quote currency
+//   - error: Any error encountered
+func (p *CoinGeckoProvider) GetLatestPrice(symbol string) (float64, error) {
+	return p.GetLatestPriceContext(context.Background(), symbol)
+}
+
+// GetLatestPriceContext fetches the current price from CoinGecko, bound to ctx.
+func (p *CoinGeckoProvider) GetLatestPriceContext(ctx context.Context, symbol string) (float64, error) {
+	id, vsCurrency, err := p.resolve(ctx, symbol)
+	if err != nil {
+		return 0.0, err
+	}
+
+	params := url.Values{}
+	params.Set("ids", id)
+	params.Set("vs_currencies", vsCurrency)
+
+	body, err := p.doRequest(ctx, "/simple/price", params)
+	if err != nil {
+		return 0.0, fmt.Errorf("failed to fetch price for %s: %w", symbol, err)
+	}
+
+	var prices map[string]map[string]float64
+	if err := json.Unmarshal(body, &prices); err != nil {
+		return 0.0, newProviderError("coingecko", KindUnavailable, err, "failed to parse response for %s", symbol)
+	}
+
+	price, ok := prices[id][vsCurrency]
+	if !ok {
+		return 0.0, newProviderError("coingecko", KindNotFound, nil, "no price data returned for %s", symbol)
+	}
+	return price, nil
+}
+
+// GetTicker fetches ticker information from CoinGecko.
+//
+// Args:
+//   - symbol: Canonical crypto pair (e.g., "BTC-USD")
+//
+// Returns:
+//   - *models.Ticker: Ticker information
+//   - error: Any error encountered
+func (p *CoinGeckoProvider) GetTicker(symbol string) (*models.Ticker, error) {
+	return p.GetTickerContext(context.Background(), symbol)
+}
+
+// GetTickerContext fetches ticker information from CoinGecko, bound to ctx.
+func (p *CoinGeckoProvider) GetTickerContext(ctx context.Context, symbol string) (*models.Ticker, error) {
+	id, _, err := p.resolve(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	for _, section := range []string{"localization", "tickers", "market_data", "community_data", "developer_data"} {
+		params.Set(section, "false")
+	}
+
+	body, err := p.doRequest(ctx, "/coins/"+id, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ticker info for %s: %w", symbol, err)
+	}
+
+	var coin coinGeckoCoin
+	if err := json.Unmarshal(body, &coin); err != nil {
+		return nil, newProviderError("coingecko", KindUnavailable, err, "failed to parse ticker info for %s", symbol)
+	}
+
+	return &models.Ticker{
+		Symbol:    data.CanonicalSymbol(symbol),
+		Name:      coin.Name,
+		AssetType: "crypto",
+		Exchange:  "coingecko",
+	}, nil
+}
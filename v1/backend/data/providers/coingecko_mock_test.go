@@ -0,0 +1,197 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockCoinGecko returns a CoinGecko provider without rate limiting whose
+// requests are answered by respond.
+func newMockCoinGecko(t *testing.T, respond func(req *http.Request) (int, string)) *CoinGeckoProvider {
+	t.Helper()
+	p := NewCoinGeckoProvider()
+	p.minInterval = 0
+	p.httpClient.Transport = &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) *http.Response {
+			status, body := respond(req)
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(bytes.NewBufferString(body)),
+				Header:     make(http.Header),
+			}
+		},
+	}
+	return p
+}
+
+func TestCoinGeckoProvider_SupportsInterval(t *testing.T) {
+	var p data.IntervalProvider = NewCoinGeckoProvider()
+	assert.True(t, p.SupportsInterval("5m"))
+	assert.True(t, p.SupportsInterval("4h"))
+	assert.True(t, p.SupportsInterval("1d"))
+	assert.False(t, p.SupportsInterval("1m"))
+	assert.False(t, p.SupportsInterval("1mo"))
+}
+
+func TestCoinGeckoProvider_GetLatestPrice_Mock(t *testing.T) {
+	p := newMockCoinGecko(t, func(req *http.Request) (int, string) {
+		assert.Equal(t, "/api/v3/simple/price", req.URL.Path)
+		assert.Equal(t, "bitcoin", req.URL.Query().Get("ids"))
+		assert.Equal(t, "usd", req.URL.Query().Get("vs_currencies"))
+		return http.StatusOK, `{"bitcoin": {"usd": 67187.33}}`
+	})
+
+	price, err := p.GetLatestPrice("BTC-USD")
+	require.NoError(t, err)
+	assert.Equal(t, 67187.33, price)
+
+	// Stablecoin quotes are priced in USD
+	price, err = p.GetLatestPrice("BTC-USDT")
+	require.NoError(t, err)
+	assert.Equal(t, 67187.33, price)
+}
+
+// TestCoinGeckoProvider_RateLimitContext verifies a request waiting for its
+// rate limit slot gives up when its context ends, without blocking requests
+// queued behind it.
+func TestCoinGeckoProvider_RateLimitContext(t *testing.T) {
+	p := newMockCoinGecko(t, func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"bitcoin": {"usd": 67187.33}}`
+	})
+	p.minInterval = time.Hour
+	p.lastRequest = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 2)
+	go func() { _, err := p.GetLatestPriceContext(ctx, "BTC-USD"); done <- err }()
+	go func() { _, err := p.GetLatestPriceContext(ctx, "ETH-USD"); done <- err }()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-done:
+			require.Error(t, err)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		case <-time.After(time.Second):
+			t.Fatal("rate limit wait ignored the context")
+		}
+	}
+}
+
+func TestCoinGeckoProvider_GetHistoricalData_Mock(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(8 * time.Hour)
+
+	p := newMockCoinGecko(t, func(req *http.Request) (int, string) {
+		assert.Equal(t, "/api/v3/coins/ethereum/market_chart/range", req.URL.Path)
+		q := req.URL.Query()
+		assert.Equal(t, "usd", q.Get("vs_currency"))
+		assert.Equal(t, "1772323200", q.Get("from"))
+		assert.Equal(t, "1772352000", q.Get("to"))
+		// Hourly samples, a few seconds past each hour
+		return http.StatusOK, `{"prices": [
+			[1772323205000, 100], [1772326805000, 104], [1772330405000, 98], [1772334005000, 101],
+			[1772337605000, 102], [1772341205000, 107], [1772344805000, 103], [1772348405000, 105]
+		], "total_volumes": []}`
+	})
+	p.now = func() time.Time { return start.Add(7 * 24 * time.Hour) }
+
+	bars, err := p.GetHistoricalData("ETH-USD", start, end, "4h")
+	require.NoError(t, err)
+	require.Len(t, bars, 2)
+	assert.Equal(t, start, bars[0].Timestamp)
+	assert.Equal(t, 100.0, bars[0].Open)
+	assert.Equal(t, 104.0, bars[0].High)
+	assert.Equal(t, 98.0, bars[0].Low)
+	assert.Equal(t, 101.0, bars[0].Close)
+	assert.Equal(t, start.Add(4*time.Hour), bars[1].Timestamp)
+	assert.Equal(t, 105.0, bars[1].Close)
+	assert.Zero(t, bars[1].Volume)
+}
+
+func TestCoinGeckoProvider_GetHistoricalData_UnsupportedRange(t *testing.T) {
+	p := newMockCoinGecko(t, func(req *http.Request) (int, string) {
+		t.Errorf("unexpected request to %s", req.URL)
+		return http.StatusInternalServerError, ""
+	})
+	now := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+
+	// 30 days of data comes hourly, too coarse for 5m bars
+	_, err := p.GetHistoricalData("BTC-USD", now.AddDate(0, 0, -30), now, "5m")
+	require.Error(t, err)
+	kind, _ := ErrorKindOf(err)
+	assert.Equal(t, KindBadInput, kind)
+	assert.Contains(t, err.Error(), "returns 1h prices for this range")
+
+	// A year of data comes daily
+	_, err = p.GetHistoricalData("BTC-USD", now.AddDate(-1, 0, 0), now, "4h")
+	assert.Contains(t, err.Error(), "returns 1d prices for this range")
+
+	_, err = p.GetHistoricalData("BTC-USD", now.AddDate(0, 0, -1), now, "1m")
+	assert.Contains(t, err.Error(), "unsupported interval")
+}
+
+func TestCoinGeckoProvider_CoinList_Mock(t *testing.T) {
+	listRequests := 0
+	p := newMockCoinGecko(t, func(req *http.Request) (int, string) {
+		switch req.URL.Path {
+		case "/api/v3/coins/list":
+			listRequests++
+			return http.StatusOK, `[
+				{"id": "pepe", "symbol": "pepe", "name": "Pepe"},
+				{"id": "first-cat", "symbol": "cat", "name": "Cat One"},
+				{"id": "second-cat", "symbol": "cat", "name": "Cat Two"}
+			]`
+		case "/api/v3/coins/pepe":
+			return http.StatusOK, `{"id": "pepe", "symbol": "pepe", "name": "Pepe"}`
+		default:
+			assert.Equal(t, "pepe", req.URL.Query().Get("ids"))
+			return http.StatusOK, `{"pepe": {"usd": 0.0000071}}`
+		}
+	})
+
+	price, err := p.GetLatestPrice("PEPE-USD")
+	require.NoError(t, err)
+	assert.Equal(t, 0.0000071, price)
+
+	ticker, err := p.GetTicker("pepe/usd")
+	require.NoError(t, err)
+	assert.Equal(t, "PEPE-USD", ticker.Symbol)
+	assert.Equal(t, "Pepe", ticker.Name)
+	assert.Equal(t, "crypto", ticker.AssetType)
+
+	_, err = p.GetLatestPrice("CAT-USD")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "matches 2 CoinGecko coins")
+
+	_, err = p.GetLatestPrice("NOPE-USD")
+	kind, _ := ErrorKindOf(err)
+	assert.Equal(t, KindNotFound, kind)
+
+	assert.Equal(t, 1, listRequests, "coin list should be fetched once")
+}
+
+func TestCoinGeckoProvider_ErrorKinds(t *testing.T) {
+	p := newMockCoinGecko(t, func(req *http.Request) (int, string) {
+		return http.StatusTooManyRequests, `{"status": {"error_code": 429}}`
+	})
+
+	_, err := p.GetLatestPrice("BTC-USD")
+	require.Error(t, err)
+	kind, ok := ErrorKindOf(err)
+	require.True(t, ok)
+	assert.Equal(t, KindRateLimited, kind)
+
+	_, err = p.GetLatestPrice("AAPL")
+	kind, _ = ErrorKindOf(err)
+	assert.Equal(t, KindBadInput, kind)
+}
@@ -0,0 +1,139 @@
+// Package providers contains data provider implementations.
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrorKind classifies a provider failure so callers can react to it.
+type ErrorKind string
+
+const (
+	// KindNotFound means the symbol or data does not exist.
+	KindNotFound ErrorKind = "NOT_FOUND"
+	// KindRateLimited means the provider throttled the request.
+	KindRateLimited ErrorKind = "RATE_LIMITED"
+	// KindUnauthorized means the provider rejected our credentials.
+	KindUnauthorized ErrorKind = "UNAUTHORIZED"
+	// KindUnavailable means the provider could not be reached or failed.
+	KindUnavailable ErrorKind = "UNAVAILABLE"
+	// KindBadInput means the request parameters were invalid.
+	KindBadInput ErrorKind = "BAD_INPUT"
+	// KindBadResponse means the provider answered successfully but the
+	// response could not be used, typically because its API changed shape.
+	KindBadResponse ErrorKind = "BAD_RESPONSE"
+)
+
+// badResponseSnippet is how many bytes of an unusable response are logged.
+const badResponseSnippet = 256
+
+// ProviderError is a typed data provider failure.
+type ProviderError struct {
+	Provider string
+	Kind     ErrorKind
+	Message  string
+	Err      error
+}
+
+// Error implements the error interface.
+func (e *ProviderError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Provider, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Provider, e.Message)
+}
+
+// Unwrap returns the underlying error.
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// newProviderError creates a ProviderError.
+//
+// Args:
+//   - provider: Provider name
+//   - kind: Failure classification
+//   - err: Underlying error (can be nil)
+//   - format: Message format string
+//   - args: Format arguments
+//
+// Returns:
+//   - *ProviderError: The typed error
+func newProviderError(provider string, kind ErrorKind, err error, format string, args ...interface{}) *ProviderError {
+	return &ProviderError{
+		Provider: provider,
+		Kind:     kind,
+		Message:  fmt.Sprintf(format, args...),
+		Err:      err,
+	}
+}
+
+// newBadResponseError creates a KindBadResponse error and logs the start of
+// the response, so a changed API can be told apart from an empty date range
+// and diagnosed from the logs.
+//
+// Args:
+//   - provider: Provider name
+//   - raw: The response, or a printable form of it
+//   - err: Underlying parse error (can be nil)
+//   - format: Message format string
+//   - args: Format arguments
+//
+// Returns:
+//   - *ProviderError: The typed error
+func newBadResponseError(provider string, raw []byte, err error, format string, args ...interface{}) *ProviderError {
+	perr := newProviderError(provider, KindBadResponse, err, format, args...)
+	snippet := raw
+	if len(snippet) > badResponseSnippet {
+		snippet = snippet[:badResponseSnippet]
+	}
+	log.Warn().
+		Err(err).
+		Str("provider", provider).
+		Bytes("response", snippet).
+		Int("response_bytes", len(raw)).
+		Msg("Unusable provider response: " + perr.Message)
+	return perr
+}
+
+// KindFromHTTPStatus maps an upstream HTTP status code to an ErrorKind.
+//
+// Args:
+//   - status: HTTP status code returned by the provider
+//
+// Returns:
+//   - ErrorKind: The matching classification
+func KindFromHTTPStatus(status int) ErrorKind {
+	switch status {
+	case http.StatusNotFound:
+		return KindNotFound
+	case http.StatusTooManyRequests:
+		return KindRateLimited
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return KindUnauthorized
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return KindBadInput
+	default:
+		return KindUnavailable
+	}
+}
+
+// ErrorKindOf returns the kind of a provider error anywhere in err's chain.
+//
+// Args:
+//   - err: Error to inspect
+//
+// Returns:
+//   - ErrorKind: The classification
+//   - bool: False if err is not a ProviderError
+func ErrorKindOf(err error) (ErrorKind, bool) {
+	var perr *ProviderError
+	if errors.As(err, &perr) {
+		return perr.Kind, true
+	}
+	return "", false
+}
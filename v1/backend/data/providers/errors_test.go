@@ -0,0 +1,191 @@
+package providers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	binance "github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestKindFromHTTPStatus verifies HTTP status classification.
+func TestKindFromHTTPStatus(t *testing.T) {
+	assert.Equal(t, KindNotFound, KindFromHTTPStatus(http.StatusNotFound))
+	assert.Equal(t, KindRateLimited, KindFromHTTPStatus(http.StatusTooManyRequests))
+	assert.Equal(t, KindUnauthorized, KindFromHTTPStatus(http.StatusUnauthorized))
+	assert.Equal(t, KindUnauthorized, KindFromHTTPStatus(http.StatusForbidden))
+	assert.Equal(t, KindBadInput, KindFromHTTPStatus(http.StatusBadRequest))
+	assert.Equal(t, KindUnavailable, KindFromHTTPStatus(http.StatusInternalServerError))
+	assert.Equal(t, KindUnavailable, KindFromHTTPStatus(http.StatusBadGateway))
+}
+
+// TestErrorKindOf verifies kinds survive error wrapping.
+func TestErrorKindOf(t *testing.T) {
+	base := newProviderError("tiingo", KindRateLimited, nil, "slow down")
+	wrapped := fmt.Errorf("failed to fetch: %w", base)
+
+	kind, ok := ErrorKindOf(wrapped)
+	assert.True(t, ok)
+	assert.Equal(t, KindRateLimited, kind)
+
+	_, ok = ErrorKindOf(errors.New("plain"))
+	assert.False(t, ok)
+}
+
+// TestTiingoProvider_ErrorKinds verifies Tiingo HTTP statuses map to kinds.
+func TestTiingoProvider_ErrorKinds(t *testing.T) {
+	tests := []struct {
+		status int
+		want   ErrorKind
+	}{
+		{http.StatusNotFound, KindNotFound},
+		{http.StatusTooManyRequests, KindRateLimited},
+		{http.StatusUnauthorized, KindUnauthorized},
+		{http.StatusServiceUnavailable, KindUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			p := NewTiingoProvider("test-key")
+			p.minInterval = 0
+			p.httpClient.Transport = &MockRoundTripper{
+				RoundTripFunc: func(req *http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: tt.status,
+						Body:       io.NopCloser(bytes.NewBufferString("error")),
+						Header:     make(http.Header),
+					}
+				},
+			}
+
+			_, err := p.GetLatestPrice("AAPL")
+			require.Error(t, err)
+			kind, ok := ErrorKindOf(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, kind)
+		})
+	}
+
+	t.Run("MissingAPIKey", func(t *testing.T) {
+		_, err := NewTiingoProvider("").GetLatestPrice("AAPL")
+		kind, _ := ErrorKindOf(err)
+		assert.Equal(t, KindUnauthorized, kind)
+	})
+}
+
+// TestBinanceProvider_ErrorKinds verifies Binance API codes map to kinds.
+func TestBinanceProvider_ErrorKinds(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorKind
+	}{
+		{"InvalidSymbol", &common.APIError{Code: -1121, Message: "Invalid symbol."}, KindNotFound},
+		{"TooManyRequests", &common.APIError{Code: -1003, Message: "Too many requests."}, KindRateLimited},
+		{"BadAPIKey", &common.APIError{Code: -2015, Message: "Invalid API-key."}, KindUnauthorized},
+		{"BadParam", &common.APIError{Code: -1100, Message: "Illegal characters."}, KindBadInput},
+		{"Network", errors.New("connection reset"), KindUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockBinanceAPI)
+			p := NewBinanceProvider("", "")
+			p.api = mockAPI
+			p.minInterval = 0
+
+			mockAPI.On("GetPrices", "BTCUSDT").Return(nil, tt.err)
+
+			_, err := p.GetLatestPrice("BTC/USD")
+			require.Error(t, err)
+			kind, ok := ErrorKindOf(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, kind)
+		})
+	}
+}
+
+// TestTiingoProvider_BadResponse verifies a successful response with no
+// usable fields is reported as KindBadResponse, while an empty range is
+// still KindNotFound.
+func TestTiingoProvider_BadResponse(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want ErrorKind
+	}{
+		{"EmptyRange", `[]`, KindNotFound},
+		{"RenamedFields", `[{"day": "2023-01-01", "price": 105.0}]`, KindBadResponse},
+		{"NotAnArray", `{"detail": "moved"}`, KindBadResponse},
+		{"BadDate", `[{"date": "01/02/2023", "adjClose": 105.0}]`, KindBadResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewTiingoProvider("test-key")
+			p.minInterval = 0
+			p.httpClient.Transport = &MockRoundTripper{
+				RoundTripFunc: func(req *http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(bytes.NewBufferString(tt.body)),
+						Header:     make(http.Header),
+					}
+				},
+			}
+
+			start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+			_, err := p.GetHistoricalData("AAPL", start, start.AddDate(0, 0, 1), "1d")
+			kind, ok := ErrorKindOf(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, kind)
+
+			if tt.name != "BadDate" {
+				_, err = p.GetLatestPrice("AAPL")
+				kind, ok = ErrorKindOf(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.want, kind)
+			}
+		})
+	}
+}
+
+// TestBinanceProvider_BadResponse verifies klines without usable fields are
+// reported as KindBadResponse.
+func TestBinanceProvider_BadResponse(t *testing.T) {
+	tests := []struct {
+		name  string
+		kline binance.Kline
+	}{
+		{"EmptyPrices", binance.Kline{OpenTime: 1600000000000}},
+		{"NoOpenTime", binance.Kline{Open: "100", High: "110", Low: "90", Close: "105", Volume: "1000"}},
+		{"UnparseableClose", binance.Kline{OpenTime: 1600000000000, Open: "100", High: "110", Low: "90", Close: "n/a", Volume: "1000"}},
+	}
+
+	start := time.UnixMilli(1600000000000)
+	end := time.UnixMilli(1600003600000)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockBinanceAPI)
+			p := NewBinanceProvider("", "")
+			p.api = mockAPI
+			p.minInterval = 0
+
+			kline := tt.kline
+			mockAPI.On("GetKlines", "BTCUSDT", "1h", start.UnixMilli(), end.UnixMilli(), 1000).
+				Return([]*binance.Kline{&kline}, nil)
+
+			_, err := p.GetHistoricalData("BTC/USD", start, end, "1h")
+			kind, ok := ErrorKindOf(err)
+			require.True(t, ok)
+			assert.Equal(t, KindBadResponse, kind)
+		})
+	}
+}
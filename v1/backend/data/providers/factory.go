@@ -2,7 +2,9 @@
 package providers
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/alexherrero/sherwood/backend/config"
 	"github.com/alexherrero/sherwood/backend/data"
@@ -18,8 +20,25 @@ const (
 	ProviderTiingo ProviderType = "tiingo"
 	// ProviderBinance represents Binance exchange provider.
 	ProviderBinance ProviderType = "binance"
+	// ProviderCoinGecko represents CoinGecko provider (keyless, crypto only).
+	ProviderCoinGecko ProviderType = "coingecko"
+	// ProviderReplay represents recorded candles replayed from files.
+	ProviderReplay ProviderType = "replay"
 )
 
+// DefaultRequestTimeout bounds each upstream request when no timeout is
+// configured.
+const DefaultRequestTimeout = 30 * time.Second
+
+// withRequestTimeout derives a context for a single upstream request. A
+// non-positive timeout leaves ctx's own deadline, if any, in effect.
+func withRequestTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
 // NewProvider creates a data provider based on the specified type.
 //
 // Args:
@@ -32,14 +51,26 @@ const (
 func NewProvider(providerType ProviderType, cfg *config.Config) (data.DataProvider, error) {
 	switch providerType {
 	case ProviderYahoo:
-		return NewYahooProvider(), nil
+		opts := DefaultYahooOptions()
+		if cfg != nil {
+			opts.Adjusted = cfg.YahooAdjusted
+		}
+		provider := NewYahooProviderWithOptions(opts)
+		if cfg != nil {
+			provider.SetTimeout(cfg.ProviderTimeout)
+		}
+		return provider, nil
 
 	case ProviderTiingo:
 		apiKey := ""
 		if cfg != nil {
 			apiKey = cfg.TiingoAPIKey
 		}
-		return NewTiingoProvider(apiKey), nil
+		provider := NewTiingoProvider(apiKey)
+		if cfg != nil {
+			provider.SetTimeout(cfg.ProviderTimeout)
+		}
+		return provider, nil
 
 	case ProviderBinance:
 		apiKey := ""
@@ -50,17 +81,38 @@ func NewProvider(providerType ProviderType, cfg *config.Config) (data.DataProvid
 			apiSecret = cfg.BinanceAPISecret
 			useBinanceUS = cfg.UseBinanceUS
 		}
+		provider := NewBinanceProvider(apiKey, apiSecret)
 		if useBinanceUS {
-			return NewBinanceUSProvider(apiKey, apiSecret), nil
+			provider = NewBinanceUSProvider(apiKey, apiSecret)
+		}
+		if cfg != nil {
+			provider.SetTimeout(cfg.ProviderTimeout)
+		}
+		return provider, nil
+
+	case ProviderCoinGecko:
+		provider := NewCoinGeckoProvider()
+		if cfg != nil {
+			provider.SetTimeout(cfg.ProviderTimeout)
+		}
+		return provider, nil
+
+	case ProviderReplay:
+		dir := ""
+		if cfg != nil {
+			dir = cfg.ReplayDataDir
 		}
-		return NewBinanceProvider(apiKey, apiSecret), nil
+		return NewReplayProvider(dir)
 
 	default:
 		return nil, fmt.Errorf("unsupported provider type: %s", providerType)
 	}
 }
 
-// NewProviderFromString creates a provider from a string type name.
+// NewProviderFromString creates a provider from a string type name. The
+// provider is wrapped with its symbol normalizer, so callers pass and receive
+// canonical symbols such as "AAPL" and "BTC-USD". Configured symbol aliases
+// and provider symbols are applied on top of the normalizer.
 //
 // Args:
 //   - providerType: String name of the provider type
@@ -70,19 +122,54 @@ func NewProvider(providerType ProviderType, cfg *config.Config) (data.DataProvid
 //   - data.DataProvider: The created provider
 //   - error: Any error encountered
 func NewProviderFromString(providerType string, cfg *config.Config) (data.DataProvider, error) {
+	var pt ProviderType
 	switch providerType {
 	case "yahoo":
-		return NewProvider(ProviderYahoo, cfg)
+		pt = ProviderYahoo
 	case "tiingo":
-		return NewProvider(ProviderTiingo, cfg)
+		pt = ProviderTiingo
 	case "binance":
-		return NewProvider(ProviderBinance, cfg)
+		pt = ProviderBinance
+	case "coingecko":
+		pt = ProviderCoinGecko
+	case "replay":
+		pt = ProviderReplay
 	default:
 		return nil, fmt.Errorf("unknown provider type: %s", providerType)
 	}
+
+	provider, err := NewProvider(pt, cfg)
+	if err != nil {
+		return nil, err
+	}
+	normalizer := SymbolNormalizerFor(pt)
+	if cfg != nil {
+		normalizer = cfg.Aliases().Normalizer(normalizer)
+	}
+	return data.NewNormalizedProvider(provider, normalizer), nil
+}
+
+// SymbolNormalizerFor returns the symbol mapping for a provider type.
+//
+// Args:
+//   - providerType: Type of provider
+//
+// Returns:
+//   - data.SymbolNormalizer: Mapping between canonical and provider symbols
+func SymbolNormalizerFor(providerType ProviderType) data.SymbolNormalizer {
+	switch providerType {
+	case ProviderTiingo:
+		return data.TiingoSymbols{}
+	case ProviderBinance:
+		return data.BinanceSymbols{}
+	default:
+		// CoinGecko resolves canonical pairs to coin ids itself and replay
+		// recordings are named by canonical symbol
+		return data.YahooSymbols{}
+	}
 }
 
 // AvailableProviders returns a list of all available provider types.
 func AvailableProviders() []ProviderType {
-	return []ProviderType{ProviderYahoo, ProviderTiingo, ProviderBinance}
+	return []ProviderType{ProviderYahoo, ProviderTiingo, ProviderBinance, ProviderCoinGecko, ProviderReplay}
 }
@@ -3,6 +3,7 @@ package providers
 import (
 	"testing"
 
+	"github.com/alexherrero/sherwood/backend/data"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -18,6 +19,8 @@ func TestNewProvider(t *testing.T) {
 		{"yahoo provider", ProviderYahoo, "yahoo", false},
 		{"tiingo provider", ProviderTiingo, "tiingo", false},
 		{"binance provider", ProviderBinance, "binance", false},
+		{"coingecko provider", ProviderCoinGecko, "coingecko", false},
+		{"replay provider without data directory", ProviderReplay, "", true},
 		{"unsupported provider", ProviderType("invalid"), "", true},
 	}
 
@@ -45,6 +48,8 @@ func TestNewProviderFromString(t *testing.T) {
 		{"yahoo string", "yahoo", "yahoo", false},
 		{"tiingo string", "tiingo", "tiingo", false},
 		{"binance string", "binance", "binance", false},
+		{"coingecko string", "coingecko", "coingecko", false},
+		{"replay string without data directory", "replay", "", true},
 		{"unknown string", "unknown", "", true},
 	}
 
@@ -57,15 +62,26 @@ func TestNewProviderFromString(t *testing.T) {
 			}
 			require.NoError(t, err)
 			assert.Equal(t, tt.wantName, provider.Name())
+			assert.IsType(t, &data.NormalizedProvider{}, provider)
 		})
 	}
 }
 
+// TestSymbolNormalizerFor verifies each provider gets its symbol format.
+func TestSymbolNormalizerFor(t *testing.T) {
+	assert.Equal(t, "BTC-USD", SymbolNormalizerFor(ProviderYahoo).ToProvider("BTC-USD"))
+	assert.Equal(t, "btcusd", SymbolNormalizerFor(ProviderTiingo).ToProvider("BTC-USD"))
+	assert.Equal(t, "BTCUSDT", SymbolNormalizerFor(ProviderBinance).ToProvider("BTC-USD"))
+	assert.Equal(t, "BTC-USD", SymbolNormalizerFor(ProviderCoinGecko).ToProvider("btc/usd"))
+}
+
 // TestAvailableProviders verifies the list of available providers.
 func TestAvailableProviders(t *testing.T) {
 	providers := AvailableProviders()
 	assert.Contains(t, providers, ProviderYahoo)
 	assert.Contains(t, providers, ProviderTiingo)
 	assert.Contains(t, providers, ProviderBinance)
-	assert.Len(t, providers, 3)
+	assert.Contains(t, providers, ProviderCoinGecko)
+	assert.Contains(t, providers, ProviderReplay)
+	assert.Len(t, providers, 5)
 }
@@ -0,0 +1,333 @@
+// Package providers contains data provider implementations.
+package providers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// replayIntervals are the intervals a recording's bar spacing is matched
+// against, finest first.
+var replayIntervals = []string{"1m", "5m", "15m", "30m", "1h", "4h", "1d", "1w"}
+
+// replayTimeLayouts are the accepted CSV timestamp formats, besides Unix
+// seconds.
+var replayTimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+// replaySeries is one symbol's recorded candles.
+type replaySeries struct {
+	interval string
+	bars     []models.OHLCV
+}
+
+// ReplayProvider serves recorded candles from files instead of a live API,
+// so demos and offline development see the same data on every run.
+//
+// Each symbol is a file in the data directory named after its canonical
+// symbol: BTC-USD.csv or BTC-USD.json. CSV files have a header row naming
+// timestamp, open, high, low, close and, optionally, volume columns. JSON
+// files hold an array of OHLCV objects. A recording's interval is inferred
+// from its bar spacing.
+//
+// GetHistoricalData serves the recorded bars in the requested range.
+// GetLatestPrice returns the close of the current bar. The current bar only
+// moves when the trading engine ticks (see AdvanceTick), so API reads and
+// order fills between ticks all see the price the engine last saw.
+type ReplayProvider struct {
+	dir    string
+	mu     sync.Mutex
+	series map[string]*replaySeries
+	ticks  int // Engine ticks started, each stepping every recording one bar
+}
+
+// NewReplayProvider loads every recording in a directory.
+//
+// Args:
+//   - dir: Directory of .csv and .json recordings
+//
+// Returns:
+//   - *ReplayProvider: The provider instance
+//   - error: If the directory can't be read, holds no recordings, or a
+//     recording is invalid
+func NewReplayProvider(dir string) (*ReplayProvider, error) {
+	if dir == "" {
+		return nil, errors.New("replay provider requires a data directory")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read replay data directory: %w", err)
+	}
+
+	p := &ReplayProvider{dir: dir, series: make(map[string]*replaySeries)}
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".csv" && ext != ".json") {
+			continue
+		}
+		symbol := data.CanonicalSymbol(strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())))
+		if _, dup := p.series[symbol]; dup {
+			return nil, fmt.Errorf("replay data directory has more than one recording for %s", symbol)
+		}
+		series, err := loadReplaySeries(filepath.Join(dir, entry.Name()), symbol, ext)
+		if err != nil {
+			return nil, fmt.Errorf("invalid replay recording %s: %w", entry.Name(), err)
+		}
+		p.series[symbol] = series
+	}
+	if len(p.series) == 0 {
+		return nil, fmt.Errorf("replay data directory %s has no .csv or .json recordings", dir)
+	}
+	return p, nil
+}
+
+// loadReplaySeries reads and checks one recording.
+func loadReplaySeries(path, symbol, ext string) (*replaySeries, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var bars []models.OHLCV
+	if ext == ".json" {
+		err = json.NewDecoder(f).Decode(&bars)
+	} else {
+		bars, err = readReplayCSV(f)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(bars) == 0 {
+		return nil, errors.New("no bars")
+	}
+
+	sort.Slice(bars, func(i, j int) bool { return bars[i].Timestamp.Before(bars[j].Timestamp) })
+	var spacing time.Duration
+	for i := range bars {
+		bars[i].Symbol = symbol
+		bars[i].Timestamp = bars[i].Timestamp.UTC()
+		if bars[i].Close <= 0 {
+			return nil, fmt.Errorf("bar at %s has a non-positive close", bars[i].Timestamp.Format(time.RFC3339))
+		}
+		if i == 0 {
+			continue
+		}
+		gap := bars[i].Timestamp.Sub(bars[i-1].Timestamp)
+		if gap == 0 {
+			return nil, fmt.Errorf("more than one bar at %s", bars[i].Timestamp.Format(time.RFC3339))
+		}
+		if spacing == 0 || gap < spacing {
+			spacing = gap
+		}
+	}
+
+	// A single bar gives no spacing to go by, so it's taken as daily
+	interval := "1d"
+	if spacing > 0 {
+		interval = ""
+		for _, candidate := range replayIntervals {
+			if d, _ := data.IntervalDuration(candidate); d == spacing {
+				interval = candidate
+				break
+			}
+		}
+		if interval == "" {
+			return nil, fmt.Errorf("bar spacing %s is not a supported interval (%s)", spacing, strings.Join(replayIntervals, ", "))
+		}
+	}
+	return &replaySeries{interval: interval, bars: bars}, nil
+}
+
+// readReplayCSV parses a CSV recording, locating columns by header name.
+func readReplayCSV(r io.Reader) ([]models.OHLCV, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"timestamp", "open", "high", "low", "close"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing %s column", name)
+		}
+	}
+
+	var bars []models.OHLCV
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return bars, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		bar, err := parseReplayRecord(record, columns)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		bars = append(bars, bar)
+	}
+}
+
+// parseReplayRecord converts one CSV row to a bar.
+func parseReplayRecord(record []string, columns map[string]int) (models.OHLCV, error) {
+	var bar models.OHLCV
+	timestamp, err := parseReplayTime(record[columns["timestamp"]])
+	if err != nil {
+		return bar, err
+	}
+	bar.Timestamp = timestamp
+
+	fields := map[string]*float64{
+		"open": &bar.Open, "high": &bar.High, "low": &bar.Low, "close": &bar.Close, "volume": &bar.Volume,
+	}
+	for name, dst := range fields {
+		i, ok := columns[name]
+		if !ok {
+			continue
+		}
+		if *dst, err = strconv.ParseFloat(strings.TrimSpace(record[i]), 64); err != nil {
+			return bar, fmt.Errorf("invalid %s %q", name, record[i])
+		}
+	}
+	return bar, nil
+}
+
+// parseReplayTime parses a CSV timestamp in any of replayTimeLayouts or as
+// Unix seconds.
+func parseReplayTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if secs, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(secs, 0).UTC(), nil
+	}
+	for _, layout := range replayTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+}
+
+// Name returns the provider name.
+func (p *ReplayProvider) Name() string {
+	return "replay"
+}
+
+// lookup returns a symbol's recording.
+func (p *ReplayProvider) lookup(symbol string) (*replaySeries, error) {
+	series, ok := p.series[data.CanonicalSymbol(symbol)]
+	if !ok {
+		return nil, newProviderError("replay", KindNotFound, nil, "no recording for %s in %s", symbol, p.dir)
+	}
+	return series, nil
+}
+
+// GetHistoricalData returns the recorded bars in a range. Intervals coarser
+// than the recording are resampled from it; finer ones are rejected.
+//
+// Args:
+//   - symbol: Canonical symbol (e.g., "BTC-USD")
+//   - start: Start time
+//   - end: End time
+//   - interval: Time interval (e.g., "1h", "1d")
+//
+// Returns:
+//   - []models.OHLCV: Recorded bars in the range
+//   - error: Any error encountered
+func (p *ReplayProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	series, err := p.lookup(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	from := sort.Search(len(series.bars), func(i int) bool { return !series.bars[i].Timestamp.Before(start) })
+	to := sort.Search(len(series.bars), func(i int) bool { return series.bars[i].Timestamp.After(end) })
+	if from >= to {
+		return nil, newProviderError("replay", KindNotFound, nil,
+			"recording for %s has no bars between %s and %s", symbol, start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+	bars := make([]models.OHLCV, to-from)
+	copy(bars, series.bars[from:to])
+
+	resampled, err := data.Resample(bars, series.interval, interval)
+	if err != nil {
+		return nil, newProviderError("replay", KindBadInput, err,
+			"recording for %s is %s and can't serve %s bars", symbol, series.interval, interval)
+	}
+	return resampled, nil
+}
+
+// AdvanceTick steps every recording to its next bar, starting over after the
+// last one. The trading engine calls it at the start of each tick; the first
+// tick stays on the first bar.
+func (p *ReplayProvider) AdvanceTick() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ticks++
+}
+
+// GetLatestPrice returns the close of the recording's current bar. It doesn't
+// move the replay forward; only AdvanceTick does.
+//
+// Args:
+//   - symbol: Canonical symbol
+//
+// Returns:
+//   - float64: The bar's close
+//   - error: If the symbol has no recording
+func (p *ReplayProvider) GetLatestPrice(symbol string) (float64, error) {
+	series, err := p.lookup(symbol)
+	if err != nil {
+		return 0.0, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	cursor := 0
+	if p.ticks > 0 {
+		cursor = (p.ticks - 1) % len(series.bars)
+	}
+	return series.bars[cursor].Close, nil
+}
+
+// GetTicker returns ticker information for a recorded symbol.
+//
+// Args:
+//   - symbol: Canonical symbol
+//
+// Returns:
+//   - *models.Ticker: Ticker information
+//   - error: If the symbol has no recording
+func (p *ReplayProvider) GetTicker(symbol string) (*models.Ticker, error) {
+	if _, err := p.lookup(symbol); err != nil {
+		return nil, err
+	}
+	canonical := data.CanonicalSymbol(symbol)
+	assetType := "stock"
+	if data.IsCryptoSymbol(canonical) {
+		assetType = "crypto"
+	}
+	return &models.Ticker{
+		Symbol:    canonical,
+		Name:      canonical,
+		AssetType: assetType,
+		Exchange:  "replay",
+	}, nil
+}
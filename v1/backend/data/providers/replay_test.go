@@ -0,0 +1,152 @@
+package providers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeReplayFiles writes recordings into a fresh directory.
+func writeReplayFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+const replayHourlyCSV = `timestamp,open,high,low,close,volume
+2024-01-01T02:00:00Z,102,104,101,103,30
+2024-01-01T00:00:00Z,100,102,99,101,10
+2024-01-01T01:00:00Z,101,103,100,102,20
+2024-01-01T03:00:00Z,103,105,102,104,40
+`
+
+func TestReplayProvider_GetHistoricalData(t *testing.T) {
+	dir := writeReplayFiles(t, map[string]string{"BTC-USD.csv": replayHourlyCSV})
+	p, err := NewReplayProvider(dir)
+	require.NoError(t, err)
+
+	start := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)
+	bars, err := p.GetHistoricalData("btc/usd", start, end, "1h")
+	require.NoError(t, err)
+	require.Len(t, bars, 2)
+	assert.Equal(t, "BTC-USD", bars[0].Symbol)
+	assert.Equal(t, start, bars[0].Timestamp)
+	assert.Equal(t, 102.0, bars[0].Close)
+	assert.Equal(t, 103.0, bars[1].Close)
+
+	// Coarser intervals are resampled from the recording
+	all := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	bars, err = p.GetHistoricalData("BTC-USD", start.Add(-time.Hour), all, "4h")
+	require.NoError(t, err)
+	require.Len(t, bars, 1)
+	assert.Equal(t, 100.0, bars[0].Open)
+	assert.Equal(t, 105.0, bars[0].High)
+	assert.Equal(t, 99.0, bars[0].Low)
+	assert.Equal(t, 104.0, bars[0].Close)
+	assert.Equal(t, 100.0, bars[0].Volume)
+
+	_, err = p.GetHistoricalData("BTC-USD", start, end, "5m")
+	var pe *ProviderError
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, KindBadInput, pe.Kind)
+
+	_, err = p.GetHistoricalData("BTC-USD", all, all.Add(time.Hour), "1h")
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, KindNotFound, pe.Kind)
+}
+
+func TestReplayProvider_GetLatestPrice(t *testing.T) {
+	dir := writeReplayFiles(t, map[string]string{
+		"BTC-USD.csv": replayHourlyCSV,
+		"AAPL.json": `[
+			{"timestamp": "2024-01-01T00:00:00Z", "open": 1, "high": 1, "low": 1, "close": 190},
+			{"timestamp": "2024-01-02T00:00:00Z", "open": 1, "high": 1, "low": 1, "close": 191}
+		]`,
+		"notes.txt": "ignored",
+	})
+	p, err := NewReplayProvider(dir)
+	require.NoError(t, err)
+
+	// Reads don't move the replay forward
+	for range 3 {
+		price, err := p.GetLatestPrice("BTC-USD")
+		require.NoError(t, err)
+		assert.Equal(t, 101.0, price)
+	}
+
+	// Each tick steps every recording, starting over after the last bar; the
+	// first tick stays on the first bar
+	var prices, aapl []float64
+	for range 5 {
+		p.AdvanceTick()
+		price, err := p.GetLatestPrice("BTC-USD")
+		require.NoError(t, err)
+		prices = append(prices, price)
+		price, err = p.GetLatestPrice("AAPL")
+		require.NoError(t, err)
+		aapl = append(aapl, price)
+	}
+	assert.Equal(t, []float64{101, 102, 103, 104, 101}, prices)
+	assert.Equal(t, []float64{190, 191, 190, 191, 190}, aapl)
+
+	ticker, err := p.GetTicker("btc-usd")
+	require.NoError(t, err)
+	assert.Equal(t, "BTC-USD", ticker.Symbol)
+	assert.Equal(t, "crypto", ticker.AssetType)
+
+	_, err = p.GetLatestPrice("ETH-USD")
+	var pe *ProviderError
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, KindNotFound, pe.Kind)
+}
+
+func TestNewReplayProvider_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{"no recordings", map[string]string{"README.md": "x"}, "no .csv or .json recordings"},
+		{"missing column", map[string]string{"SPY.csv": "timestamp,open,high,low\n2024-01-01,1,1,1\n"}, "missing close column"},
+		{"bad timestamp", map[string]string{"SPY.csv": "timestamp,open,high,low,close\nyesterday,1,1,1,1\n"}, "line 2: invalid timestamp"},
+		{"bad price", map[string]string{"SPY.csv": "timestamp,open,high,low,close\n2024-01-01,1,1,1,x\n"}, `invalid close "x"`},
+		{"non-positive close", map[string]string{"SPY.csv": "timestamp,open,high,low,close\n2024-01-01,1,1,1,0\n"}, "non-positive close"},
+		{"duplicate bar", map[string]string{"SPY.csv": "timestamp,open,high,low,close\n2024-01-01,1,1,1,1\n2024-01-01,1,1,1,1\n"}, "more than one bar"},
+		{"unsupported spacing", map[string]string{"SPY.csv": "timestamp,open,high,low,close\n1704067200,1,1,1,1\n1704067207,1,1,1,1\n"}, "not a supported interval"},
+		{"duplicate symbol", map[string]string{"SPY.csv": "timestamp,open,high,low,close\n2024-01-01,1,1,1,1\n", "spy.json": `[]`}, "more than one recording for SPY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReplayProvider(writeReplayFiles(t, tt.files))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	_, err := NewReplayProvider(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestNewProviderFromString_Replay(t *testing.T) {
+	dir := writeReplayFiles(t, map[string]string{"BTC-USD.csv": replayHourlyCSV})
+	cfg := &config.Config{ReplayDataDir: dir}
+
+	provider, err := NewProviderFromString("replay", cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "replay", provider.Name())
+
+	price, err := provider.GetLatestPrice("BTC-USD")
+	require.NoError(t, err)
+	assert.Equal(t, 101.0, price)
+}
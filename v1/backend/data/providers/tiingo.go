@@ -2,11 +2,13 @@
 package providers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"time"
 
 	"github.com/alexherrero/sherwood/backend/models"
@@ -22,6 +24,7 @@ const (
 type TiingoProvider struct {
 	apiKey      string
 	httpClient  *http.Client
+	timeout     time.Duration
 	rateLimiter time.Time
 	minInterval time.Duration
 }
@@ -35,10 +38,9 @@ type TiingoProvider struct {
 //   - *TiingoProvider: The provider instance
 func NewTiingoProvider(apiKey string) *TiingoProvider {
 	return &TiingoProvider{
-		apiKey: apiKey,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		apiKey:      apiKey,
+		httpClient:  &http.Client{},
+		timeout:     DefaultRequestTimeout,
 		rateLimiter: time.Time{},
 		minInterval: 100 * time.Millisecond, // ~10 requests/second
 	}
@@ -49,6 +51,18 @@ func (p *TiingoProvider) Name() string {
 	return "tiingo"
 }
 
+// SupportsInterval reports whether Tiingo can serve the interval.
+// The EOD API only provides daily data.
+func (p *TiingoProvider) SupportsInterval(interval string) bool {
+	return interval == "1d" || interval == "daily"
+}
+
+// SetTimeout sets the per-request timeout. Zero disables it, leaving requests
+// bounded only by the caller's context.
+func (p *TiingoProvider) SetTimeout(timeout time.Duration) {
+	p.timeout = timeout
+}
+
 // rateLimit ensures we don't exceed API rate limits.
 func (p *TiingoProvider) rateLimit() {
 	if !p.rateLimiter.IsZero() {
@@ -61,9 +75,9 @@ func (p *TiingoProvider) rateLimit() {
 }
 
 // doRequest performs an authenticated HTTP request to Tiingo API.
-func (p *TiingoProvider) doRequest(endpoint string, params url.Values) ([]byte, error) {
+func (p *TiingoProvider) doRequest(ctx context.Context, endpoint string, params url.Values) ([]byte, error) {
 	if p.apiKey == "" {
-		return nil, fmt.Errorf("tiingo API key is required (get free at tiingo.com)")
+		return nil, newProviderError("tiingo", KindUnauthorized, nil, "API key is required (get free at tiingo.com)")
 	}
 
 	p.rateLimit()
@@ -73,7 +87,10 @@ func (p *TiingoProvider) doRequest(endpoint string, params url.Values) ([]byte,
 		reqURL = fmt.Sprintf("%s?%s", reqURL, params.Encode())
 	}
 
-	req, err := http.NewRequest("GET", reqURL, nil)
+	ctx, cancel := withRequestTimeout(ctx, p.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -83,17 +100,18 @@ func (p *TiingoProvider) doRequest(endpoint string, params url.Values) ([]byte,
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, newProviderError("tiingo", KindUnavailable, err, "request failed")
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, newProviderError("tiingo", KindUnavailable, err, "failed to read response")
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		return nil, newProviderError("tiingo", KindFromHTTPStatus(resp.StatusCode), nil,
+			"API error (status %d): %s", resp.StatusCode, string(body))
 	}
 
 	return body, nil
@@ -113,6 +131,12 @@ type tiingoPriceData struct {
 	AdjClose float64 `json:"adjClose"`
 }
 
+// usable reports whether a row carries a date and a price. A 200 response
+// whose rows have neither means Tiingo's format changed.
+func (d tiingoPriceData) usable() bool {
+	return d.Date != "" && (d.Close > 0 || d.AdjClose > 0)
+}
+
 // tiingoMetaData represents Tiingo's ticker metadata response.
 type tiingoMetaData struct {
 	Ticker      string `json:"ticker"`
@@ -133,9 +157,14 @@ type tiingoMetaData struct {
 //   - []models.OHLCV: Historical data
 //   - error: Any error encountered
 func (p *TiingoProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	return p.GetHistoricalDataContext(context.Background(), symbol, start, end, interval)
+}
+
+// GetHistoricalDataContext fetches OHLCV data from Tiingo, bound to ctx.
+func (p *TiingoProvider) GetHistoricalDataContext(ctx context.Context, symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
 	// Tiingo EOD API only supports daily data
 	if interval != "1d" && interval != "daily" {
-		return nil, fmt.Errorf("tiingo EOD API only supports daily interval (1d), got: %s", interval)
+		return nil, newProviderError("tiingo", KindBadInput, nil, "EOD API only supports daily interval (1d), got: %s", interval)
 	}
 
 	params := url.Values{}
@@ -143,25 +172,28 @@ func (p *TiingoProvider) GetHistoricalData(symbol string, start, end time.Time,
 	params.Set("endDate", end.Format("2006-01-02"))
 
 	endpoint := fmt.Sprintf("/tiingo/daily/%s/prices", symbol)
-	body, err := p.doRequest(endpoint, params)
+	body, err := p.doRequest(ctx, endpoint, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch historical data for %s: %w", symbol, err)
 	}
 
 	var priceData []tiingoPriceData
 	if err := json.Unmarshal(body, &priceData); err != nil {
-		return nil, fmt.Errorf("failed to parse response for %s: %w", symbol, err)
+		return nil, newBadResponseError("tiingo", body, err, "failed to parse response for %s", symbol)
 	}
 
 	if len(priceData) == 0 {
-		return nil, fmt.Errorf("no data returned for symbol %s", symbol)
+		return nil, newProviderError("tiingo", KindNotFound, nil, "no data returned for symbol %s", symbol)
+	}
+	if !slices.ContainsFunc(priceData, tiingoPriceData.usable) {
+		return nil, newBadResponseError("tiingo", body, nil, "no usable price fields in response for %s", symbol)
 	}
 
 	ohlcvData := make([]models.OHLCV, len(priceData))
 	for i, pd := range priceData {
 		timestamp, err := time.Parse(time.RFC3339, pd.Date)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse date for %s: %w", symbol, err)
+			return nil, newBadResponseError("tiingo", body, err, "failed to parse date for %s", symbol)
 		}
 		ohlcvData[i] = models.OHLCV{
 			Timestamp: timestamp,
@@ -186,23 +218,32 @@ func (p *TiingoProvider) GetHistoricalData(symbol string, start, end time.Time,
 //   - float64: Latest closing price
 //   - error: Any error encountered
 func (p *TiingoProvider) GetLatestPrice(symbol string) (float64, error) {
+	return p.GetLatestPriceContext(context.Background(), symbol)
+}
+
+// GetLatestPriceContext fetches the latest price from Tiingo, bound to ctx.
+func (p *TiingoProvider) GetLatestPriceContext(ctx context.Context, symbol string) (float64, error) {
 	endpoint := fmt.Sprintf("/tiingo/daily/%s/prices", symbol)
-	body, err := p.doRequest(endpoint, nil)
+	body, err := p.doRequest(ctx, endpoint, nil)
 	if err != nil {
 		return 0.0, fmt.Errorf("failed to fetch price for %s: %w", symbol, err)
 	}
 
 	var priceData []tiingoPriceData
 	if err := json.Unmarshal(body, &priceData); err != nil {
-		return 0.0, fmt.Errorf("failed to parse response for %s: %w", symbol, err)
+		return 0.0, newBadResponseError("tiingo", body, err, "failed to parse response for %s", symbol)
 	}
 
 	if len(priceData) == 0 {
-		return 0.0, fmt.Errorf("no price data returned for %s", symbol)
+		return 0.0, newProviderError("tiingo", KindNotFound, nil, "no price data returned for %s", symbol)
 	}
 
 	// Return the most recent adjusted close price
-	return priceData[len(priceData)-1].AdjClose, nil
+	latest := priceData[len(priceData)-1]
+	if !latest.usable() || latest.AdjClose <= 0 {
+		return 0.0, newBadResponseError("tiingo", body, nil, "no usable price fields in response for %s", symbol)
+	}
+	return latest.AdjClose, nil
 }
 
 // GetTicker fetches ticker information from Tiingo.
@@ -214,15 +255,20 @@ func (p *TiingoProvider) GetLatestPrice(symbol string) (float64, error) {
 //   - *models.Ticker: Ticker information
 //   - error: Any error encountered
 func (p *TiingoProvider) GetTicker(symbol string) (*models.Ticker, error) {
+	return p.GetTickerContext(context.Background(), symbol)
+}
+
+// GetTickerContext fetches ticker information from Tiingo, bound to ctx.
+func (p *TiingoProvider) GetTickerContext(ctx context.Context, symbol string) (*models.Ticker, error) {
 	endpoint := fmt.Sprintf("/tiingo/daily/%s", symbol)
-	body, err := p.doRequest(endpoint, nil)
+	body, err := p.doRequest(ctx, endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch ticker info for %s: %w", symbol, err)
 	}
 
 	var meta tiingoMetaData
 	if err := json.Unmarshal(body, &meta); err != nil {
-		return nil, fmt.Errorf("failed to parse ticker info for %s: %w", symbol, err)
+		return nil, newProviderError("tiingo", KindUnavailable, err, "failed to parse ticker info for %s", symbol)
 	}
 
 	return &models.Ticker{
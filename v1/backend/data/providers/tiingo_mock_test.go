@@ -2,6 +2,7 @@ package providers
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/http"
 	"testing"
@@ -133,3 +134,35 @@ func TestTiingoProvider_ErrorHandling_Mock(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "status 401")
 }
+
+// blockingRoundTripper holds each request until its context is done.
+type blockingRoundTripper struct{}
+
+func (blockingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestTiingoProvider_Timeout(t *testing.T) {
+	p := NewTiingoProvider("test-key")
+	p.httpClient.Transport = blockingRoundTripper{}
+	p.SetTimeout(20 * time.Millisecond)
+
+	_, err := p.GetLatestPrice("AAPL")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	kind, ok := ErrorKindOf(err)
+	assert.True(t, ok)
+	assert.Equal(t, KindUnavailable, kind)
+}
+
+func TestTiingoProvider_ContextCancelled(t *testing.T) {
+	p := NewTiingoProvider("test-key")
+	p.httpClient.Transport = blockingRoundTripper{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	_, err := p.GetTickerContext(ctx, "AAPL")
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -35,6 +35,14 @@ func TestTiingoProvider_UnsupportedInterval(t *testing.T) {
 	assert.Contains(t, err.Error(), "only supports daily interval")
 }
 
+// TestTiingoProvider_SupportsInterval verifies only daily data is advertised.
+func TestTiingoProvider_SupportsInterval(t *testing.T) {
+	var p data.IntervalProvider = NewTiingoProvider("test-key")
+	assert.True(t, p.SupportsInterval("1d"))
+	assert.True(t, p.SupportsInterval("daily"))
+	assert.False(t, p.SupportsInterval("1h"))
+}
+
 // Integration tests - require TIINGO_API_KEY environment variable
 // Get a free API key at: https://www.tiingo.com/
 
@@ -2,6 +2,7 @@
 package providers
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -13,17 +14,31 @@ import (
 	"github.com/alexherrero/sherwood/backend/models"
 )
 
-// YahooAPI defines the interface for Yahoo Finance API calls.
+// YahooAPI defines the interface for Yahoo Finance API calls. Chart requests
+// carry their context in params.Context.
 type YahooAPI interface {
-	GetQuote(symbol string) (*finance.Quote, error)
+	GetQuote(ctx context.Context, symbol string) (*finance.Quote, error)
 	GetChartData(params *chart.Params) ([]models.OHLCV, error)
 }
 
 // defaultYahooAPI implements YahooAPI using the finance-go library.
-type defaultYahooAPI struct{}
+type defaultYahooAPI struct {
+	// adjusted scales OHLC by the adjusted close factor when true.
+	adjusted bool
+}
 
-func (api *defaultYahooAPI) GetQuote(symbol string) (*finance.Quote, error) {
-	return quote.Get(symbol)
+func (api *defaultYahooAPI) GetQuote(ctx context.Context, symbol string) (*finance.Quote, error) {
+	iter := quote.ListP(&quote.Params{
+		Params:  finance.Params{Context: &ctx},
+		Symbols: []string{symbol},
+	})
+	if iter.Count() == 0 {
+		return nil, fmt.Errorf("can't find quote for symbol: %s", symbol)
+	}
+	if !iter.Next() {
+		return nil, iter.Err()
+	}
+	return iter.Quote(), nil
 }
 
 func (api *defaultYahooAPI) GetChartData(params *chart.Params) ([]models.OHLCV, error) {
@@ -38,17 +53,7 @@ func (api *defaultYahooAPI) GetChartData(params *chart.Params) ([]models.OHLCV,
 		if bar == nil {
 			continue
 		}
-
-		ohlcv := models.OHLCV{
-			Timestamp: time.Unix(int64(bar.Timestamp), 0),
-			Symbol:    params.Symbol,
-			Open:      bar.Open.InexactFloat64(),
-			High:      bar.High.InexactFloat64(),
-			Low:       bar.Low.InexactFloat64(),
-			Close:     bar.Close.InexactFloat64(),
-			Volume:    float64(bar.Volume),
-		}
-		ohlcvData = append(ohlcvData, ohlcv)
+		ohlcvData = append(ohlcvData, barToOHLCV(bar, params.Symbol, api.adjusted))
 	}
 
 	if iter.Err() != nil {
@@ -57,32 +62,114 @@ func (api *defaultYahooAPI) GetChartData(params *chart.Params) ([]models.OHLCV,
 	return ohlcvData, nil
 }
 
+// barToOHLCV converts a Yahoo chart bar to OHLCV.
+// When adjusted is true and the bar carries an adjusted close, open/high/low/close
+// are scaled by AdjClose/Close so the series is split and dividend adjusted.
+// Intraday bars have no adjusted close and are returned as-is.
+//
+// Args:
+//   - bar: Yahoo chart bar
+//   - symbol: Ticker symbol
+//   - adjusted: Whether to apply the adjustment factor
+//
+// Returns:
+//   - models.OHLCV: The converted bar
+func barToOHLCV(bar *finance.ChartBar, symbol string, adjusted bool) models.OHLCV {
+	ohlcv := models.OHLCV{
+		Timestamp: time.Unix(int64(bar.Timestamp), 0),
+		Symbol:    symbol,
+		Open:      bar.Open.InexactFloat64(),
+		High:      bar.High.InexactFloat64(),
+		Low:       bar.Low.InexactFloat64(),
+		Close:     bar.Close.InexactFloat64(),
+		Volume:    float64(bar.Volume),
+	}
+
+	adjClose := bar.AdjClose.InexactFloat64()
+	if adjusted && adjClose > 0 && ohlcv.Close > 0 {
+		factor := adjClose / ohlcv.Close
+		ohlcv.Open *= factor
+		ohlcv.High *= factor
+		ohlcv.Low *= factor
+		ohlcv.Close = adjClose
+	}
+	return ohlcv
+}
+
+// YahooOptions configures a YahooProvider.
+type YahooOptions struct {
+	// Adjusted returns split/dividend adjusted OHLC for daily and longer intervals.
+	Adjusted bool
+}
+
+// DefaultYahooOptions returns the default Yahoo options (raw, unadjusted
+// prices).
+func DefaultYahooOptions() YahooOptions {
+	return YahooOptions{}
+}
+
 // YahooProvider fetches market data from Yahoo Finance.
 // Uses the unofficial Yahoo Finance API via piquette/finance-go library.
 type YahooProvider struct {
-	api YahooAPI
+	api      YahooAPI
+	adjusted bool
+	// timeout bounds each request; zero leaves only the caller's context.
+	timeout time.Duration
 	// rateLimiter controls request rate to avoid API throttling.
 	lastRequest time.Time
 	minInterval time.Duration
+	// now is the clock used for lookback checks (overridable in tests).
+	now func() time.Time
 }
 
-// NewYahooProvider creates a new YahooProvider instance.
+// NewYahooProvider creates a new YahooProvider instance with default options.
 //
 // Returns:
 //   - *YahooProvider: The provider instance
 func NewYahooProvider() *YahooProvider {
+	return NewYahooProviderWithOptions(DefaultYahooOptions())
+}
+
+// NewYahooProviderWithOptions creates a new YahooProvider with the given options.
+//
+// Args:
+//   - opts: Provider options
+//
+// Returns:
+//   - *YahooProvider: The provider instance
+func NewYahooProviderWithOptions(opts YahooOptions) *YahooProvider {
 	return &YahooProvider{
-		api:         &defaultYahooAPI{},
+		api:         &defaultYahooAPI{adjusted: opts.Adjusted},
+		adjusted:    opts.Adjusted,
+		timeout:     DefaultRequestTimeout,
 		lastRequest: time.Time{},
 		minInterval: 200 * time.Millisecond, // ~5 requests/second max
+		now:         time.Now,
 	}
 }
 
+// Adjusted reports whether the provider returns adjusted prices.
+func (p *YahooProvider) Adjusted() bool {
+	return p.adjusted
+}
+
+// SetTimeout sets the per-request timeout. Zero disables it, leaving requests
+// bounded only by the caller's context.
+func (p *YahooProvider) SetTimeout(timeout time.Duration) {
+	p.timeout = timeout
+}
+
 // Name returns the provider name.
 func (p *YahooProvider) Name() string {
 	return "yahoo"
 }
 
+// SupportsInterval reports whether Yahoo can serve the interval.
+func (p *YahooProvider) SupportsInterval(interval string) bool {
+	_, err := mapInterval(interval)
+	return err == nil
+}
+
 // rateLimit ensures we don't exceed API rate limits.
 func (p *YahooProvider) rateLimit() {
 	if !p.lastRequest.IsZero() {
@@ -114,6 +201,8 @@ func mapInterval(interval string) (datetime.Interval, error) {
 		return datetime.FifteenMins, nil
 	case "30m":
 		return datetime.ThirtyMins, nil
+	case "60m":
+		return datetime.SixtyMins, nil
 	case "1h":
 		return datetime.OneHour, nil
 	case "1d":
@@ -127,11 +216,57 @@ func mapInterval(interval string) (datetime.Interval, error) {
 	case "3mo":
 		return datetime.ThreeMonth, nil
 	default:
-		return datetime.OneDay, fmt.Errorf("unsupported interval: %s", interval)
+		return datetime.OneDay, newProviderError("yahoo", KindBadInput, nil, "unsupported interval: %s", interval)
+	}
+}
+
+// yahooIntradayLookback is how far back Yahoo serves each intraday granularity.
+var yahooIntradayLookback = map[string]time.Duration{
+	"1m":  30 * 24 * time.Hour,
+	"2m":  60 * 24 * time.Hour,
+	"5m":  60 * 24 * time.Hour,
+	"15m": 60 * 24 * time.Hour,
+	"30m": 60 * 24 * time.Hour,
+	"60m": 730 * 24 * time.Hour,
+	"1h":  730 * 24 * time.Hour,
+}
+
+// yahoo1mMaxSpan is the widest range Yahoo returns in a single 1m request.
+const yahoo1mMaxSpan = 7 * 24 * time.Hour
+
+// checkIntradayRange verifies the requested range is within Yahoo's limits
+// for the interval. Daily and longer intervals are unrestricted.
+//
+// Args:
+//   - interval: Standard interval string
+//   - start: Start date
+//   - end: End date
+//   - now: Current time
+//
+// Returns:
+//   - error: If the range exceeds what Yahoo permits
+func checkIntradayRange(interval string, start, end, now time.Time) error {
+	lookback, ok := yahooIntradayLookback[interval]
+	if !ok {
+		return nil
+	}
+
+	if start.Before(now.Add(-lookback)) {
+		return newProviderError("yahoo", KindBadInput, nil, "only serves %s data for the last %d days; start %s is too old",
+			interval, int(lookback.Hours()/24), start.Format(time.DateOnly))
+	}
+
+	if interval == "1m" && end.Sub(start) > yahoo1mMaxSpan {
+		return newProviderError("yahoo", KindBadInput, nil, "limits 1m requests to %d days; requested %.1f days",
+			int(yahoo1mMaxSpan.Hours()/24), end.Sub(start).Hours()/24)
 	}
+
+	return nil
 }
 
 // GetHistoricalData fetches OHLCV data from Yahoo Finance.
+// Intraday intervals (1m, 5m, 15m, 60m, ...) are limited to Yahoo's lookback
+// windows and return an error when the range exceeds them.
 //
 // Args:
 //   - symbol: Ticker symbol (e.g., "AAPL", "BTC-USD")
@@ -143,6 +278,11 @@ func mapInterval(interval string) (datetime.Interval, error) {
 //   - []models.OHLCV: Historical data
 //   - error: Any error encountered
 func (p *YahooProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	return p.GetHistoricalDataContext(context.Background(), symbol, start, end, interval)
+}
+
+// GetHistoricalDataContext fetches OHLCV data from Yahoo Finance, bound to ctx.
+func (p *YahooProvider) GetHistoricalDataContext(ctx context.Context, symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
 	p.rateLimit()
 
 	mappedInterval, err := mapInterval(interval)
@@ -150,7 +290,15 @@ func (p *YahooProvider) GetHistoricalData(symbol string, start, end time.Time, i
 		return nil, fmt.Errorf("failed to map interval: %w", err)
 	}
 
+	if err := checkIntradayRange(interval, start, end, p.now()); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := withRequestTimeout(ctx, p.timeout)
+	defer cancel()
+
 	params := &chart.Params{
+		Params:   finance.Params{Context: &ctx},
 		Symbol:   symbol,
 		Interval: mappedInterval,
 		Start:    datetime.New(&start),
@@ -159,11 +307,11 @@ func (p *YahooProvider) GetHistoricalData(symbol string, start, end time.Time, i
 
 	ohlcvData, err := p.api.GetChartData(params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch chart data for %s: %w", symbol, err)
+		return nil, newProviderError("yahoo", KindUnavailable, err, "failed to fetch chart data for %s", symbol)
 	}
 
 	if len(ohlcvData) == 0 {
-		return nil, fmt.Errorf("no data returned for symbol %s", symbol)
+		return nil, newProviderError("yahoo", KindNotFound, nil, "no data returned for symbol %s", symbol)
 	}
 
 	return ohlcvData, nil
@@ -178,15 +326,23 @@ func (p *YahooProvider) GetHistoricalData(symbol string, start, end time.Time, i
 //   - float64: Current price
 //   - error: Any error encountered
 func (p *YahooProvider) GetLatestPrice(symbol string) (float64, error) {
+	return p.GetLatestPriceContext(context.Background(), symbol)
+}
+
+// GetLatestPriceContext fetches the current price from Yahoo Finance, bound
+// to ctx.
+func (p *YahooProvider) GetLatestPriceContext(ctx context.Context, symbol string) (float64, error) {
 	p.rateLimit()
 
-	q, err := p.api.GetQuote(symbol)
+	ctx, cancel := withRequestTimeout(ctx, p.timeout)
+	defer cancel()
+	q, err := p.api.GetQuote(ctx, symbol)
 	if err != nil {
-		return 0.0, fmt.Errorf("failed to fetch quote for %s: %w", symbol, err)
+		return 0.0, newProviderError("yahoo", KindUnavailable, err, "failed to fetch quote for %s", symbol)
 	}
 
 	if q == nil {
-		return 0.0, fmt.Errorf("no quote data returned for %s", symbol)
+		return 0.0, newProviderError("yahoo", KindNotFound, nil, "no quote data returned for %s", symbol)
 	}
 
 	return q.RegularMarketPrice, nil
@@ -201,15 +357,23 @@ func (p *YahooProvider) GetLatestPrice(symbol string) (float64, error) {
 //   - *models.Ticker: Ticker information
 //   - error: Any error encountered
 func (p *YahooProvider) GetTicker(symbol string) (*models.Ticker, error) {
+	return p.GetTickerContext(context.Background(), symbol)
+}
+
+// GetTickerContext fetches ticker information from Yahoo Finance, bound to
+// ctx.
+func (p *YahooProvider) GetTickerContext(ctx context.Context, symbol string) (*models.Ticker, error) {
 	p.rateLimit()
 
-	q, err := p.api.GetQuote(symbol)
+	ctx, cancel := withRequestTimeout(ctx, p.timeout)
+	defer cancel()
+	q, err := p.api.GetQuote(ctx, symbol)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch quote for %s: %w", symbol, err)
+		return nil, newProviderError("yahoo", KindUnavailable, err, "failed to fetch quote for %s", symbol)
 	}
 
 	if q == nil {
-		return nil, fmt.Errorf("no quote data returned for %s", symbol)
+		return nil, newProviderError("yahoo", KindNotFound, nil, "no quote data returned for %s", symbol)
 	}
 
 	// Determine asset type based on quote type
@@ -1,6 +1,7 @@
 package providers
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -17,7 +18,7 @@ type MockYahooAPI struct {
 	mock.Mock
 }
 
-func (m *MockYahooAPI) GetQuote(symbol string) (*finance.Quote, error) {
+func (m *MockYahooAPI) GetQuote(ctx context.Context, symbol string) (*finance.Quote, error) {
 	args := m.Called(symbol)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -4,6 +4,8 @@ import (
 	"testing"
 	"time"
 
+	finance "github.com/piquette/finance-go"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,6 +26,7 @@ func TestMapInterval(t *testing.T) {
 		{"5m", false},
 		{"15m", false},
 		{"30m", false},
+		{"60m", false},
 		{"1h", false},
 		{"1d", false},
 		{"1mo", false},
@@ -54,6 +57,87 @@ func TestYahooProvider_GetHistoricalData_InvalidInterval(t *testing.T) {
 	assert.Contains(t, err.Error(), "unsupported interval")
 }
 
+// TestBarToOHLCV verifies adjusted and unadjusted bar conversion.
+func TestBarToOHLCV(t *testing.T) {
+	bar := &finance.ChartBar{
+		Open:      decimal.NewFromFloat(100),
+		High:      decimal.NewFromFloat(110),
+		Low:       decimal.NewFromFloat(90),
+		Close:     decimal.NewFromFloat(100),
+		AdjClose:  decimal.NewFromFloat(50),
+		Volume:    1000,
+		Timestamp: 1700000000,
+	}
+
+	raw := barToOHLCV(bar, "AAPL", false)
+	assert.Equal(t, 100.0, raw.Open)
+	assert.Equal(t, 100.0, raw.Close)
+
+	adj := barToOHLCV(bar, "AAPL", true)
+	assert.InDelta(t, 50.0, adj.Open, 1e-9)
+	assert.InDelta(t, 55.0, adj.High, 1e-9)
+	assert.InDelta(t, 45.0, adj.Low, 1e-9)
+	assert.InDelta(t, 50.0, adj.Close, 1e-9)
+	assert.Equal(t, 1000.0, adj.Volume)
+
+	// Intraday bars carry no adjusted close and are left unchanged
+	bar.AdjClose = decimal.Zero
+	intraday := barToOHLCV(bar, "AAPL", true)
+	assert.Equal(t, 100.0, intraday.Close)
+}
+
+// TestYahooProvider_Options verifies adjusted prices are opt-in.
+func TestYahooProvider_Options(t *testing.T) {
+	assert.False(t, NewYahooProvider().Adjusted())
+	assert.True(t, NewYahooProviderWithOptions(YahooOptions{Adjusted: true}).Adjusted())
+}
+
+// TestCheckIntradayRange verifies Yahoo's intraday lookback limits.
+func TestCheckIntradayRange(t *testing.T) {
+	now := time.Date(2026, 3, 10, 16, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		interval string
+		start    time.Time
+		end      time.Time
+		wantErr  string
+	}{
+		{"daily unrestricted", "1d", now.AddDate(-10, 0, 0), now, ""},
+		{"1m within window", "1m", now.AddDate(0, 0, -5), now, ""},
+		{"1m span too wide", "1m", now.AddDate(0, 0, -10), now, "limits 1m requests to 7 days"},
+		{"1m too old", "1m", now.AddDate(0, 0, -40), now.AddDate(0, 0, -38), "last 30 days"},
+		{"5m within window", "5m", now.AddDate(0, 0, -59), now, ""},
+		{"15m too old", "15m", now.AddDate(0, 0, -61), now, "last 60 days"},
+		{"60m within window", "60m", now.AddDate(-1, 0, 0), now, ""},
+		{"60m too old", "60m", now.AddDate(-3, 0, 0), now, "last 730 days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkIntradayRange(tt.interval, tt.start, tt.end, now)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestYahooProvider_GetHistoricalData_IntradayRangeExceeded verifies the
+// range check runs before any API call.
+func TestYahooProvider_GetHistoricalData_IntradayRangeExceeded(t *testing.T) {
+	p := NewYahooProvider()
+	end := time.Now()
+	start := end.AddDate(0, 0, -90)
+
+	_, err := p.GetHistoricalData("AAPL", start, end, "5m")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "last 60 days")
+}
+
 // Integration tests - skipped by default, run with: go test -tags=integration
 // These tests make actual API calls.
 // NOTE: piquette/finance-go may have reliability issues with Yahoo's unofficial API.
@@ -0,0 +1,195 @@
+// Package data provides candle aggregation across intervals.
+package data
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/rs/zerolog/log"
+)
+
+// resampleSources are the intervals a coarser interval can be built from,
+// coarsest first so the fewest bars are fetched.
+var resampleSources = []string{"12h", "8h", "6h", "4h", "2h", "1h", "30m", "15m", "5m", "3m", "2m", "1m"}
+
+// Resample aggregates bars from a finer interval into a coarser one. Each
+// output bar opens at the first bar's open, closes at the last bar's close,
+// spans the highest high and lowest low, and sums the volume. Buckets are
+// aligned to UTC (hours on the hour, days at midnight, weeks on Monday), so a
+// trailing bucket may still be forming.
+//
+// Args:
+//   - bars: Bars at the source interval, sorted by timestamp
+//   - from: Source interval (e.g., "1m")
+//   - to: Target interval (e.g., "15m"); must be a whole multiple of from
+//
+// Returns:
+//   - []models.OHLCV: Aggregated bars stamped with their bucket start
+//   - error: If either interval is unknown or to is not a multiple of from
+func Resample(bars []models.OHLCV, from, to string) ([]models.OHLCV, error) {
+	src, target, err := resampleDurations(from, to)
+	if err != nil {
+		return nil, err
+	}
+	if src == target {
+		return bars, nil
+	}
+
+	resampled := make([]models.OHLCV, 0, len(bars)*int(src)/int(target)+1)
+	for _, bar := range bars {
+		bucket := bar.Timestamp.UTC().Truncate(target)
+		last := len(resampled) - 1
+		if last >= 0 && resampled[last].Timestamp.Equal(bucket) {
+			agg := &resampled[last]
+			agg.High = max(agg.High, bar.High)
+			agg.Low = min(agg.Low, bar.Low)
+			agg.Close = bar.Close
+			agg.Volume += bar.Volume
+			continue
+		}
+		resampled = append(resampled, models.OHLCV{
+			Timestamp: bucket,
+			Symbol:    bar.Symbol,
+			Open:      bar.Open,
+			High:      bar.High,
+			Low:       bar.Low,
+			Close:     bar.Close,
+			Volume:    bar.Volume,
+		})
+	}
+	return resampled, nil
+}
+
+// resampleDurations validates a resampling and returns both candle lengths.
+// Month intervals are calendar based, so they cannot be built from bars.
+func resampleDurations(from, to string) (time.Duration, time.Duration, error) {
+	src, ok := IntervalDuration(from)
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown source interval '%s'", from)
+	}
+	target, ok := IntervalDuration(to)
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown target interval '%s'", to)
+	}
+	if strings.HasSuffix(to, "mo") || strings.HasSuffix(to, "M") {
+		return 0, 0, fmt.Errorf("cannot resample to calendar interval '%s'", to)
+	}
+	if target < src || target%src != 0 {
+		return 0, 0, fmt.Errorf("interval '%s' is not a whole multiple of '%s'", to, from)
+	}
+	return src, target, nil
+}
+
+// ResampleSource picks the coarsest supported interval that a target
+// interval can be resampled from.
+//
+// Args:
+//   - interval: Target interval
+//   - supports: Reports whether the provider serves an interval natively
+//
+// Returns:
+//   - string: The source interval
+//   - bool: False if no supported interval divides the target
+func ResampleSource(interval string, supports func(string) bool) (string, bool) {
+	for _, from := range resampleSources {
+		if _, _, err := resampleDurations(from, interval); err == nil && supports(from) {
+			return from, true
+		}
+	}
+	return "", false
+}
+
+// ResamplingProvider wraps a DataProvider and serves intervals the provider
+// doesn't support natively by fetching a finer interval and resampling it.
+type ResamplingProvider struct {
+	provider DataProvider
+}
+
+// NewResamplingProvider creates a resampling provider.
+//
+// Args:
+//   - provider: The underlying data provider
+//
+// Returns:
+//   - *ResamplingProvider: The wrapped provider
+func NewResamplingProvider(provider DataProvider) *ResamplingProvider {
+	return &ResamplingProvider{provider: provider}
+}
+
+// Name returns the wrapped provider's name.
+func (p *ResamplingProvider) Name() string {
+	return p.provider.Name()
+}
+
+// GetHistoricalData fetches bars, resampling when the interval isn't native.
+func (p *ResamplingProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	return p.GetHistoricalDataContext(context.Background(), symbol, start, end, interval)
+}
+
+// GetHistoricalDataContext fetches bars bound to ctx, resampling when the
+// interval isn't native.
+func (p *ResamplingProvider) GetHistoricalDataContext(ctx context.Context, symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	if p.nativeInterval(interval) {
+		return GetHistoricalDataContext(ctx, p.provider, symbol, start, end, interval)
+	}
+	from, ok := ResampleSource(interval, p.nativeInterval)
+	if !ok {
+		return GetHistoricalDataContext(ctx, p.provider, symbol, start, end, interval)
+	}
+
+	bars, err := GetHistoricalDataContext(ctx, p.provider, symbol, start, end, from)
+	if err != nil {
+		return bars, err
+	}
+	log.Debug().
+		Str("symbol", symbol).
+		Str("from", from).
+		Str("to", interval).
+		Int("bars", len(bars)).
+		Msg("Resampling provider bars")
+	return Resample(bars, from, interval)
+}
+
+// GetLatestPrice fetches the current price from the wrapped provider.
+func (p *ResamplingProvider) GetLatestPrice(symbol string) (float64, error) {
+	return p.provider.GetLatestPrice(symbol)
+}
+
+// GetLatestPriceContext fetches the current price from the wrapped provider,
+// bound to ctx.
+func (p *ResamplingProvider) GetLatestPriceContext(ctx context.Context, symbol string) (float64, error) {
+	return GetLatestPriceContext(ctx, p.provider, symbol)
+}
+
+// GetTicker fetches ticker information from the wrapped provider.
+func (p *ResamplingProvider) GetTicker(symbol string) (*models.Ticker, error) {
+	return p.provider.GetTicker(symbol)
+}
+
+// GetTickerContext fetches ticker information from the wrapped provider,
+// bound to ctx.
+func (p *ResamplingProvider) GetTickerContext(ctx context.Context, symbol string) (*models.Ticker, error) {
+	return GetTickerContext(ctx, p.provider, symbol)
+}
+
+// SupportsInterval reports whether the interval is served natively or can
+// be resampled from one that is.
+func (p *ResamplingProvider) SupportsInterval(interval string) bool {
+	if p.nativeInterval(interval) {
+		return true
+	}
+	_, ok := ResampleSource(interval, p.nativeInterval)
+	return ok
+}
+
+// nativeInterval reports whether the wrapped provider serves the interval.
+// Providers that don't report their intervals are assumed to serve all.
+func (p *ResamplingProvider) nativeInterval(interval string) bool {
+	if ip, ok := p.provider.(IntervalProvider); ok {
+		return ip.SupportsInterval(interval)
+	}
+	return true
+}
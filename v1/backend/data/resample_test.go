@@ -0,0 +1,148 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// barsEvery builds n bars spaced by step with rising prices.
+func barsEvery(symbol string, start time.Time, step time.Duration, n int) []models.OHLCV {
+	bars := make([]models.OHLCV, n)
+	for i := range bars {
+		price := 100 + float64(i)
+		bars[i] = models.OHLCV{
+			Timestamp: start.Add(time.Duration(i) * step),
+			Symbol:    symbol,
+			Open:      price,
+			High:      price + 0.5,
+			Low:       price - 0.5,
+			Close:     price + 0.25,
+			Volume:    10,
+		}
+	}
+	return bars
+}
+
+// TestResample_MinuteToHour verifies OHLCV aggregation and hour boundaries.
+func TestResample_MinuteToHour(t *testing.T) {
+	start := time.Date(2026, 3, 6, 14, 30, 0, 0, time.UTC)
+	bars := barsEvery("BTC-USD", start, time.Minute, 90)
+
+	hourly, err := Resample(bars, "1m", "1h")
+	require.NoError(t, err)
+	require.Len(t, hourly, 2)
+
+	first := hourly[0]
+	assert.Equal(t, time.Date(2026, 3, 6, 14, 0, 0, 0, time.UTC), first.Timestamp)
+	assert.Equal(t, "BTC-USD", first.Symbol)
+	assert.Equal(t, 100.0, first.Open)
+	assert.Equal(t, 129.5, first.High)
+	assert.Equal(t, 99.5, first.Low)
+	assert.Equal(t, 129.25, first.Close)
+	assert.Equal(t, 300.0, first.Volume)
+
+	second := hourly[1]
+	assert.Equal(t, time.Date(2026, 3, 6, 15, 0, 0, 0, time.UTC), second.Timestamp)
+	assert.Equal(t, 130.0, second.Open)
+	assert.Equal(t, 189.25, second.Close)
+	assert.Equal(t, 600.0, second.Volume)
+}
+
+// TestResample_HourToDay verifies bars split at UTC midnight and gaps are
+// skipped rather than filled.
+func TestResample_HourToDay(t *testing.T) {
+	start := time.Date(2026, 3, 6, 20, 0, 0, 0, time.UTC)
+	bars := barsEvery("BTC-USD", start, time.Hour, 8)
+	bars = append(bars, barsEvery("BTC-USD", start.AddDate(0, 0, 2), time.Hour, 1)...)
+
+	daily, err := Resample(bars, "1h", "1d")
+	require.NoError(t, err)
+	require.Len(t, daily, 3)
+
+	assert.Equal(t, time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC), daily[0].Timestamp)
+	assert.Equal(t, 40.0, daily[0].Volume)
+	assert.Equal(t, 103.25, daily[0].Close)
+
+	assert.Equal(t, time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC), daily[1].Timestamp)
+	assert.Equal(t, 104.0, daily[1].Open)
+	assert.Equal(t, 107.5, daily[1].High)
+	assert.Equal(t, 40.0, daily[1].Volume)
+
+	assert.Equal(t, time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC), daily[2].Timestamp)
+	assert.Equal(t, 10.0, daily[2].Volume)
+}
+
+// TestResample_Invalid verifies intervals must be known whole multiples.
+func TestResample_Invalid(t *testing.T) {
+	bars := barsEvery("BTC-USD", time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC), time.Minute, 4)
+
+	tests := []struct {
+		from, to string
+	}{
+		{"2m", "5m"},
+		{"1h", "1m"},
+		{"bogus", "1h"},
+		{"1h", "bogus"},
+		{"1d", "1mo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			_, err := Resample(bars, tt.from, tt.to)
+			assert.Error(t, err)
+		})
+	}
+
+	same, err := Resample(bars, "1m", "1m")
+	require.NoError(t, err)
+	assert.Len(t, same, 4)
+}
+
+// intervalLimitedProvider serves only the listed intervals.
+type intervalLimitedProvider struct {
+	mockDataProvider
+	intervals map[string]bool
+	requested []string
+}
+
+func (p *intervalLimitedProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	p.requested = append(p.requested, interval)
+	step, _ := IntervalDuration(interval)
+	return barsEvery(symbol, start, step, int(end.Sub(start)/step)), nil
+}
+
+func (p *intervalLimitedProvider) SupportsInterval(interval string) bool {
+	return p.intervals[interval]
+}
+
+// TestResamplingProvider verifies unsupported intervals are built from the
+// coarsest native interval that divides them.
+func TestResamplingProvider(t *testing.T) {
+	inner := &intervalLimitedProvider{intervals: map[string]bool{"1m": true, "5m": true, "1h": true, "1d": true}}
+	provider := NewResamplingProvider(inner)
+	start := time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, provider.SupportsInterval("1h"))
+	assert.True(t, provider.SupportsInterval("4h"))
+	assert.True(t, provider.SupportsInterval("15m"))
+	assert.False(t, provider.SupportsInterval("1mo"))
+
+	bars, err := provider.GetHistoricalDataContext(context.Background(), "BTC-USD", start, start.Add(8*time.Hour), "4h")
+	require.NoError(t, err)
+	assert.Len(t, bars, 2)
+	assert.Equal(t, 40.0, bars[0].Volume)
+
+	bars, err = provider.GetHistoricalData("BTC-USD", start, start.Add(time.Hour), "15m")
+	require.NoError(t, err)
+	assert.Len(t, bars, 4)
+
+	bars, err = provider.GetHistoricalData("BTC-USD", start, start.Add(2*time.Hour), "1h")
+	require.NoError(t, err)
+	assert.Len(t, bars, 2)
+
+	assert.Equal(t, []string{"1h", "5m", "1h"}, inner.requested)
+}
@@ -0,0 +1,117 @@
+package data
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// SignalStore provides persistence for logged strategy signals.
+type SignalStore interface {
+	SaveSignals(records []models.SignalRecord) error
+	QuerySignals(filter SignalFilter) ([]models.SignalRecord, int, error)
+}
+
+// SignalFilter narrows a signal query. Zero values match everything.
+type SignalFilter struct {
+	Symbol   string
+	Strategy string
+	Start    time.Time // Inclusive; zero = no lower bound
+	End      time.Time // Inclusive; zero = no upper bound
+	Limit    int       // 0 = no limit
+	Offset   int
+}
+
+// SQLSignalStore implements SignalStore using SQLite.
+type SQLSignalStore struct {
+	db *DB
+}
+
+// NewSignalStore creates a new SQL-based signal store.
+func NewSignalStore(db *DB) *SQLSignalStore {
+	return &SQLSignalStore{db: db}
+}
+
+// SaveSignals persists a batch of signal records in one transaction.
+//
+// Args:
+//   - records: Signals to record
+//
+// Returns:
+//   - error: Any error encountered (nothing is saved on error)
+func (s *SQLSignalStore) SaveSignals(records []models.SignalRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO signals (timestamp, symbol, type, strength, price, strategy_name, reason, executed)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	for _, r := range records {
+		// Stored in UTC so timestamps compare correctly as text
+		_, err := tx.Exec(query, r.Timestamp.UTC(), r.Symbol, r.Type, r.Strength, r.Price, r.StrategyName, r.Reason, r.Executed)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to save signal: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// QuerySignals returns signals matching the filter, newest first.
+//
+// Args:
+//   - filter: Symbol, strategy, time range and pagination
+//
+// Returns:
+//   - []models.SignalRecord: The requested page of signals
+//   - int: Total count of matching signals (before pagination)
+//   - error: Any error encountered
+func (s *SQLSignalStore) QuerySignals(filter SignalFilter) ([]models.SignalRecord, int, error) {
+	where := " WHERE 1=1"
+	var args []interface{}
+	if filter.Symbol != "" {
+		where += " AND symbol = ?"
+		args = append(args, filter.Symbol)
+	}
+	if filter.Strategy != "" {
+		where += " AND strategy_name = ?"
+		args = append(args, filter.Strategy)
+	}
+	if !filter.Start.IsZero() {
+		where += " AND timestamp >= ?"
+		args = append(args, filter.Start.UTC())
+	}
+	if !filter.End.IsZero() {
+		where += " AND timestamp <= ?"
+		args = append(args, filter.End.UTC())
+	}
+
+	var total int
+	if err := s.db.Get(&total, "SELECT COUNT(*) FROM signals"+where, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count signals: %w", err)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = -1 // SQLite: no limit
+	}
+	query := `SELECT id, timestamp, symbol, type, strength, price, strategy_name, reason, executed FROM signals` +
+		where + ` ORDER BY timestamp DESC, id DESC LIMIT ? OFFSET ?`
+
+	records := []models.SignalRecord{}
+	if err := s.db.Select(&records, query, append(args, limit, filter.Offset)...); err != nil {
+		return nil, 0, fmt.Errorf("failed to query signals: %w", err)
+	}
+
+	return records, total, nil
+}
@@ -0,0 +1,66 @@
+package data
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSignalStore_QuerySignals verifies batch saves, filters and pagination.
+func TestSignalStore_QuerySignals(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	store := NewSignalStore(db)
+	base := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	var records []models.SignalRecord
+	for i := 0; i < 6; i++ {
+		symbol, strategy := "AAPL", "ma_crossover"
+		if i%2 == 1 {
+			symbol = "BTC-USD"
+		}
+		if i >= 4 {
+			strategy = "rsi_momentum"
+		}
+		records = append(records, models.SignalRecord{
+			Timestamp:    base.Add(time.Duration(i) * time.Hour),
+			Symbol:       symbol,
+			Type:         models.SignalHold,
+			StrategyName: strategy,
+			Executed:     i == 2,
+		})
+	}
+	require.NoError(t, store.SaveSignals(records))
+	require.NoError(t, store.SaveSignals(nil))
+
+	all, total, err := store.QuerySignals(SignalFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 6, total)
+	require.Len(t, all, 6)
+	assert.True(t, all[0].Timestamp.Equal(base.Add(5*time.Hour)), "newest first")
+	assert.True(t, all[3].Executed)
+
+	aapl, total, err := store.QuerySignals(SignalFilter{Symbol: "AAPL", Strategy: "ma_crossover"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Len(t, aapl, 2)
+
+	window, total, err := store.QuerySignals(SignalFilter{
+		Start: base.Add(time.Hour),
+		End:   base.Add(3 * time.Hour),
+		Limit: 2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, window, 2)
+	assert.True(t, window[0].Timestamp.Equal(base.Add(3*time.Hour)))
+
+	page2, _, err := store.QuerySignals(SignalFilter{Limit: 4, Offset: 4})
+	require.NoError(t, err)
+	assert.Len(t, page2, 2)
+}
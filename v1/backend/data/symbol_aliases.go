@@ -0,0 +1,209 @@
+// Package data provides user-defined symbol aliases on top of the
+// provider-derived symbol normalization.
+package data
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SymbolAliases are user-defined symbol names. Names map to canonical
+// symbols, so a symbol can be referred to by a preferred name wherever
+// symbols are accepted, and the first name listed for a symbol is its
+// display name. Provider symbols map canonical symbols to the exact symbol a
+// provider uses, overriding its normalizer. A nil *SymbolAliases has no
+// aliases, and its Resolve is plain CanonicalSymbol, so it is the one
+// resolver for every symbol read from users or configuration.
+type SymbolAliases struct {
+	names     map[string]string // alias -> canonical
+	display   map[string]string // canonical -> first alias
+	provider  map[string]string // canonical -> provider symbol
+	canonical map[string]string // provider symbol -> canonical
+}
+
+// ParseSymbolAliases parses alias lists of comma-separated FROM:TO entries.
+// Alias names and canonical symbols are normalized with CanonicalSymbol;
+// provider symbols are kept as written.
+//
+// Args:
+//   - aliases: NAME:CANONICAL entries (e.g., "BTCUSDT:BTC-USD,XBT:BTC-USD")
+//   - providerSymbols: CANONICAL:PROVIDER_SYMBOL entries (e.g., "BTC-USD:XBTUSD")
+//
+// Returns:
+//   - *SymbolAliases: The aliases
+//   - error: If an entry is malformed or aliases collide
+func ParseSymbolAliases(aliases, providerSymbols string) (*SymbolAliases, error) {
+	a := &SymbolAliases{
+		names:     make(map[string]string),
+		display:   make(map[string]string),
+		provider:  make(map[string]string),
+		canonical: make(map[string]string),
+	}
+
+	for _, entry := range splitAliasEntries(aliases) {
+		from, to, err := parseAliasEntry(entry, "NAME:CANONICAL")
+		if err != nil {
+			return nil, err
+		}
+		name, target := CanonicalSymbol(from), CanonicalSymbol(to)
+		switch {
+		case name == target:
+			return nil, fmt.Errorf("invalid symbol alias %q: %s is an alias of itself", entry, name)
+		case a.names[name] != "":
+			return nil, fmt.Errorf("invalid symbol alias %q: %s is already an alias of %s", entry, name, a.names[name])
+		}
+		a.names[name] = target
+		if _, ok := a.display[target]; !ok {
+			a.display[target] = name
+		}
+	}
+	// A name that is also a target would make lookups depend on order
+	for name, target := range a.names {
+		if _, chained := a.names[target]; chained {
+			return nil, fmt.Errorf("invalid symbol alias %s:%s: %s is itself an alias", name, target, target)
+		}
+	}
+
+	for _, entry := range splitAliasEntries(providerSymbols) {
+		from, to, err := parseAliasEntry(entry, "CANONICAL:PROVIDER_SYMBOL")
+		if err != nil {
+			return nil, err
+		}
+		canonical := CanonicalSymbol(from)
+		switch {
+		case a.names[canonical] != "":
+			return nil, fmt.Errorf("invalid provider symbol %q: %s is an alias of %s, not a canonical symbol", entry, canonical, a.names[canonical])
+		case a.provider[canonical] != "":
+			return nil, fmt.Errorf("invalid provider symbol %q: %s already maps to %s", entry, canonical, a.provider[canonical])
+		case a.canonical[to] != "":
+			return nil, fmt.Errorf("invalid provider symbol %q: %s is already the provider symbol of %s", entry, to, a.canonical[to])
+		}
+		a.provider[canonical] = to
+		a.canonical[to] = canonical
+	}
+	return a, nil
+}
+
+// splitAliasEntries splits a comma-separated list, dropping empty entries.
+func splitAliasEntries(s string) []string {
+	var entries []string
+	for _, entry := range strings.Split(s, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// parseAliasEntry splits a FROM:TO entry.
+func parseAliasEntry(entry, format string) (from, to string, err error) {
+	from, to, ok := strings.Cut(entry, ":")
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if !ok || from == "" || to == "" || strings.Contains(to, ":") {
+		return "", "", fmt.Errorf("invalid symbol alias %q: must be %s", entry, format)
+	}
+	return from, to, nil
+}
+
+// Resolve normalizes user input to a canonical symbol, following an alias
+// if the input is one.
+//
+// Args:
+//   - symbol: Symbol or alias in any supported form (e.g., "btcusdt")
+//
+// Returns:
+//   - string: Canonical symbol (e.g., "BTC-USD")
+func (a *SymbolAliases) Resolve(symbol string) string {
+	canonical := CanonicalSymbol(symbol)
+	if a == nil {
+		return canonical
+	}
+	if target, ok := a.names[canonical]; ok {
+		return target
+	}
+	return canonical
+}
+
+// Display returns the name a canonical symbol is shown as: its first alias,
+// or the symbol itself when it has none.
+//
+// Args:
+//   - symbol: Canonical symbol (e.g., "BTC-USD")
+//
+// Returns:
+//   - string: Display name (e.g., "BITCOIN")
+func (a *SymbolAliases) Display(symbol string) string {
+	if a != nil {
+		if name, ok := a.display[symbol]; ok {
+			return name
+		}
+	}
+	return symbol
+}
+
+// DisplayNames returns the display name of every aliased canonical symbol.
+//
+// Returns:
+//   - map[string]string: Display names keyed by canonical symbol (empty if none)
+func (a *SymbolAliases) DisplayNames() map[string]string {
+	names := make(map[string]string)
+	if a != nil {
+		for symbol, name := range a.display {
+			names[symbol] = name
+		}
+	}
+	return names
+}
+
+// ResolveAll resolves each symbol in a list.
+func (a *SymbolAliases) ResolveAll(symbols []string) []string {
+	resolved := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		resolved[i] = a.Resolve(symbol)
+	}
+	return resolved
+}
+
+// Empty reports whether there are no aliases or provider symbols.
+func (a *SymbolAliases) Empty() bool {
+	return a == nil || (len(a.names) == 0 && len(a.provider) == 0)
+}
+
+// Normalizer wraps a provider's normalizer so aliases are resolved before
+// conversion and provider symbols override it in both directions.
+//
+// Args:
+//   - normalizer: The provider's own mapping
+//
+// Returns:
+//   - SymbolNormalizer: normalizer with the aliases applied, or normalizer
+//     itself when there are none
+func (a *SymbolAliases) Normalizer(normalizer SymbolNormalizer) SymbolNormalizer {
+	if a.Empty() {
+		return normalizer
+	}
+	return aliasedNormalizer{aliases: a, normalizer: normalizer}
+}
+
+// aliasedNormalizer applies SymbolAliases around a provider's normalizer.
+type aliasedNormalizer struct {
+	aliases    *SymbolAliases
+	normalizer SymbolNormalizer
+}
+
+// ToProvider converts a symbol or alias to the provider's format.
+func (n aliasedNormalizer) ToProvider(symbol string) string {
+	canonical := n.aliases.Resolve(symbol)
+	if providerSymbol, ok := n.aliases.provider[canonical]; ok {
+		return providerSymbol
+	}
+	return n.normalizer.ToProvider(canonical)
+}
+
+// ToCanonical converts a provider symbol to canonical form.
+func (n aliasedNormalizer) ToCanonical(symbol string) string {
+	if canonical, ok := n.aliases.canonical[symbol]; ok {
+		return canonical
+	}
+	return n.normalizer.ToCanonical(symbol)
+}
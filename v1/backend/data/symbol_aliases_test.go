@@ -0,0 +1,77 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSymbolAliases_Resolve verifies aliases resolve to canonical symbols in
+// any case, and other symbols are only normalized.
+func TestSymbolAliases_Resolve(t *testing.T) {
+	aliases, err := ParseSymbolAliases("BTCUSDT:BTC-USD, xbt:btc/usd,SPX:SPY", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "BTC-USD", aliases.Resolve("btcusdt"))
+	assert.Equal(t, "BTC-USD", aliases.Resolve("XBT"))
+	assert.Equal(t, "SPY", aliases.Resolve(" spx "))
+	assert.Equal(t, "ETH-USD", aliases.Resolve("eth/usd"))
+	assert.Equal(t, []string{"BTC-USD", "AAPL"}, aliases.ResolveAll([]string{"xbt", "AAPL"}))
+
+	// The first alias listed is the display name
+	assert.Equal(t, "BTCUSDT", aliases.Display("BTC-USD"))
+	assert.Equal(t, "AAPL", aliases.Display("AAPL"))
+	assert.Equal(t, map[string]string{"BTC-USD": "BTCUSDT", "SPY": "SPX"}, aliases.DisplayNames())
+
+	// No aliases behaves like CanonicalSymbol
+	var none *SymbolAliases
+	assert.Equal(t, "BTCUSDT", none.Resolve("btcusdt"))
+	assert.Equal(t, "BTC-USD", none.Display("BTC-USD"))
+	assert.Empty(t, none.DisplayNames())
+	assert.True(t, none.Empty())
+}
+
+// TestSymbolAliases_Normalizer verifies provider symbols override the
+// provider's normalizer in both directions, and aliases resolve first.
+func TestSymbolAliases_Normalizer(t *testing.T) {
+	aliases, err := ParseSymbolAliases("BITCOIN:BTC-USD", "BTC-USD:XBTUSD")
+	require.NoError(t, err)
+	normalizer := aliases.Normalizer(BinanceSymbols{})
+
+	assert.Equal(t, "XBTUSD", normalizer.ToProvider("BTC-USD"))
+	assert.Equal(t, "XBTUSD", normalizer.ToProvider("bitcoin"))
+	assert.Equal(t, "BTC-USD", normalizer.ToCanonical("XBTUSD"))
+	assert.Equal(t, "ETHUSDT", normalizer.ToProvider("ETH-USD"))
+	assert.Equal(t, "ETH-USD", normalizer.ToCanonical("ETHUSDT"))
+
+	empty, err := ParseSymbolAliases("", "")
+	require.NoError(t, err)
+	assert.Equal(t, YahooSymbols{}, empty.Normalizer(YahooSymbols{}))
+}
+
+// TestParseSymbolAliases_Invalid verifies malformed and colliding aliases
+// are rejected.
+func TestParseSymbolAliases_Invalid(t *testing.T) {
+	tests := []struct {
+		name            string
+		aliases         string
+		providerSymbols string
+	}{
+		{"MissingTarget", "BTCUSDT", ""},
+		{"EmptyName", ":BTC-USD", ""},
+		{"ExtraField", "A:B:C", ""},
+		{"Self", "btc-usd:BTC-USD", ""},
+		{"Duplicate", "XBT:BTC-USD,xbt:ETH-USD", ""},
+		{"Chained", "XBT:BTCUSDT,BTCUSDT:BTC-USD", ""},
+		{"ProviderForAlias", "XBT:BTC-USD", "XBT:XBTUSD"},
+		{"ProviderTwice", "", "BTC-USD:XBTUSD,btc-usd:BTCUSD"},
+		{"SharedProviderSymbol", "", "BTC-USD:XBTUSD,BTC-USDT:XBTUSD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSymbolAliases(tt.aliases, tt.providerSymbols)
+			assert.Error(t, err)
+		})
+	}
+}
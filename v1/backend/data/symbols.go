@@ -0,0 +1,245 @@
+// Package data provides symbol normalization between the canonical symbols
+// used by the engine and API and each provider's own format.
+package data
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// cryptoQuoteCurrencies are the quote assets that mark a pair as crypto,
+// longest first so "USDT" is matched before "USD".
+var cryptoQuoteCurrencies = []string{"USDT", "USDC", "BUSD", "USD", "BTC", "ETH", "EUR"}
+
+// SymbolNormalizer maps canonical symbols to a provider's format and back.
+//
+// Canonical symbols are uppercase. Equities are plain tickers ("AAPL",
+// "BRK-B") and crypto pairs are BASE-QUOTE ("BTC-USD").
+type SymbolNormalizer interface {
+	// ToProvider converts a canonical symbol to the provider's format.
+	ToProvider(symbol string) string
+
+	// ToCanonical converts a provider symbol to canonical form.
+	ToCanonical(symbol string) string
+}
+
+// CanonicalSymbol normalizes user input to canonical form: trimmed,
+// uppercase, with "/" pair separators replaced by "-". Synthetic ratio specs
+// keep their lowercase prefix and "/" leg separator.
+//
+// Args:
+//   - symbol: Symbol in any supported form (e.g., "btc/usd", " AAPL")
+//
+// Returns:
+//   - string: Canonical symbol (e.g., "BTC-USD", "AAPL")
+func CanonicalSymbol(symbol string) string {
+	if spec, ok := ParseSyntheticSymbol(symbol); ok {
+		return spec.String()
+	}
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	return strings.ReplaceAll(symbol, "/", "-")
+}
+
+// stablecoinQuotes are the quote assets that mark a pair written with no
+// separator ("BTCUSDT") as crypto. Fiat and coin quotes are left out, since
+// equity tickers can end in them ("BETH").
+var stablecoinQuotes = []string{"USDT", "USDC", "BUSD"}
+
+// IsCryptoSymbol reports whether a symbol is a crypto pair, such as
+// "BTC-USD" (canonical), "BTC/USDT" or "BTCUSDT" (Binance).
+//
+// Args:
+//   - symbol: Ticker symbol
+//
+// Returns:
+//   - bool: True if the symbol is a crypto pair
+func IsCryptoSymbol(symbol string) bool {
+	symbol = CanonicalSymbol(symbol)
+	if _, _, ok := splitPair(symbol); ok {
+		return true
+	}
+	for _, q := range stablecoinQuotes {
+		if len(symbol) > len(q) && strings.HasSuffix(symbol, q) && !strings.Contains(symbol, "-") {
+			return true
+		}
+	}
+	return false
+}
+
+// splitPair splits a canonical BASE-QUOTE crypto pair.
+func splitPair(symbol string) (base, quote string, ok bool) {
+	i := strings.Index(symbol, "-")
+	if i <= 0 {
+		return "", "", false
+	}
+	base, quote = symbol[:i], symbol[i+1:]
+	for _, q := range cryptoQuoteCurrencies {
+		if quote == q {
+			return base, quote, true
+		}
+	}
+	return "", "", false
+}
+
+// splitJoinedPair splits a crypto pair with no separator, such as "BTCUSDT",
+// by its quote currency suffix.
+func splitJoinedPair(symbol string) (base, quote string, ok bool) {
+	for _, q := range cryptoQuoteCurrencies {
+		if len(symbol) > len(q) && strings.HasSuffix(symbol, q) {
+			return strings.TrimSuffix(symbol, q), q, true
+		}
+	}
+	return "", "", false
+}
+
+// YahooSymbols is the Yahoo Finance format, which already matches canonical
+// form ("AAPL", "BRK-B", "BTC-USD").
+type YahooSymbols struct{}
+
+// ToProvider converts a canonical symbol to Yahoo format.
+func (YahooSymbols) ToProvider(symbol string) string {
+	return CanonicalSymbol(symbol)
+}
+
+// ToCanonical converts a Yahoo symbol to canonical form.
+func (YahooSymbols) ToCanonical(symbol string) string {
+	return CanonicalSymbol(symbol)
+}
+
+// TiingoSymbols is the Tiingo format. Equities use the canonical ticker and
+// crypto pairs are lowercase with no separator ("btcusd").
+type TiingoSymbols struct{}
+
+// ToProvider converts a canonical symbol to Tiingo format.
+func (TiingoSymbols) ToProvider(symbol string) string {
+	symbol = CanonicalSymbol(symbol)
+	if base, quote, ok := splitPair(symbol); ok {
+		return strings.ToLower(base + quote)
+	}
+	return symbol
+}
+
+// ToCanonical converts a Tiingo symbol to canonical form. Only lowercase
+// symbols are treated as crypto pairs, since equity tickers can end in a
+// quote currency ("BETH").
+func (TiingoSymbols) ToCanonical(symbol string) string {
+	if symbol == strings.ToLower(symbol) {
+		if base, quote, ok := splitJoinedPair(strings.ToUpper(symbol)); ok {
+			return base + "-" + quote
+		}
+	}
+	return CanonicalSymbol(symbol)
+}
+
+// BinanceSymbols is the Binance format: no separator, with USD pairs quoted
+// in USDT ("BTCUSDT", "ETHBTC").
+type BinanceSymbols struct{}
+
+// ToProvider converts a canonical symbol to Binance format.
+func (BinanceSymbols) ToProvider(symbol string) string {
+	symbol = strings.ReplaceAll(CanonicalSymbol(symbol), "-", "")
+	// Binance has no USD pairs, so quote them in USDT (but avoid USDTT)
+	if strings.HasSuffix(symbol, "USD") && !strings.HasSuffix(symbol, "USDT") {
+		symbol += "T"
+	}
+	return symbol
+}
+
+// ToCanonical converts a Binance symbol to canonical form. USDT pairs map
+// back to USD, the canonical dollar quote.
+func (BinanceSymbols) ToCanonical(symbol string) string {
+	symbol = CanonicalSymbol(symbol)
+	base, quote, ok := splitJoinedPair(symbol)
+	if !ok {
+		return symbol
+	}
+	if quote == "USDT" {
+		quote = "USD"
+	}
+	return base + "-" + quote
+}
+
+// NormalizedProvider wraps a DataProvider so callers use canonical symbols.
+type NormalizedProvider struct {
+	provider   DataProvider
+	normalizer SymbolNormalizer
+}
+
+// NewNormalizedProvider wraps a provider so that it accepts and returns
+// canonical symbols, converting them to the provider's format on each call.
+//
+// Args:
+//   - provider: Provider expecting its own symbol format
+//   - normalizer: Mapping between canonical and provider symbols
+//
+// Returns:
+//   - *NormalizedProvider: Provider that works in canonical symbols
+func NewNormalizedProvider(provider DataProvider, normalizer SymbolNormalizer) *NormalizedProvider {
+	return &NormalizedProvider{provider: provider, normalizer: normalizer}
+}
+
+// Name returns the wrapped provider's name.
+func (p *NormalizedProvider) Name() string {
+	return p.provider.Name()
+}
+
+// GetHistoricalData fetches bars for a canonical symbol.
+func (p *NormalizedProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	return p.GetHistoricalDataContext(context.Background(), symbol, start, end, interval)
+}
+
+// GetHistoricalDataContext fetches bars for a canonical symbol, bound to ctx.
+func (p *NormalizedProvider) GetHistoricalDataContext(ctx context.Context, symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	canonical := CanonicalSymbol(symbol)
+	bars, err := GetHistoricalDataContext(ctx, p.provider, p.normalizer.ToProvider(canonical), start, end, interval)
+	for i := range bars {
+		bars[i].Symbol = canonical
+	}
+	return bars, err
+}
+
+// GetLatestPrice fetches the current price for a canonical symbol.
+func (p *NormalizedProvider) GetLatestPrice(symbol string) (float64, error) {
+	return p.GetLatestPriceContext(context.Background(), symbol)
+}
+
+// GetLatestPriceContext fetches the current price for a canonical symbol,
+// bound to ctx.
+func (p *NormalizedProvider) GetLatestPriceContext(ctx context.Context, symbol string) (float64, error) {
+	return GetLatestPriceContext(ctx, p.provider, p.normalizer.ToProvider(symbol))
+}
+
+// GetTicker fetches ticker information for a canonical symbol.
+func (p *NormalizedProvider) GetTicker(symbol string) (*models.Ticker, error) {
+	return p.GetTickerContext(context.Background(), symbol)
+}
+
+// GetTickerContext fetches ticker information for a canonical symbol, bound
+// to ctx.
+func (p *NormalizedProvider) GetTickerContext(ctx context.Context, symbol string) (*models.Ticker, error) {
+	ticker, err := GetTickerContext(ctx, p.provider, p.normalizer.ToProvider(symbol))
+	if ticker != nil {
+		ticker.Symbol = CanonicalSymbol(symbol)
+	}
+	return ticker, err
+}
+
+// SupportsInterval reports whether the wrapped provider can serve the interval.
+func (p *NormalizedProvider) SupportsInterval(interval string) bool {
+	if ip, ok := p.provider.(IntervalProvider); ok {
+		return ip.SupportsInterval(interval)
+	}
+	return true
+}
+
+// GetLotSize returns the exchange lot size for a canonical symbol, or
+// ErrLotSizeUnsupported if the wrapped provider does not report lot sizes.
+func (p *NormalizedProvider) GetLotSize(ctx context.Context, symbol string) (float64, error) {
+	if lp, ok := p.provider.(LotSizeProvider); ok {
+		return lp.GetLotSize(ctx, p.normalizer.ToProvider(symbol))
+	}
+	return 0, ErrLotSizeUnsupported
+}
@@ -0,0 +1,104 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCanonicalSymbol verifies user input is normalized.
+func TestCanonicalSymbol(t *testing.T) {
+	assert.Equal(t, "AAPL", CanonicalSymbol(" aapl "))
+	assert.Equal(t, "BTC-USD", CanonicalSymbol("btc/usd"))
+	assert.Equal(t, "BRK-B", CanonicalSymbol("BRK-B"))
+}
+
+// TestIsCryptoSymbol verifies crypto pair detection.
+func TestIsCryptoSymbol(t *testing.T) {
+	assert.True(t, IsCryptoSymbol("BTC-USD"))
+	assert.True(t, IsCryptoSymbol("ETH/USDT"))
+	assert.True(t, IsCryptoSymbol("BTCUSDT"))
+	assert.True(t, IsCryptoSymbol("solusdc"))
+	assert.False(t, IsCryptoSymbol("AAPL"))
+	assert.False(t, IsCryptoSymbol("BRK-B"))
+	assert.False(t, IsCryptoSymbol("BETH"), "only stablecoin quotes mark a joined pair")
+	assert.False(t, IsCryptoSymbol("USDT"))
+}
+
+// TestSymbolNormalizers_RoundTrip verifies canonical crypto and equity symbols
+// survive conversion to each provider's format and back.
+func TestSymbolNormalizers_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		normalizer SymbolNormalizer
+		canonical  string
+		provider   string
+	}{
+		{"yahoo", YahooSymbols{}, "AAPL", "AAPL"},
+		{"yahoo", YahooSymbols{}, "BRK-B", "BRK-B"},
+		{"yahoo", YahooSymbols{}, "BTC-USD", "BTC-USD"},
+		{"yahoo", YahooSymbols{}, "ETH-BTC", "ETH-BTC"},
+		{"tiingo", TiingoSymbols{}, "AAPL", "AAPL"},
+		{"tiingo", TiingoSymbols{}, "BRK-B", "BRK-B"},
+		{"tiingo", TiingoSymbols{}, "BETH", "BETH"},
+		{"tiingo", TiingoSymbols{}, "BTC-USD", "btcusd"},
+		{"tiingo", TiingoSymbols{}, "ETH-BTC", "ethbtc"},
+		{"binance", BinanceSymbols{}, "AAPL", "AAPL"},
+		{"binance", BinanceSymbols{}, "BTC-USD", "BTCUSDT"},
+		{"binance", BinanceSymbols{}, "ETH-BTC", "ETHBTC"},
+		{"binance", BinanceSymbols{}, "SOL-USDC", "SOLUSDC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.canonical, func(t *testing.T) {
+			assert.Equal(t, tt.provider, tt.normalizer.ToProvider(tt.canonical))
+			assert.Equal(t, tt.canonical, tt.normalizer.ToCanonical(tt.provider))
+		})
+	}
+}
+
+// TestBinanceSymbols_AcceptsAnyPairFormat verifies Binance conversion from
+// non-canonical input.
+func TestBinanceSymbols_AcceptsAnyPairFormat(t *testing.T) {
+	assert.Equal(t, "BTCUSDT", BinanceSymbols{}.ToProvider("btc/usd"))
+	assert.Equal(t, "BTCUSDT", BinanceSymbols{}.ToProvider("BTC-USDT"))
+}
+
+// recordingProvider records the symbols it is called with.
+type recordingProvider struct {
+	mockDataProvider
+	symbols []string
+}
+
+func (p *recordingProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	p.symbols = append(p.symbols, symbol)
+	return p.mockDataProvider.GetHistoricalData(symbol, start, end, interval)
+}
+
+func (p *recordingProvider) GetTicker(symbol string) (*models.Ticker, error) {
+	p.symbols = append(p.symbols, symbol)
+	return p.mockDataProvider.GetTicker(symbol)
+}
+
+// TestNormalizedProvider verifies provider calls use the provider format and
+// results carry the canonical symbol.
+func TestNormalizedProvider(t *testing.T) {
+	inner := &recordingProvider{}
+	provider := NewNormalizedProvider(inner, BinanceSymbols{})
+	assert.Equal(t, "mock", provider.Name())
+
+	bars, err := provider.GetHistoricalData("btc/usd", time.Now().Add(-time.Hour), time.Now(), "1h")
+	require.NoError(t, err)
+	require.Len(t, bars, 1)
+	assert.Equal(t, "BTC-USD", bars[0].Symbol)
+
+	ticker, err := provider.GetTicker("ETH-BTC")
+	require.NoError(t, err)
+	assert.Equal(t, "ETH-BTC", ticker.Symbol)
+
+	assert.Equal(t, []string{"BTCUSDT", "ETHBTC"}, inner.symbols)
+	assert.True(t, provider.SupportsInterval("1h"))
+}
@@ -0,0 +1,218 @@
+// Package data provides synthetic multi-leg symbols derived from the price
+// series of two tradable legs.
+package data
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/rs/zerolog/log"
+)
+
+// SyntheticRatioPrefix marks a ratio symbol such as "ratio:ETH-USD/BTC-USD",
+// whose price is the first leg divided by the second.
+const SyntheticRatioPrefix = "ratio:"
+
+// SyntheticSymbol is a parsed ratio symbol spec.
+type SyntheticSymbol struct {
+	// Numerator is the canonical symbol of the first leg.
+	Numerator string
+	// Denominator is the canonical symbol of the second leg.
+	Denominator string
+}
+
+// String returns the canonical spec ("ratio:ETH-USD/BTC-USD").
+func (s SyntheticSymbol) String() string {
+	return SyntheticRatioPrefix + s.Numerator + "/" + s.Denominator
+}
+
+// ParseSyntheticSymbol parses a ratio spec. The prefix is case-insensitive
+// and the legs are separated by a single "/", so each leg must use the
+// dashed pair form ("ETH-USD").
+//
+// Args:
+//   - symbol: Symbol spec (e.g., "ratio:ETH-USD/BTC-USD")
+//
+// Returns:
+//   - SyntheticSymbol: The parsed legs in canonical form
+//   - bool: True if the symbol is a valid ratio spec
+func ParseSyntheticSymbol(symbol string) (SyntheticSymbol, bool) {
+	symbol = strings.TrimSpace(symbol)
+	if len(symbol) < len(SyntheticRatioPrefix) ||
+		!strings.EqualFold(symbol[:len(SyntheticRatioPrefix)], SyntheticRatioPrefix) {
+		return SyntheticSymbol{}, false
+	}
+
+	legs := strings.Split(symbol[len(SyntheticRatioPrefix):], "/")
+	if len(legs) != 2 {
+		return SyntheticSymbol{}, false
+	}
+	num, den := CanonicalSymbol(legs[0]), CanonicalSymbol(legs[1])
+	if num == "" || den == "" || num == den || strings.Contains(num+den, ":") {
+		return SyntheticSymbol{}, false
+	}
+	return SyntheticSymbol{Numerator: num, Denominator: den}, true
+}
+
+// IsSyntheticSymbol reports whether a symbol is a synthetic ratio spec.
+// Synthetic symbols are analysis-only and can't be traded directly.
+//
+// Args:
+//   - symbol: Ticker symbol
+//
+// Returns:
+//   - bool: True if the symbol is a ratio spec
+func IsSyntheticSymbol(symbol string) bool {
+	_, ok := ParseSyntheticSymbol(symbol)
+	return ok
+}
+
+// RatioSeries derives a ratio series from two legs, keeping only bars whose
+// timestamps appear in both. Open and close are the leg ratios; high and low
+// are the high and low ratios widened to contain them, since the legs' true
+// intrabar extremes can't be combined. Volume is zero. Bars where the
+// denominator is zero are dropped.
+//
+// Args:
+//   - symbol: Symbol to stamp on the derived bars
+//   - num: Numerator leg bars
+//   - den: Denominator leg bars
+//
+// Returns:
+//   - []models.OHLCV: Derived bars, in numerator order
+func RatioSeries(symbol string, num, den []models.OHLCV) []models.OHLCV {
+	byTime := make(map[int64]models.OHLCV, len(den))
+	for _, bar := range den {
+		byTime[bar.Timestamp.UnixNano()] = bar
+	}
+
+	result := make([]models.OHLCV, 0, len(num))
+	for _, n := range num {
+		d, ok := byTime[n.Timestamp.UnixNano()]
+		if !ok || d.Open == 0 || d.High == 0 || d.Low == 0 || d.Close == 0 {
+			continue
+		}
+		open, closePrice := n.Open/d.Open, n.Close/d.Close
+		result = append(result, models.OHLCV{
+			Timestamp: n.Timestamp,
+			Symbol:    symbol,
+			Open:      open,
+			High:      max(open, closePrice, n.High/d.High),
+			Low:       min(open, closePrice, n.Low/d.Low),
+			Close:     closePrice,
+		})
+	}
+	return result
+}
+
+// SyntheticProvider wraps a DataProvider and serves ratio symbols by
+// fetching both legs and deriving the ratio. Other symbols pass through.
+type SyntheticProvider struct {
+	provider DataProvider
+}
+
+// NewSyntheticProvider creates a synthetic symbol provider.
+//
+// Args:
+//   - provider: The underlying data provider
+//
+// Returns:
+//   - *SyntheticProvider: The wrapped provider
+func NewSyntheticProvider(provider DataProvider) *SyntheticProvider {
+	return &SyntheticProvider{provider: provider}
+}
+
+// Name returns the wrapped provider's name.
+func (p *SyntheticProvider) Name() string {
+	return p.provider.Name()
+}
+
+// GetHistoricalData fetches bars, deriving ratio symbols from their legs.
+func (p *SyntheticProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	return p.GetHistoricalDataContext(context.Background(), symbol, start, end, interval)
+}
+
+// GetHistoricalDataContext fetches bars bound to ctx, deriving ratio symbols
+// from their legs.
+func (p *SyntheticProvider) GetHistoricalDataContext(ctx context.Context, symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	spec, ok := ParseSyntheticSymbol(symbol)
+	if !ok {
+		return GetHistoricalDataContext(ctx, p.provider, symbol, start, end, interval)
+	}
+
+	num, err := GetHistoricalDataContext(ctx, p.provider, spec.Numerator, start, end, interval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s leg of %s: %w", spec.Numerator, spec, err)
+	}
+	den, err := GetHistoricalDataContext(ctx, p.provider, spec.Denominator, start, end, interval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s leg of %s: %w", spec.Denominator, spec, err)
+	}
+
+	bars := RatioSeries(spec.String(), num, den)
+	log.Debug().
+		Str("symbol", spec.String()).
+		Int("numerator_bars", len(num)).
+		Int("denominator_bars", len(den)).
+		Int("bars", len(bars)).
+		Msg("Derived synthetic ratio bars")
+	return bars, nil
+}
+
+// GetLatestPrice fetches the current price, deriving ratio symbols from
+// their legs.
+func (p *SyntheticProvider) GetLatestPrice(symbol string) (float64, error) {
+	return p.GetLatestPriceContext(context.Background(), symbol)
+}
+
+// GetLatestPriceContext fetches the current price bound to ctx, deriving
+// ratio symbols from their legs.
+func (p *SyntheticProvider) GetLatestPriceContext(ctx context.Context, symbol string) (float64, error) {
+	spec, ok := ParseSyntheticSymbol(symbol)
+	if !ok {
+		return GetLatestPriceContext(ctx, p.provider, symbol)
+	}
+
+	num, err := GetLatestPriceContext(ctx, p.provider, spec.Numerator)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch %s leg of %s: %w", spec.Numerator, spec, err)
+	}
+	den, err := GetLatestPriceContext(ctx, p.provider, spec.Denominator)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch %s leg of %s: %w", spec.Denominator, spec, err)
+	}
+	if den == 0 {
+		return 0, fmt.Errorf("%s leg of %s has zero price", spec.Denominator, spec)
+	}
+	return num / den, nil
+}
+
+// GetTicker fetches ticker information, describing ratio symbols locally.
+func (p *SyntheticProvider) GetTicker(symbol string) (*models.Ticker, error) {
+	return p.GetTickerContext(context.Background(), symbol)
+}
+
+// GetTickerContext fetches ticker information bound to ctx, describing
+// ratio symbols locally.
+func (p *SyntheticProvider) GetTickerContext(ctx context.Context, symbol string) (*models.Ticker, error) {
+	spec, ok := ParseSyntheticSymbol(symbol)
+	if !ok {
+		return GetTickerContext(ctx, p.provider, symbol)
+	}
+	return &models.Ticker{
+		Symbol:    spec.String(),
+		Name:      spec.Numerator + " / " + spec.Denominator,
+		AssetType: "synthetic",
+	}, nil
+}
+
+// SupportsInterval reports whether the wrapped provider serves the interval.
+func (p *SyntheticProvider) SupportsInterval(interval string) bool {
+	if ip, ok := p.provider.(IntervalProvider); ok {
+		return ip.SupportsInterval(interval)
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package data
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseSyntheticSymbol verifies ratio spec parsing and canonicalization.
+func TestParseSyntheticSymbol(t *testing.T) {
+	spec, ok := ParseSyntheticSymbol(" Ratio:eth-usd/btc-usd ")
+	require.True(t, ok)
+	assert.Equal(t, "ETH-USD", spec.Numerator)
+	assert.Equal(t, "BTC-USD", spec.Denominator)
+	assert.Equal(t, "ratio:ETH-USD/BTC-USD", spec.String())
+	assert.Equal(t, "ratio:ETH-USD/BTC-USD", CanonicalSymbol("RATIO:eth-usd/btc-usd"))
+
+	for _, symbol := range []string{
+		"ETH-USD",
+		"ratio:",
+		"ratio:ETH-USD",
+		"ratio:ETH/USD/BTC/USD",
+		"ratio:ETH-USD/",
+		"ratio:ETH-USD/ETH-USD",
+		"ratio:ratio:ETH-USD/BTC-USD",
+	} {
+		assert.False(t, IsSyntheticSymbol(symbol), symbol)
+	}
+	assert.False(t, IsCryptoSymbol("ratio:ETH-USD/BTC-USD"))
+}
+
+// TestRatioSeries verifies timestamp alignment and ratio bar construction.
+func TestRatioSeries(t *testing.T) {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	num := []models.OHLCV{
+		{Timestamp: start, Open: 200, High: 220, Low: 190, Close: 210},
+		{Timestamp: start.Add(time.Hour), Open: 210, High: 230, Low: 200, Close: 220},
+		{Timestamp: start.Add(2 * time.Hour), Open: 220, High: 240, Low: 210, Close: 230},
+	}
+	den := []models.OHLCV{
+		{Timestamp: start, Open: 100, High: 200, Low: 50, Close: 100},
+		{Timestamp: start.Add(2 * time.Hour), Open: 0, High: 0, Low: 0, Close: 0},
+		{Timestamp: start.Add(3 * time.Hour), Open: 100, High: 100, Low: 100, Close: 100},
+	}
+
+	bars := RatioSeries("ratio:A/B", num, den)
+	require.Len(t, bars, 1)
+	bar := bars[0]
+	assert.Equal(t, start, bar.Timestamp)
+	assert.Equal(t, "ratio:A/B", bar.Symbol)
+	assert.Equal(t, 2.0, bar.Open)
+	assert.Equal(t, 2.1, bar.Close)
+	assert.Equal(t, 2.1, bar.High)
+	assert.Equal(t, 2.0, bar.Low)
+	assert.Zero(t, bar.Volume)
+}
+
+// legProvider serves flat bars at a fixed price per symbol and records the
+// symbols it is called with.
+type legProvider struct {
+	mockDataProvider
+	prices  map[string]float64
+	symbols []string
+	err     error
+}
+
+func (p *legProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	p.symbols = append(p.symbols, symbol)
+	if p.err != nil {
+		return nil, p.err
+	}
+	price := p.prices[symbol]
+	return []models.OHLCV{{Timestamp: start, Symbol: symbol, Open: price, High: price, Low: price, Close: price}}, nil
+}
+
+func (p *legProvider) GetLatestPrice(symbol string) (float64, error) {
+	return p.prices[symbol], p.err
+}
+
+// TestSyntheticProvider verifies ratio symbols are derived from both legs
+// and other symbols pass through.
+func TestSyntheticProvider(t *testing.T) {
+	inner := &legProvider{prices: map[string]float64{"ETH-USD": 3000, "BTC-USD": 60000, "AAPL": 150}}
+	provider := NewSyntheticProvider(inner)
+	assert.Equal(t, "mock", provider.Name())
+	assert.True(t, provider.SupportsInterval("1h"))
+
+	bars, err := provider.GetHistoricalData("ratio:eth-usd/btc-usd", time.Now().Add(-time.Hour), time.Now(), "1h")
+	require.NoError(t, err)
+	require.Len(t, bars, 1)
+	assert.Equal(t, "ratio:ETH-USD/BTC-USD", bars[0].Symbol)
+	assert.Equal(t, 0.05, bars[0].Close)
+	assert.Equal(t, []string{"ETH-USD", "BTC-USD"}, inner.symbols)
+
+	price, err := provider.GetLatestPrice("ratio:ETH-USD/BTC-USD")
+	require.NoError(t, err)
+	assert.Equal(t, 0.05, price)
+
+	ticker, err := provider.GetTicker("ratio:ETH-USD/BTC-USD")
+	require.NoError(t, err)
+	assert.Equal(t, "synthetic", ticker.AssetType)
+	assert.Equal(t, "ETH-USD / BTC-USD", ticker.Name)
+
+	bars, err = provider.GetHistoricalData("AAPL", time.Now().Add(-time.Hour), time.Now(), "1h")
+	require.NoError(t, err)
+	require.Len(t, bars, 1)
+	assert.Equal(t, "AAPL", bars[0].Symbol)
+	assert.Equal(t, "AAPL", inner.symbols[len(inner.symbols)-1])
+}
+
+// TestSyntheticProvider_LegError verifies a failing leg fails the series.
+func TestSyntheticProvider_LegError(t *testing.T) {
+	provider := NewSyntheticProvider(&legProvider{err: errors.New("upstream down")})
+
+	_, err := provider.GetHistoricalData("ratio:ETH-USD/BTC-USD", time.Now().Add(-time.Hour), time.Now(), "1h")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ETH-USD leg of ratio:ETH-USD/BTC-USD")
+	assert.Contains(t, err.Error(), "upstream down")
+}
@@ -0,0 +1,93 @@
+// Package data provides normalization of candle timestamps to an exchange
+// timezone.
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// InLocation converts each bar's timestamp to loc in place. The instant is
+// unchanged; only the zone its wall-clock fields are reported in changes.
+//
+// Args:
+//   - bars: Bars to convert
+//   - loc: Target timezone
+//
+// Returns:
+//   - []models.OHLCV: The same bars, for chaining
+func InLocation(bars []models.OHLCV, loc *time.Location) []models.OHLCV {
+	for i := range bars {
+		bars[i].Timestamp = bars[i].Timestamp.In(loc)
+	}
+	return bars
+}
+
+// TimezoneProvider wraps a DataProvider and reports historical bar
+// timestamps in a fixed exchange timezone, so session logic sees the same
+// wall-clock times whether the provider returns UTC or a local zone.
+type TimezoneProvider struct {
+	provider DataProvider
+	location *time.Location
+}
+
+// NewTimezoneProvider creates a timezone-normalizing provider.
+//
+// Args:
+//   - provider: The underlying data provider
+//   - loc: Timezone bar timestamps are converted to
+//
+// Returns:
+//   - *TimezoneProvider: The wrapped provider
+func NewTimezoneProvider(provider DataProvider, loc *time.Location) *TimezoneProvider {
+	return &TimezoneProvider{provider: provider, location: loc}
+}
+
+// Name returns the wrapped provider's name.
+func (p *TimezoneProvider) Name() string {
+	return p.provider.Name()
+}
+
+// GetHistoricalData fetches bars with timestamps in the configured timezone.
+func (p *TimezoneProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	return p.GetHistoricalDataContext(context.Background(), symbol, start, end, interval)
+}
+
+// GetHistoricalDataContext fetches bars bound to ctx, with timestamps in the
+// configured timezone.
+func (p *TimezoneProvider) GetHistoricalDataContext(ctx context.Context, symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	bars, err := GetHistoricalDataContext(ctx, p.provider, symbol, start, end, interval)
+	return InLocation(bars, p.location), err
+}
+
+// GetLatestPrice fetches the current price from the wrapped provider.
+func (p *TimezoneProvider) GetLatestPrice(symbol string) (float64, error) {
+	return p.provider.GetLatestPrice(symbol)
+}
+
+// GetLatestPriceContext fetches the current price from the wrapped provider,
+// bound to ctx.
+func (p *TimezoneProvider) GetLatestPriceContext(ctx context.Context, symbol string) (float64, error) {
+	return GetLatestPriceContext(ctx, p.provider, symbol)
+}
+
+// GetTicker fetches ticker information from the wrapped provider.
+func (p *TimezoneProvider) GetTicker(symbol string) (*models.Ticker, error) {
+	return p.provider.GetTicker(symbol)
+}
+
+// GetTickerContext fetches ticker information from the wrapped provider,
+// bound to ctx.
+func (p *TimezoneProvider) GetTickerContext(ctx context.Context, symbol string) (*models.Ticker, error) {
+	return GetTickerContext(ctx, p.provider, symbol)
+}
+
+// SupportsInterval reports whether the wrapped provider serves the interval.
+func (p *TimezoneProvider) SupportsInterval(interval string) bool {
+	if ip, ok := p.provider.(IntervalProvider); ok {
+		return ip.SupportsInterval(interval)
+	}
+	return true
+}
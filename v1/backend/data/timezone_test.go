@@ -0,0 +1,54 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTimezoneProvider verifies bar timestamps are reported in the exchange
+// timezone across a daylight saving change without moving the instant.
+func TestTimezoneProvider(t *testing.T) {
+	nyc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	inner := &legProvider{prices: map[string]float64{"BTC-USD": 60000}}
+	provider := NewTimezoneProvider(inner, nyc)
+	assert.Equal(t, "mock", provider.Name())
+	assert.True(t, provider.SupportsInterval("1h"))
+
+	// 21:00 UTC is 16:00 EST before 8 March 2026 and 17:00 EDT after
+	for _, tt := range []struct {
+		start time.Time
+		hour  int
+	}{
+		{time.Date(2026, 3, 6, 21, 0, 0, 0, time.UTC), 16},
+		{time.Date(2026, 3, 9, 21, 0, 0, 0, time.UTC), 17},
+	} {
+		bars, err := provider.GetHistoricalData("BTC-USD", tt.start, tt.start.Add(time.Hour), "1h")
+		require.NoError(t, err)
+		require.Len(t, bars, 1)
+		assert.Equal(t, nyc, bars[0].Timestamp.Location())
+		assert.Equal(t, tt.hour, bars[0].Timestamp.Hour())
+		assert.True(t, bars[0].Timestamp.Equal(tt.start))
+	}
+
+	price, err := provider.GetLatestPrice("BTC-USD")
+	require.NoError(t, err)
+	assert.Equal(t, 60000.0, price)
+}
+
+// TestInLocation verifies conversion is in place and keeps the instant.
+func TestInLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	bars := []models.OHLCV{{Timestamp: start}}
+	InLocation(bars, tokyo)
+	assert.Equal(t, 9, bars[0].Timestamp.Hour())
+	assert.True(t, bars[0].Timestamp.Equal(start))
+}
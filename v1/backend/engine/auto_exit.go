@@ -0,0 +1,214 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/execution"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/tracing"
+)
+
+// Auto-exit reasons, added as a tag next to models.TagAutoExit.
+const (
+	AutoExitTakeProfit = "take_profit"
+	AutoExitStopLoss   = "stop_loss"
+)
+
+// AutoExitLevels are the unrealized P&L percentages at which a position is
+// closed. Zero disables a level.
+type AutoExitLevels struct {
+	TakeProfitPct float64 // Close at a gain of this many percent of cost
+	StopLossPct   float64 // Close at a loss of this many percent of cost
+}
+
+// Enabled reports whether either level is set.
+func (l AutoExitLevels) Enabled() bool {
+	return l.TakeProfitPct > 0 || l.StopLossPct > 0
+}
+
+// AutoExit closes open positions whose unrealized P&L reaches a take-profit
+// or stop-loss level, whatever the strategies signal. The zero value
+// disables it.
+type AutoExit struct {
+	// Default applies to every symbol without an override.
+	Default AutoExitLevels
+	// Symbols overrides the levels per canonical symbol.
+	Symbols map[string]AutoExitLevels
+}
+
+// Levels returns the levels that apply to a symbol.
+func (a AutoExit) Levels(symbol string) AutoExitLevels {
+	if levels, ok := a.Symbols[symbol]; ok {
+		return levels
+	}
+	return a.Default
+}
+
+// Enabled reports whether any symbol has a level set.
+func (a AutoExit) Enabled() bool {
+	if a.Default.Enabled() {
+		return true
+	}
+	for _, levels := range a.Symbols {
+		if levels.Enabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseAutoExitOverrides parses per-symbol auto-exit levels from a
+// comma-separated list of SYMBOL:TAKE_PROFIT_PCT:STOP_LOSS_PCT entries, e.g.
+// "BTC-USD:15:8,SPY:0:3". A 0 disables that level for the symbol.
+//
+// Args:
+//   - s: The override list (empty for none)
+//   - aliases: Symbol aliases entries may use (nil for none)
+//
+// Returns:
+//   - map[string]AutoExitLevels: Levels keyed by canonical symbol
+//   - error: If an entry is malformed or a percentage is negative
+func ParseAutoExitOverrides(s string, aliases *data.SymbolAliases) (map[string]AutoExitLevels, error) {
+	overrides := make(map[string]AutoExitLevels)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid auto-exit override %q: must be SYMBOL:TAKE_PROFIT_PCT:STOP_LOSS_PCT", entry)
+		}
+		var pcts [2]float64
+		for i, part := range parts[1:] {
+			pct, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil || pct < 0 {
+				return nil, fmt.Errorf("invalid auto-exit override %q: percentages must be numbers, 0 or greater", entry)
+			}
+			pcts[i] = pct
+		}
+		overrides[aliases.Resolve(parts[0])] = AutoExitLevels{
+			TakeProfitPct: pcts[0],
+			StopLossPct:   pcts[1],
+		}
+	}
+	return overrides, nil
+}
+
+// SetAutoExit configures protective exits, checked after every tick. Must
+// be called before Start.
+//
+// Args:
+//   - autoExit: Take-profit and stop-loss levels (zero value disables)
+func (e *TradingEngine) SetAutoExit(autoExit AutoExit) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.autoExit = autoExit
+}
+
+// checkAutoExits closes, with a market sell, every long position whose
+// unrealized P&L has reached its take-profit or stop-loss level. Positions
+// are valued at the order manager's market price, or the broker's position
+// price when that is unknown. Exits are tagged models.TagAutoExit and go
+// through the kill-switch like shutdown closes. A symbol is not exited again
+// while its previous exit is open.
+func (e *TradingEngine) checkAutoExits(ctx context.Context) {
+	e.mu.RLock()
+	autoExit := e.autoExit
+	e.mu.RUnlock()
+	if !autoExit.Enabled() || e.BrokerPaused() {
+		return
+	}
+
+	logger := tracing.Logger(ctx)
+	positions, err := e.orderManager.GetPositions()
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to get positions for auto-exit")
+		return
+	}
+
+	exitCtx := execution.WithKillSwitchBypass(execution.NewEngineContextWithTrace(ctx))
+	for _, pos := range positions {
+		if pos.Quantity <= 0 || pos.AverageCost <= 0 {
+			continue
+		}
+		price := e.orderManager.MarketPrice(ctx, pos.Symbol)
+		if price <= 0 {
+			price = pos.CurrentPrice
+		}
+		if price <= 0 {
+			continue
+		}
+		levels := autoExit.Levels(pos.Symbol)
+		change := (price - pos.AverageCost) / pos.AverageCost * 100
+		var reason string
+		switch {
+		case levels.TakeProfitPct > 0 && change >= levels.TakeProfitPct:
+			reason = AutoExitTakeProfit
+		case levels.StopLossPct > 0 && change <= -levels.StopLossPct:
+			reason = AutoExitStopLoss
+		default:
+			continue
+		}
+		if e.autoExitOpen(pos.Symbol) {
+			continue
+		}
+
+		logger.Warn().
+			Str("symbol", pos.Symbol).
+			Str("reason", reason).
+			Float64("quantity", pos.Quantity).
+			Float64("average_cost", pos.AverageCost).
+			Float64("price", price).
+			Float64("change_pct", change).
+			Msg("Auto-exit triggered, closing position")
+
+		order, err := e.orderManager.SubmitOrder(exitCtx, models.Order{
+			Symbol:   pos.Symbol,
+			Side:     models.OrderSideSell,
+			Type:     models.OrderTypeMarket,
+			Quantity: pos.Quantity,
+			Tags:     models.Tags{models.TagAutoExit, reason},
+		})
+		if err != nil {
+			logger.Error().Err(err).Str("symbol", pos.Symbol).Msg("Failed to place auto-exit order")
+			continue
+		}
+		if order.Status == models.OrderStatusPendingConfirmation {
+			logger.Warn().Str("symbol", pos.Symbol).Msg("Auto-exit order staged for confirmation, not placed")
+			continue
+		}
+
+		e.mu.Lock()
+		if e.autoExitOrders == nil {
+			e.autoExitOrders = make(map[string]string)
+		}
+		e.autoExitOrders[pos.Symbol] = order.ID
+		e.orderCount++
+		e.mu.Unlock()
+	}
+}
+
+// autoExitOpen reports whether the last auto-exit order for a symbol has not
+// yet filled or been cancelled.
+func (e *TradingEngine) autoExitOpen(symbol string) bool {
+	e.mu.RLock()
+	id, ok := e.autoExitOrders[symbol]
+	e.mu.RUnlock()
+	if !ok {
+		return false
+	}
+	order, err := e.orderManager.GetOrder(id)
+	if err != nil {
+		return false
+	}
+	switch order.Status {
+	case models.OrderStatusPending, models.OrderStatusSubmitted, models.OrderStatusPartiallyFilled:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/data"
+)
+
+// TradingCalendar decides whether a symbol's market is open.
+// The engine skips strategy execution for symbols whose market is closed.
+type TradingCalendar interface {
+	// IsOpen reports whether the market for a symbol is open at a time.
+	//
+	// Args:
+	//   - symbol: Ticker symbol
+	//   - t: Time to check
+	//
+	// Returns:
+	//   - bool: True if the symbol can be traded at t
+	IsOpen(symbol string, t time.Time) bool
+}
+
+// usMarketHolidays lists NYSE full-day closures (observed dates).
+var usMarketHolidays = []string{
+	// 2025
+	"2025-01-01", "2025-01-20", "2025-02-17", "2025-04-18", "2025-05-26",
+	"2025-06-19", "2025-07-04", "2025-09-01", "2025-11-27", "2025-12-25",
+	// 2026
+	"2026-01-01", "2026-01-19", "2026-02-16", "2026-04-03", "2026-05-25",
+	"2026-06-19", "2026-07-03", "2026-09-07", "2026-11-26", "2026-12-25",
+	// 2027
+	"2027-01-01", "2027-01-18", "2027-02-15", "2027-03-26", "2027-05-31",
+	"2027-06-18", "2027-07-05", "2027-09-06", "2027-11-25", "2027-12-24",
+}
+
+// USEquityCalendar follows the regular US equity session, 9:30–16:00 ET on
+// weekdays, excluding NYSE holidays. Crypto pairs are treated as always open.
+type USEquityCalendar struct {
+	location *time.Location
+	holidays map[string]bool
+}
+
+// NewUSEquityCalendar creates a US equity calendar with the built-in holiday list.
+//
+// Returns:
+//   - *USEquityCalendar: The calendar
+//   - error: If the America/New_York timezone cannot be loaded
+func NewUSEquityCalendar() (*USEquityCalendar, error) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load NYC timezone: %w", err)
+	}
+
+	holidays := make(map[string]bool, len(usMarketHolidays))
+	for _, day := range usMarketHolidays {
+		holidays[day] = true
+	}
+
+	return &USEquityCalendar{
+		location: loc,
+		holidays: holidays,
+	}, nil
+}
+
+// IsOpen reports whether the symbol's market is open at t. A synthetic
+// ratio symbol is open only while both of its legs are.
+func (c *USEquityCalendar) IsOpen(symbol string, t time.Time) bool {
+	if spec, ok := data.ParseSyntheticSymbol(symbol); ok {
+		return c.IsOpen(spec.Numerator, t) && c.IsOpen(spec.Denominator, t)
+	}
+	if IsCryptoSymbol(symbol) {
+		return true
+	}
+
+	local := t.In(c.location)
+	if local.Weekday() == time.Saturday || local.Weekday() == time.Sunday {
+		return false
+	}
+	if c.holidays[local.Format("2006-01-02")] {
+		return false
+	}
+
+	minutes := local.Hour()*60 + local.Minute()
+	return minutes >= 9*60+30 && minutes < 16*60
+}
+
+// Location returns the exchange timezone the calendar reads weekdays in.
+func (c *USEquityCalendar) Location() *time.Location {
+	return c.location
+}
+
+// IsTradingDay reports whether the symbol's market trades on day's date,
+// read in day's own location so daily bars stamped at midnight UTC keep
+// their date. Crypto pairs trade every day; a synthetic ratio symbol trades
+// only on days both legs do. It implements data.TradingDays.
+func (c *USEquityCalendar) IsTradingDay(symbol string, day time.Time) bool {
+	if spec, ok := data.ParseSyntheticSymbol(symbol); ok {
+		return c.IsTradingDay(spec.Numerator, day) && c.IsTradingDay(spec.Denominator, day)
+	}
+	if IsCryptoSymbol(symbol) {
+		return true
+	}
+	if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		return false
+	}
+	return !c.holidays[day.Format("2006-01-02")]
+}
+
+// IsCryptoSymbol reports whether a symbol is a crypto pair, such as
+// "BTC-USD" (Yahoo) or "BTC/USDT" (Binance).
+//
+// Args:
+//   - symbol: Ticker symbol
+//
+// Returns:
+//   - bool: True if the symbol is a crypto pair
+func IsCryptoSymbol(symbol string) bool {
+	return data.IsCryptoSymbol(symbol)
+}
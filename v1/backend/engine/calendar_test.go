@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUSEquityCalendar_IsOpen verifies session hours, weekends, holidays,
+// crypto and synthetic ratios.
+func TestUSEquityCalendar_IsOpen(t *testing.T) {
+	cal, err := NewUSEquityCalendar()
+	require.NoError(t, err)
+	nyc := cal.location
+
+	tests := []struct {
+		name   string
+		symbol string
+		at     time.Time
+		open   bool
+	}{
+		{"session open", "AAPL", time.Date(2026, 3, 10, 9, 30, 0, 0, nyc), true},
+		{"mid session", "AAPL", time.Date(2026, 3, 10, 13, 0, 0, 0, nyc), true},
+		{"before open", "AAPL", time.Date(2026, 3, 10, 9, 29, 0, 0, nyc), false},
+		{"at close", "AAPL", time.Date(2026, 3, 10, 16, 0, 0, 0, nyc), false},
+		{"weekend", "AAPL", time.Date(2026, 3, 14, 12, 0, 0, 0, nyc), false},
+		{"holiday", "SPY", time.Date(2026, 12, 25, 12, 0, 0, 0, nyc), false},
+		{"utc during session", "MSFT", time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC), true},
+		{"crypto on weekend", "BTC-USD", time.Date(2026, 3, 14, 3, 0, 0, 0, nyc), true},
+		{"crypto on holiday", "ETH/USDT", time.Date(2026, 12, 25, 12, 0, 0, 0, nyc), true},
+		{"joined stablecoin pair on weekend", "BTCUSDT", time.Date(2026, 3, 14, 3, 0, 0, 0, nyc), true},
+		{"crypto ratio on weekend", "ratio:ETH-USD/BTC-USD", time.Date(2026, 3, 14, 3, 0, 0, 0, nyc), true},
+		{"mixed ratio on weekend", "ratio:BTC-USD/SPY", time.Date(2026, 3, 14, 3, 0, 0, 0, nyc), false},
+		{"mixed ratio in session", "ratio:BTC-USD/SPY", time.Date(2026, 3, 10, 13, 0, 0, 0, nyc), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.open, cal.IsOpen(tt.symbol, tt.at))
+		})
+	}
+}
+
+// TestUSEquityCalendar_HolidaysAreWeekdays guards the holiday list against typos.
+func TestUSEquityCalendar_HolidaysAreWeekdays(t *testing.T) {
+	for _, day := range usMarketHolidays {
+		d, err := time.Parse("2006-01-02", day)
+		require.NoError(t, err)
+		assert.NotContains(t, []time.Weekday{time.Saturday, time.Sunday}, d.Weekday(), day)
+	}
+}
+
+// TestUSEquityCalendar_IsTradingDay verifies weekends and holidays are not
+// trading days for equities but are for crypto pairs.
+func TestUSEquityCalendar_IsTradingDay(t *testing.T) {
+	cal, err := NewUSEquityCalendar()
+	require.NoError(t, err)
+
+	goodFriday := time.Date(2026, 4, 3, 0, 0, 0, 0, time.UTC)
+	assert.False(t, cal.IsTradingDay("AAPL", goodFriday))
+	assert.True(t, cal.IsTradingDay("AAPL", goodFriday.AddDate(0, 0, -1)))
+	assert.False(t, cal.IsTradingDay("AAPL", goodFriday.AddDate(0, 0, 1)))
+	assert.True(t, cal.IsTradingDay("BTC-USD", goodFriday))
+	assert.False(t, cal.IsTradingDay("ratio:BTC-USD/SPY", goodFriday))
+
+	var _ data.TradingDays = cal
+}
+
+// TestIsCryptoSymbol verifies crypto pair detection.
+func TestIsCryptoSymbol(t *testing.T) {
+	assert.True(t, IsCryptoSymbol("BTC-USD"))
+	assert.True(t, IsCryptoSymbol("ETH/USDT"))
+	assert.True(t, IsCryptoSymbol("SOL-usdc"))
+	assert.True(t, IsCryptoSymbol("BTCUSDT"))
+	assert.True(t, IsCryptoSymbol("ethusdc"))
+	assert.False(t, IsCryptoSymbol("USDT"))
+	assert.False(t, IsCryptoSymbol("AAPL"))
+	assert.False(t, IsCryptoSymbol("BRK-B"))
+	assert.False(t, IsCryptoSymbol("-USD"))
+}
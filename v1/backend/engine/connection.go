@@ -0,0 +1,146 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/tracing"
+)
+
+// SetConnectionMonitor configures watching the broker connection. When a
+// check finds the broker disconnected, signal execution pauses and Connect is
+// retried with doubling backoff until it succeeds; the engine then reconciles
+// and resumes. Disconnects and reconnects are sent to the notifier. For the
+// paper broker, which never drops, this is a no-op. Must be called before Start.
+//
+// Args:
+//   - interval: how often to check the connection (0 disables)
+//   - backoff: wait before the first reconnect retry (0 retries every interval)
+//   - maxBackoff: upper bound on the retry wait
+func (e *TradingEngine) SetConnectionMonitor(interval, backoff, maxBackoff time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.connCheckEvery = interval
+	e.reconnectBackoff = backoff
+	e.reconnectMaxBackoff = maxBackoff
+}
+
+// BrokerPaused reports whether signal execution is paused because the
+// broker connection was lost.
+func (e *TradingEngine) BrokerPaused() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.brokerPaused
+}
+
+// monitorConnection checks the broker connection every interval and
+// reconnects when it has dropped. It runs alongside the main loop.
+func (e *TradingEngine) monitorConnection(ctx context.Context, interval time.Duration) {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-e.stopCh:
+			return
+		case <-ticker.C:
+			if !e.orderManager.IsBrokerConnected() {
+				e.reconnect(ctx, interval)
+			}
+		}
+	}
+}
+
+// reconnect pauses execution and retries Connect with bounded exponential
+// backoff. Order submission through the order manager, including API
+// orders, is paused too. Once connected it reconciles before resuming, so no
+// order is placed against a stale order cache. It returns early if the
+// engine stops, lifting the order manager's pause since nothing else will.
+func (e *TradingEngine) reconnect(ctx context.Context, interval time.Duration) {
+	reconnectCtx := tracing.WithTraceID(ctx, tracing.NewTraceID())
+	logger := tracing.Logger(reconnectCtx)
+
+	e.mu.Lock()
+	e.brokerPaused = true
+	delay := e.reconnectBackoff
+	maxDelay := e.reconnectMaxBackoff
+	e.mu.Unlock()
+	if delay <= 0 {
+		delay = interval
+	}
+	if maxDelay < delay {
+		maxDelay = delay
+	}
+	e.orderManager.SetBrokerPaused(true)
+	defer e.orderManager.SetBrokerPaused(false)
+
+	logger.Warn().Msg("Broker disconnected, pausing signal execution")
+	e.broadcastConnection(false)
+	e.notifyConnection(reconnectCtx, models.NotificationWarning, "Broker disconnected",
+		"Lost connection to the broker. Signal execution and order submission are paused while reconnecting.",
+		map[string]interface{}{models.NotificationCriticalKey: true})
+
+	attempts := 0
+	for {
+		attempts++
+		err := e.orderManager.ReconnectBroker()
+		if err == nil && e.orderManager.IsBrokerConnected() {
+			break
+		}
+		if err == nil {
+			err = fmt.Errorf("broker still reports disconnected")
+		}
+		logger.Warn().Err(err).Int("attempt", attempts).Dur("retry_in", delay).Msg("Broker reconnect failed")
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-e.stopCh:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		delay = min(delay*2, maxDelay)
+	}
+
+	e.reconcile(reconnectCtx)
+
+	e.mu.Lock()
+	e.brokerPaused = false
+	e.mu.Unlock()
+
+	logger.Info().Int("attempts", attempts).Msg("Broker reconnected, resuming signal execution")
+	e.broadcastConnection(true)
+	e.notifyConnection(reconnectCtx, models.NotificationSuccess, "Broker reconnected",
+		fmt.Sprintf("Reconnected to the broker after %d attempt(s). Signal execution has resumed.", attempts), nil)
+}
+
+// broadcastConnection sends a broker_connection WebSocket event.
+func (e *TradingEngine) broadcastConnection(connected bool) {
+	if e.wsManager != nil {
+		e.wsManager.Broadcast("broker_connection", map[string]bool{"connected": connected})
+	}
+}
+
+// notifyConnection sends a broker connection notification, if a notifier is set.
+func (e *TradingEngine) notifyConnection(ctx context.Context, notifType models.NotificationType, title, message string, metadata map[string]interface{}) {
+	e.mu.RLock()
+	notifier := e.notifier
+	e.mu.RUnlock()
+	if notifier == nil {
+		return
+	}
+
+	if _, err := notifier.Send(notifType, title, message, metadata); err != nil {
+		logger := tracing.Logger(ctx)
+		logger.Error().Err(err).Msg("Failed to send broker connection notification")
+	}
+}
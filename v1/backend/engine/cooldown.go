@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"sort"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/strategies"
+)
+
+// CooldownStatus reports a strategy that may not trade a symbol yet.
+type CooldownStatus struct {
+	Strategy         string  `json:"strategy"`
+	Symbol           string  `json:"symbol"`
+	RemainingTicks   int     `json:"remaining_ticks"`
+	RemainingSeconds float64 `json:"remaining_seconds"`
+}
+
+// cooldownKey identifies a strategy trading a symbol.
+type cooldownKey struct {
+	strategy string
+	symbol   string
+}
+
+// cooldownEntry records the last trade for a cooldownKey.
+type cooldownEntry struct {
+	tick     int       // Tick the trade was placed on
+	at       time.Time // When the trade was placed
+	cooldown strategies.Cooldown
+}
+
+// remaining returns how long the entry still blocks trading, given the tick
+// being processed and the current time.
+func (c cooldownEntry) remaining(tick int, now time.Time) (int, time.Duration) {
+	ticks := 0
+	if c.cooldown.Ticks > 0 {
+		ticks = max(c.tick+c.cooldown.Ticks+1-tick, 0)
+	}
+	wait := time.Duration(0)
+	if c.cooldown.Duration > 0 {
+		wait = max(c.at.Add(c.cooldown.Duration).Sub(now), 0)
+	}
+	return ticks, wait
+}
+
+// strategyCooldown returns the cooldown configured by a registered strategy.
+func (e *TradingEngine) strategyCooldown(name string) strategies.Cooldown {
+	strategy, ok := e.registry.Get(name)
+	if !ok {
+		return strategies.Cooldown{}
+	}
+	if cp, ok := strategy.(strategies.CooldownProvider); ok {
+		return cp.Cooldown()
+	}
+	return strategies.Cooldown{}
+}
+
+// cooldownRemaining returns how long a strategy must still wait before
+// trading a symbol. Both values are zero when it may trade.
+func (e *TradingEngine) cooldownRemaining(strategy, symbol string, now time.Time) (int, time.Duration) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	entry, ok := e.cooldowns[cooldownKey{strategy, symbol}]
+	if !ok {
+		return 0, 0
+	}
+	return entry.remaining(e.ticksCompleted, now)
+}
+
+// startCooldown records a trade by a strategy on a symbol, starting its
+// cooldown. Strategies without a cooldown are not tracked.
+func (e *TradingEngine) startCooldown(strategy, symbol string, now time.Time) {
+	cooldown := e.strategyCooldown(strategy)
+	if cooldown.IsZero() {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cooldowns == nil {
+		e.cooldowns = make(map[cooldownKey]cooldownEntry)
+	}
+	e.cooldowns[cooldownKey{strategy, symbol}] = cooldownEntry{
+		tick:     e.ticksCompleted,
+		at:       now,
+		cooldown: cooldown,
+	}
+}
+
+// activeCooldowns lists the cooldowns still in effect, sorted by strategy and
+// symbol. Must be called with e.mu held.
+func (e *TradingEngine) activeCooldowns(now time.Time) []CooldownStatus {
+	active := make([]CooldownStatus, 0, len(e.cooldowns))
+	for key, entry := range e.cooldowns {
+		ticks, wait := entry.remaining(e.ticksCompleted, now)
+		if ticks == 0 && wait == 0 {
+			continue
+		}
+		active = append(active, CooldownStatus{
+			Strategy:         key.strategy,
+			Symbol:           key.symbol,
+			RemainingTicks:   ticks,
+			RemainingSeconds: wait.Seconds(),
+		})
+	}
+	sort.Slice(active, func(i, j int) bool {
+		if active[i].Strategy != active[j].Strategy {
+			return active[i].Strategy < active[j].Strategy
+		}
+		return active[i].Symbol < active[j].Symbol
+	})
+	return active
+}
@@ -0,0 +1,32 @@
+package engine
+
+import "time"
+
+// Heartbeat is broadcast as a heartbeat WebSocket event at the end of each
+// tick, so clients can tell an idle engine from one that has stopped.
+type Heartbeat struct {
+	Tick             int       `json:"tick"`              // Ticks completed since start, including this one
+	Timestamp        time.Time `json:"timestamp"`         // When the tick finished
+	SymbolsProcessed int       `json:"symbols_processed"` // Symbols processed without error
+	SymbolErrors     int       `json:"symbol_errors"`     // Symbols skipped or failed, see engine status for details
+	SignalsGenerated int       `json:"signals_generated"` // Buy and sell signals generated during the tick
+	DurationMs       int64     `json:"duration_ms"`       // Time the tick took
+}
+
+// SetHeartbeat enables broadcasting a heartbeat event after every tick.
+// Must be called before Start.
+//
+// Args:
+//   - enabled: Whether to broadcast heartbeats
+func (e *TradingEngine) SetHeartbeat(enabled bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.heartbeat = enabled
+}
+
+// broadcastHeartbeat sends a heartbeat WebSocket event.
+func (e *TradingEngine) broadcastHeartbeat(beat Heartbeat) {
+	if e.wsManager != nil {
+		e.wsManager.Broadcast("heartbeat", beat)
+	}
+}
@@ -0,0 +1,96 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/rs/zerolog/log"
+)
+
+// Mode controls which signals the engine executes.
+type Mode string
+
+const (
+	// ModeNormal executes all signals.
+	ModeNormal Mode = "normal"
+	// ModeCloseOnly executes sells on symbols with an open position and
+	// blocks everything else, so existing positions are still managed while
+	// no new ones are opened (e.g. winding down before maintenance).
+	ModeCloseOnly Mode = "close_only"
+)
+
+// ParseMode validates a mode name.
+//
+// Args:
+//   - s: Mode name ("normal" or "close_only")
+//
+// Returns:
+//   - Mode: The parsed mode
+//   - error: If the name is not a known mode
+func ParseMode(s string) (Mode, error) {
+	switch Mode(s) {
+	case ModeNormal, ModeCloseOnly:
+		return Mode(s), nil
+	}
+	return "", fmt.Errorf("unknown engine mode %q: must be %q or %q", s, ModeNormal, ModeCloseOnly)
+}
+
+// SetMode switches the engine's execution mode. It takes effect from the next
+// signal and may be changed while the engine runs. Changes are broadcast as
+// an engine_mode WebSocket event.
+//
+// Args:
+//   - mode: The new mode
+func (e *TradingEngine) SetMode(mode Mode) {
+	e.mu.Lock()
+	previous := e.mode
+	e.mode = mode
+	e.mu.Unlock()
+
+	if previous.orNormal() == mode {
+		return
+	}
+	log.Info().Str("mode", string(mode)).Str("previous", string(previous.orNormal())).Msg("Engine mode changed")
+	if e.wsManager != nil {
+		e.wsManager.Broadcast("engine_mode", map[string]string{"mode": string(mode)})
+	}
+}
+
+// Mode returns the engine's execution mode.
+func (e *TradingEngine) Mode() Mode {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.mode.orNormal()
+}
+
+// orNormal treats the zero mode as ModeNormal.
+func (m Mode) orNormal() Mode {
+	if m == "" {
+		return ModeNormal
+	}
+	return m
+}
+
+// allowedInMode reports whether a signal may be executed in the current
+// mode, and the most it may trade. In close-only mode only sells against an
+// open long position pass, capped at the quantity held so they can't open a
+// short; the cap is 0 (none) in normal mode.
+func (e *TradingEngine) allowedInMode(signal models.Signal) (float64, bool, error) {
+	if e.Mode() != ModeCloseOnly {
+		return 0, true, nil
+	}
+	if signal.Type != models.SignalSell {
+		return 0, false, nil
+	}
+
+	positions, err := e.orderManager.GetPositions()
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get positions for close-only check: %w", err)
+	}
+	for _, pos := range positions {
+		if pos.Symbol == signal.Symbol && pos.Quantity > 0 {
+			return pos.Quantity, true, nil
+		}
+	}
+	return 0, false, nil
+}
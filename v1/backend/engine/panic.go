@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/tracing"
+)
+
+// ErrSymbolPanic marks a symbol whose processing panicked, e.g. on bad
+// strategy math or an unexpected provider response.
+var ErrSymbolPanic = errors.New("symbol processing panicked")
+
+// safeProcessSymbol runs processSymbol, converting a panic into an
+// ErrSymbolPanic error so one symbol can't take down the tick or the engine.
+// The first panic in a run of them is notified; a clean run ends the run.
+func (e *TradingEngine) safeProcessSymbol(ctx context.Context, symbol string) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			e.setPanicked(symbol, false)
+			return
+		}
+		err = fmt.Errorf("%w: %v", ErrSymbolPanic, r)
+		logger := tracing.Logger(ctx)
+		logger.Error().
+			Str("symbol", symbol).
+			Interface("panic", r).
+			Str("stack", string(debug.Stack())).
+			Msg("Recovered from panic processing symbol")
+		if e.setPanicked(symbol, true) {
+			e.notifyPanic(ctx, symbol, err)
+		}
+	}()
+	return e.processSymbol(ctx, symbol)
+}
+
+// setPanicked records whether a symbol's last run panicked and reports
+// whether it has just started panicking.
+func (e *TradingEngine) setPanicked(symbol string, panicked bool) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !panicked {
+		delete(e.panickedSymbols, symbol)
+		return false
+	}
+	if e.panickedSymbols == nil {
+		e.panickedSymbols = make(map[string]bool)
+	}
+	if e.panickedSymbols[symbol] {
+		return false
+	}
+	e.panickedSymbols[symbol] = true
+	return true
+}
+
+// notifyPanic alerts the user that a symbol's processing panicked.
+func (e *TradingEngine) notifyPanic(ctx context.Context, symbol string, cause error) {
+	e.mu.RLock()
+	notifier := e.notifier
+	e.mu.RUnlock()
+	if notifier == nil {
+		return
+	}
+
+	message := fmt.Sprintf("Processing %s panicked and was skipped; the engine keeps running: %v", symbol, cause)
+	if _, err := notifier.Send(models.NotificationError, "Engine panic", message, map[string]interface{}{
+		"symbol":   symbol,
+		"trace_id": tracing.TraceIDFromCtx(ctx),
+	}); err != nil {
+		logger := tracing.Logger(ctx)
+		logger.Error().Err(err).Msg("Failed to send panic notification")
+	}
+}
@@ -0,0 +1,149 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+// SetFetchFailureLimit configures pausing the engine when the data provider
+// appears to be down. After limit consecutive ticks in which every data fetch
+// failed, ticks stop processing symbols and an error notification is sent.
+// While paused each tick makes a single probe request instead, and the engine
+// resumes as soon as a probe succeeds or ResumeFetching is called. A tick
+// with any successful fetch resets the count. Must be called before Start.
+//
+// Args:
+//   - limit: consecutive all-failed ticks before pausing (0 disables)
+func (e *TradingEngine) SetFetchFailureLimit(limit int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.fetchFailureLimit = limit
+}
+
+// FetchPaused reports whether symbol processing is paused because every
+// data fetch has been failing.
+func (e *TradingEngine) FetchPaused() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.fetchPaused
+}
+
+// ResumeFetching clears a provider outage pause, so the next tick processes
+// every symbol again.
+//
+// Returns:
+//   - bool: true if the engine was paused
+func (e *TradingEngine) ResumeFetching() bool {
+	e.mu.Lock()
+	paused := e.fetchPaused
+	e.fetchPaused = false
+	e.failedFetchTicks = 0
+	e.mu.Unlock()
+
+	if paused {
+		log.Info().Msg("Data fetching resumed manually")
+		e.broadcastFetchPaused(false)
+	}
+	return paused
+}
+
+// recordFetch counts the outcome of one symbol's data fetch in the current tick.
+func (e *TradingEngine) recordFetch(ok bool) {
+	if ok {
+		e.tickFetches.Add(1)
+	} else {
+		e.tickFetchFailures.Add(1)
+	}
+}
+
+// checkFetchFailures updates the failure streak once a tick's fetches are
+// done and pauses the engine when it reaches the limit. Ticks that fetched
+// nothing, e.g. with every market closed, leave the streak unchanged.
+func (e *TradingEngine) checkFetchFailures(ctx context.Context) {
+	fetches, failures := e.tickFetches.Load(), e.tickFetchFailures.Load()
+	if fetches == 0 && failures == 0 {
+		return
+	}
+
+	e.mu.Lock()
+	if fetches > 0 {
+		e.failedFetchTicks = 0
+		e.mu.Unlock()
+		return
+	}
+	e.failedFetchTicks++
+	streak := e.failedFetchTicks
+	pause := e.fetchFailureLimit > 0 && streak >= e.fetchFailureLimit && !e.fetchPaused
+	if pause {
+		e.fetchPaused = true
+	}
+	e.mu.Unlock()
+
+	if !pause {
+		return
+	}
+	logger := tracing.Logger(ctx)
+	logger.Error().Int("ticks", streak).Msg("Every data fetch failed, pausing symbol processing")
+	e.broadcastFetchPaused(true)
+	e.notifyFetch(ctx, models.NotificationError, "Market data provider down",
+		fmt.Sprintf("Every data fetch failed for %d consecutive ticks. Symbol processing is paused and "+
+			"resumes when the provider responds again or when resumed manually.", streak))
+}
+
+// probeProvider makes one request to check whether a paused provider has
+// recovered, resuming the engine if it has.
+//
+// Returns:
+//   - bool: true if the engine may process symbols this tick
+func (e *TradingEngine) probeProvider(ctx context.Context) bool {
+	if !e.FetchPaused() {
+		return true
+	}
+	if len(e.symbols) == 0 {
+		return false
+	}
+
+	logger := tracing.Logger(ctx)
+	if _, err := data.GetLatestPriceContext(ctx, e.provider, e.symbols[0]); err != nil {
+		logger.Debug().Err(err).Str("symbol", e.symbols[0]).Msg("Provider probe failed, symbol processing stays paused")
+		return false
+	}
+
+	e.mu.Lock()
+	e.fetchPaused = false
+	e.failedFetchTicks = 0
+	e.mu.Unlock()
+
+	logger.Info().Msg("Provider probe succeeded, resuming symbol processing")
+	e.broadcastFetchPaused(false)
+	e.notifyFetch(ctx, models.NotificationSuccess, "Market data provider recovered",
+		"The data provider is responding again. Symbol processing has resumed.")
+	return true
+}
+
+// broadcastFetchPaused sends a provider_status WebSocket event.
+func (e *TradingEngine) broadcastFetchPaused(paused bool) {
+	if e.wsManager != nil {
+		e.wsManager.Broadcast("provider_status", map[string]bool{"paused": paused})
+	}
+}
+
+// notifyFetch sends a provider outage notification, if a notifier is set.
+func (e *TradingEngine) notifyFetch(ctx context.Context, notifType models.NotificationType, title, message string) {
+	e.mu.RLock()
+	notifier := e.notifier
+	e.mu.RUnlock()
+	if notifier == nil {
+		return
+	}
+
+	if _, err := notifier.Send(notifType, title, message, nil); err != nil {
+		logger := tracing.Logger(ctx)
+		logger.Error().Err(err).Msg("Failed to send provider outage notification")
+	}
+}
@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/rs/zerolog/log"
+)
+
+// SignalStore persists logged strategy signals.
+// *data.SQLSignalStore satisfies it.
+type SignalStore interface {
+	SaveSignals(records []models.SignalRecord) error
+	QuerySignals(filter data.SignalFilter) ([]models.SignalRecord, int, error)
+}
+
+const (
+	signalLogBuffer        = 1024        // Records queued before new ones are dropped
+	signalLogBatchSize     = 100         // Records written per transaction
+	signalLogFlushInterval = time.Second // Longest a record waits before being written
+)
+
+// signalLog writes signal records to a SignalStore in batches on its own
+// goroutine, so recording never blocks the tick loop.
+type signalLog struct {
+	store   SignalStore
+	records chan models.SignalRecord
+	done    chan struct{}
+}
+
+// newSignalLog starts a signal writer for a store.
+func newSignalLog(store SignalStore) *signalLog {
+	l := &signalLog{
+		store:   store,
+		records: make(chan models.SignalRecord, signalLogBuffer),
+		done:    make(chan struct{}),
+	}
+	go l.run()
+	return l
+}
+
+// record queues a signal for writing. If the queue is full the record is
+// dropped rather than stalling the engine.
+func (l *signalLog) record(record models.SignalRecord) {
+	select {
+	case l.records <- record:
+	default:
+		log.Warn().Str("symbol", record.Symbol).Str("strategy", record.StrategyName).Msg("Signal log full, dropping signal")
+	}
+}
+
+// close flushes queued records and stops the writer. record must not be
+// called after close.
+func (l *signalLog) close() {
+	close(l.records)
+	<-l.done
+}
+
+// run batches queued records, writing when a batch fills, the flush interval
+// elapses or the log is closed.
+func (l *signalLog) run() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(signalLogFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]models.SignalRecord, 0, signalLogBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := l.store.SaveSignals(batch); err != nil {
+			log.Error().Err(err).Int("signals", len(batch)).Msg("Failed to save signals")
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case record, ok := <-l.records:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, record)
+			if len(batch) >= signalLogBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
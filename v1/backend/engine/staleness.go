@@ -0,0 +1,139 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/tracing"
+)
+
+// ErrStaleData marks a symbol skipped because its latest candle is too old
+// to act on.
+var ErrStaleData = errors.New("stale market data")
+
+// StaleDataGuard configures when the latest candle is too old to trade on.
+// Thresholds are counted in candle intervals missed since the latest candle
+// closed; 0 disables the guard for that asset class.
+type StaleDataGuard struct {
+	CryptoIntervals int  // Crypto trades around the clock, so any gap is suspect
+	EquityIntervals int  // Weekend hours are not counted for equities
+	Notify          bool // Send a notification when a symbol goes stale
+}
+
+// SetStaleDataGuard configures skipping execution for symbols whose data
+// has stopped updating, e.g. after a provider outage. Must be called before
+// Start.
+//
+// Args:
+//   - guard: Staleness thresholds and notification setting
+func (e *TradingEngine) SetStaleDataGuard(guard StaleDataGuard) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.staleGuard = guard
+}
+
+// checkStale returns an ErrStaleData error if the latest candle closed more
+// than the configured number of intervals before now. Timeframes of unknown
+// length are never treated as stale.
+func (e *TradingEngine) checkStale(ctx context.Context, symbol, timeframe string, latest models.OHLCV, now time.Time) error {
+	e.mu.RLock()
+	guard := e.staleGuard
+	calendar := e.calendar
+	e.mu.RUnlock()
+
+	crypto := data.IsCryptoSymbol(symbol)
+	intervals := guard.EquityIntervals
+	if crypto {
+		intervals = guard.CryptoIntervals
+	}
+	candle, ok := data.IntervalDuration(timeframe)
+	if intervals <= 0 || !ok {
+		return nil
+	}
+
+	closed := latest.Timestamp.Add(candle)
+	age := now.Sub(closed)
+	if !crypto {
+		age = weekdayDuration(closed, now, calendarLocation(calendar))
+	}
+	limit := time.Duration(intervals) * candle
+
+	if age <= limit {
+		e.setStale(symbol, false)
+		return nil
+	}
+
+	err := fmt.Errorf("%w: latest %s candle closed %s ago (limit %s)",
+		ErrStaleData, timeframe, age.Round(time.Second), limit)
+	if e.setStale(symbol, true) && guard.Notify {
+		e.notifyStale(ctx, symbol, err)
+	}
+	return err
+}
+
+// setStale records whether a symbol's data is stale and reports whether it
+// has just become stale.
+func (e *TradingEngine) setStale(symbol string, stale bool) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !stale {
+		delete(e.staleSymbols, symbol)
+		return false
+	}
+	if e.staleSymbols == nil {
+		e.staleSymbols = make(map[string]bool)
+	}
+	if e.staleSymbols[symbol] {
+		return false
+	}
+	e.staleSymbols[symbol] = true
+	return true
+}
+
+// notifyStale alerts the user that a symbol's execution is paused.
+func (e *TradingEngine) notifyStale(ctx context.Context, symbol string, cause error) {
+	e.mu.RLock()
+	notifier := e.notifier
+	e.mu.RUnlock()
+	if notifier == nil {
+		return
+	}
+
+	message := fmt.Sprintf("Signals for %s are not executed until fresh data arrives: %v", symbol, cause)
+	if _, err := notifier.Send(models.NotificationWarning, "Stale market data", message, map[string]interface{}{
+		"symbol": symbol,
+	}); err != nil {
+		logger := tracing.Logger(ctx)
+		logger.Error().Err(err).Msg("Failed to send stale data notification")
+	}
+}
+
+// calendarLocation returns the timezone a calendar reads weekdays in, or
+// UTC when the calendar doesn't expose one.
+func calendarLocation(calendar TradingCalendar) *time.Location {
+	if c, ok := calendar.(interface{ Location() *time.Location }); ok {
+		return c.Location()
+	}
+	return time.UTC
+}
+
+// weekdayDuration returns the time between from and to, excluding Saturdays
+// and Sundays in loc.
+func weekdayDuration(from, to time.Time, loc *time.Location) time.Duration {
+	var total time.Duration
+	for t := from.In(loc); t.Before(to); {
+		next := time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		if next.After(to) {
+			next = to.In(loc)
+		}
+		if day := t.Weekday(); day != time.Saturday && day != time.Sunday {
+			total += next.Sub(t)
+		}
+		t = next
+	}
+	return total
+}
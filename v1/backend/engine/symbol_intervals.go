@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// ParseSymbolIntervals parses per-symbol data intervals from a
+// comma-separated list of SYMBOL:INTERVAL entries, e.g. "BTC-USD:1h,ETH-USD:1h".
+//
+// Args:
+//   - s: The override list (empty for none)
+//   - aliases: Symbol aliases entries may use (nil for none)
+//
+// Returns:
+//   - map[string]string: Intervals keyed by canonical symbol
+//   - error: If an entry is malformed, repeats a symbol or names an unknown interval
+func ParseSymbolIntervals(s string, aliases *data.SymbolAliases) (map[string]string, error) {
+	intervals := make(map[string]string)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		symbol, interval, ok := strings.Cut(entry, ":")
+		symbol, interval = strings.TrimSpace(symbol), strings.TrimSpace(interval)
+		if !ok || symbol == "" || interval == "" {
+			return nil, fmt.Errorf("invalid symbol interval %q: must be SYMBOL:INTERVAL", entry)
+		}
+		if _, known := data.IntervalDuration(interval); !known {
+			return nil, fmt.Errorf("invalid symbol interval %q: unknown interval %q", entry, interval)
+		}
+		canonical := aliases.Resolve(symbol)
+		if _, dup := intervals[canonical]; dup {
+			return nil, fmt.Errorf("invalid symbol interval %q: %s already has an interval", entry, canonical)
+		}
+		intervals[canonical] = interval
+	}
+	return intervals, nil
+}
+
+// SetSymbolIntervals sets the candle interval fetched for individual
+// symbols, in place of the strategies' timeframe. Each strategy sees a
+// symbol's candles resampled to its own timeframe, so every registered
+// strategy's timeframe must be a whole multiple of each override. Must be
+// called before Start, after strategies are registered.
+//
+// Args:
+//   - intervals: Intervals keyed by symbol (nil or empty for none)
+//
+// Returns:
+//   - error: If an interval is unknown or a strategy can't consume it
+func (e *TradingEngine) SetSymbolIntervals(intervals map[string]string) error {
+	canonical := make(map[string]string, len(intervals))
+	for symbol, interval := range intervals {
+		if _, known := data.IntervalDuration(interval); !known {
+			return fmt.Errorf("unknown interval %q for %s", interval, symbol)
+		}
+		for _, strategy := range e.registry.All() {
+			if _, err := data.Resample(nil, interval, strategy.Timeframe()); err != nil {
+				return fmt.Errorf("strategy %s can't use %s candles for %s: %w", strategy.Name(), interval, symbol, err)
+			}
+		}
+		canonical[data.CanonicalSymbol(symbol)] = interval
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.symbolIntervals = canonical
+	return nil
+}
+
+// symbolTimeframe returns the candle interval fetched for a symbol: its
+// override, or the engine's timeframe.
+func (e *TradingEngine) symbolTimeframe(symbol string) string {
+	e.mu.RLock()
+	interval, ok := e.symbolIntervals[symbol]
+	e.mu.RUnlock()
+	if ok {
+		return interval
+	}
+	return e.timeframe()
+}
+
+// strategyCandles returns a symbol's candles at a strategy's timeframe.
+// Candles fetched at an override interval are resampled, so the last bar may
+// still be forming; otherwise they're passed through unchanged. Resampled
+// series are cached by timeframe for the rest of the tick.
+func (e *TradingEngine) strategyCandles(symbol, fetched, timeframe string, candles []models.OHLCV, cache map[string][]models.OHLCV) []models.OHLCV {
+	e.mu.RLock()
+	_, overridden := e.symbolIntervals[symbol]
+	e.mu.RUnlock()
+	if !overridden || timeframe == fetched {
+		return candles
+	}
+	if resampled, ok := cache[timeframe]; ok {
+		return resampled
+	}
+	// SetSymbolIntervals checked every strategy can use the interval
+	resampled, err := data.Resample(candles, fetched, timeframe)
+	if err != nil {
+		resampled = candles
+	}
+	cache[timeframe] = resampled
+	return resampled
+}
@@ -0,0 +1,62 @@
+package engine
+
+import "time"
+
+// SetOrderThrottle sets the minimum time between engine orders on the same
+// symbol, whatever the strategy. Unlike a strategy cooldown it is a hard floor
+// on submission frequency, and it carries across restarts: Start seeds each
+// symbol's last order time from the order history. Must be called before
+// Start.
+//
+// Args:
+//   - interval: Minimum spacing between orders per symbol (0 disables)
+func (e *TradingEngine) SetOrderThrottle(interval time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.orderThrottle = interval
+}
+
+// lastEngineOrders returns when the engine last ordered each symbol, from the
+// order manager's history. Manual orders, which carry no strategy, are not
+// counted.
+func (e *TradingEngine) lastEngineOrders() map[string]time.Time {
+	last := make(map[string]time.Time)
+	if e.orderManager == nil {
+		return last
+	}
+	orders, err := e.orderManager.GetAllOrders()
+	if err != nil {
+		return last
+	}
+	for _, order := range orders {
+		if order.StrategyName == "" {
+			continue
+		}
+		if order.CreatedAt.After(last[order.Symbol]) {
+			last[order.Symbol] = order.CreatedAt
+		}
+	}
+	return last
+}
+
+// throttleRemaining returns how long until the engine may order a symbol
+// again; zero when it may.
+func (e *TradingEngine) throttleRemaining(symbol string, now time.Time) time.Duration {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	last, ok := e.lastOrderAt[symbol]
+	if e.orderThrottle <= 0 || !ok {
+		return 0
+	}
+	return max(last.Add(e.orderThrottle).Sub(now), 0)
+}
+
+// recordOrder notes that the engine ordered a symbol.
+func (e *TradingEngine) recordOrder(symbol string, now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.lastOrderAt == nil {
+		e.lastOrderAt = make(map[string]time.Time)
+	}
+	e.lastOrderAt[symbol] = now
+}
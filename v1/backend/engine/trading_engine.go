@@ -2,12 +2,17 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/alexherrero/sherwood/backend/data"
 	"github.com/alexherrero/sherwood/backend/execution"
+	"github.com/alexherrero/sherwood/backend/metrics"
 	"github.com/alexherrero/sherwood/backend/models"
 	"github.com/alexherrero/sherwood/backend/realtime"
 	"github.com/alexherrero/sherwood/backend/strategies"
@@ -15,22 +20,104 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// ErrSignalLogUnavailable is returned by QuerySignals when no signal store is configured.
+var ErrSignalLogUnavailable = errors.New("signal log not configured")
+
+// Notifier delivers user-facing alerts, such as a dropped signal.
+// *notifications.Manager satisfies it.
+type Notifier interface {
+	Send(notifType models.NotificationType, title, message string, metadata map[string]interface{}) (string, error)
+}
+
+// CircuitBreakerStatus reports the risk manager's daily loss breaker.
+type CircuitBreakerStatus struct {
+	Enabled bool `json:"enabled"`
+	Tripped bool `json:"tripped"`
+}
+
+// EngineStatus is a snapshot of the engine's runtime state.
+type EngineStatus struct {
+	Running          bool                 `json:"running"`
+	Interval         string               `json:"interval"`
+	Symbols          []string             `json:"symbols"`
+	StartedAt        time.Time            `json:"started_at,omitempty"`
+	LastTickAt       time.Time            `json:"last_tick_at,omitempty"`
+	TickCount        int                  `json:"tick_count"`
+	WarmingUp        bool                 `json:"warming_up"`
+	Priming          bool                 `json:"priming"`
+	Mode             Mode                 `json:"mode"`
+	BrokerPaused     bool                 `json:"broker_paused"`
+	FetchPaused      bool                 `json:"fetch_paused"`
+	FailedFetchTicks int                  `json:"failed_fetch_ticks"`
+	SignalsGenerated int                  `json:"signals_generated"`
+	OrdersPlaced     int                  `json:"orders_placed"`
+	SymbolErrors     map[string]string    `json:"symbol_errors"`
+	Cooldowns        []CooldownStatus     `json:"cooldowns"`
+	LastOrderAt      map[string]time.Time `json:"last_order_at"`
+	CircuitBreaker   CircuitBreakerStatus `json:"circuit_breaker"`
+}
+
 // TradingEngine manages the core trading loop.
 type TradingEngine struct {
-	provider        data.DataProvider
-	registry        *strategies.Registry
-	orderManager    *execution.OrderManager
-	wsManager       *realtime.WebSocketManager
-	symbols         []string
-	interval        time.Duration
-	lookback        time.Duration
-	closeOnShutdown bool
-	stopCh          chan struct{}
-	wg              sync.WaitGroup
-	mu              sync.RWMutex
-	running         bool
-	ctx             context.Context
-	cancel          context.CancelFunc
+	provider            data.DataProvider
+	registry            *strategies.Registry
+	orderManager        *execution.OrderManager
+	wsManager           *realtime.WebSocketManager
+	symbols             []string
+	interval            time.Duration
+	alignTicks          bool
+	warmupTicks         int
+	mode                Mode
+	concurrency         int // Max symbols processed at once per tick; 0 means all
+	primeData           bool
+	primeTimeout        time.Duration
+	primed              map[string]primedCandles // History fetched at start, consumed by the first tick
+	priming             bool                     // Whether start-up priming is still running
+	symbolIntervals     map[string]string        // Candle interval per symbol, overriding the strategies' timeframe
+	ticksCompleted      int
+	orderAttempts       int
+	orderRetryDelay     time.Duration
+	notifier            Notifier
+	reconcileStart      bool
+	reconcileEvery      time.Duration
+	connCheckEvery      time.Duration
+	reconnectBackoff    time.Duration
+	reconnectMaxBackoff time.Duration
+	brokerPaused        bool // Set while the connection monitor is reconnecting
+	snapshotEvery       time.Duration
+	heartbeat           bool // Broadcast a heartbeat event after each tick
+	signalStore         SignalStore
+	logSignals          bool
+	signalLog           *signalLog // Running signal writer, set between Start and Stop
+	calendar            TradingCalendar
+	staleGuard          StaleDataGuard
+	staleSymbols        map[string]bool // Symbols skipped for stale data, to notify once per outage
+	panickedSymbols     map[string]bool // Symbols whose last run panicked, to notify once per run of panics
+	fetchFailureLimit   int             // Consecutive all-failed ticks before pausing; 0 disables
+	failedFetchTicks    int             // Current run of ticks in which every fetch failed
+	fetchPaused         bool            // Set while the provider looks down, until a probe succeeds
+	tickFetches         atomic.Int32    // Successful fetches in the current tick
+	tickFetchFailures   atomic.Int32    // Failed fetches in the current tick
+	fetchWhenClosed     bool
+	tickAdvancer        data.TickAdvancer // Stepped at the start of each tick (e.g., a replay provider)
+	startedAt           time.Time
+	lastTickAt          time.Time
+	signalCount         int
+	orderCount          int
+	symbolErrors        map[string]string
+	cooldowns           map[cooldownKey]cooldownEntry // Last trade per strategy and symbol
+	orderThrottle       time.Duration                 // Minimum time between engine orders per symbol; 0 disables
+	lastOrderAt         map[string]time.Time          // Last engine order per symbol, for the order throttle
+	autoExit            AutoExit                      // Take-profit and stop-loss levels (zero value disables)
+	autoExitOrders      map[string]string             // Last auto-exit order ID per symbol
+	lookback            time.Duration
+	closeOnShutdown     bool
+	stopCh              chan struct{}
+	wg                  sync.WaitGroup
+	mu                  sync.RWMutex
+	running             bool
+	ctx                 context.Context
+	cancel              context.CancelFunc
 }
 
 // NewTradingEngine creates a new trading engine instance.
@@ -40,7 +127,7 @@ type TradingEngine struct {
 //   - registry: Strategy registry
 //   - orderManager: Execution order manager
 //   - wsManager: WebSocket manager for real-time updates (can be nil)
-//   - symbols: List of symbols to trade
+//   - symbols: List of symbols to trade, normalized to canonical form
 //   - interval: Polling interval
 //   - lookback: Historical data lookback period
 //   - closeOnShutdown: If true, close all positions on graceful shutdown
@@ -57,12 +144,17 @@ func NewTradingEngine(
 	lookback time.Duration,
 	closeOnShutdown bool,
 ) *TradingEngine {
+	canonical := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		canonical[i] = data.CanonicalSymbol(symbol)
+	}
+
 	return &TradingEngine{
 		provider:        provider,
 		registry:        registry,
 		orderManager:    orderManager,
 		wsManager:       wsManager,
-		symbols:         symbols,
+		symbols:         canonical,
 		interval:        interval,
 		lookback:        lookback,
 		closeOnShutdown: closeOnShutdown,
@@ -76,6 +168,12 @@ func NewTradingEngine(
 // Start begins the trading loop.
 // It runs until the context is cancelled or Stop() is called.
 func (e *TradingEngine) Start(ctx context.Context) error {
+	if e.IsRunning() {
+		return fmt.Errorf("trading engine already running")
+	}
+
+	lastOrders := e.lastEngineOrders()
+
 	e.mu.Lock()
 	if e.running {
 		e.mu.Unlock()
@@ -84,10 +182,37 @@ func (e *TradingEngine) Start(ctx context.Context) error {
 	e.running = true
 	// Re-initialize stopCh to allow restart
 	e.stopCh = make(chan struct{})
+	// Warm-up and runtime counters restart with the engine
+	e.ticksCompleted = 0
+	e.startedAt = time.Now()
+	e.lastTickAt = time.Time{}
+	e.signalCount = 0
+	e.orderCount = 0
+	e.symbolErrors = make(map[string]string)
+	e.primed = nil
+	e.priming = e.primeData && len(e.symbols) > 0
+	e.brokerPaused = false
+	e.staleSymbols = nil
+	e.failedFetchTicks = 0
+	e.fetchPaused = false
+	connCheckEvery := e.connCheckEvery
+	e.cooldowns = make(map[cooldownKey]cooldownEntry)
+	e.lastOrderAt = lastOrders
+	if e.logSignals && e.signalStore != nil {
+		e.signalLog = newSignalLog(e.signalStore)
+	}
+	// Stop cancels this context so in-flight provider requests are abandoned
+	ctx, cancel := context.WithCancel(ctx)
+	e.ctx = ctx
+	e.cancel = cancel
 	e.mu.Unlock()
 
 	e.wg.Add(1)
 	go e.loop(ctx)
+	if connCheckEvery > 0 {
+		e.wg.Add(1)
+		go e.monitorConnection(ctx, connCheckEvery)
+	}
 
 	log.Info().
 		Dur("interval", e.interval).
@@ -120,6 +245,234 @@ func (e *TradingEngine) UpdateConfig(closeOnShutdown bool) {
 	log.Info().Bool("close_on_shutdown", closeOnShutdown).Msg("Engine config updated via hot-reload")
 }
 
+// SetAlignTicks enables aligning ticks to interval boundaries, so a 1-minute
+// engine fires at :00 of each minute rather than drifting from its start time.
+// Must be called before Start.
+//
+// Args:
+//   - align: whether to align ticks to interval boundaries
+func (e *TradingEngine) SetAlignTicks(align bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.alignTicks = align
+}
+
+// SetConcurrency limits how many symbols a tick processes at once. The rest
+// wait for a free slot, bounding goroutines and concurrent provider requests
+// on large symbol universes. Must be called before Start.
+//
+// Args:
+//   - limit: maximum symbols in flight per tick (0 processes all at once)
+func (e *TradingEngine) SetConcurrency(limit int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.concurrency = limit
+}
+
+// SetTickAdvancer sets a provider to step forward at the start of every tick,
+// so replayed data advances with the engine instead of with each price read.
+// Must be called before Start.
+//
+// Args:
+//   - advancer: provider to step each tick (nil disables)
+func (e *TradingEngine) SetTickAdvancer(advancer data.TickAdvancer) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.tickAdvancer = advancer
+}
+
+// SetPriming configures fetching each symbol's history when the engine
+// starts. Symbols are fetched one at a time rather than all at once, so the
+// provider isn't hit with a burst, in the background so Start doesn't wait.
+// The first tick runs as soon as priming finishes and reuses the results. A
+// provider or auth failure for every symbol is logged and notified at once
+// instead of surfacing one interval later. Must be called before Start.
+//
+// Args:
+//   - enabled: whether to prime history at start
+//   - timeout: time allowed for priming; symbols not reached are fetched on the first tick (0 means no limit)
+func (e *TradingEngine) SetPriming(enabled bool, timeout time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.primeData = enabled
+	e.primeTimeout = timeout
+}
+
+// SetWarmupTicks sets how many ticks strategies run before their signals are
+// executed. During warm-up signals are logged and broadcast but not traded,
+// giving indicators like MACD and RSI time to settle. Must be called before Start.
+//
+// Args:
+//   - ticks: number of warm-up ticks (0 disables warm-up)
+func (e *TradingEngine) SetWarmupTicks(ticks int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.warmupTicks = ticks
+}
+
+// IsWarmingUp reports whether the engine is still within its warm-up period.
+//
+// Returns:
+//   - bool: true if signals are currently suppressed
+func (e *TradingEngine) IsWarmingUp() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.ticksCompleted < e.warmupTicks
+}
+
+// SetOrderRetry configures how order submissions that fail with a retryable
+// error (e.g. a transient broker failure) are retried. The delay doubles after
+// each attempt. Validation and risk rejections are never retried.
+// Must be called before Start.
+//
+// Args:
+//   - attempts: total submission attempts per signal (values below 1 mean a single attempt)
+//   - delay: wait before the first retry
+func (e *TradingEngine) SetOrderRetry(attempts int, delay time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.orderAttempts = attempts
+	e.orderRetryDelay = delay
+}
+
+// SetNotifier sets where dropped-signal alerts are sent. Must be called before Start.
+//
+// Args:
+//   - notifier: notification sink (can be nil)
+func (e *TradingEngine) SetNotifier(notifier Notifier) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.notifier = notifier
+}
+
+// SetReconciliation configures reconciling the order cache and positions
+// against the broker. Reconciliation runs in the engine loop, so it never
+// overlaps with a tick. Must be called before Start.
+//
+// Args:
+//   - onStart: reconcile once when the engine starts, before the first tick
+//   - interval: how often to reconcile while running (0 disables)
+func (e *TradingEngine) SetReconciliation(onStart bool, interval time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.reconcileStart = onStart
+	e.reconcileEvery = interval
+}
+
+// SetEquitySnapshots configures recording account equity for performance
+// history. A snapshot is taken when the engine starts and then every interval
+// until it stops. Must be called before Start.
+//
+// Args:
+//   - interval: how often to record a snapshot (0 disables)
+func (e *TradingEngine) SetEquitySnapshots(interval time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.snapshotEvery = interval
+}
+
+// SetSignalLog configures the store for strategy signals. When enabled, every
+// signal (including holds) is recorded with whether it was executed. Writes
+// are batched in the background. The store also serves QuerySignals, even
+// when recording is disabled. Must be called before Start.
+//
+// Args:
+//   - store: signal persistence (can be nil)
+//   - enabled: record signals while the engine runs
+func (e *TradingEngine) SetSignalLog(store SignalStore, enabled bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.signalStore = store
+	e.logSignals = enabled
+}
+
+// QuerySignals returns logged signals matching the filter, newest first.
+//
+// Args:
+//   - filter: Symbol, strategy, time range and pagination
+//
+// Returns:
+//   - []models.SignalRecord: The requested page of signals
+//   - int: Total count of matching signals
+//   - error: If no signal store is configured or the query fails
+func (e *TradingEngine) QuerySignals(filter data.SignalFilter) ([]models.SignalRecord, int, error) {
+	e.mu.RLock()
+	store := e.signalStore
+	e.mu.RUnlock()
+	if store == nil {
+		return nil, 0, ErrSignalLogUnavailable
+	}
+	return store.QuerySignals(filter)
+}
+
+// recordSignal queues a signal for the signal log, if it is running.
+func (e *TradingEngine) recordSignal(signal models.Signal, executed bool) {
+	e.mu.RLock()
+	signals := e.signalLog
+	e.mu.RUnlock()
+	if signals != nil {
+		signals.record(models.NewSignalRecord(signal, executed, time.Now()))
+	}
+}
+
+// SetTradingCalendar restricts strategy execution to market hours. Symbols
+// whose market is closed are skipped for the tick. Must be called before Start.
+//
+// Args:
+//   - calendar: market hours source (nil trades around the clock)
+//   - fetchWhenClosed: still fetch and broadcast data for closed markets
+func (e *TradingEngine) SetTradingCalendar(calendar TradingCalendar, fetchWhenClosed bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.calendar = calendar
+	e.fetchWhenClosed = fetchWhenClosed
+}
+
+// Trades reports whether a canonical symbol is in the engine's universe.
+func (e *TradingEngine) Trades(symbol string) bool {
+	return slices.Contains(e.symbols, symbol)
+}
+
+// Status returns a snapshot of the engine's runtime state. Counters cover
+// the period since the last Start.
+//
+// Returns:
+//   - EngineStatus: The current state
+func (e *TradingEngine) Status() EngineStatus {
+	e.mu.RLock()
+	status := EngineStatus{
+		Running:          e.running,
+		Interval:         e.interval.String(),
+		Symbols:          append([]string{}, e.symbols...),
+		StartedAt:        e.startedAt,
+		LastTickAt:       e.lastTickAt,
+		TickCount:        e.ticksCompleted,
+		WarmingUp:        e.ticksCompleted < e.warmupTicks,
+		Priming:          e.priming,
+		Mode:             e.mode.orNormal(),
+		BrokerPaused:     e.brokerPaused,
+		FetchPaused:      e.fetchPaused,
+		FailedFetchTicks: e.failedFetchTicks,
+		SignalsGenerated: e.signalCount,
+		OrdersPlaced:     e.orderCount,
+		SymbolErrors:     make(map[string]string, len(e.symbolErrors)),
+		Cooldowns:        e.activeCooldowns(time.Now()),
+		LastOrderAt:      make(map[string]time.Time, len(e.lastOrderAt)),
+	}
+	for symbol, msg := range e.symbolErrors {
+		status.SymbolErrors[symbol] = msg
+	}
+	for symbol, at := range e.lastOrderAt {
+		status.LastOrderAt[symbol] = at
+	}
+	e.mu.RUnlock()
+
+	if e.orderManager != nil {
+		status.CircuitBreaker.Enabled, status.CircuitBreaker.Tripped = e.orderManager.TradingHalted()
+	}
+	return status
+}
+
 // Stop gracefully stops the trading engine loop.
 // It signals the loop to exit and waits for the current tick to complete.
 func (e *TradingEngine) Stop() {
@@ -130,9 +483,24 @@ func (e *TradingEngine) Stop() {
 	}
 	e.running = false
 	close(e.stopCh)
+	cancel := e.cancel
 	e.mu.Unlock()
 
+	// Abort slow provider calls rather than waiting out their timeout
+	if cancel != nil {
+		cancel()
+	}
 	e.wg.Wait()
+
+	// Flush logged signals once no tick can record more
+	e.mu.Lock()
+	signals := e.signalLog
+	e.signalLog = nil
+	e.mu.Unlock()
+	if signals != nil {
+		signals.close()
+	}
+
 	log.Info().Msg("Trading Engine stopped")
 }
 
@@ -167,8 +535,8 @@ func (e *TradingEngine) Shutdown(ctx context.Context) error {
 	default:
 	}
 
-	// Create an engine context for audit
-	shutdownCtx := execution.NewEngineContextWithTrace(ctx)
+	// Create an engine context for audit; closing sells bypass the kill-switch
+	shutdownCtx := execution.WithKillSwitchBypass(execution.NewEngineContextWithTrace(ctx))
 
 	// Step 3: Cancel all pending/submitted orders
 	cancelled, err := e.orderManager.CancelAllPendingOrders(shutdownCtx)
@@ -247,6 +615,64 @@ func (e *TradingEngine) closeAllPositions(ctx context.Context) error {
 func (e *TradingEngine) loop(ctx context.Context) {
 	defer e.wg.Done()
 
+	e.mu.RLock()
+	alignTicks := e.alignTicks
+	reconcileStart := e.reconcileStart
+	reconcileEvery := e.reconcileEvery
+	snapshotEvery := e.snapshotEvery
+	e.mu.RUnlock()
+
+	// Priming runs here rather than in Start, so Start never waits on the
+	// provider
+	primed, ok := e.primeSymbols(ctx)
+	if !ok {
+		return
+	}
+
+	if reconcileStart {
+		e.reconcile(ctx)
+	}
+
+	var snapshotC <-chan time.Time
+	if snapshotEvery > 0 {
+		e.recordEquity(ctx)
+		snapshotTicker := time.NewTicker(snapshotEvery)
+		defer snapshotTicker.Stop()
+		snapshotC = snapshotTicker.C
+	}
+
+	// A nil channel never fires, leaving periodic reconciliation disabled
+	var reconcileC <-chan time.Time
+	if reconcileEvery > 0 {
+		reconcileTicker := time.NewTicker(reconcileEvery)
+		defer reconcileTicker.Stop()
+		reconcileC = reconcileTicker.C
+	}
+
+	// The first tick follows priming at once, while the history is fresh
+	if primed {
+		e.tick(ctx)
+	}
+
+	// Wait for the next interval boundary before starting the ticker
+	if alignTicks {
+		now := time.Now()
+		delay := nextTickBoundary(now, e.interval).Sub(now)
+		log.Info().Dur("delay", delay).Msg("Aligning engine ticks to interval boundary")
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-e.stopCh:
+			timer.Stop()
+			return
+		case <-timer.C:
+			e.tick(ctx)
+		}
+	}
+
 	ticker := time.NewTicker(e.interval)
 	defer ticker.Stop()
 
@@ -257,31 +683,319 @@ func (e *TradingEngine) loop(ctx context.Context) {
 		case <-e.stopCh:
 			return
 		case <-ticker.C:
-			// Generate a unique trace ID for this tick
-			tickTraceID := tracing.NewTraceID()
-			tickCtx := tracing.WithTraceID(ctx, tickTraceID)
-			tickLogger := tracing.Logger(tickCtx)
-
-			tickLogger.Debug().
-				Int("symbols", len(e.symbols)).
-				Msg("Engine tick started")
-
-			// Process symbols concurrently
-			var wg sync.WaitGroup
-			for _, symbol := range e.symbols {
-				wg.Add(1)
-				go func(sym string) {
-					defer wg.Done()
-					if err := e.processSymbol(tickCtx, sym); err != nil {
-						tickLogger.Error().Err(err).Str("symbol", sym).Msg("Error processing symbol")
-					}
-				}(symbol)
+			e.tick(ctx)
+		case <-reconcileC:
+			e.reconcile(ctx)
+		case <-snapshotC:
+			e.recordEquity(ctx)
+		}
+	}
+}
+
+// reconcile syncs the order manager with the broker, logging any failure.
+func (e *TradingEngine) reconcile(ctx context.Context) {
+	reconcileCtx := tracing.WithTraceID(ctx, tracing.NewTraceID())
+	if _, err := e.orderManager.Reconcile(reconcileCtx); err != nil {
+		logger := tracing.Logger(reconcileCtx)
+		logger.Error().Err(err).Msg("Reconciliation failed")
+	}
+}
+
+// recordEquity persists an account equity snapshot, logging any failure.
+func (e *TradingEngine) recordEquity(ctx context.Context) {
+	snapshotCtx := tracing.WithTraceID(ctx, tracing.NewTraceID())
+	if _, err := e.orderManager.RecordEquitySnapshot(snapshotCtx); err != nil {
+		logger := tracing.Logger(snapshotCtx)
+		logger.Error().Err(err).Msg("Equity snapshot failed")
+	}
+}
+
+// tick processes all symbols once.
+func (e *TradingEngine) tick(ctx context.Context) {
+	// Generate a unique trace ID for this tick
+	tickTraceID := tracing.NewTraceID()
+	tickCtx := tracing.WithTraceID(ctx, tickTraceID)
+	tickLogger := tracing.Logger(tickCtx)
+
+	tickLogger.Debug().
+		Int("symbols", len(e.symbols)).
+		Msg("Engine tick started")
+	tickStart := time.Now()
+
+	e.mu.RLock()
+	advancer := e.tickAdvancer
+	e.mu.RUnlock()
+	if advancer != nil {
+		advancer.AdvanceTick()
+	}
+
+	// A provider that looks down gets one probe instead of a fetch per symbol
+	if !e.probeProvider(tickCtx) {
+		return
+	}
+	e.tickFetches.Store(0)
+	e.tickFetchFailures.Store(0)
+
+	e.mu.RLock()
+	limit := e.concurrency
+	signalsBefore := e.signalCount
+	e.mu.RUnlock()
+	if limit <= 0 || limit > len(e.symbols) {
+		limit = len(e.symbols)
+	}
+
+	// Process symbols concurrently, at most limit at a time
+	var processed, failed atomic.Int32
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, limit)
+symbols:
+	for _, symbol := range e.symbols {
+		select {
+		case slots <- struct{}{}:
+		case <-tickCtx.Done():
+			// Stopping; don't start the symbols still waiting for a slot
+			break symbols
+		}
+		wg.Add(1)
+		go func(sym string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			err := e.safeProcessSymbol(tickCtx, sym)
+			if err != nil && tickCtx.Err() != nil {
+				// Stopped mid-tick; keep the symbol's last real result
+				return
+			}
+			if errors.Is(err, ErrStaleData) {
+				tickLogger.Warn().Err(err).Str("symbol", sym).Msg("Skipping symbol with stale data")
+			} else if err != nil && !errors.Is(err, ErrSymbolPanic) {
+				tickLogger.Error().Err(err).Str("symbol", sym).Msg("Error processing symbol")
+			}
+			if err != nil {
+				failed.Add(1)
+			} else {
+				processed.Add(1)
+			}
+			e.recordSymbolResult(sym, err)
+		}(symbol)
+	}
+	wg.Wait()
+	e.checkFetchFailures(tickCtx)
+	e.checkAutoExits(tickCtx)
+	tickDuration := time.Since(tickStart)
+	metrics.EngineTickDuration.Observe(tickDuration.Seconds())
+
+	e.mu.Lock()
+	e.ticksCompleted++
+	e.lastTickAt = time.Now()
+	// Primed history is only fresh enough for the first tick
+	e.primed = nil
+	if e.ticksCompleted == e.warmupTicks {
+		tickLogger.Info().Int("ticks", e.warmupTicks).Msg("Engine warm-up complete, signal execution enabled")
+	}
+	heartbeat := e.heartbeat
+	beat := Heartbeat{
+		Tick:             e.ticksCompleted,
+		Timestamp:        e.lastTickAt,
+		SymbolsProcessed: int(processed.Load()),
+		SymbolErrors:     int(failed.Load()),
+		SignalsGenerated: e.signalCount - signalsBefore,
+		DurationMs:       tickDuration.Milliseconds(),
+	}
+	e.mu.Unlock()
+
+	if heartbeat {
+		e.broadcastHeartbeat(beat)
+	}
+
+	tickLogger.Debug().Msg("Engine tick completed")
+}
+
+// recordSymbolResult stores the latest error for a symbol, clearing it on success.
+func (e *TradingEngine) recordSymbolResult(symbol string, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.symbolErrors == nil {
+		e.symbolErrors = make(map[string]string)
+	}
+	if err != nil {
+		e.symbolErrors[symbol] = err.Error()
+	} else {
+		delete(e.symbolErrors, symbol)
+	}
+}
+
+// nextTickBoundary returns the next multiple of interval after now.
+// Boundaries are measured from the zero time, so minute and hour intervals
+// land on wall-clock minutes and hours (UTC).
+func nextTickBoundary(now time.Time, interval time.Duration) time.Time {
+	if interval <= 0 {
+		return now
+	}
+	return now.Truncate(interval).Add(interval)
+}
+
+// timeframe returns the candle interval the engine fetches. Grouping by
+// timeframe would be ideal, but for now we assume a primary timeframe derived
+// from the first strategy by name, so it doesn't change between calls, or
+// default to "1d".
+func (e *TradingEngine) timeframe() string {
+	all := e.registry.All()
+	names := slices.Sorted(maps.Keys(all))
+	if len(names) == 0 {
+		return "1d"
+	}
+	return all[names[0]].Timeframe()
+}
+
+// primedCandles is history fetched for a symbol while the engine started.
+type primedCandles struct {
+	timeframe string
+	candles   []models.OHLCV
+}
+
+// prime fetches each symbol's history sequentially, within the priming
+// timeout, for the first tick to reuse. Symbols that fail are reported in the
+// returned error map and refetched on the first tick; symbols not reached
+// before the timeout are simply left unprimed.
+//
+// Args:
+//   - ctx: Parent context; cancelling it aborts priming
+//
+// Returns:
+//   - map[string]primedCandles: Fetched history by symbol (nil when priming is disabled)
+//   - map[string]string: Fetch errors by symbol, to seed the engine's symbol errors
+//   - error: If priming was aborted, or no symbol could be fetched (e.g. because provider auth failed)
+func (e *TradingEngine) prime(ctx context.Context) (map[string]primedCandles, map[string]string, error) {
+	e.mu.RLock()
+	enabled := e.primeData
+	timeout := e.primeTimeout
+	e.mu.RUnlock()
+
+	errs := make(map[string]string)
+	if !enabled || len(e.symbols) == 0 {
+		return nil, errs, nil
+	}
+
+	primeCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		primeCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	primeCtx = tracing.WithTraceID(primeCtx, tracing.NewTraceID())
+	logger := tracing.Logger(primeCtx)
+
+	primed := make(map[string]primedCandles, len(e.symbols))
+	startedAt := time.Now()
+	for _, symbol := range e.symbols {
+		if primeCtx.Err() != nil {
+			break
+		}
+		timeframe := e.symbolTimeframe(symbol)
+		end := time.Now()
+		candles, err := data.GetHistoricalDataContext(primeCtx, e.provider, symbol, end.Add(-e.lookback), end, timeframe)
+		if err == nil && len(candles) == 0 {
+			err = fmt.Errorf("no data returned")
+		}
+		if err != nil {
+			if primeCtx.Err() != nil {
+				break
 			}
-			wg.Wait()
+			logger.Warn().Err(err).Str("symbol", symbol).Msg("Failed to prime symbol data")
+			errs[symbol] = fmt.Sprintf("failed to fetch data: %v", err)
+			continue
+		}
+		primed[symbol] = primedCandles{timeframe: timeframe, candles: candles}
+	}
 
-			tickLogger.Debug().Msg("Engine tick completed")
+	if err := ctx.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errPrimingAborted, err)
+	}
+	if len(primed) < len(e.symbols)-len(errs) {
+		logger.Warn().
+			Dur("timeout", timeout).
+			Int("primed", len(primed)).
+			Int("symbols", len(e.symbols)).
+			Msg("Data priming timed out, remaining symbols will be fetched on the first tick")
+	}
+	if len(primed) == 0 && len(errs) > 0 {
+		for _, symbol := range e.symbols {
+			if msg, ok := errs[symbol]; ok {
+				return nil, errs, fmt.Errorf("data priming failed for all symbols: %s: %s", symbol, msg)
+			}
 		}
 	}
+
+	logger.Info().
+		Int("primed", len(primed)).
+		Int("failed", len(errs)).
+		Dur("elapsed", time.Since(startedAt)).
+		Msg("Symbol data primed")
+	return primed, errs, nil
+}
+
+// errPrimingAborted is returned by prime when the engine stops mid-priming.
+var errPrimingAborted = errors.New("data priming aborted")
+
+// primeSymbols primes history for the first tick and seeds the symbol
+// errors. When no symbol can be fetched the failure is logged and notified,
+// and the engine carries on, refetching on its regular ticks.
+//
+// Args:
+//   - ctx: Engine context; cancelling it aborts priming
+//
+// Returns:
+//   - bool: Whether any history was primed for the first tick
+//   - bool: False if the engine stopped during priming
+func (e *TradingEngine) primeSymbols(ctx context.Context) (bool, bool) {
+	primed, errs, err := e.prime(ctx)
+
+	e.mu.Lock()
+	e.priming = false
+	if errors.Is(err, errPrimingAborted) {
+		e.mu.Unlock()
+		return false, false
+	}
+	for symbol, msg := range errs {
+		e.symbolErrors[symbol] = msg
+	}
+	e.primed = primed
+	e.mu.Unlock()
+
+	if err != nil {
+		log.Error().Err(err).Msg("Data priming failed, symbols will be fetched on the next tick")
+		e.notifyPrimingFailed(ctx, err)
+	}
+	return len(primed) > 0, true
+}
+
+// notifyPrimingFailed alerts the user that no symbol could be primed, which
+// usually means the provider is down or rejecting its credentials.
+func (e *TradingEngine) notifyPrimingFailed(ctx context.Context, cause error) {
+	e.mu.RLock()
+	notifier := e.notifier
+	e.mu.RUnlock()
+	if notifier == nil {
+		return
+	}
+
+	if _, err := notifier.Send(models.NotificationError, "Data priming failed", cause.Error(), nil); err != nil {
+		logger := tracing.Logger(ctx)
+		logger.Error().Err(err).Msg("Failed to send priming notification")
+	}
+}
+
+// takePrimed returns and removes the history primed for a symbol, if any was
+// fetched for the same timeframe.
+func (e *TradingEngine) takePrimed(symbol, timeframe string) ([]models.OHLCV, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	entry, ok := e.primed[symbol]
+	if !ok {
+		return nil, false
+	}
+	delete(e.primed, symbol)
+	return entry.candles, entry.timeframe == timeframe
 }
 
 // processSymbol handles data fetching and strategy execution for a single symbol.
@@ -289,26 +1003,34 @@ func (e *TradingEngine) loop(ctx context.Context) {
 func (e *TradingEngine) processSymbol(ctx context.Context, symbol string) error {
 	logger := tracing.Logger(ctx)
 
+	e.mu.RLock()
+	calendar := e.calendar
+	fetchWhenClosed := e.fetchWhenClosed
+	e.mu.RUnlock()
+
+	end := time.Now()
+	marketOpen := calendar == nil || calendar.IsOpen(symbol, end)
+	if !marketOpen && !fetchWhenClosed {
+		logger.Debug().Str("symbol", symbol).Msg("Market closed, skipping symbol")
+		return nil
+	}
+
 	// 1. Fetch latest data
 	// Fetch enough candles for strategies
-	end := time.Now()
 	start := end.Add(-e.lookback)
 
-	// 2. Iterate over strategies, grouping by timeframe would be ideal, but for now we assume a primary timeframe derived from the first available strategy or default to "1d"
-	timeframe := "1d"
-	strategiesList := e.registry.All()
-	if len(strategiesList) > 0 {
-		for _, s := range strategiesList {
-			timeframe = s.Timeframe()
-			break // Use the first strategy's timeframe for now
-		}
-	}
+	timeframe := e.symbolTimeframe(symbol)
 
-	// Assume generic timeframe (Daily) for now.
-	// In a real system, we'd need to handle multiple timeframes.
-	candles, err := e.provider.GetHistoricalData(symbol, start, end, timeframe)
-	if err != nil {
-		return fmt.Errorf("failed to fetch data: %w", err)
+	candles, ok := e.takePrimed(symbol, timeframe)
+	if !ok {
+		var err error
+		candles, err = data.GetHistoricalDataContext(ctx, e.provider, symbol, start, end, timeframe)
+		e.recordFetch(err == nil)
+		if err != nil {
+			return fmt.Errorf("failed to fetch data: %w", err)
+		}
+	} else {
+		e.recordFetch(true)
 	}
 
 	if len(candles) == 0 {
@@ -320,21 +1042,39 @@ func (e *TradingEngine) processSymbol(ctx context.Context, symbol string) error
 		Int("candles", len(candles)).
 		Msg("Data fetched for symbol")
 
-	// Broadcast latest candle
+	// Broadcast latest candle, coalesced per symbol if market_data is throttled
 	if e.wsManager != nil {
 		latest := candles[len(candles)-1]
-		e.wsManager.Broadcast("market_data", map[string]interface{}{
-			"symbol": symbol,
-			"candle": latest,
+		e.wsManager.BroadcastKeyed("market_data", symbol, map[string]interface{}{
+			"symbol":   symbol,
+			"interval": timeframe,
+			"candle":   latest,
 		})
 	}
 
+	if !marketOpen {
+		logger.Debug().Str("symbol", symbol).Msg("Market closed, skipping strategy execution")
+		return nil
+	}
+
+	// Acting on a feed that stopped updating could trade on old prices
+	if err := e.checkStale(ctx, symbol, timeframe, candles[len(candles)-1], end); err != nil {
+		return err
+	}
+
 	// 2. Iterate over strategies
+	var execErr error
+	resampled := make(map[string][]models.OHLCV)
 	for _, strategy := range e.registry.All() {
+		if !e.registry.Enabled(strategy.Name()) {
+			continue
+		}
+
 		// 3. Generate Signal
-		signal := strategy.OnData(candles)
+		signal := strategy.OnData(e.strategyCandles(symbol, timeframe, strategy.Timeframe(), candles, resampled))
 
 		// 4. Handle Signal
+		executed := false
 		if signal.Type != models.SignalHold {
 			logger.Info().
 				Str("strategy", strategy.Name()).
@@ -342,24 +1082,105 @@ func (e *TradingEngine) processSymbol(ctx context.Context, symbol string) error
 				Str("signal", string(signal.Type)).
 				Msg("Strategy signal generated")
 
-			if err := e.executeSignal(ctx, signal); err != nil {
-				logger.Error().
-					Err(err).
-					Str("strategy", strategy.Name()).
-					Str("symbol", symbol).
-					Msg("Failed to execute signal")
+			e.mu.Lock()
+			e.signalCount++
+			e.mu.Unlock()
+			metrics.SignalsGenerated.Inc(strategy.Name(), string(signal.Type))
+
+			// Keep going so one failing strategy doesn't block the others;
+			// the error is logged by the caller and surfaced in Status()
+			placed, err := e.executeSignal(ctx, signal)
+			if err != nil {
+				execErr = fmt.Errorf("strategy %s failed to execute signal: %w", strategy.Name(), err)
 			}
+			executed = placed
 		}
+		e.recordSignal(signal, executed)
 	}
 
-	return nil
+	return execErr
 }
 
-// executeSignal handles the execution of a trading signal.
-// The context carries the tick's trace ID for log correlation.
-func (e *TradingEngine) executeSignal(ctx context.Context, signal models.Signal) error {
+// executeSignal handles the execution of a trading signal. Signals are
+// suppressed during warm-up and while the strategy's cooldown for the symbol
+// is running. The context carries the tick's trace ID for log correlation.
+//
+// Returns:
+//   - bool: true if an order was placed
+//   - error: Any error encountered placing the order
+func (e *TradingEngine) executeSignal(ctx context.Context, signal models.Signal) (bool, error) {
 	logger := tracing.Logger(ctx)
 
+	// Suppress execution during warm-up, but still surface the signal
+	if e.IsWarmingUp() {
+		logger.Info().
+			Str("symbol", signal.Symbol).
+			Str("type", string(signal.Type)).
+			Float64("price", signal.Price).
+			Str("strategy", signal.StrategyName).
+			Msg("Warm-up signal (not executed)")
+
+		if e.wsManager != nil {
+			e.wsManager.Broadcast("warmup_signal", signal)
+		}
+		return false, nil
+	}
+
+	// Synthetic symbols are derived series with no order book of their own
+	if data.IsSyntheticSymbol(signal.Symbol) {
+		logger.Info().
+			Str("symbol", signal.Symbol).
+			Str("type", string(signal.Type)).
+			Float64("price", signal.Price).
+			Str("strategy", signal.StrategyName).
+			Msg("Synthetic symbol signal (analysis only, not executed)")
+		return false, nil
+	}
+
+	if e.BrokerPaused() {
+		logger.Info().
+			Str("symbol", signal.Symbol).
+			Str("type", string(signal.Type)).
+			Str("strategy", signal.StrategyName).
+			Msg("Signal blocked while broker is disconnected")
+		return false, nil
+	}
+
+	maxQuantity, allowed, err := e.allowedInMode(signal)
+	if err != nil {
+		return false, err
+	}
+	if !allowed {
+		logger.Info().
+			Str("symbol", signal.Symbol).
+			Str("type", string(signal.Type)).
+			Str("strategy", signal.StrategyName).
+			Msg("Signal blocked in close-only mode")
+		return false, nil
+	}
+
+	now := time.Now()
+	if ticks, wait := e.cooldownRemaining(signal.StrategyName, signal.Symbol, now); ticks > 0 || wait > 0 {
+		logger.Info().
+			Str("symbol", signal.Symbol).
+			Str("type", string(signal.Type)).
+			Str("strategy", signal.StrategyName).
+			Int("remaining_ticks", ticks).
+			Dur("remaining", wait).
+			Msg("Signal suppressed by cooldown")
+		return false, nil
+	}
+
+	if wait := e.throttleRemaining(signal.Symbol, now); wait > 0 {
+		logger.Warn().
+			Str("symbol", signal.Symbol).
+			Str("type", string(signal.Type)).
+			Str("strategy", signal.StrategyName).
+			Dur("remaining", wait).
+			Msg("Order throttled: too soon after the last order on this symbol")
+		return false, nil
+	}
+
 	logger.Info().
 		Str("symbol", signal.Symbol).
 		Str("type", string(signal.Type)).
@@ -372,6 +1193,14 @@ func (e *TradingEngine) executeSignal(ctx context.Context, signal models.Signal)
 	if signal.Quantity > 0 {
 		quantity = signal.Quantity
 	}
+	if maxQuantity > 0 && quantity > maxQuantity {
+		logger.Info().
+			Str("symbol", signal.Symbol).
+			Float64("quantity", quantity).
+			Float64("held", maxQuantity).
+			Msg("Close-only sell capped at the position size")
+		quantity = maxQuantity
+	}
 
 	var side models.OrderSide
 	if signal.Type == models.SignalBuy {
@@ -379,23 +1208,119 @@ func (e *TradingEngine) executeSignal(ctx context.Context, signal models.Signal)
 	} else if signal.Type == models.SignalSell {
 		side = models.OrderSideSell
 	} else {
-		return nil // Should be filtered already
+		return false, nil // Should be filtered already
+	}
+
+	placed, err := e.submitOrder(ctx, signal, side, quantity)
+	if err != nil || !placed {
+		return false, err
+	}
+	e.startCooldown(signal.StrategyName, signal.Symbol, now)
+	e.recordOrder(signal.Symbol, now)
+	return true, nil
+}
+
+// submitOrder places the order for a signal, retrying retryable failures
+// with exponential backoff. If every attempt fails, a notification is sent
+// so the dropped signal does not go unnoticed. An order staged for
+// confirmation is not placed: it reports false with no error, so no
+// cooldown or throttle starts until it is confirmed and the signal recurs.
+func (e *TradingEngine) submitOrder(ctx context.Context, signal models.Signal, side models.OrderSide, quantity float64) (bool, error) {
+	logger := tracing.Logger(ctx)
+
+	e.mu.RLock()
+	attempts := e.orderAttempts
+	delay := e.orderRetryDelay
+	stopCh := e.stopCh
+	e.mu.RUnlock()
+	if attempts < 1 {
+		attempts = 1
 	}
 
 	// Create engine context that inherits the tick's trace ID
 	engineCtx := execution.NewEngineContextWithTrace(ctx)
 
-	var err error
 	// If price is specified, use Limit Order, otherwise Market Order
+	order := models.Order{
+		Symbol:       signal.Symbol,
+		Side:         side,
+		Type:         models.OrderTypeMarket,
+		Quantity:     quantity,
+		StrategyName: signal.StrategyName,
+		Tags:         slices.Clone(signal.Tags),
+	}
 	if signal.Price > 0 {
-		_, err = e.orderManager.CreateLimitOrder(engineCtx, signal.Symbol, side, quantity, signal.Price)
-	} else {
-		_, err = e.orderManager.CreateMarketOrder(engineCtx, signal.Symbol, side, quantity)
+		order.Type = models.OrderTypeLimit
+		order.Price = signal.Price
 	}
 
-	if err != nil {
-		return fmt.Errorf("failed to submit order: %w", err)
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		order.Status = models.OrderStatusPending
+		order.CreatedAt = time.Now()
+		order.UpdatedAt = order.CreatedAt
+		var result *models.Order
+		result, err = e.orderManager.SubmitOrder(engineCtx, order)
+		if err == nil && result != nil && result.Status == models.OrderStatusPendingConfirmation {
+			logger.Warn().
+				Str("symbol", signal.Symbol).
+				Str("strategy", signal.StrategyName).
+				Time("confirm_by", result.ConfirmBy).
+				Msg("Order staged for confirmation, not placed")
+			return false, nil
+		}
+		if err == nil {
+			e.mu.Lock()
+			e.orderCount++
+			e.mu.Unlock()
+			return true, nil
+		}
+		if !execution.IsRetryable(err) {
+			return false, fmt.Errorf("failed to submit order: %w", err)
+		}
+		if attempt == attempts {
+			break
+		}
+
+		wait := delay << (attempt - 1)
+		logger.Warn().
+			Err(err).
+			Str("symbol", signal.Symbol).
+			Int("attempt", attempt).
+			Dur("retry_in", wait).
+			Msg("Order submission failed, retrying")
+
+		select {
+		case <-ctx.Done():
+			return false, fmt.Errorf("failed to submit order: %w", err)
+		case <-stopCh:
+			return false, fmt.Errorf("failed to submit order: %w", err)
+		case <-time.After(wait):
+		}
 	}
 
-	return nil
+	e.notifyDroppedSignal(ctx, signal, attempts, err)
+	return false, fmt.Errorf("failed to submit order after %d attempts: %w", attempts, err)
+}
+
+// notifyDroppedSignal alerts the user that a signal was not executed.
+func (e *TradingEngine) notifyDroppedSignal(ctx context.Context, signal models.Signal, attempts int, cause error) {
+	e.mu.RLock()
+	notifier := e.notifier
+	e.mu.RUnlock()
+	if notifier == nil {
+		return
+	}
+
+	message := fmt.Sprintf("%s %s signal from %s was dropped after %d attempts: %v",
+		signal.Type, signal.Symbol, signal.StrategyName, attempts, cause)
+	if _, err := notifier.Send(models.NotificationError, "Signal dropped", message, map[string]interface{}{
+		"symbol":   signal.Symbol,
+		"type":     string(signal.Type),
+		"strategy": signal.StrategyName,
+		"attempts": attempts,
+	}); err != nil {
+		logger := tracing.Logger(ctx)
+		logger.Error().Err(err).Msg("Failed to send dropped signal notification")
+	}
 }
@@ -2,11 +2,16 @@ package engine
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/alexherrero/sherwood/backend/data"
 	"github.com/alexherrero/sherwood/backend/execution"
 	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/realtime"
 	"github.com/alexherrero/sherwood/backend/strategies"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -125,9 +130,9 @@ func TestTradingEngine_RunLoop(t *testing.T) {
 		StrategyName: "MockStrategy",
 	})
 
-	// Expectation: Broker PlaceOrder called
+	// Expectation: Broker PlaceOrder called with the signal's strategy attached
 	mockBroker.On("PlaceOrder", mock.MatchedBy(func(o models.Order) bool {
-		return o.Symbol == "AAPL" && o.Side == models.OrderSideBuy && o.Quantity == 10
+		return o.Symbol == "AAPL" && o.Side == models.OrderSideBuy && o.Quantity == 10 && o.StrategyName == "MockStrategy"
 	})).Return(&models.Order{ID: "order-1", Status: models.OrderStatusSubmitted}, nil)
 
 	// Run Engine
@@ -144,6 +149,11 @@ func TestTradingEngine_RunLoop(t *testing.T) {
 	// Verify
 	mockProvider.AssertExpectations(t)
 	mockBroker.AssertExpectations(t)
+
+	// The broker's copy has no attribution, so the order manager keeps it
+	order, err := orderManager.GetOrder("order-1")
+	require.NoError(t, err)
+	assert.Equal(t, "MockStrategy", order.StrategyName)
 }
 
 func TestTradingEngine_StopIdempotency(t *testing.T) {
@@ -566,3 +576,1543 @@ func TestTradingEngine_ShutdownIdempotent(t *testing.T) {
 	err = eng.Shutdown(shutdownCtx)
 	require.NoError(t, err)
 }
+
+// TestNextTickBoundary verifies ticks align to interval boundaries.
+func TestNextTickBoundary(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 30, 42, 500, time.UTC)
+
+	assert.Equal(t, time.Date(2026, 3, 10, 14, 31, 0, 0, time.UTC), nextTickBoundary(now, time.Minute))
+	assert.Equal(t, time.Date(2026, 3, 10, 14, 35, 0, 0, time.UTC), nextTickBoundary(now, 5*time.Minute))
+	assert.Equal(t, time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC), nextTickBoundary(now, time.Hour))
+
+	// Exactly on a boundary waits for the next one
+	onBoundary := time.Date(2026, 3, 10, 14, 31, 0, 0, time.UTC)
+	assert.Equal(t, onBoundary.Add(time.Minute), nextTickBoundary(onBoundary, time.Minute))
+}
+
+// TestTradingEngine_AlignTicks verifies an aligned engine still ticks and stops cleanly.
+func TestTradingEngine_AlignTicks(t *testing.T) {
+	mockProvider := new(MockProvider)
+	mockBroker := new(MockBroker)
+	registry := strategies.NewRegistry()
+	orderManager := execution.NewOrderManager(mockBroker, nil, nil, nil)
+
+	eng := NewTradingEngine(
+		mockProvider,
+		registry,
+		orderManager,
+		nil,
+		[]string{"AAPL"},
+		10*time.Millisecond,
+		24*time.Hour,
+		false,
+	)
+	eng.SetAlignTicks(true)
+
+	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").
+		Return([]models.OHLCV{{Close: 150.0}}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, eng.Start(ctx))
+
+	time.Sleep(50 * time.Millisecond)
+
+	cancel()
+	eng.Stop()
+
+	mockProvider.AssertCalled(t, "GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d")
+}
+
+// TestTradingEngine_WarmupSuppressesExecution verifies signals are not
+// executed during warm-up and execution resumes afterwards.
+func TestTradingEngine_WarmupSuppressesExecution(t *testing.T) {
+	mockProvider := new(MockProvider)
+	mockStrategy := new(MockStrategy)
+	mockBroker := new(MockBroker)
+
+	registry := strategies.NewRegistry()
+	registry.Register(mockStrategy)
+	orderManager := execution.NewOrderManager(mockBroker, nil, nil, nil)
+
+	eng := NewTradingEngine(
+		mockProvider,
+		registry,
+		orderManager,
+		nil,
+		[]string{"AAPL"},
+		time.Hour,
+		24*time.Hour,
+		false,
+	)
+	eng.SetWarmupTicks(2)
+
+	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").
+		Return([]models.OHLCV{{Close: 150.0}}, nil)
+	mockStrategy.On("OnData", mock.Anything).Return(models.Signal{
+		Type:         models.SignalBuy,
+		Symbol:       "AAPL",
+		Quantity:     10,
+		StrategyName: "MockStrategy",
+	})
+	mockBroker.On("PlaceOrder", mock.Anything).
+		Return(&models.Order{ID: "order-1", Status: models.OrderStatusSubmitted}, nil)
+
+	ctx := context.Background()
+
+	// Two warm-up ticks: signals generated but not executed
+	assert.True(t, eng.IsWarmingUp())
+	eng.tick(ctx)
+	eng.tick(ctx)
+	mockStrategy.AssertNumberOfCalls(t, "OnData", 2)
+	mockBroker.AssertNotCalled(t, "PlaceOrder", mock.Anything)
+	assert.False(t, eng.IsWarmingUp())
+
+	// Warm-up over: signals are executed
+	eng.tick(ctx)
+	mockBroker.AssertNumberOfCalls(t, "PlaceOrder", 1)
+}
+
+// recordingNotifier captures notifications sent by the engine.
+type recordingNotifier struct {
+	mu       sync.Mutex
+	sent     []string
+	types    []models.NotificationType
+	critical []bool
+}
+
+func (n *recordingNotifier) Send(notifType models.NotificationType, title, message string, metadata map[string]interface{}) (string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = append(n.sent, message)
+	n.types = append(n.types, notifType)
+	critical, _ := metadata[models.NotificationCriticalKey].(bool)
+	n.critical = append(n.critical, critical)
+	return "notif-1", nil
+}
+
+// TestTradingEngine_OrderRetry verifies transient broker failures are retried,
+// permanent rejections fail fast, and exhausted retries notify the user.
+func TestTradingEngine_OrderRetry(t *testing.T) {
+	signal := models.Signal{
+		Type:         models.SignalBuy,
+		Symbol:       "AAPL",
+		Quantity:     10,
+		StrategyName: "MockStrategy",
+	}
+
+	newEngine := func(broker *MockBroker, rm *execution.RiskManager) (*TradingEngine, *recordingNotifier) {
+		orderManager := execution.NewOrderManager(broker, rm, nil, nil)
+		eng := NewTradingEngine(new(MockProvider), strategies.NewRegistry(), orderManager, nil,
+			[]string{"AAPL"}, time.Hour, 24*time.Hour, false)
+		notifier := &recordingNotifier{}
+		eng.SetOrderRetry(3, time.Millisecond)
+		eng.SetNotifier(notifier)
+		return eng, notifier
+	}
+
+	t.Run("RecoversFromTransientError", func(t *testing.T) {
+		broker := new(MockBroker)
+		broker.On("PlaceOrder", mock.Anything).Return(nil, fmt.Errorf("connection reset: %w", execution.ErrBrokerUnavailable)).Once()
+		broker.On("PlaceOrder", mock.Anything).
+			Return(&models.Order{ID: "order-1", Status: models.OrderStatusSubmitted}, nil).Once()
+		eng, notifier := newEngine(broker, nil)
+
+		placed, err := eng.executeSignal(context.Background(), signal)
+		require.NoError(t, err)
+		assert.True(t, placed)
+		broker.AssertNumberOfCalls(t, "PlaceOrder", 2)
+		assert.Empty(t, notifier.sent)
+	})
+
+	t.Run("NotifiesAfterExhaustingRetries", func(t *testing.T) {
+		broker := new(MockBroker)
+		broker.On("PlaceOrder", mock.Anything).Return(nil, fmt.Errorf("connection reset: %w", execution.ErrBrokerUnavailable))
+		eng, notifier := newEngine(broker, nil)
+
+		_, err := eng.executeSignal(context.Background(), signal)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "after 3 attempts")
+		broker.AssertNumberOfCalls(t, "PlaceOrder", 3)
+		require.Len(t, notifier.sent, 1)
+		assert.Equal(t, models.NotificationError, notifier.types[0])
+		assert.Contains(t, notifier.sent[0], "AAPL")
+	})
+
+	t.Run("BrokerRejectionFailsFast", func(t *testing.T) {
+		broker := new(MockBroker)
+		broker.On("PlaceOrder", mock.Anything).Return(nil, fmt.Errorf("insufficient funds"))
+		eng, _ := newEngine(broker, nil)
+
+		_, err := eng.executeSignal(context.Background(), signal)
+		require.Error(t, err)
+		broker.AssertNumberOfCalls(t, "PlaceOrder", 1)
+	})
+
+	t.Run("RiskRejectionFailsFast", func(t *testing.T) {
+		broker := new(MockBroker)
+		rm := execution.NewRiskManager(nil, broker)
+		rm.UpdateDailyPnL(-600) // Exceed daily loss limit
+		eng, notifier := newEngine(broker, rm)
+
+		_, err := eng.executeSignal(context.Background(), signal)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, execution.ErrRiskRejected)
+		broker.AssertNotCalled(t, "PlaceOrder", mock.Anything)
+		assert.Empty(t, notifier.sent)
+	})
+}
+
+// TestTradingEngine_SyntheticSignalNotExecuted verifies signals on synthetic
+// ratio symbols are analysis-only.
+func TestTradingEngine_SyntheticSignalNotExecuted(t *testing.T) {
+	broker := new(MockBroker)
+	orderManager := execution.NewOrderManager(broker, nil, nil, nil)
+	eng := NewTradingEngine(new(MockProvider), strategies.NewRegistry(), orderManager, nil,
+		[]string{"ratio:ETH-USD/BTC-USD"}, time.Hour, 24*time.Hour, false)
+
+	placed, err := eng.executeSignal(context.Background(), models.Signal{
+		Type:         models.SignalBuy,
+		Symbol:       "ratio:ETH-USD/BTC-USD",
+		Quantity:     1,
+		StrategyName: "MockStrategy",
+	})
+	require.NoError(t, err)
+	assert.False(t, placed)
+	broker.AssertNotCalled(t, "PlaceOrder", mock.Anything)
+}
+
+// TestTradingEngine_ReconcileOnStart verifies the engine reconciles with the
+// broker before its first tick.
+func TestTradingEngine_ReconcileOnStart(t *testing.T) {
+	broker := execution.NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+
+	// Placed directly at the broker, so unknown to the order manager
+	external, err := broker.PlaceOrder(models.Order{
+		Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeMarket, Quantity: 1,
+	})
+	require.NoError(t, err)
+
+	orderManager := execution.NewOrderManager(broker, nil, nil, nil)
+	eng := NewTradingEngine(new(MockProvider), strategies.NewRegistry(), orderManager, nil,
+		[]string{}, time.Hour, 24*time.Hour, false)
+	eng.SetReconciliation(true, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, eng.Start(ctx))
+	defer eng.Stop()
+
+	assert.Eventually(t, func() bool {
+		orders, _ := orderManager.GetAllOrders()
+		for _, o := range orders {
+			if o.ID == external.ID {
+				return true
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+}
+
+// TestTradingEngine_EquitySnapshots verifies snapshots are recorded on start
+// and periodically, and stop with the engine.
+func TestTradingEngine_EquitySnapshots(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	broker := execution.NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	orderManager := execution.NewOrderManager(broker, nil, data.NewOrderStore(db), nil)
+
+	eng := NewTradingEngine(new(MockProvider), strategies.NewRegistry(), orderManager, nil,
+		[]string{}, time.Hour, 24*time.Hour, false)
+	eng.SetEquitySnapshots(20 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, eng.Start(ctx))
+
+	assert.Eventually(t, func() bool {
+		snapshots, _ := orderManager.GetEquitySnapshots(time.Time{})
+		return len(snapshots) >= 3
+	}, time.Second, 10*time.Millisecond)
+
+	eng.Stop()
+	stopped, err := orderManager.GetEquitySnapshots(time.Time{})
+	require.NoError(t, err)
+
+	time.Sleep(60 * time.Millisecond)
+	after, err := orderManager.GetEquitySnapshots(time.Time{})
+	require.NoError(t, err)
+	assert.Len(t, after, len(stopped), "no snapshots after stop")
+	assert.Equal(t, 10000.0, after[0].Equity)
+}
+
+// TestTradingEngine_SignalLog verifies every signal, including holds and
+// warm-up signals, is recorded with whether it was executed.
+func TestTradingEngine_SignalLog(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mockProvider := new(MockProvider)
+	mockStrategy := new(MockStrategy)
+	mockBroker := new(MockBroker)
+	registry := strategies.NewRegistry()
+	registry.Register(mockStrategy)
+
+	eng := NewTradingEngine(mockProvider, registry, execution.NewOrderManager(mockBroker, nil, nil, nil), nil,
+		[]string{"AAPL"}, time.Hour, 24*time.Hour, false)
+	eng.SetWarmupTicks(1)
+	eng.SetSignalLog(data.NewSignalStore(db), true)
+
+	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").
+		Return([]models.OHLCV{{Close: 150.0}}, nil)
+	buy := models.Signal{Type: models.SignalBuy, Symbol: "AAPL", Price: 150, Reason: "crossover", StrategyName: "MockStrategy"}
+	mockStrategy.On("OnData", mock.Anything).Return(buy).Twice()
+	mockStrategy.On("OnData", mock.Anything).Return(models.Signal{Type: models.SignalHold, Symbol: "AAPL", StrategyName: "MockStrategy"}).Once()
+	mockBroker.On("PlaceOrder", mock.Anything).
+		Return(&models.Order{ID: "order-1", Status: models.OrderStatusSubmitted}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, eng.Start(ctx))
+	eng.tick(ctx) // warm-up: not executed
+	eng.tick(ctx) // executed
+	eng.tick(ctx) // hold
+	eng.Stop()    // flushes the log
+
+	records, total, err := eng.QuerySignals(data.SignalFilter{Symbol: "AAPL"})
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+
+	// Newest first
+	assert.Equal(t, models.SignalHold, records[0].Type)
+	assert.False(t, records[0].Executed)
+	assert.Equal(t, models.SignalBuy, records[1].Type)
+	assert.True(t, records[1].Executed)
+	assert.Equal(t, models.SignalBuy, records[2].Type)
+	assert.False(t, records[2].Executed)
+	assert.Equal(t, "MockStrategy", records[2].StrategyName)
+	assert.Equal(t, "crossover", records[2].Reason)
+	assert.Equal(t, 150.0, records[2].Price)
+
+	// Without a store, queries report the log as unavailable
+	_, _, err = NewTradingEngine(mockProvider, registry, nil, nil, nil, time.Hour, time.Hour, false).
+		QuerySignals(data.SignalFilter{})
+	assert.ErrorIs(t, err, ErrSignalLogUnavailable)
+}
+
+// closedCalendar reports every market as closed.
+type closedCalendar struct{}
+
+func (closedCalendar) IsOpen(symbol string, t time.Time) bool { return false }
+
+// TestTradingEngine_CalendarSkipsClosedMarkets verifies strategies do not run
+// while the market is closed, with and without fetching data.
+func TestTradingEngine_CalendarSkipsClosedMarkets(t *testing.T) {
+	for _, fetch := range []bool{false, true} {
+		mockProvider := new(MockProvider)
+		mockStrategy := new(MockStrategy)
+		registry := strategies.NewRegistry()
+		registry.Register(mockStrategy)
+
+		eng := NewTradingEngine(mockProvider, registry, execution.NewOrderManager(new(MockBroker), nil, nil, nil),
+			nil, []string{"AAPL"}, time.Hour, 24*time.Hour, false)
+		eng.SetTradingCalendar(closedCalendar{}, fetch)
+
+		mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").
+			Return([]models.OHLCV{{Close: 150.0}}, nil)
+
+		require.NoError(t, eng.processSymbol(context.Background(), "AAPL"))
+		mockStrategy.AssertNotCalled(t, "OnData", mock.Anything)
+		if fetch {
+			mockProvider.AssertNumberOfCalls(t, "GetHistoricalData", 1)
+		} else {
+			mockProvider.AssertNotCalled(t, "GetHistoricalData", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		}
+	}
+}
+
+// TestTradingEngine_DisabledStrategy verifies a disabled strategy is not run
+// until it is enabled again.
+func TestTradingEngine_DisabledStrategy(t *testing.T) {
+	mockProvider := new(MockProvider)
+	mockStrategy := new(MockStrategy)
+	registry := strategies.NewRegistry()
+	registry.Register(mockStrategy)
+
+	eng := NewTradingEngine(mockProvider, registry, execution.NewOrderManager(new(MockBroker), nil, nil, nil),
+		nil, []string{"AAPL"}, time.Hour, 24*time.Hour, false)
+
+	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").
+		Return([]models.OHLCV{{Close: 150.0}}, nil)
+	mockStrategy.On("OnData", mock.Anything).Return(models.Signal{Type: models.SignalHold, Symbol: "AAPL"})
+
+	require.True(t, registry.DisableStrategy("MockStrategy"))
+	require.NoError(t, eng.processSymbol(context.Background(), "AAPL"))
+	mockStrategy.AssertNotCalled(t, "OnData", mock.Anything)
+
+	require.True(t, registry.EnableStrategy("MockStrategy"))
+	require.NoError(t, eng.processSymbol(context.Background(), "AAPL"))
+	mockStrategy.AssertNumberOfCalls(t, "OnData", 1)
+}
+
+// TestTradingEngine_Status verifies runtime counters and per-symbol errors.
+func TestTradingEngine_Status(t *testing.T) {
+	mockProvider := new(MockProvider)
+	mockStrategy := new(MockStrategy)
+	mockBroker := new(MockBroker)
+
+	registry := strategies.NewRegistry()
+	registry.Register(mockStrategy)
+	rm := execution.NewRiskManager(&execution.RiskConfig{
+		MaxPositionSize: 1e9, MaxPortfolioRisk: 1, MaxDailyLoss: 500, MaxOpenOrders: 10,
+	}, mockBroker)
+	orderManager := execution.NewOrderManager(mockBroker, rm, nil, nil)
+
+	eng := NewTradingEngine(mockProvider, registry, orderManager, nil,
+		[]string{"AAPL", "FAIL"}, time.Hour, 24*time.Hour, false)
+
+	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").
+		Return([]models.OHLCV{{Close: 150.0}}, nil)
+	mockProvider.On("GetHistoricalData", "FAIL", mock.Anything, mock.Anything, "1d").
+		Return(nil, fmt.Errorf("provider down"))
+	mockStrategy.On("OnData", mock.Anything).Return(models.Signal{
+		Type: models.SignalBuy, Symbol: "AAPL", Quantity: 1, StrategyName: "MockStrategy",
+	})
+	mockBroker.On("PlaceOrder", mock.Anything).
+		Return(&models.Order{ID: "order-1", Status: models.OrderStatusSubmitted}, nil)
+
+	status := eng.Status()
+	assert.False(t, status.Running)
+	assert.True(t, status.LastTickAt.IsZero())
+
+	eng.tick(context.Background())
+
+	status = eng.Status()
+	assert.Equal(t, 1, status.TickCount)
+	assert.False(t, status.LastTickAt.IsZero())
+	assert.Equal(t, 1, status.SignalsGenerated)
+	assert.Equal(t, 1, status.OrdersPlaced)
+	assert.Contains(t, status.SymbolErrors["FAIL"], "provider down")
+	assert.NotContains(t, status.SymbolErrors, "AAPL")
+	assert.Equal(t, CircuitBreakerStatus{Enabled: true, Tripped: false}, status.CircuitBreaker)
+
+	rm.UpdateDailyPnL(-600)
+	assert.True(t, eng.Status().CircuitBreaker.Tripped)
+}
+
+// TestTradingEngine_StopCancelsProviderFetch verifies Stop does not wait for a
+// hung provider request to finish.
+func TestTradingEngine_StopCancelsProviderFetch(t *testing.T) {
+	mockProvider := new(MockProvider)
+	registry := strategies.NewRegistry()
+	registry.Register(new(MockStrategy))
+
+	eng := NewTradingEngine(mockProvider, registry, execution.NewOrderManager(new(MockBroker), nil, nil, nil),
+		nil, []string{"AAPL"}, 10*time.Millisecond, 24*time.Hour, false)
+
+	fetching := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	var once sync.Once
+	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").
+		Run(func(args mock.Arguments) {
+			once.Do(func() { close(fetching) })
+			<-release
+		}).
+		Return(nil, fmt.Errorf("released"))
+
+	require.NoError(t, eng.Start(context.Background()))
+	select {
+	case <-fetching:
+	case <-time.After(time.Second):
+		t.Fatal("provider was never called")
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		eng.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Stop blocked on the in-flight provider request")
+	}
+}
+
+// TestTradingEngine_Cooldown verifies a strategy's cooldown suppresses trades
+// on a symbol for the configured ticks and duration, and shows in Status().
+func TestTradingEngine_Cooldown(t *testing.T) {
+	newEngine := func(config map[string]interface{}) (*TradingEngine, *MockBroker) {
+		mockProvider := new(MockProvider)
+		mockBroker := new(MockBroker)
+		mockStrategy := new(MockStrategy)
+		require.NoError(t, mockStrategy.BaseStrategy.Init(config))
+		registry := strategies.NewRegistry()
+		registry.Register(mockStrategy)
+
+		eng := NewTradingEngine(mockProvider, registry, execution.NewOrderManager(mockBroker, nil, nil, nil),
+			nil, []string{"AAPL"}, time.Hour, 24*time.Hour, false)
+
+		mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").
+			Return([]models.OHLCV{{Close: 150.0}}, nil)
+		mockStrategy.On("OnData", mock.Anything).Return(models.Signal{
+			Type:         models.SignalBuy,
+			Symbol:       "AAPL",
+			Quantity:     1,
+			StrategyName: "MockStrategy",
+		})
+		mockBroker.On("PlaceOrder", mock.Anything).
+			Return(&models.Order{ID: "order-1", Status: models.OrderStatusSubmitted}, nil)
+		return eng, mockBroker
+	}
+	ctx := context.Background()
+
+	t.Run("Ticks", func(t *testing.T) {
+		eng, broker := newEngine(map[string]interface{}{"cooldown_ticks": 2})
+
+		eng.tick(ctx)
+		broker.AssertNumberOfCalls(t, "PlaceOrder", 1)
+
+		status := eng.Status()
+		require.Len(t, status.Cooldowns, 1)
+		assert.Equal(t, CooldownStatus{Strategy: "MockStrategy", Symbol: "AAPL", RemainingTicks: 2}, status.Cooldowns[0])
+
+		// The next two ticks are suppressed
+		eng.tick(ctx)
+		eng.tick(ctx)
+		broker.AssertNumberOfCalls(t, "PlaceOrder", 1)
+		assert.Empty(t, eng.Status().Cooldowns)
+
+		eng.tick(ctx)
+		broker.AssertNumberOfCalls(t, "PlaceOrder", 2)
+	})
+
+	t.Run("Duration", func(t *testing.T) {
+		eng, broker := newEngine(map[string]interface{}{"cooldown_seconds": 3600})
+
+		eng.tick(ctx)
+		eng.tick(ctx)
+		broker.AssertNumberOfCalls(t, "PlaceOrder", 1)
+
+		status := eng.Status()
+		require.Len(t, status.Cooldowns, 1)
+		assert.Zero(t, status.Cooldowns[0].RemainingTicks)
+		assert.InDelta(t, 3600, status.Cooldowns[0].RemainingSeconds, 5)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		eng, broker := newEngine(map[string]interface{}{})
+
+		eng.tick(ctx)
+		eng.tick(ctx)
+		broker.AssertNumberOfCalls(t, "PlaceOrder", 2)
+		assert.Empty(t, eng.Status().Cooldowns)
+	})
+}
+
+// TestTradingEngine_OrderThrottle verifies the engine orders a symbol at most
+// once per throttle window, including across a restart.
+func TestTradingEngine_OrderThrottle(t *testing.T) {
+	mockProvider := new(MockProvider)
+	mockBroker := new(MockBroker)
+	mockStrategy := new(MockStrategy)
+	require.NoError(t, mockStrategy.BaseStrategy.Init(map[string]interface{}{}))
+	registry := strategies.NewRegistry()
+	registry.Register(mockStrategy)
+	orderManager := execution.NewOrderManager(mockBroker, nil, nil, nil)
+
+	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").
+		Return([]models.OHLCV{{Close: 150.0}}, nil)
+	mockStrategy.On("OnData", mock.Anything).Return(models.Signal{
+		Type:         models.SignalBuy,
+		Symbol:       "AAPL",
+		Quantity:     1,
+		StrategyName: "MockStrategy",
+	})
+	mockBroker.On("PlaceOrder", mock.MatchedBy(func(o models.Order) bool { return o.Symbol == "AAPL" })).
+		Return(&models.Order{ID: "order-1", Symbol: "AAPL", Status: models.OrderStatusSubmitted, CreatedAt: time.Now()}, nil)
+	mockBroker.On("PlaceOrder", mock.MatchedBy(func(o models.Order) bool { return o.Symbol == "MSFT" })).
+		Return(&models.Order{ID: "order-2", Symbol: "MSFT", Status: models.OrderStatusSubmitted, CreatedAt: time.Now()}, nil)
+
+	newEngine := func() *TradingEngine {
+		eng := NewTradingEngine(mockProvider, registry, orderManager, nil, []string{"AAPL"}, time.Hour, 24*time.Hour, false)
+		eng.SetOrderThrottle(time.Hour)
+		return eng
+	}
+	ctx := context.Background()
+
+	eng := newEngine()
+	eng.tick(ctx)
+	eng.tick(ctx)
+	mockBroker.AssertNumberOfCalls(t, "PlaceOrder", 1)
+	first, ok := eng.Status().LastOrderAt["AAPL"]
+	require.True(t, ok)
+
+	// Manual orders carry no strategy and don't count toward the throttle
+	_, err := orderManager.CreateMarketOrder(ctx, "MSFT", models.OrderSideBuy, 1)
+	require.NoError(t, err)
+	mockBroker.AssertNumberOfCalls(t, "PlaceOrder", 2)
+
+	// A restarted engine picks up the last order from the history
+	restarted := newEngine()
+	runCtx, cancel := context.WithCancel(ctx)
+	require.NoError(t, restarted.Start(runCtx))
+	cancel()
+	restarted.Stop()
+
+	restarted.tick(ctx)
+	mockBroker.AssertNumberOfCalls(t, "PlaceOrder", 2)
+	lastOrders := restarted.Status().LastOrderAt
+	assert.WithinDuration(t, first, lastOrders["AAPL"], time.Second)
+	assert.NotContains(t, lastOrders, "MSFT")
+}
+
+// TestTradingEngine_Priming verifies history is primed in the background,
+// reused by a tick run as soon as priming finishes, and that priming failures
+// and timeouts surface correctly.
+func TestTradingEngine_Priming(t *testing.T) {
+	candles := []models.OHLCV{{Symbol: "AAPL", Close: 150.0}}
+
+	newEngine := func(provider *MockProvider, strategy *MockStrategy, symbols ...string) *TradingEngine {
+		registry := strategies.NewRegistry()
+		registry.Register(strategy)
+		eng := NewTradingEngine(provider, registry, execution.NewOrderManager(new(MockBroker), nil, nil, nil),
+			nil, symbols, time.Hour, 24*time.Hour, false)
+		eng.SetPriming(true, time.Second)
+		return eng
+	}
+	primingDone := func(eng *TradingEngine) func() bool {
+		return func() bool { return !eng.Status().Priming }
+	}
+
+	t.Run("first tick reuses primed history", func(t *testing.T) {
+		provider := new(MockProvider)
+		strategy := new(MockStrategy)
+		eng := newEngine(provider, strategy, "AAPL", "MSFT")
+		provider.On("GetHistoricalData", mock.Anything, mock.Anything, mock.Anything, "1d").Return(candles, nil)
+		strategy.On("OnData", candles).Return(models.Signal{Type: models.SignalHold})
+
+		ctx := context.Background()
+		require.NoError(t, eng.Start(ctx))
+		defer eng.Stop()
+
+		// The first tick runs once priming finishes, not an interval later
+		require.Eventually(t, func() bool { return eng.Status().TickCount == 1 }, time.Second, 5*time.Millisecond)
+		provider.AssertNumberOfCalls(t, "GetHistoricalData", 2)
+		strategy.AssertNumberOfCalls(t, "OnData", 2)
+
+		eng.tick(ctx)
+		provider.AssertNumberOfCalls(t, "GetHistoricalData", 4)
+	})
+
+	t.Run("start does not wait for priming", func(t *testing.T) {
+		provider := new(MockProvider)
+		eng := newEngine(provider, new(MockStrategy), "AAPL")
+		release := make(chan struct{})
+		provider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").
+			Run(func(args mock.Arguments) { <-release }).
+			Return(nil, fmt.Errorf("unavailable"))
+
+		require.NoError(t, eng.Start(context.Background()))
+		assert.True(t, eng.Status().Priming)
+		close(release)
+		eng.Stop()
+	})
+
+	t.Run("failure for every symbol is notified", func(t *testing.T) {
+		provider := new(MockProvider)
+		eng := newEngine(provider, new(MockStrategy), "AAPL", "MSFT")
+		notifier := &recordingNotifier{}
+		eng.SetNotifier(notifier)
+		provider.On("GetHistoricalData", mock.Anything, mock.Anything, mock.Anything, "1d").
+			Return(nil, fmt.Errorf("unauthorized"))
+
+		require.NoError(t, eng.Start(context.Background()))
+		defer eng.Stop()
+		require.Eventually(t, primingDone(eng), time.Second, 5*time.Millisecond)
+		assert.True(t, eng.IsRunning())
+		assert.Contains(t, eng.Status().SymbolErrors["AAPL"], "unauthorized")
+		assert.Zero(t, eng.Status().TickCount, "nothing primed, so no early tick")
+
+		notifier.mu.Lock()
+		defer notifier.mu.Unlock()
+		require.Len(t, notifier.sent, 1)
+		assert.Contains(t, notifier.sent[0], "unauthorized")
+	})
+
+	t.Run("partial failure is reported in status", func(t *testing.T) {
+		provider := new(MockProvider)
+		strategy := new(MockStrategy)
+		eng := newEngine(provider, strategy, "AAPL", "MSFT")
+		provider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").Return(candles, nil)
+		provider.On("GetHistoricalData", "MSFT", mock.Anything, mock.Anything, "1d").
+			Return(nil, fmt.Errorf("symbol not found"))
+		strategy.On("OnData", candles).Return(models.Signal{Type: models.SignalHold})
+
+		require.NoError(t, eng.Start(context.Background()))
+		defer eng.Stop()
+		require.Eventually(t, func() bool { return eng.Status().TickCount == 1 }, time.Second, 5*time.Millisecond)
+		assert.Contains(t, eng.Status().SymbolErrors["MSFT"], "symbol not found")
+		assert.NotContains(t, eng.Status().SymbolErrors, "AAPL")
+	})
+
+	t.Run("timeout leaves remaining symbols unprimed", func(t *testing.T) {
+		provider := new(MockProvider)
+		eng := newEngine(provider, new(MockStrategy), "AAPL", "MSFT")
+		eng.SetPriming(true, 20*time.Millisecond)
+		release := make(chan struct{})
+		defer close(release)
+		provider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").
+			Run(func(args mock.Arguments) { <-release }).
+			Return(candles, nil)
+
+		require.NoError(t, eng.Start(context.Background()))
+		defer eng.Stop()
+		require.Eventually(t, primingDone(eng), time.Second, 5*time.Millisecond)
+		provider.AssertNotCalled(t, "GetHistoricalData", "MSFT", mock.Anything, mock.Anything, "1d")
+		assert.Empty(t, eng.Status().SymbolErrors)
+	})
+}
+
+// timeframeStrategy is a MockStrategy with its own name and timeframe.
+type timeframeStrategy struct {
+	*MockStrategy
+	name, timeframe string
+}
+
+func (s *timeframeStrategy) Name() string      { return s.name }
+func (s *timeframeStrategy) Timeframe() string { return s.timeframe }
+
+// TestTradingEngine_Timeframe verifies the engine timeframe comes from the
+// first strategy by name, whatever the map order.
+func TestTradingEngine_Timeframe(t *testing.T) {
+	registry := strategies.NewRegistry()
+	eng := NewTradingEngine(new(MockProvider), registry, nil, nil, nil, time.Hour, 24*time.Hour, false)
+	assert.Equal(t, "1d", eng.timeframe())
+
+	for _, s := range []*timeframeStrategy{
+		{MockStrategy: new(MockStrategy), name: "zeta", timeframe: "1d"},
+		{MockStrategy: new(MockStrategy), name: "alpha", timeframe: "1h"},
+		{MockStrategy: new(MockStrategy), name: "mu", timeframe: "4h"},
+	} {
+		require.NoError(t, registry.Register(s))
+	}
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, "1h", eng.timeframe())
+	}
+}
+
+// positionsBroker is a MockBroker that reports fixed open positions.
+type positionsBroker struct {
+	*MockBroker
+	positions []models.Position
+}
+
+func (b *positionsBroker) GetPositions() ([]models.Position, error) { return b.positions, nil }
+
+// TestTradingEngine_CloseOnlyMode verifies close-only mode blocks buys and
+// sells without a position, but still executes sells that close one.
+func TestTradingEngine_CloseOnlyMode(t *testing.T) {
+	broker := &positionsBroker{
+		MockBroker: new(MockBroker),
+		positions:  []models.Position{{Symbol: "AAPL", Quantity: 10}},
+	}
+	broker.On("PlaceOrder", mock.Anything).Return(&models.Order{ID: "order-1", Status: models.OrderStatusSubmitted}, nil)
+	eng := NewTradingEngine(new(MockProvider), strategies.NewRegistry(), execution.NewOrderManager(broker, nil, nil, nil),
+		nil, []string{"AAPL", "MSFT"}, time.Hour, 24*time.Hour, false)
+
+	assert.Equal(t, ModeNormal, eng.Mode())
+	eng.SetMode(ModeCloseOnly)
+	assert.Equal(t, ModeCloseOnly, eng.Status().Mode)
+
+	signal := func(signalType models.SignalType, symbol string) models.Signal {
+		return models.Signal{Type: signalType, Symbol: symbol, Quantity: 5, StrategyName: "MockStrategy"}
+	}
+	ctx := context.Background()
+
+	placed, err := eng.executeSignal(ctx, signal(models.SignalBuy, "AAPL"))
+	require.NoError(t, err)
+	assert.False(t, placed, "buys are blocked even with an open position")
+
+	placed, err = eng.executeSignal(ctx, signal(models.SignalSell, "MSFT"))
+	require.NoError(t, err)
+	assert.False(t, placed, "sells without a position are blocked")
+	broker.AssertNotCalled(t, "PlaceOrder", mock.Anything)
+
+	placed, err = eng.executeSignal(ctx, signal(models.SignalSell, "AAPL"))
+	require.NoError(t, err)
+	assert.True(t, placed)
+	broker.AssertNumberOfCalls(t, "PlaceOrder", 1)
+
+	// A sell larger than the position only closes it
+	oversell := signal(models.SignalSell, "AAPL")
+	oversell.Quantity = 25
+	oversell.StrategyName = "OtherStrategy"
+	placed, err = eng.executeSignal(ctx, oversell)
+	require.NoError(t, err)
+	assert.True(t, placed)
+	broker.AssertCalled(t, "PlaceOrder", mock.MatchedBy(func(o models.Order) bool {
+		return o.Side == models.OrderSideSell && o.Quantity == 10
+	}))
+
+	eng.SetMode(ModeNormal)
+	placed, err = eng.executeSignal(ctx, signal(models.SignalBuy, "MSFT"))
+	require.NoError(t, err)
+	assert.True(t, placed)
+}
+
+func TestParseMode(t *testing.T) {
+	mode, err := ParseMode("close_only")
+	require.NoError(t, err)
+	assert.Equal(t, ModeCloseOnly, mode)
+
+	_, err = ParseMode("paused")
+	assert.Error(t, err)
+}
+
+// TestTradingEngine_ConcurrencyLimit verifies a tick processes at most the
+// configured number of symbols at once and still processes them all.
+func TestTradingEngine_ConcurrencyLimit(t *testing.T) {
+	symbols := []string{"AAPL", "MSFT", "GOOG", "AMZN", "META", "NVDA"}
+	mockProvider := new(MockProvider)
+	eng := NewTradingEngine(mockProvider, strategies.NewRegistry(), execution.NewOrderManager(new(MockBroker), nil, nil, nil),
+		nil, symbols, time.Hour, 24*time.Hour, false)
+	eng.SetConcurrency(2)
+
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	mockProvider.On("GetHistoricalData", mock.Anything, mock.Anything, mock.Anything, "1d").
+		Run(func(args mock.Arguments) {
+			mu.Lock()
+			inFlight++
+			peak = max(peak, inFlight)
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}).
+		Return([]models.OHLCV{{Close: 100}}, nil)
+
+	eng.tick(context.Background())
+
+	mockProvider.AssertNumberOfCalls(t, "GetHistoricalData", len(symbols))
+	assert.LessOrEqual(t, peak, 2)
+}
+
+// TestTradingEngine_ConcurrencyLimitStop verifies symbols waiting for a slot
+// are not started once the engine stops.
+func TestTradingEngine_ConcurrencyLimitStop(t *testing.T) {
+	mockProvider := new(MockProvider)
+	eng := NewTradingEngine(mockProvider, strategies.NewRegistry(), execution.NewOrderManager(new(MockBroker), nil, nil, nil),
+		nil, []string{"AAPL", "MSFT"}, time.Hour, 24*time.Hour, false)
+	eng.SetConcurrency(1)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").
+		Run(func(mock.Arguments) {
+			close(started)
+			<-release
+		}).
+		Return([]models.OHLCV{{Close: 100}}, nil)
+	mockProvider.On("GetHistoricalData", "MSFT", mock.Anything, mock.Anything, "1d").
+		Return([]models.OHLCV{{Close: 100}}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		eng.tick(ctx)
+		close(done)
+	}()
+
+	<-started
+	cancel()
+	// Give the tick a chance to see the cancellation before the slot frees
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("tick did not return after the engine stopped")
+	}
+	mockProvider.AssertNotCalled(t, "GetHistoricalData", "MSFT", mock.Anything, mock.Anything, "1d")
+}
+
+// flakyBroker is a PaperBroker whose first reconnect attempts fail.
+type flakyBroker struct {
+	*execution.PaperBroker
+	mu       sync.Mutex
+	failures int
+	attempts int
+}
+
+func (b *flakyBroker) Connect() error {
+	b.mu.Lock()
+	b.attempts++
+	fail := b.attempts <= b.failures
+	b.mu.Unlock()
+	if fail {
+		return fmt.Errorf("connection refused")
+	}
+	return b.PaperBroker.Connect()
+}
+
+// TestTradingEngine_ConnectionMonitor verifies a dropped broker pauses signal
+// execution, is reconnected with retries, and notifies on both transitions.
+func TestTradingEngine_ConnectionMonitor(t *testing.T) {
+	broker := &flakyBroker{PaperBroker: execution.NewPaperBroker(10000), failures: 2}
+	orderManager := execution.NewOrderManager(broker, nil, nil, nil)
+	eng := NewTradingEngine(new(MockProvider), strategies.NewRegistry(), orderManager, nil,
+		[]string{}, time.Hour, 24*time.Hour, false)
+	notifier := &recordingNotifier{}
+	eng.SetNotifier(notifier)
+	eng.SetConnectionMonitor(5*time.Millisecond, time.Millisecond, 2*time.Millisecond)
+
+	// Never connected, so the first check finds it down
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, eng.Start(ctx))
+	defer eng.Stop()
+
+	assert.Eventually(t, func() bool {
+		notifier.mu.Lock()
+		defer notifier.mu.Unlock()
+		return len(notifier.types) == 2
+	}, time.Second, 5*time.Millisecond)
+
+	assert.True(t, broker.IsConnected())
+	assert.False(t, eng.BrokerPaused())
+	assert.False(t, eng.Status().BrokerPaused)
+	assert.False(t, orderManager.BrokerPaused())
+	broker.mu.Lock()
+	assert.Equal(t, 3, broker.attempts)
+	broker.mu.Unlock()
+	notifier.mu.Lock()
+	assert.Equal(t, []models.NotificationType{models.NotificationWarning, models.NotificationSuccess}, notifier.types)
+	assert.Equal(t, []bool{true, false}, notifier.critical, "disconnects get past quiet hours")
+	notifier.mu.Unlock()
+}
+
+// TestTradingEngine_BrokerPausedBlocksOrders verifies orders placed outside
+// the engine are rejected while the broker is reconnecting, and the pause is
+// lifted if the engine stops first.
+func TestTradingEngine_BrokerPausedBlocksOrders(t *testing.T) {
+	broker := &flakyBroker{PaperBroker: execution.NewPaperBroker(10000), failures: 1000}
+	orderManager := execution.NewOrderManager(broker, nil, nil, nil)
+	eng := NewTradingEngine(new(MockProvider), strategies.NewRegistry(), orderManager, nil,
+		[]string{}, time.Hour, 24*time.Hour, false)
+	eng.SetConnectionMonitor(5*time.Millisecond, time.Millisecond, 2*time.Millisecond)
+
+	require.NoError(t, eng.Start(context.Background()))
+	assert.Eventually(t, orderManager.BrokerPaused, time.Second, 5*time.Millisecond)
+
+	_, err := orderManager.CreateMarketOrder(context.Background(), "AAPL", models.OrderSideBuy, 1)
+	assert.ErrorIs(t, err, execution.ErrBrokerPaused)
+
+	eng.Stop()
+	assert.False(t, orderManager.BrokerPaused())
+}
+
+// TestTradingEngine_BrokerPausedBlocksSignals verifies no orders are placed
+// while the connection monitor is reconnecting.
+func TestTradingEngine_BrokerPausedBlocksSignals(t *testing.T) {
+	broker := new(MockBroker)
+	eng := NewTradingEngine(new(MockProvider), strategies.NewRegistry(), execution.NewOrderManager(broker, nil, nil, nil),
+		nil, []string{"AAPL"}, time.Hour, 24*time.Hour, false)
+	eng.brokerPaused = true
+
+	placed, err := eng.executeSignal(context.Background(), models.Signal{
+		Type: models.SignalBuy, Symbol: "AAPL", Quantity: 1, StrategyName: "MockStrategy",
+	})
+	require.NoError(t, err)
+	assert.False(t, placed)
+	broker.AssertNotCalled(t, "PlaceOrder", mock.Anything)
+}
+
+// TestTradingEngine_StaleDataGuard verifies stale candles skip execution,
+// notify once per outage, and recover when fresh data arrives.
+func TestTradingEngine_StaleDataGuard(t *testing.T) {
+	mockProvider := new(MockProvider)
+	mockBroker := new(MockBroker)
+	mockStrategy := new(MockStrategy)
+	registry := strategies.NewRegistry()
+	registry.Register(mockStrategy)
+	eng := NewTradingEngine(mockProvider, registry, execution.NewOrderManager(mockBroker, nil, nil, nil),
+		nil, []string{"BTC-USD"}, time.Hour, 24*time.Hour, false)
+	notifier := &recordingNotifier{}
+	eng.SetNotifier(notifier)
+	eng.SetStaleDataGuard(StaleDataGuard{CryptoIntervals: 2, EquityIntervals: 5, Notify: true})
+
+	stale := []models.OHLCV{{Timestamp: time.Now().Add(-4 * 24 * time.Hour), Close: 100}}
+	fresh := []models.OHLCV{{Timestamp: time.Now().Add(-24 * time.Hour), Close: 100}}
+	mockProvider.On("GetHistoricalData", "BTC-USD", mock.Anything, mock.Anything, "1d").Return(stale, nil).Twice()
+	mockProvider.On("GetHistoricalData", "BTC-USD", mock.Anything, mock.Anything, "1d").Return(fresh, nil)
+	mockStrategy.On("OnData", mock.Anything).Return(models.Signal{
+		Type: models.SignalBuy, Symbol: "BTC-USD", Quantity: 1, StrategyName: "MockStrategy",
+	})
+	mockBroker.On("PlaceOrder", mock.Anything).Return(&models.Order{ID: "order-1", Status: models.OrderStatusSubmitted}, nil)
+	ctx := context.Background()
+
+	eng.tick(ctx)
+	eng.tick(ctx)
+	mockStrategy.AssertNotCalled(t, "OnData", mock.Anything)
+	mockBroker.AssertNotCalled(t, "PlaceOrder", mock.Anything)
+	assert.Contains(t, eng.Status().SymbolErrors["BTC-USD"], "stale market data")
+	require.Len(t, notifier.sent, 1, "notified once per outage")
+	assert.Equal(t, models.NotificationWarning, notifier.types[0])
+
+	eng.tick(ctx)
+	mockBroker.AssertNumberOfCalls(t, "PlaceOrder", 1)
+	assert.Empty(t, eng.Status().SymbolErrors)
+}
+
+// TestTradingEngine_StaleDataEquityWeekend verifies weekend hours don't make
+// equity data stale.
+func TestTradingEngine_StaleDataEquityWeekend(t *testing.T) {
+	eng := NewTradingEngine(new(MockProvider), strategies.NewRegistry(), nil, nil,
+		[]string{"AAPL"}, time.Hour, 24*time.Hour, false)
+	eng.SetStaleDataGuard(StaleDataGuard{CryptoIntervals: 1, EquityIntervals: 1})
+	ctx := context.Background()
+
+	friday := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	monday := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	latest := models.OHLCV{Timestamp: friday}
+
+	// Closed Saturday 00:00; only Monday's 12 hours count
+	assert.NoError(t, eng.checkStale(ctx, "AAPL", "1d", latest, monday))
+	assert.ErrorIs(t, eng.checkStale(ctx, "BTC-USD", "1d", latest, monday), ErrStaleData)
+	assert.ErrorIs(t, eng.checkStale(ctx, "AAPL", "1d", latest, monday.Add(24*time.Hour)), ErrStaleData)
+	assert.NoError(t, eng.checkStale(ctx, "AAPL", "unknown", latest, monday.Add(240*time.Hour)),
+		"unknown timeframes are never stale")
+}
+
+// TestTradingEngine_StaleDataCalendarLocation verifies the weekend is read in
+// the calendar's timezone rather than UTC.
+func TestTradingEngine_StaleDataCalendarLocation(t *testing.T) {
+	calendar, err := NewUSEquityCalendar()
+	require.NoError(t, err)
+	eng := NewTradingEngine(new(MockProvider), strategies.NewRegistry(), nil, nil,
+		[]string{"AAPL"}, time.Hour, 24*time.Hour, false)
+	eng.SetStaleDataGuard(StaleDataGuard{EquityIntervals: 1})
+	ctx := context.Background()
+
+	// Friday 19:00 ET closes at 20:00 ET, already Saturday 00:00 UTC
+	latest := models.OHLCV{Timestamp: time.Date(2026, 10, 16, 23, 0, 0, 0, time.UTC)}
+	saturday := time.Date(2026, 10, 17, 12, 0, 0, 0, calendar.Location())
+
+	assert.NoError(t, eng.checkStale(ctx, "AAPL", "1h", latest, saturday), "UTC sees only weekend hours")
+	eng.SetTradingCalendar(calendar, false)
+	assert.ErrorIs(t, eng.checkStale(ctx, "AAPL", "1h", latest, saturday), ErrStaleData,
+		"Friday evening in New York counts")
+}
+
+// TestTradingEngine_Heartbeat verifies each tick broadcasts a heartbeat with
+// its symbol and signal counts when enabled, and nothing when disabled.
+func TestTradingEngine_Heartbeat(t *testing.T) {
+	mockProvider := new(MockProvider)
+	mockBroker := new(MockBroker)
+	mockStrategy := new(MockStrategy)
+	registry := strategies.NewRegistry()
+	registry.Register(mockStrategy)
+	wsManager := realtime.NewWebSocketManager()
+	go wsManager.Run()
+	messages, unsubscribe := wsManager.Subscribe(10)
+	defer unsubscribe()
+
+	eng := NewTradingEngine(mockProvider, registry, execution.NewOrderManager(mockBroker, nil, nil, nil),
+		wsManager, []string{"BTC-USD", "FAIL"}, time.Hour, 24*time.Hour, false)
+	eng.SetHeartbeat(true)
+
+	bars := []models.OHLCV{{Timestamp: time.Now().Add(-24 * time.Hour), Close: 100}}
+	mockProvider.On("GetHistoricalData", "BTC-USD", mock.Anything, mock.Anything, "1d").Return(bars, nil)
+	mockProvider.On("GetHistoricalData", "FAIL", mock.Anything, mock.Anything, "1d").Return(nil, fmt.Errorf("no data"))
+	mockStrategy.On("OnData", mock.Anything).Return(models.Signal{
+		Type: models.SignalBuy, Symbol: "BTC-USD", Quantity: 1, StrategyName: "MockStrategy",
+	})
+	mockBroker.On("PlaceOrder", mock.Anything).Return(&models.Order{ID: "order-1", Status: models.OrderStatusSubmitted}, nil)
+
+	eng.tick(context.Background())
+
+	var beat Heartbeat
+	require.Eventually(t, func() bool {
+		for {
+			select {
+			case msg := <-messages:
+				if msg.Type == "heartbeat" {
+					beat = msg.Payload.(Heartbeat)
+					return true
+				}
+			default:
+				return false
+			}
+		}
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, beat.Tick)
+	assert.Equal(t, 1, beat.SymbolsProcessed)
+	assert.Equal(t, 1, beat.SymbolErrors)
+	assert.Equal(t, 1, beat.SignalsGenerated)
+	assert.False(t, beat.Timestamp.IsZero())
+
+	eng.SetHeartbeat(false)
+	eng.tick(context.Background())
+	time.Sleep(50 * time.Millisecond)
+	for len(messages) > 0 {
+		assert.NotEqual(t, "heartbeat", (<-messages).Type)
+	}
+}
+
+// cancelingProvider serves history until it reaches cancelAt, then stops the
+// engine mid-tick by canceling its context.
+type cancelingProvider struct {
+	MockProvider
+	cancelAt string
+	cancel   context.CancelFunc
+}
+
+func (p *cancelingProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	if symbol == p.cancelAt {
+		p.cancel()
+		return nil, context.Canceled
+	}
+	return []models.OHLCV{{Timestamp: time.Now(), Close: 100}}, nil
+}
+
+// TestTradingEngine_HeartbeatCountsFinishedSymbols verifies a tick stopped
+// midway only counts the symbols that actually finished, not those cut short
+// or never started.
+func TestTradingEngine_HeartbeatCountsFinishedSymbols(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	provider := &cancelingProvider{cancelAt: "ETH-USD", cancel: cancel}
+	wsManager := realtime.NewWebSocketManager()
+	go wsManager.Run()
+	messages, unsubscribe := wsManager.Subscribe(10)
+	defer unsubscribe()
+
+	eng := NewTradingEngine(provider, strategies.NewRegistry(), nil, wsManager,
+		[]string{"BTC-USD", "ETH-USD", "SOL-USD", "ADA-USD"}, time.Hour, 24*time.Hour, false)
+	eng.SetHeartbeat(true)
+	eng.SetConcurrency(1)
+
+	eng.tick(ctx)
+
+	var beat Heartbeat
+	require.Eventually(t, func() bool {
+		for {
+			select {
+			case msg := <-messages:
+				if msg.Type == "heartbeat" {
+					beat = msg.Payload.(Heartbeat)
+					return true
+				}
+			default:
+				return false
+			}
+		}
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, beat.SymbolsProcessed, "only BTC-USD finished before the stop")
+	assert.Equal(t, 0, beat.SymbolErrors)
+}
+
+// countingAdvancer counts the ticks it is stepped.
+type countingAdvancer struct {
+	ticks int
+}
+
+func (a *countingAdvancer) AdvanceTick() {
+	a.ticks++
+}
+
+// TestTradingEngine_TickAdvancer verifies the tick advancer is stepped once
+// at the start of every tick.
+func TestTradingEngine_TickAdvancer(t *testing.T) {
+	advancer := &countingAdvancer{}
+	eng := NewTradingEngine(&cancelingProvider{}, strategies.NewRegistry(), nil, nil,
+		[]string{"BTC-USD"}, time.Hour, 24*time.Hour, false)
+	eng.SetTickAdvancer(advancer)
+
+	eng.tick(context.Background())
+	assert.Equal(t, 1, advancer.ticks)
+	eng.tick(context.Background())
+	assert.Equal(t, 2, advancer.ticks)
+}
+
+// outageProvider fails every request while down, counting history fetches.
+type outageProvider struct {
+	MockProvider
+	mu      sync.Mutex
+	down    bool
+	fetches int
+}
+
+func (p *outageProvider) setDown(down bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.down = down
+}
+
+func (p *outageProvider) GetLatestPrice(symbol string) (float64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.down {
+		return 0, fmt.Errorf("provider unavailable")
+	}
+	return 100, nil
+}
+
+func (p *outageProvider) GetHistoricalData(symbol string, start, end time.Time, interval string) ([]models.OHLCV, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.fetches++
+	if p.down {
+		return nil, fmt.Errorf("provider unavailable")
+	}
+	return []models.OHLCV{{Timestamp: time.Now().Add(-24 * time.Hour), Close: 100}}, nil
+}
+
+// TestTradingEngine_FetchFailureLimit verifies the engine pauses after
+// consecutive ticks where every fetch failed, probes instead of fetching
+// while paused, and resumes on a successful probe or manually.
+func TestTradingEngine_FetchFailureLimit(t *testing.T) {
+	provider := &outageProvider{}
+	mockStrategy := new(MockStrategy)
+	mockStrategy.On("OnData", mock.Anything).Return(models.Signal{Type: models.SignalHold})
+	registry := strategies.NewRegistry()
+	registry.Register(mockStrategy)
+	notifier := &recordingNotifier{}
+
+	eng := NewTradingEngine(provider, registry, execution.NewOrderManager(new(MockBroker), nil, nil, nil),
+		nil, []string{"BTC-USD", "ETH-USD"}, time.Hour, 24*time.Hour, false)
+	eng.SetFetchFailureLimit(3)
+	eng.SetNotifier(notifier)
+	ctx := context.Background()
+
+	// A tick with one good fetch resets the streak
+	provider.setDown(true)
+	eng.tick(ctx)
+	eng.tick(ctx)
+	assert.Equal(t, 2, eng.Status().FailedFetchTicks)
+	provider.setDown(false)
+	eng.tick(ctx)
+	assert.Zero(t, eng.Status().FailedFetchTicks)
+
+	provider.setDown(true)
+	for i := 0; i < 3; i++ {
+		eng.tick(ctx)
+	}
+	assert.True(t, eng.FetchPaused())
+	assert.True(t, eng.Status().FetchPaused)
+	require.Len(t, notifier.types, 1)
+	assert.Equal(t, models.NotificationError, notifier.types[0])
+	assert.Contains(t, notifier.sent[0], "3 consecutive ticks")
+
+	// Paused ticks only probe
+	fetches := provider.fetches
+	eng.tick(ctx)
+	eng.tick(ctx)
+	assert.Equal(t, fetches, provider.fetches, "paused ticks must not fetch history")
+	assert.True(t, eng.FetchPaused())
+
+	// A successful probe resumes within the same tick
+	provider.setDown(false)
+	eng.tick(ctx)
+	assert.False(t, eng.FetchPaused())
+	assert.Equal(t, fetches+2, provider.fetches)
+	require.Len(t, notifier.types, 2)
+	assert.Equal(t, models.NotificationSuccess, notifier.types[1])
+
+	// Manual resume
+	provider.setDown(true)
+	for i := 0; i < 3; i++ {
+		eng.tick(ctx)
+	}
+	require.True(t, eng.FetchPaused())
+	assert.True(t, eng.ResumeFetching())
+	assert.False(t, eng.FetchPaused())
+	assert.Zero(t, eng.Status().FailedFetchTicks)
+	assert.False(t, eng.ResumeFetching())
+}
+
+// TestTradingEngine_FetchFailureLimitDisabled verifies a zero limit never pauses.
+func TestTradingEngine_FetchFailureLimitDisabled(t *testing.T) {
+	provider := &outageProvider{down: true}
+	eng := NewTradingEngine(provider, strategies.NewRegistry(), execution.NewOrderManager(new(MockBroker), nil, nil, nil),
+		nil, []string{"BTC-USD"}, time.Hour, 24*time.Hour, false)
+
+	for i := 0; i < 5; i++ {
+		eng.tick(context.Background())
+	}
+	assert.False(t, eng.FetchPaused())
+	assert.Equal(t, 5, eng.Status().FailedFetchTicks)
+	assert.Equal(t, 5, provider.fetches)
+}
+
+// TestTradingEngine_AutoExit verifies positions are closed at their
+// take-profit or stop-loss level, with per-symbol overrides, and tagged.
+func TestTradingEngine_AutoExit(t *testing.T) {
+	broker := execution.NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	orderManager := execution.NewOrderManager(broker, nil, nil, nil)
+	eng := NewTradingEngine(new(MockProvider), strategies.NewRegistry(), orderManager, nil,
+		[]string{}, time.Hour, 24*time.Hour, false)
+	eng.SetAutoExit(AutoExit{
+		Default: AutoExitLevels{TakeProfitPct: 10, StopLossPct: 5},
+		Symbols: map[string]AutoExitLevels{"BTC-USD": {StopLossPct: 20}},
+	})
+	ctx := context.Background()
+
+	for symbol, price := range map[string]float64{"AAPL": 100, "MSFT": 100, "BTC-USD": 100} {
+		broker.SetPrice(symbol, price)
+		_, err := orderManager.CreateMarketOrder(ctx, symbol, models.OrderSideBuy, 2)
+		require.NoError(t, err)
+	}
+
+	// Within the levels nothing is closed
+	broker.SetPrice("AAPL", 109)
+	broker.SetPrice("MSFT", 96)
+	eng.checkAutoExits(ctx)
+	positions, err := orderManager.GetPositions()
+	require.NoError(t, err)
+	assert.Len(t, positions, 3)
+
+	// AAPL reaches take-profit, MSFT its stop-loss; BTC-USD has no take-profit
+	// and its own stop-loss of 20%
+	broker.SetPrice("AAPL", 110)
+	broker.SetPrice("MSFT", 95)
+	broker.SetPrice("BTC-USD", 150)
+	eng.checkAutoExits(ctx)
+
+	positions, err = orderManager.GetPositions()
+	require.NoError(t, err)
+	require.Len(t, positions, 1)
+	assert.Equal(t, "BTC-USD", positions[0].Symbol)
+
+	orders, err := orderManager.GetAllOrders()
+	require.NoError(t, err)
+	exits := map[string]models.Tags{}
+	for _, order := range orders {
+		if order.Tags.Has(models.TagAutoExit) {
+			assert.Equal(t, models.OrderSideSell, order.Side)
+			assert.Equal(t, 2.0, order.Quantity)
+			exits[order.Symbol] = order.Tags
+		}
+	}
+	assert.Equal(t, map[string]models.Tags{
+		"AAPL": {models.TagAutoExit, AutoExitTakeProfit},
+		"MSFT": {models.TagAutoExit, AutoExitStopLoss},
+	}, exits)
+
+	broker.SetPrice("BTC-USD", 80)
+	eng.checkAutoExits(ctx)
+	positions, err = orderManager.GetPositions()
+	require.NoError(t, err)
+	assert.Empty(t, positions)
+}
+
+// TestParseAutoExitOverrides verifies the per-symbol override format.
+func TestParseAutoExitOverrides(t *testing.T) {
+	overrides, err := ParseAutoExitOverrides(" btc-usd:15:8, SPY:0:3 ,", nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]AutoExitLevels{
+		"BTC-USD": {TakeProfitPct: 15, StopLossPct: 8},
+		"SPY":     {StopLossPct: 3},
+	}, overrides)
+
+	empty, err := ParseAutoExitOverrides("", nil)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	for _, bad := range []string{"SPY:5", ":5:5", "SPY:x:5", "SPY:5:-1"} {
+		_, err := ParseAutoExitOverrides(bad, nil)
+		assert.Error(t, err, bad)
+	}
+
+	// Aliases key the override by the symbol they name
+	aliases, err := data.ParseSymbolAliases("BITCOIN:BTC-USD", "")
+	require.NoError(t, err)
+	overrides, err = ParseAutoExitOverrides("bitcoin:15:8", aliases)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]AutoExitLevels{"BTC-USD": {TakeProfitPct: 15, StopLossPct: 8}}, overrides)
+}
+
+// TestTradingEngine_RecoversFromSymbolPanic verifies a panicking strategy
+// fails only its symbol, notifies once, and leaves the engine ticking.
+func TestTradingEngine_RecoversFromSymbolPanic(t *testing.T) {
+	mockProvider := new(MockProvider)
+	mockBroker := new(MockBroker)
+	mockStrategy := new(MockStrategy)
+	registry := strategies.NewRegistry()
+	registry.Register(mockStrategy)
+	eng := NewTradingEngine(mockProvider, registry, execution.NewOrderManager(mockBroker, nil, nil, nil),
+		nil, []string{"AAPL", "MSFT"}, 10*time.Millisecond, 24*time.Hour, false)
+	notifier := &recordingNotifier{}
+	eng.SetNotifier(notifier)
+
+	for _, symbol := range []string{"AAPL", "MSFT"} {
+		mockProvider.On("GetHistoricalData", symbol, mock.Anything, mock.Anything, "1d").
+			Return([]models.OHLCV{{Symbol: symbol, Close: 100}}, nil)
+	}
+	isSymbol := func(symbol string) interface{} {
+		return mock.MatchedBy(func(candles []models.OHLCV) bool { return candles[0].Symbol == symbol })
+	}
+	mockStrategy.On("OnData", isSymbol("AAPL")).Run(func(mock.Arguments) { panic("division by zero") })
+	mockStrategy.On("OnData", isSymbol("MSFT")).Return(models.Signal{Type: models.SignalHold, Symbol: "MSFT"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, eng.Start(ctx))
+	require.Eventually(t, func() bool { return eng.Status().TickCount >= 3 }, time.Second, 5*time.Millisecond)
+	assert.True(t, eng.IsRunning())
+	eng.Stop()
+
+	status := eng.Status()
+	assert.Contains(t, status.SymbolErrors["AAPL"], "symbol processing panicked: division by zero")
+	assert.NotContains(t, status.SymbolErrors, "MSFT")
+	mockStrategy.AssertCalled(t, "OnData", isSymbol("MSFT"))
+
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	require.Len(t, notifier.sent, 1, "notified once per run of panics")
+	assert.Equal(t, models.NotificationError, notifier.types[0])
+	assert.Contains(t, notifier.sent[0], "AAPL")
+}
+
+// TestParseSymbolIntervals verifies the per-symbol interval format.
+func TestParseSymbolIntervals(t *testing.T) {
+	intervals, err := ParseSymbolIntervals(" btc/usd:1h, ETH-USD:4h ,", nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"BTC-USD": "1h", "ETH-USD": "4h"}, intervals)
+
+	empty, err := ParseSymbolIntervals("", nil)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	for _, bad := range []string{"BTC-USD", ":1h", "BTC-USD:", "BTC-USD:7m", "BTC-USD:1h,btc-usd:4h"} {
+		_, err := ParseSymbolIntervals(bad, nil)
+		assert.Error(t, err, bad)
+	}
+
+	// An alias and the symbol it names are the same entry
+	aliases, err := data.ParseSymbolAliases("BITCOIN:BTC-USD", "")
+	require.NoError(t, err)
+	intervals, err = ParseSymbolIntervals("bitcoin:1h", aliases)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"BTC-USD": "1h"}, intervals)
+	_, err = ParseSymbolIntervals("bitcoin:1h,BTC-USD:4h", aliases)
+	assert.Error(t, err)
+}
+
+// TestTradingEngine_SymbolIntervals verifies overridden symbols are fetched at
+// their interval and resampled to the strategy's timeframe, while other
+// symbols keep the strategy timeframe.
+func TestTradingEngine_SymbolIntervals(t *testing.T) {
+	mockProvider := new(MockProvider)
+	mockStrategy := new(MockStrategy)
+	registry := strategies.NewRegistry()
+	registry.Register(mockStrategy)
+	eng := NewTradingEngine(mockProvider, registry, execution.NewOrderManager(new(MockBroker), nil, nil, nil),
+		nil, []string{"BTC-USD", "AAPL"}, time.Hour, 72*time.Hour, false)
+
+	// 1d strategies can't be fed weekly candles
+	require.Error(t, eng.SetSymbolIntervals(map[string]string{"BTC-USD": "1w"}))
+	require.Error(t, eng.SetSymbolIntervals(map[string]string{"BTC-USD": "7m"}))
+	require.NoError(t, eng.SetSymbolIntervals(map[string]string{"btc-usd": "1h"}))
+
+	day := time.Now().UTC().Truncate(24 * time.Hour).Add(-24 * time.Hour)
+	var hourly []models.OHLCV
+	for i := range 30 {
+		hourly = append(hourly, models.OHLCV{Symbol: "BTC-USD", Timestamp: day.Add(time.Duration(i) * time.Hour), Open: float64(100 + i), Close: float64(100 + i)})
+	}
+	mockProvider.On("GetHistoricalData", "BTC-USD", mock.Anything, mock.Anything, "1h").Return(hourly, nil)
+	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").
+		Return([]models.OHLCV{{Symbol: "AAPL", Timestamp: day, Close: 150}}, nil)
+
+	var received [][]models.OHLCV
+	mockStrategy.On("OnData", mock.Anything).Run(func(args mock.Arguments) {
+		received = append(received, args.Get(0).([]models.OHLCV))
+	}).Return(models.Signal{Type: models.SignalHold})
+
+	require.NoError(t, eng.processSymbol(context.Background(), "BTC-USD"))
+	require.NoError(t, eng.processSymbol(context.Background(), "AAPL"))
+	mockProvider.AssertExpectations(t)
+
+	require.Len(t, received, 2)
+	require.Len(t, received[0], 2)
+	assert.Equal(t, day, received[0][0].Timestamp)
+	assert.Equal(t, 100.0, received[0][0].Open)
+	assert.Equal(t, 123.0, received[0][0].Close)
+	assert.Equal(t, 129.0, received[0][1].Close)
+	assert.Equal(t, []models.OHLCV{{Symbol: "AAPL", Timestamp: day, Close: 150}}, received[1])
+}
+
+// TestTradingEngine_WebhookSignals verifies queued external signals are
+// executed on the symbol's next tick with their tags on the order.
+func TestTradingEngine_WebhookSignals(t *testing.T) {
+	mockProvider := new(MockProvider)
+	mockBroker := new(MockBroker)
+	webhook := strategies.NewWebhookSignalStrategy()
+	require.NoError(t, webhook.Init(map[string]interface{}{}))
+	registry := strategies.NewRegistry()
+	require.NoError(t, registry.Register(webhook))
+
+	eng := NewTradingEngine(mockProvider, registry, execution.NewOrderManager(mockBroker, nil, nil, nil),
+		nil, []string{"AAPL"}, time.Hour, 24*time.Hour, false)
+
+	mockProvider.On("GetHistoricalData", "AAPL", mock.Anything, mock.Anything, "1d").
+		Return([]models.OHLCV{{Symbol: "AAPL", Close: 150.0}}, nil)
+	mockBroker.On("PlaceOrder", mock.MatchedBy(func(o models.Order) bool {
+		return o.Side == models.OrderSideBuy && o.Tags.Has(models.TagExternalSignal) && o.Tags.Has("tv-alert")
+	})).Return(&models.Order{ID: "order-1", Status: models.OrderStatusSubmitted}, nil)
+
+	ctx := context.Background()
+	eng.tick(ctx)
+	mockBroker.AssertNotCalled(t, "PlaceOrder", mock.Anything)
+
+	_, err := webhook.Enqueue(models.Signal{
+		Symbol:   "AAPL",
+		Type:     models.SignalBuy,
+		Quantity: 1,
+		Tags:     models.Tags{models.TagExternalSignal, "tv-alert"},
+	})
+	require.NoError(t, err)
+
+	eng.tick(ctx)
+	mockBroker.AssertNumberOfCalls(t, "PlaceOrder", 1)
+	assert.Empty(t, webhook.Pending())
+
+	eng.tick(ctx)
+	mockBroker.AssertNumberOfCalls(t, "PlaceOrder", 1)
+}
+
+// TestTradingEngine_StagedOrderNotPlaced verifies an order staged for
+// confirmation doesn't count as placed or start a cooldown.
+func TestTradingEngine_StagedOrderNotPlaced(t *testing.T) {
+	broker := execution.NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+	orderManager := execution.NewOrderManager(broker, nil, nil, nil)
+	orderManager.SetOrderConfirmation(execution.OrderConfirmation{Threshold: 1000, Window: time.Minute})
+
+	mockStrategy := new(MockStrategy)
+	require.NoError(t, mockStrategy.BaseStrategy.Init(map[string]interface{}{"cooldown_ticks": 5}))
+	registry := strategies.NewRegistry()
+	require.NoError(t, registry.Register(mockStrategy))
+	eng := NewTradingEngine(new(MockProvider), registry, orderManager, nil, []string{"AAPL"}, time.Hour, 24*time.Hour, false)
+
+	signal := models.Signal{Type: models.SignalBuy, Symbol: "AAPL", Quantity: 50, StrategyName: "MockStrategy"}
+	placed, err := eng.executeSignal(context.Background(), signal)
+	require.NoError(t, err)
+	assert.False(t, placed)
+	assert.Zero(t, eng.Status().OrdersPlaced)
+	assert.Empty(t, eng.Status().Cooldowns)
+	assert.Len(t, orderManager.StagedOrders(), 1)
+}
@@ -3,7 +3,7 @@ package execution
 import "context"
 
 // contextKey is a private type for context keys to avoid collisions.
-// These keys must match the ones used by the API audit middleware.
+// The API audit middleware sets them through WithAuditContext.
 type contextKey string
 
 const (
@@ -31,6 +31,22 @@ func auditKeyIDFromCtx(ctx context.Context) string {
 	return "unknown"
 }
 
+// WithAuditContext returns a context carrying the requestor's IP and API
+// key identifier, which order logs and the order audit trail record. The API
+// audit middleware uses it so requests are attributed to their key.
+//
+// Args:
+//   - ctx: Parent context
+//   - ip: Requestor IP address
+//   - keyID: API key identifier (a fingerprint, never the key itself)
+//
+// Returns:
+//   - context.Context: Context with the audit fields
+func WithAuditContext(ctx context.Context, ip, keyID string) context.Context {
+	ctx = context.WithValue(ctx, auditIPKey, ip)
+	return context.WithValue(ctx, auditKeyIDKey, keyID)
+}
+
 // NewEngineContext creates a context with audit fields and a trace ID
 // for engine-initiated operations, distinguishing automated orders
 // from manual API orders.
@@ -0,0 +1,71 @@
+// Package execution provides persistence of pending bracket exits.
+package execution
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/rs/zerolog/log"
+)
+
+// PendingBracketsKey is the system config key holding the exit legs of
+// bracket entries that have not filled yet, so a restart does not leave a
+// filled entry without its exits.
+const PendingBracketsKey = "pending_brackets"
+
+// pendingBracket is the stored form of bracketLegs.
+type pendingBracket struct {
+	TakeProfit models.Order `json:"take_profit"`
+	StopLoss   models.Order `json:"stop_loss"`
+}
+
+// saveBrackets persists the pending bracket exits. Callers must hold om.mu,
+// so concurrent changes are written in order.
+func (om *OrderManager) saveBrackets() {
+	if om.store == nil {
+		return
+	}
+
+	pending := make(map[string]pendingBracket, len(om.brackets))
+	for entryID, legs := range om.brackets {
+		pending[entryID] = pendingBracket{TakeProfit: legs.takeProfit, StopLoss: legs.stopLoss}
+	}
+	encoded, err := json.Marshal(pending)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encode pending bracket exits")
+		return
+	}
+	if err := om.store.SetSystemConfig(PendingBracketsKey, string(encoded)); err != nil {
+		log.Error().Err(err).Msg("Failed to persist pending bracket exits")
+	}
+}
+
+// loadBrackets reads the persisted pending bracket exits. A missing or
+// unreadable value is treated as none pending.
+func loadBrackets(store OrderStore) map[string]bracketLegs {
+	brackets := make(map[string]bracketLegs)
+	if store == nil {
+		return brackets
+	}
+
+	value, err := store.GetSystemConfig(PendingBracketsKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return brackets
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load pending bracket exits")
+		return brackets
+	}
+
+	var pending map[string]pendingBracket
+	if err := json.Unmarshal([]byte(value), &pending); err != nil {
+		log.Error().Err(err).Msg("Invalid pending bracket exits")
+		return brackets
+	}
+	for entryID, legs := range pending {
+		brackets[entryID] = bracketLegs{takeProfit: legs.TakeProfit, stopLoss: legs.StopLoss}
+	}
+	return brackets
+}
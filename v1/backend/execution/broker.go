@@ -2,9 +2,35 @@
 package execution
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/alexherrero/sherwood/backend/models"
 )
 
+var (
+	// ErrBrokerUnavailable marks submissions that failed before reaching the
+	// broker, such as while it is disconnected. The order was not placed.
+	ErrBrokerUnavailable = errors.New("broker unavailable")
+	// ErrBrokerRateLimited marks submissions the broker refused because of
+	// its rate limit. The order was not placed.
+	ErrBrokerRateLimited = errors.New("broker rate limit exceeded")
+)
+
+// BrokerStatusError is returned by HTTP-based brokers when a submission gets
+// an error status back, so callers can tell server failures from rejections.
+type BrokerStatusError struct {
+	// StatusCode is the HTTP status the broker responded with.
+	StatusCode int
+	// Message is the broker's error message.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *BrokerStatusError) Error() string {
+	return fmt.Sprintf("broker returned %d: %s", e.StatusCode, e.Message)
+}
+
 // Broker defines the interface for executing trades.
 // Implementations connect to real brokers (Robinhood, Alpaca) or paper trading.
 type Broker interface {
@@ -86,15 +112,72 @@ type Broker interface {
 	//   - error: Any error encountered
 	GetTrades() ([]models.Trade, error)
 
-	// ModifyOrder updates an existing open order.
+	// ModifyOrder updates an existing open order. Implementations should
+	// enforce the rules in ApplyOrderModification.
 	//
 	// Args:
 	//   - orderID: ID of the order to modify
 	//   - newPrice: New limit price (0 to keep current)
-	//   - newQuantity: New quantity (0 to keep current)
+	//   - newQuantity: New total quantity (0 to keep current)
 	//
 	// Returns:
 	//   - *models.Order: The modified order
 	//   - error: Any error encountered
 	ModifyOrder(orderID string, newPrice, newQuantity float64) (*models.Order, error)
 }
+
+// OrderUpdateHandler is called when a broker changes an order's state outside
+// of a direct request, e.g. a resting limit order filling on a price update.
+type OrderUpdateHandler func(order models.Order)
+
+// OrderUpdateNotifier is implemented by brokers that can report asynchronous
+// order updates. The OrderManager registers itself so its in-memory order map
+// stays in sync with fills and one-cancels-other cancellations.
+type OrderUpdateNotifier interface {
+	// SetOrderUpdateHandler registers the callback for asynchronous order updates.
+	//
+	// Args:
+	//   - handler: Function called with the updated order
+	SetOrderUpdateHandler(handler OrderUpdateHandler)
+}
+
+// OrderLister is implemented by brokers that can list all known orders.
+// Reconciliation uses it to discover orders missing from the local cache.
+type OrderLister interface {
+	// ListOrders retrieves all orders known to the broker.
+	//
+	// Returns:
+	//   - []models.Order: The broker's orders
+	//   - error: Any error encountered
+	ListOrders() ([]models.Order, error)
+}
+
+// OrderTradeLister is implemented by brokers that can look up one order's
+// trades directly. The OrderManager uses it to record fills without listing
+// every trade the broker knows.
+type OrderTradeLister interface {
+	// GetOrderTrades retrieves the trades executed for an order.
+	//
+	// Args:
+	//   - orderID: ID of the order
+	//
+	// Returns:
+	//   - []models.Trade: The order's trades (empty if it has not filled)
+	//   - error: Any error encountered
+	GetOrderTrades(orderID string) ([]models.Trade, error)
+}
+
+// PriceQuoter is implemented by brokers that know the latest price of a
+// symbol. The OrderManager uses it to value market orders against the
+// minimum order notional.
+type PriceQuoter interface {
+	// LatestPrice returns the last known price for a symbol.
+	//
+	// Args:
+	//   - symbol: Ticker symbol
+	//
+	// Returns:
+	//   - float64: The latest price
+	//   - bool: False if no price is known
+	LatestPrice(symbol string) (float64, bool)
+}
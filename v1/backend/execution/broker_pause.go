@@ -0,0 +1,45 @@
+// Package execution provides pausing order submission while the broker is
+// disconnected.
+package execution
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// ErrBrokerPaused marks an order rejected because the broker connection was
+// lost and has not yet been re-established and reconciled.
+var ErrBrokerPaused = errors.New("order submission paused while the broker is disconnected")
+
+// SetBrokerPaused pauses or resumes order submission. The engine's
+// connection monitor pauses it when the broker drops and resumes it once
+// the broker has reconnected and orders are reconciled.
+//
+// Args:
+//   - paused: True to reject new orders with ErrBrokerPaused
+func (om *OrderManager) SetBrokerPaused(paused bool) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	om.brokerPaused = paused
+}
+
+// BrokerPaused reports whether order submission is paused because the
+// broker is disconnected.
+//
+// Returns:
+//   - bool: True while new orders are rejected
+func (om *OrderManager) BrokerPaused() bool {
+	om.mu.RLock()
+	defer om.mu.RUnlock()
+	return om.brokerPaused
+}
+
+// checkBrokerPaused rejects orders while submission is paused.
+func (om *OrderManager) checkBrokerPaused(order models.Order) error {
+	if !om.BrokerPaused() {
+		return nil
+	}
+	return fmt.Errorf("%w: %s %s rejected", ErrBrokerPaused, order.Side, order.Symbol)
+}
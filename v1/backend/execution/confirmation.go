@@ -0,0 +1,249 @@
+// Package execution provides two-phase confirmation for large orders.
+package execution
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrConfirmationNotFound marks a confirmation token that is unknown, was
+// already used, or has expired.
+var ErrConfirmationNotFound = errors.New("staged order not found or expired")
+
+// orderConfirmedKey marks contexts submitting an order that was confirmed.
+const orderConfirmedKey contextKey = "order_confirmed"
+
+// OrderConfirmation stages orders worth more than Threshold instead of
+// sending them to the broker. A staged order is submitted by ConfirmOrder
+// within Window and discarded after it. The zero value disables staging.
+type OrderConfirmation struct {
+	// Threshold is the order value (quantity × price) above which orders
+	// are staged.
+	Threshold float64
+	// Window is how long a staged order waits for confirmation.
+	Window time.Duration
+}
+
+// Enabled reports whether orders are staged.
+func (c OrderConfirmation) Enabled() bool {
+	return c.Threshold > 0 && c.Window > 0
+}
+
+// stagedOrder is an order awaiting confirmation.
+type stagedOrder struct {
+	order   models.Order // As submitted, so confirming re-runs every check
+	pending models.Order // As returned to the caller, with token and expiry
+	bracket *bracketLegs // Exit legs when the order is a bracket entry
+	expires time.Time
+}
+
+// SetOrderConfirmation configures staging of large orders. Staging is
+// meant for live trading; it is disabled by default.
+//
+// Args:
+//   - confirmation: Value threshold and confirmation window
+func (om *OrderManager) SetOrderConfirmation(confirmation OrderConfirmation) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	om.confirmation = confirmation
+}
+
+// ConfirmOrder submits a staged order. The kill-switch, risk and sizing
+// checks run again, since the account may have changed while it waited.
+// The context carries audit information (user IP, API key ID) for logging.
+//
+// Args:
+//   - ctx: Context with audit information
+//   - token: The staged order's confirmation token
+//
+// Returns:
+//   - *models.Order: The submitted order
+//   - error: ErrConfirmationNotFound, or any error from SubmitOrder
+func (om *OrderManager) ConfirmOrder(ctx context.Context, token string) (*models.Order, error) {
+	om.mu.Lock()
+	om.pruneStaged(time.Now())
+	staged, ok := om.staged[token]
+	delete(om.staged, token)
+	om.mu.Unlock()
+	if !ok {
+		return nil, ErrConfirmationNotFound
+	}
+
+	order := staged.order
+	order.CreatedAt = time.Now()
+	order.UpdatedAt = order.CreatedAt
+	result, err := om.SubmitOrder(context.WithValue(ctx, orderConfirmedKey, true), order)
+	if err != nil {
+		return nil, err
+	}
+	if staged.bracket != nil {
+		result = om.attachBracket(ctx, result, *staged.bracket)
+	}
+	return result, nil
+}
+
+// needsConfirmation reports whether an order must be staged. Orders whose
+// value can't be determined are staged as well. Bracket exits, shutdown
+// closes and orders being confirmed are never staged.
+func (om *OrderManager) needsConfirmation(ctx context.Context, order models.Order) bool {
+	om.mu.RLock()
+	confirmation := om.confirmation
+	om.mu.RUnlock()
+	if !confirmation.Enabled() || order.ParentID != "" || killSwitchBypassed(ctx) {
+		return false
+	}
+	if confirmed, _ := ctx.Value(orderConfirmedKey).(bool); confirmed {
+		return false
+	}
+
+	price := om.orderPrice(ctx, order, true)
+	return price <= 0 || order.Quantity*price > confirmation.Threshold
+}
+
+// StagedOrders lists the orders awaiting confirmation, soonest to expire
+// first. Staged orders are held in memory, so a restart discards them.
+//
+// Returns:
+//   - []models.Order: Orders in pending_confirmation, with their tokens
+func (om *OrderManager) StagedOrders() []models.Order {
+	om.mu.Lock()
+	om.pruneStaged(time.Now())
+	orders := make([]models.Order, 0, len(om.staged))
+	for _, staged := range om.staged {
+		orders = append(orders, staged.pending)
+	}
+	om.mu.Unlock()
+
+	sort.Slice(orders, func(i, j int) bool { return orders[i].ConfirmBy.Before(orders[j].ConfirmBy) })
+	return orders
+}
+
+// stageOrder holds an order until it is confirmed, and notifies and
+// broadcasts its token so it doesn't expire unseen. An engine order that
+// matches one already waiting reuses it, so a strategy repeating its signal
+// each tick doesn't stage duplicates.
+//
+// Args:
+//   - ctx: Context with audit information
+//   - submitted: The order as submitted
+//   - normalized: The order after sizing, which is what the caller sees
+//
+// Returns:
+//   - *models.Order: The staged order, with its token and expiry
+//   - error: If no token could be generated
+func (om *OrderManager) stageOrder(ctx context.Context, submitted, normalized models.Order) (*models.Order, error) {
+	token, err := newConfirmationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	om.mu.Lock()
+	om.pruneStaged(now)
+	if auditIPFromCtx(ctx) == "engine" {
+		for _, staged := range om.staged {
+			if sameStagedOrder(staged.order, submitted) {
+				om.mu.Unlock()
+				result := staged.pending
+				return &result, nil
+			}
+		}
+	}
+	expires := now.Add(om.confirmation.Window)
+
+	result := normalized
+	result.Status = models.OrderStatusPendingConfirmation
+	result.ConfirmationToken = token
+	result.ConfirmBy = expires
+	if normalized.Quantity != submitted.Quantity {
+		result.RequestedQuantity = submitted.Quantity
+	}
+	om.staged[token] = stagedOrder{order: submitted, pending: result, expires: expires}
+	notifier := om.notifier
+	om.mu.Unlock()
+
+	if om.wsManager != nil {
+		om.wsManager.Broadcast("order_staged", result)
+	}
+	if notifier != nil {
+		message := fmt.Sprintf("%s %g %s is awaiting confirmation until %s",
+			result.Side, result.Quantity, result.Symbol, expires.UTC().Format(time.RFC3339))
+		if result.StrategyName != "" {
+			message += fmt.Sprintf(" (%s)", result.StrategyName)
+		}
+		if _, err := notifier.Send(models.NotificationWarning, "Order awaiting confirmation", message, map[string]interface{}{
+			"symbol":                       result.Symbol,
+			"side":                         string(result.Side),
+			"quantity":                     result.Quantity,
+			"strategy":                     result.StrategyName,
+			"confirmation_token":           token,
+			"confirm_by":                   expires,
+			models.NotificationCriticalKey: true,
+		}); err != nil {
+			log.Error().Err(err).Msg("Failed to send order confirmation notification")
+		}
+	}
+
+	logger := tracing.Logger(ctx)
+	logger.Warn().
+		Str("symbol", result.Symbol).
+		Str("side", string(result.Side)).
+		Float64("quantity", result.Quantity).
+		Str("strategy", result.StrategyName).
+		Time("confirm_by", expires).
+		Str("user_ip", auditIPFromCtx(ctx)).
+		Str("api_key_id", auditKeyIDFromCtx(ctx)).
+		Msg("Order staged, awaiting confirmation")
+
+	return &result, nil
+}
+
+// stageBracket attaches exit legs to a staged entry order, so they are
+// registered when it is confirmed.
+func (om *OrderManager) stageBracket(token string, legs bracketLegs) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	if staged, ok := om.staged[token]; ok {
+		staged.bracket = &legs
+		om.staged[token] = staged
+	}
+}
+
+// sameStagedOrder reports whether two submitted orders are the same trade.
+func sameStagedOrder(a, b models.Order) bool {
+	return a.Symbol == b.Symbol && a.Side == b.Side && a.Type == b.Type &&
+		a.Quantity == b.Quantity && a.Price == b.Price &&
+		a.StrategyName == b.StrategyName
+}
+
+// pruneStaged discards expired staged orders. Callers must hold om.mu.
+func (om *OrderManager) pruneStaged(now time.Time) {
+	for token, staged := range om.staged {
+		if now.After(staged.expires) {
+			delete(om.staged, token)
+			log.Info().
+				Str("symbol", staged.order.Symbol).
+				Str("side", string(staged.order.Side)).
+				Float64("quantity", staged.order.Quantity).
+				Msg("Staged order expired without confirmation")
+		}
+	}
+}
+
+// newConfirmationToken returns a random, unguessable token.
+func newConfirmationToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
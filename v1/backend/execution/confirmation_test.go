@@ -0,0 +1,172 @@
+package execution
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newConfirmingManager returns an order manager that stages orders worth
+// more than 5000.
+func newConfirmingManager(t *testing.T) (*OrderManager, *PaperBroker) {
+	t.Helper()
+	broker := NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+	om := NewOrderManager(broker, nil, nil, nil)
+	om.SetOrderConfirmation(OrderConfirmation{Threshold: 5000, Window: time.Minute})
+	return om, broker
+}
+
+// TestOrderManager_OrderConfirmation verifies large orders are staged until
+// confirmed, and small ones are placed directly.
+func TestOrderManager_OrderConfirmation(t *testing.T) {
+	om, broker := newConfirmingManager(t)
+	ctx := context.Background()
+
+	small, err := om.CreateMarketOrder(ctx, "AAPL", models.OrderSideBuy, 10)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusFilled, small.Status)
+	assert.Empty(t, small.ConfirmationToken)
+
+	staged, err := om.CreateLimitOrder(ctx, "AAPL", models.OrderSideBuy, 60, 100.0)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusPendingConfirmation, staged.Status)
+	assert.Empty(t, staged.ID)
+	require.NotEmpty(t, staged.ConfirmationToken)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), staged.ConfirmBy, 5*time.Second)
+
+	// Nothing reaches the broker until it is confirmed
+	position, err := broker.GetPosition("AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, position.Quantity)
+
+	placed, err := om.ConfirmOrder(ctx, staged.ConfirmationToken)
+	require.NoError(t, err)
+	assert.NotEmpty(t, placed.ID)
+	assert.Equal(t, models.OrderStatusFilled, placed.Status)
+	assert.Empty(t, placed.ConfirmationToken)
+
+	// A token works once
+	_, err = om.ConfirmOrder(ctx, staged.ConfirmationToken)
+	assert.ErrorIs(t, err, ErrConfirmationNotFound)
+	_, err = om.ConfirmOrder(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrConfirmationNotFound)
+}
+
+// TestOrderManager_OrderConfirmation_Expiry verifies a staged order can't be
+// confirmed after its window.
+func TestOrderManager_OrderConfirmation_Expiry(t *testing.T) {
+	om, _ := newConfirmingManager(t)
+	ctx := context.Background()
+
+	staged, err := om.CreateMarketOrder(ctx, "AAPL", models.OrderSideBuy, 60)
+	require.NoError(t, err)
+	require.Equal(t, models.OrderStatusPendingConfirmation, staged.Status)
+
+	om.mu.Lock()
+	entry := om.staged[staged.ConfirmationToken]
+	entry.expires = time.Now().Add(-time.Second)
+	om.staged[staged.ConfirmationToken] = entry
+	om.mu.Unlock()
+
+	_, err = om.ConfirmOrder(ctx, staged.ConfirmationToken)
+	assert.ErrorIs(t, err, ErrConfirmationNotFound)
+	assert.Empty(t, om.staged)
+}
+
+// TestOrderManager_OrderConfirmation_Rechecks verifies confirming runs the
+// submission checks again.
+func TestOrderManager_OrderConfirmation_Rechecks(t *testing.T) {
+	om, _ := newConfirmingManager(t)
+	ctx := context.Background()
+
+	staged, err := om.CreateMarketOrder(ctx, "AAPL", models.OrderSideBuy, 60)
+	require.NoError(t, err)
+
+	require.NoError(t, om.SetTradingEnabled(false))
+	_, err = om.ConfirmOrder(ctx, staged.ConfirmationToken)
+	assert.ErrorIs(t, err, ErrTradingDisabled)
+}
+
+// TestOrderManager_OrderConfirmation_Exempt verifies shutdown closes are
+// placed directly and unpriced market orders are staged.
+func TestOrderManager_OrderConfirmation_Exempt(t *testing.T) {
+	om, _ := newConfirmingManager(t)
+	ctx := context.Background()
+
+	bought, err := om.CreateMarketOrder(ctx, "AAPL", models.OrderSideBuy, 40)
+	require.NoError(t, err)
+	require.Equal(t, models.OrderStatusFilled, bought.Status)
+
+	require.NoError(t, om.SetTradingEnabled(false))
+	closed, err := om.CreateMarketOrder(WithKillSwitchBypass(ctx), "AAPL", models.OrderSideSell, 40)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusFilled, closed.Status)
+	require.NoError(t, om.SetTradingEnabled(true))
+
+	// No quote means the order value is unknown, so it waits for a human
+	unpriced, err := om.CreateMarketOrder(ctx, "MSFT", models.OrderSideBuy, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusPendingConfirmation, unpriced.Status)
+}
+
+// TestOrderManager_OrderConfirmation_Bracket verifies a staged bracket entry
+// gets its exits once confirmed.
+func TestOrderManager_OrderConfirmation_Bracket(t *testing.T) {
+	om, _ := newConfirmingManager(t)
+	ctx := context.Background()
+
+	staged, err := om.CreateBracketOrder(ctx, "AAPL", models.OrderSideBuy, 60, 0, 110.0, 90.0)
+	require.NoError(t, err)
+	require.Equal(t, models.OrderStatusPendingConfirmation, staged.Status)
+
+	entry, err := om.ConfirmOrder(ctx, staged.ConfirmationToken)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusFilled, entry.Status)
+
+	// The exits are worth more than the threshold too, but are never staged
+	exits, total, err := om.GetOrders(OrderFilter{ParentID: entry.ID})
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	for _, exit := range exits {
+		assert.NotEmpty(t, exit.ID)
+		assert.NotEqual(t, models.OrderStatusPendingConfirmation, exit.Status)
+	}
+}
+
+// TestOrderManager_OrderConfirmation_Notifies verifies staging sends a
+// critical notification with the token and lists the order, and a repeated
+// engine order reuses the staged one.
+func TestOrderManager_OrderConfirmation_Notifies(t *testing.T) {
+	om, _ := newConfirmingManager(t)
+	notifier := &recordingNotifier{}
+	om.SetNotifier(notifier)
+	order := models.Order{Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeMarket, Quantity: 60, StrategyName: "ma_crossover"}
+
+	first, err := om.SubmitOrder(NewEngineContext(), order)
+	require.NoError(t, err)
+	require.Equal(t, models.OrderStatusPendingConfirmation, first.Status)
+	assert.Equal(t, []string{"Order awaiting confirmation"}, notifier.titles)
+	assert.Equal(t, []bool{true}, notifier.critical)
+	assert.Contains(t, notifier.messages[0], "buy 60 AAPL")
+
+	again, err := om.SubmitOrder(NewEngineContext(), order)
+	require.NoError(t, err)
+	assert.Equal(t, first.ConfirmationToken, again.ConfirmationToken)
+	assert.Len(t, notifier.titles, 1)
+
+	// Manual orders are staged separately
+	manual, err := om.SubmitOrder(context.Background(), order)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ConfirmationToken, manual.ConfirmationToken)
+
+	staged := om.StagedOrders()
+	require.Len(t, staged, 2)
+	assert.Equal(t, first.ConfirmationToken, staged[0].ConfirmationToken)
+	assert.Equal(t, "ma_crossover", staged[0].StrategyName)
+}
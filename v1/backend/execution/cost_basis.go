@@ -0,0 +1,80 @@
+// Package execution provides average-cost replay of a trade history.
+package execution
+
+import (
+	"sort"
+
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// holding is a quantity held at an average cost.
+type holding struct {
+	quantity    float64
+	averageCost float64
+}
+
+// replayCostBasis replays trades in execution order against holdings kept at
+// average cost, each under the key tradeKey gives it. Imported positions
+// replace the holding under importKey as of their import time. Sells beyond
+// the quantity held close only what was held.
+//
+// Args:
+//   - trades: Fills in any order
+//   - imports: Imported positions (nil for none)
+//   - tradeKey: Holding a trade buys into or sells from
+//   - importKey: Holding an imported position replaces
+//   - visit: Called for each trade with the quantity it closed and the
+//     average cost it closed at (both 0 for buys); an error stops the replay
+//
+// Returns:
+//   - map[K]holding: Holdings after the last trade
+//   - error: The first error returned by visit
+func replayCostBasis[K comparable](
+	trades []models.Trade,
+	imports []models.Position,
+	tradeKey func(models.Trade) K,
+	importKey func(models.Position) K,
+	visit func(trade models.Trade, closed, averageCost float64) error,
+) (map[K]holding, error) {
+	sorted := append([]models.Trade{}, trades...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ExecutedAt.Before(sorted[j].ExecutedAt)
+	})
+	seeds := append([]models.Position{}, imports...)
+	sort.SliceStable(seeds, func(i, j int) bool {
+		return seeds[i].UpdatedAt.Before(seeds[j].UpdatedAt)
+	})
+
+	holdings := make(map[K]holding)
+	for _, trade := range sorted {
+		for len(seeds) > 0 && !seeds[0].UpdatedAt.After(trade.ExecutedAt) {
+			holdings[importKey(seeds[0])] = holding{seeds[0].Quantity, seeds[0].AverageCost}
+			seeds = seeds[1:]
+		}
+
+		key := tradeKey(trade)
+		h := holdings[key]
+		var closed, closedCost float64
+		switch trade.Side {
+		case models.OrderSideBuy:
+			total := h.quantity + trade.Quantity
+			if total > 0 {
+				h.averageCost = (h.averageCost*h.quantity + trade.Price*trade.Quantity) / total
+			}
+			h.quantity = total
+		case models.OrderSideSell:
+			closed = min(trade.Quantity, h.quantity)
+			closedCost = h.averageCost
+			h.quantity -= closed
+		}
+		holdings[key] = h
+
+		if err := visit(trade, closed, closedCost); err != nil {
+			return nil, err
+		}
+	}
+	for _, seed := range seeds {
+		holdings[importKey(seed)] = holding{seed.Quantity, seed.AverageCost}
+	}
+	return holdings, nil
+}
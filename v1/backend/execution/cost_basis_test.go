@@ -0,0 +1,46 @@
+package execution
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReplayCostBasis verifies holdings are averaged per key, imports replace
+// a holding as of their time, and visit sees what each sell closed.
+func TestReplayCostBasis(t *testing.T) {
+	now := time.Now()
+	trades := []models.Trade{
+		{Symbol: "AAPL", Side: models.OrderSideSell, Quantity: 30, Price: 150, ExecutedAt: now.Add(3 * time.Hour)},
+		{Symbol: "AAPL", Side: models.OrderSideBuy, Quantity: 10, Price: 100, ExecutedAt: now},
+		{Symbol: "AAPL", Side: models.OrderSideBuy, Quantity: 10, Price: 120, ExecutedAt: now.Add(time.Hour)},
+	}
+	imports := []models.Position{
+		{Symbol: "AAPL", Quantity: 25, AverageCost: 90, UpdatedAt: now.Add(2 * time.Hour)},
+		{Symbol: "MSFT", Quantity: 5, AverageCost: 300, UpdatedAt: now.Add(4 * time.Hour)},
+	}
+	bySymbol := func(t models.Trade) string { return t.Symbol }
+	importSymbol := func(p models.Position) string { return p.Symbol }
+
+	var closed, closedCost float64
+	holdings, err := replayCostBasis(trades, imports, bySymbol, importSymbol, func(trade models.Trade, q, cost float64) error {
+		if trade.Side == models.OrderSideSell {
+			closed, closedCost = q, cost
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 25.0, closed, "only the imported 25 are held")
+	assert.Equal(t, 90.0, closedCost)
+	assert.Equal(t, holding{0, 90}, holdings["AAPL"])
+	assert.Equal(t, holding{5, 300}, holdings["MSFT"], "imports after the last trade still seed a holding")
+
+	_, err = replayCostBasis(trades, nil, bySymbol, importSymbol, func(models.Trade, float64, float64) error {
+		return errors.New("stop")
+	})
+	assert.EqualError(t, err, "stop")
+}
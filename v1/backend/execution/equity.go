@@ -0,0 +1,61 @@
+// Package execution provides account equity snapshots.
+package execution
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// RecordEquitySnapshot reads the broker balance and persists it as an equity
+// snapshot.
+//
+// Args:
+//   - ctx: Context for cancellation
+//
+// Returns:
+//   - *models.EquitySnapshot: The recorded snapshot
+//   - error: If the balance cannot be read or the store does not support snapshots
+func (om *OrderManager) RecordEquitySnapshot(ctx context.Context) (*models.EquitySnapshot, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if om.store == nil {
+		return nil, fmt.Errorf("order store does not support equity snapshots")
+	}
+
+	balance, err := om.broker.GetBalance()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	snapshot := models.EquitySnapshot{
+		Timestamp:      time.Now(),
+		Cash:           balance.Cash,
+		Equity:         balance.Equity,
+		PortfolioValue: balance.PortfolioValue,
+	}
+	if err := om.store.SaveEquitySnapshot(snapshot); err != nil {
+		return nil, fmt.Errorf("failed to save equity snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// GetEquitySnapshots returns the recorded equity history since a time.
+// Without a store the history is empty.
+//
+// Args:
+//   - since: Earliest snapshot time to include
+//
+// Returns:
+//   - []models.EquitySnapshot: Snapshots, oldest first
+//   - error: Any error encountered
+func (om *OrderManager) GetEquitySnapshots(since time.Time) ([]models.EquitySnapshot, error) {
+	if om.store == nil {
+		return nil, nil
+	}
+	return om.store.GetEquitySnapshots(since)
+}
@@ -0,0 +1,45 @@
+package execution
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOrderManager_RecordEquitySnapshot verifies the broker balance is persisted.
+func TestOrderManager_RecordEquitySnapshot(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	broker := NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	om := NewOrderManager(broker, nil, data.NewOrderStore(db), nil)
+
+	snapshot, err := om.RecordEquitySnapshot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 10000.0, snapshot.Cash)
+	assert.Equal(t, 10000.0, snapshot.Equity)
+
+	history, err := om.GetEquitySnapshots(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, 10000.0, history[0].PortfolioValue)
+}
+
+// TestOrderManager_RecordEquitySnapshot_NoStore verifies snapshots need a store.
+func TestOrderManager_RecordEquitySnapshot_NoStore(t *testing.T) {
+	om := NewOrderManager(NewPaperBroker(10000), nil, nil, nil)
+
+	_, err := om.RecordEquitySnapshot(context.Background())
+	assert.ErrorContains(t, err, "does not support equity snapshots")
+
+	history, err := om.GetEquitySnapshots(time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
@@ -0,0 +1,199 @@
+// Package execution provides trading fees and fee-adjusted realized P&L.
+package execution
+
+import (
+	"fmt"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/rs/zerolog/log"
+)
+
+// FeeSchedule is the commission charged on each paper fill. The zero value
+// charges nothing.
+type FeeSchedule struct {
+	// PerOrder is a flat fee charged on every fill.
+	PerOrder float64
+	// Bps is charged on the fill's notional value, in basis points.
+	Bps float64
+	// Minimum is the smallest fee charged on a fill.
+	Minimum float64
+}
+
+// IsZero reports whether the schedule charges nothing.
+func (f FeeSchedule) IsZero() bool {
+	return f.PerOrder <= 0 && f.Bps <= 0 && f.Minimum <= 0
+}
+
+// Fee returns the commission for a fill.
+//
+// Args:
+//   - notional: Fill price times quantity
+//
+// Returns:
+//   - float64: Fee in the fill's quote currency
+func (f FeeSchedule) Fee(notional float64) float64 {
+	if f.IsZero() {
+		return 0
+	}
+	return max(f.PerOrder+notional*f.Bps/10000, f.Minimum)
+}
+
+// TradeCosts summarizes realized performance across filled trades.
+type TradeCosts struct {
+	// RealizedPL is the gross profit/loss of closed quantity, measured
+	// against each position's average cost.
+	RealizedPL float64
+	// TotalFees is the sum of commissions paid.
+	TotalFees float64
+	// FeeTrades is the number of trades that paid a commission.
+	FeeTrades int
+}
+
+// tradeCosts computes realized P&L and fees from a trade history, converting
+// each symbol's amounts to the base currency. Imported positions replace the
+// holding for their symbol as of their import time, so later sells are
+// measured against the imported average cost. Sells beyond the quantity
+// held close only what was held.
+func tradeCosts(trades []models.Trade, imports []models.Position, base string, rates data.FXRateSource) (TradeCosts, error) {
+	var costs TradeCosts
+	bySymbol := func(t models.Trade) string { return t.Symbol }
+	importSymbol := func(p models.Position) string { return p.Symbol }
+	_, err := replayCostBasis(trades, imports, bySymbol, importSymbol, func(trade models.Trade, closed, averageCost float64) error {
+		rate, err := rates.Rate(data.QuoteCurrency(trade.Symbol), base)
+		if err != nil {
+			return fmt.Errorf("failed to value %s: %w", trade.Symbol, err)
+		}
+		if trade.Commission > 0 {
+			costs.TotalFees += trade.Commission * rate
+			costs.FeeTrades++
+		}
+		costs.RealizedPL += (trade.Price - averageCost) * closed * rate
+		return nil
+	})
+	if err != nil {
+		return TradeCosts{}, err
+	}
+	return costs, nil
+}
+
+// recordRealizedPnL feeds the realized P&L of an order's fills, net of
+// commissions, into the risk manager's daily loss circuit breaker. Call it
+// once the order's trades are recorded. Partial fills count as they arrive:
+// only the change since the order's last update is added.
+func (om *OrderManager) recordRealizedPnL(order models.Order) {
+	if om.riskManager == nil {
+		return
+	}
+	if order.Status != models.OrderStatusFilled && order.Status != models.OrderStatusPartiallyFilled {
+		om.mu.Lock()
+		delete(om.pnlCounted, order.ID)
+		om.mu.Unlock()
+		return
+	}
+
+	pnl, err := om.orderRealizedPnL(order.ID)
+	if err != nil {
+		log.Error().Err(err).Str("order_id", order.ID).Msg("Failed to compute realized P&L for order")
+		return
+	}
+	om.mu.Lock()
+	change := pnl - om.pnlCounted[order.ID]
+	if order.Status == models.OrderStatusPartiallyFilled {
+		om.pnlCounted[order.ID] = pnl
+	} else {
+		delete(om.pnlCounted, order.ID)
+	}
+	om.mu.Unlock()
+
+	if change != 0 {
+		om.riskManager.UpdateDailyPnL(change)
+	}
+}
+
+// orderRealizedPnL returns the realized P&L of one order's fills, less their
+// commissions, in the base currency.
+func (om *OrderManager) orderRealizedPnL(orderID string) (float64, error) {
+	om.mu.RLock()
+	base := om.baseCurrency
+	rates := om.fxRates
+	imports := append([]models.Position{}, om.imports...)
+	om.mu.RUnlock()
+
+	trades, err := om.tradeHistory()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get trades: %w", err)
+	}
+	pnl := 0.0
+	bySymbol := func(t models.Trade) string { return t.Symbol }
+	importSymbol := func(p models.Position) string { return p.Symbol }
+	_, err = replayCostBasis(trades, imports, bySymbol, importSymbol, func(trade models.Trade, closed, averageCost float64) error {
+		if trade.OrderID != orderID {
+			return nil
+		}
+		rate, err := rates.Rate(data.QuoteCurrency(trade.Symbol), base)
+		if err != nil {
+			return fmt.Errorf("failed to value %s: %w", trade.Symbol, err)
+		}
+		pnl += ((trade.Price-averageCost)*closed - trade.Commission) * rate
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return pnl, nil
+}
+
+// cachedTradeCosts is a TradeCosts result and the trade history version it
+// was computed from.
+type cachedTradeCosts struct {
+	version uint64
+	costs   TradeCosts
+}
+
+// tradesChanged invalidates the cached trade costs. It is called whenever
+// fills are recorded, positions are imported or the base currency changes.
+// The caller must hold om.mu.
+func (om *OrderManager) tradesChanged() {
+	om.tradesVersion++
+}
+
+// realizedCosts returns the trade costs for the current trade history,
+// replaying it only when fills or imports have changed since the last call.
+func (om *OrderManager) realizedCosts() (TradeCosts, error) {
+	om.mu.RLock()
+	version := om.tradesVersion
+	cached := om.costCache
+	base := om.baseCurrency
+	rates := om.fxRates
+	imports := append([]models.Position{}, om.imports...)
+	om.mu.RUnlock()
+	if cached != nil && cached.version == version {
+		return cached.costs, nil
+	}
+
+	trades, err := om.tradeHistory()
+	if err != nil {
+		return TradeCosts{}, fmt.Errorf("failed to get trades: %w", err)
+	}
+	costs, err := tradeCosts(trades, imports, base, rates)
+	if err != nil {
+		return TradeCosts{}, err
+	}
+
+	// A fill recorded meanwhile bumped the version, so this result is
+	// stored as stale and recomputed on the next call
+	om.mu.Lock()
+	om.costCache = &cachedTradeCosts{version: version, costs: costs}
+	om.mu.Unlock()
+	return costs, nil
+}
+
+// tradeHistory returns every fill, from the trade store when persistence is
+// configured and otherwise from the broker.
+func (om *OrderManager) tradeHistory() ([]models.Trade, error) {
+	if om.store != nil {
+		return om.store.GetAllTrades()
+	}
+	return om.broker.GetTrades()
+}
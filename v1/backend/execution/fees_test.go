@@ -0,0 +1,96 @@
+package execution
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFeeSchedule_Fee verifies flat, percentage and minimum fees combine.
+func TestFeeSchedule_Fee(t *testing.T) {
+	assert.Equal(t, 0.0, FeeSchedule{}.Fee(10000))
+	assert.InDelta(t, 1.0, FeeSchedule{PerOrder: 1}.Fee(10000), 1e-9)
+	assert.InDelta(t, 6.0, FeeSchedule{PerOrder: 1, Bps: 5}.Fee(10000), 1e-9)
+	assert.InDelta(t, 2.0, FeeSchedule{Bps: 5, Minimum: 2}.Fee(1000), 1e-9, "minimum applies to small fills")
+}
+
+// TestTradeCosts verifies realized P&L uses average cost and fees are summed.
+func TestTradeCosts(t *testing.T) {
+	now := time.Now()
+	trades := []models.Trade{
+		// Out of order, to check trades are replayed by execution time
+		{Symbol: "AAPL", Side: models.OrderSideSell, Quantity: 10, Price: 130, Commission: 1, ExecutedAt: now.Add(2 * time.Hour)},
+		{Symbol: "AAPL", Side: models.OrderSideBuy, Quantity: 10, Price: 100, Commission: 1, ExecutedAt: now},
+		{Symbol: "AAPL", Side: models.OrderSideBuy, Quantity: 10, Price: 120, Commission: 1, ExecutedAt: now.Add(time.Hour)},
+		{Symbol: "BTC-EUR", Side: models.OrderSideBuy, Quantity: 1, Price: 100, ExecutedAt: now},
+		{Symbol: "BTC-EUR", Side: models.OrderSideSell, Quantity: 2, Price: 110, ExecutedAt: now.Add(time.Hour)},
+	}
+	rates := data.NewStaticFXRates()
+	rates.SetRate("EUR", "USD", 2)
+
+	costs, err := tradeCosts(trades, nil, "USD", rates)
+	require.NoError(t, err)
+	// AAPL: 10 sold at 130 against an average cost of 110; BTC-EUR: only the
+	// 1 held closes, for 10 EUR
+	assert.InDelta(t, 200.0+20.0, costs.RealizedPL, 1e-9)
+	assert.InDelta(t, 3.0, costs.TotalFees, 1e-9)
+	assert.Equal(t, 3, costs.FeeTrades)
+
+	_, err = tradeCosts(trades, nil, "USD", data.NewStaticFXRates())
+	assert.ErrorContains(t, err, "no exchange rate from EUR to USD")
+
+	// An import before the sell replaces the AAPL holding's cost basis
+	imports := []models.Position{{Symbol: "AAPL", Quantity: 20, AverageCost: 100, UpdatedAt: now.Add(90 * time.Minute)}}
+	costs, err = tradeCosts(trades, imports, "USD", rates)
+	require.NoError(t, err)
+	assert.InDelta(t, 300.0+20.0, costs.RealizedPL, 1e-9)
+}
+
+// TestOrderManager_PortfolioSummary_Fees verifies paper fees are charged to
+// cash and reported in the portfolio summary.
+func TestOrderManager_PortfolioSummary_Fees(t *testing.T) {
+	broker := NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	broker.SetFeeSchedule(FeeSchedule{PerOrder: 1, Bps: 10})
+	broker.SetPrice("AAPL", 100.0)
+
+	om := NewOrderManager(broker, nil, nil, nil)
+	ctx := context.Background()
+	_, err := om.CreateMarketOrder(ctx, "AAPL", models.OrderSideBuy, 10)
+	require.NoError(t, err)
+	broker.SetPrice("AAPL", 110.0)
+	_, err = om.CreateMarketOrder(ctx, "AAPL", models.OrderSideSell, 10)
+	require.NoError(t, err)
+
+	// Buy fee 1 + 1000*10bps = 2, sell fee 1 + 1100*10bps = 2.1
+	summary, err := om.PortfolioSummary()
+	require.NoError(t, err)
+	assert.InDelta(t, 10000.0+100.0-4.1, summary.Balance.Cash, 1e-9)
+	assert.InDelta(t, 100.0, summary.RealizedPL, 1e-9)
+	assert.InDelta(t, 4.1, summary.TotalFees, 1e-9)
+	assert.Equal(t, 2, summary.FeeTrades)
+	assert.InDelta(t, 95.9, summary.NetRealizedPL, 1e-9)
+
+	trades, err := om.GetTrades()
+	require.NoError(t, err)
+	for _, trade := range trades {
+		assert.Greater(t, trade.Commission, 0.0)
+	}
+
+	// The summary is cached until another fill is recorded
+	version := om.tradesVersion
+	_, err = om.PortfolioSummary()
+	require.NoError(t, err)
+	assert.Equal(t, version, om.costCache.version)
+	_, err = om.CreateMarketOrder(ctx, "AAPL", models.OrderSideBuy, 1)
+	require.NoError(t, err)
+	assert.Greater(t, om.tradesVersion, om.costCache.version)
+	summary, err = om.PortfolioSummary()
+	require.NoError(t, err)
+	assert.Equal(t, 3, summary.FeeTrades)
+}
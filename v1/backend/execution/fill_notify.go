@@ -0,0 +1,52 @@
+// Package execution provides notifications of order fills.
+package execution
+
+import (
+	"fmt"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/rs/zerolog/log"
+)
+
+// SetNotifier sets where order fill notifications are sent.
+//
+// Args:
+//   - notifier: notification sink (nil disables fill notifications)
+func (om *OrderManager) SetNotifier(notifier Notifier) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	om.notifier = notifier
+}
+
+// notifyFill sends a trade notification for a filled order.
+func (om *OrderManager) notifyFill(order models.Order) {
+	om.mu.RLock()
+	notifier := om.notifier
+	om.mu.RUnlock()
+	if notifier == nil {
+		return
+	}
+
+	quantity := order.FilledQuantity
+	if quantity == 0 {
+		quantity = order.Quantity
+	}
+	verb := "Bought"
+	if order.Side == models.OrderSideSell {
+		verb = "Sold"
+	}
+	message := fmt.Sprintf("%s %g %s at %.2f", verb, quantity, order.Symbol, order.AveragePrice)
+	if order.StrategyName != "" {
+		message += fmt.Sprintf(" (%s)", order.StrategyName)
+	}
+	if _, err := notifier.Send(models.NotificationTrade, "Order filled", message, map[string]interface{}{
+		"order_id": order.ID,
+		"symbol":   order.Symbol,
+		"side":     string(order.Side),
+		"quantity": quantity,
+		"price":    order.AveragePrice,
+		"strategy": order.StrategyName,
+	}); err != nil {
+		log.Error().Err(err).Str("order_id", order.ID).Msg("Failed to send fill notification")
+	}
+}
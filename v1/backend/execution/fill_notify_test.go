@@ -0,0 +1,44 @@
+package execution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOrderManager_FillNotifications verifies immediate and later fills are
+// each notified once, and unfilled orders are not.
+func TestOrderManager_FillNotifications(t *testing.T) {
+	broker := NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 50.0)
+	om := NewOrderManager(broker, nil, nil, nil)
+	notifier := &recordingNotifier{}
+	om.SetNotifier(notifier)
+	ctx := context.Background()
+
+	_, err := om.SubmitOrder(ctx, models.Order{
+		Symbol:       "AAPL",
+		Side:         models.OrderSideBuy,
+		Type:         models.OrderTypeMarket,
+		Quantity:     10,
+		StrategyName: "ma_crossover",
+	})
+	require.NoError(t, err)
+	resting, err := om.CreateLimitOrder(ctx, "AAPL", models.OrderSideSell, 4, 55.0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bought 10 AAPL at 50.00 (ma_crossover)"}, notifier.messages)
+
+	broker.SetPrice("AAPL", 56.0)
+	filled, err := om.GetOrder(resting.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.OrderStatusFilled, filled.Status)
+
+	// Repeated updates for a filled order are not notified again
+	om.handleOrderUpdate(*filled)
+	assert.Equal(t, []string{"Bought 10 AAPL at 50.00 (ma_crossover)", "Sold 4 AAPL at 55.00"}, notifier.messages)
+	assert.Equal(t, []models.NotificationType{models.NotificationTrade, models.NotificationTrade}, notifier.types)
+}
@@ -0,0 +1,106 @@
+// Package execution provides the persisted initial capital baseline used for
+// performance calculations.
+package execution
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+)
+
+// InitialCapitalKey is the system config key holding the initial capital.
+const InitialCapitalKey = "initial_capital"
+
+// DefaultInitialCapital is the paper account's starting cash when none is
+// configured.
+const DefaultInitialCapital = 100000.0
+
+// StoredInitialCapital reads the persisted initial capital.
+//
+// Args:
+//   - store: Persistence layer; nil reads as unset
+//
+// Returns:
+//   - float64: The stored capital, or 0 if none has been stored
+//   - error: If the value could not be read or parsed
+func StoredInitialCapital(store OrderStore) (float64, error) {
+	if store == nil {
+		return 0, nil
+	}
+
+	valStr, err := store.GetSystemConfig(InitialCapitalKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	val, err := strconv.ParseFloat(valStr, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid initial capital value '%s': %w", valStr, err)
+	}
+	return val, nil
+}
+
+// GetInitialCapital retrieves the initial capital from configuration. It
+// returns 0 if none has been stored.
+func (om *OrderManager) GetInitialCapital() (float64, error) {
+	return StoredInitialCapital(om.store)
+}
+
+// SetInitialCapital stores the initial capital in configuration.
+func (om *OrderManager) SetInitialCapital(amount float64) error {
+	if om.store == nil {
+		return fmt.Errorf("no persistence configured")
+	}
+
+	valStr := strconv.FormatFloat(amount, 'f', 2, 64)
+	return om.store.SetSystemConfig(InitialCapitalKey, valStr)
+}
+
+// EnsureInitialCapital returns the persisted initial capital, storing one on
+// first run so restarts keep the same performance baseline. Live accounts
+// are seeded from the broker's current equity, paper accounts from the
+// configured amount.
+//
+// Args:
+//   - configured: Configured starting capital, used for paper accounts
+//   - live: True to seed from the broker balance instead
+//
+// Returns:
+//   - float64: The effective initial capital
+//   - error: If the value could not be read, fetched or stored
+func (om *OrderManager) EnsureInitialCapital(configured float64, live bool) (float64, error) {
+	stored, err := om.GetInitialCapital()
+	if err != nil {
+		return 0, err
+	}
+	if stored > 0 {
+		return stored, nil
+	}
+
+	capital := configured
+	if live {
+		balance, err := om.broker.GetBalance()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get balance: %w", err)
+		}
+		capital = balance.Equity
+	}
+	if capital <= 0 {
+		return 0, fmt.Errorf("initial capital must be positive, got %g", capital)
+	}
+	if om.store == nil {
+		return capital, nil
+	}
+
+	if err := om.SetInitialCapital(capital); err != nil {
+		return 0, err
+	}
+	log.Info().Float64("initial_capital", capital).Bool("live", live).Msg("Initial capital recorded")
+	return capital, nil
+}
@@ -0,0 +1,72 @@
+package execution
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOrderManager_EnsureInitialCapital verifies the baseline is seeded once
+// and survives a restart with a different configured amount.
+func TestOrderManager_EnsureInitialCapital(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	store := data.NewOrderStore(db)
+
+	stored, err := StoredInitialCapital(store)
+	require.NoError(t, err)
+	assert.Zero(t, stored)
+
+	om := NewOrderManager(NewPaperBroker(25000), nil, store, nil)
+	capital, err := om.EnsureInitialCapital(25000, false)
+	require.NoError(t, err)
+	assert.Equal(t, 25000.0, capital)
+
+	// Restart with a different configured amount keeps the stored baseline
+	om = NewOrderManager(NewPaperBroker(50000), nil, store, nil)
+	capital, err = om.EnsureInitialCapital(50000, false)
+	require.NoError(t, err)
+	assert.Equal(t, 25000.0, capital)
+
+	stored, err = StoredInitialCapital(store)
+	require.NoError(t, err)
+	assert.Equal(t, 25000.0, stored)
+}
+
+// TestOrderManager_EnsureInitialCapital_Live verifies live accounts are
+// seeded from the broker's equity rather than the configured amount.
+func TestOrderManager_EnsureInitialCapital_Live(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	store := data.NewOrderStore(db)
+
+	broker := NewPaperBroker(12345)
+	require.NoError(t, broker.Connect())
+
+	om := NewOrderManager(liveBroker{broker}, nil, store, nil)
+	capital, err := om.EnsureInitialCapital(DefaultInitialCapital, true)
+	require.NoError(t, err)
+	assert.Equal(t, 12345.0, capital)
+
+	summary, err := om.PortfolioSummary()
+	require.NoError(t, err)
+	assert.Equal(t, 12345.0, summary.InitialCapital)
+}
+
+// TestOrderManager_EnsureInitialCapital_NoStore verifies the configured
+// amount is returned without persistence and invalid amounts are refused.
+func TestOrderManager_EnsureInitialCapital_NoStore(t *testing.T) {
+	om := NewOrderManager(NewPaperBroker(10000), nil, nil, nil)
+
+	capital, err := om.EnsureInitialCapital(10000, false)
+	require.NoError(t, err)
+	assert.Equal(t, 10000.0, capital)
+
+	_, err = om.EnsureInitialCapital(0, false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,134 @@
+// Package execution provides the operator kill-switch for order submission.
+package execution
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/rs/zerolog/log"
+)
+
+// TradingEnabledKey is the system config key holding the kill-switch state.
+const TradingEnabledKey = "trading_enabled"
+
+// ErrTradingDisabled marks orders rejected because the kill-switch is set.
+var ErrTradingDisabled = errors.New("trading disabled by kill-switch")
+
+// killSwitchBypassKey marks contexts whose closing sells skip the kill-switch.
+const killSwitchBypassKey contextKey = "kill_switch_bypass"
+
+// WithKillSwitchBypass marks a context so sell orders placed with it are
+// accepted while trading is disabled. The engine uses it to close positions
+// on shutdown; buys are still rejected.
+//
+// Args:
+//   - ctx: Parent context
+//
+// Returns:
+//   - context.Context: Context that bypasses the kill-switch for sells
+func WithKillSwitchBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, killSwitchBypassKey, true)
+}
+
+// killSwitchBypassed reports whether ctx was marked by WithKillSwitchBypass.
+func killSwitchBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(killSwitchBypassKey).(bool)
+	return bypass
+}
+
+// TradingEnabled reports whether new orders are accepted.
+func (om *OrderManager) TradingEnabled() bool {
+	om.mu.RLock()
+	defer om.mu.RUnlock()
+	return !om.tradingDisabled
+}
+
+// SetTradingEnabled flips the kill-switch. The flag is persisted before it
+// takes effect, so a restart never silently re-enables trading.
+//
+// Args:
+//   - enabled: False blocks new orders until re-enabled
+//
+// Returns:
+//   - error: If the flag could not be persisted
+func (om *OrderManager) SetTradingEnabled(enabled bool) error {
+	if om.store != nil {
+		if err := om.store.SetSystemConfig(TradingEnabledKey, strconv.FormatBool(enabled)); err != nil {
+			return err
+		}
+	}
+
+	om.mu.Lock()
+	om.tradingDisabled = !enabled
+	om.mu.Unlock()
+
+	log.Warn().Bool("trading_enabled", enabled).Msg("Kill-switch updated")
+	om.notifyKillSwitch(enabled)
+	return nil
+}
+
+// notifyKillSwitch sends a critical notification when the kill-switch is
+// flipped, so it is delivered even during quiet hours.
+func (om *OrderManager) notifyKillSwitch(enabled bool) {
+	om.mu.RLock()
+	notifier := om.notifier
+	om.mu.RUnlock()
+	if notifier == nil {
+		return
+	}
+
+	title, message := "Trading disabled", "The kill-switch is set; new orders are rejected until trading is re-enabled"
+	if enabled {
+		title, message = "Trading enabled", "The kill-switch was cleared; new orders are accepted again"
+	}
+	if _, err := notifier.Send(models.NotificationWarning, title, message, map[string]interface{}{
+		"trading_enabled":              enabled,
+		models.NotificationCriticalKey: true,
+	}); err != nil {
+		log.Error().Err(err).Msg("Failed to send kill-switch notification")
+	}
+}
+
+// checkKillSwitch rejects orders while trading is disabled, except closing
+// sells placed with a bypass context.
+func (om *OrderManager) checkKillSwitch(ctx context.Context, order models.Order) error {
+	if om.TradingEnabled() {
+		return nil
+	}
+	if order.Side == models.OrderSideSell && killSwitchBypassed(ctx) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s %s rejected", ErrTradingDisabled, order.Side, order.Symbol)
+}
+
+// loadTradingEnabled reads the persisted kill-switch state. A missing key
+// means trading was never disabled; any other failure keeps trading disabled
+// rather than risk trading against an operator's wishes.
+func loadTradingEnabled(store OrderStore) bool {
+	if store == nil {
+		return true
+	}
+
+	value, err := store.GetSystemConfig(TradingEnabledKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return true
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load kill-switch state, trading disabled")
+		return false
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Error().Str("value", value).Msg("Invalid kill-switch state, trading disabled")
+		return false
+	}
+	if !enabled {
+		log.Warn().Msg("Kill-switch is set, new orders will be rejected")
+	}
+	return enabled
+}
@@ -0,0 +1,86 @@
+package execution
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestKillSwitch_BlocksOrders verifies disabled trading rejects buys and
+// sells, except closing sells placed with a bypass context.
+func TestKillSwitch_BlocksOrders(t *testing.T) {
+	broker := NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+	om := NewOrderManager(broker, nil, nil, nil)
+	notifier := &recordingNotifier{}
+	om.SetNotifier(notifier)
+	ctx := context.Background()
+
+	assert.True(t, om.TradingEnabled())
+	_, err := om.CreateMarketOrder(ctx, "AAPL", models.OrderSideBuy, 10)
+	require.NoError(t, err)
+
+	require.NoError(t, om.SetTradingEnabled(false))
+	assert.False(t, om.TradingEnabled())
+
+	_, err = om.CreateMarketOrder(ctx, "AAPL", models.OrderSideBuy, 10)
+	assert.ErrorIs(t, err, ErrTradingDisabled)
+	assert.False(t, IsRetryable(err))
+
+	_, err = om.CreateMarketOrder(ctx, "AAPL", models.OrderSideSell, 5)
+	assert.ErrorIs(t, err, ErrTradingDisabled)
+
+	// Shutdown closures bypass the switch, but only for sells
+	bypass := WithKillSwitchBypass(ctx)
+	_, err = om.CreateMarketOrder(bypass, "AAPL", models.OrderSideBuy, 10)
+	assert.ErrorIs(t, err, ErrTradingDisabled)
+	_, err = om.CreateMarketOrder(bypass, "AAPL", models.OrderSideSell, 10)
+	require.NoError(t, err)
+
+	require.NoError(t, om.SetTradingEnabled(true))
+	_, err = om.CreateMarketOrder(ctx, "AAPL", models.OrderSideBuy, 10)
+	assert.NoError(t, err)
+
+	// Both flips notify, past quiet hours; fills notify as trades
+	var titles []string
+	for i, notifType := range notifier.types {
+		if notifType != models.NotificationTrade {
+			titles = append(titles, notifier.titles[i])
+			assert.True(t, notifier.critical[i])
+		}
+	}
+	assert.Equal(t, []string{"Trading disabled", "Trading enabled"}, titles)
+}
+
+// TestKillSwitch_PersistsAcrossRestart verifies a new order manager picks up
+// the stored flag.
+func TestKillSwitch_PersistsAcrossRestart(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	store := data.NewOrderStore(db)
+	broker := NewPaperBroker(100000)
+
+	// No stored flag means trading is enabled
+	om1 := NewOrderManager(broker, nil, store, nil)
+	assert.True(t, om1.TradingEnabled())
+	require.NoError(t, om1.SetTradingEnabled(false))
+
+	om2 := NewOrderManager(broker, nil, store, nil)
+	assert.False(t, om2.TradingEnabled())
+	require.NoError(t, om2.SetTradingEnabled(true))
+
+	om3 := NewOrderManager(broker, nil, store, nil)
+	assert.True(t, om3.TradingEnabled())
+
+	// An unreadable flag fails closed
+	require.NoError(t, store.SetSystemConfig(TradingEnabledKey, "maybe"))
+	om4 := NewOrderManager(broker, nil, store, nil)
+	assert.False(t, om4.TradingEnabled())
+}
@@ -0,0 +1,62 @@
+// Package execution provides the rules for modifying open orders.
+package execution
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+var (
+	// ErrOrderNotModifiable marks modifications to orders that are no longer
+	// open (filled, cancelled or rejected).
+	ErrOrderNotModifiable = errors.New("order cannot be modified")
+	// ErrQuantityBelowFilled marks a new quantity smaller than the amount
+	// that has already filled.
+	ErrQuantityBelowFilled = errors.New("quantity below filled amount")
+)
+
+// ApplyOrderModification applies a price and quantity change to an order
+// using the same rules for every broker:
+//   - Only pending, submitted and partially filled orders can be modified.
+//   - A quantity below the filled quantity is rejected.
+//   - A quantity equal to the filled quantity cancels the unfilled remainder.
+//   - Any other quantity becomes the order's total, so the open amount is
+//     the new quantity minus what has filled.
+//
+// Args:
+//   - order: The order's current state
+//   - newPrice: New limit price (0 to keep current)
+//   - newQuantity: New total quantity (0 to keep current)
+//
+// Returns:
+//   - models.Order: The modified order
+//   - error: ErrOrderNotModifiable, ErrQuantityBelowFilled, or ErrOrderInvalid
+func ApplyOrderModification(order models.Order, newPrice, newQuantity float64) (models.Order, error) {
+	switch order.Status {
+	case models.OrderStatusPending, models.OrderStatusSubmitted, models.OrderStatusPartiallyFilled:
+	default:
+		return order, fmt.Errorf("%w: order %s is %s", ErrOrderNotModifiable, order.ID, order.Status)
+	}
+
+	if newPrice > 0 {
+		if order.Type == models.OrderTypeMarket {
+			return order, fmt.Errorf("%w: cannot set price for market order", ErrOrderInvalid)
+		}
+		order.Price = newPrice
+	}
+
+	if newQuantity > 0 {
+		switch {
+		case newQuantity < order.FilledQuantity:
+			return order, fmt.Errorf("%w: requested %g but %g already filled",
+				ErrQuantityBelowFilled, newQuantity, order.FilledQuantity)
+		case newQuantity == order.FilledQuantity:
+			order.Status = models.OrderStatusCancelled
+		}
+		order.Quantity = newQuantity
+	}
+
+	return order, nil
+}
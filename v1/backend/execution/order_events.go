@@ -0,0 +1,76 @@
+// Package execution provides the audit trail of order state changes.
+package execution
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/tracing"
+)
+
+// ErrEventsUnsupported is returned when the order manager has no store to
+// persist order events in.
+var ErrEventsUnsupported = errors.New("order events require an order store")
+
+// GetOrderEvents lists the state changes of a stored order.
+//
+// Args:
+//   - orderID: ID of the order
+//
+// Returns:
+//   - []models.OrderEvent: The order's events, oldest first
+//   - error: ErrEventsUnsupported, ErrOrderNotFound, or a store error
+func (om *OrderManager) GetOrderEvents(orderID string) ([]models.OrderEvent, error) {
+	if om.store == nil {
+		return nil, ErrEventsUnsupported
+	}
+	if err := om.requireStoredOrder(orderID); err != nil {
+		return nil, err
+	}
+	return om.store.GetOrderEvents(orderID)
+}
+
+// priorOrder returns an order's state before a change, from the cache or
+// else the store. It only looks when events are recorded, and returns the
+// zero Order (with an empty status) if the order is unknown.
+func (om *OrderManager) priorOrder(orderID string) models.Order {
+	if om.store == nil {
+		return models.Order{}
+	}
+	om.mu.RLock()
+	cached, exists := om.orders[orderID]
+	om.mu.RUnlock()
+	if exists {
+		return cached
+	}
+	if stored, err := om.store.GetOrder(orderID); err == nil && stored != nil {
+		return *stored
+	}
+	return models.Order{}
+}
+
+// recordEvent appends an order state change to the audit trail, attributed
+// to the API key (or "system" for the engine) and trace ID in ctx. Failures
+// are logged, never returned, so auditing cannot block trading.
+func (om *OrderManager) recordEvent(ctx context.Context, action models.OrderAction, from models.OrderStatus, order models.Order) {
+	if om.store == nil {
+		return
+	}
+	event := models.OrderEvent{
+		OrderID:    order.ID,
+		Action:     action,
+		FromStatus: from,
+		ToStatus:   order.Status,
+		Quantity:   order.Quantity,
+		Price:      order.Price,
+		Actor:      auditKeyIDFromCtx(ctx),
+		TraceID:    tracing.TraceIDFromCtx(ctx),
+		CreatedAt:  time.Now(),
+	}
+	if err := om.store.SaveOrderEvent(event); err != nil {
+		logger := tracing.Logger(ctx)
+		logger.Error().Err(err).Str("order_id", order.ID).Msg("Failed to record order event")
+	}
+}
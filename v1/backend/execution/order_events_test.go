@@ -0,0 +1,89 @@
+package execution
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOrderManager_OrderEvents verifies submit, modify and cancel each add
+// an event attributed to the caller, in order.
+func TestOrderManager_OrderEvents(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	broker := NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 150)
+	om := NewOrderManager(broker, nil, data.NewOrderStore(db), nil)
+
+	apiCtx := context.WithValue(tracing.WithTraceID(context.Background(), "trace-api"), auditKeyIDKey, "key-1")
+	order, err := om.CreateLimitOrder(apiCtx, "AAPL", models.OrderSideBuy, 10, 100)
+	require.NoError(t, err)
+	_, err = om.ModifyOrder(apiCtx, order.ID, 0, 5)
+	require.NoError(t, err)
+	require.NoError(t, om.CancelOrder(NewEngineContext(), order.ID))
+
+	events, err := om.GetOrderEvents(order.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, models.OrderActionSubmit, events[0].Action)
+	assert.Empty(t, events[0].FromStatus, "a new order has no prior status")
+	assert.Equal(t, models.OrderStatusPending, events[0].ToStatus)
+	assert.Equal(t, "key-1", events[0].Actor)
+	assert.Equal(t, "trace-api", events[0].TraceID)
+
+	assert.Equal(t, models.OrderActionModify, events[1].Action)
+	assert.Equal(t, models.OrderStatusPending, events[1].FromStatus)
+	assert.Equal(t, 5.0, events[1].Quantity)
+
+	assert.Equal(t, models.OrderActionCancel, events[2].Action)
+	assert.Equal(t, models.OrderStatusPending, events[2].FromStatus)
+	assert.Equal(t, models.OrderStatusCancelled, events[2].ToStatus)
+	assert.Equal(t, "system", events[2].Actor)
+
+	_, err = om.GetOrderEvents("missing")
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+}
+
+// TestOrderManager_OrderEvents_BrokerUpdate verifies a fill reported by the
+// broker is recorded as an update from the engine.
+func TestOrderManager_OrderEvents_BrokerUpdate(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	broker := NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 150)
+	om := NewOrderManager(broker, nil, data.NewOrderStore(db), nil)
+
+	order, err := om.CreateLimitOrder(context.Background(), "AAPL", models.OrderSideBuy, 10, 100)
+	require.NoError(t, err)
+	broker.SetPrice("AAPL", 99)
+
+	events, err := om.GetOrderEvents(order.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, models.OrderActionSubmit, events[0].Action)
+	assert.Equal(t, models.OrderActionUpdate, events[1].Action)
+	assert.Equal(t, models.OrderStatusPending, events[1].FromStatus)
+	assert.Equal(t, models.OrderStatusFilled, events[1].ToStatus)
+	assert.Equal(t, "system", events[1].Actor)
+}
+
+// TestOrderManager_OrderEvents_NoStore verifies the audit trail needs a store.
+func TestOrderManager_OrderEvents_NoStore(t *testing.T) {
+	om := NewOrderManager(NewPaperBroker(10000), nil, nil, nil)
+
+	_, err := om.GetOrderEvents("order-1")
+	assert.ErrorIs(t, err, ErrEventsUnsupported)
+}
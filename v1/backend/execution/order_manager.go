@@ -3,18 +3,72 @@ package execution
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"sort"
-	"strconv"
 	"sync"
 	"time"
 
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/metrics"
 	"github.com/alexherrero/sherwood/backend/models"
 	"github.com/alexherrero/sherwood/backend/realtime"
 	"github.com/alexherrero/sherwood/backend/tracing"
 	"github.com/rs/zerolog/log"
 )
 
+var (
+	// ErrOrderInvalid marks orders that failed validation.
+	ErrOrderInvalid = errors.New("order validation failed")
+	// ErrRiskRejected marks orders rejected by the risk manager.
+	ErrRiskRejected = errors.New("risk check failed")
+	// ErrSyntheticSymbol marks orders on a synthetic ratio symbol, which is
+	// analysis-only. It is returned alongside ErrOrderInvalid.
+	ErrSyntheticSymbol = errors.New("synthetic symbol is analysis-only")
+)
+
+// IsRetryable reports whether resubmitting a failed order is safe and could
+// succeed. Only failures known to have happened before the broker accepted
+// the order are retried: an unavailable broker, a failed connection attempt,
+// a broker rate limit, and 5xx responses other than 504. Anything else,
+// including broker rejections, insufficient funds and timeouts after the
+// request was sent, is not retried, since a retry could place a duplicate
+// order.
+//
+// Args:
+//   - err: Error returned by an order submission
+//
+// Returns:
+//   - bool: True if the order may be retried
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrBrokerUnavailable) || errors.Is(err, ErrBrokerRateLimited) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		// The connection was never established, so nothing was sent
+		return true
+	}
+	var statusErr *BrokerStatusError
+	if errors.As(err, &statusErr) {
+		switch {
+		case statusErr.StatusCode == http.StatusTooManyRequests:
+			return true
+		case statusErr.StatusCode == http.StatusGatewayTimeout:
+			// A proxy timed out waiting; the broker may still have accepted it
+			return false
+		default:
+			return statusErr.StatusCode >= 500
+		}
+	}
+	return false
+}
+
 // OrderStore defines persistence operations for orders and positions.
 type OrderStore interface {
 	SaveOrder(order models.Order) error
@@ -22,18 +76,47 @@ type OrderStore interface {
 	GetAllOrders() ([]models.Order, error)
 	SavePosition(position models.Position) error
 	GetAllPositions() ([]models.Position, error)
+	SaveTrade(trade models.Trade) error
+	GetTradesByOrder(orderID string) ([]models.Trade, error)
+	GetAllTrades() ([]models.Trade, error)
 	GetSystemConfig(key string) (string, error)
 	SetSystemConfig(key, value string) error
+	SaveOrderEvent(event models.OrderEvent) error
+	GetOrderEvents(orderID string) ([]models.OrderEvent, error)
+	SaveOrderNote(note models.OrderNote) (*models.OrderNote, error)
+	GetOrderNotes(orderID string) ([]models.OrderNote, error)
+	SaveEquitySnapshot(snapshot models.EquitySnapshot) error
+	GetEquitySnapshots(since time.Time) ([]models.EquitySnapshot, error)
 }
 
 // OrderManager handles order lifecycle and execution.
 type OrderManager struct {
-	broker      Broker
-	riskManager *RiskManager
-	orders      map[string]models.Order // In-memory cache
-	store       OrderStore              // Database persistence
-	wsManager   *realtime.WebSocketManager
-	mu          sync.RWMutex
+	broker          Broker
+	riskManager     *RiskManager
+	orders          map[string]models.Order // In-memory cache
+	store           OrderStore              // Database persistence
+	wsManager       *realtime.WebSocketManager
+	brackets        map[string]bracketLegs // Exit legs awaiting entry fill, keyed by entry order ID
+	sizing          OrderSizing            // Lot rounding and notional limits (zero value disables)
+	priceProvider   data.DataProvider      // Values market orders the broker cannot quote (nil to skip)
+	baseCurrency    string                 // Currency portfolio totals are reported in
+	fxRates         data.FXRateSource      // Converts position values to the base currency
+	tradingDisabled bool                   // Mirrors the persisted kill-switch; new orders are rejected while set
+	brokerPaused    bool                   // Set while the broker is disconnected; new orders are rejected
+	confirmation    OrderConfirmation      // Staging of large orders (zero value disables)
+	staged          map[string]stagedOrder // Orders awaiting confirmation, keyed by token
+	notifier        Notifier               // Receives fill notifications (nil to skip)
+	imports         []models.Position      // Imported positions, seeding cost basis as of their import time
+	tradesVersion   uint64                 // Bumped when realized P&L inputs change
+	costCache       *cachedTradeCosts      // Trade costs for a tradesVersion
+	pnlCounted      map[string]float64     // Realized P&L fed to the risk manager, keyed by partially filled order ID
+	mu              sync.RWMutex
+}
+
+// bracketLegs holds the take-profit and stop-loss exits of a bracket order.
+type bracketLegs struct {
+	takeProfit models.Order
+	stopLoss   models.Order
 }
 
 // NewOrderManager creates a new order manager.
@@ -52,16 +135,61 @@ func NewOrderManager(
 	store OrderStore,
 	wsManager *realtime.WebSocketManager,
 ) *OrderManager {
-	return &OrderManager{
-		broker:      broker,
-		riskManager: riskManager,
-		orders:      make(map[string]models.Order),
-		store:       store,
-		wsManager:   wsManager,
+	om := &OrderManager{
+		broker:       broker,
+		riskManager:  riskManager,
+		orders:       make(map[string]models.Order),
+		store:        store,
+		wsManager:    wsManager,
+		brackets:     loadBrackets(store),
+		staged:       make(map[string]stagedOrder),
+		pnlCounted:   make(map[string]float64),
+		baseCurrency: DefaultBaseCurrency,
+		fxRates:      data.NewStaticFXRates(),
+	}
+	om.tradingDisabled = !loadTradingEnabled(store)
+	om.imports = loadPositionImports(store)
+
+	// Keep the order cache in sync with fills the broker reports later
+	if notifier, ok := broker.(OrderUpdateNotifier); ok {
+		notifier.SetOrderUpdateHandler(om.handleOrderUpdate)
 	}
+
+	return om
+}
+
+// SetOrderSizing configures lot-size rounding and the minimum and maximum
+// order notional applied to every submitted order. All are disabled by default.
+//
+// Args:
+//   - sizing: Lot sizes per asset class and order value limits
+func (om *OrderManager) SetOrderSizing(sizing OrderSizing) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	om.sizing = sizing
+}
+
+// SetPriceProvider sets where market orders are priced for the notional
+// checks when the broker has no quote for the symbol.
+//
+// Args:
+//   - provider: Market data provider (nil to rely on broker quotes only)
+func (om *OrderManager) SetPriceProvider(provider data.DataProvider) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	om.priceProvider = provider
 }
 
 // SubmitOrder validates and submits an order for execution.
+// The quantity is first rounded down to the symbol's lot size, and orders
+// worth less than the minimum notional or more than the maximum notional are
+// rejected. When rounding changes the quantity, the original is returned as
+// RequestedQuantity.
+// Orders are rejected while the kill-switch is set, except closing sells
+// placed with WithKillSwitchBypass.
+// When order confirmation is configured, orders above its threshold pass the
+// same checks but are staged rather than placed: the result has status
+// pending_confirmation and a token for ConfirmOrder, and no ID.
 // The context carries audit information (user IP, API key ID) for logging.
 //
 // Args:
@@ -74,35 +202,79 @@ func NewOrderManager(
 func (om *OrderManager) SubmitOrder(ctx context.Context, order models.Order) (*models.Order, error) {
 	logger := tracing.Logger(ctx)
 
+	requested := order.Quantity
+	order, err := om.normalizeOrder(ctx, order)
+	if err != nil {
+		metrics.OrdersRejected.Inc(metrics.RejectValidation)
+		return nil, fmt.Errorf("%w: %w", ErrOrderInvalid, err)
+	}
+
 	// Validate order
 	if err := om.validateOrder(order); err != nil {
-		return nil, fmt.Errorf("order validation failed: %w", err)
+		metrics.OrdersRejected.Inc(metrics.RejectValidation)
+		return nil, fmt.Errorf("%w: %w", ErrOrderInvalid, err)
+	}
+
+	if err := om.checkKillSwitch(ctx, order); err != nil {
+		metrics.OrdersRejected.Inc(metrics.RejectDisabled)
+		return nil, err
+	}
+
+	if err := om.checkBrokerPaused(order); err != nil {
+		metrics.OrdersRejected.Inc(metrics.RejectBroker)
+		return nil, err
 	}
 
 	// Check risk limits
 	if om.riskManager != nil {
 		if err := om.riskManager.CheckOrder(order); err != nil {
-			return nil, fmt.Errorf("risk check failed: %w", err)
+			metrics.OrdersRejected.Inc(metrics.RejectRisk)
+			return nil, fmt.Errorf("%w: %w", ErrRiskRejected, err)
 		}
 	}
 
+	if om.needsConfirmation(ctx, order) {
+		submitted := order
+		submitted.Quantity = requested
+		return om.stageOrder(ctx, submitted, order)
+	}
+
 	// Submit to broker
-	result, err := om.broker.PlaceOrder(order)
+	placed, err := om.broker.PlaceOrder(order)
 	if err != nil {
+		metrics.OrdersRejected.Inc(metrics.RejectBroker)
 		return nil, fmt.Errorf("broker rejected order: %w", err)
 	}
+	metrics.OrdersPlaced.Inc(string(order.Side))
+	// Brokers don't track attribution, so carry it over from the request
+	result := new(models.Order)
+	*result = *placed
+	result.Attribution(order)
+	if order.Quantity != requested {
+		result.RequestedQuantity = requested
+	}
 
 	// Store order in memory
 	om.mu.Lock()
 	om.orders[result.ID] = *result
 	om.mu.Unlock()
 
+	if result.Status == models.OrderStatusFilled {
+		if om.riskManager != nil {
+			om.riskManager.RecordFill(*result)
+		}
+		om.notifyFill(*result)
+	}
+
 	// Persist to database
 	if om.store != nil {
 		if err := om.store.SaveOrder(*result); err != nil {
 			logger.Error().Err(err).Str("order_id", result.ID).Msg("Failed to persist order")
 		}
+		om.recordEvent(ctx, models.OrderActionSubmit, "", *result)
 	}
+	om.recordTrades(*result)
+	om.recordRealizedPnL(*result)
 
 	// Audit log with requestor and trace context
 	logger.Info().
@@ -113,6 +285,7 @@ func (om *OrderManager) SubmitOrder(ctx context.Context, order models.Order) (*m
 		Float64("quantity", result.Quantity).
 		Float64("price", result.Price).
 		Str("status", string(result.Status)).
+		Str("strategy", result.StrategyName).
 		Str("user_ip", auditIPFromCtx(ctx)).
 		Str("api_key_id", auditKeyIDFromCtx(ctx)).
 		Msg("Order submitted")
@@ -122,20 +295,94 @@ func (om *OrderManager) SubmitOrder(ctx context.Context, order models.Order) (*m
 		om.wsManager.Broadcast("order_update", result)
 	}
 
+	// An immediate fill may have cancelled a one-cancels-other sibling
+	if result.Status == models.OrderStatusFilled && result.LinkedOrderID != "" {
+		om.refreshOrder(result.LinkedOrderID)
+	}
+
 	return result, nil
 }
 
+// normalizeOrder applies the configured OrderSizing. Market orders are valued
+// at the broker's latest price when it is known, then at the price
+// provider's; with notional limits set, one neither can price is rejected.
+// Sells are sized against the broker's position, so closing one in full is
+// never left with an unsellable remainder.
+func (om *OrderManager) normalizeOrder(ctx context.Context, order models.Order) (models.Order, error) {
+	om.mu.RLock()
+	sizing := om.sizing
+	om.mu.RUnlock()
+	if !sizing.Enabled() {
+		return order, nil
+	}
+
+	var held float64
+	if order.Side == models.OrderSideSell {
+		if position, err := om.broker.GetPosition(order.Symbol); err == nil && position != nil {
+			held = position.Quantity
+		}
+	}
+	checksNotional := sizing.MinNotional > 0 || sizing.MaxNotional > 0
+	return sizing.Normalize(ctx, order, om.orderPrice(ctx, order, checksNotional), held)
+}
+
+// orderPrice values an order: at its price, or for market orders at the
+// broker's latest price and then, if allowed, the price provider's. It
+// returns 0 when the price is unknown.
+func (om *OrderManager) orderPrice(ctx context.Context, order models.Order, useProvider bool) float64 {
+	if order.Type != models.OrderTypeMarket {
+		return order.Price
+	}
+
+	om.mu.RLock()
+	provider := om.priceProvider
+	om.mu.RUnlock()
+
+	var price float64
+	if quoter, ok := om.broker.(PriceQuoter); ok {
+		price, _ = quoter.LatestPrice(order.Symbol)
+	}
+	if price <= 0 && provider != nil && useProvider {
+		if latest, err := data.GetLatestPriceContext(ctx, provider, order.Symbol); err == nil {
+			price = latest
+		}
+	}
+	return price
+}
+
+// MarketPrice returns a symbol's current price, as market orders are valued:
+// the broker's latest price, then the price provider's.
+//
+// Args:
+//   - ctx: Context for the provider request
+//   - symbol: Ticker symbol
+//
+// Returns:
+//   - float64: The price, or 0 if unknown
+func (om *OrderManager) MarketPrice(ctx context.Context, symbol string) float64 {
+	return om.orderPrice(ctx, models.Order{Symbol: symbol, Type: models.OrderTypeMarket}, true)
+}
+
 // validateOrder checks basic order validity.
 func (om *OrderManager) validateOrder(order models.Order) error {
 	if order.Symbol == "" {
 		return fmt.Errorf("symbol is required")
 	}
+	if data.IsSyntheticSymbol(order.Symbol) {
+		return fmt.Errorf("%w: %s can't be traded, trade its legs instead", ErrSyntheticSymbol, order.Symbol)
+	}
 	if order.Quantity <= 0 {
 		return fmt.Errorf("quantity must be positive")
 	}
 	if order.Type == models.OrderTypeLimit && order.Price <= 0 {
 		return fmt.Errorf("limit orders require a positive price")
 	}
+	if order.Type == models.OrderTypeStop && order.Price <= 0 {
+		return fmt.Errorf("stop orders require a positive price")
+	}
+	if !order.TimeInForce.IsValid() {
+		return fmt.Errorf("invalid time in force: %s", order.TimeInForce)
+	}
 	return nil
 }
 
@@ -186,14 +433,21 @@ func (om *OrderManager) CancelOrder(ctx context.Context, orderID string) error {
 		Str("api_key_id", auditKeyIDFromCtx(ctx)).
 		Msg("Order cancellation requested")
 
+	prior := om.priorOrder(orderID)
 	err := om.broker.CancelOrder(orderID)
 	if err != nil {
 		logger.Warn().
 			Str("order_id", orderID).
 			Err(err).
 			Msg("Order cancellation failed")
+		return err
 	}
-	return err
+
+	cancelled := prior
+	cancelled.ID = orderID
+	cancelled.Status = models.OrderStatusCancelled
+	om.recordEvent(ctx, models.OrderActionCancel, prior.Status, cancelled)
+	return nil
 }
 
 // GetOrder retrieves an order by ID.
@@ -220,10 +474,13 @@ func (om *OrderManager) GetOrder(orderID string) (*models.Order, error) {
 
 // OrderFilter defines criteria for filtering orders.
 type OrderFilter struct {
-	Symbol string
-	Status models.OrderStatus
-	Limit  int
-	Offset int
+	Symbol   string
+	Status   models.OrderStatus
+	ParentID string // Only orders attached to this bracket entry
+	Strategy string // Only orders placed by this strategy
+	Tag      string // Only orders carrying this tag
+	Limit    int
+	Offset   int
 }
 
 // GetOrders retrieves orders matching the filter criteria.
@@ -248,14 +505,26 @@ func (om *OrderManager) GetOrders(filter OrderFilter) ([]models.Order, int, erro
 		if filter.Status != "" && order.Status != filter.Status {
 			continue
 		}
+		if filter.ParentID != "" && order.ParentID != filter.ParentID {
+			continue
+		}
+		if filter.Strategy != "" && order.StrategyName != filter.Strategy {
+			continue
+		}
+		if filter.Tag != "" && !order.Tags.Has(filter.Tag) {
+			continue
+		}
 		filtered = append(filtered, order)
 	}
 
 	totalCount := len(filtered)
 
-	// 2. Sort (by CreatedAt descending)
+	// 2. Sort (by CreatedAt descending, then time-ordered ID)
 	sort.Slice(filtered, func(i, j int) bool {
-		return filtered[i].CreatedAt.After(filtered[j].CreatedAt)
+		if !filtered[i].CreatedAt.Equal(filtered[j].CreatedAt) {
+			return filtered[i].CreatedAt.After(filtered[j].CreatedAt)
+		}
+		return filtered[i].ID > filtered[j].ID
 	})
 
 	// 3. Paginate
@@ -336,6 +605,268 @@ func (om *OrderManager) CreateLimitOrder(ctx context.Context, symbol string, sid
 	return om.SubmitOrder(ctx, order)
 }
 
+// CreateBracketOrder submits an entry order with an attached take-profit limit
+// and stop-loss stop as a one-cancels-other pair. The exits are placed once the
+// entry fills; when either exit fills, the other is cancelled.
+// The context carries audit information (user IP, API key ID) for logging.
+//
+// Args:
+//   - ctx: Context with audit information
+//   - symbol: Ticker symbol
+//   - side: Entry direction (exits use the opposite side)
+//   - quantity: Amount to trade
+//   - entry: Entry limit price (0 for a market entry)
+//   - takeProfit: Take-profit limit price
+//   - stopLoss: Stop-loss trigger price
+//
+// Returns:
+//   - *models.Order: The submitted entry order
+//   - error: Any error encountered
+func (om *OrderManager) CreateBracketOrder(ctx context.Context, symbol string, side models.OrderSide, quantity, entry, takeProfit, stopLoss float64) (*models.Order, error) {
+	if err := validateBracket(side, entry, takeProfit, stopLoss); err != nil {
+		return nil, fmt.Errorf("bracket validation failed: %w", err)
+	}
+
+	exitSide := models.OrderSideSell
+	if side == models.OrderSideSell {
+		exitSide = models.OrderSideBuy
+	}
+
+	now := time.Now()
+	legs := bracketLegs{
+		takeProfit: models.Order{
+			Symbol:    symbol,
+			Side:      exitSide,
+			Type:      models.OrderTypeLimit,
+			Quantity:  quantity,
+			Price:     takeProfit,
+			Status:    models.OrderStatusPending,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		stopLoss: models.Order{
+			Symbol:    symbol,
+			Side:      exitSide,
+			Type:      models.OrderTypeStop,
+			Quantity:  quantity,
+			Price:     stopLoss,
+			Status:    models.OrderStatusPending,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+	}
+
+	var result *models.Order
+	var err error
+	if entry > 0 {
+		result, err = om.CreateLimitOrder(ctx, symbol, side, quantity, entry)
+	} else {
+		result, err = om.CreateMarketOrder(ctx, symbol, side, quantity)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if result.Status == models.OrderStatusPendingConfirmation {
+		// The exits are registered once the entry is confirmed
+		om.stageBracket(result.ConfirmationToken, legs)
+		return result, nil
+	}
+
+	return om.attachBracket(ctx, result, legs), nil
+}
+
+// attachBracket registers the exit legs of a submitted entry order, placing
+// them right away if it has already filled.
+//
+// Args:
+//   - ctx: Context with audit information
+//   - result: The submitted entry order
+//   - legs: The take-profit and stop-loss exits
+//
+// Returns:
+//   - *models.Order: The entry order, refreshed from the broker
+func (om *OrderManager) attachBracket(ctx context.Context, result *models.Order, legs bracketLegs) *models.Order {
+	// Register the exits, then re-check the entry in case it filled on a
+	// price update before registration
+	om.mu.Lock()
+	om.brackets[result.ID] = legs
+	om.saveBrackets()
+	om.mu.Unlock()
+
+	if result.Status != models.OrderStatusFilled {
+		if current, getErr := om.broker.GetOrder(result.ID); getErr == nil {
+			result = current
+		}
+	}
+	if result.Status == models.OrderStatusFilled {
+		om.placeBracketExits(ctx, *result)
+	}
+
+	return result
+}
+
+// validateBracket checks that bracket prices are ordered correctly for the
+// entry side: for a buy, stop-loss < entry < take-profit; for a sell, the reverse.
+func validateBracket(side models.OrderSide, entry, takeProfit, stopLoss float64) error {
+	if takeProfit <= 0 || stopLoss <= 0 {
+		return fmt.Errorf("take-profit and stop-loss prices must be positive")
+	}
+	if entry < 0 {
+		return fmt.Errorf("entry price cannot be negative")
+	}
+
+	switch side {
+	case models.OrderSideBuy:
+		if stopLoss >= takeProfit {
+			return fmt.Errorf("stop-loss (%.2f) must be below take-profit (%.2f) for a buy", stopLoss, takeProfit)
+		}
+		if entry > 0 && (entry <= stopLoss || entry >= takeProfit) {
+			return fmt.Errorf("entry (%.2f) must be between stop-loss and take-profit", entry)
+		}
+	case models.OrderSideSell:
+		if stopLoss <= takeProfit {
+			return fmt.Errorf("stop-loss (%.2f) must be above take-profit (%.2f) for a sell", stopLoss, takeProfit)
+		}
+		if entry > 0 && (entry >= stopLoss || entry <= takeProfit) {
+			return fmt.Errorf("entry (%.2f) must be between take-profit and stop-loss", entry)
+		}
+	default:
+		return fmt.Errorf("invalid side: %s", side)
+	}
+	return nil
+}
+
+// placeBracketExits submits the registered exit legs for a filled entry order.
+// It is a no-op if the legs were already placed.
+func (om *OrderManager) placeBracketExits(ctx context.Context, entry models.Order) {
+	logger := tracing.Logger(ctx)
+
+	om.mu.Lock()
+	legs, ok := om.brackets[entry.ID]
+	if ok {
+		delete(om.brackets, entry.ID)
+		om.saveBrackets()
+	}
+	om.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	legs.takeProfit.ParentID = entry.ID
+	legs.stopLoss.ParentID = entry.ID
+	legs.takeProfit.Attribution(entry)
+	legs.stopLoss.Attribution(entry)
+	if entry.FilledQuantity > 0 {
+		legs.takeProfit.Quantity = entry.FilledQuantity
+		legs.stopLoss.Quantity = entry.FilledQuantity
+	}
+
+	tp, err := om.SubmitOrder(ctx, legs.takeProfit)
+	if err != nil {
+		logger.Error().Err(err).Str("entry_id", entry.ID).Msg("Failed to place bracket take-profit")
+		return
+	}
+	if tp.Status == models.OrderStatusFilled {
+		// Exited immediately; the stop-loss is moot
+		return
+	}
+
+	legs.stopLoss.LinkedOrderID = tp.ID
+	sl, err := om.SubmitOrder(ctx, legs.stopLoss)
+	if err != nil {
+		// A lone take-profit would leave the position without its stop, so
+		// pull it rather than leave half a bracket resting
+		logger.Error().Err(err).Str("entry_id", entry.ID).Msg("Failed to place bracket stop-loss, cancelling take-profit")
+		if cancelErr := om.CancelOrder(ctx, tp.ID); cancelErr != nil {
+			logger.Error().Err(cancelErr).Str("entry_id", entry.ID).Str("take_profit_id", tp.ID).Msg("Failed to cancel bracket take-profit")
+			return
+		}
+		om.refreshOrder(tp.ID)
+		return
+	}
+
+	// Complete the link on the take-profit side of the cache and store
+	om.mu.Lock()
+	cached, exists := om.orders[tp.ID]
+	linked := exists && cached.LinkedOrderID == ""
+	if linked {
+		cached.LinkedOrderID = sl.ID
+		om.orders[tp.ID] = cached
+	}
+	om.mu.Unlock()
+	if linked && om.store != nil {
+		if err := om.store.SaveOrder(cached); err != nil {
+			logger.Error().Err(err).Str("order_id", tp.ID).Msg("Failed to persist bracket link")
+		}
+	}
+
+	logger.Info().
+		Str("entry_id", entry.ID).
+		Str("take_profit_id", tp.ID).
+		Str("stop_loss_id", sl.ID).
+		Msg("Bracket exits placed")
+}
+
+// handleOrderUpdate applies an asynchronous order update from the broker to
+// the cache, persists, audits and broadcasts it, and places bracket exits
+// when an entry order fills.
+func (om *OrderManager) handleOrderUpdate(order models.Order) {
+	om.mu.Lock()
+	newlyFilled := order.Status == models.OrderStatusFilled
+	var prior models.OrderStatus
+	if cached, exists := om.orders[order.ID]; exists {
+		prior = cached.Status
+		newlyFilled = newlyFilled && cached.Status != models.OrderStatusFilled
+		if order.ParentID == "" {
+			order.ParentID = cached.ParentID
+		}
+		if order.LinkedOrderID == "" {
+			order.LinkedOrderID = cached.LinkedOrderID
+		}
+		order.Attribution(cached)
+	}
+	om.orders[order.ID] = order
+	om.mu.Unlock()
+
+	if newlyFilled {
+		if om.riskManager != nil {
+			om.riskManager.RecordFill(order)
+		}
+		om.notifyFill(order)
+	}
+
+	if om.store != nil {
+		if err := om.store.SaveOrder(order); err != nil {
+			log.Error().Err(err).Str("order_id", order.ID).Msg("Failed to persist order update")
+		}
+		if order.Status != prior {
+			om.recordEvent(NewEngineContext(), models.OrderActionUpdate, prior, order)
+		}
+	}
+	om.recordTrades(order)
+	// A repeated fill update must not count the order's P&L twice
+	if newlyFilled || order.Status != models.OrderStatusFilled {
+		om.recordRealizedPnL(order)
+	}
+
+	if om.wsManager != nil {
+		om.wsManager.Broadcast("order_update", order)
+	}
+
+	if order.Status == models.OrderStatusFilled {
+		om.placeBracketExits(NewEngineContext(), order)
+	}
+}
+
+// refreshOrder reloads an order from the broker into the cache.
+func (om *OrderManager) refreshOrder(orderID string) {
+	order, err := om.broker.GetOrder(orderID)
+	if err != nil {
+		return
+	}
+	om.handleOrderUpdate(*order)
+}
+
 // GetPositions retrieves all current positions from the broker.
 //
 // Returns:
@@ -363,18 +894,141 @@ func (om *OrderManager) GetTrades() ([]models.Trade, error) {
 	return om.broker.GetTrades()
 }
 
+// GetOrderTrades retrieves the fills for a single order. Fills are read from
+// the trade store when persistence is configured, otherwise from the broker.
+//
+// Args:
+//   - orderID: ID of the order
+//
+// Returns:
+//   - []models.Trade: The order's trades (empty if it has not filled)
+//   - error: If the order is unknown or the trades cannot be read
+func (om *OrderManager) GetOrderTrades(orderID string) ([]models.Trade, error) {
+	if _, err := om.GetOrder(orderID); err != nil {
+		return nil, err
+	}
+
+	if om.store != nil {
+		return om.store.GetTradesByOrder(orderID)
+	}
+	return om.brokerTrades(orderID)
+}
+
+// brokerTrades returns the broker's trades for an order, asking for just
+// that order's when the broker supports it.
+func (om *OrderManager) brokerTrades(orderID string) ([]models.Trade, error) {
+	if lister, ok := om.broker.(OrderTradeLister); ok {
+		return lister.GetOrderTrades(orderID)
+	}
+	all, err := om.broker.GetTrades()
+	if err != nil {
+		return nil, err
+	}
+	trades := []models.Trade{}
+	for _, trade := range all {
+		if trade.OrderID == orderID {
+			trades = append(trades, trade)
+		}
+	}
+	return trades, nil
+}
+
+// recordTrades persists the broker's fills for an order that has executed,
+// when a store is configured, and invalidates the cached realized P&L.
+// Saving is idempotent, so repeated updates for the same order are harmless.
+func (om *OrderManager) recordTrades(order models.Order) {
+	if order.Status != models.OrderStatusFilled && order.Status != models.OrderStatusPartiallyFilled {
+		return
+	}
+	defer func() {
+		om.mu.Lock()
+		om.tradesChanged()
+		om.mu.Unlock()
+	}()
+	if om.store == nil {
+		return
+	}
+
+	trades, err := om.brokerTrades(order.ID)
+	if err != nil {
+		log.Error().Err(err).Str("order_id", order.ID).Msg("Failed to fetch trades for order")
+		return
+	}
+	for _, trade := range trades {
+		if err := om.store.SaveTrade(trade); err != nil {
+			log.Error().Err(err).Str("trade_id", trade.ID).Msg("Failed to persist trade")
+		}
+	}
+}
+
+// IsBrokerConnected reports whether the underlying broker is connected.
+//
+// Returns:
+//   - bool: True if the broker connection is live
+func (om *OrderManager) IsBrokerConnected() bool {
+	return om.broker.IsConnected()
+}
+
+// ReconnectBroker asks the broker to re-establish its connection.
+//
+// Returns:
+//   - error: If the connection attempt failed
+func (om *OrderManager) ReconnectBroker() error {
+	return om.broker.Connect()
+}
+
+// StorePinger is an optional OrderStore capability for checking that the
+// underlying database is reachable.
+type StorePinger interface {
+	Ping(ctx context.Context) error
+}
+
+// PingStore checks the order store's database connection.
+//
+// Args:
+//   - ctx: Context bounding the check
+//
+// Returns:
+//   - bool: True if an order store is configured
+//   - error: If the store cannot be reached
+func (om *OrderManager) PingStore(ctx context.Context) (enabled bool, err error) {
+	if om.store == nil {
+		return false, nil
+	}
+	if pinger, ok := om.store.(StorePinger); ok {
+		return true, pinger.Ping(ctx)
+	}
+	return true, nil
+}
+
+// TradingHalted reports the state of the risk manager's daily loss circuit breaker.
+//
+// Returns:
+//   - bool: True if a risk manager with a daily loss limit is configured
+//   - bool: True if the breaker has tripped and orders are blocked
+func (om *OrderManager) TradingHalted() (enabled, halted bool) {
+	if om.riskManager == nil || om.riskManager.GetConfig().MaxDailyLoss <= 0 {
+		return false, false
+	}
+	return true, om.riskManager.IsHalted()
+}
+
 // ModifyOrder modifies an existing open order.
+// Quantity changes follow ApplyOrderModification: reducing below the filled
+// quantity is rejected, reducing to exactly the filled quantity cancels the
+// remainder, and any other value adjusts the open quantity. Cached orders are
+// checked before the broker is called.
 // The context carries audit information (user IP, API key ID) for logging.
 //
 // Args:
 //   - ctx: Context with audit information
 //   - orderID: ID of the order to modify
 //   - newPrice: New limit price (0 to keep current)
-//   - newQuantity: New quantity (0 to keep current)
+//   - newQuantity: New total quantity (0 to keep current)
 //
 // Returns:
 //   - *models.Order: The modified order
-//   - error: Any error encountered
+//   - error: ErrOrderNotModifiable, ErrQuantityBelowFilled, ErrOrderInvalid, or a broker error
 func (om *OrderManager) ModifyOrder(ctx context.Context, orderID string, newPrice, newQuantity float64) (*models.Order, error) {
 	logger := tracing.Logger(ctx)
 
@@ -386,6 +1040,24 @@ func (om *OrderManager) ModifyOrder(ctx context.Context, orderID string, newPric
 		Str("api_key_id", auditKeyIDFromCtx(ctx)).
 		Msg("Order modification requested")
 
+	om.mu.RLock()
+	cached, exists := om.orders[orderID]
+	om.mu.RUnlock()
+	if exists {
+		if _, err := ApplyOrderModification(cached, newPrice, newQuantity); err != nil {
+			logger.Warn().
+				Str("order_id", orderID).
+				Err(err).
+				Msg("Order modification rejected")
+			return nil, err
+		}
+	}
+
+	prior := cached
+	if !exists {
+		prior = om.priorOrder(orderID)
+	}
+
 	order, err := om.broker.ModifyOrder(orderID, newPrice, newQuantity)
 	if err != nil {
 		logger.Warn().
@@ -397,6 +1069,9 @@ func (om *OrderManager) ModifyOrder(ctx context.Context, orderID string, newPric
 
 	// Update local cache
 	om.mu.Lock()
+	if cached, exists := om.orders[order.ID]; exists {
+		order.Attribution(cached)
+	}
 	om.orders[order.ID] = *order
 	om.mu.Unlock()
 
@@ -406,6 +1081,7 @@ func (om *OrderManager) ModifyOrder(ctx context.Context, orderID string, newPric
 			logger.Error().Err(err).Str("order_id", order.ID).Msg("Failed to persist modified order")
 		}
 	}
+	om.recordEvent(ctx, models.OrderActionModify, prior.Status, *order)
 
 	return order, nil
 }
@@ -475,33 +1151,3 @@ func (om *OrderManager) CancelAllPendingOrders(ctx context.Context) (int, error)
 	log.Info().Int("cancelled", cancelled).Int("pending", len(pendingIDs)).Msg("Cancelled pending orders during shutdown")
 	return cancelled, firstErr
 }
-
-// GetInitialCapital retrieves the initial capital from configuration.
-func (om *OrderManager) GetInitialCapital() (float64, error) {
-	if om.store == nil {
-		return 0, nil
-	}
-
-	valStr, err := om.store.GetSystemConfig("initial_capital")
-	if err != nil {
-		// Treat missing key as not found/default
-		return 0, err
-	}
-
-	val, err := strconv.ParseFloat(valStr, 64)
-	if err != nil {
-		return 0, fmt.Errorf("invalid initial capital value '%s': %w", valStr, err)
-	}
-
-	return val, nil
-}
-
-// SetInitialCapital stores the initial capital in configuration.
-func (om *OrderManager) SetInitialCapital(amount float64) error {
-	if om.store == nil {
-		return fmt.Errorf("no persistence configured")
-	}
-
-	valStr := strconv.FormatFloat(amount, 'f', 2, 64)
-	return om.store.SetSystemConfig("initial_capital", valStr)
-}
@@ -2,8 +2,13 @@ package execution
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/alexherrero/sherwood/backend/data"
 	"github.com/alexherrero/sherwood/backend/models"
@@ -54,6 +59,7 @@ func TestOrderManager_SubmitOrder_ValidationFails(t *testing.T) {
 		name        string
 		order       models.Order
 		errContains string
+		errIs       error
 	}{
 		{
 			name: "empty symbol",
@@ -89,6 +95,15 @@ func TestOrderManager_SubmitOrder_ValidationFails(t *testing.T) {
 			},
 			errContains: "limit orders require a positive price",
 		},
+		{
+			name: "synthetic symbol",
+			order: models.Order{
+				Symbol:   "ratio:ETH-USD/BTC-USD",
+				Quantity: 1,
+			},
+			errContains: "analysis-only",
+			errIs:       ErrSyntheticSymbol,
+		},
 	}
 
 	for _, tt := range tests {
@@ -96,10 +111,76 @@ func TestOrderManager_SubmitOrder_ValidationFails(t *testing.T) {
 			_, err := om.SubmitOrder(context.Background(), tt.order)
 			assert.Error(t, err)
 			assert.Contains(t, err.Error(), tt.errContains)
+			assert.ErrorIs(t, err, ErrOrderInvalid)
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+			}
+			assert.False(t, IsRetryable(err))
 		})
 	}
 }
 
+// TestOrderManager_RealizedLossTripsCircuitBreaker verifies fills feed their
+// realized P&L, net of fees, into the daily loss limit once per order.
+func TestOrderManager_RealizedLossTripsCircuitBreaker(t *testing.T) {
+	broker := NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	broker.SetFeeSchedule(FeeSchedule{PerOrder: 1})
+	broker.SetPrice("AAPL", 100.0)
+	rm := NewRiskManager(&RiskConfig{MaxDailyLoss: 500}, broker)
+	om := NewOrderManager(broker, rm, nil, nil)
+	ctx := context.Background()
+
+	_, err := om.CreateMarketOrder(ctx, "AAPL", models.OrderSideBuy, 10)
+	require.NoError(t, err)
+	assert.Equal(t, -1.0, rm.GetDailyPnL(), "a buy realizes only its fee")
+
+	resting, err := om.CreateLimitOrder(ctx, "AAPL", models.OrderSideSell, 5, 60.0)
+	require.NoError(t, err)
+	broker.SetPrice("AAPL", 50.0)
+	filled, err := om.GetOrder(resting.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.OrderStatusFilled, filled.Status)
+	om.handleOrderUpdate(*filled)
+	assert.InDelta(t, -202.0, rm.GetDailyPnL(), 1e-9, "a repeated fill update counts once")
+	assert.False(t, rm.IsHalted())
+
+	_, err = om.CreateMarketOrder(ctx, "AAPL", models.OrderSideSell, 5)
+	require.NoError(t, err)
+	assert.InDelta(t, -453.0, rm.GetDailyPnL(), 1e-9)
+	assert.False(t, rm.IsHalted())
+
+	_, err = om.CreateMarketOrder(ctx, "AAPL", models.OrderSideBuy, 10)
+	require.NoError(t, err)
+	broker.SetPrice("AAPL", 45.0)
+	_, err = om.CreateMarketOrder(ctx, "AAPL", models.OrderSideSell, 10)
+	require.NoError(t, err)
+	assert.True(t, rm.IsHalted())
+	_, err = om.CreateMarketOrder(ctx, "AAPL", models.OrderSideBuy, 1)
+	assert.ErrorContains(t, err, "daily loss limit exceeded")
+}
+
+// TestOrderManager_SubmitOrder_BrokerPaused verifies orders are rejected
+// while submission is paused for a broker disconnect.
+func TestOrderManager_SubmitOrder_BrokerPaused(t *testing.T) {
+	broker := NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+	om := NewOrderManager(broker, nil, nil, nil)
+
+	om.SetBrokerPaused(true)
+	_, err := om.CreateMarketOrder(context.Background(), "AAPL", models.OrderSideBuy, 1)
+	require.ErrorIs(t, err, ErrBrokerPaused)
+	assert.False(t, IsRetryable(err))
+	trades, err := broker.GetTrades()
+	require.NoError(t, err)
+	assert.Empty(t, trades, "nothing reaches the broker")
+
+	om.SetBrokerPaused(false)
+	_, err = om.CreateMarketOrder(context.Background(), "AAPL", models.OrderSideBuy, 1)
+	assert.NoError(t, err)
+}
+
 // TestOrderManager_SubmitOrder_RiskCheckFails verifies risk rejections.
 func TestOrderManager_SubmitOrder_RiskCheckFails(t *testing.T) {
 	broker := NewPaperBroker(10000)
@@ -121,6 +202,121 @@ func TestOrderManager_SubmitOrder_RiskCheckFails(t *testing.T) {
 	_, err := om.SubmitOrder(context.Background(), order)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "risk check failed")
+	assert.ErrorIs(t, err, ErrRiskRejected)
+	assert.False(t, IsRetryable(err))
+}
+
+// TestOrderManager_SubmitOrder_Sizing verifies orders are rounded to the lot
+// size and small orders are rejected before reaching the broker.
+func TestOrderManager_SubmitOrder_Sizing(t *testing.T) {
+	broker := NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 150.0)
+	broker.SetPrice("BTC-USD", 50000.0)
+
+	om := NewOrderManager(broker, nil, nil, nil)
+	om.SetOrderSizing(OrderSizing{MinNotional: 100, EquityLotSize: 1, CryptoLotSize: 0.0001})
+
+	result, err := om.CreateMarketOrder(context.Background(), "AAPL", models.OrderSideBuy, 2.75)
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, result.Quantity)
+	assert.Equal(t, 2.75, result.RequestedQuantity)
+
+	result, err = om.CreateMarketOrder(context.Background(), "BTC-USD", models.OrderSideBuy, 0.012345)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0123, result.Quantity)
+	assert.Equal(t, 0.012345, result.RequestedQuantity)
+
+	// Quantities already on a lot boundary report no rounding
+	result, err = om.CreateMarketOrder(context.Background(), "AAPL", models.OrderSideBuy, 3)
+	require.NoError(t, err)
+	assert.Zero(t, result.RequestedQuantity)
+
+	// 0.5 shares of AAPL rounds to nothing; the market order is valued at the
+	// broker's latest price for the notional check
+	_, err = om.CreateMarketOrder(context.Background(), "AAPL", models.OrderSideBuy, 0.5)
+	require.ErrorIs(t, err, ErrOrderInvalid)
+	_, err = om.CreateMarketOrder(context.Background(), "BTC-USD", models.OrderSideBuy, 0.001)
+	require.ErrorIs(t, err, ErrOrderInvalid)
+	assert.Contains(t, err.Error(), "minimum notional")
+	assert.False(t, IsRetryable(err))
+
+	orders, err := om.GetAllOrders()
+	require.NoError(t, err)
+	assert.Len(t, orders, 3)
+}
+
+// TestOrderManager_SubmitOrder_SizingClosesPosition verifies a sell of the
+// whole broker position skips lot rounding.
+func TestOrderManager_SubmitOrder_SizingClosesPosition(t *testing.T) {
+	broker := NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 150.0)
+
+	om := NewOrderManager(broker, nil, nil, nil)
+	_, err := om.CreateMarketOrder(context.Background(), "AAPL", models.OrderSideBuy, 2.5)
+	require.NoError(t, err)
+
+	om.SetOrderSizing(OrderSizing{EquityLotSize: 1})
+	result, err := om.CreateMarketOrder(context.Background(), "AAPL", models.OrderSideSell, 2.5)
+	require.NoError(t, err)
+	assert.Equal(t, 2.5, result.Quantity)
+	assert.Zero(t, result.RequestedQuantity)
+}
+
+// TestOrderManager_SubmitOrder_MaxNotional verifies oversized orders are
+// rejected, with market orders the broker has not priced yet valued at the
+// price provider's latest price.
+func TestOrderManager_SubmitOrder_MaxNotional(t *testing.T) {
+	broker := NewPaperBroker(10000000)
+	require.NoError(t, broker.Connect())
+	provider := &priceProvider{price: 150.0}
+	broker.SetPriceProvider(provider, time.Minute)
+
+	om := NewOrderManager(broker, nil, nil, nil)
+	om.SetOrderSizing(OrderSizing{MaxNotional: 100000})
+
+	om.SetPriceProvider(provider)
+	_, err := om.CreateMarketOrder(context.Background(), "AAPL", models.OrderSideBuy, 1000)
+	require.ErrorIs(t, err, ErrMaxNotionalExceeded)
+	require.ErrorIs(t, err, ErrOrderInvalid)
+	assert.Contains(t, err.Error(), "order value 150000.00 is above the maximum notional 100000.00")
+
+	result, err := om.CreateMarketOrder(context.Background(), "AAPL", models.OrderSideBuy, 500)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusFilled, result.Status)
+
+	// Limit orders are valued at their limit price
+	_, err = om.CreateLimitOrder(context.Background(), "AAPL", models.OrderSideBuy, 100, 1500)
+	require.ErrorIs(t, err, ErrMaxNotionalExceeded)
+
+	// A market order nothing can price is rejected, not placed unchecked
+	om.SetPriceProvider(nil)
+	_, err = om.CreateMarketOrder(context.Background(), "MSFT", models.OrderSideBuy, 1000)
+	require.ErrorIs(t, err, ErrNotionalUnknown)
+	require.ErrorIs(t, err, ErrOrderInvalid)
+}
+
+// TestIsRetryable verifies which order failures may be retried.
+func TestIsRetryable(t *testing.T) {
+	assert.False(t, IsRetryable(nil))
+	assert.False(t, IsRetryable(fmt.Errorf("%w: quantity must be positive", ErrOrderInvalid)))
+	assert.False(t, IsRetryable(fmt.Errorf("%w: daily loss limit", ErrRiskRejected)))
+	assert.False(t, IsRetryable(context.Canceled))
+
+	// Failures before the broker saw the order are transient
+	assert.True(t, IsRetryable(fmt.Errorf("broker rejected order: %w", ErrBrokerUnavailable)))
+	assert.True(t, IsRetryable(fmt.Errorf("broker rejected order: %w", ErrBrokerRateLimited)))
+	assert.True(t, IsRetryable(&net.OpError{Op: "dial", Err: errors.New("connection refused")}))
+	assert.True(t, IsRetryable(&BrokerStatusError{StatusCode: http.StatusServiceUnavailable}))
+	assert.True(t, IsRetryable(&BrokerStatusError{StatusCode: http.StatusTooManyRequests}))
+
+	// Rejections and failures the broker may have accepted are not
+	assert.False(t, IsRetryable(fmt.Errorf("broker rejected order: %w", errors.New("insufficient funds"))))
+	assert.False(t, IsRetryable(&BrokerStatusError{StatusCode: http.StatusUnprocessableEntity}))
+	assert.False(t, IsRetryable(&BrokerStatusError{StatusCode: http.StatusGatewayTimeout}))
+	assert.False(t, IsRetryable(&net.OpError{Op: "read", Err: errors.New("connection reset")}))
+	assert.False(t, IsRetryable(context.DeadlineExceeded))
 }
 
 // TestOrderManager_CancelOrder verifies order cancellation.
@@ -183,6 +379,34 @@ func TestOrderManager_GetAllOrders(t *testing.T) {
 	assert.Len(t, orders, 2)
 }
 
+// TestOrderManager_GetOrders_Attribution verifies orders keep their strategy
+// and tags and can be filtered by them.
+func TestOrderManager_GetOrders_Attribution(t *testing.T) {
+	broker := NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+
+	om := NewOrderManager(broker, nil, nil, nil)
+
+	_, err := om.SubmitOrder(context.Background(), models.Order{Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeMarket, Quantity: 1, StrategyName: "ma_crossover"})
+	require.NoError(t, err)
+	_, err = om.SubmitOrder(context.Background(), models.Order{Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeMarket, Quantity: 1, StrategyName: "rsi_momentum"})
+	require.NoError(t, err)
+	manual, err := om.SubmitOrder(context.Background(), models.Order{Symbol: "AAPL", Side: models.OrderSideSell, Type: models.OrderTypeMarket, Quantity: 1, Tags: models.Tags{models.TagManual}})
+	require.NoError(t, err)
+	assert.Equal(t, models.Tags{models.TagManual}, manual.Tags)
+
+	orders, total, err := om.GetOrders(OrderFilter{Strategy: "ma_crossover"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "ma_crossover", orders[0].StrategyName)
+
+	orders, total, err = om.GetOrders(OrderFilter{Tag: models.TagManual})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, manual.ID, orders[0].ID)
+}
+
 // TestOrderManager_CreateMarketOrder verifies market order creation.
 func TestOrderManager_CreateMarketOrder(t *testing.T) {
 	broker := NewPaperBroker(10000)
@@ -252,7 +476,10 @@ func TestOrderManager_Persistence(t *testing.T) {
 
 	// Create first OrderManager and submit order
 	om1 := NewOrderManager(broker, nil, store, nil)
-	order, err := om1.CreateMarketOrder(context.Background(), "AAPL", models.OrderSideBuy, 10)
+	order, err := om1.SubmitOrder(context.Background(), models.Order{
+		Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeMarket, Quantity: 10,
+		StrategyName: "ma_crossover", Tags: models.Tags{"swing"},
+	})
 	require.NoError(t, err)
 	orderID := order.ID
 
@@ -280,6 +507,8 @@ func TestOrderManager_Persistence(t *testing.T) {
 	assert.Equal(t, "AAPL", restored.Symbol)
 	assert.Equal(t, models.OrderSideBuy, restored.Side)
 	assert.Equal(t, 10.0, restored.Quantity)
+	assert.Equal(t, "ma_crossover", restored.StrategyName)
+	assert.Equal(t, models.Tags{"swing"}, restored.Tags)
 
 	// Verify in-memory cache is populated
 	allOrders, err = om2.GetAllOrders()
@@ -308,6 +537,32 @@ func TestOrderManager_ModifyOrder(t *testing.T) {
 	assert.Equal(t, 105.0, retrieved.Price)
 }
 
+// TestOrderManager_ModifyOrder_ChecksCache verifies the modify rules are
+// applied to the cached order before the broker is called.
+func TestOrderManager_ModifyOrder_ChecksCache(t *testing.T) {
+	broker := NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	om := NewOrderManager(broker, nil, nil, nil)
+
+	order, err := om.CreateLimitOrder(context.Background(), "AAPL", models.OrderSideBuy, 10, 100.0)
+	require.NoError(t, err)
+
+	// A fill the broker has not seen yet, as reported by an order update
+	om.mu.Lock()
+	cached := om.orders[order.ID]
+	cached.Status = models.OrderStatusPartiallyFilled
+	cached.FilledQuantity = 6
+	om.orders[order.ID] = cached
+	om.mu.Unlock()
+
+	_, err = om.ModifyOrder(context.Background(), order.ID, 0, 5)
+	require.ErrorIs(t, err, ErrQuantityBelowFilled)
+
+	stored, err := broker.GetOrder(order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, stored.Quantity, "broker order is left unchanged")
+}
+
 func TestOrderManager_PassThroughs(t *testing.T) {
 	broker := NewPaperBroker(10000)
 	require.NoError(t, broker.Connect())
@@ -330,3 +585,217 @@ func TestOrderManager_PassThroughs(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, trades)
 }
+
+// TestOrderManager_CreateBracketOrder_TakeProfit verifies a take-profit fill cancels the stop-loss.
+func TestOrderManager_CreateBracketOrder_TakeProfit(t *testing.T) {
+	broker := NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+
+	om := NewOrderManager(broker, nil, nil, nil)
+
+	entry, err := om.CreateBracketOrder(context.Background(), "AAPL", models.OrderSideBuy, 10, 0, 110.0, 90.0)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusFilled, entry.Status)
+
+	exits, total, err := om.GetOrders(OrderFilter{ParentID: entry.ID})
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+
+	var tp, sl models.Order
+	for _, o := range exits {
+		if o.Type == models.OrderTypeLimit {
+			tp = o
+		} else {
+			sl = o
+		}
+	}
+	assert.Equal(t, models.OrderSideSell, tp.Side)
+	assert.Equal(t, models.OrderTypeStop, sl.Type)
+	assert.Equal(t, sl.ID, tp.LinkedOrderID)
+	assert.Equal(t, tp.ID, sl.LinkedOrderID)
+
+	broker.SetPrice("AAPL", 111.0)
+
+	tpAfter, _ := om.GetOrder(tp.ID)
+	slAfter, _ := om.GetOrder(sl.ID)
+	assert.Equal(t, models.OrderStatusFilled, tpAfter.Status)
+	assert.Equal(t, models.OrderStatusCancelled, slAfter.Status)
+
+	positions, err := om.GetPositions()
+	require.NoError(t, err)
+	assert.Empty(t, positions)
+}
+
+// TestOrderManager_CreateBracketOrder_StopLoss verifies a stop-loss fill cancels the take-profit.
+func TestOrderManager_CreateBracketOrder_StopLoss(t *testing.T) {
+	broker := NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+
+	om := NewOrderManager(broker, nil, nil, nil)
+
+	entry, err := om.CreateBracketOrder(context.Background(), "AAPL", models.OrderSideBuy, 10, 0, 110.0, 90.0)
+	require.NoError(t, err)
+
+	broker.SetPrice("AAPL", 88.0)
+
+	filled, _, err := om.GetOrders(OrderFilter{ParentID: entry.ID, Status: models.OrderStatusFilled})
+	require.NoError(t, err)
+	require.Len(t, filled, 1)
+	assert.Equal(t, models.OrderTypeStop, filled[0].Type)
+
+	cancelled, _, err := om.GetOrders(OrderFilter{ParentID: entry.ID, Status: models.OrderStatusCancelled})
+	require.NoError(t, err)
+	require.Len(t, cancelled, 1)
+	assert.Equal(t, models.OrderTypeLimit, cancelled[0].Type)
+}
+
+// TestOrderManager_CreateBracketOrder_RestingEntry verifies exits wait for the entry to fill.
+func TestOrderManager_CreateBracketOrder_RestingEntry(t *testing.T) {
+	broker := NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+
+	om := NewOrderManager(broker, nil, nil, nil)
+
+	entry, err := om.CreateBracketOrder(context.Background(), "AAPL", models.OrderSideBuy, 10, 95.0, 110.0, 90.0)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusPending, entry.Status)
+
+	_, total, _ := om.GetOrders(OrderFilter{ParentID: entry.ID})
+	assert.Equal(t, 0, total)
+
+	broker.SetPrice("AAPL", 95.0)
+
+	cached, _ := om.GetOrder(entry.ID)
+	assert.Equal(t, models.OrderStatusFilled, cached.Status)
+
+	_, total, _ = om.GetOrders(OrderFilter{ParentID: entry.ID, Status: models.OrderStatusPending})
+	assert.Equal(t, 2, total)
+}
+
+// stopRejectingBroker is a PaperBroker that rejects stop orders.
+type stopRejectingBroker struct {
+	*PaperBroker
+}
+
+func (b *stopRejectingBroker) PlaceOrder(order models.Order) (*models.Order, error) {
+	if order.Type == models.OrderTypeStop {
+		return nil, errors.New("stop orders not supported")
+	}
+	return b.PaperBroker.PlaceOrder(order)
+}
+
+// TestOrderManager_CreateBracketOrder_StopLossFails verifies the take-profit
+// is cancelled when the stop-loss cannot be placed.
+func TestOrderManager_CreateBracketOrder_StopLossFails(t *testing.T) {
+	broker := &stopRejectingBroker{NewPaperBroker(10000)}
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+
+	om := NewOrderManager(broker, nil, nil, nil)
+
+	entry, err := om.CreateBracketOrder(context.Background(), "AAPL", models.OrderSideBuy, 10, 0, 110.0, 90.0)
+	require.NoError(t, err)
+
+	exits, total, err := om.GetOrders(OrderFilter{ParentID: entry.ID})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	assert.Equal(t, models.OrderTypeLimit, exits[0].Type)
+	assert.Equal(t, models.OrderStatusCancelled, exits[0].Status)
+}
+
+// TestOrderManager_CreateBracketOrder_Persisted verifies pending exits and
+// the links between placed exits survive a restart.
+func TestOrderManager_CreateBracketOrder_Persisted(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	store := data.NewOrderStore(db)
+
+	broker := NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+
+	om := NewOrderManager(broker, nil, store, nil)
+	entry, err := om.CreateBracketOrder(context.Background(), "AAPL", models.OrderSideBuy, 10, 95.0, 110.0, 90.0)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusPending, entry.Status)
+
+	// A restarted manager still places the exits when the entry fills
+	restarted := NewOrderManager(broker, nil, store, nil)
+	require.NoError(t, restarted.LoadOrders())
+	broker.SetPrice("AAPL", 95.0)
+
+	_, total, err := restarted.GetOrders(OrderFilter{ParentID: entry.ID, Status: models.OrderStatusPending})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+
+	// And a second restart keeps the exits linked to the entry and each other
+	reloaded := NewOrderManager(broker, nil, store, nil)
+	require.NoError(t, reloaded.LoadOrders())
+	exits, total, err := reloaded.GetOrders(OrderFilter{ParentID: entry.ID})
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	assert.Equal(t, exits[1].ID, exits[0].LinkedOrderID)
+	assert.Equal(t, exits[0].ID, exits[1].LinkedOrderID)
+
+	_, err = store.GetSystemConfig(PendingBracketsKey)
+	require.NoError(t, err)
+	assert.Empty(t, loadBrackets(store), "placed exits are no longer pending")
+}
+
+// TestOrderManager_CreateBracketOrder_Validation verifies bracket price ordering checks.
+func TestOrderManager_CreateBracketOrder_Validation(t *testing.T) {
+	broker := NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+
+	om := NewOrderManager(broker, nil, nil, nil)
+
+	_, err := om.CreateBracketOrder(context.Background(), "AAPL", models.OrderSideBuy, 10, 0, 90.0, 110.0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "stop-loss")
+
+	_, err = om.CreateBracketOrder(context.Background(), "AAPL", models.OrderSideSell, 10, 100.0, 90.0, 95.0)
+	assert.Error(t, err)
+
+	orders, _ := om.GetAllOrders()
+	assert.Empty(t, orders)
+}
+
+// tradeCountingBroker is a PaperBroker that counts full trade listings.
+type tradeCountingBroker struct {
+	*PaperBroker
+	listings int
+}
+
+func (b *tradeCountingBroker) GetTrades() ([]models.Trade, error) {
+	b.listings++
+	return b.PaperBroker.GetTrades()
+}
+
+// TestOrderManager_RecordTrades_ByOrder verifies fills are recorded from the
+// order's own trades, without listing every trade the broker has.
+func TestOrderManager_RecordTrades_ByOrder(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	store := data.NewOrderStore(db)
+
+	broker := &tradeCountingBroker{PaperBroker: NewPaperBroker(10000)}
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100)
+	om := NewOrderManager(broker, nil, store, nil)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_, err := om.CreateMarketOrder(ctx, "AAPL", models.OrderSideBuy, 1)
+		require.NoError(t, err)
+	}
+
+	trades, err := store.GetAllTrades()
+	require.NoError(t, err)
+	assert.Len(t, trades, 3)
+	assert.Zero(t, broker.listings)
+}
@@ -0,0 +1,81 @@
+// Package execution provides journal notes on orders.
+package execution
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+var (
+	// ErrNotesUnsupported is returned when there is no order store to persist
+	// notes.
+	ErrNotesUnsupported = errors.New("order store does not support notes")
+	// ErrOrderNotFound is returned when a note references an order that is
+	// not in the store.
+	ErrOrderNotFound = errors.New("order not found")
+)
+
+// AddOrderNote attaches a journal note to a stored order.
+//
+// Args:
+//   - orderID: ID of the order to annotate
+//   - text: Note body; surrounding whitespace is trimmed
+//
+// Returns:
+//   - *models.OrderNote: The saved note
+//   - error: ErrNotesUnsupported, ErrOrderNotFound, or a store error
+func (om *OrderManager) AddOrderNote(orderID, text string) (*models.OrderNote, error) {
+	if om.store == nil {
+		return nil, ErrNotesUnsupported
+	}
+	if err := om.requireStoredOrder(orderID); err != nil {
+		return nil, err
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("%w: note text is required", ErrOrderInvalid)
+	}
+	return om.store.SaveOrderNote(models.OrderNote{
+		OrderID:   orderID,
+		Text:      text,
+		CreatedAt: time.Now(),
+	})
+}
+
+// GetOrderNotes lists the journal notes on a stored order.
+//
+// Args:
+//   - orderID: ID of the order
+//
+// Returns:
+//   - []models.OrderNote: The order's notes, oldest first
+//   - error: ErrNotesUnsupported, ErrOrderNotFound, or a store error
+func (om *OrderManager) GetOrderNotes(orderID string) ([]models.OrderNote, error) {
+	if om.store == nil {
+		return nil, ErrNotesUnsupported
+	}
+	if err := om.requireStoredOrder(orderID); err != nil {
+		return nil, err
+	}
+	return om.store.GetOrderNotes(orderID)
+}
+
+// requireStoredOrder checks an order exists in the store. Only a missing
+// row is ErrOrderNotFound; other store failures are returned wrapped so
+// callers can tell them apart. Callers must check om.store is set.
+func (om *OrderManager) requireStoredOrder(orderID string) error {
+	_, err := om.store.GetOrder(orderID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("%w: %s", ErrOrderNotFound, orderID)
+	case err != nil:
+		return fmt.Errorf("failed to look up order %s: %w", orderID, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOrderManager_OrderNotes verifies notes attach only to stored orders and
+// are trimmed.
+func TestOrderManager_OrderNotes(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	broker := NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100)
+	om := NewOrderManager(broker, nil, data.NewOrderStore(db), nil)
+	order, err := om.CreateMarketOrder(context.Background(), "AAPL", models.OrderSideBuy, 1)
+	require.NoError(t, err)
+
+	note, err := om.AddOrderNote(order.ID, "  earnings tomorrow \n")
+	require.NoError(t, err)
+	assert.Equal(t, "earnings tomorrow", note.Text)
+
+	_, err = om.AddOrderNote(order.ID, " ")
+	assert.ErrorIs(t, err, ErrOrderInvalid)
+
+	_, err = om.AddOrderNote("missing", "note")
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+	_, err = om.GetOrderNotes("missing")
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+
+	notes, err := om.GetOrderNotes(order.ID)
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	assert.Equal(t, note.ID, notes[0].ID)
+}
+
+// TestOrderManager_OrderNotes_NoStore verifies notes need a store.
+func TestOrderManager_OrderNotes_NoStore(t *testing.T) {
+	om := NewOrderManager(NewPaperBroker(10000), nil, nil, nil)
+
+	_, err := om.AddOrderNote("order-1", "note")
+	assert.ErrorIs(t, err, ErrNotesUnsupported)
+}
+
+// failingLookupStore fails every order lookup with a store error.
+type failingLookupStore struct {
+	OrderStore
+}
+
+func (s failingLookupStore) GetOrder(orderID string) (*models.Order, error) {
+	return nil, errors.New("database is locked")
+}
+
+// TestOrderManager_OrderNotes_StoreError verifies a failed lookup is not
+// reported as a missing order.
+func TestOrderManager_OrderNotes_StoreError(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	om := NewOrderManager(NewPaperBroker(10000), nil, failingLookupStore{data.NewOrderStore(db)}, nil)
+
+	_, err = om.AddOrderNote("order-1", "note")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrOrderNotFound)
+	assert.ErrorContains(t, err, "database is locked")
+
+	_, err = om.GetOrderNotes("order-1")
+	assert.NotErrorIs(t, err, ErrOrderNotFound)
+}
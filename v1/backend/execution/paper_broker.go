@@ -3,13 +3,38 @@ package execution
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/alexherrero/sherwood/backend/data"
 	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/utils/ids"
 	"github.com/rs/zerolog/log"
 )
 
+// DefaultPriceCacheTTL is how long a price fetched from the price provider
+// is reused before it is fetched again.
+const DefaultPriceCacheTTL = 5 * time.Second
+
+// fetchedPrice is a price fetched from the price provider.
+type fetchedPrice struct {
+	price     float64
+	fetchedAt time.Time
+}
+
+// PaperFillOptions configures the synthetic order book market-priced paper
+// fills walk through. The zero value fills at the latest price.
+type PaperFillOptions struct {
+	// SpreadBps is the full bid-ask spread in basis points around the latest
+	// price. Buys fill from the ask (half the spread above), sells from the bid.
+	SpreadBps float64
+	// DepthImpact is the fractional price move per unit of notional consumed
+	// from the book. Liquidity thins linearly, so an order of notional N fills
+	// at an average of top-of-book moved by DepthImpact*N/2.
+	DepthImpact float64
+}
+
 // PaperBroker simulates a broker for paper trading.
 // No real money is at risk - all trades are simulated.
 type PaperBroker struct {
@@ -18,9 +43,19 @@ type PaperBroker struct {
 	balance      models.Balance
 	positions    map[string]models.Position
 	orders       map[string]models.Order
-	orderCounter int
+	ids          ids.Generator // Order ID source
 	mu           sync.RWMutex
 	latestPrices map[string]float64
+	onUpdate     OrderUpdateHandler
+	now          func() time.Time   // Clock for order timestamps and DAY expiry
+	fill         PaperFillOptions   // Synthetic order book for market-priced fills
+	fees         FeeSchedule        // Commission charged on each fill
+	commissions  map[string]float64 // Fee paid per filled order ID
+
+	// Optional live pricing for symbols with no SetPrice price
+	priceProvider data.DataProvider
+	priceTTL      time.Duration
+	fetched       map[string]fetchedPrice
 }
 
 // NewPaperBroker creates a new paper trading broker.
@@ -43,8 +78,11 @@ func NewPaperBroker(initialCash float64) *PaperBroker {
 		},
 		positions:    make(map[string]models.Position),
 		orders:       make(map[string]models.Order),
-		orderCounter: 0,
+		ids:          ids.NewTimeOrdered("paper-"),
 		latestPrices: make(map[string]float64),
+		commissions:  make(map[string]float64),
+		now:          time.Now,
+		fetched:      make(map[string]fetchedPrice),
 	}
 }
 
@@ -78,104 +116,333 @@ func (b *PaperBroker) IsConnected() bool {
 	return b.connected
 }
 
+// Reset restores the account to a fresh state: cash is set to initialCash
+// and all positions and orders are discarded. Prices, fill options and the
+// price provider are kept.
+//
+// Args:
+//   - initialCash: Starting cash balance after the reset
+func (b *PaperBroker) Reset(initialCash float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.balance = models.Balance{
+		Cash:           initialCash,
+		Equity:         initialCash,
+		BuyingPower:    initialCash,
+		PortfolioValue: initialCash,
+		UpdatedAt:      b.now(),
+	}
+	b.positions = make(map[string]models.Position)
+	b.orders = make(map[string]models.Order)
+	b.commissions = make(map[string]float64)
+	log.Info().Float64("initial_cash", initialCash).Msg("Paper broker reset")
+}
+
 // SetPrice sets the latest price for a symbol (for simulation).
+// DAY orders left over from a previous day are cancelled first. Resting limit
+// and stop orders for the symbol whose trigger price is crossed are then
+// filled, and their one-cancels-other siblings are cancelled. Any orders
+// changed this way are reported to the registered OrderUpdateHandler.
 //
 // Args:
 //   - symbol: Ticker symbol
 //   - price: Current price
 func (b *PaperBroker) SetPrice(symbol string, price float64) {
 	b.mu.Lock()
-	defer b.mu.Unlock()
 	b.latestPrices[symbol] = price
+	updates := b.expireDayOrders(b.now())
+	updates = append(updates, b.processRestingOrders(symbol, price)...)
+	handler := b.onUpdate
+	b.mu.Unlock()
+
+	// Notify outside the lock so handlers may call back into the broker
+	if handler != nil {
+		for _, order := range updates {
+			handler(order)
+		}
+	}
 }
 
-// PlaceOrder simulates order execution.
-func (b *PaperBroker) PlaceOrder(order models.Order) (*models.Order, error) {
+// LatestPrice returns the last price set for a symbol, or else the last
+// price fetched from the price provider.
+func (b *PaperBroker) LatestPrice(symbol string) (float64, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.currentPrice(symbol)
+}
+
+// SetPriceProvider backs pricing with a data provider. When an order is placed
+// for a symbol with no SetPrice price, the latest price is fetched and reused
+// for ttl. Prices set with SetPrice always take precedence.
+//
+// Args:
+//   - provider: Source of latest prices (nil disables fetching)
+//   - ttl: How long a fetched price is reused (<= 0 uses DefaultPriceCacheTTL)
+func (b *PaperBroker) SetPriceProvider(provider data.DataProvider, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultPriceCacheTTL
+	}
 	b.mu.Lock()
 	defer b.mu.Unlock()
+	b.priceProvider = provider
+	b.priceTTL = ttl
+	b.fetched = make(map[string]fetchedPrice)
+}
 
-	if !b.connected {
-		return nil, fmt.Errorf("broker not connected")
+// currentPrice returns the SetPrice price for a symbol, falling back to the
+// last fetched price. Must be called with b.mu held.
+func (b *PaperBroker) currentPrice(symbol string) (float64, bool) {
+	if price, ok := b.latestPrices[symbol]; ok {
+		return price, true
 	}
+	if fetched, ok := b.fetched[symbol]; ok {
+		return fetched.price, true
+	}
+	return 0, false
+}
 
-	// Generate order ID
-	b.orderCounter++
-	order.ID = fmt.Sprintf("paper-%06d", b.orderCounter)
-	order.CreatedAt = time.Now()
-	order.UpdatedAt = time.Now()
-	order.Status = models.OrderStatusSubmitted
+// refreshPrice fetches the latest price from the price provider when the
+// symbol has no SetPrice price and no fresh fetched price. The fetch runs
+// without holding b.mu so a slow provider doesn't block the broker.
+//
+// Returns:
+//   - error: If the fetch fails (nil when no fetch is needed)
+func (b *PaperBroker) refreshPrice(symbol string) error {
+	b.mu.RLock()
+	provider := b.priceProvider
+	_, manual := b.latestPrices[symbol]
+	fetched, cached := b.fetched[symbol]
+	fresh := cached && b.now().Sub(fetched.fetchedAt) < b.priceTTL
+	b.mu.RUnlock()
+
+	if provider == nil || manual || fresh {
+		return nil
+	}
 
-	// Determine execution price and fill status
-	var executionPrice float64
-	shouldFill := false
+	price, err := provider.GetLatestPrice(symbol)
+	if err != nil {
+		return err
+	}
+	if price <= 0 {
+		return fmt.Errorf("invalid price %g", price)
+	}
 
-	if order.Type == models.OrderTypeMarket {
-		if latestPrice, ok := b.latestPrices[order.Symbol]; ok {
-			executionPrice = latestPrice
-			shouldFill = true
-		} else {
-			return nil, fmt.Errorf("no price available for %s", order.Symbol)
+	b.mu.Lock()
+	b.fetched[symbol] = fetchedPrice{price: price, fetchedAt: b.now()}
+	b.mu.Unlock()
+	return nil
+}
+
+// SetIDGenerator replaces the source of order IDs, e.g. with a deterministic
+// generator in tests.
+//
+// Args:
+//   - gen: ID generator for new orders
+func (b *PaperBroker) SetIDGenerator(gen ids.Generator) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.ids = gen
+}
+
+// SetFillOptions configures the synthetic order book used to price market
+// and triggered stop orders. Limit orders still fill at their limit price.
+//
+// Args:
+//   - opts: Spread and depth impact (zero value disables)
+func (b *PaperBroker) SetFillOptions(opts PaperFillOptions) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.fill = opts
+}
+
+// SetFeeSchedule configures the commission charged on each fill. Fees are
+// deducted from cash and reported on the order's trade.
+//
+// Args:
+//   - fees: Fee schedule (zero value charges nothing)
+func (b *PaperBroker) SetFeeSchedule(fees FeeSchedule) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.fees = fees
+}
+
+// bookPrice returns the volume-weighted average price for filling quantity
+// against the synthetic order book around the latest price. Buys pay the
+// ask plus depth impact and sells receive the bid minus it; the price never
+// goes below zero.
+func (o PaperFillOptions) bookPrice(side models.OrderSide, quantity, price float64) float64 {
+	if o.SpreadBps <= 0 && o.DepthImpact <= 0 {
+		return price
+	}
+
+	halfSpread := price * o.SpreadBps / 10000 / 2
+	top := price + halfSpread
+	if side == models.OrderSideSell {
+		top = price - halfSpread
+	}
+
+	// Walking a linearly thinning book moves the price by DepthImpact per
+	// unit of notional, so the average fill is half the final move.
+	move := top * o.DepthImpact * (quantity * top) / 2
+	if side == models.OrderSideSell {
+		return max(top-move, 0)
+	}
+	return top + move
+}
+
+// SetOrderUpdateHandler registers a callback for fills and cancellations
+// triggered by price updates.
+func (b *PaperBroker) SetOrderUpdateHandler(handler OrderUpdateHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onUpdate = handler
+}
+
+// expireDayOrders cancels pending DAY orders created before the current
+// calendar day. Must be called with b.mu held.
+//
+// Returns:
+//   - []models.Order: Orders that were cancelled
+func (b *PaperBroker) expireDayOrders(now time.Time) []models.Order {
+	var ids []string
+	for id, order := range b.orders {
+		if order.TimeInForce == models.TimeInForceDay &&
+			order.Status == models.OrderStatusPending &&
+			!sameDay(order.CreatedAt, now) {
+			ids = append(ids, id)
 		}
-	} else if order.Type == models.OrderTypeLimit {
-		// Check against latest price if available
-		latestPrice, hasPrice := b.latestPrices[order.Symbol]
+	}
+	sort.Strings(ids)
+
+	updates := make([]models.Order, 0, len(ids))
+	for _, id := range ids {
+		order := b.orders[id]
+		order.Status = models.OrderStatusCancelled
+		order.UpdatedAt = now
+		b.orders[id] = order
+		updates = append(updates, order)
+
+		log.Info().
+			Str("order_id", id).
+			Str("symbol", order.Symbol).
+			Msg("DAY order expired")
+	}
+	return updates
+}
 
-		if !hasPrice {
-			// If no price, assume pending
-			shouldFill = false
-		} else {
-			if order.Side == models.OrderSideBuy {
-				// Buy limit: fill if market price <= limit price
-				if latestPrice <= order.Price {
-					executionPrice = order.Price // Fill at limit price (pessimistic) or market?
-					// Paper trading convention: fill at limit or better.
-					// Let's use limit price for simplicity or latestPrice?
-					// Using limit price guarantees price.
-					executionPrice = order.Price
-					shouldFill = true
-				}
-			} else {
-				// Sell limit: fill if market price >= limit price
-				if latestPrice >= order.Price {
-					executionPrice = order.Price
-					shouldFill = true
-				}
-			}
+// sameDay reports whether two times fall on the same calendar day in now's location.
+func sameDay(t, now time.Time) bool {
+	y1, m1, d1 := t.In(now.Location()).Date()
+	y2, m2, d2 := now.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// processRestingOrders fills pending orders for a symbol whose trigger price
+// has been crossed. Must be called with b.mu held.
+//
+// Returns:
+//   - []models.Order: Orders whose status changed
+func (b *PaperBroker) processRestingOrders(symbol string, price float64) []models.Order {
+	// Collect and sort candidates so fills are deterministic (oldest first)
+	var ids []string
+	for id, order := range b.orders {
+		if order.Symbol == symbol && order.Status == models.OrderStatusPending {
+			ids = append(ids, id)
 		}
 	}
+	sort.Strings(ids)
+
+	var updates []models.Order
+	for _, id := range ids {
+		order := b.orders[id]
+		// A sibling fill earlier in this pass may have cancelled the order
+		if order.Status != models.OrderStatusPending {
+			continue
+		}
 
-	// Just return pending if not filled
-	if !shouldFill {
-		order.Status = models.OrderStatusPending
+		executionPrice, ok := triggerPrice(order, price)
+		if !ok {
+			continue
+		}
+		if order.Type != models.OrderTypeLimit {
+			executionPrice = b.fill.bookPrice(order.Side, order.Quantity, executionPrice)
+		}
+
+		if err := b.fillOrder(&order, executionPrice); err != nil {
+			log.Warn().Err(err).Str("order_id", order.ID).Msg("Resting paper order rejected")
+		}
 		b.orders[order.ID] = order
-		return &order, nil
+		updates = append(updates, order)
+
+		if order.Status == models.OrderStatusFilled {
+			if sibling, ok := b.cancelLinked(order); ok {
+				updates = append(updates, sibling)
+			}
+		}
 	}
+	return updates
+}
 
-	// Check buying power (only if filling)
+// triggerPrice reports whether an order is executable at the given market
+// price and, if so, the price it executes at.
+//
+// Market orders execute at the market price. Limit orders execute at their
+// limit price once the market reaches it. Stop orders trigger once the market
+// crosses the stop price and execute at the market price.
+func triggerPrice(order models.Order, marketPrice float64) (float64, bool) {
+	switch order.Type {
+	case models.OrderTypeMarket:
+		return marketPrice, true
+	case models.OrderTypeLimit:
+		if order.Side == models.OrderSideBuy && marketPrice <= order.Price {
+			return order.Price, true
+		}
+		if order.Side == models.OrderSideSell && marketPrice >= order.Price {
+			return order.Price, true
+		}
+	case models.OrderTypeStop:
+		if order.Side == models.OrderSideBuy && marketPrice >= order.Price {
+			return marketPrice, true
+		}
+		if order.Side == models.OrderSideSell && marketPrice <= order.Price {
+			return marketPrice, true
+		}
+	}
+	return 0, false
+}
+
+// fillOrder executes an order at the given price, updating positions and
+// balance and charging the fee schedule. Buys whose cost plus fee exceed
+// buying power are marked rejected.
+// Must be called with b.mu held.
+func (b *PaperBroker) fillOrder(order *models.Order, executionPrice float64) error {
+	fee := b.fees.Fee(executionPrice * order.Quantity)
 	if order.Side == models.OrderSideBuy {
-		cost := executionPrice * order.Quantity
+		cost := executionPrice*order.Quantity + fee
 		if cost > b.balance.BuyingPower {
 			order.Status = models.OrderStatusRejected
-			b.orders[order.ID] = order
-			return &order, fmt.Errorf("insufficient buying power: need %.2f, have %.2f",
+			order.UpdatedAt = time.Now()
+			return fmt.Errorf("insufficient buying power: need %.2f, have %.2f",
 				cost, b.balance.BuyingPower)
 		}
 	}
 
-	// Execute fill
 	order.Status = models.OrderStatusFilled
 	order.FilledQuantity = order.Quantity
 	order.AveragePrice = executionPrice
 	order.UpdatedAt = time.Now()
 
-	// Update positions
 	if order.Side == models.OrderSideBuy {
 		b.executeBuy(order.Symbol, order.Quantity, executionPrice)
 	} else {
 		b.executeSell(order.Symbol, order.Quantity, executionPrice)
 	}
-
-	b.orders[order.ID] = order
+	if fee > 0 {
+		b.balance.Cash -= fee
+		b.balance.BuyingPower -= fee
+		b.commissions[order.ID] = fee
+	}
 
 	log.Info().
 		Str("order_id", order.ID).
@@ -183,11 +450,152 @@ func (b *PaperBroker) PlaceOrder(order models.Order) (*models.Order, error) {
 		Str("side", string(order.Side)).
 		Float64("quantity", order.Quantity).
 		Float64("price", executionPrice).
+		Float64("commission", fee).
 		Msg("Paper order executed")
 
+	return nil
+}
+
+// cancelLinked cancels the one-cancels-other sibling of a filled order if it
+// is still open. Must be called with b.mu held.
+//
+// Returns:
+//   - models.Order: The cancelled sibling
+//   - bool: True if a sibling was cancelled
+func (b *PaperBroker) cancelLinked(order models.Order) (models.Order, bool) {
+	if order.LinkedOrderID == "" {
+		return models.Order{}, false
+	}
+	sibling, exists := b.orders[order.LinkedOrderID]
+	if !exists {
+		return models.Order{}, false
+	}
+	if sibling.Status != models.OrderStatusPending && sibling.Status != models.OrderStatusSubmitted {
+		return models.Order{}, false
+	}
+
+	sibling.Status = models.OrderStatusCancelled
+	sibling.UpdatedAt = time.Now()
+	b.orders[sibling.ID] = sibling
+
+	log.Info().
+		Str("order_id", sibling.ID).
+		Str("filled_order_id", order.ID).
+		Msg("OCO sibling cancelled")
+
+	return sibling, true
+}
+
+// PlaceOrder simulates order execution.
+func (b *PaperBroker) PlaceOrder(order models.Order) (*models.Order, error) {
+	// A failed fetch only matters when no stale price is left to fall back on
+	fetchErr := b.refreshPrice(order.Symbol)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.connected {
+		return nil, fmt.Errorf("%w: broker not connected", ErrBrokerUnavailable)
+	}
+
+	// Generate order ID
+	order.ID = b.ids.NewID()
+	order.CreatedAt = b.now()
+	order.UpdatedAt = order.CreatedAt
+	order.Status = models.OrderStatusSubmitted
+	if order.TimeInForce == "" {
+		order.TimeInForce = models.TimeInForceGTC
+	}
+
+	// Link one-cancels-other siblings in both directions. If the sibling
+	// has already filled, this order is dead on arrival.
+	if order.LinkedOrderID != "" {
+		if sibling, exists := b.orders[order.LinkedOrderID]; exists {
+			if sibling.Status == models.OrderStatusFilled {
+				order.Status = models.OrderStatusCancelled
+				b.orders[order.ID] = order
+				return &order, nil
+			}
+			sibling.LinkedOrderID = order.ID
+			b.orders[sibling.ID] = sibling
+		}
+	}
+
+	// Determine execution price and fill status
+	latestPrice, hasPrice := b.currentPrice(order.Symbol)
+	if order.Type == models.OrderTypeMarket && !hasPrice {
+		if fetchErr != nil {
+			return nil, fmt.Errorf("no price available for %s: %w", order.Symbol, fetchErr)
+		}
+		return nil, fmt.Errorf("no price available for %s", order.Symbol)
+	}
+
+	executionPrice, shouldFill := 0.0, false
+	if hasPrice {
+		executionPrice, shouldFill = triggerPrice(order, latestPrice)
+	}
+
+	// IOC orders that cannot fill now are cancelled rather than resting.
+	// Paper fills are all-or-nothing, so there is no partial remainder.
+	if !shouldFill && order.TimeInForce == models.TimeInForceIOC {
+		order.Status = models.OrderStatusCancelled
+		b.orders[order.ID] = order
+		log.Info().Str("order_id", order.ID).Msg("IOC order not fillable, cancelled")
+		return &order, nil
+	}
+
+	// Just return pending if not filled
+	if !shouldFill {
+		order.Status = models.OrderStatusPending
+		b.orders[order.ID] = order
+		return &order, nil
+	}
+
+	// Market and stop orders take liquidity from the synthetic book
+	if order.Type != models.OrderTypeLimit {
+		executionPrice = b.fill.bookPrice(order.Side, order.Quantity, executionPrice)
+	}
+
+	err := b.fillOrder(&order, executionPrice)
+	b.orders[order.ID] = order
+	if err != nil {
+		return &order, err
+	}
+
+	b.cancelLinked(order)
+
 	return &order, nil
 }
 
+// ImportPositions sets positions held before paper trading began, replacing
+// any held for their symbols. Cash is left alone. Each position is valued at
+// the symbol's latest price, or at its average cost until a price is known.
+//
+// Args:
+//   - positions: Positions by symbol, quantity and average cost
+func (b *PaperBroker) ImportPositions(positions []models.Position) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, pos := range positions {
+		price, ok := b.currentPrice(pos.Symbol)
+		if !ok || price <= 0 {
+			price = pos.AverageCost
+		}
+		b.positions[pos.Symbol] = models.Position{
+			Symbol:        pos.Symbol,
+			Quantity:      pos.Quantity,
+			AverageCost:   pos.AverageCost,
+			CurrentPrice:  price,
+			MarketValue:   pos.Quantity * price,
+			UnrealizedPL:  pos.Quantity * (price - pos.AverageCost),
+			QuoteCurrency: data.QuoteCurrency(pos.Symbol),
+			UpdatedAt:     b.now(),
+		}
+	}
+	log.Info().Int("positions", len(positions)).Msg("Paper broker positions imported")
+}
+
 // executeBuy updates positions and balance for a buy order.
 func (b *PaperBroker) executeBuy(symbol string, quantity, price float64) {
 	cost := quantity * price
@@ -206,9 +614,10 @@ func (b *PaperBroker) executeBuy(symbol string, quantity, price float64) {
 		pos.Quantity = totalQty
 	} else {
 		pos = models.Position{
-			Symbol:      symbol,
-			Quantity:    quantity,
-			AverageCost: price,
+			Symbol:        symbol,
+			Quantity:      quantity,
+			AverageCost:   price,
+			QuoteCurrency: data.QuoteCurrency(symbol),
 		}
 	}
 	pos.CurrentPrice = price
@@ -275,6 +684,21 @@ func (b *PaperBroker) GetOrder(orderID string) (*models.Order, error) {
 	return &order, nil
 }
 
+// ListOrders retrieves all orders, oldest first.
+func (b *PaperBroker) ListOrders() ([]models.Order, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	orders := make([]models.Order, 0, len(b.orders))
+	for _, order := range b.orders {
+		orders = append(orders, order)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].ID < orders[j].ID
+	})
+	return orders, nil
+}
+
 // GetPositions retrieves all current positions.
 func (b *PaperBroker) GetPositions() ([]models.Position, error) {
 	b.mu.RLock()
@@ -313,23 +737,44 @@ func (b *PaperBroker) GetTrades() ([]models.Trade, error) {
 
 	var trades []models.Trade
 	for _, order := range b.orders {
-		if order.Status == models.OrderStatusFilled {
-			// In paper trading, we assume 1 order = 1 trade for simplicity
-			trades = append(trades, models.Trade{
-				ID:         "trade-" + order.ID,
-				OrderID:    order.ID,
-				Symbol:     order.Symbol,
-				Side:       order.Side,
-				Quantity:   order.FilledQuantity,
-				Price:      order.AveragePrice,
-				ExecutedAt: order.UpdatedAt,
-			})
+		if trade, ok := b.orderTrade(order); ok {
+			trades = append(trades, trade)
 		}
 	}
 	return trades, nil
 }
 
-// ModifyOrder updates an existing open order.
+// GetOrderTrades retrieves the trades executed for one order.
+func (b *PaperBroker) GetOrderTrades(orderID string) ([]models.Trade, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	trades := []models.Trade{}
+	if trade, ok := b.orderTrade(b.orders[orderID]); ok {
+		trades = append(trades, trade)
+	}
+	return trades, nil
+}
+
+// orderTrade returns the trade for a filled order. In paper trading, we
+// assume 1 order = 1 trade for simplicity. The caller must hold mu.
+func (b *PaperBroker) orderTrade(order models.Order) (models.Trade, bool) {
+	if order.Status != models.OrderStatusFilled {
+		return models.Trade{}, false
+	}
+	return models.Trade{
+		ID:         "trade-" + order.ID,
+		OrderID:    order.ID,
+		Symbol:     order.Symbol,
+		Side:       order.Side,
+		Quantity:   order.FilledQuantity,
+		Price:      order.AveragePrice,
+		Commission: b.commissions[order.ID],
+		ExecutedAt: order.UpdatedAt,
+	}, true
+}
+
+// ModifyOrder updates an existing open order using ApplyOrderModification.
 func (b *PaperBroker) ModifyOrder(orderID string, newPrice, newQuantity float64) (*models.Order, error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
@@ -339,21 +784,9 @@ func (b *PaperBroker) ModifyOrder(orderID string, newPrice, newQuantity float64)
 		return nil, fmt.Errorf("order not found: %s", orderID)
 	}
 
-	// Only pending or submitted orders can be modified
-	if order.Status != models.OrderStatusPending && order.Status != models.OrderStatusSubmitted {
-		return nil, fmt.Errorf("cannot modify order in status: %s", order.Status)
-	}
-
-	// Update fields if provided
-	if newPrice > 0 {
-		if order.Type == models.OrderTypeMarket {
-			return nil, fmt.Errorf("cannot set price for market order")
-		}
-		order.Price = newPrice
-	}
-
-	if newQuantity > 0 {
-		order.Quantity = newQuantity
+	order, err := ApplyOrderModification(order, newPrice, newQuantity)
+	if err != nil {
+		return nil, err
 	}
 
 	order.UpdatedAt = time.Now()
@@ -363,6 +796,7 @@ func (b *PaperBroker) ModifyOrder(orderID string, newPrice, newQuantity float64)
 		Str("order_id", orderID).
 		Float64("new_price", order.Price).
 		Float64("new_quantity", order.Quantity).
+		Str("status", string(order.Status)).
 		Msg("Order modified")
 
 	return &order, nil
@@ -1,9 +1,13 @@
 package execution
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/utils/ids"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -309,7 +313,7 @@ func TestPaperBroker_GetTrades(t *testing.T) {
 	broker.SetPrice("AAPL", 100.0)
 
 	// Create two trades
-	_, _ = broker.PlaceOrder(models.Order{
+	first, _ := broker.PlaceOrder(models.Order{
 		Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeMarket, Quantity: 5,
 	})
 	_, _ = broker.PlaceOrder(models.Order{
@@ -319,6 +323,17 @@ func TestPaperBroker_GetTrades(t *testing.T) {
 	trades, err := broker.GetTrades()
 	require.NoError(t, err)
 	assert.Len(t, trades, 2)
+
+	// One order's trades are looked up directly
+	trades, err = broker.GetOrderTrades(first.ID)
+	require.NoError(t, err)
+	require.Len(t, trades, 1)
+	assert.Equal(t, first.ID, trades[0].OrderID)
+	assert.Equal(t, 5.0, trades[0].Quantity)
+
+	trades, err = broker.GetOrderTrades("unknown")
+	require.NoError(t, err)
+	assert.Empty(t, trades)
 }
 
 // TestPaperBroker_MarketOrder_NoPrice verifies error when no price available.
@@ -338,3 +353,397 @@ func TestPaperBroker_MarketOrder_NoPrice(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "no price available")
 }
+
+// TestPaperBroker_SetPrice_FillsRestingOrders verifies limit and stop orders fill on price crossings.
+func TestPaperBroker_SetPrice_FillsRestingOrders(t *testing.T) {
+	broker := NewPaperBroker(10000.0)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+
+	var updates []models.Order
+	broker.SetOrderUpdateHandler(func(order models.Order) {
+		updates = append(updates, order)
+	})
+
+	limit, err := broker.PlaceOrder(models.Order{
+		Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeLimit, Quantity: 10, Price: 95.0,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusPending, limit.Status)
+
+	stop, err := broker.PlaceOrder(models.Order{
+		Symbol: "AAPL", Side: models.OrderSideSell, Type: models.OrderTypeStop, Quantity: 10, Price: 90.0,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusPending, stop.Status)
+
+	// Limit buy fills at its limit price once the market reaches it
+	broker.SetPrice("AAPL", 94.0)
+	filled, _ := broker.GetOrder(limit.ID)
+	assert.Equal(t, models.OrderStatusFilled, filled.Status)
+	assert.Equal(t, 95.0, filled.AveragePrice)
+	require.Len(t, updates, 1)
+
+	// Stop sell triggers below the stop and fills at market
+	broker.SetPrice("AAPL", 89.0)
+	triggered, _ := broker.GetOrder(stop.ID)
+	assert.Equal(t, models.OrderStatusFilled, triggered.Status)
+	assert.Equal(t, 89.0, triggered.AveragePrice)
+	assert.Len(t, updates, 2)
+}
+
+// TestPaperBroker_LinkedOrders_CancelSibling verifies one-cancels-other linkage.
+func TestPaperBroker_LinkedOrders_CancelSibling(t *testing.T) {
+	broker := NewPaperBroker(10000.0)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+
+	tp, err := broker.PlaceOrder(models.Order{
+		Symbol: "AAPL", Side: models.OrderSideSell, Type: models.OrderTypeLimit, Quantity: 5, Price: 110.0,
+	})
+	require.NoError(t, err)
+	sl, err := broker.PlaceOrder(models.Order{
+		Symbol: "AAPL", Side: models.OrderSideSell, Type: models.OrderTypeStop, Quantity: 5, Price: 90.0,
+		LinkedOrderID: tp.ID,
+	})
+	require.NoError(t, err)
+
+	// Link is established in both directions
+	linked, _ := broker.GetOrder(tp.ID)
+	assert.Equal(t, sl.ID, linked.LinkedOrderID)
+
+	broker.SetPrice("AAPL", 112.0)
+
+	tpAfter, _ := broker.GetOrder(tp.ID)
+	slAfter, _ := broker.GetOrder(sl.ID)
+	assert.Equal(t, models.OrderStatusFilled, tpAfter.Status)
+	assert.Equal(t, models.OrderStatusCancelled, slAfter.Status)
+}
+
+// TestPaperBroker_TimeInForce_DefaultsToGTC verifies unspecified orders rest
+// across days.
+func TestPaperBroker_TimeInForce_DefaultsToGTC(t *testing.T) {
+	broker := NewPaperBroker(10000.0)
+	require.NoError(t, broker.Connect())
+	now := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	broker.now = func() time.Time { return now }
+	broker.SetPrice("AAPL", 100.0)
+
+	order, err := broker.PlaceOrder(models.Order{
+		Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeLimit, Quantity: 1, Price: 90.0,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.TimeInForceGTC, order.TimeInForce)
+
+	now = now.AddDate(0, 0, 3)
+	broker.SetPrice("AAPL", 99.0)
+	resting, _ := broker.GetOrder(order.ID)
+	assert.Equal(t, models.OrderStatusPending, resting.Status)
+}
+
+// TestPaperBroker_TimeInForce_DayExpires verifies DAY orders are cancelled on
+// the first price update of a new day.
+func TestPaperBroker_TimeInForce_DayExpires(t *testing.T) {
+	broker := NewPaperBroker(10000.0)
+	require.NoError(t, broker.Connect())
+	now := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	broker.now = func() time.Time { return now }
+	broker.SetPrice("AAPL", 100.0)
+	broker.SetPrice("MSFT", 300.0)
+
+	var updates []models.Order
+	broker.SetOrderUpdateHandler(func(order models.Order) {
+		updates = append(updates, order)
+	})
+
+	day, err := broker.PlaceOrder(models.Order{
+		Symbol: "MSFT", Side: models.OrderSideBuy, Type: models.OrderTypeLimit, Quantity: 1, Price: 250.0,
+		TimeInForce: models.TimeInForceDay,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusPending, day.Status)
+
+	// Same day: still resting
+	now = now.Add(time.Hour)
+	broker.SetPrice("AAPL", 101.0)
+	resting, _ := broker.GetOrder(day.ID)
+	assert.Equal(t, models.OrderStatusPending, resting.Status)
+	assert.Empty(t, updates)
+
+	// Next day: a price update for any symbol expires it
+	now = now.Add(12 * time.Hour)
+	broker.SetPrice("AAPL", 102.0)
+	expired, _ := broker.GetOrder(day.ID)
+	assert.Equal(t, models.OrderStatusCancelled, expired.Status)
+	require.Len(t, updates, 1)
+	assert.Equal(t, day.ID, updates[0].ID)
+}
+
+// TestPaperBroker_TimeInForce_IOC verifies IOC orders fill immediately or are cancelled.
+func TestPaperBroker_TimeInForce_IOC(t *testing.T) {
+	broker := NewPaperBroker(10000.0)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+
+	filled, err := broker.PlaceOrder(models.Order{
+		Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeLimit, Quantity: 1, Price: 105.0,
+		TimeInForce: models.TimeInForceIOC,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusFilled, filled.Status)
+
+	cancelled, err := broker.PlaceOrder(models.Order{
+		Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeLimit, Quantity: 1, Price: 95.0,
+		TimeInForce: models.TimeInForceIOC,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusCancelled, cancelled.Status)
+
+	// A later price cross does not revive it
+	broker.SetPrice("AAPL", 90.0)
+	after, _ := broker.GetOrder(cancelled.ID)
+	assert.Equal(t, models.OrderStatusCancelled, after.Status)
+}
+
+// TestPaperBroker_OrderIDs verifies orders get time-ordered IDs that survive
+// a restart, and that the ID generator can be injected.
+func TestPaperBroker_OrderIDs(t *testing.T) {
+	order := models.Order{Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeLimit, Quantity: 1, Price: 100}
+
+	first := NewPaperBroker(10000.0)
+	require.NoError(t, first.Connect())
+	a, err := first.PlaceOrder(order)
+	require.NoError(t, err)
+
+	// A new broker, as after a restart, must not reuse the ID
+	second := NewPaperBroker(10000.0)
+	require.NoError(t, second.Connect())
+	b, err := second.PlaceOrder(order)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(a.ID, "paper-"))
+	assert.NotEqual(t, a.ID, b.ID)
+	assert.Less(t, a.ID, b.ID)
+
+	second.SetIDGenerator(ids.NewSequential("paper-"))
+	c, err := second.PlaceOrder(order)
+	require.NoError(t, err)
+	assert.Equal(t, "paper-000001", c.ID)
+}
+
+// TestPaperBroker_FillOptions verifies market orders fill through the
+// synthetic order book while limit orders keep their limit price.
+func TestPaperBroker_FillOptions(t *testing.T) {
+	broker := NewPaperBroker(1000000.0)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+	broker.SetFillOptions(PaperFillOptions{SpreadBps: 10, DepthImpact: 1e-6})
+
+	place := func(side models.OrderSide, orderType models.OrderType, quantity, price float64) *models.Order {
+		order, err := broker.PlaceOrder(models.Order{
+			Symbol:   "AAPL",
+			Side:     side,
+			Type:     orderType,
+			Quantity: quantity,
+			Price:    price,
+		})
+		require.NoError(t, err)
+		require.Equal(t, models.OrderStatusFilled, order.Status)
+		return order
+	}
+
+	// Ask is 100.05; a small order barely moves the book
+	small := place(models.OrderSideBuy, models.OrderTypeMarket, 1, 0)
+	assert.InDelta(t, 100.05+100.05*1e-6*100.05/2, small.AveragePrice, 1e-9)
+
+	// A large order walks deeper into the book for a worse average
+	large := place(models.OrderSideBuy, models.OrderTypeMarket, 100, 0)
+	assert.InDelta(t, 100.05+100.05*1e-6*10005/2, large.AveragePrice, 1e-9)
+	assert.Greater(t, large.AveragePrice, small.AveragePrice)
+
+	// Sells fill from the bid, below the latest price
+	sell := place(models.OrderSideSell, models.OrderTypeMarket, 100, 0)
+	assert.InDelta(t, 99.95-99.95*1e-6*9995/2, sell.AveragePrice, 1e-9)
+
+	// Limit orders fill at their limit price
+	limit := place(models.OrderSideBuy, models.OrderTypeLimit, 100, 101)
+	assert.Equal(t, 101.0, limit.AveragePrice)
+
+	// The zero value fills at the latest price
+	broker.SetFillOptions(PaperFillOptions{})
+	flat := place(models.OrderSideBuy, models.OrderTypeMarket, 100, 0)
+	assert.Equal(t, 100.0, flat.AveragePrice)
+}
+
+// priceProvider is a data provider that serves a settable latest price.
+type priceProvider struct {
+	price float64
+	err   error
+	calls int
+}
+
+func (p *priceProvider) Name() string { return "price" }
+
+func (p *priceProvider) GetHistoricalData(string, time.Time, time.Time, string) ([]models.OHLCV, error) {
+	return nil, nil
+}
+
+func (p *priceProvider) GetLatestPrice(string) (float64, error) {
+	p.calls++
+	return p.price, p.err
+}
+
+func (p *priceProvider) GetTicker(string) (*models.Ticker, error) { return nil, nil }
+
+// TestPaperBroker_PriceProvider verifies market orders fetch a missing price,
+// reuse it briefly, and defer to prices set with SetPrice.
+func TestPaperBroker_PriceProvider(t *testing.T) {
+	broker := NewPaperBroker(100000.0)
+	require.NoError(t, broker.Connect())
+	now := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	broker.now = func() time.Time { return now }
+	provider := &priceProvider{price: 150.0}
+	broker.SetPriceProvider(provider, time.Minute)
+
+	buy := func(symbol string) (*models.Order, error) {
+		return broker.PlaceOrder(models.Order{
+			Symbol:   symbol,
+			Side:     models.OrderSideBuy,
+			Type:     models.OrderTypeMarket,
+			Quantity: 1,
+		})
+	}
+
+	order, err := buy("AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, 150.0, order.AveragePrice)
+	price, ok := broker.LatestPrice("AAPL")
+	assert.True(t, ok)
+	assert.Equal(t, 150.0, price)
+
+	// Cached within the TTL
+	provider.price = 155.0
+	order, err = buy("AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, 150.0, order.AveragePrice)
+	assert.Equal(t, 1, provider.calls)
+
+	// Refetched once stale
+	now = now.Add(2 * time.Minute)
+	order, err = buy("AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, 155.0, order.AveragePrice)
+	assert.Equal(t, 2, provider.calls)
+
+	// A failed refresh falls back to the stale price
+	now = now.Add(2 * time.Minute)
+	provider.err = fmt.Errorf("upstream unavailable")
+	order, err = buy("AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, 155.0, order.AveragePrice)
+
+	// With nothing cached, the fetch error is reported
+	_, err = buy("MSFT")
+	assert.ErrorContains(t, err, "no price available for MSFT: upstream unavailable")
+
+	// Manual prices take precedence and are never fetched
+	provider.err = nil
+	broker.SetPrice("AAPL", 100.0)
+	calls := provider.calls
+	order, err = buy("AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, order.AveragePrice)
+	assert.Equal(t, calls, provider.calls)
+}
+
+// TestPaperBroker_ModifyOrder_Quantity verifies modify-down rules against
+// pending, partially filled and filled orders.
+func TestPaperBroker_ModifyOrder_Quantity(t *testing.T) {
+	newBroker := func(t *testing.T) (*PaperBroker, *models.Order) {
+		broker := NewPaperBroker(10000.0)
+		require.NoError(t, broker.Connect())
+		order, err := broker.PlaceOrder(models.Order{
+			Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeLimit, Quantity: 10, Price: 145.0,
+		})
+		require.NoError(t, err)
+		require.Equal(t, models.OrderStatusPending, order.Status)
+		return broker, order
+	}
+	// Paper orders fill all at once, so simulate a partial fill directly
+	partiallyFill := func(broker *PaperBroker, orderID string, filled float64) {
+		broker.mu.Lock()
+		defer broker.mu.Unlock()
+		order := broker.orders[orderID]
+		order.Status = models.OrderStatusPartiallyFilled
+		order.FilledQuantity = filled
+		broker.orders[orderID] = order
+	}
+
+	t.Run("PendingReduced", func(t *testing.T) {
+		broker, order := newBroker(t)
+		modified, err := broker.ModifyOrder(order.ID, 0, 4)
+		require.NoError(t, err)
+		assert.Equal(t, 4.0, modified.Quantity)
+		assert.Equal(t, models.OrderStatusPending, modified.Status)
+		assert.Equal(t, 145.0, modified.Price)
+	})
+
+	t.Run("PartiallyFilledReduced", func(t *testing.T) {
+		broker, order := newBroker(t)
+		partiallyFill(broker, order.ID, 3)
+		modified, err := broker.ModifyOrder(order.ID, 0, 5)
+		require.NoError(t, err)
+		assert.Equal(t, 5.0, modified.Quantity)
+		assert.Equal(t, 3.0, modified.FilledQuantity)
+		assert.Equal(t, models.OrderStatusPartiallyFilled, modified.Status)
+	})
+
+	t.Run("PartiallyFilledReducedToFilledCancelsRemainder", func(t *testing.T) {
+		broker, order := newBroker(t)
+		partiallyFill(broker, order.ID, 3)
+		modified, err := broker.ModifyOrder(order.ID, 0, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 3.0, modified.Quantity)
+		assert.Equal(t, models.OrderStatusCancelled, modified.Status)
+
+		stored, err := broker.GetOrder(order.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.OrderStatusCancelled, stored.Status)
+	})
+
+	t.Run("PartiallyFilledBelowFilledRejected", func(t *testing.T) {
+		broker, order := newBroker(t)
+		partiallyFill(broker, order.ID, 3)
+		_, err := broker.ModifyOrder(order.ID, 0, 2)
+		require.ErrorIs(t, err, ErrQuantityBelowFilled)
+
+		stored, err := broker.GetOrder(order.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 10.0, stored.Quantity, "rejected changes leave the order untouched")
+	})
+
+	t.Run("FilledRejected", func(t *testing.T) {
+		broker := NewPaperBroker(10000.0)
+		require.NoError(t, broker.Connect())
+		broker.SetPrice("AAPL", 140.0)
+		order, err := broker.PlaceOrder(models.Order{
+			Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeLimit, Quantity: 10, Price: 145.0,
+		})
+		require.NoError(t, err)
+		require.Equal(t, models.OrderStatusFilled, order.Status)
+
+		_, err = broker.ModifyOrder(order.ID, 0, 5)
+		require.ErrorIs(t, err, ErrOrderNotModifiable)
+	})
+
+	t.Run("MarketOrderPriceRejected", func(t *testing.T) {
+		broker := NewPaperBroker(10000.0)
+		require.NoError(t, broker.Connect())
+		broker.mu.Lock()
+		broker.orders["mkt-1"] = models.Order{ID: "mkt-1", Type: models.OrderTypeMarket, Status: models.OrderStatusPending, Quantity: 1}
+		broker.mu.Unlock()
+
+		_, err := broker.ModifyOrder("mkt-1", 100, 0)
+		require.ErrorIs(t, err, ErrOrderInvalid)
+	})
+}
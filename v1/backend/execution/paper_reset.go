@@ -0,0 +1,158 @@
+// Package execution provides resetting of paper trading state.
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrResetUnsupported is returned when the broker cannot be reset, i.e. it
+// is not a paper broker.
+var ErrResetUnsupported = errors.New("broker does not support reset")
+
+// PaperResetter is an optional Broker capability for wiping simulated state.
+type PaperResetter interface {
+	Reset(initialCash float64)
+}
+
+// TradingHistoryClearer is an optional OrderStore capability for deleting
+// persisted orders, trades and positions.
+type TradingHistoryClearer interface {
+	ClearTradingHistory() error
+}
+
+// PaperReset describes a completed paper account reset.
+type PaperReset struct {
+	InitialCash    float64 `json:"initial_cash"`
+	HistoryCleared bool    `json:"history_cleared"`
+}
+
+// ResetPaper wipes the paper account: the broker's cash is set to
+// initialCash and its positions and orders are dropped, the order cache and
+// pending bracket exits are cleared, and the risk manager's daily counters
+// are reset. The new cash is stored as the initial capital. When
+// clearHistory is true, persisted orders, trades, positions, equity
+// snapshots, order notes and position imports are deleted as well.
+// Otherwise history is kept but made consistent with the empty account:
+// open orders are cancelled first and persisted positions are saved with
+// zero quantity. A "paper_reset" event is broadcast so connected clients
+// refresh.
+//
+// Args:
+//   - ctx: Context with audit information for the cancellations
+//   - initialCash: Starting cash after the reset
+//   - clearHistory: Also delete persisted trading history
+//
+// Returns:
+//   - *PaperReset: What was reset
+//   - error: ErrResetUnsupported for non-paper brokers, or a store failure
+func (om *OrderManager) ResetPaper(ctx context.Context, initialCash float64, clearHistory bool) (*PaperReset, error) {
+	resetter, ok := om.broker.(PaperResetter)
+	if !ok {
+		return nil, ErrResetUnsupported
+	}
+	if initialCash <= 0 {
+		return nil, fmt.Errorf("initial cash must be positive, got %.2f", initialCash)
+	}
+
+	// Clear history first so a failure leaves the account untouched
+	if clearHistory && om.store != nil {
+		clearer, ok := om.store.(TradingHistoryClearer)
+		if !ok {
+			return nil, fmt.Errorf("order store does not support clearing history")
+		}
+		if err := clearer.ClearTradingHistory(); err != nil {
+			return nil, err
+		}
+	} else {
+		om.cancelOpenOrders(ctx)
+	}
+
+	resetter.Reset(initialCash)
+	if !clearHistory {
+		om.zeroStoredPositions()
+	}
+
+	if clearHistory && om.store != nil {
+		if err := savePositionImports(om.store, nil); err != nil {
+			log.Error().Err(err).Msg("Failed to clear position imports after paper reset")
+		}
+	}
+
+	om.mu.Lock()
+	cleared := len(om.orders)
+	om.orders = make(map[string]models.Order)
+	om.brackets = make(map[string]bracketLegs)
+	om.saveBrackets()
+	om.pnlCounted = make(map[string]float64)
+	if clearHistory {
+		om.imports = nil
+	}
+	om.tradesChanged()
+	om.mu.Unlock()
+
+	if om.riskManager != nil {
+		om.riskManager.ResetDaily()
+	}
+	if om.store != nil {
+		if err := om.SetInitialCapital(initialCash); err != nil {
+			log.Error().Err(err).Msg("Failed to store initial capital after paper reset")
+		}
+	}
+
+	result := &PaperReset{InitialCash: initialCash, HistoryCleared: clearHistory && om.store != nil}
+	log.Warn().
+		Float64("initial_cash", initialCash).
+		Int("orders_cleared", cleared).
+		Bool("history_cleared", result.HistoryCleared).
+		Msg("Paper account reset")
+
+	if om.wsManager != nil {
+		om.wsManager.Broadcast("paper_reset", result)
+	}
+	return result, nil
+}
+
+// cancelOpenOrders cancels every open order and persists its final state.
+func (om *OrderManager) cancelOpenOrders(ctx context.Context) {
+	om.mu.RLock()
+	var openIDs []string
+	for id, order := range om.orders {
+		if order.Status == models.OrderStatusPending || order.Status == models.OrderStatusSubmitted {
+			openIDs = append(openIDs, id)
+		}
+	}
+	om.mu.RUnlock()
+
+	for _, id := range openIDs {
+		if err := om.CancelOrder(ctx, id); err != nil {
+			continue // Already logged
+		}
+		om.refreshOrder(id)
+	}
+}
+
+// zeroStoredPositions records every persisted position as closed.
+func (om *OrderManager) zeroStoredPositions() {
+	if om.store == nil {
+		return
+	}
+	positions, err := om.store.GetAllPositions()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load positions for paper reset")
+		return
+	}
+	for _, pos := range positions {
+		if pos.Quantity == 0 {
+			continue
+		}
+		pos.Quantity = 0
+		if err := om.store.SavePosition(pos); err != nil {
+			log.Error().Err(err).Str("symbol", pos.Symbol).Msg("Failed to close position for paper reset")
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package execution
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// liveBroker hides the paper broker's Reset, standing in for a real broker.
+type liveBroker struct {
+	Broker
+}
+
+// TestPaperBroker_Reset verifies cash, positions and orders are wiped.
+func TestPaperBroker_Reset(t *testing.T) {
+	broker := NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100)
+
+	_, err := broker.PlaceOrder(models.Order{Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeMarket, Quantity: 10})
+	require.NoError(t, err)
+	_, err = broker.PlaceOrder(models.Order{Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeLimit, Quantity: 1, Price: 90})
+	require.NoError(t, err)
+
+	broker.Reset(5000)
+
+	balance, err := broker.GetBalance()
+	require.NoError(t, err)
+	assert.Equal(t, 5000.0, balance.Cash)
+	assert.Equal(t, 5000.0, balance.Equity)
+
+	positions, err := broker.GetPositions()
+	require.NoError(t, err)
+	assert.Empty(t, positions)
+
+	orders, err := broker.ListOrders()
+	require.NoError(t, err)
+	assert.Empty(t, orders)
+
+	// Prices survive the reset
+	price, ok := broker.LatestPrice("AAPL")
+	assert.True(t, ok)
+	assert.Equal(t, 100.0, price)
+}
+
+// TestOrderManager_ResetPaper verifies the cache, store and initial capital
+// are reset, and non-paper brokers are refused.
+func TestOrderManager_ResetPaper(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	store := data.NewOrderStore(db)
+
+	broker := NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100)
+	om := NewOrderManager(broker, nil, store, nil)
+
+	ctx := context.Background()
+	order, err := om.CreateMarketOrder(ctx, "AAPL", models.OrderSideBuy, 10)
+	require.NoError(t, err)
+	resting, err := om.CreateLimitOrder(ctx, "AAPL", models.OrderSideBuy, 1, 90)
+	require.NoError(t, err)
+	require.NoError(t, store.SavePosition(models.Position{Symbol: "AAPL", Quantity: 10, AverageCost: 100}))
+
+	t.Run("KeepHistory", func(t *testing.T) {
+		reset, err := om.ResetPaper(ctx, 20000, false)
+		require.NoError(t, err)
+		assert.Equal(t, &PaperReset{InitialCash: 20000}, reset)
+
+		// History is kept, with open orders cancelled and positions closed
+		stored, err := store.GetOrder(resting.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.OrderStatusCancelled, stored.Status)
+		positions, err := store.GetAllPositions()
+		require.NoError(t, err)
+		require.Len(t, positions, 1)
+		assert.Zero(t, positions[0].Quantity)
+
+		cached, _, err := om.GetOrders(OrderFilter{})
+		require.NoError(t, err)
+		assert.Empty(t, cached)
+
+		balance, err := om.GetBalance()
+		require.NoError(t, err)
+		assert.Equal(t, 20000.0, balance.Cash)
+
+		capital, err := om.GetInitialCapital()
+		require.NoError(t, err)
+		assert.Equal(t, 20000.0, capital)
+	})
+
+	t.Run("ClearHistory", func(t *testing.T) {
+		reset, err := om.ResetPaper(ctx, 30000, true)
+		require.NoError(t, err)
+		assert.True(t, reset.HistoryCleared)
+
+		_, err = om.GetOrder(order.ID)
+		assert.Error(t, err)
+
+		stored, err := store.GetAllOrders()
+		require.NoError(t, err)
+		assert.Empty(t, stored)
+		positions, err := store.GetAllPositions()
+		require.NoError(t, err)
+		assert.Empty(t, positions)
+		trades, err := store.GetTradesByOrder(order.ID)
+		require.NoError(t, err)
+		assert.Empty(t, trades)
+	})
+
+	t.Run("InvalidCash", func(t *testing.T) {
+		_, err := om.ResetPaper(ctx, 0, false)
+		assert.Error(t, err)
+	})
+
+	t.Run("NonPaperBroker", func(t *testing.T) {
+		live := NewOrderManager(liveBroker{broker}, nil, nil, nil)
+		_, err := live.ResetPaper(ctx, 10000, false)
+		assert.ErrorIs(t, err, ErrResetUnsupported)
+	})
+}
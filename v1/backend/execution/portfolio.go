@@ -0,0 +1,116 @@
+// Package execution provides portfolio valuation in a base currency.
+package execution
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// DefaultBaseCurrency is the currency portfolio totals are reported in when
+// none is configured.
+const DefaultBaseCurrency = "USD"
+
+// PortfolioSummary aggregates the account in a single base currency.
+type PortfolioSummary struct {
+	BaseCurrency      string         `json:"base_currency"`
+	Balance           models.Balance `json:"balance"`
+	PositionsValue    float64        `json:"positions_value"`
+	TotalUnrealizedPL float64        `json:"total_unrealized_pl"`
+	OpenPositions     int            `json:"open_positions"`
+	// InitialCapital is the baseline performance is measured against
+	// (omitted if none has been recorded).
+	InitialCapital float64 `json:"initial_capital,omitempty"`
+	// RealizedPL is the gross profit/loss of closed trades.
+	RealizedPL float64 `json:"realized_pl"`
+	// TotalFees is the sum of commissions paid on filled trades.
+	TotalFees float64 `json:"total_fees"`
+	// FeeTrades is the number of trades that paid a commission.
+	FeeTrades int `json:"fee_trades"`
+	// NetRealizedPL is RealizedPL less TotalFees.
+	NetRealizedPL float64 `json:"net_realized_pl"`
+}
+
+// SetBaseCurrency sets the currency portfolio totals are converted to, and
+// the source of exchange rates used to convert them.
+//
+// Args:
+//   - base: Base currency (e.g., "USD"); empty selects DefaultBaseCurrency
+//   - rates: Exchange rate source; nil keeps the current source
+func (om *OrderManager) SetBaseCurrency(base string, rates data.FXRateSource) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	if base == "" {
+		base = DefaultBaseCurrency
+	}
+	om.baseCurrency = strings.ToUpper(base)
+	if rates != nil {
+		om.fxRates = rates
+	}
+	om.tradesChanged()
+}
+
+// PortfolioSummary values every position in the base currency and totals
+// them with the cash balance, which is assumed to be held in the base
+// currency. Equity and portfolio value are recomputed from the converted
+// position values so assets quoted in different currencies add up correctly.
+// Realized P&L and fees are computed from the trade history, read from the
+// trade store when it can list trades and otherwise from the broker.
+//
+// Returns:
+//   - *PortfolioSummary: The converted summary
+//   - error: If the broker fails or a position's currency has no rate
+func (om *OrderManager) PortfolioSummary() (*PortfolioSummary, error) {
+	om.mu.RLock()
+	base := om.baseCurrency
+	rates := om.fxRates
+	om.mu.RUnlock()
+
+	balance, err := om.broker.GetBalance()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+	positions, err := om.broker.GetPositions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get positions: %w", err)
+	}
+
+	summary := &PortfolioSummary{
+		BaseCurrency:  base,
+		Balance:       *balance,
+		OpenPositions: len(positions),
+	}
+	for _, p := range positions {
+		quote := p.QuoteCurrency
+		if quote == "" {
+			quote = data.QuoteCurrency(p.Symbol)
+		}
+		rate, err := rates.Rate(quote, base)
+		if err != nil {
+			return nil, fmt.Errorf("failed to value %s: %w", p.Symbol, err)
+		}
+		summary.PositionsValue += p.MarketValue * rate
+		summary.TotalUnrealizedPL += p.UnrealizedPL * rate
+	}
+
+	summary.Balance.Equity = balance.Cash + summary.PositionsValue
+	summary.Balance.PortfolioValue = summary.Balance.Equity
+
+	initialCapital, err := om.GetInitialCapital()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get initial capital: %w", err)
+	}
+	summary.InitialCapital = initialCapital
+
+	costs, err := om.realizedCosts()
+	if err != nil {
+		return nil, err
+	}
+	summary.RealizedPL = costs.RealizedPL
+	summary.TotalFees = costs.TotalFees
+	summary.FeeTrades = costs.FeeTrades
+	summary.NetRealizedPL = costs.RealizedPL - costs.TotalFees
+	return summary, nil
+}
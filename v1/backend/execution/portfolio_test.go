@@ -0,0 +1,57 @@
+package execution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOrderManager_PortfolioSummary verifies positions quoted in different
+// currencies are converted to the base currency before they are totalled.
+func TestOrderManager_PortfolioSummary(t *testing.T) {
+	broker := NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+	broker.SetPrice("BTC-USDT", 20000.0)
+	broker.SetPrice("BTC-EUR", 20000.0)
+
+	om := NewOrderManager(broker, nil, nil, nil)
+	ctx := context.Background()
+	_, err := om.CreateMarketOrder(ctx, "AAPL", models.OrderSideBuy, 10)
+	require.NoError(t, err)
+	_, err = om.CreateMarketOrder(ctx, "BTC-USDT", models.OrderSideBuy, 0.1)
+	require.NoError(t, err)
+
+	positions, err := om.GetPositions()
+	require.NoError(t, err)
+	for _, p := range positions {
+		assert.Equal(t, data.QuoteCurrency(p.Symbol), p.QuoteCurrency)
+	}
+
+	// USDT is pegged to USD by default.
+	summary, err := om.PortfolioSummary()
+	require.NoError(t, err)
+	assert.Equal(t, "USD", summary.BaseCurrency)
+	assert.Equal(t, 2, summary.OpenPositions)
+	assert.InDelta(t, 3000.0, summary.PositionsValue, 1e-9)
+	assert.InDelta(t, summary.Balance.Cash+3000.0, summary.Balance.Equity, 1e-9)
+
+	// A EUR-quoted position has no rate until one is configured.
+	_, err = om.CreateMarketOrder(ctx, "BTC-EUR", models.OrderSideBuy, 0.1)
+	require.NoError(t, err)
+	_, err = om.PortfolioSummary()
+	assert.ErrorContains(t, err, "no exchange rate from EUR to USD")
+
+	rates := data.NewStaticFXRates()
+	rates.SetRate("EUR", "USD", 1.5)
+	om.SetBaseCurrency("usd", rates)
+	summary, err = om.PortfolioSummary()
+	require.NoError(t, err)
+	assert.Equal(t, 3, summary.OpenPositions)
+	assert.InDelta(t, 3000.0+2000.0*1.5, summary.PositionsValue, 1e-9)
+	assert.InDelta(t, summary.Balance.Cash+summary.PositionsValue, summary.Balance.PortfolioValue, 1e-9)
+}
@@ -0,0 +1,152 @@
+// Package execution provides importing positions held before trading with
+// Sherwood.
+package execution
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrImportUnsupported is returned when the broker cannot take imported
+// positions, i.e. it is not a paper broker. Live positions come from the
+// broker through reconciliation.
+var ErrImportUnsupported = errors.New("broker does not support position import")
+
+// ErrInvalidImport marks a position import rejected for its contents.
+var ErrInvalidImport = errors.New("invalid position import")
+
+// PositionImportsKey is the system config key holding every imported
+// position, which seeds the cost basis realized P&L is measured against.
+const PositionImportsKey = "position_imports"
+
+// PositionImporter is an optional Broker capability for seeding positions.
+type PositionImporter interface {
+	ImportPositions(positions []models.Position)
+}
+
+// ImportPositions seeds the paper account with positions held elsewhere, so
+// strategies, risk checks and exits start from them. Each position replaces
+// any held for its symbol; other positions and cash are left alone. Each
+// also becomes the opening cost basis for its symbol in realized P&L, so a
+// later sell is measured against the imported average cost. The positions
+// are persisted before the broker takes them, so a store failure leaves the
+// account untouched. A "positions" event with the resulting
+// positions is broadcast.
+//
+// Args:
+//   - ctx: Context with audit information
+//   - positions: Positions to import, by symbol, quantity and average cost
+//
+// Returns:
+//   - []models.Position: The broker's positions after the import
+//   - error: ErrImportUnsupported for non-paper brokers, ErrInvalidImport
+//     for an invalid position, or a store failure
+func (om *OrderManager) ImportPositions(ctx context.Context, positions []models.Position) ([]models.Position, error) {
+	importer, ok := om.broker.(PositionImporter)
+	if !ok {
+		return nil, ErrImportUnsupported
+	}
+	if len(positions) == 0 {
+		return nil, fmt.Errorf("%w: no positions given", ErrInvalidImport)
+	}
+
+	seen := make(map[string]bool, len(positions))
+	for _, pos := range positions {
+		switch {
+		case pos.Symbol == "":
+			return nil, fmt.Errorf("%w: symbol is required", ErrInvalidImport)
+		case data.IsSyntheticSymbol(pos.Symbol):
+			return nil, fmt.Errorf("%w: synthetic symbol %s is analysis-only and can't be held", ErrInvalidImport, pos.Symbol)
+		case seen[pos.Symbol]:
+			return nil, fmt.Errorf("%w: %s is listed more than once", ErrInvalidImport, pos.Symbol)
+		case pos.Quantity <= 0:
+			return nil, fmt.Errorf("%w: %s quantity must be positive", ErrInvalidImport, pos.Symbol)
+		case pos.AverageCost <= 0:
+			return nil, fmt.Errorf("%w: %s average cost must be positive", ErrInvalidImport, pos.Symbol)
+		}
+		seen[pos.Symbol] = true
+	}
+
+	now := time.Now()
+	for i := range positions {
+		positions[i].UpdatedAt = now
+	}
+	om.mu.RLock()
+	imports := append(append([]models.Position{}, om.imports...), positions...)
+	om.mu.RUnlock()
+	if om.store != nil {
+		for _, pos := range positions {
+			if err := om.store.SavePosition(pos); err != nil {
+				return nil, fmt.Errorf("failed to persist imported position %s: %w", pos.Symbol, err)
+			}
+		}
+		if err := savePositionImports(om.store, imports); err != nil {
+			return nil, err
+		}
+	}
+
+	importer.ImportPositions(positions)
+	om.mu.Lock()
+	om.imports = imports
+	om.tradesChanged()
+	om.mu.Unlock()
+	held, err := om.broker.GetPositions()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Warn().
+		Int("imported", len(positions)).
+		Str("user_ip", auditIPFromCtx(ctx)).
+		Str("api_key_id", auditKeyIDFromCtx(ctx)).
+		Msg("Positions imported")
+
+	if om.wsManager != nil {
+		om.wsManager.Broadcast("positions", held)
+	}
+	return held, nil
+}
+
+// savePositionImports persists the full list of imported positions.
+func savePositionImports(store OrderStore, imports []models.Position) error {
+	encoded, err := json.Marshal(imports)
+	if err != nil {
+		return fmt.Errorf("failed to encode position imports: %w", err)
+	}
+	if err := store.SetSystemConfig(PositionImportsKey, string(encoded)); err != nil {
+		return fmt.Errorf("failed to persist position imports: %w", err)
+	}
+	return nil
+}
+
+// loadPositionImports reads the persisted imported positions. A missing or
+// unreadable list is treated as no imports, which only affects realized P&L.
+func loadPositionImports(store OrderStore) []models.Position {
+	if store == nil {
+		return nil
+	}
+
+	value, err := store.GetSystemConfig(PositionImportsKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load position imports")
+		return nil
+	}
+
+	var imports []models.Position
+	if err := json.Unmarshal([]byte(value), &imports); err != nil {
+		log.Error().Err(err).Msg("Invalid position imports")
+		return nil
+	}
+	return imports
+}
@@ -0,0 +1,115 @@
+package execution
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOrderManager_ImportPositions verifies imported positions are valued,
+// persisted and replace held ones, while cash and other positions are kept.
+func TestOrderManager_ImportPositions(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	store := data.NewOrderStore(db)
+
+	broker := NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100)
+	broker.SetPrice("MSFT", 50)
+	om := NewOrderManager(broker, nil, store, nil)
+	ctx := context.Background()
+	_, err = om.CreateMarketOrder(ctx, "MSFT", models.OrderSideBuy, 10)
+	require.NoError(t, err)
+	balance, err := broker.GetBalance()
+	require.NoError(t, err)
+	cash := balance.Cash
+
+	held, err := om.ImportPositions(ctx, []models.Position{
+		{Symbol: "AAPL", Quantity: 5, AverageCost: 80},
+		{Symbol: "BTC-USD", Quantity: 0.5, AverageCost: 30000},
+	})
+	require.NoError(t, err)
+	assert.Len(t, held, 3)
+
+	aapl, err := broker.GetPosition("AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, aapl.CurrentPrice)
+	assert.Equal(t, 500.0, aapl.MarketValue)
+	assert.Equal(t, 100.0, aapl.UnrealizedPL)
+	assert.Equal(t, "USD", aapl.QuoteCurrency)
+
+	// With no price yet, a position is valued at its cost
+	btc, err := broker.GetPosition("BTC-USD")
+	require.NoError(t, err)
+	assert.Equal(t, 15000.0, btc.MarketValue)
+	assert.Zero(t, btc.UnrealizedPL)
+
+	stored, err := store.GetPosition("BTC-USD")
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, stored.Quantity)
+	assert.Equal(t, 30000.0, stored.AverageCost)
+
+	balance, err = broker.GetBalance()
+	require.NoError(t, err)
+	assert.Equal(t, cash, balance.Cash)
+
+	// Importing a held symbol replaces it
+	_, err = om.ImportPositions(ctx, []models.Position{{Symbol: "MSFT", Quantity: 2, AverageCost: 40}})
+	require.NoError(t, err)
+	msft, err := broker.GetPosition("MSFT")
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, msft.Quantity)
+	assert.Equal(t, 40.0, msft.AverageCost)
+
+	// A later sell is measured against the imported cost, not the 50 paid
+	// for the shares the import replaced
+	_, err = om.CreateMarketOrder(ctx, "MSFT", models.OrderSideSell, 2)
+	require.NoError(t, err)
+	summary, err := om.PortfolioSummary()
+	require.NoError(t, err)
+	assert.InDelta(t, 20.0, summary.RealizedPL, 1e-9)
+
+	// The imports are persisted, so a restart measures it the same way
+	restarted := NewOrderManager(broker, nil, store, nil)
+	summary, err = restarted.PortfolioSummary()
+	require.NoError(t, err)
+	assert.InDelta(t, 20.0, summary.RealizedPL, 1e-9)
+}
+
+// TestOrderManager_ImportPositions_Invalid verifies bad positions and
+// non-paper brokers are refused without changing the account.
+func TestOrderManager_ImportPositions_Invalid(t *testing.T) {
+	broker := NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	om := NewOrderManager(broker, nil, nil, nil)
+	ctx := context.Background()
+
+	invalid := map[string][]models.Position{
+		"empty":       nil,
+		"no symbol":   {{Quantity: 1, AverageCost: 1}},
+		"synthetic":   {{Symbol: "ratio:ETH-USD/BTC-USD", Quantity: 1, AverageCost: 1}},
+		"duplicate":   {{Symbol: "AAPL", Quantity: 1, AverageCost: 1}, {Symbol: "AAPL", Quantity: 2, AverageCost: 1}},
+		"no quantity": {{Symbol: "AAPL", AverageCost: 1}},
+		"no cost":     {{Symbol: "AAPL", Quantity: 1}},
+	}
+	for name, positions := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := om.ImportPositions(ctx, positions)
+			assert.ErrorIs(t, err, ErrInvalidImport)
+		})
+	}
+	held, err := broker.GetPositions()
+	require.NoError(t, err)
+	assert.Empty(t, held)
+
+	live := NewOrderManager(liveBroker{broker}, nil, nil, nil)
+	_, err = live.ImportPositions(ctx, []models.Position{{Symbol: "AAPL", Quantity: 1, AverageCost: 1}})
+	assert.ErrorIs(t, err, ErrImportUnsupported)
+}
@@ -0,0 +1,208 @@
+// Package execution provides broker state reconciliation.
+package execution
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/alexherrero/sherwood/backend/tracing"
+)
+
+// ReconcileReport summarizes the corrections made by a reconciliation run.
+type ReconcileReport struct {
+	// OrdersChecked is the number of open local orders looked up at the broker.
+	OrdersChecked int `json:"orders_checked"`
+	// OrdersUpdated is the number of cached orders whose state was corrected.
+	OrdersUpdated int `json:"orders_updated"`
+	// OrdersAdded is the number of broker orders missing from the cache.
+	OrdersAdded int `json:"orders_added"`
+	// OrdersMissing is the number of open local orders the broker did not return.
+	OrdersMissing int `json:"orders_missing"`
+	// PositionsCorrected is the number of persisted positions that differed from the broker.
+	PositionsCorrected int `json:"positions_corrected"`
+}
+
+// Reconcile corrects the local order cache and persisted positions from the
+// broker, which is treated as the source of truth. Every open cached order is
+// looked up with GetOrder; if the broker implements OrderLister, orders the
+// cache has never seen are added too. Positions are compared with the store and
+// overwritten with the broker's. Discrepancies are logged as warnings.
+//
+// Orders the broker cannot find are reported but left unchanged, so running
+// against a fresh PaperBroker after a restart does not discard history. With a
+// long-running PaperBroker the cache is already in sync and nothing changes.
+//
+// Args:
+//   - ctx: Context for log correlation
+//
+// Returns:
+//   - *ReconcileReport: Summary of corrections
+//   - error: If the broker is disconnected or positions cannot be fetched
+func (om *OrderManager) Reconcile(ctx context.Context) (*ReconcileReport, error) {
+	logger := tracing.Logger(ctx)
+	report := &ReconcileReport{}
+
+	if !om.broker.IsConnected() {
+		return nil, fmt.Errorf("cannot reconcile: broker not connected")
+	}
+
+	// Check every open order we are tracking
+	om.mu.RLock()
+	var openOrders []models.Order
+	for _, order := range om.orders {
+		if isOpenStatus(order.Status) {
+			openOrders = append(openOrders, order)
+		}
+	}
+	om.mu.RUnlock()
+
+	for _, cached := range openOrders {
+		report.OrdersChecked++
+		remote, err := om.broker.GetOrder(cached.ID)
+		if err != nil {
+			report.OrdersMissing++
+			logger.Warn().Err(err).Str("order_id", cached.ID).Msg("Reconcile: open order not found at broker")
+			continue
+		}
+		if om.reconcileOrder(ctx, cached, *remote) {
+			report.OrdersUpdated++
+		}
+	}
+
+	// Pick up orders placed or changed outside this process
+	if lister, ok := om.broker.(OrderLister); ok {
+		remoteOrders, err := lister.ListOrders()
+		if err != nil {
+			logger.Warn().Err(err).Msg("Reconcile: failed to list broker orders")
+		}
+		for _, remote := range remoteOrders {
+			om.mu.RLock()
+			cached, exists := om.orders[remote.ID]
+			om.mu.RUnlock()
+
+			if !exists {
+				report.OrdersAdded++
+				logger.Warn().
+					Str("order_id", remote.ID).
+					Str("symbol", remote.Symbol).
+					Str("status", string(remote.Status)).
+					Msg("Reconcile: broker order missing from local cache")
+				om.handleOrderUpdate(remote)
+				continue
+			}
+			if !isOpenStatus(cached.Status) && om.reconcileOrder(ctx, cached, remote) {
+				report.OrdersUpdated++
+			}
+		}
+	}
+
+	corrected, err := om.reconcilePositions(ctx)
+	if err != nil {
+		return report, err
+	}
+	report.PositionsCorrected = corrected
+
+	logger.Info().
+		Int("orders_checked", report.OrdersChecked).
+		Int("orders_updated", report.OrdersUpdated).
+		Int("orders_added", report.OrdersAdded).
+		Int("orders_missing", report.OrdersMissing).
+		Int("positions_corrected", report.PositionsCorrected).
+		Msg("Reconciliation complete")
+
+	return report, nil
+}
+
+// reconcileOrder applies the broker's view of an order if it differs from the
+// cache, returning true if a correction was made.
+func (om *OrderManager) reconcileOrder(ctx context.Context, cached, remote models.Order) bool {
+	if cached.Status == remote.Status &&
+		cached.FilledQuantity == remote.FilledQuantity &&
+		cached.AveragePrice == remote.AveragePrice {
+		return false
+	}
+
+	logger := tracing.Logger(ctx)
+	logger.Warn().
+		Str("order_id", cached.ID).
+		Str("local_status", string(cached.Status)).
+		Str("broker_status", string(remote.Status)).
+		Float64("local_filled", cached.FilledQuantity).
+		Float64("broker_filled", remote.FilledQuantity).
+		Msg("Reconcile: order state differs from broker")
+
+	om.handleOrderUpdate(remote)
+	return true
+}
+
+// reconcilePositions overwrites persisted positions with the broker's,
+// recording positions the broker no longer holds with zero quantity.
+func (om *OrderManager) reconcilePositions(ctx context.Context) (int, error) {
+	positions, err := om.broker.GetPositions()
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch broker positions: %w", err)
+	}
+	if om.store == nil {
+		return 0, nil // Nothing persisted to correct
+	}
+
+	stored, err := om.store.GetAllPositions()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load stored positions: %w", err)
+	}
+	storedBySymbol := make(map[string]models.Position, len(stored))
+	for _, pos := range stored {
+		storedBySymbol[pos.Symbol] = pos
+	}
+
+	logger := tracing.Logger(ctx)
+	corrected := 0
+	for _, pos := range positions {
+		local, exists := storedBySymbol[pos.Symbol]
+		delete(storedBySymbol, pos.Symbol)
+		if exists && local.Quantity == pos.Quantity && local.AverageCost == pos.AverageCost {
+			continue
+		}
+
+		corrected++
+		logger.Warn().
+			Str("symbol", pos.Symbol).
+			Float64("local_quantity", local.Quantity).
+			Float64("broker_quantity", pos.Quantity).
+			Msg("Reconcile: position differs from broker")
+		if err := om.store.SavePosition(pos); err != nil {
+			logger.Error().Err(err).Str("symbol", pos.Symbol).Msg("Failed to persist reconciled position")
+		}
+	}
+
+	// Anything left was closed at the broker
+	for _, local := range storedBySymbol {
+		if local.Quantity == 0 {
+			continue
+		}
+
+		corrected++
+		logger.Warn().
+			Str("symbol", local.Symbol).
+			Float64("local_quantity", local.Quantity).
+			Msg("Reconcile: position no longer held at broker")
+		local.Quantity = 0
+		local.MarketValue = 0
+		local.UnrealizedPL = 0
+		local.UpdatedAt = time.Now()
+		if err := om.store.SavePosition(local); err != nil {
+			logger.Error().Err(err).Str("symbol", local.Symbol).Msg("Failed to persist reconciled position")
+		}
+	}
+
+	return corrected, nil
+}
+
+// isOpenStatus reports whether an order can still change state.
+func isOpenStatus(status models.OrderStatus) bool {
+	return status == models.OrderStatusPending ||
+		status == models.OrderStatusSubmitted ||
+		status == models.OrderStatusPartiallyFilled
+}
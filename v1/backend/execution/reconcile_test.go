@@ -0,0 +1,111 @@
+package execution
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOrderManager_Reconcile_InSync verifies reconciling against the paper
+// broker that placed every order changes nothing.
+func TestOrderManager_Reconcile_InSync(t *testing.T) {
+	broker := NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+
+	om := NewOrderManager(broker, nil, nil, nil)
+	_, err := om.CreateMarketOrder(context.Background(), "AAPL", models.OrderSideBuy, 5)
+	require.NoError(t, err)
+	_, err = om.CreateLimitOrder(context.Background(), "AAPL", models.OrderSideBuy, 5, 90.0)
+	require.NoError(t, err)
+
+	report, err := om.Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.OrdersChecked)
+	assert.Zero(t, report.OrdersUpdated)
+	assert.Zero(t, report.OrdersAdded)
+	assert.Zero(t, report.OrdersMissing)
+}
+
+// TestOrderManager_Reconcile_CorrectsDrift verifies missed fills, unknown
+// orders and stale positions are corrected from the broker.
+func TestOrderManager_Reconcile_CorrectsDrift(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	store := data.NewOrderStore(db)
+
+	broker := NewPaperBroker(10000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 100.0)
+
+	om := NewOrderManager(broker, nil, store, nil)
+
+	// A resting limit order whose fill the manager never hears about
+	resting, err := om.CreateLimitOrder(context.Background(), "AAPL", models.OrderSideBuy, 5, 95.0)
+	require.NoError(t, err)
+	broker.SetOrderUpdateHandler(nil)
+	broker.SetPrice("AAPL", 94.0)
+
+	// An order placed directly at the broker
+	external, err := broker.PlaceOrder(models.Order{
+		Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeMarket, Quantity: 1,
+	})
+	require.NoError(t, err)
+
+	// A tracked open order the broker has never seen
+	om.mu.Lock()
+	om.orders["ghost-1"] = models.Order{ID: "ghost-1", Symbol: "MSFT", Status: models.OrderStatusSubmitted}
+	om.mu.Unlock()
+
+	// A stale persisted position the broker no longer holds
+	require.NoError(t, store.SavePosition(models.Position{Symbol: "TSLA", Quantity: 3}))
+
+	report, err := om.Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.OrdersChecked)
+	assert.Equal(t, 1, report.OrdersUpdated)
+	assert.Equal(t, 1, report.OrdersAdded)
+	assert.Equal(t, 1, report.OrdersMissing)
+	assert.Equal(t, 2, report.PositionsCorrected)
+
+	filled, err := om.GetOrder(resting.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusFilled, filled.Status)
+
+	om.mu.RLock()
+	_, cached := om.orders[external.ID]
+	om.mu.RUnlock()
+	assert.True(t, cached, "external order should be added to the cache")
+
+	ghost, err := om.GetOrder("ghost-1")
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusSubmitted, ghost.Status)
+
+	// The corrected state is persisted
+	saved, err := store.GetOrder(resting.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderStatusFilled, saved.Status)
+
+	positions, err := store.GetAllPositions()
+	require.NoError(t, err)
+	bySymbol := map[string]float64{}
+	for _, pos := range positions {
+		bySymbol[pos.Symbol] = pos.Quantity
+	}
+	assert.Equal(t, 6.0, bySymbol["AAPL"])
+	assert.Equal(t, 0.0, bySymbol["TSLA"])
+}
+
+// TestOrderManager_Reconcile_Disconnected verifies reconciliation requires a connection.
+func TestOrderManager_Reconcile_Disconnected(t *testing.T) {
+	om := NewOrderManager(NewPaperBroker(10000), nil, nil, nil)
+
+	_, err := om.Reconcile(context.Background())
+	assert.ErrorContains(t, err, "broker not connected")
+}
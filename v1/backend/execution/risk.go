@@ -3,22 +3,41 @@ package execution
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/rs/zerolog/log"
 )
 
-// RiskConfig holds risk management configuration.
+// Notifier delivers user-facing alerts, such as a strategy reaching its
+// daily trade cap or the daily loss circuit breaker tripping.
+// *notifications.Manager satisfies it.
+type Notifier interface {
+	Send(notifType models.NotificationType, title, message string, metadata map[string]interface{}) (string, error)
+}
+
+// RiskConfig holds risk management configuration. A zero limit disables
+// its check.
 type RiskConfig struct {
 	// MaxPositionSize is the maximum value per position.
 	MaxPositionSize float64
 	// MaxPortfolioRisk is the maximum portfolio risk percentage.
 	MaxPortfolioRisk float64
-	// MaxDailyLoss is the maximum daily loss allowed.
+	// MaxDailyLoss is the maximum realized loss per UTC day, net of fees.
 	MaxDailyLoss float64
 	// RiskPerTrade is the maximum risk per trade (default 2%).
 	RiskPerTrade float64
 	// MaxOpenOrders is the maximum number of open orders.
 	MaxOpenOrders int
+	// MaxDailyTrades is the maximum number of filled orders per strategy per
+	// day (0 disables). Orders without a strategy name are not counted.
+	MaxDailyTrades int
+	// MinCashReserve is the cash a buy may not spend below (0 disables).
+	MinCashReserve float64
+	// MinCashReservePct is the cash reserve as a fraction of equity (e.g.,
+	// 0.05 for 5%; 0 disables). The larger of the two reserves applies.
+	MinCashReservePct float64
 }
 
 // DefaultRiskConfig returns default risk configuration.
@@ -27,11 +46,14 @@ type RiskConfig struct {
 //   - *RiskConfig: Default configuration
 func DefaultRiskConfig() *RiskConfig {
 	return &RiskConfig{
-		MaxPositionSize:  10000.0, // $10,000 max per position
-		MaxPortfolioRisk: 0.20,    // 20% max portfolio risk
-		MaxDailyLoss:     500.0,   // $500 max daily loss
-		RiskPerTrade:     0.02,    // 2% risk per trade
-		MaxOpenOrders:    10,      // 10 open orders max
+		MaxPositionSize:   10000.0, // $10,000 max per position
+		MaxPortfolioRisk:  0.20,    // 20% max portfolio risk
+		MaxDailyLoss:      500.0,   // $500 max daily loss
+		RiskPerTrade:      0.02,    // 2% risk per trade
+		MaxOpenOrders:     10,      // 10 open orders max
+		MaxDailyTrades:    0,       // No per-strategy trade cap
+		MinCashReserve:    0,       // No cash floor beyond buying power
+		MinCashReservePct: 0,
 	}
 }
 
@@ -41,6 +63,13 @@ type RiskManager struct {
 	broker     Broker
 	dailyPnL   float64
 	openOrders int
+
+	// Per-strategy filled order counts for tradeDay (UTC date)
+	dailyTrades map[string]int
+	tradeDay    string
+	notifier    Notifier
+	now         func() time.Time
+	mu          sync.Mutex
 }
 
 // NewRiskManager creates a new risk manager.
@@ -60,9 +89,22 @@ func NewRiskManager(config *RiskConfig, broker Broker) *RiskManager {
 		broker:     broker,
 		dailyPnL:   0,
 		openOrders: 0,
+
+		dailyTrades: make(map[string]int),
+		now:         time.Now,
 	}
 }
 
+// SetNotifier sets where daily trade cap and circuit breaker alerts are sent.
+//
+// Args:
+//   - notifier: notification sink (can be nil)
+func (rm *RiskManager) SetNotifier(notifier Notifier) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.notifier = notifier
+}
+
 // CheckOrder evaluates if an order passes risk checks.
 //
 // Args:
@@ -72,12 +114,22 @@ func NewRiskManager(config *RiskConfig, broker Broker) *RiskManager {
 //   - error: Risk violation error, or nil if passed
 func (rm *RiskManager) CheckOrder(order models.Order) error {
 	// Check daily loss limit
-	if rm.dailyPnL < -rm.config.MaxDailyLoss {
-		return fmt.Errorf("daily loss limit exceeded: %.2f", rm.dailyPnL)
+	rm.mu.Lock()
+	rm.rollDay()
+	halted, dailyPnL, openOrders := rm.halted(), rm.dailyPnL, rm.openOrders
+	rm.mu.Unlock()
+	if halted {
+		return fmt.Errorf("daily loss limit exceeded: %.2f", dailyPnL)
+	}
+
+	// Check the strategy's daily trade cap
+	if trades, capped := rm.dailyTradeCapReached(order.StrategyName); capped {
+		return fmt.Errorf("max daily trades reached for strategy %s: %d of %d",
+			order.StrategyName, trades, rm.config.MaxDailyTrades)
 	}
 
 	// Check max open orders
-	if rm.openOrders >= rm.config.MaxOpenOrders {
+	if rm.config.MaxOpenOrders > 0 && openOrders >= rm.config.MaxOpenOrders {
 		return fmt.Errorf("max open orders reached: %d", rm.config.MaxOpenOrders)
 	}
 
@@ -88,20 +140,56 @@ func (rm *RiskManager) CheckOrder(order models.Order) error {
 		positionValue = order.Quantity * 100 // Conservative estimate
 	}
 
-	if positionValue > rm.config.MaxPositionSize {
+	if rm.config.MaxPositionSize > 0 && positionValue > rm.config.MaxPositionSize {
 		return fmt.Errorf("position size exceeds limit: %.2f > %.2f",
 			positionValue, rm.config.MaxPositionSize)
 	}
 
 	// Check portfolio risk
 	balance, err := rm.broker.GetBalance()
-	if err == nil && balance != nil {
+	if err == nil && balance != nil && rm.config.MaxPortfolioRisk > 0 {
 		riskAmount := positionValue * rm.config.RiskPerTrade
 		if riskAmount > balance.Equity*rm.config.MaxPortfolioRisk {
 			return fmt.Errorf("order exceeds portfolio risk limit")
 		}
 	}
 
+	return rm.checkCashReserve(order, balance, err)
+}
+
+// cashReserve returns the cash that must remain after a buy, or 0 if no
+// reserve is configured.
+func (rm *RiskManager) cashReserve(equity float64) float64 {
+	return max(rm.config.MinCashReserve, equity*rm.config.MinCashReservePct)
+}
+
+// checkCashReserve rejects buys that would leave less cash than the reserve.
+// Market orders are valued at the broker's latest price when it is known,
+// and otherwise at their limit price, if any. Sells are never rejected.
+func (rm *RiskManager) checkCashReserve(order models.Order, balance *models.Balance, balanceErr error) error {
+	if order.Side != models.OrderSideBuy || (rm.config.MinCashReserve <= 0 && rm.config.MinCashReservePct <= 0) {
+		return nil
+	}
+	if balanceErr != nil || balance == nil {
+		return fmt.Errorf("cannot check cash reserve: balance unavailable")
+	}
+
+	price := order.Price
+	if order.Type == models.OrderTypeMarket {
+		if quoter, ok := rm.broker.(PriceQuoter); ok {
+			if latest, ok := quoter.LatestPrice(order.Symbol); ok && latest > 0 {
+				price = latest
+			}
+		}
+	}
+	if price <= 0 {
+		return fmt.Errorf("cannot check cash reserve: no price for %s", order.Symbol)
+	}
+
+	reserve := rm.cashReserve(balance.Equity)
+	if remaining := balance.Cash - order.Quantity*price; remaining < reserve {
+		return fmt.Errorf("order would leave %.2f cash, below the %.2f minimum cash reserve", remaining, reserve)
+	}
 	return nil
 }
 
@@ -143,27 +231,151 @@ func (rm *RiskManager) CalculatePositionSize(entryPrice, stopLoss float64, balan
 	return positionSize
 }
 
-// UpdateDailyPnL updates the daily P&L tracking.
+// UpdateDailyPnL updates the daily P&L tracking, and sends a critical
+// notification when the change trips the daily loss circuit breaker. The
+// order manager calls it with the realized P&L of each fill.
 //
 // Args:
 //   - pnl: P&L change to add
 func (rm *RiskManager) UpdateDailyPnL(pnl float64) {
+	rm.mu.Lock()
+	rm.rollDay()
+	wasHalted := rm.halted()
 	rm.dailyPnL += pnl
+	tripped := !wasHalted && rm.halted()
+	dailyPnL := rm.dailyPnL
+	notifier := rm.notifier
+	rm.mu.Unlock()
+
+	if !tripped {
+		return
+	}
+	log.Warn().
+		Float64("daily_pnl", dailyPnL).
+		Float64("max_daily_loss", rm.config.MaxDailyLoss).
+		Msg("Daily loss circuit breaker tripped")
+	if notifier == nil {
+		return
+	}
+	message := fmt.Sprintf("Daily loss of %.2f exceeded the %.2f limit; all new orders are rejected until the daily reset",
+		-dailyPnL, rm.config.MaxDailyLoss)
+	if _, err := notifier.Send(models.NotificationWarning, "Circuit breaker tripped", message, map[string]interface{}{
+		"daily_pnl":                    dailyPnL,
+		"max_daily_loss":               rm.config.MaxDailyLoss,
+		models.NotificationCriticalKey: true,
+	}); err != nil {
+		log.Error().Err(err).Msg("Failed to send circuit breaker notification")
+	}
+}
+
+// IsHalted reports whether the daily loss limit has been breached, which
+// blocks all new orders until ResetDaily or the UTC date rolls over.
+//
+// Returns:
+//   - bool: True if trading is halted
+func (rm *RiskManager) IsHalted() bool {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.rollDay()
+	return rm.halted()
+}
+
+// halted reports whether the daily loss limit has been breached. Callers
+// must hold rm.mu.
+func (rm *RiskManager) halted() bool {
+	return rm.config.MaxDailyLoss > 0 && rm.dailyPnL < -rm.config.MaxDailyLoss
 }
 
 // ResetDaily resets the daily tracking (call at market open).
 func (rm *RiskManager) ResetDaily() {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
 	rm.dailyPnL = 0
 	rm.openOrders = 0
+	rm.dailyTrades = make(map[string]int)
+	rm.tradeDay = ""
+}
+
+// RecordFill counts a filled order against its strategy's daily trade cap,
+// and sends a notification when the cap is reached. Counts also reset when
+// the UTC date rolls over, which falls outside US equity trading hours.
+//
+// Args:
+//   - order: The filled order
+func (rm *RiskManager) RecordFill(order models.Order) {
+	limit := rm.config.MaxDailyTrades
+	if limit <= 0 || order.StrategyName == "" {
+		return
+	}
+
+	rm.mu.Lock()
+	rm.rollDay()
+	rm.dailyTrades[order.StrategyName]++
+	trades := rm.dailyTrades[order.StrategyName]
+	notifier := rm.notifier
+	rm.mu.Unlock()
+
+	if trades != limit {
+		return
+	}
+	log.Warn().
+		Str("strategy", order.StrategyName).
+		Int("trades", trades).
+		Msg("Strategy reached its daily trade cap")
+	if notifier == nil {
+		return
+	}
+	message := fmt.Sprintf("Strategy %s reached its limit of %d trades today; further orders are rejected until the next day",
+		order.StrategyName, limit)
+	if _, err := notifier.Send(models.NotificationWarning, "Daily trade cap reached", message, map[string]interface{}{
+		"strategy":                     order.StrategyName,
+		"max_daily_trades":             limit,
+		models.NotificationCriticalKey: true,
+	}); err != nil {
+		log.Error().Err(err).Msg("Failed to send daily trade cap notification")
+	}
+}
+
+// GetDailyTrades returns the number of filled orders a strategy has made today.
+func (rm *RiskManager) GetDailyTrades(strategy string) int {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.rollDay()
+	return rm.dailyTrades[strategy]
+}
+
+// dailyTradeCapReached reports whether a strategy has used up its trades for
+// the day, along with its current count.
+func (rm *RiskManager) dailyTradeCapReached(strategy string) (int, bool) {
+	if rm.config.MaxDailyTrades <= 0 || strategy == "" {
+		return 0, false
+	}
+	trades := rm.GetDailyTrades(strategy)
+	return trades, trades >= rm.config.MaxDailyTrades
+}
+
+// rollDay clears the trade counts and daily P&L when the date has changed.
+// Callers must hold rm.mu.
+func (rm *RiskManager) rollDay() {
+	day := rm.now().UTC().Format("2006-01-02")
+	if day != rm.tradeDay {
+		rm.dailyPnL = 0
+		rm.dailyTrades = make(map[string]int)
+		rm.tradeDay = day
+	}
 }
 
 // IncrementOpenOrders increments the open order count.
 func (rm *RiskManager) IncrementOpenOrders() {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
 	rm.openOrders++
 }
 
 // DecrementOpenOrders decrements the open order count.
 func (rm *RiskManager) DecrementOpenOrders() {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
 	if rm.openOrders > 0 {
 		rm.openOrders--
 	}
@@ -171,6 +383,9 @@ func (rm *RiskManager) DecrementOpenOrders() {
 
 // GetDailyPnL returns the current daily P&L.
 func (rm *RiskManager) GetDailyPnL() float64 {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.rollDay()
 	return rm.dailyPnL
 }
 
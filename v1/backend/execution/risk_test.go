@@ -1,10 +1,14 @@
 package execution
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/alexherrero/sherwood/backend/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestDefaultRiskConfig verifies default configuration values.
@@ -16,6 +20,9 @@ func TestDefaultRiskConfig(t *testing.T) {
 	assert.Equal(t, 500.0, cfg.MaxDailyLoss)
 	assert.Equal(t, 0.02, cfg.RiskPerTrade)
 	assert.Equal(t, 10, cfg.MaxOpenOrders)
+	assert.Equal(t, 0, cfg.MaxDailyTrades)
+	assert.Zero(t, cfg.MinCashReserve)
+	assert.Zero(t, cfg.MinCashReservePct)
 }
 
 // TestNewRiskManager verifies risk manager creation.
@@ -125,6 +132,165 @@ func TestRiskManager_CheckOrder_MaxOpenOrders(t *testing.T) {
 	assert.Contains(t, err.Error(), "max open orders reached")
 }
 
+// recordingNotifier captures sent notifications.
+type recordingNotifier struct {
+	types    []models.NotificationType
+	titles   []string
+	messages []string
+	critical []bool
+}
+
+func (n *recordingNotifier) Send(notifType models.NotificationType, title, message string, metadata map[string]interface{}) (string, error) {
+	n.types = append(n.types, notifType)
+	n.titles = append(n.titles, title)
+	n.messages = append(n.messages, message)
+	critical, _ := metadata[models.NotificationCriticalKey].(bool)
+	n.critical = append(n.critical, critical)
+	return "notif-1", nil
+}
+
+// TestRiskManager_MinCashReserve verifies buys may not spend cash below the
+// larger of the absolute and percent-of-equity reserves, and sells are allowed.
+func TestRiskManager_MinCashReserve(t *testing.T) {
+	broker := NewPaperBroker(10000)
+	_ = broker.Connect()
+	broker.SetPrice("AAPL", 100)
+	cfg := DefaultRiskConfig()
+	cfg.MinCashReserve = 1000
+	rm := NewRiskManager(cfg, broker)
+
+	buy := func(quantity float64) models.Order {
+		return models.Order{Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeMarket, Quantity: quantity}
+	}
+
+	// $10,000 cash: spending $9,000 leaves exactly the reserve
+	assert.NoError(t, rm.CheckOrder(buy(90)))
+	err := rm.CheckOrder(buy(91))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "below the 1000.00 minimum cash reserve")
+
+	// Limit orders are valued at their price
+	limit := models.Order{Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeLimit, Quantity: 100, Price: 95}
+	assert.Error(t, rm.CheckOrder(limit))
+	limit.Price = 85
+	assert.NoError(t, rm.CheckOrder(limit))
+
+	// The percent reserve applies when it is larger: 20% of $10,000
+	cfg.MinCashReservePct = 0.20
+	assert.Error(t, rm.CheckOrder(buy(81)))
+	assert.NoError(t, rm.CheckOrder(buy(80)))
+
+	// Sells are unaffected
+	sell := buy(95)
+	sell.Side = models.OrderSideSell
+	assert.NoError(t, rm.CheckOrder(sell))
+}
+
+// TestRiskManager_MaxDailyTrades verifies each strategy is capped separately,
+// the cap notifies once, and counts reset on a new day.
+func TestRiskManager_MaxDailyTrades(t *testing.T) {
+	broker := NewPaperBroker(10000)
+	_ = broker.Connect()
+	cfg := &RiskConfig{
+		MaxPositionSize: 10000,
+		MaxOpenOrders:   10,
+		MaxDailyLoss:    1000,
+		MaxDailyTrades:  2,
+	}
+	rm := NewRiskManager(cfg, broker)
+	now := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	rm.now = func() time.Time { return now }
+	notifier := &recordingNotifier{}
+	rm.SetNotifier(notifier)
+
+	order := models.Order{
+		Symbol:       "AAPL",
+		Quantity:     10,
+		Price:        100.0,
+		Type:         models.OrderTypeLimit,
+		StrategyName: "ma_crossover",
+	}
+	other := order
+	other.StrategyName = "rsi_momentum"
+	manual := order
+	manual.StrategyName = ""
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, rm.CheckOrder(order))
+		rm.RecordFill(order)
+	}
+	rm.RecordFill(manual)
+
+	err := rm.CheckOrder(order)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "max daily trades reached for strategy ma_crossover: 2 of 2")
+	assert.NoError(t, rm.CheckOrder(other))
+	assert.NoError(t, rm.CheckOrder(manual))
+	assert.Equal(t, []string{"Daily trade cap reached"}, notifier.titles)
+	assert.Equal(t, []bool{true}, notifier.critical)
+
+	// A new UTC day lifts the cap
+	now = now.Add(12 * time.Hour)
+	assert.Equal(t, 0, rm.GetDailyTrades("ma_crossover"))
+	assert.NoError(t, rm.CheckOrder(order))
+
+	// As does an explicit reset
+	rm.RecordFill(order)
+	rm.RecordFill(order)
+	require.Error(t, rm.CheckOrder(order))
+	rm.ResetDaily()
+	assert.NoError(t, rm.CheckOrder(order))
+}
+
+// TestOrderManager_MaxDailyTrades verifies fills placed through the order
+// manager count toward the cap, including resting orders that fill later.
+func TestOrderManager_MaxDailyTrades(t *testing.T) {
+	broker := NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	broker.SetPrice("AAPL", 50.0)
+	rm := NewRiskManager(&RiskConfig{
+		MaxPositionSize:  10000,
+		MaxPortfolioRisk: 1,
+		MaxOpenOrders:    10,
+		MaxDailyLoss:     1000,
+		RiskPerTrade:     0.02,
+		MaxDailyTrades:   2,
+	}, broker)
+	om := NewOrderManager(broker, rm, nil, nil)
+	ctx := context.Background()
+
+	newOrder := func(orderType models.OrderType, price float64) models.Order {
+		return models.Order{
+			Symbol:       "AAPL",
+			Side:         models.OrderSideBuy,
+			Type:         orderType,
+			Quantity:     1,
+			Price:        price,
+			StrategyName: "ma_crossover",
+		}
+	}
+
+	_, err := om.SubmitOrder(ctx, newOrder(models.OrderTypeMarket, 0))
+	require.NoError(t, err)
+	resting, err := om.SubmitOrder(ctx, newOrder(models.OrderTypeLimit, 40))
+	require.NoError(t, err)
+	assert.Equal(t, 1, rm.GetDailyTrades("ma_crossover"))
+
+	broker.SetPrice("AAPL", 39.0)
+	filled, err := om.GetOrder(resting.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.OrderStatusFilled, filled.Status)
+	assert.Equal(t, 2, rm.GetDailyTrades("ma_crossover"))
+
+	// Repeated updates for a filled order are not counted again
+	om.handleOrderUpdate(*filled)
+	assert.Equal(t, 2, rm.GetDailyTrades("ma_crossover"))
+
+	_, err = om.SubmitOrder(ctx, newOrder(models.OrderTypeMarket, 0))
+	require.ErrorIs(t, err, ErrRiskRejected)
+	assert.Contains(t, err.Error(), "max daily trades reached")
+}
+
 // TestRiskManager_CalculatePositionSize verifies position sizing.
 func TestRiskManager_CalculatePositionSize(t *testing.T) {
 	broker := NewPaperBroker(10000)
@@ -212,3 +378,91 @@ func TestRiskManager_GetConfig(t *testing.T) {
 	assert.Equal(t, cfg, rm.GetConfig())
 	assert.Equal(t, 5000.0, rm.GetConfig().MaxPositionSize)
 }
+
+// TestRiskManager_CircuitBreakerNotifies verifies tripping the daily loss
+// limit sends one critical notification.
+func TestRiskManager_CircuitBreakerNotifies(t *testing.T) {
+	broker := NewPaperBroker(10000)
+	_ = broker.Connect()
+	rm := NewRiskManager(&RiskConfig{MaxPositionSize: 10000, MaxOpenOrders: 10, MaxDailyLoss: 500}, broker)
+	notifier := &recordingNotifier{}
+	rm.SetNotifier(notifier)
+
+	rm.UpdateDailyPnL(-400)
+	assert.False(t, rm.IsHalted())
+	assert.Empty(t, notifier.titles)
+
+	rm.UpdateDailyPnL(-200)
+	assert.True(t, rm.IsHalted())
+	rm.UpdateDailyPnL(-50)
+	assert.Equal(t, []string{"Circuit breaker tripped"}, notifier.titles)
+	assert.Equal(t, []bool{true}, notifier.critical)
+	assert.Contains(t, notifier.messages[0], "Daily loss of 600.00 exceeded the 500.00 limit")
+
+	// Tripping again after a reset notifies again
+	rm.ResetDaily()
+	rm.UpdateDailyPnL(-501)
+	assert.Len(t, notifier.titles, 2)
+}
+
+// TestRiskManager_CircuitBreakerNewDay verifies the daily loss resets when
+// the UTC date rolls over.
+func TestRiskManager_CircuitBreakerNewDay(t *testing.T) {
+	broker := NewPaperBroker(10000)
+	_ = broker.Connect()
+	rm := NewRiskManager(&RiskConfig{MaxDailyLoss: 500}, broker)
+	now := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	rm.now = func() time.Time { return now }
+
+	rm.UpdateDailyPnL(-600)
+	assert.True(t, rm.IsHalted())
+
+	now = now.Add(12 * time.Hour)
+	assert.False(t, rm.IsHalted())
+	assert.Zero(t, rm.GetDailyPnL())
+}
+
+// TestRiskManager_ZeroLimitsDisabled verifies limits left at 0 don't reject
+// orders, so only the configured ones apply.
+func TestRiskManager_ZeroLimitsDisabled(t *testing.T) {
+	broker := NewPaperBroker(10000)
+	_ = broker.Connect()
+	rm := NewRiskManager(&RiskConfig{MaxDailyTrades: 1}, broker)
+
+	order := models.Order{Symbol: "AAPL", Side: models.OrderSideBuy, Type: models.OrderTypeMarket, Quantity: 500, StrategyName: "ma_crossover"}
+	require.NoError(t, rm.CheckOrder(order))
+	rm.UpdateDailyPnL(-1e6)
+	assert.False(t, rm.IsHalted())
+
+	rm.RecordFill(order)
+	assert.Error(t, rm.CheckOrder(order))
+}
+
+// TestRiskManager_ConcurrentDailyPnL verifies P&L updates and halt checks
+// from different goroutines are safe (run with -race).
+func TestRiskManager_ConcurrentDailyPnL(t *testing.T) {
+	broker := NewPaperBroker(10000)
+	_ = broker.Connect()
+	rm := NewRiskManager(&RiskConfig{MaxDailyLoss: 500}, broker)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				rm.UpdateDailyPnL(-1)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				_ = rm.IsHalted()
+				_ = rm.CheckOrder(models.Order{Symbol: "AAPL", Side: models.OrderSideSell, Type: models.OrderTypeLimit, Quantity: 1, Price: 10})
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, -400.0, rm.GetDailyPnL())
+	assert.False(t, rm.IsHalted())
+}
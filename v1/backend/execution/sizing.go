@@ -0,0 +1,128 @@
+// Package execution provides order size normalization.
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// ErrMaxNotionalExceeded marks an order worth more than the configured
+// maximum notional, which guards against fat-fingered quantities.
+var ErrMaxNotionalExceeded = errors.New("order value exceeds maximum notional")
+
+// ErrNotionalUnknown marks an order that can't be valued while notional
+// limits are configured. Such orders are rejected rather than let through
+// unchecked.
+var ErrNotionalUnknown = errors.New("order value is unknown")
+
+// LotSizeSource reports the quantity step an exchange accepts for a symbol.
+type LotSizeSource interface {
+	// LotSize returns the quantity step for a symbol, or false if unknown.
+	// A lookup that reaches the exchange is bound to ctx.
+	LotSize(ctx context.Context, symbol string) (float64, bool)
+}
+
+// OrderSizing rounds order quantities to a tradable lot size and rejects
+// orders too small to be worth placing or implausibly large. The zero value
+// disables all three.
+type OrderSizing struct {
+	// MinNotional is the smallest order value (quantity × price) accepted.
+	MinNotional float64
+	// MaxNotional is the largest order value (quantity × price) accepted.
+	MaxNotional float64
+	// EquityLotSize is the quantity step for equities (1 = whole shares).
+	EquityLotSize float64
+	// CryptoLotSize is the quantity step for crypto pairs (e.g., 0.0001).
+	CryptoLotSize float64
+	// Exchange reports per-symbol lot sizes, which take precedence over
+	// the asset-class defaults (nil to use only the defaults).
+	Exchange LotSizeSource
+}
+
+// Enabled reports whether any sizing rule is configured.
+func (s OrderSizing) Enabled() bool {
+	return s.MinNotional > 0 || s.MaxNotional > 0 || s.EquityLotSize > 0 || s.CryptoLotSize > 0 || s.Exchange != nil
+}
+
+// LotSize returns the quantity step for a symbol: the exchange's when it
+// reports one, otherwise the step for the symbol's asset class, or 0 if
+// quantities are not rounded.
+func (s OrderSizing) LotSize(ctx context.Context, symbol string) float64 {
+	if s.Exchange != nil {
+		if step, ok := s.Exchange.LotSize(ctx, symbol); ok && step > 0 {
+			return step
+		}
+	}
+	if data.IsCryptoSymbol(symbol) {
+		return s.CryptoLotSize
+	}
+	return s.EquityLotSize
+}
+
+// Normalize rounds the order quantity down to the symbol's lot size and
+// checks the order value against MinNotional and MaxNotional. A sell of the
+// whole held quantity is neither rounded nor held to MinNotional, so
+// positions bought before rounding applied (or at a finer step), and dust
+// left behind by partial fills, can still be closed completely.
+//
+// Args:
+//   - ctx: Context bounding an exchange lot size lookup
+//   - order: The order to normalize
+//   - price: Price used to value the order (0 if unknown)
+//   - held: Quantity of the symbol currently held (0 if none or unknown)
+//
+// Returns:
+//   - models.Order: The order with its quantity rounded
+//   - error: If the rounded quantity is zero, the order value is below
+//     MinNotional, it is above MaxNotional (ErrMaxNotionalExceeded), or
+//     either is set and the price is unknown (ErrNotionalUnknown)
+func (s OrderSizing) Normalize(ctx context.Context, order models.Order, price, held float64) (models.Order, error) {
+	closesPosition := order.Side == models.OrderSideSell && held > 0 && math.Abs(order.Quantity-held) < 1e-9
+	if step := s.LotSize(ctx, order.Symbol); step > 0 && order.Quantity > 0 && !closesPosition {
+		// Nudge by a small epsilon so 0.3/0.1 does not floor to 2
+		lots := math.Floor(order.Quantity/step + 1e-9)
+		if lots < 1 {
+			return order, fmt.Errorf("quantity %g is below the lot size %g for %s", order.Quantity, step, order.Symbol)
+		}
+		order.Quantity = lots * step
+		// Trim float noise from the multiplication (e.g., 3 × 0.1)
+		if decimals := stepDecimals(step); decimals >= 0 {
+			scale := math.Pow(10, float64(decimals))
+			order.Quantity = math.Round(order.Quantity*scale) / scale
+		}
+	}
+
+	checkMin := s.MinNotional > 0 && !closesPosition
+	if !checkMin && s.MaxNotional <= 0 {
+		return order, nil
+	}
+	if price <= 0 {
+		return order, fmt.Errorf("%w: no price for %s to check notional limits", ErrNotionalUnknown, order.Symbol)
+	}
+	notional := order.Quantity * price
+	if checkMin && notional < s.MinNotional {
+		return order, fmt.Errorf("order value %.2f is below the minimum notional %.2f", notional, s.MinNotional)
+	}
+	if s.MaxNotional > 0 && notional > s.MaxNotional {
+		return order, fmt.Errorf("%w: order value %.2f is above the maximum notional %.2f",
+			ErrMaxNotionalExceeded, notional, s.MaxNotional)
+	}
+	return order, nil
+}
+
+// stepDecimals returns the number of decimal places in a lot size, or -1 if
+// it has more than 12.
+func stepDecimals(step float64) int {
+	for d := 0; d <= 12; d++ {
+		scale := math.Pow(10, float64(d))
+		if math.Abs(step*scale-math.Round(step*scale)) < 1e-9 {
+			return d
+		}
+	}
+	return -1
+}
@@ -0,0 +1,147 @@
+package execution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOrderSizing_Normalize verifies lot rounding and the minimum notional.
+func TestOrderSizing_Normalize(t *testing.T) {
+	sizing := OrderSizing{MinNotional: 10, EquityLotSize: 1, CryptoLotSize: 0.001}
+
+	tests := []struct {
+		name        string
+		symbol      string
+		quantity    float64
+		price       float64
+		want        float64
+		errContains string
+	}{
+		{name: "equity rounds down to whole shares", symbol: "AAPL", quantity: 7.9, price: 150, want: 7},
+		{name: "crypto rounds down to step", symbol: "BTC-USD", quantity: 0.12345, price: 50000, want: 0.123},
+		{name: "exact multiple is unchanged", symbol: "ETH-USD", quantity: 0.3, price: 3000, want: 0.3},
+		{name: "below lot size", symbol: "AAPL", quantity: 0.5, price: 150, errContains: "below the lot size"},
+		{name: "crypto below lot size", symbol: "BTC-USD", quantity: 0.0001, price: 50000, errContains: "below the lot size"},
+		{name: "rounded order below minimum notional", symbol: "F", quantity: 1.5, price: 9, errContains: "minimum notional"},
+		{name: "unknown price fails the notional check", symbol: "F", quantity: 1, price: 0, errContains: "no price for F"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := models.Order{Symbol: tt.symbol, Quantity: tt.quantity}
+			result, err := sizing.Normalize(context.Background(), order, tt.price, 0)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result.Quantity)
+		})
+	}
+}
+
+// TestOrderSizing_MaxNotional verifies orders above the maximum notional are
+// rejected with ErrMaxNotionalExceeded, after lot rounding.
+func TestOrderSizing_MaxNotional(t *testing.T) {
+	sizing := OrderSizing{MaxNotional: 10000, EquityLotSize: 1}
+	assert.True(t, sizing.Enabled())
+
+	_, err := sizing.Normalize(context.Background(), models.Order{Symbol: "AAPL", Quantity: 100}, 150, 0)
+	require.ErrorIs(t, err, ErrMaxNotionalExceeded)
+	assert.Contains(t, err.Error(), "order value 15000.00 is above the maximum notional 10000.00")
+
+	// 66.9 shares rounds to 66, worth 9900
+	result, err := sizing.Normalize(context.Background(), models.Order{Symbol: "AAPL", Quantity: 66.9}, 150, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 66.0, result.Quantity)
+
+	// Without a price the order cannot be valued, so it is rejected
+	_, err = sizing.Normalize(context.Background(), models.Order{Symbol: "AAPL", Quantity: 1000}, 0, 0)
+	assert.ErrorIs(t, err, ErrNotionalUnknown)
+
+	// Lot rounding alone needs no price
+	result, err = OrderSizing{EquityLotSize: 1}.Normalize(context.Background(), models.Order{Symbol: "AAPL", Quantity: 2.5}, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, result.Quantity)
+}
+
+// TestOrderSizing_ClosingSell verifies a sell of the whole position is not
+// rounded or held to the minimum notional, while partial sells and buys are.
+func TestOrderSizing_ClosingSell(t *testing.T) {
+	sizing := OrderSizing{EquityLotSize: 1, CryptoLotSize: 0.001}
+	ctx := context.Background()
+
+	result, err := sizing.Normalize(ctx, models.Order{Symbol: "AAPL", Side: models.OrderSideSell, Quantity: 2.5}, 0, 2.5)
+	require.NoError(t, err)
+	assert.Equal(t, 2.5, result.Quantity, "closing sell keeps the fractional share")
+
+	result, err = sizing.Normalize(ctx, models.Order{Symbol: "BTC-USD", Side: models.OrderSideSell, Quantity: 0.0004}, 0, 0.0004)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0004, result.Quantity, "dust below the lot size can still be closed")
+
+	result, err = sizing.Normalize(ctx, models.Order{Symbol: "AAPL", Side: models.OrderSideSell, Quantity: 1.5}, 0, 2.5)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, result.Quantity, "partial sell is rounded")
+
+	sizing.MinNotional = 10
+	result, err = sizing.Normalize(ctx, models.Order{Symbol: "BTC-USD", Side: models.OrderSideSell, Quantity: 0.0004}, 20000, 0.0004)
+	require.NoError(t, err, "dust below the minimum notional can still be closed")
+	assert.Equal(t, 0.0004, result.Quantity)
+
+	_, err = sizing.Normalize(ctx, models.Order{Symbol: "BTC-USD", Side: models.OrderSideSell, Quantity: 0.001}, 5000, 0.002)
+	assert.ErrorContains(t, err, "below the minimum notional", "partial sells still need the minimum")
+	sizing.MinNotional = 0
+
+	result, err = sizing.Normalize(ctx, models.Order{Symbol: "AAPL", Side: models.OrderSideBuy, Quantity: 2.5}, 0, 2.5)
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, result.Quantity, "buy is rounded")
+}
+
+// TestOrderSizing_ZeroValue verifies the zero value leaves orders unchanged.
+func TestOrderSizing_ZeroValue(t *testing.T) {
+	var sizing OrderSizing
+	assert.False(t, sizing.Enabled())
+
+	order := models.Order{Symbol: "BTC-USD", Quantity: 0.000123}
+	result, err := sizing.Normalize(context.Background(), order, 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, order.Quantity, result.Quantity)
+}
+
+// staticLotSizes is a LotSizeSource backed by a fixed map.
+type staticLotSizes map[string]float64
+
+func (s staticLotSizes) LotSize(ctx context.Context, symbol string) (float64, bool) {
+	step, ok := s[symbol]
+	return step, ok
+}
+
+// TestOrderSizing_ExchangeLotSize verifies exchange-reported steps take
+// precedence over the asset-class defaults.
+func TestOrderSizing_ExchangeLotSize(t *testing.T) {
+	sizing := OrderSizing{
+		EquityLotSize: 1,
+		CryptoLotSize: 0.001,
+		Exchange:      staticLotSizes{"BTC-USD": 0.00001},
+	}
+	assert.True(t, OrderSizing{Exchange: staticLotSizes{}}.Enabled())
+
+	assert.Equal(t, 0.00001, sizing.LotSize(context.Background(), "BTC-USD"))
+	assert.Equal(t, 0.001, sizing.LotSize(context.Background(), "ETH-USD"))
+	assert.Equal(t, 1.0, sizing.LotSize(context.Background(), "AAPL"))
+	// A joined pair the exchange can't report still rounds as crypto
+	assert.Equal(t, 0.001, sizing.LotSize(context.Background(), "SOLUSDT"))
+
+	result, err := sizing.Normalize(context.Background(), models.Order{Symbol: "BTC-USD", Quantity: 0.1234567}, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0.12345, result.Quantity)
+
+	result, err = sizing.Normalize(context.Background(), models.Order{Symbol: "ETH-USD", Quantity: 0.1234567}, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0.123, result.Quantity)
+}
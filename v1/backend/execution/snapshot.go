@@ -0,0 +1,54 @@
+// Package execution provides account snapshots for initializing clients.
+package execution
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// AccountSnapshot is the account state a client needs before it can apply
+// incremental order and position updates.
+type AccountSnapshot struct {
+	Positions  []models.Position `json:"positions"`
+	OpenOrders []models.Order    `json:"open_orders"` // Pending, submitted and partially filled, newest first
+	Balance    *models.Balance   `json:"balance"`
+}
+
+// AccountSnapshot returns current positions, open orders and balance.
+//
+// Returns:
+//   - *AccountSnapshot: The current account state
+//   - error: If positions or balance could not be read from the broker
+func (om *OrderManager) AccountSnapshot() (*AccountSnapshot, error) {
+	positions, err := om.broker.GetPositions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get positions: %w", err)
+	}
+	balance, err := om.broker.GetBalance()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	om.mu.RLock()
+	open := make([]models.Order, 0)
+	for _, order := range om.orders {
+		switch order.Status {
+		case models.OrderStatusPending, models.OrderStatusSubmitted, models.OrderStatusPartiallyFilled:
+			open = append(open, order)
+		}
+	}
+	om.mu.RUnlock()
+	sort.Slice(open, func(i, j int) bool {
+		if !open[i].CreatedAt.Equal(open[j].CreatedAt) {
+			return open[i].CreatedAt.After(open[j].CreatedAt)
+		}
+		return open[i].ID > open[j].ID
+	})
+
+	if positions == nil {
+		positions = []models.Position{}
+	}
+	return &AccountSnapshot{Positions: positions, OpenOrders: open, Balance: balance}, nil
+}
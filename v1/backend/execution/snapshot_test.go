@@ -0,0 +1,39 @@
+package execution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOrderManager_AccountSnapshot verifies the snapshot holds positions,
+// balance and only the orders still open.
+func TestOrderManager_AccountSnapshot(t *testing.T) {
+	broker := NewPaperBroker(100000)
+	broker.SetPrice("AAPL", 100)
+	require.NoError(t, broker.Connect())
+	om := NewOrderManager(broker, nil, nil, nil)
+	ctx := context.Background()
+
+	snapshot, err := om.AccountSnapshot()
+	require.NoError(t, err)
+	assert.Empty(t, snapshot.Positions)
+	assert.NotNil(t, snapshot.Positions)
+	assert.Empty(t, snapshot.OpenOrders)
+	assert.Equal(t, 100000.0, snapshot.Balance.Cash)
+
+	_, err = om.CreateMarketOrder(ctx, "AAPL", models.OrderSideBuy, 10)
+	require.NoError(t, err)
+	limit, err := om.CreateLimitOrder(ctx, "AAPL", models.OrderSideBuy, 5, 90)
+	require.NoError(t, err)
+
+	snapshot, err = om.AccountSnapshot()
+	require.NoError(t, err)
+	require.Len(t, snapshot.Positions, 1)
+	assert.Equal(t, 10.0, snapshot.Positions[0].Quantity)
+	require.Len(t, snapshot.OpenOrders, 1)
+	assert.Equal(t, limit.ID, snapshot.OpenOrders[0].ID)
+}
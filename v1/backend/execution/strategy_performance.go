@@ -0,0 +1,156 @@
+// Package execution provides live trading performance by strategy.
+package execution
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// StrategyPerformance is the realized performance and open exposure of the
+// orders placed by one strategy. Amounts are in the base currency.
+type StrategyPerformance struct {
+	// Strategy is the strategy name, or "manual" for hand-placed orders.
+	Strategy string `json:"strategy"`
+	// Trades is the number of sell fills that closed held quantity.
+	Trades int `json:"trades"`
+	// WinningTrades is the number of closing fills with a positive P&L.
+	WinningTrades int `json:"winning_trades"`
+	// LosingTrades is the number of closing fills with a zero or negative P&L.
+	LosingTrades int `json:"losing_trades"`
+	// WinRate is WinningTrades / Trades (0 without trades).
+	WinRate float64 `json:"win_rate"`
+	// TotalPnL is the gross realized profit/loss.
+	TotalPnL float64 `json:"total_pnl"`
+	// AveragePnL is TotalPnL / Trades (0 without trades).
+	AveragePnL float64 `json:"average_pnl"`
+	// TotalFees is the sum of commissions paid.
+	TotalFees float64 `json:"total_fees"`
+	// OpenExposure is the market value of the quantity still held, at the
+	// broker's latest price or position price, or at cost where neither is
+	// known.
+	OpenExposure float64 `json:"open_exposure"`
+}
+
+// StrategyPerformance computes realized performance per strategy from the
+// trade history. Each strategy's holdings are tracked separately at average
+// cost, so a strategy's sells only close what that strategy bought. Fills of
+// orders without a strategy, and imported positions, are attributed to
+// "manual". Holdings are built from the full history, while trades, P&L and
+// fees only count fills executed within the range. Open exposure is always
+// current.
+//
+// Args:
+//   - start: Earliest fill counted (zero for no lower bound)
+//   - end: Latest fill counted (zero for no upper bound)
+//
+// Returns:
+//   - []StrategyPerformance: One row per strategy with fills, sorted by name
+//   - error: If trades or positions could not be read or valued
+func (om *OrderManager) StrategyPerformance(start, end time.Time) ([]StrategyPerformance, error) {
+	om.mu.RLock()
+	base := om.baseCurrency
+	rates := om.fxRates
+	imports := append([]models.Position{}, om.imports...)
+	strategyOf := make(map[string]string, len(om.orders))
+	for id, order := range om.orders {
+		strategyOf[id] = order.StrategyName
+	}
+	om.mu.RUnlock()
+
+	trades, err := om.tradeHistory()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trades: %w", err)
+	}
+	positions, err := om.broker.GetPositions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get positions: %w", err)
+	}
+	prices := make(map[string]float64, len(positions))
+	for _, p := range positions {
+		prices[p.Symbol] = p.CurrentPrice
+	}
+
+	type holdingKey struct{ strategy, symbol string }
+	strategyKey := func(trade models.Trade) holdingKey {
+		strategy := strategyOf[trade.OrderID]
+		if strategy == "" {
+			strategy = models.TagManual
+		}
+		return holdingKey{strategy, trade.Symbol}
+	}
+	// Imported positions weren't bought by any strategy
+	importKey := func(p models.Position) holdingKey { return holdingKey{models.TagManual, p.Symbol} }
+
+	rows := make(map[string]*StrategyPerformance)
+	row := func(strategy string) *StrategyPerformance {
+		r, ok := rows[strategy]
+		if !ok {
+			r = &StrategyPerformance{Strategy: strategy}
+			rows[strategy] = r
+		}
+		return r
+	}
+
+	holdings, err := replayCostBasis(trades, imports, strategyKey, importKey, func(trade models.Trade, closed, averageCost float64) error {
+		r := row(strategyKey(trade).strategy)
+		rate, err := rates.Rate(data.QuoteCurrency(trade.Symbol), base)
+		if err != nil {
+			return fmt.Errorf("failed to value %s: %w", trade.Symbol, err)
+		}
+		inRange := (start.IsZero() || !trade.ExecutedAt.Before(start)) &&
+			(end.IsZero() || !trade.ExecutedAt.After(end))
+		if !inRange {
+			return nil
+		}
+		r.TotalFees += trade.Commission * rate
+		if closed > 0 {
+			pnl := (trade.Price - averageCost) * closed * rate
+			r.Trades++
+			r.TotalPnL += pnl
+			if pnl > 0 {
+				r.WinningTrades++
+			} else {
+				r.LosingTrades++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for key, h := range holdings {
+		if h.quantity <= 0 {
+			continue
+		}
+		price := prices[key.symbol]
+		if quoter, ok := om.broker.(PriceQuoter); ok {
+			if latest, ok := quoter.LatestPrice(key.symbol); ok && latest > 0 {
+				price = latest
+			}
+		}
+		if price <= 0 {
+			price = h.averageCost
+		}
+		rate, err := rates.Rate(data.QuoteCurrency(key.symbol), base)
+		if err != nil {
+			return nil, fmt.Errorf("failed to value %s: %w", key.symbol, err)
+		}
+		row(key.strategy).OpenExposure += h.quantity * price * rate
+	}
+
+	result := make([]StrategyPerformance, 0, len(rows))
+	for _, row := range rows {
+		if row.Trades > 0 {
+			row.WinRate = float64(row.WinningTrades) / float64(row.Trades)
+			row.AveragePnL = row.TotalPnL / float64(row.Trades)
+		}
+		result = append(result, *row)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Strategy < result[j].Strategy })
+	return result, nil
+}
@@ -0,0 +1,73 @@
+package execution
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOrderManager_StrategyPerformance verifies fills are grouped by
+// strategy, with hand-placed orders under "manual", and that each strategy
+// only closes its own holdings.
+func TestOrderManager_StrategyPerformance(t *testing.T) {
+	broker := NewPaperBroker(100000)
+	require.NoError(t, broker.Connect())
+	om := NewOrderManager(broker, nil, nil, nil)
+	ctx := context.Background()
+
+	submit := func(strategy string, side models.OrderSide, quantity float64) {
+		t.Helper()
+		_, err := om.SubmitOrder(ctx, models.Order{
+			Symbol: "AAPL", Side: side, Type: models.OrderTypeMarket, Quantity: quantity, StrategyName: strategy,
+		})
+		require.NoError(t, err)
+	}
+
+	broker.SetPrice("AAPL", 100)
+	submit("ma_crossover", models.OrderSideBuy, 10)
+	broker.SetPrice("AAPL", 110)
+	submit("ma_crossover", models.OrderSideSell, 10)
+	submit("", models.OrderSideBuy, 5)
+	broker.SetPrice("AAPL", 105)
+	submit("", models.OrderSideSell, 5)
+	submit("ma_crossover", models.OrderSideBuy, 2)
+	// Nothing for RSI to close, so its sell realizes nothing
+	submit("rsi_momentum", models.OrderSideSell, 1)
+	broker.SetPrice("AAPL", 120)
+
+	rows, err := om.StrategyPerformance(time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	ma, manual, rsi := rows[0], rows[1], rows[2]
+	assert.Equal(t, "ma_crossover", ma.Strategy)
+	assert.Equal(t, 1, ma.Trades)
+	assert.Equal(t, 1, ma.WinningTrades)
+	assert.Equal(t, 1.0, ma.WinRate)
+	assert.InDelta(t, 100.0, ma.TotalPnL, 1e-9)
+	assert.InDelta(t, 100.0, ma.AveragePnL, 1e-9)
+	assert.InDelta(t, 240.0, ma.OpenExposure, 1e-9, "2 shares at the current price")
+
+	assert.Equal(t, models.TagManual, manual.Strategy)
+	assert.Equal(t, 1, manual.Trades)
+	assert.Equal(t, 1, manual.LosingTrades)
+	assert.Equal(t, 0.0, manual.WinRate)
+	assert.InDelta(t, -25.0, manual.TotalPnL, 1e-9)
+	assert.Zero(t, manual.OpenExposure)
+
+	assert.Equal(t, "rsi_momentum", rsi.Strategy)
+	assert.Zero(t, rsi.Trades)
+	assert.Zero(t, rsi.TotalPnL)
+
+	// A range after every fill counts no trades but still reports exposure
+	rows, err = om.StrategyPerformance(time.Now().Add(time.Hour), time.Time{})
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Zero(t, rows[0].Trades)
+	assert.Zero(t, rows[0].TotalPnL)
+	assert.InDelta(t, 240.0, rows[0].OpenExposure, 1e-9)
+}
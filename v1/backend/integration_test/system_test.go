@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"path/filepath"
 	"testing"
 	"time"
@@ -311,7 +312,7 @@ func TestSystemFlow_BacktestEndToEnd(t *testing.T) {
 		"initial_capital": 10000,
 	}
 	body, _ := json.Marshal(payload)
-	resp, err := client.Post(server.URL+"/api/v1/backtests", "application/json", bytes.NewReader(body))
+	resp, err := client.Post(server.URL+"/api/v1/backtests?sync=true", "application/json", bytes.NewReader(body))
 	require.NoError(t, err)
 	require.Equal(t, http.StatusAccepted, resp.StatusCode)
 
@@ -405,6 +406,10 @@ func TestSystemFlow_PerformanceMetrics(t *testing.T) {
 	_, err = orderManager.CreateMarketOrder(ctx, "AAPL", models.OrderSideSell, 10.0)
 	require.NoError(t, err)
 
+	// Record the resulting account equity
+	_, err = orderManager.RecordEquitySnapshot(ctx)
+	require.NoError(t, err)
+
 	// 2. Set Initial Capital via API
 	t.Log("Setting Initial Capital")
 	configPayload := map[string]interface{}{"initial_capital": 50000.0}
@@ -433,4 +438,20 @@ func TestSystemFlow_PerformanceMetrics(t *testing.T) {
 	assert.Equal(t, 1.0, totalTrades, "Expected 1 closed trade")
 	assert.Equal(t, 1.0, winningTrades, "Expected 1 winning trade")
 	assert.Equal(t, 100.0, totalPnL, "Expected $100 profit (10 * 10)")
+
+	curve := metrics["equity_curve"].([]interface{})
+	require.Len(t, curve, 1)
+	assert.Equal(t, 100100.0, curve[0].(map[string]interface{})["cash"])
+
+	// Snapshots before the window are excluded
+	since := url.QueryEscape(time.Now().Add(time.Hour).Format(time.RFC3339))
+	resp, err = server.Client().Get(server.URL + "/api/v1/portfolio/performance?since=" + since)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&metrics))
+	assert.Empty(t, metrics["equity_curve"])
+
+	resp, err = server.Client().Get(server.URL + "/api/v1/portfolio/performance?since=yesterday")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 }
@@ -44,6 +44,10 @@ func main() {
 	}
 	zerolog.SetGlobalLevel(level)
 
+	for _, warning := range cfg.Warnings() {
+		log.Warn().Msg(warning)
+	}
+
 	// Log trading mode warning
 	if cfg.IsLive() {
 		log.Warn().Msg("⚠️  LIVE TRADING MODE - Real money at risk!")
@@ -53,6 +57,12 @@ func main() {
 
 	// Initialize WebSocket Manager
 	wsManager := realtime.NewWebSocketManager()
+	wsManager.SetMaxClients(cfg.WSMaxClients)
+	broadcastThrottles, err := config.ParseBroadcastThrottles(cfg.WSBroadcastThrottle)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid broadcast throttle")
+	}
+	wsManager.SetBroadcastThrottles(broadcastThrottles)
 	go wsManager.Run()
 
 	// Initialize Strategy Registry
@@ -69,6 +79,9 @@ func main() {
 		if err != nil {
 			log.Fatal().Err(err).Msgf("Failed to create strategy: %s", strategyName)
 		}
+		if webhook, ok := strategy.(*strategies.WebhookSignalStrategy); ok {
+			webhook.SetSymbolAliases(cfg.Aliases())
+		}
 		if err := registry.Register(strategy); err != nil {
 			log.Fatal().Err(err).Msgf("Failed to register strategy: %s", strategyName)
 		}
@@ -81,9 +94,37 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msgf("Failed to create data provider: %s", cfg.DataProvider)
 	}
+	// The wrappers below hide optional capabilities, so keep the raw
+	// provider for exchange lot sizes and replay stepping
+	var lotSizes execution.LotSizeSource
+	if lp, ok := provider.(data.LotSizeProvider); ok {
+		lotSizes = data.NewLotSizeCache(lp, cfg.ProviderTimeout)
+	}
+	tickAdvancer, _ := provider.(data.TickAdvancer)
+	provider = data.NewInstrumentedProvider(provider)
+	gapChecked := data.NewGapCheckedProvider(data.NewResamplingProvider(provider), data.GapPolicy(cfg.DataGapPolicy))
+	var calendar *engine.USEquityCalendar
+	if cfg.TradingCalendar == "us_equity" {
+		calendar, err = engine.NewUSEquityCalendar()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load trading calendar")
+		}
+		gapChecked.SetTradingDays(calendar)
+	}
+	provider = data.NewSyntheticProvider(gapChecked)
+	if cfg.CandleTimezone != "" {
+		candleLocation, err := time.LoadLocation(cfg.CandleTimezone)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("Failed to load candle timezone: %s", cfg.CandleTimezone)
+		}
+		provider = data.NewTimezoneProvider(provider, candleLocation)
+	}
 
 	// Initialize Database
-	db, err := data.NewDB(cfg.DatabasePath)
+	db, err := data.OpenDB(cfg.DatabasePath, data.DBOptions{
+		BusyTimeout:  cfg.DBBusyTimeout,
+		MaxOpenConns: cfg.DBMaxOpenConns,
+	})
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to initialize database")
 	}
@@ -92,15 +133,68 @@ func main() {
 	// Initialize Order Store
 	orderStore := data.NewOrderStore(db)
 
-	// Initialize Execution Layer (Paper Trading for now)
-	initialCash := 100000.0
-	broker := execution.NewPaperBroker(initialCash)
+	// Initialize Execution Layer (Paper Trading for now). A stored baseline
+	// wins over the configured one so restarts keep the same starting cash.
+	initialCapital := cfg.InitialCapital
+	if initialCapital == 0 {
+		initialCapital = execution.DefaultInitialCapital
+	}
+	if stored, err := execution.StoredInitialCapital(orderStore); err != nil {
+		log.Warn().Err(err).Msg("Failed to load stored initial capital")
+	} else if stored > 0 {
+		if stored != initialCapital {
+			log.Info().
+				Float64("stored", stored).
+				Float64("configured", initialCapital).
+				Msg("Using stored initial capital; reset the paper account to apply INITIAL_CAPITAL")
+		}
+		initialCapital = stored
+	}
+	broker := execution.NewPaperBroker(initialCapital)
+	broker.SetPriceProvider(provider, execution.DefaultPriceCacheTTL)
+	broker.SetFillOptions(execution.PaperFillOptions{
+		SpreadBps:   cfg.PaperSpreadBps,
+		DepthImpact: cfg.PaperDepthImpact,
+	})
+	broker.SetFeeSchedule(execution.FeeSchedule{
+		PerOrder: cfg.PaperFeePerOrder,
+		Bps:      cfg.PaperFeeBps,
+		Minimum:  cfg.PaperFeeMinimum,
+	})
 	if err := broker.Connect(); err != nil {
 		log.Fatal().Err(err).Msg("Failed to connect to paper broker")
 	}
 
+	// Only the configured risk limits apply; the rest are left at 0 (disabled)
+	riskManager := execution.NewRiskManager(&execution.RiskConfig{
+		MaxDailyTrades:    cfg.MaxDailyTrades,
+		MaxDailyLoss:      cfg.MaxDailyLoss,
+		MinCashReserve:    cfg.MinCashReserve,
+		MinCashReservePct: cfg.MinCashReservePct,
+	}, broker)
+
 	// Initialize Order Manager with persistence and WebSocket
-	orderManager := execution.NewOrderManager(broker, nil, orderStore, wsManager)
+	orderManager := execution.NewOrderManager(broker, riskManager, orderStore, wsManager)
+	wsManager.SetSnapshot(func() (interface{}, error) { return orderManager.AccountSnapshot() })
+	orderManager.SetOrderSizing(execution.OrderSizing{
+		MinNotional:   cfg.OrderMinNotional,
+		MaxNotional:   cfg.OrderMaxNotional,
+		EquityLotSize: cfg.OrderEquityLotSize,
+		CryptoLotSize: cfg.OrderCryptoLotSize,
+		Exchange:      lotSizes,
+	})
+	orderManager.SetBaseCurrency(cfg.BaseCurrency, data.NewStaticFXRates())
+	orderManager.SetPriceProvider(provider)
+	if cfg.IsLive() {
+		orderManager.SetOrderConfirmation(execution.OrderConfirmation{
+			Threshold: cfg.OrderConfirmThreshold,
+			Window:    cfg.OrderConfirmWindow,
+		})
+	}
+
+	if _, err := orderManager.EnsureInitialCapital(initialCapital, !cfg.IsDryRun()); err != nil {
+		log.Warn().Err(err).Msg("Failed to record initial capital")
+	}
 
 	// Restore orders from database
 	if err := orderManager.LoadOrders(); err != nil {
@@ -110,20 +204,68 @@ func main() {
 	// Initialize Notification System
 	notifStore := data.NewNotificationStore(db)
 	notifManager := notifications.NewManager(notifStore, wsManager)
+	notifManager.SetThrottle(cfg.NotificationDedupWindow, cfg.NotificationRateLimit)
+	quietHours, err := notifications.ParseQuietHours(cfg.NotificationQuietStart, cfg.NotificationQuietEnd, cfg.NotificationQuietTZ)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid notification quiet hours")
+	}
+	notifManager.SetQuietHours(quietHours)
+	riskManager.SetNotifier(notifManager)
+	if cfg.OrderFillNotify {
+		orderManager.SetNotifier(notifManager)
+	}
 
 	// Initialize Trading Engine
-	// Hardcoded symbols for now
-	symbols := []string{"SPY", "BTC-USD", "ETH-USD", "AAPL", "MSFT"}
 	tradingEngine := engine.NewTradingEngine(
 		provider,
 		registry,
 		orderManager,
 		wsManager,
-		symbols,
+		cfg.Aliases().ResolveAll(cfg.TradingSymbols),
 		1*time.Minute,    // Tick every minute
 		100*24*time.Hour, // Lookback 100 days
 		cfg.CloseOnShutdown,
 	)
+	tradingEngine.SetAlignTicks(cfg.EngineAlignTicks)
+	tradingEngine.SetWarmupTicks(cfg.EngineWarmupTicks)
+	tradingEngine.SetConcurrency(cfg.EngineConcurrency)
+	tradingEngine.SetTickAdvancer(tickAdvancer)
+	tradingEngine.SetStaleDataGuard(engine.StaleDataGuard{
+		CryptoIntervals: cfg.StaleCryptoIntervals,
+		EquityIntervals: cfg.StaleEquityIntervals,
+		Notify:          cfg.StaleDataNotify,
+	})
+	tradingEngine.SetPriming(cfg.EnginePrimeData, cfg.EnginePrimeTimeout)
+	tradingEngine.SetOrderRetry(cfg.OrderRetryAttempts, cfg.OrderRetryDelay)
+	tradingEngine.SetNotifier(notifManager)
+	tradingEngine.SetReconciliation(cfg.ReconcileOnStart, cfg.ReconcileInterval)
+	tradingEngine.SetConnectionMonitor(cfg.BrokerCheckInterval, cfg.BrokerReconnectBackoff, cfg.BrokerReconnectMaxBackoff)
+	tradingEngine.SetEquitySnapshots(cfg.EquitySnapshotInterval)
+	tradingEngine.SetSignalLog(data.NewSignalStore(db), cfg.LogSignals)
+	tradingEngine.SetHeartbeat(cfg.EngineHeartbeat)
+	tradingEngine.SetOrderThrottle(cfg.EngineOrderThrottle)
+	tradingEngine.SetFetchFailureLimit(cfg.EngineFetchFailures)
+	symbolIntervals, err := engine.ParseSymbolIntervals(cfg.SymbolIntervals, cfg.Aliases())
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid symbol intervals")
+	}
+	if err := tradingEngine.SetSymbolIntervals(symbolIntervals); err != nil {
+		log.Fatal().Err(err).Msg("Invalid symbol intervals")
+	}
+	autoExitOverrides, err := engine.ParseAutoExitOverrides(cfg.AutoExitOverrides, cfg.Aliases())
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid auto-exit overrides")
+	}
+	tradingEngine.SetAutoExit(engine.AutoExit{
+		Default: engine.AutoExitLevels{
+			TakeProfitPct: cfg.AutoExitTakeProfitPct,
+			StopLossPct:   cfg.AutoExitStopLossPct,
+		},
+		Symbols: autoExitOverrides,
+	})
+	if calendar != nil {
+		tradingEngine.SetTradingCalendar(calendar, cfg.CalendarFetchWhenClosed)
+	}
 
 	// Start Trading Engine
 	ctx, cancelEngine := context.WithCancel(context.Background())
@@ -173,5 +315,13 @@ func main() {
 		log.Fatal().Err(err).Msg("Server forced to shutdown")
 	}
 
+	// Step 3: Let queued and running backtests finish
+	if err := router.Shutdown(ctxShutdown); err != nil {
+		log.Error().Err(err).Msg("Backtest shutdown encountered errors")
+	}
+
+	// Step 4: Stop broadcasting, including throttled messages still held back
+	wsManager.Close()
+
 	log.Info().Msg("Sherwood exited gracefully")
 }
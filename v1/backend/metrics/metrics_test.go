@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, r *Registry) string {
+	t.Helper()
+	var b strings.Builder
+	require.NoError(t, r.WritePrometheus(&b))
+	return b.String()
+}
+
+func TestCounter(t *testing.T) {
+	r := NewRegistry()
+	c := NewCounter(r, "test_orders_total", "Orders.", "side")
+	c.Inc("buy")
+	c.Add(2, "buy")
+	c.Inc("sell")
+	c.Add(-5, "sell") // Ignored
+
+	assert.Equal(t, 3.0, c.Value("buy"))
+	assert.Equal(t, `# HELP test_orders_total Orders.
+# TYPE test_orders_total counter
+test_orders_total{side="buy"} 3
+test_orders_total{side="sell"} 1
+`, scrape(t, r))
+}
+
+func TestHistogram(t *testing.T) {
+	r := NewRegistry()
+	h := NewHistogram(r, "test_duration_seconds", "Durations.", []float64{0.1, 1})
+	h.Observe(0.05)
+	h.Observe(0.5)
+	h.Observe(3)
+
+	assert.Equal(t, uint64(3), h.Count())
+	assert.Equal(t, `# HELP test_duration_seconds Durations.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{le="0.1"} 1
+test_duration_seconds_bucket{le="1"} 2
+test_duration_seconds_bucket{le="+Inf"} 3
+test_duration_seconds_sum 3.55
+test_duration_seconds_count 3
+`, scrape(t, r))
+}
+
+func TestGaugeFuncAndEscaping(t *testing.T) {
+	r := NewRegistry()
+	NewGaugeFunc(r, "test_gauge", "A\\gauge\nhelp.", func() float64 { return 1.5 })
+	NewCounter(r, "test_labels_total", "Labels.", "name").Inc("say \"hi\"\n")
+
+	out := scrape(t, r)
+	assert.Contains(t, out, "# HELP test_gauge A\\\\gauge\\nhelp.\n# TYPE test_gauge gauge\ntest_gauge 1.5\n")
+	assert.Contains(t, out, `test_labels_total{name="say \"hi\"\n"} 1`)
+}
+
+func TestRegistry_DuplicatePanics(t *testing.T) {
+	r := NewRegistry()
+	NewCounter(r, "test_total", "Test.")
+	assert.Panics(t, func() { NewCounter(r, "test_total", "Test.") })
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	out := scrape(t, Default)
+	for _, name := range []string{
+		"sherwood_orders_placed_total",
+		"sherwood_orders_rejected_total",
+		"sherwood_signals_generated_total",
+		"sherwood_engine_tick_duration_seconds",
+		"sherwood_provider_request_duration_seconds",
+		"go_goroutines",
+		"go_memstats_alloc_bytes",
+	} {
+		assert.Contains(t, out, "# TYPE "+name+" ")
+	}
+}
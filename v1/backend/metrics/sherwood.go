@@ -0,0 +1,67 @@
+// Package metrics provides the engine's process-wide metrics on the default
+// registry.
+package metrics
+
+import (
+	"runtime"
+	"time"
+)
+
+// Default is the registry served by the /metrics endpoint.
+var Default = NewRegistry()
+
+// Order rejection reasons for OrdersRejected.
+const (
+	RejectValidation = "validation"
+	RejectRisk       = "risk"
+	RejectDisabled   = "kill_switch"
+	RejectBroker     = "broker"
+)
+
+var startTime = time.Now()
+
+var (
+	// OrdersPlaced counts orders accepted by the broker.
+	OrdersPlaced = NewCounter(Default, "sherwood_orders_placed_total",
+		"Orders accepted by the broker.", "side")
+	// OrdersRejected counts orders that were not placed, by reason.
+	OrdersRejected = NewCounter(Default, "sherwood_orders_rejected_total",
+		"Orders rejected before or by the broker.", "reason")
+	// SignalsGenerated counts non-hold strategy signals.
+	SignalsGenerated = NewCounter(Default, "sherwood_signals_generated_total",
+		"Buy and sell signals generated by strategies.", "strategy", "type")
+	// EngineTickDuration observes how long each engine tick takes.
+	EngineTickDuration = NewHistogram(Default, "sherwood_engine_tick_duration_seconds",
+		"Time taken to process all symbols in an engine tick.", nil)
+	// ProviderRequestDuration observes data provider request latency.
+	ProviderRequestDuration = NewHistogram(Default, "sherwood_provider_request_duration_seconds",
+		"Data provider request latency.", nil, "provider", "method")
+	// ProviderErrors counts failed data provider requests.
+	ProviderErrors = NewCounter(Default, "sherwood_provider_errors_total",
+		"Data provider requests that returned an error.", "provider", "method")
+)
+
+func init() {
+	NewGaugeFunc(Default, "sherwood_uptime_seconds", "Seconds since the process started.", func() float64 {
+		return time.Since(startTime).Seconds()
+	})
+	NewGaugeFunc(Default, "go_goroutines", "Number of goroutines that currently exist.", func() float64 {
+		return float64(runtime.NumGoroutine())
+	})
+	NewGaugeFunc(Default, "go_memstats_alloc_bytes", "Bytes of allocated heap objects.", func() float64 {
+		return float64(readMemStats().Alloc)
+	})
+	NewGaugeFunc(Default, "go_memstats_sys_bytes", "Bytes of memory obtained from the OS.", func() float64 {
+		return float64(readMemStats().Sys)
+	})
+	NewGaugeFunc(Default, "go_gc_cycles", "Completed GC cycles.", func() float64 {
+		return float64(readMemStats().NumGC)
+	})
+}
+
+// readMemStats reads runtime memory statistics.
+func readMemStats() runtime.MemStats {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m
+}
@@ -16,6 +16,11 @@ const (
 	NotificationTrade   NotificationType = "trade"
 )
 
+// NotificationCriticalKey is the metadata key that marks a notification as
+// critical. Critical notifications, like errors, are delivered even during
+// quiet hours.
+const NotificationCriticalKey = "critical"
+
 // Notification represents a system event or alert for the user.
 type Notification struct {
 	ID        string           `json:"id" db:"id"`
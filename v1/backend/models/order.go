@@ -1,6 +1,9 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -44,8 +47,98 @@ const (
 	OrderStatusCancelled OrderStatus = "cancelled"
 	// OrderStatusRejected indicates the order was rejected.
 	OrderStatusRejected OrderStatus = "rejected"
+	// OrderStatusPendingConfirmation indicates a large order is staged and
+	// is only sent to the broker once confirmed.
+	OrderStatusPendingConfirmation OrderStatus = "pending_confirmation"
 )
 
+// TimeInForce controls how long an order stays open.
+type TimeInForce string
+
+const (
+	// TimeInForceGTC keeps the order open until filled or cancelled (default).
+	TimeInForceGTC TimeInForce = "gtc"
+	// TimeInForceDay cancels the order if unfilled at the end of the trading day.
+	TimeInForceDay TimeInForce = "day"
+	// TimeInForceIOC fills what is possible immediately and cancels the rest.
+	TimeInForceIOC TimeInForce = "ioc"
+)
+
+// IsValid reports whether the time in force is a known value. Empty is valid
+// and treated as GTC.
+func (t TimeInForce) IsValid() bool {
+	switch t {
+	case "", TimeInForceGTC, TimeInForceDay, TimeInForceIOC:
+		return true
+	}
+	return false
+}
+
+// TagManual marks orders placed by hand rather than by a strategy.
+const TagManual = "manual"
+
+// TagAutoExit marks orders the engine placed to close a position at its
+// take-profit or stop-loss level.
+const TagAutoExit = "auto_exit"
+
+// TagExternalSignal marks orders placed for a signal ingested from an
+// external system.
+const TagExternalSignal = "external_signal"
+
+// Tags are free-form labels on an order, stored as a JSON array.
+type Tags []string
+
+// Value encodes the tags as JSON for storage.
+func (t Tags) Value() (driver.Value, error) {
+	if len(t) == 0 {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(t))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan decodes tags stored as JSON.
+func (t *Tags) Scan(src interface{}) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return fmt.Errorf("cannot scan %T into Tags", src)
+	}
+	if len(raw) == 0 {
+		*t = nil
+		return nil
+	}
+	var tags []string
+	if err := json.Unmarshal(raw, &tags); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		tags = nil
+	}
+	*t = tags
+	return nil
+}
+
+// Has reports whether the tags contain a tag.
+func (t Tags) Has(tag string) bool {
+	for _, v := range t {
+		if v == tag {
+			return true
+		}
+	}
+	return false
+}
+
 // Order represents a trading order.
 type Order struct {
 	// ID is the unique identifier for the order.
@@ -58,18 +151,50 @@ type Order struct {
 	Type OrderType `json:"type" db:"type"`
 	// Quantity is the number of units to trade.
 	Quantity float64 `json:"quantity" db:"quantity"`
-	// Price is the limit/stop price (0 for market orders).
-	Price float64 `json:"price" db:"price"`
+	// Price is the limit/stop price (0 for market orders, omitted from JSON).
+	Price float64 `json:"price,omitempty" db:"price"`
 	// Status is the current order status.
 	Status OrderStatus `json:"status" db:"status"`
-	// FilledQuantity is the quantity that has been filled.
-	FilledQuantity float64 `json:"filled_quantity" db:"filled_quantity"`
-	// AveragePrice is the average fill price.
-	AveragePrice float64 `json:"average_price" db:"average_price"`
+	// FilledQuantity is the quantity that has been filled (omitted from JSON
+	// before the first fill).
+	FilledQuantity float64 `json:"filled_quantity,omitempty" db:"filled_quantity"`
+	// AveragePrice is the average fill price (omitted from JSON before the
+	// first fill).
+	AveragePrice float64 `json:"average_price,omitempty" db:"average_price"`
 	// CreatedAt is when the order was created.
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	// UpdatedAt is when the order was last updated.
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	// TimeInForce controls when an unfilled order expires (empty = GTC).
+	TimeInForce TimeInForce `json:"time_in_force,omitempty" db:"-"`
+	// ParentID is the entry order ID for bracket exit orders (empty otherwise).
+	ParentID string `json:"parent_id,omitempty" db:"parent_id"`
+	// LinkedOrderID is the one-cancels-other sibling of a bracket exit order.
+	// When either order fills, the linked order is cancelled.
+	LinkedOrderID string `json:"linked_order_id,omitempty" db:"linked_order_id"`
+	// RequestedQuantity is the quantity asked for when it was rounded to the
+	// lot size before submission (0 if unchanged).
+	RequestedQuantity float64 `json:"requested_quantity,omitempty" db:"-"`
+	// ConfirmationToken confirms a staged order (pending_confirmation only).
+	ConfirmationToken string `json:"confirmation_token,omitempty" db:"-"`
+	// ConfirmBy is when a staged order expires unless confirmed
+	// (pending_confirmation only).
+	ConfirmBy time.Time `json:"confirm_by,omitzero" db:"-"`
+	// StrategyName is the strategy whose signal placed the order (empty otherwise).
+	StrategyName string `json:"strategy_name,omitempty" db:"strategy_name"`
+	// Tags are free-form labels, such as "manual" for hand-placed orders.
+	Tags Tags `json:"tags,omitempty" db:"tags"`
+}
+
+// Attribution copies the strategy name and tags from another order when this
+// order has none, such as a broker's copy of an order it was sent.
+func (o *Order) Attribution(from Order) {
+	if o.StrategyName == "" {
+		o.StrategyName = from.StrategyName
+	}
+	if len(o.Tags) == 0 {
+		o.Tags = from.Tags
+	}
 }
 
 // Trade represents a completed trade (filled order).
@@ -86,6 +211,61 @@ type Trade struct {
 	Quantity float64 `json:"quantity" db:"quantity"`
 	// Price is the execution price.
 	Price float64 `json:"price" db:"price"`
+	// Commission is the fee charged for the fill.
+	Commission float64 `json:"commission" db:"commission"`
 	// ExecutedAt is when the trade was executed.
 	ExecutedAt time.Time `json:"executed_at" db:"executed_at"`
 }
+
+// OrderNote is a user-authored journal note attached to an order. Notes are
+// stored apart from the order record so reconciliation never touches them.
+type OrderNote struct {
+	// ID is the note's identifier.
+	ID int64 `json:"id" db:"id"`
+	// OrderID is the annotated order.
+	OrderID string `json:"order_id" db:"order_id"`
+	// Text is the note body.
+	Text string `json:"text" db:"text"`
+	// CreatedAt is when the note was written.
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// OrderAction is a request that changed an order's state.
+type OrderAction string
+
+const (
+	// OrderActionSubmit is an order being placed.
+	OrderActionSubmit OrderAction = "submit"
+	// OrderActionCancel is an order being cancelled.
+	OrderActionCancel OrderAction = "cancel"
+	// OrderActionModify is an order's price or quantity being changed.
+	OrderActionModify OrderAction = "modify"
+	// OrderActionUpdate is a status change reported by the broker, such as
+	// a fill, an expiry or an OCO cancel.
+	OrderActionUpdate OrderAction = "update"
+)
+
+// OrderEvent records one state change of an order for the audit trail.
+type OrderEvent struct {
+	// ID is the event's identifier.
+	ID int64 `json:"id" db:"id"`
+	// OrderID is the order that changed.
+	OrderID string `json:"order_id" db:"order_id"`
+	// Action is the request that caused the change.
+	Action OrderAction `json:"action" db:"action"`
+	// FromStatus is the status before the change (empty for a new order, or
+	// if unknown).
+	FromStatus OrderStatus `json:"from_status,omitempty" db:"from_status"`
+	// ToStatus is the status after the change.
+	ToStatus OrderStatus `json:"to_status" db:"to_status"`
+	// Quantity is the order's total quantity after the change.
+	Quantity float64 `json:"quantity" db:"quantity"`
+	// Price is the order's limit or stop price after the change.
+	Price float64 `json:"price" db:"price"`
+	// Actor is the API key ID that made the request, or "system" for the engine.
+	Actor string `json:"actor" db:"actor"`
+	// TraceID correlates the change with the request or engine tick logs.
+	TraceID string `json:"trace_id,omitempty" db:"trace_id"`
+	// CreatedAt is when the change happened.
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
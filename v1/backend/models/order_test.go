@@ -52,6 +52,44 @@ func TestOrder_JSON(t *testing.T) {
 	assert.True(t, order.CreatedAt.Equal(parsed.CreatedAt))
 }
 
+// TestOrder_JSONOmitsZeroValues verifies an unfilled market order's JSON
+// omits price and fill fields, and enums serialize as their string values.
+func TestOrder_JSONOmitsZeroValues(t *testing.T) {
+	order := Order{
+		ID:       "123",
+		Symbol:   "AAPL",
+		Side:     OrderSideBuy,
+		Type:     OrderTypeMarket,
+		Quantity: 10,
+		Status:   OrderStatusPending,
+	}
+
+	data, err := json.Marshal(order)
+	require.NoError(t, err)
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+	assert.NotContains(t, fields, "price")
+	assert.NotContains(t, fields, "filled_quantity")
+	assert.NotContains(t, fields, "average_price")
+	assert.NotContains(t, fields, "time_in_force")
+	assert.Equal(t, "buy", fields["side"])
+	assert.Equal(t, "market", fields["type"])
+	assert.Equal(t, "pending", fields["status"])
+	assert.Equal(t, 10.0, fields["quantity"])
+
+	order.Status = OrderStatusFilled
+	order.FilledQuantity = 10
+	order.AveragePrice = 150.25
+	data, err = json.Marshal(order)
+	require.NoError(t, err)
+	fields = nil
+	require.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, 10.0, fields["filled_quantity"])
+	assert.Equal(t, 150.25, fields["average_price"])
+	assert.NotContains(t, fields, "price")
+}
+
 // TestTrade_JSON verifies JSON marshaling of Trade.
 func TestTrade_JSON(t *testing.T) {
 	now := time.Now().Truncate(time.Second)
@@ -77,3 +115,41 @@ func TestTrade_JSON(t *testing.T) {
 	assert.Equal(t, trade.Quantity, parsed.Quantity)
 	assert.Equal(t, trade.Price, parsed.Price)
 }
+
+// TestTags_ValueScan verifies tags round-trip through their stored JSON form.
+func TestTags_ValueScan(t *testing.T) {
+	value, err := Tags{"manual", "hedge"}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `["manual","hedge"]`, value)
+
+	var tags Tags
+	require.NoError(t, tags.Scan(value))
+	assert.Equal(t, Tags{"manual", "hedge"}, tags)
+	assert.True(t, tags.Has("hedge"))
+	assert.False(t, tags.Has("swing"))
+
+	empty, err := Tags(nil).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "[]", empty)
+
+	require.NoError(t, tags.Scan([]byte("[]")))
+	assert.Nil(t, tags)
+	require.NoError(t, tags.Scan(nil))
+	assert.Nil(t, tags)
+	assert.Error(t, tags.Scan(42))
+}
+
+// TestOrder_Attribution verifies attribution is only filled in when missing.
+func TestOrder_Attribution(t *testing.T) {
+	source := Order{StrategyName: "ma_crossover", Tags: Tags{"swing"}}
+
+	order := Order{ID: "broker-copy"}
+	order.Attribution(source)
+	assert.Equal(t, "ma_crossover", order.StrategyName)
+	assert.Equal(t, Tags{"swing"}, order.Tags)
+
+	tagged := Order{StrategyName: "rsi_momentum", Tags: Tags{"manual"}}
+	tagged.Attribution(source)
+	assert.Equal(t, "rsi_momentum", tagged.StrategyName)
+	assert.Equal(t, Tags{"manual"}, tagged.Tags)
+}
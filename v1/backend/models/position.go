@@ -18,6 +18,8 @@ type Position struct {
 	MarketValue float64 `json:"market_value" db:"market_value"`
 	// UnrealizedPL is the unrealized profit/loss.
 	UnrealizedPL float64 `json:"unrealized_pl" db:"unrealized_pl"`
+	// QuoteCurrency is the currency prices and values are in (e.g., "USD", "USDT").
+	QuoteCurrency string `json:"quote_currency" db:"quote_currency"`
 	// UpdatedAt is when the position was last updated.
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -35,3 +37,15 @@ type Balance struct {
 	// UpdatedAt is when the balance was last updated.
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
+
+// EquitySnapshot records the account's value at a point in time.
+type EquitySnapshot struct {
+	// Timestamp is when the snapshot was taken.
+	Timestamp time.Time `json:"timestamp" db:"timestamp"`
+	// Cash is the cash balance.
+	Cash float64 `json:"cash" db:"cash"`
+	// Equity is the total account equity.
+	Equity float64 `json:"equity" db:"equity"`
+	// PortfolioValue is the total portfolio value.
+	PortfolioValue float64 `json:"portfolio_value" db:"portfolio_value"`
+}
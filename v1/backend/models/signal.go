@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // SignalType represents the type of trading signal.
 type SignalType string
 
@@ -36,6 +38,10 @@ type Signal struct {
 	Price float64 `json:"price"`
 	// Quantity is the suggested position size.
 	Quantity float64 `json:"quantity"`
+	// SizeFraction scales a backtest entry or exit when pyramiding is
+	// enabled: a buy adds this fraction of the normal position size and a
+	// sell closes this fraction of the open position. 0 means the full size.
+	SizeFraction float64 `json:"size_fraction,omitempty"`
 	// StopLoss is the suggested stop-loss price.
 	StopLoss float64 `json:"stop_loss,omitempty"`
 	// TakeProfit is the suggested take-profit price.
@@ -44,4 +50,51 @@ type Signal struct {
 	Reason string `json:"reason"`
 	// StrategyName is the name of the strategy that generated this signal.
 	StrategyName string `json:"strategy_name"`
+	// Tags are copied onto the order placed for the signal.
+	Tags Tags `json:"tags,omitempty"`
+}
+
+// SignalRecord is a logged strategy signal, including holds and signals that
+// did not result in an order.
+type SignalRecord struct {
+	// ID is the database row ID.
+	ID int64 `json:"id" db:"id"`
+	// Timestamp is when the signal was generated.
+	Timestamp time.Time `json:"timestamp" db:"timestamp"`
+	// Symbol is the ticker symbol the signal is for.
+	Symbol string `json:"symbol" db:"symbol"`
+	// Type is the signal type (buy/sell/hold).
+	Type SignalType `json:"type" db:"type"`
+	// Strength indicates the confidence level.
+	Strength SignalStrength `json:"strength" db:"strength"`
+	// Price is the suggested entry/exit price.
+	Price float64 `json:"price" db:"price"`
+	// StrategyName is the name of the strategy that generated the signal.
+	StrategyName string `json:"strategy_name" db:"strategy_name"`
+	// Reason provides context for the signal.
+	Reason string `json:"reason" db:"reason"`
+	// Executed is true if an order was placed for the signal.
+	Executed bool `json:"executed" db:"executed"`
+}
+
+// NewSignalRecord creates a log record for a signal.
+//
+// Args:
+//   - signal: The strategy signal
+//   - executed: Whether an order was placed for it
+//   - at: When the signal was generated
+//
+// Returns:
+//   - SignalRecord: The record
+func NewSignalRecord(signal Signal, executed bool, at time.Time) SignalRecord {
+	return SignalRecord{
+		Timestamp:    at,
+		Symbol:       signal.Symbol,
+		Type:         signal.Type,
+		Strength:     signal.Strength,
+		Price:        signal.Price,
+		StrategyName: signal.StrategyName,
+		Reason:       signal.Reason,
+		Executed:     executed,
+	}
 }
@@ -2,6 +2,7 @@ package notifications
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/alexherrero/sherwood/backend/data"
@@ -15,6 +16,19 @@ import (
 type Manager struct {
 	store     data.NotificationStore
 	wsManager *realtime.WebSocketManager
+
+	// mu guards the throttle and quiet hours state so duplicate counts and
+	// rate windows stay consistent. It is never held across store writes or
+	// broadcasts.
+	mu          sync.Mutex
+	dedupWindow time.Duration
+	rateLimit   int
+	recent      map[string]*recentNotification
+	sentByType  map[models.NotificationType][]time.Time
+	quiet       QuietHours
+	held        []models.Notification // Saved during quiet hours, awaiting the summary
+	now         func() time.Time
+	after       func(d time.Duration, f func()) // Schedules the quiet hours summary
 }
 
 // NewManager creates a new notification manager.
@@ -27,13 +41,28 @@ type Manager struct {
 //   - *Manager: The new manager instance
 func NewManager(store data.NotificationStore, wsManager *realtime.WebSocketManager) *Manager {
 	return &Manager{
-		store:     store,
-		wsManager: wsManager,
+		store:      store,
+		wsManager:  wsManager,
+		recent:     make(map[string]*recentNotification),
+		sentByType: make(map[models.NotificationType][]time.Time),
+		now:        time.Now,
+		after:      func(d time.Duration, f func()) { time.AfterFunc(d, f) },
 	}
 }
 
 // Send creates and broadcasts a new notification.
 //
+// With throttling enabled (see SetThrottle), a notification with the same
+// type and message as one sent within the dedup window is not sent again;
+// the original's "occurrences" metadata is incremented and its ID returned.
+// Notifications over the per-type rate limit are dropped and return an
+// empty ID with no error.
+//
+// During quiet hours (see SetQuietHours), non-critical notifications are
+// saved but not broadcast, and a summary is sent when the window ends.
+// Errors, and notifications whose metadata sets
+// models.NotificationCriticalKey, are always broadcast.
+//
 // Args:
 //   - notifType: Type of notification (info, success, warning, error)
 //   - title: Brief summary
@@ -41,33 +70,83 @@ func NewManager(store data.NotificationStore, wsManager *realtime.WebSocketManag
 //   - metadata: Optional key-value context data
 //
 // Returns:
-//   - string: ID of the created notification
+//   - string: ID of the created (or collapsed-into) notification
 //   - error: Any error encountered
 func (m *Manager) Send(notifType models.NotificationType, title, message string, metadata map[string]interface{}) (string, error) {
-	id := uuid.New().String()
+	// Decisions are made under m.mu; the store writes and broadcasts happen
+	// after it is released so a slow database or client never blocks other
+	// senders.
+	m.mu.Lock()
+	now := m.now()
+	summary := m.flushQuiet(now)
+	if dup, ok := m.collapseDuplicate(notifType, message, now); ok {
+		m.mu.Unlock()
+		m.publish(summary)
+		if err := m.store.UpdateMetadata(dup.id, dup.metadata); err != nil {
+			log.Error().Err(err).Str("id", dup.id).Msg("Failed to update notification occurrences")
+		}
+		return dup.id, nil
+	}
+	if !m.allowType(notifType, now) {
+		m.mu.Unlock()
+		m.publish(summary)
+		log.Debug().
+			Str("type", string(notifType)).
+			Str("title", title).
+			Msg("Notification dropped by rate limit")
+		return "", nil
+	}
 
 	n := models.Notification{
-		ID:        id,
+		ID:        uuid.New().String(),
 		Type:      notifType,
 		Title:     title,
 		Message:   message,
-		CreatedAt: time.Now(),
+		CreatedAt: now,
 		IsRead:    false,
 		Metadata:  metadata,
 	}
+	m.recordSent(n, now)
+	held := !isCritical(n) && m.quiet.Contains(now)
+	if held {
+		m.holdQuiet(n, now)
+	}
+	m.mu.Unlock()
+	m.publish(summary)
 
 	// Persist
 	if err := m.store.SaveNotification(n); err != nil {
 		log.Error().Err(err).Msg("Failed to persist notification")
+		m.mu.Lock()
+		m.forgetSent(n, now)
+		m.mu.Unlock()
 		return "", fmt.Errorf("failed to save: %w", err)
 	}
+	if held {
+		return n.ID, nil
+	}
 
 	// Broadcast
 	if m.wsManager != nil {
 		m.wsManager.Broadcast("notification", n)
 	}
 
-	return id, nil
+	return n.ID, nil
+}
+
+// publish persists and broadcasts a notification built under m.mu. Callers
+// must not hold m.mu. A nil notification is ignored.
+func (m *Manager) publish(n *models.Notification) {
+	if n == nil {
+		return
+	}
+	if err := m.store.SaveNotification(*n); err != nil {
+		log.Error().Err(err).Str("title", n.Title).Msg("Failed to persist notification")
+		return
+	}
+	if m.wsManager != nil {
+		m.wsManager.Broadcast("notification", *n)
+	}
 }
 
 // GetHistory retrieves recent notifications.
@@ -75,6 +154,24 @@ func (m *Manager) GetHistory(limit, offset int) ([]models.Notification, error) {
 	return m.store.GetNotifications(limit, offset)
 }
 
+// Query retrieves notifications matching a filter.
+//
+// Args:
+//   - filter: Type, read status and pagination
+//
+// Returns:
+//   - []models.Notification: The requested page of notifications
+//   - int: Total count of matching notifications (before pagination)
+//   - error: Any error encountered
+func (m *Manager) Query(filter data.NotificationFilter) ([]models.Notification, int, error) {
+	return m.store.QueryNotifications(filter)
+}
+
+// UnreadCount returns the number of unread notifications.
+func (m *Manager) UnreadCount() (int, error) {
+	return m.store.CountUnread()
+}
+
 // MarkAsRead marks a notification as read.
 func (m *Manager) MarkAsRead(id string) error {
 	return m.store.MarkAsRead(id)
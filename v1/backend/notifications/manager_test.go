@@ -0,0 +1,334 @@
+package notifications
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestManager creates a manager backed by a temporary SQLite store.
+func newTestManager(t *testing.T) (*Manager, *data.SQLNotificationStore) {
+	t.Helper()
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	store := data.NewNotificationStore(db)
+	return NewManager(store, nil), store
+}
+
+// TestManager_Send_Deduplicates verifies identical notifications collapse into one with a count.
+func TestManager_Send_Deduplicates(t *testing.T) {
+	manager, store := newTestManager(t)
+	manager.SetThrottle(time.Minute, 20)
+
+	var firstID string
+	for i := 0; i < 100; i++ {
+		id, err := manager.Send(models.NotificationError, "Order rejected", "AAPL buy rejected: insufficient funds",
+			map[string]interface{}{"symbol": "AAPL"})
+		require.NoError(t, err)
+		if i == 0 {
+			firstID = id
+		}
+		assert.Equal(t, firstID, id, "duplicates return the original ID")
+	}
+
+	notifs, total, err := store.QueryNotifications(data.NotificationFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, notifs, 1)
+	assert.Equal(t, firstID, notifs[0].ID)
+	assert.Equal(t, float64(100), notifs[0].Metadata["occurrences"])
+	assert.Equal(t, "AAPL", notifs[0].Metadata["symbol"])
+}
+
+// TestManager_Send_DedupWindowExpires verifies a duplicate after the window is sent again.
+func TestManager_Send_DedupWindowExpires(t *testing.T) {
+	manager, store := newTestManager(t)
+	manager.SetThrottle(time.Minute, 0)
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	manager.now = func() time.Time { return now }
+
+	first, err := manager.Send(models.NotificationWarning, "Slow", "Provider is slow", nil)
+	require.NoError(t, err)
+
+	now = now.Add(time.Minute)
+	second, err := manager.Send(models.NotificationWarning, "Slow", "Provider is slow", nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	_, total, err := store.QueryNotifications(data.NotificationFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+}
+
+// TestManager_Send_RateLimit verifies the per-type cap drops floods of distinct messages.
+func TestManager_Send_RateLimit(t *testing.T) {
+	manager, store := newTestManager(t)
+	manager.SetThrottle(time.Minute, 5)
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	manager.now = func() time.Time { return now }
+
+	for i := 0; i < 10; i++ {
+		id, err := manager.Send(models.NotificationError, "Failed", fmt.Sprintf("failure %d", i), nil)
+		require.NoError(t, err)
+		if i < 5 {
+			assert.NotEmpty(t, id)
+		} else {
+			assert.Empty(t, id, "over the limit is dropped")
+		}
+	}
+
+	// Other types have their own budget
+	id, err := manager.Send(models.NotificationInfo, "Started", "Engine started", nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, id)
+
+	// The window rolls over after a minute
+	now = now.Add(time.Minute)
+	id, err = manager.Send(models.NotificationError, "Failed", "failure 10", nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, id)
+
+	_, total, err := store.QueryNotifications(data.NotificationFilter{Type: models.NotificationError})
+	require.NoError(t, err)
+	assert.Equal(t, 6, total)
+}
+
+// hookStore runs a hook before saving each notification.
+type hookStore struct {
+	data.NotificationStore
+	onSave func(n models.Notification) error
+}
+
+func (s *hookStore) SaveNotification(n models.Notification) error {
+	if s.onSave != nil {
+		if err := s.onSave(n); err != nil {
+			return err
+		}
+	}
+	return s.NotificationStore.SaveNotification(n)
+}
+
+// TestManager_Send_UnlocksBeforeSave verifies the manager lock is released
+// before the store write, so a slow or re-entrant store cannot block other
+// senders.
+func TestManager_Send_UnlocksBeforeSave(t *testing.T) {
+	_, inner := newTestManager(t)
+	store := &hookStore{NotificationStore: inner}
+	manager := NewManager(store, nil)
+	manager.SetThrottle(time.Minute, 5)
+
+	store.onSave = func(n models.Notification) error {
+		if n.Title == "Outer" {
+			_, err := manager.Send(models.NotificationInfo, "Inner", "sent while saving", nil)
+			return err
+		}
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := manager.Send(models.NotificationInfo, "Outer", "slow save", nil)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Send held the lock across the store write")
+	}
+
+	_, total, err := inner.QueryNotifications(data.NotificationFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+}
+
+// TestManager_Send_SaveFailure verifies a notification that fails to persist
+// does not count towards deduplication or the rate limit.
+func TestManager_Send_SaveFailure(t *testing.T) {
+	_, inner := newTestManager(t)
+	store := &hookStore{NotificationStore: inner}
+	manager := NewManager(store, nil)
+	manager.SetThrottle(time.Minute, 1)
+
+	store.onSave = func(models.Notification) error { return fmt.Errorf("disk full") }
+	_, err := manager.Send(models.NotificationError, "Failed", "order rejected", nil)
+	require.Error(t, err)
+
+	store.onSave = nil
+	id, err := manager.Send(models.NotificationError, "Failed", "order rejected", nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, id, "the failed send is neither a duplicate nor rate limited")
+
+	_, total, err := inner.QueryNotifications(data.NotificationFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+}
+
+// TestManager_Send_Unthrottled verifies every notification is sent by default.
+func TestManager_Send_Unthrottled(t *testing.T) {
+	manager, store := newTestManager(t)
+
+	for i := 0; i < 3; i++ {
+		_, err := manager.Send(models.NotificationInfo, "Tick", "tick", nil)
+		require.NoError(t, err)
+	}
+
+	_, total, err := store.QueryNotifications(data.NotificationFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+}
+
+// TestManager_Send_QuietHours verifies non-critical notifications are held
+// during quiet hours and summarized when the window ends.
+func TestManager_Send_QuietHours(t *testing.T) {
+	manager, store := newTestManager(t)
+	quiet, err := ParseQuietHours("22:00", "07:00", "America/New_York")
+	require.NoError(t, err)
+	manager.SetQuietHours(quiet)
+
+	now := time.Date(2024, 1, 1, 23, 30, 0, 0, quiet.Location)
+	manager.now = func() time.Time { return now }
+	var scheduled []time.Duration
+	manager.after = func(d time.Duration, f func()) { scheduled = append(scheduled, d) }
+
+	fill, err := manager.Send(models.NotificationTrade, "Order filled", "Bought 0.1 BTC-USD", nil)
+	require.NoError(t, err)
+	_, err = manager.Send(models.NotificationWarning, "Slow", "Provider is slow", nil)
+	require.NoError(t, err)
+	_, err = manager.Send(models.NotificationError, "Failed", "Order rejected", nil)
+	require.NoError(t, err)
+
+	// Held notifications are saved, and errors are not held
+	require.Len(t, manager.held, 2)
+	assert.Equal(t, fill, manager.held[0].ID)
+	assert.Equal(t, []time.Duration{7*time.Hour + 30*time.Minute}, scheduled, "one summary at 07:00")
+	_, total, err := store.QueryNotifications(data.NotificationFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+
+	// A timer firing early waits for the end of the window
+	now = time.Date(2024, 1, 2, 6, 0, 0, 0, quiet.Location)
+	manager.quietHoursEnded()
+	assert.Len(t, manager.held, 2)
+	assert.Equal(t, time.Hour, scheduled[1])
+
+	now = time.Date(2024, 1, 2, 7, 0, 0, 0, quiet.Location)
+	manager.quietHoursEnded()
+	assert.Empty(t, manager.held)
+
+	notifs, total, err := store.QueryNotifications(data.NotificationFilter{Type: models.NotificationInfo})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	assert.Equal(t, "Quiet hours summary", notifs[0].Title)
+	assert.Equal(t, "2 notifications during quiet hours: 1 trade, 1 warning", notifs[0].Message)
+	assert.Equal(t, float64(2), notifs[0].Metadata["count"])
+}
+
+// TestManager_Send_QuietHoursCritical verifies notifications marked critical
+// in their metadata are broadcast during quiet hours, while the rest are held.
+func TestManager_Send_QuietHoursCritical(t *testing.T) {
+	manager, _ := newTestManager(t)
+	quiet, err := ParseQuietHours("22:00", "07:00", "UTC")
+	require.NoError(t, err)
+	manager.SetQuietHours(quiet)
+	manager.now = func() time.Time { return time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC) }
+	manager.after = func(time.Duration, func()) {}
+
+	critical := map[string]interface{}{models.NotificationCriticalKey: true}
+	for _, title := range []string{"Broker disconnected", "Trading disabled", "Circuit breaker tripped", "Daily trade cap reached"} {
+		_, err := manager.Send(models.NotificationWarning, title, title, critical)
+		require.NoError(t, err)
+	}
+	assert.Empty(t, manager.held)
+
+	_, err = manager.Send(models.NotificationWarning, "Slow", "Provider is slow", map[string]interface{}{models.NotificationCriticalKey: false})
+	require.NoError(t, err)
+	_, err = manager.Send(models.NotificationSuccess, "Broker reconnected", "Reconnected", nil)
+	require.NoError(t, err)
+	assert.Len(t, manager.held, 2)
+}
+
+// TestManager_Send_QuietHoursFlushOnSend verifies the summary goes out with the
+// first notification after the window if the timer has not fired.
+func TestManager_Send_QuietHoursFlushOnSend(t *testing.T) {
+	manager, store := newTestManager(t)
+	quiet, err := ParseQuietHours("22:00", "07:00", "UTC")
+	require.NoError(t, err)
+	manager.SetQuietHours(quiet)
+
+	now := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	manager.now = func() time.Time { return now }
+	manager.after = func(time.Duration, func()) {}
+
+	_, err = manager.Send(models.NotificationTrade, "Order filled", "Sold 10 AAPL", nil)
+	require.NoError(t, err)
+
+	now = time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC)
+	_, err = manager.Send(models.NotificationTrade, "Order filled", "Bought 10 AAPL", nil)
+	require.NoError(t, err)
+	assert.Empty(t, manager.held)
+
+	_, total, err := store.QueryNotifications(data.NotificationFilter{Type: models.NotificationInfo})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+}
+
+// TestParseQuietHours verifies window parsing and membership.
+func TestParseQuietHours(t *testing.T) {
+	disabled, err := ParseQuietHours("", "", "UTC")
+	require.NoError(t, err)
+	assert.False(t, disabled.Enabled())
+	assert.False(t, disabled.Contains(time.Now()))
+
+	for _, tc := range []struct{ start, end, tz string }{
+		{"22:00", "", "UTC"},
+		{"25:00", "07:00", "UTC"},
+		{"22:00", "22:00", "UTC"},
+		{"22:00", "07:00", "Mars/Olympus"},
+	} {
+		_, err := ParseQuietHours(tc.start, tc.end, tc.tz)
+		assert.Error(t, err, "%s-%s %s", tc.start, tc.end, tc.tz)
+	}
+
+	day, err := ParseQuietHours("12:00", "13:30", "UTC")
+	require.NoError(t, err)
+	assert.True(t, day.Contains(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)))
+	assert.True(t, day.Contains(time.Date(2024, 1, 1, 13, 29, 0, 0, time.UTC)))
+	assert.False(t, day.Contains(time.Date(2024, 1, 1, 13, 30, 0, 0, time.UTC)))
+
+	night, err := ParseQuietHours("22:00", "07:00", "UTC")
+	require.NoError(t, err)
+	assert.True(t, night.Contains(time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)))
+	assert.True(t, night.Contains(time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)))
+	assert.False(t, night.Contains(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)))
+}
+
+// TestQuietHours_NextEndDST verifies the window ends at its wall-clock time
+// on the days clocks change.
+func TestQuietHours_NextEndDST(t *testing.T) {
+	quiet, err := ParseQuietHours("22:00", "07:00", "America/New_York")
+	require.NoError(t, err)
+	loc := quiet.Location
+
+	// Fall back: 2026-11-01 has 25 hours
+	end := quiet.NextEnd(time.Date(2026, 10, 31, 23, 0, 0, 0, loc))
+	assert.Equal(t, time.Date(2026, 11, 1, 7, 0, 0, 0, loc), end)
+	assert.Equal(t, 7, end.In(loc).Hour())
+	assert.False(t, quiet.Contains(end))
+
+	// Spring forward: 2026-03-08 has 23 hours
+	end = quiet.NextEnd(time.Date(2026, 3, 8, 1, 0, 0, 0, loc))
+	assert.Equal(t, time.Date(2026, 3, 8, 7, 0, 0, 0, loc), end)
+	assert.Equal(t, 7, end.In(loc).Hour())
+	assert.False(t, quiet.Contains(end))
+}
@@ -0,0 +1,190 @@
+package notifications
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/google/uuid"
+)
+
+// QuietHours is a daily window, in wall-clock time of a timezone, during
+// which non-critical notifications are saved but not broadcast. A summary of
+// what was held is sent when the window ends. The window may wrap past
+// midnight (e.g., 22:00 to 07:00). The zero value disables it.
+type QuietHours struct {
+	// Start is the window start as an offset from local midnight.
+	Start time.Duration
+	// End is the window end as an offset from local midnight.
+	End time.Duration
+	// Location is the timezone Start and End are in.
+	Location *time.Location
+}
+
+// ParseQuietHours builds a quiet hours window from HH:MM times.
+//
+// Args:
+//   - start: Window start, HH:MM (empty disables quiet hours)
+//   - end: Window end, HH:MM
+//   - timezone: IANA timezone name the times are in
+//
+// Returns:
+//   - QuietHours: The window
+//   - error: If a time or the timezone is invalid, or start equals end
+func ParseQuietHours(start, end, timezone string) (QuietHours, error) {
+	if start == "" && end == "" {
+		return QuietHours{}, nil
+	}
+	from, err := parseClock(start)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	to, err := parseClock(end)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	if from == to {
+		return QuietHours{}, fmt.Errorf("quiet hours start and end are both %s", start)
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("failed to load timezone %s: %w", timezone, err)
+	}
+	return QuietHours{Start: from, End: to, Location: loc}, nil
+}
+
+// parseClock parses an HH:MM wall-clock time into an offset from midnight.
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q: must be HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Enabled reports whether the window is set.
+func (q QuietHours) Enabled() bool {
+	return q.Location != nil && q.Start != q.End
+}
+
+// Contains reports whether t falls inside the window.
+func (q QuietHours) Contains(t time.Time) bool {
+	if !q.Enabled() {
+		return false
+	}
+	offset := q.sinceMidnight(t)
+	if q.Start < q.End {
+		return offset >= q.Start && offset < q.End
+	}
+	return offset >= q.Start || offset < q.End
+}
+
+// NextEnd returns the first end of the window after t. The end is built
+// from its wall-clock time, so it stays at End on days with a DST change.
+func (q QuietHours) NextEnd(t time.Time) time.Time {
+	local := t.In(q.Location)
+	hour, minute := int(q.End/time.Hour), int(q.End%time.Hour/time.Minute)
+	end := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, q.Location)
+	if !end.After(t) {
+		end = time.Date(local.Year(), local.Month(), local.Day()+1, hour, minute, 0, 0, q.Location)
+	}
+	return end
+}
+
+// sinceMidnight returns t's wall-clock offset from local midnight.
+func (q QuietHours) sinceMidnight(t time.Time) time.Duration {
+	local := t.In(q.Location)
+	return time.Duration(local.Hour())*time.Hour +
+		time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second
+}
+
+// SetQuietHours configures the quiet hours window. Must be called before the
+// manager is shared.
+//
+// Args:
+//   - quiet: Daily window non-critical notifications are held in (zero value disables)
+func (m *Manager) SetQuietHours(quiet QuietHours) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.quiet = quiet
+}
+
+// isCritical reports whether a notification is delivered even during quiet
+// hours: errors, and anything whose metadata sets NotificationCriticalKey
+// (broker disconnects, the kill switch, risk halts).
+func isCritical(n models.Notification) bool {
+	if n.Type == models.NotificationError {
+		return true
+	}
+	critical, _ := n.Metadata[models.NotificationCriticalKey].(bool)
+	return critical
+}
+
+// holdQuiet records a notification that will not be broadcast, and
+// schedules the summary for the end of quiet hours. Callers must hold m.mu.
+func (m *Manager) holdQuiet(n models.Notification, now time.Time) {
+	if len(m.held) == 0 {
+		m.after(m.quiet.NextEnd(now).Sub(now), m.quietHoursEnded)
+	}
+	m.held = append(m.held, n)
+}
+
+// quietHoursEnded sends the summary of notifications held during quiet
+// hours. It runs on a timer at the end of the window.
+func (m *Manager) quietHoursEnded() {
+	m.mu.Lock()
+	now := m.now()
+	if len(m.held) > 0 && m.quiet.Contains(now) {
+		// The timer fired before the window ended; try again at the end
+		m.after(m.quiet.NextEnd(now).Sub(now), m.quietHoursEnded)
+		m.mu.Unlock()
+		return
+	}
+	summary := m.flushQuiet(now)
+	m.mu.Unlock()
+	m.publish(summary)
+}
+
+// flushQuiet builds the quiet hours summary once the window has ended and
+// clears the held notifications. It returns nil if there is nothing to send.
+// Callers must hold m.mu and publish the summary after releasing it.
+func (m *Manager) flushQuiet(now time.Time) *models.Notification {
+	if len(m.held) == 0 || m.quiet.Contains(now) {
+		return nil
+	}
+	held := m.held
+	m.held = nil
+
+	byType := make(map[string]int)
+	ids := make([]string, 0, len(held))
+	for _, n := range held {
+		byType[string(n.Type)]++
+		ids = append(ids, n.ID)
+	}
+	types := make([]string, 0, len(byType))
+	for t := range byType {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	counts := make([]string, 0, len(types))
+	for _, t := range types {
+		counts = append(counts, fmt.Sprintf("%d %s", byType[t], t))
+	}
+
+	n := models.Notification{
+		ID:        uuid.New().String(),
+		Type:      models.NotificationInfo,
+		Title:     "Quiet hours summary",
+		Message:   fmt.Sprintf("%d notifications during quiet hours: %s", len(held), strings.Join(counts, ", ")),
+		CreatedAt: now,
+		Metadata: map[string]interface{}{
+			"count":            len(held),
+			"by_type":          byType,
+			"notification_ids": ids,
+		},
+	}
+	return &n
+}
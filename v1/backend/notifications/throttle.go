@@ -0,0 +1,124 @@
+package notifications
+
+import (
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// rateWindow is the period NotificationRateLimit is counted over.
+const rateWindow = time.Minute
+
+// recentNotification tracks a sent notification for deduplication.
+type recentNotification struct {
+	id          string
+	sentAt      time.Time
+	metadata    map[string]interface{}
+	occurrences int
+}
+
+// SetThrottle configures deduplication and rate limiting. Must be called
+// before the manager is shared.
+//
+// Args:
+//   - dedupWindow: Identical type and message within this window are collapsed (0 disables)
+//   - rateLimit: Maximum notifications per type per minute (0 = unlimited)
+func (m *Manager) SetThrottle(dedupWindow time.Duration, rateLimit int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dedupWindow = dedupWindow
+	m.rateLimit = rateLimit
+}
+
+// dedupKey identifies notifications that are duplicates of each other.
+func dedupKey(notifType models.NotificationType, message string) string {
+	return string(notifType) + "\x00" + message
+}
+
+// duplicate is the metadata update for a collapsed notification, written
+// after m.mu is released.
+type duplicate struct {
+	id       string
+	metadata map[string]interface{}
+}
+
+// collapseDuplicate counts a notification against an identical one sent
+// within the dedup window. It reports the original's ID and updated
+// metadata, and true if the notification was collapsed. Callers must hold
+// m.mu.
+func (m *Manager) collapseDuplicate(notifType models.NotificationType, message string, now time.Time) (duplicate, bool) {
+	if m.dedupWindow <= 0 {
+		return duplicate{}, false
+	}
+	for key, r := range m.recent {
+		if now.Sub(r.sentAt) >= m.dedupWindow {
+			delete(m.recent, key)
+		}
+	}
+
+	r, ok := m.recent[dedupKey(notifType, message)]
+	if !ok {
+		return duplicate{}, false
+	}
+	r.occurrences++
+
+	metadata := make(map[string]interface{}, len(r.metadata)+1)
+	for k, v := range r.metadata {
+		metadata[k] = v
+	}
+	metadata["occurrences"] = r.occurrences
+	return duplicate{id: r.id, metadata: metadata}, true
+}
+
+// allowType reports whether another notification of a type fits within the
+// rate limit. Callers must hold m.mu.
+func (m *Manager) allowType(notifType models.NotificationType, now time.Time) bool {
+	if m.rateLimit <= 0 {
+		return true
+	}
+	sent := m.sentByType[notifType]
+	i := 0
+	for i < len(sent) && now.Sub(sent[i]) >= rateWindow {
+		i++
+	}
+	m.sentByType[notifType] = sent[i:]
+	return len(sent)-i < m.rateLimit
+}
+
+// recordSent registers a notification for deduplication and rate limiting
+// before it is persisted. Callers must hold m.mu.
+func (m *Manager) recordSent(n models.Notification, now time.Time) {
+	if m.dedupWindow > 0 {
+		m.recent[dedupKey(n.Type, n.Message)] = &recentNotification{
+			id:          n.ID,
+			sentAt:      now,
+			metadata:    n.Metadata,
+			occurrences: 1,
+		}
+	}
+	if m.rateLimit > 0 {
+		m.sentByType[n.Type] = append(m.sentByType[n.Type], now)
+	}
+}
+
+// forgetSent undoes recordSent and any quiet hours hold for a notification
+// that failed to persist. Callers must hold m.mu.
+func (m *Manager) forgetSent(n models.Notification, now time.Time) {
+	key := dedupKey(n.Type, n.Message)
+	if r, ok := m.recent[key]; ok && r.id == n.ID {
+		delete(m.recent, key)
+	}
+	sent := m.sentByType[n.Type]
+	for i := len(sent) - 1; i >= 0; i-- {
+		if sent[i].Equal(now) {
+			m.sentByType[n.Type] = append(sent[:i:i], sent[i+1:]...)
+			break
+		}
+	}
+	for i, h := range m.held {
+		if h.ID == n.ID {
+			m.held = append(m.held[:i:i], m.held[i+1:]...)
+			break
+		}
+	}
+}
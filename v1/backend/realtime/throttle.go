@@ -0,0 +1,104 @@
+package realtime
+
+import (
+	"time"
+)
+
+// throttleKey identifies a coalesced stream: a message type and the key
+// updates replace each other within, e.g. a symbol.
+type throttleKey struct {
+	msgType string
+	key     string
+}
+
+// throttleState tracks one coalesced stream.
+type throttleState struct {
+	last    time.Time         // When a message was last sent
+	pending *WebSocketMessage // Latest message held back, sent when timer fires
+	timer   *time.Timer
+}
+
+// SetBroadcastThrottles limits how often messages of each type are sent.
+// Within a type, messages sharing a key (see BroadcastKeyed) are coalesced to
+// at most one per interval: the first goes out at once, and later ones within
+// the interval are held back so only the most recent is sent when it ends.
+// This suits state updates such as candles, not events such as order updates
+// where every message matters.
+//
+// Args:
+//   - throttles: Minimum interval between messages, by message type
+func (m *WebSocketManager) SetBroadcastThrottles(throttles map[string]time.Duration) {
+	m.throttleMu.Lock()
+	defer m.throttleMu.Unlock()
+	m.throttles = make(map[string]time.Duration, len(throttles))
+	for msgType, interval := range throttles {
+		if interval > 0 {
+			m.throttles[msgType] = interval
+		}
+	}
+}
+
+// BroadcastKeyed sends a message to all connected clients, coalescing it
+// with other messages of its type and key if the type is throttled.
+//
+// Args:
+//   - msgType: Message type (e.g., "market_data")
+//   - key: What updates replace each other within, e.g. a symbol
+//   - payload: Message payload
+func (m *WebSocketManager) BroadcastKeyed(msgType, key string, payload interface{}) {
+	msg := WebSocketMessage{
+		Type:      msgType,
+		Timestamp: time.Now(),
+		Payload:   payload,
+	}
+
+	m.throttleMu.Lock()
+	interval := m.throttles[msgType]
+	if interval <= 0 {
+		m.throttleMu.Unlock()
+		m.send(msg)
+		return
+	}
+
+	k := throttleKey{msgType: msgType, key: key}
+	state, ok := m.throttled[k]
+	if !ok {
+		state = &throttleState{}
+		m.throttled[k] = state
+	}
+	if state.timer == nil && msg.Timestamp.Sub(state.last) >= interval {
+		state.last = msg.Timestamp
+		m.throttleMu.Unlock()
+		m.send(msg)
+		return
+	}
+
+	select {
+	case <-m.done:
+		// Closed: no timer may outlive the manager
+		m.throttleMu.Unlock()
+		return
+	default:
+	}
+	state.pending = &msg
+	if state.timer == nil {
+		state.timer = time.AfterFunc(state.last.Add(interval).Sub(msg.Timestamp), func() { m.flushThrottled(k) })
+	}
+	m.throttleMu.Unlock()
+}
+
+// flushThrottled sends the message held back for a coalesced stream. It
+// does nothing once the manager is closed.
+func (m *WebSocketManager) flushThrottled(k throttleKey) {
+	m.throttleMu.Lock()
+	state := m.throttled[k]
+	msg := state.pending
+	state.pending = nil
+	state.timer = nil
+	state.last = time.Now()
+	m.throttleMu.Unlock()
+
+	if msg != nil {
+		m.send(*msg)
+	}
+}
@@ -0,0 +1,99 @@
+package realtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWebSocketManager_BroadcastThrottle verifies a throttled type is
+// coalesced per key to the latest message, while other types and keys pass
+// through at once.
+func TestWebSocketManager_BroadcastThrottle(t *testing.T) {
+	manager := NewWebSocketManager()
+	manager.SetBroadcastThrottles(map[string]time.Duration{"market_data": 100 * time.Millisecond})
+	go manager.Run()
+	messages, unsubscribe := manager.Subscribe(16)
+	defer unsubscribe()
+
+	receive := func() WebSocketMessage {
+		t.Helper()
+		select {
+		case msg := <-messages:
+			return msg
+		case <-time.After(time.Second):
+			t.Fatal("no broadcast received")
+			return WebSocketMessage{}
+		}
+	}
+
+	// The first update for each key goes out at once
+	manager.BroadcastKeyed("market_data", "AAPL", 1)
+	manager.BroadcastKeyed("market_data", "MSFT", 10)
+	assert.Equal(t, 1, receive().Payload)
+	assert.Equal(t, 10, receive().Payload)
+
+	// Later updates within the interval collapse to the latest
+	manager.BroadcastKeyed("market_data", "AAPL", 2)
+	manager.BroadcastKeyed("market_data", "AAPL", 3)
+	manager.Broadcast("order_update", "unthrottled")
+	assert.Equal(t, "unthrottled", receive().Payload)
+
+	start := time.Now()
+	msg := receive()
+	assert.Equal(t, "market_data", msg.Type)
+	assert.Equal(t, 3, msg.Payload)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	select {
+	case msg := <-messages:
+		t.Fatalf("unexpected broadcast %v", msg)
+	case <-time.After(150 * time.Millisecond):
+	}
+
+	// Once the interval has passed, an update goes out at once again
+	manager.BroadcastKeyed("market_data", "AAPL", 4)
+	assert.Equal(t, 4, receive().Payload)
+}
+
+// TestWebSocketManager_CloseStopsThrottle verifies Close discards held-back
+// messages and that broadcasting afterwards doesn't block.
+func TestWebSocketManager_CloseStopsThrottle(t *testing.T) {
+	manager := NewWebSocketManager()
+	manager.SetBroadcastThrottles(map[string]time.Duration{"market_data": 50 * time.Millisecond})
+	stopped := make(chan struct{})
+	go func() {
+		manager.Run()
+		close(stopped)
+	}()
+	messages, unsubscribe := manager.Subscribe(16)
+	defer unsubscribe()
+
+	manager.BroadcastKeyed("market_data", "AAPL", 1)
+	assert.Equal(t, 1, (<-messages).Payload)
+	manager.BroadcastKeyed("market_data", "AAPL", 2)
+
+	manager.Close()
+	manager.Close()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after Close")
+	}
+
+	returned := make(chan struct{})
+	go func() {
+		manager.Broadcast("order_update", "late")
+		manager.BroadcastKeyed("market_data", "AAPL", 3)
+		close(returned)
+	}()
+	select {
+	case <-returned:
+	case <-time.After(time.Second):
+		t.Fatal("broadcast blocked after Close")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, messages, "held-back message discarded")
+}
@@ -16,26 +16,89 @@ type WebSocketMessage struct {
 	Payload   interface{} `json:"payload"`
 }
 
+// clientSendBuffer is how many messages a client may lag behind before it is
+// dropped as too slow.
+const clientSendBuffer = 64
+
+// writeWait is how long a single write to a client may take.
+const writeWait = 10 * time.Second
+
+// client is a connected WebSocket with its own bounded send queue, drained by
+// a dedicated writer so a stalled connection never blocks the broadcaster.
+type client struct {
+	conn *websocket.Conn
+	send chan WebSocketMessage
+}
+
+// SnapshotFunc returns the state sent to a client as it connects, e.g.
+// positions, open orders and balance.
+type SnapshotFunc func() (interface{}, error)
+
+// WebSocketStats reports connection counts for runtime metrics.
+type WebSocketStats struct {
+	// Clients is the number of connected clients.
+	Clients int `json:"clients"`
+	// MaxClients is the connection cap (0 means unlimited).
+	MaxClients int `json:"max_clients"`
+	// DroppedClients counts clients disconnected for falling behind.
+	DroppedClients int64 `json:"dropped_clients"`
+	// RejectedClients counts upgrades refused because the cap was reached.
+	RejectedClients int64 `json:"rejected_clients"`
+}
+
 // WebSocketManager handles websocket connections and broadcasting.
 type WebSocketManager struct {
-	clients    map[*websocket.Conn]bool
+	clients    map[*client]bool
 	broadcast  chan WebSocketMessage
-	register   chan *websocket.Conn
-	unregister chan *websocket.Conn
+	register   chan *client
+	unregister chan *client
 	mu         sync.Mutex
 	upgrader   websocket.Upgrader
+
+	// Connection limits, guarded by mu. active counts reserved slots,
+	// including upgrades still in progress.
+	maxClients int
+	active     int
+	dropped    int64
+	rejected   int64
+
+	// snapshot builds the first message for each new client, guarded by mu
+	snapshot SnapshotFunc
+
+	// subscribers receive every broadcast message in-process (e.g. HTTP streams)
+	subscribers map[chan WebSocketMessage]struct{}
+	subMu       sync.RWMutex
+
+	// Per-type broadcast throttling, guarded by throttleMu
+	throttles  map[string]time.Duration
+	throttled  map[throttleKey]*throttleState
+	throttleMu sync.Mutex
+
+	// done is closed by Close, stopping Run and any pending broadcasts
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
+// Subprotocol is the WebSocket subprotocol selected by the server. Clients that
+// send other Sec-WebSocket-Protocol entries (such as an API key) should also
+// offer it, since browsers fail the handshake if none is selected.
+const Subprotocol = "sherwood"
+
 // NewWebSocketManager creates a new WebSocketManager.
 func NewWebSocketManager() *WebSocketManager {
 	return &WebSocketManager{
-		clients:    make(map[*websocket.Conn]bool),
-		broadcast:  make(chan WebSocketMessage),
-		register:   make(chan *websocket.Conn),
-		unregister: make(chan *websocket.Conn),
+		clients:     make(map[*client]bool),
+		broadcast:   make(chan WebSocketMessage),
+		register:    make(chan *client),
+		unregister:  make(chan *client),
+		subscribers: make(map[chan WebSocketMessage]struct{}),
+		throttles:   make(map[string]time.Duration),
+		throttled:   make(map[throttleKey]*throttleState),
+		done:        make(chan struct{}),
 		upgrader: websocket.Upgrader{
 			ReadBufferSize:  1024,
 			WriteBufferSize: 1024,
+			Subprotocols:    []string{Subprotocol},
 			// Allow all origins for now
 			CheckOrigin: func(r *http.Request) bool {
 				return true
@@ -44,62 +107,263 @@ func NewWebSocketManager() *WebSocketManager {
 	}
 }
 
-// Run starts the manager's main loop.
+// SetMaxClients caps the number of concurrent WebSocket clients. Upgrades past
+// the cap are rejected with 503.
+//
+// Args:
+//   - max: Maximum concurrent clients (0 means unlimited)
+func (m *WebSocketManager) SetMaxClients(max int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.maxClients = max
+}
+
+// SetSnapshot sets how the initial state for new clients is built. Each
+// client receives it as a snapshot message before any other event.
+//
+// Args:
+//   - snapshot: Builds the snapshot payload (nil sends none)
+func (m *WebSocketManager) SetSnapshot(snapshot SnapshotFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.snapshot = snapshot
+}
+
+// Stats returns current connection counts.
+func (m *WebSocketManager) Stats() WebSocketStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return WebSocketStats{
+		Clients:         len(m.clients),
+		MaxClients:      m.maxClients,
+		DroppedClients:  m.dropped,
+		RejectedClients: m.rejected,
+	}
+}
+
+// Run starts the manager's main loop. It returns once Close is called.
 func (m *WebSocketManager) Run() {
 	for {
 		select {
-		case conn := <-m.register:
+		case <-m.done:
+			return
+
+		case c := <-m.register:
 			m.mu.Lock()
-			m.clients[conn] = true
+			m.clients[c] = true
 			m.mu.Unlock()
 			log.Info().Msg("WebSocket client connected")
 
-		case conn := <-m.unregister:
+		case c := <-m.unregister:
 			m.mu.Lock()
-			if _, ok := m.clients[conn]; ok {
-				delete(m.clients, conn)
-				conn.Close()
+			if m.removeClient(c) {
 				log.Info().Msg("WebSocket client disconnected")
 			}
 			m.mu.Unlock()
 
 		case message := <-m.broadcast:
 			m.mu.Lock()
-			for conn := range m.clients {
-				conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-				if err := conn.WriteJSON(message); err != nil {
-					log.Error().Err(err).Msg("Failed to write to websocket, closing connection")
-					conn.Close()
-					delete(m.clients, conn)
+			for c := range m.clients {
+				select {
+				case c.send <- message:
+				default:
+					// Drop the slow client rather than block everyone else
+					m.removeClient(c)
+					m.dropped++
+					log.Warn().Msg("WebSocket client send buffer full, dropping client")
 				}
 			}
 			m.mu.Unlock()
+			m.publish(message)
 		}
 	}
 }
 
-// Broadcast sends a message to all connected clients.
-func (m *WebSocketManager) Broadcast(msgType string, payload interface{}) {
-	msg := WebSocketMessage{
-		Type:      msgType,
-		Timestamp: time.Now(),
-		Payload:   payload,
+// Close stops the main loop and the timers holding back throttled
+// messages, which are discarded. Broadcasts after Close are dropped. It is
+// safe to call more than once.
+func (m *WebSocketManager) Close() {
+	m.closeOnce.Do(func() {
+		close(m.done)
+
+		m.throttleMu.Lock()
+		defer m.throttleMu.Unlock()
+		for _, state := range m.throttled {
+			if state.timer != nil {
+				state.timer.Stop()
+				state.timer = nil
+			}
+			state.pending = nil
+		}
+	})
+}
+
+// send hands a message to the main loop, or drops it once the manager is
+// closed, so callers never block on a loop that has stopped.
+func (m *WebSocketManager) send(msg WebSocketMessage) {
+	select {
+	case m.broadcast <- msg:
+	case <-m.done:
+	}
+}
+
+// reserveSlot claims a connection slot, returning false if the cap is reached.
+func (m *WebSocketManager) reserveSlot() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.maxClients > 0 && m.active >= m.maxClients {
+		m.rejected++
+		return false
+	}
+	m.active++
+	return true
+}
+
+// releaseSlot frees a slot reserved for an upgrade that failed.
+func (m *WebSocketManager) releaseSlot() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.active--
+}
+
+// removeClient deletes a client and closes its send queue, which stops its
+// writer and closes the connection. The caller must hold mu. Returns false if
+// the client was already removed.
+func (m *WebSocketManager) removeClient(c *client) bool {
+	if _, ok := m.clients[c]; !ok {
+		return false
 	}
-	m.broadcast <- msg
+	delete(m.clients, c)
+	close(c.send)
+	m.active--
+	return true
+}
+
+// writePump sends the snapshot, then queued messages, to the client until
+// its queue is closed or a write fails. The client is registered before the
+// snapshot is built, so events from that time wait in the queue rather than
+// being missed; clients may see an event the snapshot already reflects.
+func (m *WebSocketManager) writePump(c *client) {
+	defer c.conn.Close()
+	if message, ok := m.snapshotMessage(); ok {
+		c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+		if err := c.conn.WriteJSON(message); err != nil {
+			log.Error().Err(err).Msg("Failed to write snapshot to websocket, closing connection")
+			m.unregister <- c
+			return
+		}
+	}
+	for message := range c.send {
+		c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+		if err := c.conn.WriteJSON(message); err != nil {
+			log.Error().Err(err).Msg("Failed to write to websocket, closing connection")
+			m.unregister <- c
+			return
+		}
+	}
+	// Queue closed by the manager: tell the client why before closing
+	c.conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "client too slow"),
+		time.Now().Add(time.Second))
+}
+
+// snapshotMessage builds the snapshot message for a new client. A failed
+// snapshot is logged and skipped; the client still receives updates.
+func (m *WebSocketManager) snapshotMessage() (WebSocketMessage, bool) {
+	m.mu.Lock()
+	snapshot := m.snapshot
+	m.mu.Unlock()
+	if snapshot == nil {
+		return WebSocketMessage{}, false
+	}
+
+	payload, err := snapshot()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to build WebSocket snapshot, sending updates only")
+		return WebSocketMessage{}, false
+	}
+	return WebSocketMessage{Type: "snapshot", Timestamp: time.Now(), Payload: payload}, true
+}
+
+// Subscribe registers an in-process listener for broadcast messages, so other
+// transports can reuse the WebSocket event stream. Delivery never blocks the
+// broadcast loop: if the subscriber's buffer is full the message is dropped.
+//
+// Args:
+//   - buffer: Channel buffer size (values < 1 are treated as 1)
+//
+// Returns:
+//   - <-chan WebSocketMessage: Broadcast messages
+//   - func(): Unsubscribe function; closes the channel and is safe to call more than once
+func (m *WebSocketManager) Subscribe(buffer int) (<-chan WebSocketMessage, func()) {
+	if buffer < 1 {
+		buffer = 1
+	}
+	ch := make(chan WebSocketMessage, buffer)
+
+	m.subMu.Lock()
+	m.subscribers[ch] = struct{}{}
+	m.subMu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			m.subMu.Lock()
+			delete(m.subscribers, ch)
+			m.subMu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// SubscriberCount returns the number of active in-process subscribers.
+func (m *WebSocketManager) SubscriberCount() int {
+	m.subMu.RLock()
+	defer m.subMu.RUnlock()
+	return len(m.subscribers)
+}
+
+// publish fans a message out to in-process subscribers without blocking.
+func (m *WebSocketManager) publish(message WebSocketMessage) {
+	m.subMu.RLock()
+	defer m.subMu.RUnlock()
+	for ch := range m.subscribers {
+		select {
+		case ch <- message:
+		default:
+			log.Warn().Str("type", message.Type).Msg("Subscriber buffer full, dropping message")
+		}
+	}
+}
+
+// Broadcast sends a message to all connected clients. A throttled type is
+// coalesced as a whole, see SetBroadcastThrottles.
+func (m *WebSocketManager) Broadcast(msgType string, payload interface{}) {
+	m.BroadcastKeyed(msgType, "", payload)
 }
 
 // HandleWebSocket upgrades the HTTP connection to a WebSocket connection.
+// Upgrades past the client cap are rejected with 503.
 func (m *WebSocketManager) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
+	if !m.reserveSlot() {
+		log.Warn().Str("ip", r.RemoteAddr).Msg("WebSocket client limit reached, rejecting connection")
+		http.Error(w, "Too many WebSocket clients", http.StatusServiceUnavailable)
+		return
+	}
+
 	conn, err := m.upgrader.Upgrade(w, r, nil)
 	if err != nil {
+		m.releaseSlot()
 		log.Error().Err(err).Msg("Failed to upgrade websocket")
 		return
 	}
-	m.register <- conn
+	c := &client{conn: conn, send: make(chan WebSocketMessage, clientSendBuffer)}
+	m.register <- c
+	go m.writePump(c)
 
 	go func() {
 		defer func() {
-			m.unregister <- conn
+			m.unregister <- c
 		}()
 		for {
 			_, _, err := conn.ReadMessage()
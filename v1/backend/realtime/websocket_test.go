@@ -101,3 +101,148 @@ func TestWebSocketManager_Disconnect(t *testing.T) {
 	assert.Equal(t, 0, len(manager.clients))
 	manager.mu.Unlock()
 }
+
+func TestWebSocketManager_Subscribe(t *testing.T) {
+	manager := NewWebSocketManager()
+	go manager.Run()
+
+	messages, unsubscribe := manager.Subscribe(1)
+	manager.Broadcast("test_event", map[string]string{"foo": "bar"})
+
+	select {
+	case msg := <-messages:
+		assert.Equal(t, "test_event", msg.Type)
+	case <-time.After(time.Second):
+		t.Fatal("subscriber did not receive broadcast")
+	}
+
+	// A full buffer drops messages instead of blocking the broadcast loop
+	manager.Broadcast("first", nil)
+	manager.Broadcast("second", nil)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, "first", (<-messages).Type)
+
+	unsubscribe()
+	unsubscribe()
+	_, open := <-messages
+	assert.False(t, open, "channel should be closed after unsubscribe")
+
+	assert.Zero(t, manager.SubscriberCount())
+}
+
+// TestWebSocketManager_DropsSlowClient verifies a client whose send buffer is
+// full is dropped instead of blocking the broadcast loop.
+func TestWebSocketManager_DropsSlowClient(t *testing.T) {
+	manager := NewWebSocketManager()
+	go manager.Run()
+
+	// A client with no writer, so its queue never drains
+	require.True(t, manager.reserveSlot())
+	slow := &client{send: make(chan WebSocketMessage, 1)}
+	manager.register <- slow
+
+	manager.Broadcast("first", nil)
+	manager.Broadcast("second", nil)
+
+	assert.Eventually(t, func() bool {
+		return manager.Stats().DroppedClients == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Zero(t, manager.Stats().Clients)
+
+	assert.Equal(t, "first", (<-slow.send).Type)
+	_, open := <-slow.send
+	assert.False(t, open, "send queue should be closed when the client is dropped")
+}
+
+// TestWebSocketManager_MaxClients verifies upgrades past the cap are rejected
+// with 503 until a slot frees up.
+func TestWebSocketManager_MaxClients(t *testing.T) {
+	manager := NewWebSocketManager()
+	manager.SetMaxClients(1)
+	go manager.Run()
+
+	server := httptest.NewServer(http.HandlerFunc(manager.HandleWebSocket))
+	defer server.Close()
+	u := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	first, _, err := websocket.DefaultDialer.Dial(u, nil)
+	require.NoError(t, err)
+
+	_, resp, err := websocket.DefaultDialer.Dial(u, nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int64(1), manager.Stats().RejectedClients)
+
+	first.Close()
+	assert.Eventually(t, func() bool {
+		conn, _, err := websocket.DefaultDialer.Dial(u, nil)
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}, time.Second, 20*time.Millisecond)
+}
+
+// TestWebSocketManager_Snapshot verifies a new client receives the snapshot
+// first, including events broadcast while the snapshot was being built.
+func TestWebSocketManager_Snapshot(t *testing.T) {
+	manager := NewWebSocketManager()
+	go manager.Run()
+
+	building := make(chan struct{})
+	release := make(chan struct{})
+	manager.SetSnapshot(func() (interface{}, error) {
+		close(building)
+		<-release
+		return map[string]int{"positions": 2}, nil
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(manager.HandleWebSocket))
+	defer server.Close()
+	u := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	ws, _, err := websocket.DefaultDialer.Dial(u, nil)
+	require.NoError(t, err)
+	defer ws.Close()
+
+	// An event while the snapshot is being built is queued, not lost
+	<-building
+	manager.Broadcast("order_update", map[string]string{"id": "order-1"})
+	close(release)
+
+	ws.SetReadDeadline(time.Now().Add(time.Second))
+	var msg WebSocketMessage
+	require.NoError(t, ws.ReadJSON(&msg))
+	assert.Equal(t, "snapshot", msg.Type)
+	assert.Equal(t, map[string]interface{}{"positions": float64(2)}, msg.Payload)
+
+	require.NoError(t, ws.ReadJSON(&msg))
+	assert.Equal(t, "order_update", msg.Type)
+}
+
+// TestWebSocketManager_SnapshotError verifies a failed snapshot is skipped
+// and the client still receives updates.
+func TestWebSocketManager_SnapshotError(t *testing.T) {
+	manager := NewWebSocketManager()
+	go manager.Run()
+	manager.SetSnapshot(func() (interface{}, error) {
+		return nil, assert.AnError
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(manager.HandleWebSocket))
+	defer server.Close()
+	u := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	ws, _, err := websocket.DefaultDialer.Dial(u, nil)
+	require.NoError(t, err)
+	defer ws.Close()
+	time.Sleep(50 * time.Millisecond)
+
+	manager.Broadcast("test_event", nil)
+	ws.SetReadDeadline(time.Now().Add(time.Second))
+	var msg WebSocketMessage
+	require.NoError(t, ws.ReadJSON(&msg))
+	assert.Equal(t, "test_event", msg.Type)
+}
@@ -29,6 +29,9 @@ func NewBollingerBandsStrategy() *BollingerBandsStrategy {
 
 // Init initializes the strategy with configuration.
 func (s *BollingerBandsStrategy) Init(config map[string]interface{}) error {
+	if err := ValidateConfig(config, s.GetParameters()); err != nil {
+		return err
+	}
 	if err := s.BaseStrategy.Init(config); err != nil {
 		return err
 	}
@@ -56,18 +59,24 @@ func (s *BollingerBandsStrategy) Validate() error {
 
 // GetParameters returns the strategy parameters.
 func (s *BollingerBandsStrategy) GetParameters() map[string]Parameter {
-	return map[string]Parameter{
+	return withCooldownParameters(map[string]Parameter{
 		"period": {
 			Description: "Moving Average Period",
 			Type:        "int",
 			Default:     20,
+			Min:         2,
+			Max:         100,
+			Step:        1,
 		},
 		"stdDevMultiplier": {
 			Description: "Standard Deviation Multiplier",
 			Type:        "float",
 			Default:     2.0,
+			Min:         0.5,
+			Max:         5.0,
+			Step:        0.1,
 		},
-	}
+	})
 }
 
 // OnData processes new market data and generates signals.
@@ -0,0 +1,235 @@
+package strategies
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// ensembleName is the registry name of the ensemble strategy.
+const ensembleName = "ensemble"
+
+// defaultEnsembleMembers are the child strategies used when none are configured.
+var defaultEnsembleMembers = []string{"ma_crossover", "rsi_momentum", "macd_trend_follower"}
+
+// EnsembleStrategy is a meta-strategy that runs several child strategies on the
+// same bars and only acts when a quorum of them agree.
+type EnsembleStrategy struct {
+	*BaseStrategy
+	Members []Strategy
+	Quorum  int
+}
+
+// NewEnsembleStrategy creates an ensemble of the default members with a quorum of 2.
+func NewEnsembleStrategy() *EnsembleStrategy {
+	return &EnsembleStrategy{
+		BaseStrategy: NewBaseStrategy(
+			ensembleName,
+			"Ensemble Strategy - Buy or sell only when a quorum of child strategies agree",
+		),
+		Members: []Strategy{NewMACrossover(), NewRSIStrategy(), NewMACDStrategy()},
+		Quorum:  2,
+	}
+}
+
+// Init initializes the ensemble and its children.
+//
+// Config keys:
+//   - strategies: Child strategy names
+//   - quorum: Number of children that must agree
+//   - configs: Per-child configuration keyed by strategy name
+func (s *EnsembleStrategy) Init(config map[string]interface{}) error {
+	if err := ValidateConfig(config, s.GetParameters()); err != nil {
+		return err
+	}
+	if err := s.BaseStrategy.Init(config); err != nil {
+		return err
+	}
+
+	names := defaultEnsembleMembers
+	if raw, ok := config["strategies"]; ok {
+		names = toStringSlice(raw)
+	}
+	childConfigs, _ := config["configs"].(map[string]interface{})
+	for name := range childConfigs {
+		if !containsString(names, name) {
+			return fmt.Errorf("config given for '%s', which is not an ensemble member", name)
+		}
+	}
+
+	members := make([]Strategy, 0, len(names))
+	for _, name := range names {
+		if name == ensembleName {
+			return fmt.Errorf("ensemble cannot contain itself")
+		}
+		if name == WebhookSignalsName {
+			return fmt.Errorf("ensemble cannot contain %s, whose signals are posted to the engine's instance", name)
+		}
+		child, err := NewStrategyByName(name)
+		if err != nil {
+			return err
+		}
+
+		childConfig := map[string]interface{}{}
+		if raw, ok := childConfigs[name]; ok {
+			cfg, ok := raw.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("config for '%s' must be an object", name)
+			}
+			childConfig = cfg
+		}
+		if err := child.Init(childConfig); err != nil {
+			return fmt.Errorf("failed to initialize '%s': %w", name, err)
+		}
+		members = append(members, child)
+	}
+	s.Members = members
+
+	if val, ok := toFloat(config["quorum"]); ok {
+		s.Quorum = int(val)
+	}
+
+	return s.Validate()
+}
+
+// Validate checks the quorum is reachable and the members share a timeframe.
+func (s *EnsembleStrategy) Validate() error {
+	if len(s.Members) == 0 {
+		return fmt.Errorf("ensemble requires at least one strategy")
+	}
+	if s.Quorum < 1 || s.Quorum > len(s.Members) {
+		return fmt.Errorf("quorum must be between 1 and %d", len(s.Members))
+	}
+	timeframe := s.Members[0].Timeframe()
+	for _, member := range s.Members[1:] {
+		if member.Timeframe() != timeframe {
+			return fmt.Errorf("ensemble members must share a timeframe: %s uses %s, %s uses %s",
+				s.Members[0].Name(), timeframe, member.Name(), member.Timeframe())
+		}
+	}
+	return nil
+}
+
+// Timeframe returns the members' shared timeframe.
+func (s *EnsembleStrategy) Timeframe() string {
+	if len(s.Members) == 0 {
+		return s.BaseStrategy.Timeframe()
+	}
+	return s.Members[0].Timeframe()
+}
+
+// ensembleMemberOptions lists the strategies an ensemble may contain: every
+// available strategy except the ensemble itself.
+func ensembleMemberOptions() []interface{} {
+	var options []interface{}
+	for _, name := range AvailableStrategies() {
+		if name != ensembleName {
+			options = append(options, name)
+		}
+	}
+	return options
+}
+
+// GetParameters returns the strategy parameters.
+func (s *EnsembleStrategy) GetParameters() map[string]Parameter {
+	return withCooldownParameters(map[string]Parameter{
+		"strategies": {
+			Description: "Names of the child strategies",
+			Type:        "list",
+			Default:     defaultEnsembleMembers,
+			Options:     ensembleMemberOptions(),
+		},
+		"quorum": {
+			Description: "Number of child strategies that must agree on a buy or sell",
+			Type:        "int",
+			Default:     2,
+			Min:         1,
+			Step:        1,
+		},
+		"configs": {
+			Description: "Configuration for each child strategy, keyed by name",
+			Type:        "object",
+			Default:     map[string]interface{}{},
+		},
+	})
+}
+
+// OnData runs every member on the data and emits a buy or sell only when at
+// least Quorum members produce that signal. Anything else is a hold.
+func (s *EnsembleStrategy) OnData(data []models.OHLCV) models.Signal {
+	signal := models.Signal{
+		Type:         models.SignalHold,
+		Strength:     models.SignalStrengthWeak,
+		StrategyName: s.Name(),
+		Reason:       "No quorum",
+	}
+
+	if len(data) == 0 {
+		signal.Reason = "Not enough data"
+		return signal
+	}
+
+	lastCandle := data[len(data)-1]
+	signal.Symbol = lastCandle.Symbol
+	signal.Price = lastCandle.Close
+
+	votes := map[models.SignalType][]string{}
+	for _, member := range s.Members {
+		memberSignal := member.OnData(data)
+		if memberSignal.Type == models.SignalBuy || memberSignal.Type == models.SignalSell {
+			votes[memberSignal.Type] = append(votes[memberSignal.Type], member.Name())
+		}
+	}
+
+	buys, sells := votes[models.SignalBuy], votes[models.SignalSell]
+	var winner models.SignalType
+	var voters []string
+	switch {
+	case len(buys) >= s.Quorum && len(buys) > len(sells):
+		winner, voters = models.SignalBuy, buys
+	case len(sells) >= s.Quorum && len(sells) > len(buys):
+		winner, voters = models.SignalSell, sells
+	default:
+		signal.Reason = fmt.Sprintf("No quorum (%d buy, %d sell, need %d of %d)",
+			len(buys), len(sells), s.Quorum, len(s.Members))
+		return signal
+	}
+
+	signal.Type = winner
+	signal.Strength = models.SignalStrengthModerate
+	if len(voters) == len(s.Members) {
+		signal.Strength = models.SignalStrengthStrong
+	}
+	signal.Reason = fmt.Sprintf("%d of %d strategies agree on %s: %s",
+		len(voters), len(s.Members), winner, strings.Join(voters, ", "))
+	return signal
+}
+
+// toStringSlice converts a list parameter value to strings.
+func toStringSlice(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				out = append(out, str)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// containsString reports whether a slice contains a value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package strategies
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedStrategy always emits the same signal.
+type fixedStrategy struct {
+	*BaseStrategy
+	signal models.SignalType
+}
+
+func newFixedStrategy(name string, signal models.SignalType) *fixedStrategy {
+	return &fixedStrategy{BaseStrategy: NewBaseStrategy(name, "fixed"), signal: signal}
+}
+
+func (s *fixedStrategy) OnData(data []models.OHLCV) models.Signal {
+	return models.Signal{Type: s.signal}
+}
+
+func (s *fixedStrategy) Validate() error { return nil }
+
+func (s *fixedStrategy) GetParameters() map[string]Parameter { return nil }
+
+// TestEnsembleStrategy_Voting verifies signals are only emitted at quorum.
+func TestEnsembleStrategy_Voting(t *testing.T) {
+	bars := []models.OHLCV{{Timestamp: time.Now(), Symbol: "AAPL", Close: 100}}
+
+	tests := []struct {
+		name     string
+		votes    []models.SignalType
+		quorum   int
+		expected models.SignalType
+		strength models.SignalStrength
+	}{
+		{"MajorityBuy", []models.SignalType{models.SignalBuy, models.SignalBuy, models.SignalHold}, 2, models.SignalBuy, models.SignalStrengthModerate},
+		{"UnanimousSell", []models.SignalType{models.SignalSell, models.SignalSell, models.SignalSell}, 2, models.SignalSell, models.SignalStrengthStrong},
+		{"BelowQuorum", []models.SignalType{models.SignalBuy, models.SignalHold, models.SignalHold}, 2, models.SignalHold, models.SignalStrengthWeak},
+		{"Split", []models.SignalType{models.SignalBuy, models.SignalSell, models.SignalHold}, 1, models.SignalHold, models.SignalStrengthWeak},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ensemble := NewEnsembleStrategy()
+			ensemble.Members = nil
+			for i, vote := range tt.votes {
+				ensemble.Members = append(ensemble.Members, newFixedStrategy(string(rune('a'+i)), vote))
+			}
+			ensemble.Quorum = tt.quorum
+			require.NoError(t, ensemble.Validate())
+
+			signal := ensemble.OnData(bars)
+			assert.Equal(t, tt.expected, signal.Type)
+			assert.Equal(t, tt.strength, signal.Strength)
+			assert.Equal(t, "ensemble", signal.StrategyName)
+			assert.Equal(t, "AAPL", signal.Symbol)
+		})
+	}
+}
+
+// TestEnsembleStrategy_Init verifies children are created and configured by name.
+func TestEnsembleStrategy_Init(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		ensemble := NewEnsembleStrategy()
+		require.NoError(t, ensemble.Init(map[string]interface{}{}))
+		assert.Len(t, ensemble.Members, 3)
+		assert.Equal(t, 2, ensemble.Quorum)
+	})
+
+	t.Run("CustomMembers", func(t *testing.T) {
+		ensemble := NewEnsembleStrategy()
+		err := ensemble.Init(map[string]interface{}{
+			"strategies": []interface{}{"ma_crossover", "bb_mean_reversion"},
+			"quorum":     2.0,
+			"configs": map[string]interface{}{
+				"ma_crossover": map[string]interface{}{"short_period": 5.0, "long_period": 30.0},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, ensemble.Members, 2)
+		assert.Equal(t, 2, ensemble.Quorum)
+
+		ma, ok := ensemble.Members[0].(*MACrossover)
+		require.True(t, ok)
+		assert.Equal(t, 5, ma.shortPeriod)
+		assert.Equal(t, 30, ma.longPeriod)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		cases := map[string]map[string]interface{}{
+			"Item nope must be one of":            {"strategies": []string{"nope"}},
+			"Item ensemble must be one of":        {"strategies": []string{"ensemble"}},
+			"quorum must be between 1":            {"strategies": []string{"ma_crossover"}, "quorum": 2},
+			"not an ensemble member":              {"configs": map[string]interface{}{"bb_mean_reversion": map[string]interface{}{}}},
+			"failed to initialize 'rsi_momentum'": {"configs": map[string]interface{}{"rsi_momentum": map[string]interface{}{"typo": 1}}},
+			"Value must be a list":                {"strategies": "ma_crossover"},
+		}
+		for expected, config := range cases {
+			err := NewEnsembleStrategy().Init(config)
+			assert.ErrorContains(t, err, expected)
+		}
+	})
+}
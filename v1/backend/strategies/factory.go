@@ -26,11 +26,32 @@ func NewStrategyByName(name string) (Strategy, error) {
 		return NewMACDStrategy(), nil
 	case "nyc_close_open":
 		return NewNYCCloseOpen(), nil
+	case ensembleName:
+		return NewEnsembleStrategy(), nil
+	case WebhookSignalsName:
+		return NewWebhookSignalStrategy(), nil
 	default:
 		return nil, fmt.Errorf("unknown strategy name: %s (available: %v)", name, AvailableStrategies())
 	}
 }
 
+// NewBacktestStrategy creates a strategy instance by name for a backtest,
+// sweep or comparison. The webhook signal strategy is refused: its signals
+// are posted to the engine's instance, so over history it would only hold.
+//
+// Args:
+//   - name: Strategy identifier (e.g., "ma_crossover")
+//
+// Returns:
+//   - Strategy: The created strategy instance
+//   - error: Error if the strategy name is unknown or can't be backtested
+func NewBacktestStrategy(name string) (Strategy, error) {
+	if name == WebhookSignalsName {
+		return nil, fmt.Errorf("%s executes posted signals and has no history to backtest", name)
+	}
+	return NewStrategyByName(name)
+}
+
 // AvailableStrategies returns a list of all available strategy names.
 // This is useful for validation and documentation.
 //
@@ -43,5 +64,7 @@ func AvailableStrategies() []string {
 		"bb_mean_reversion",
 		"macd_trend_follower",
 		"nyc_close_open",
+		ensembleName,
+		WebhookSignalsName,
 	}
 }
@@ -15,6 +15,8 @@ func TestNewStrategyByName_ValidNames(t *testing.T) {
 		{"bb_mean_reversion", "*strategies.BollingerBandsStrategy"},
 		{"macd_trend_follower", "*strategies.MACDStrategy"},
 		{"nyc_close_open", "*strategies.NYCCloseOpen"},
+		{"ensemble", "*strategies.EnsembleStrategy"},
+		{"webhook_signals", "*strategies.WebhookSignalStrategy"},
 	}
 
 	for _, tc := range testCases {
@@ -55,11 +57,25 @@ func TestNewStrategyByName_InvalidName(t *testing.T) {
 	}
 }
 
+// TestNewBacktestStrategy tests that the webhook strategy is refused for
+// backtests while the others are created.
+func TestNewBacktestStrategy(t *testing.T) {
+	strategy, err := NewBacktestStrategy(WebhookSignalsName)
+	if err == nil || strategy != nil {
+		t.Errorf("Expected %s to be refused, got %v, %v", WebhookSignalsName, strategy, err)
+	}
+
+	strategy, err = NewBacktestStrategy("ma_crossover")
+	if err != nil || strategy == nil {
+		t.Errorf("Expected ma_crossover to be created, got %v", err)
+	}
+}
+
 // TestAvailableStrategies tests that all available strategies are listed.
 func TestAvailableStrategies(t *testing.T) {
 	strategies := AvailableStrategies()
 
-	expectedCount := 5
+	expectedCount := 7
 	if len(strategies) != expectedCount {
 		t.Errorf("Expected %d strategies, got %d", expectedCount, len(strategies))
 	}
@@ -39,6 +39,9 @@ func NewMACrossover() *MACrossover {
 // Returns:
 //   - error: Any initialization error
 func (s *MACrossover) Init(config map[string]interface{}) error {
+	if err := ValidateConfig(config, s.GetParameters()); err != nil {
+		return err
+	}
 	if err := s.BaseStrategy.Init(config); err != nil {
 		return err
 	}
@@ -72,12 +75,13 @@ func (s *MACrossover) Validate() error {
 // Returns:
 //   - map[string]Parameter: Parameter specifications
 func (s *MACrossover) GetParameters() map[string]Parameter {
-	return map[string]Parameter{
+	return withCooldownParameters(map[string]Parameter{
 		"short_period": {
 			Type:        "int",
 			Default:     10,
 			Min:         2,
 			Max:         50,
+			Step:        1,
 			Description: "Short moving average period",
 		},
 		"long_period": {
@@ -85,9 +89,10 @@ func (s *MACrossover) GetParameters() map[string]Parameter {
 			Default:     20,
 			Min:         5,
 			Max:         200,
+			Step:        1,
 			Description: "Long moving average period",
 		},
-	}
+	})
 }
 
 // OnData processes OHLCV data and generates trading signals.
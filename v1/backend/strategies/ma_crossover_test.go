@@ -59,7 +59,7 @@ func TestMACrossover_Init(t *testing.T) {
 				"long_period":  20,
 			},
 			wantErr:     true,
-			errContains: "must be positive",
+			errContains: "short_period: Value must be greater than or equal to 2",
 		},
 	}
 
@@ -116,27 +116,28 @@ func TestMACrossover_OnData_BullishCrossover(t *testing.T) {
 	s := NewMACrossover()
 	_ = s.Init(map[string]interface{}{
 		"short_period": 2,
-		"long_period":  4,
+		"long_period":  5,
 	})
 
 	// Create data where short MA crosses above long MA
-	// Need at least long_period + 1 = 5 data points
-	// Bar 0-3: Low prices so long MA is low
-	// Bar 4: Sharp rise so short MA crosses above
+	// Need at least long_period + 1 = 6 data points
+	// Bar 0-4: Low prices so long MA is low
+	// Bar 5: Sharp rise so short MA crosses above
 	data := []models.OHLCV{
 		{Symbol: "TEST", Close: 100},
 		{Symbol: "TEST", Close: 100},
 		{Symbol: "TEST", Close: 100},
 		{Symbol: "TEST", Close: 100},
+		{Symbol: "TEST", Close: 100},
 		{Symbol: "TEST", Close: 120}, // Jump causes short MA to cross above long MA
 	}
 
 	signal := s.OnData(data)
 	// Short MA (2 periods) = (100+120)/2 = 110
-	// Long MA (4 periods) = (100+100+100+120)/4 = 105
+	// Long MA (5 periods) = (100+100+100+100+120)/5 = 104
 	// Previous Short MA = (100+100)/2 = 100
-	// Previous Long MA = (100+100+100+100)/4 = 100
-	// prevShort <= prevLong (100 <= 100) AND currentShort > currentLong (110 > 105) = BULLISH
+	// Previous Long MA = (100+100+100+100+100)/5 = 100
+	// prevShort <= prevLong (100 <= 100) AND currentShort > currentLong (110 > 104) = BULLISH
 	assert.Equal(t, models.SignalBuy, signal.Type)
 	assert.Contains(t, signal.Reason, "Bullish crossover")
 }
@@ -146,26 +147,27 @@ func TestMACrossover_OnData_BearishCrossover(t *testing.T) {
 	s := NewMACrossover()
 	_ = s.Init(map[string]interface{}{
 		"short_period": 2,
-		"long_period":  4,
+		"long_period":  5,
 	})
 
 	// Create data where short MA crosses below long MA
-	// Bar 0-3: High prices so long MA is high
-	// Bar 4: Sharp drop so short MA crosses below
+	// Bar 0-4: High prices so long MA is high
+	// Bar 5: Sharp drop so short MA crosses below
 	data := []models.OHLCV{
 		{Symbol: "TEST", Close: 120},
 		{Symbol: "TEST", Close: 120},
 		{Symbol: "TEST", Close: 120},
 		{Symbol: "TEST", Close: 120},
+		{Symbol: "TEST", Close: 120},
 		{Symbol: "TEST", Close: 100}, // Drop causes short MA to cross below long MA
 	}
 
 	signal := s.OnData(data)
 	// Short MA (2 periods) = (120+100)/2 = 110
-	// Long MA (4 periods) = (120+120+120+100)/4 = 115
+	// Long MA (5 periods) = (120+120+120+120+100)/5 = 116
 	// Previous Short MA = (120+120)/2 = 120
-	// Previous Long MA = (120+120+120+120)/4 = 120
-	// prevShort >= prevLong (120 >= 120) AND currentShort < currentLong (110 < 115) = BEARISH
+	// Previous Long MA = (120+120+120+120+120)/5 = 120
+	// prevShort >= prevLong (120 >= 120) AND currentShort < currentLong (110 < 116) = BEARISH
 	assert.Equal(t, models.SignalSell, signal.Type)
 	assert.Contains(t, signal.Reason, "Bearish crossover")
 }
@@ -175,7 +177,7 @@ func TestMACrossover_OnData_NoCrossover(t *testing.T) {
 	s := NewMACrossover()
 	_ = s.Init(map[string]interface{}{
 		"short_period": 2,
-		"long_period":  4,
+		"long_period":  5,
 	})
 
 	// Create flat data - no crossover
@@ -185,6 +187,7 @@ func TestMACrossover_OnData_NoCrossover(t *testing.T) {
 		{Symbol: "TEST", Close: 100},
 		{Symbol: "TEST", Close: 100},
 		{Symbol: "TEST", Close: 100},
+		{Symbol: "TEST", Close: 100},
 	}
 
 	signal := s.OnData(data)
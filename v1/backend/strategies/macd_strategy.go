@@ -31,6 +31,9 @@ func NewMACDStrategy() *MACDStrategy {
 
 // Init initializes the strategy with configuration.
 func (s *MACDStrategy) Init(config map[string]interface{}) error {
+	if err := ValidateConfig(config, s.GetParameters()); err != nil {
+		return err
+	}
 	if err := s.BaseStrategy.Init(config); err != nil {
 		return err
 	}
@@ -61,23 +64,32 @@ func (s *MACDStrategy) Validate() error {
 
 // GetParameters returns the strategy parameters.
 func (s *MACDStrategy) GetParameters() map[string]Parameter {
-	return map[string]Parameter{
+	return withCooldownParameters(map[string]Parameter{
 		"fastPeriod": {
 			Description: "Fast EMA Period",
 			Type:        "int",
 			Default:     12,
+			Min:         2,
+			Max:         100,
+			Step:        1,
 		},
 		"slowPeriod": {
 			Description: "Slow EMA Period",
 			Type:        "int",
 			Default:     26,
+			Min:         2,
+			Max:         200,
+			Step:        1,
 		},
 		"signalPeriod": {
 			Description: "Signal Line Period",
 			Type:        "int",
 			Default:     9,
+			Min:         2,
+			Max:         100,
+			Step:        1,
 		},
-	}
+	})
 }
 
 // OnData processes new market data and generates signals.
@@ -2,7 +2,6 @@ package strategies
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/alexherrero/sherwood/backend/models"
 )
@@ -16,7 +15,7 @@ import (
 // exactly at these times requires an exchange that supports it.
 type NYCCloseOpen struct {
 	*BaseStrategy
-	location *time.Location
+	session Session
 }
 
 // NewNYCCloseOpen creates a new NYC Close/Open strategy.
@@ -31,18 +30,18 @@ func NewNYCCloseOpen() *NYCCloseOpen {
 
 // Init initializes the strategy.
 func (s *NYCCloseOpen) Init(config map[string]interface{}) error {
+	if err := ValidateConfig(config, s.GetParameters()); err != nil {
+		return err
+	}
 	if err := s.BaseStrategy.Init(config); err != nil {
 		return err
 	}
 
-	loc, err := time.LoadLocation("America/New_York")
+	session, err := NewUSEquitySession()
 	if err != nil {
-		// Fallback to FixedZone if LoadLocation fails (common in some container environments)
-		// EST is UTC-5, EDT is UTC-4. For simplicity in this fallback, we might not handle DST perfectly without a proper DB.
-		// However, standard environments should have tzdata.
 		return fmt.Errorf("failed to load NYC timezone: %w", err)
 	}
-	s.location = loc
+	s.session = session
 
 	return nil
 }
@@ -54,13 +53,14 @@ func (s *NYCCloseOpen) Validate() error {
 
 // GetParameters returns the strategy's parameter definitions.
 func (s *NYCCloseOpen) GetParameters() map[string]Parameter {
-	return map[string]Parameter{
+	return withCooldownParameters(map[string]Parameter{
 		"buy_hour": {
 			Type:        "int",
 			Default:     16,
 			Description: "Hour to buy (ET)",
 			Min:         0,
 			Max:         23,
+			Step:        1,
 		},
 		"buy_minute": {
 			Type:        "int",
@@ -68,6 +68,7 @@ func (s *NYCCloseOpen) GetParameters() map[string]Parameter {
 			Description: "Minute to buy (ET)",
 			Min:         0,
 			Max:         59,
+			Step:        1,
 		},
 		"sell_hour": {
 			Type:        "int",
@@ -75,6 +76,7 @@ func (s *NYCCloseOpen) GetParameters() map[string]Parameter {
 			Description: "Hour to sell (ET)",
 			Min:         0,
 			Max:         23,
+			Step:        1,
 		},
 		"sell_minute": {
 			Type:        "int",
@@ -82,8 +84,9 @@ func (s *NYCCloseOpen) GetParameters() map[string]Parameter {
 			Description: "Minute to sell (ET)",
 			Min:         0,
 			Max:         59,
+			Step:        1,
 		},
-	}
+	})
 }
 
 // OnData processes OHLCV data and generates trading signals.
@@ -102,23 +105,20 @@ func (s *NYCCloseOpen) OnData(data []models.OHLCV) models.Signal {
 
 	candle := data[len(data)-1]
 
-	// Ensure we have a valid location loaded
-	if s.location == nil {
-		// Try loading again or default to UTC if strictly necessary, but better to fail safely/log error.
-		// For now, let's assume Init worked or we shouldn't be running.
-		// If Init failed, the engine likely wouldn't start this strategy.
-		// Defensive coding:
-		loc, err := time.LoadLocation("America/New_York")
+	// Ensure we have a valid session loaded. If Init failed, the engine
+	// likely wouldn't run this strategy, but fail safely if it does.
+	if s.session.Location == nil {
+		session, err := NewUSEquitySession()
 		if err != nil {
 			signal.Reason = "Timezone data missing"
 			return signal
 		}
-		s.location = loc
+		s.session = session
 	}
 
-	candleTimeNYC := candle.Timestamp.In(s.location)
-	hour := candleTimeNYC.Hour()
-	minute := candleTimeNYC.Minute()
+	// Candle timestamps may arrive in any timezone (UTC from Binance, the
+	// exchange's zone from others); compare wall-clock times in New York
+	candleTimeNYC := s.session.Local(candle.Timestamp)
 
 	// Strategy Logic:
 	// Buy at 16:00 ET (Market Close)
@@ -137,27 +137,25 @@ func (s *NYCCloseOpen) OnData(data []models.OHLCV) models.Signal {
 	// If it's Saturday or Sunday, we generally don't generate NEW signals,
 	// but if we are holding, we wait.
 
-	isWeekend := candleTimeNYC.Weekday() == time.Saturday || candleTimeNYC.Weekday() == time.Sunday
-
 	buyHour := s.GetConfigInt("buy_hour", 16)
 	buyMinute := s.GetConfigInt("buy_minute", 0)
 	sellHour := s.GetConfigInt("sell_hour", 8)
 	sellMinute := s.GetConfigInt("sell_minute", 30)
-
-	if !isWeekend {
-		if hour == buyHour && minute == buyMinute {
-			signal.Type = models.SignalBuy
-			signal.Strength = models.SignalStrengthStrong
-			signal.Reason = fmt.Sprintf("Market Close (%02d:%02d ET) on %s", buyHour, buyMinute, candleTimeNYC.Weekday())
-			signal.Symbol = candle.Symbol
-			signal.Price = candle.Close
-		} else if hour == sellHour && minute == sellMinute {
-			signal.Type = models.SignalSell
-			signal.Strength = models.SignalStrengthStrong
-			signal.Reason = fmt.Sprintf("Pre-Market (%02d:%02d ET) on %s", sellHour, sellMinute, candleTimeNYC.Weekday())
-			signal.Symbol = candle.Symbol
-			signal.Price = candle.Close
-		}
+	buyAt := TimeOfDay{Hour: buyHour, Minute: buyMinute}
+	sellAt := TimeOfDay{Hour: sellHour, Minute: sellMinute}
+
+	if s.session.IsAt(candle.Timestamp, buyAt) {
+		signal.Type = models.SignalBuy
+		signal.Strength = models.SignalStrengthStrong
+		signal.Reason = fmt.Sprintf("Market Close (%02d:%02d ET) on %s", buyHour, buyMinute, candleTimeNYC.Weekday())
+		signal.Symbol = candle.Symbol
+		signal.Price = candle.Close
+	} else if s.session.IsAt(candle.Timestamp, sellAt) {
+		signal.Type = models.SignalSell
+		signal.Strength = models.SignalStrengthStrong
+		signal.Reason = fmt.Sprintf("Pre-Market (%02d:%02d ET) on %s", sellHour, sellMinute, candleTimeNYC.Weekday())
+		signal.Symbol = candle.Symbol
+		signal.Price = candle.Close
 	}
 
 	return signal
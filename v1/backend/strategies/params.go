@@ -0,0 +1,191 @@
+// Package strategies provides strategy parameter validation.
+package strategies
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ValidateConfig checks a strategy configuration against its parameter
+// schema and returns an error listing every offending key, sorted.
+// Strategies call this from Init so typos are not silently ignored.
+//
+// Args:
+//   - config: Strategy configuration to check
+//   - params: Parameter definitions from GetParameters()
+//
+// Returns:
+//   - error: Error naming each invalid parameter, nil if valid
+func ValidateConfig(config map[string]interface{}, params map[string]Parameter) error {
+	errs := ValidateParameters(params, config)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(errs))
+	for key := range errs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	problems := make([]string, 0, len(keys))
+	for _, key := range keys {
+		problems = append(problems, fmt.Sprintf("%s: %s", key, errs[key]))
+	}
+	return fmt.Errorf("invalid strategy config: %s", strings.Join(problems, "; "))
+}
+
+// EffectiveParameters resolves the value each parameter takes under a
+// configuration: the configured value where set, the declared default
+// otherwise. Unknown config keys are ignored.
+//
+// Args:
+//   - params: Parameter definitions from GetParameters()
+//   - config: Strategy configuration (may be nil)
+//
+// Returns:
+//   - map[string]interface{}: Resolved value per parameter
+func EffectiveParameters(params map[string]Parameter, config map[string]interface{}) map[string]interface{} {
+	effective := make(map[string]interface{}, len(params))
+	for key, param := range params {
+		if value, ok := config[key]; ok {
+			effective[key] = value
+			continue
+		}
+		effective[key] = param.Default
+	}
+	return effective
+}
+
+// ValidateParameters checks a strategy configuration against the strategy's
+// parameter definitions: every key must be a known parameter, values must
+// match the declared type, numbers must fall within Min/Max when set, and
+// values must be one of Options when set (for lists, every item must be).
+// Besides "int" and "float", a parameter may be a "list" of strings or an
+// "object" (a nested map).
+//
+// Args:
+//   - params: Parameter definitions from GetParameters()
+//   - config: Strategy configuration to check
+//
+// Returns:
+//   - map[string]string: Error message per offending parameter (empty if valid)
+func ValidateParameters(params map[string]Parameter, config map[string]interface{}) map[string]string {
+	errs := make(map[string]string)
+	for key, value := range config {
+		param, ok := params[key]
+		if !ok {
+			errs[key] = "Unknown parameter"
+			continue
+		}
+
+		switch param.Type {
+		case "list":
+			if !isStringList(value) {
+				errs[key] = "Value must be a list of strings"
+			} else if item, ok := firstDisallowed(value, param.Options); ok {
+				errs[key] = fmt.Sprintf("Item %v must be one of %v", item, param.Options)
+			}
+			continue
+		case "object":
+			if _, ok := value.(map[string]interface{}); !ok {
+				errs[key] = "Value must be an object"
+			}
+			continue
+		}
+
+		number, ok := toFloat(value)
+		if !ok {
+			errs[key] = fmt.Sprintf("Value must be of type %s", param.Type)
+			continue
+		}
+		if param.Type == "int" && number != math.Trunc(number) {
+			errs[key] = "Value must be a whole number"
+			continue
+		}
+		if min, ok := toFloat(param.Min); ok && number < min {
+			errs[key] = fmt.Sprintf("Value must be greater than or equal to %v", param.Min)
+			continue
+		}
+		if max, ok := toFloat(param.Max); ok && number > max {
+			errs[key] = fmt.Sprintf("Value must be less than or equal to %v", param.Max)
+			continue
+		}
+		if len(param.Options) > 0 && !allowed(value, param.Options) {
+			errs[key] = fmt.Sprintf("Value must be one of %v", param.Options)
+		}
+	}
+	return errs
+}
+
+// allowed reports whether a value is one of the options. Numbers compare by
+// value, so a JSON 5.0 matches an int option of 5.
+func allowed(value interface{}, options []interface{}) bool {
+	number, isNumber := toFloat(value)
+	for _, option := range options {
+		if isNumber {
+			if n, ok := toFloat(option); ok && n == number {
+				return true
+			}
+			continue
+		}
+		if option == value {
+			return true
+		}
+	}
+	return false
+}
+
+// firstDisallowed returns the first list item not among the options. Any
+// item is allowed when no options are declared.
+func firstDisallowed(list interface{}, options []interface{}) (interface{}, bool) {
+	if len(options) == 0 {
+		return nil, false
+	}
+	var items []interface{}
+	switch v := list.(type) {
+	case []string:
+		for _, item := range v {
+			items = append(items, item)
+		}
+	case []interface{}:
+		items = v
+	}
+	for _, item := range items {
+		if !allowed(item, options) {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+// toFloat converts a numeric parameter value to float64.
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// isStringList reports whether a value is a list containing only strings.
+func isStringList(value interface{}) bool {
+	switch v := value.(type) {
+	case []string:
+		return true
+	case []interface{}:
+		for _, item := range v {
+			if _, ok := item.(string); !ok {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,122 @@
+package strategies
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateParameters verifies configs are checked against parameter definitions.
+func TestValidateParameters(t *testing.T) {
+	params := NewMACrossover().GetParameters()
+
+	t.Run("Valid", func(t *testing.T) {
+		errs := ValidateParameters(params, map[string]interface{}{"short_period": 5.0, "long_period": 30})
+		assert.Empty(t, errs)
+	})
+
+	t.Run("EmptyUsesDefaults", func(t *testing.T) {
+		assert.Empty(t, ValidateParameters(params, nil))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		errs := ValidateParameters(params, map[string]interface{}{
+			"short_period": 1,
+			"long_period":  20.5,
+			"window":       10,
+		})
+		assert.Len(t, errs, 3)
+		assert.Contains(t, errs["short_period"], "greater than or equal to 2")
+		assert.Equal(t, "Value must be a whole number", errs["long_period"])
+		assert.Equal(t, "Unknown parameter", errs["window"])
+	})
+
+	t.Run("WrongType", func(t *testing.T) {
+		errs := ValidateParameters(params, map[string]interface{}{"short_period": "ten"})
+		assert.Equal(t, "Value must be of type int", errs["short_period"])
+	})
+
+	t.Run("AboveMax", func(t *testing.T) {
+		errs := ValidateParameters(params, map[string]interface{}{"long_period": 500})
+		assert.Contains(t, errs["long_period"], "less than or equal to 200")
+	})
+}
+
+// TestEffectiveParameters verifies configured values override defaults and
+// unknown keys are dropped.
+func TestEffectiveParameters(t *testing.T) {
+	params := NewMACrossover().GetParameters()
+
+	effective := EffectiveParameters(params, map[string]interface{}{"short_period": 5, "bogus": 1})
+	assert.Len(t, effective, len(params))
+	assert.Equal(t, 5, effective["short_period"])
+	assert.Equal(t, params["long_period"].Default, effective["long_period"])
+	assert.NotContains(t, effective, "bogus")
+
+	assert.Equal(t, params["short_period"].Default, EffectiveParameters(params, nil)["short_period"])
+}
+
+// TestValidateConfig verifies Init rejects unknown and out-of-range keys.
+func TestValidateConfig(t *testing.T) {
+	assert.NoError(t, ValidateConfig(map[string]interface{}{"period": 14.0}, NewRSIStrategy().GetParameters()))
+
+	err := ValidateConfig(map[string]interface{}{"shrot_period": 5, "long_period": 500}, NewMACrossover().GetParameters())
+	require.Error(t, err)
+	assert.Equal(t, "invalid strategy config: long_period: Value must be less than or equal to 200; shrot_period: Unknown parameter", err.Error())
+
+	for _, s := range []Strategy{NewMACrossover(), NewRSIStrategy(), NewBollingerBandsStrategy(), NewMACDStrategy(), NewNYCCloseOpen()} {
+		err := s.Init(map[string]interface{}{"typo": 1})
+		assert.ErrorContains(t, err, "typo: Unknown parameter", s.Name())
+	}
+}
+
+// TestValidateParameters_ListAndObject verifies non-numeric parameter types.
+func TestValidateParameters_ListAndObject(t *testing.T) {
+	params := NewEnsembleStrategy().GetParameters()
+
+	assert.Empty(t, ValidateParameters(params, map[string]interface{}{
+		"strategies": []interface{}{"ma_crossover", "rsi_momentum"},
+		"configs":    map[string]interface{}{},
+	}))
+
+	errs := ValidateParameters(params, map[string]interface{}{
+		"strategies": []interface{}{"ma_crossover", 3},
+		"configs":    "none",
+	})
+	assert.Equal(t, "Value must be a list of strings", errs["strategies"])
+	assert.Equal(t, "Value must be an object", errs["configs"])
+}
+
+// TestValidateParameters_Options verifies scalar values and list items must
+// be among the declared options.
+func TestValidateParameters_Options(t *testing.T) {
+	params := map[string]Parameter{
+		"window": {Type: "int", Default: 5, Options: []interface{}{5, 10, 20}},
+		"mode":   {Type: "list", Default: []string{"a"}, Options: []interface{}{"a", "b"}},
+	}
+
+	assert.Empty(t, ValidateParameters(params, map[string]interface{}{"window": 10.0, "mode": []string{"a", "b"}}))
+
+	errs := ValidateParameters(params, map[string]interface{}{"window": 15, "mode": []interface{}{"a", "c"}})
+	assert.Equal(t, "Value must be one of [5 10 20]", errs["window"])
+	assert.Equal(t, "Item c must be one of [a b]", errs["mode"])
+}
+
+// TestGetParameters_Bounds verifies every numeric parameter declares bounds
+// and a step, and its default satisfies them.
+func TestGetParameters_Bounds(t *testing.T) {
+	for _, name := range AvailableStrategies() {
+		strategy, err := NewStrategyByName(name)
+		require.NoError(t, err)
+
+		for key, param := range strategy.GetParameters() {
+			if param.Type != "int" && param.Type != "float" {
+				continue
+			}
+			assert.NotNil(t, param.Min, "%s.%s min", name, key)
+			assert.NotNil(t, param.Step, "%s.%s step", name, key)
+			assert.Empty(t, ValidateParameters(strategy.GetParameters(), map[string]interface{}{key: param.Default}), "%s.%s default", name, key)
+		}
+	}
+}
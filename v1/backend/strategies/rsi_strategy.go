@@ -30,6 +30,9 @@ func NewRSIStrategy() *RSIStrategy {
 
 // Init initializes the strategy with configuration.
 func (s *RSIStrategy) Init(config map[string]interface{}) error {
+	if err := ValidateConfig(config, s.GetParameters()); err != nil {
+		return err
+	}
 	if err := s.BaseStrategy.Init(config); err != nil {
 		return err
 	}
@@ -60,23 +63,32 @@ func (s *RSIStrategy) Validate() error {
 
 // GetParameters returns the strategy parameters.
 func (s *RSIStrategy) GetParameters() map[string]Parameter {
-	return map[string]Parameter{
+	return withCooldownParameters(map[string]Parameter{
 		"period": {
 			Description: "RSI Period",
 			Type:        "int",
 			Default:     14,
+			Min:         2,
+			Max:         100,
+			Step:        1,
 		},
 		"overbought": {
 			Description: "Level above which asset is considered overbought",
 			Type:        "float",
 			Default:     70.0,
+			Min:         50.0,
+			Max:         95.0,
+			Step:        1.0,
 		},
 		"oversold": {
 			Description: "Level below which asset is considered oversold",
 			Type:        "float",
 			Default:     30.0,
+			Min:         5.0,
+			Max:         50.0,
+			Step:        1.0,
 		},
-	}
+	})
 }
 
 // OnData processes new market data and generates signals.
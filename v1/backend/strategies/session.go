@@ -0,0 +1,124 @@
+// Package strategies provides exchange session helpers for strategies that
+// trade around market hours.
+package strategies
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultSessionTimezone is the exchange timezone of the US equity session.
+const DefaultSessionTimezone = "America/New_York"
+
+// TimeOfDay is a wall-clock time in a session's timezone.
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+}
+
+// String formats the time as HH:MM.
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+}
+
+// Session is a daily trading session in an exchange timezone, held Monday to
+// Friday. Boundaries are computed from wall-clock times, so they follow the
+// exchange across daylight saving changes regardless of the timezone candle
+// timestamps arrive in.
+type Session struct {
+	// Location is the exchange timezone.
+	Location *time.Location
+	// Open is the session open in Location.
+	Open TimeOfDay
+	// Close is the session close in Location.
+	Close TimeOfDay
+}
+
+// NewSession creates a session in the named timezone.
+//
+// Args:
+//   - timezone: IANA timezone name (e.g., "America/New_York")
+//   - open: Session open, wall-clock
+//   - close: Session close, wall-clock
+//
+// Returns:
+//   - Session: The session
+//   - error: If the timezone cannot be loaded
+func NewSession(timezone string, open, close TimeOfDay) (Session, error) {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return Session{}, fmt.Errorf("failed to load timezone %s: %w", timezone, err)
+	}
+	return Session{Location: loc, Open: open, Close: close}, nil
+}
+
+// NewUSEquitySession creates the regular US equity session, 09:30 to 16:00
+// New York time.
+//
+// Returns:
+//   - Session: The session
+//   - error: If the timezone cannot be loaded
+func NewUSEquitySession() (Session, error) {
+	return NewSession(DefaultSessionTimezone, TimeOfDay{Hour: 9, Minute: 30}, TimeOfDay{Hour: 16})
+}
+
+// Local returns t in the session's timezone.
+func (s Session) Local(t time.Time) time.Time {
+	return t.In(s.Location)
+}
+
+// IsTradingDay reports whether t falls on a weekday in the session's
+// timezone.
+func (s Session) IsTradingDay(t time.Time) bool {
+	day := s.Local(t).Weekday()
+	return day != time.Saturday && day != time.Sunday
+}
+
+// At returns the instant of a wall-clock time on t's date in the session's
+// timezone.
+func (s Session) At(t time.Time, tod TimeOfDay) time.Time {
+	local := s.Local(t)
+	return time.Date(local.Year(), local.Month(), local.Day(), tod.Hour, tod.Minute, 0, 0, s.Location)
+}
+
+// IsAt reports whether t is within the minute of the wall-clock time on a
+// trading day.
+func (s Session) IsAt(t time.Time, tod TimeOfDay) bool {
+	return s.IsTradingDay(t) && s.At(t, tod).Equal(t.Truncate(time.Minute))
+}
+
+// IsOpen reports whether the session is open at t.
+func (s Session) IsOpen(t time.Time) bool {
+	return s.IsTradingDay(t) && !t.Before(s.At(t, s.Open)) && t.Before(s.At(t, s.Close))
+}
+
+// OpensBetween reports whether a session open falls in (prev, cur], so a
+// strategy sees the boundary on the first bar at or after it whatever the
+// bar interval.
+func (s Session) OpensBetween(prev, cur time.Time) bool {
+	return s.crosses(s.Open, prev, cur)
+}
+
+// ClosesBetween reports whether a session close falls in (prev, cur].
+func (s Session) ClosesBetween(prev, cur time.Time) bool {
+	return s.crosses(s.Close, prev, cur)
+}
+
+// crosses reports whether the wall-clock time on a trading day falls in
+// (prev, cur].
+func (s Session) crosses(tod TimeOfDay, prev, cur time.Time) bool {
+	if !cur.After(prev) {
+		return false
+	}
+	// Any week-long span contains every weekday boundary
+	if cur.Sub(prev) >= 7*24*time.Hour {
+		return true
+	}
+	for day := s.Local(prev); !s.At(day, TimeOfDay{}).After(cur); day = day.AddDate(0, 0, 1) {
+		at := s.At(day, tod)
+		if s.IsTradingDay(at) && at.After(prev) && !at.After(cur) {
+			return true
+		}
+	}
+	return false
+}
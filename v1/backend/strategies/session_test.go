@@ -0,0 +1,68 @@
+package strategies
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSession_DSTBoundary verifies session boundaries follow New York wall
+// time across the March 2026 daylight saving change when candles are in UTC.
+// The open is 14:30 UTC on Friday 6 March (EST) and 13:30 UTC on Monday
+// 9 March (EDT).
+func TestSession_DSTBoundary(t *testing.T) {
+	session, err := NewUSEquitySession()
+	require.NoError(t, err)
+
+	friOpen := time.Date(2026, 3, 6, 14, 30, 0, 0, time.UTC)
+	monOpen := time.Date(2026, 3, 9, 13, 30, 0, 0, time.UTC)
+
+	assert.True(t, session.IsAt(friOpen, session.Open))
+	assert.True(t, session.IsAt(monOpen, session.Open))
+	assert.False(t, session.IsAt(monOpen.Add(time.Hour), session.Open), "EST offset applied after DST")
+
+	assert.False(t, session.IsOpen(friOpen.Add(-time.Minute)))
+	assert.True(t, session.IsOpen(friOpen))
+	assert.False(t, session.IsOpen(monOpen.Add(-time.Minute)))
+	assert.True(t, session.IsOpen(monOpen))
+	assert.True(t, session.IsOpen(time.Date(2026, 3, 9, 19, 59, 0, 0, time.UTC)))
+	assert.False(t, session.IsOpen(time.Date(2026, 3, 9, 20, 0, 0, 0, time.UTC)))
+
+	// Hourly UTC bars: the open is seen on the bar at or after it
+	assert.True(t, session.OpensBetween(monOpen.Add(-30*time.Minute), monOpen.Add(30*time.Minute)))
+	assert.False(t, session.OpensBetween(monOpen, monOpen.Add(time.Hour)))
+	assert.True(t, session.ClosesBetween(
+		time.Date(2026, 3, 9, 19, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 9, 20, 0, 0, 0, time.UTC)))
+	assert.False(t, session.ClosesBetween(
+		time.Date(2026, 3, 9, 20, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 9, 21, 0, 0, 0, time.UTC)))
+
+	// No boundaries over the weekend, and the Friday close to Monday open
+	// span contains the open
+	assert.False(t, session.OpensBetween(
+		time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 8, 23, 0, 0, 0, time.UTC)))
+	assert.True(t, session.OpensBetween(friOpen.Add(7*time.Hour), monOpen))
+	assert.True(t, session.OpensBetween(friOpen, friOpen.Add(8*24*time.Hour)))
+	assert.False(t, session.OpensBetween(monOpen, monOpen))
+}
+
+// TestNYCCloseOpen_UTCCandlesAcrossDST verifies the buy fires at 16:00 New
+// York time whether the candle is in EST or EDT, given UTC timestamps.
+func TestNYCCloseOpen_UTCCandlesAcrossDST(t *testing.T) {
+	strategy := NewNYCCloseOpen()
+	require.NoError(t, strategy.Init(nil))
+
+	signalAt := func(ts time.Time) models.SignalType {
+		return strategy.OnData([]models.OHLCV{{Timestamp: ts, Symbol: "BTC-USD", Close: 50000}}).Type
+	}
+
+	assert.Equal(t, models.SignalBuy, signalAt(time.Date(2026, 3, 6, 21, 0, 0, 0, time.UTC)))   // 16:00 EST
+	assert.Equal(t, models.SignalHold, signalAt(time.Date(2026, 3, 9, 21, 0, 0, 0, time.UTC)))  // 17:00 EDT
+	assert.Equal(t, models.SignalBuy, signalAt(time.Date(2026, 3, 9, 20, 0, 0, 0, time.UTC)))   // 16:00 EDT
+	assert.Equal(t, models.SignalSell, signalAt(time.Date(2026, 3, 9, 12, 30, 0, 0, time.UTC))) // 08:30 EDT
+}
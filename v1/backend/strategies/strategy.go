@@ -3,6 +3,8 @@ package strategies
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/alexherrero/sherwood/backend/models"
 )
@@ -50,13 +52,58 @@ type Strategy interface {
 	GetParameters() map[string]Parameter
 }
 
-// Parameter describes a configurable strategy parameter.
+// Parameter describes a configurable strategy parameter. Min, Max and
+// Options are enforced by ValidateParameters; Step is the granularity a
+// form should offer (e.g., a slider increment) and is not enforced.
 type Parameter struct {
-	Type        string      `json:"type"`
-	Default     interface{} `json:"default"`
-	Min         interface{} `json:"min,omitempty"`
-	Max         interface{} `json:"max,omitempty"`
-	Description string      `json:"description"`
+	Type        string        `json:"type"`
+	Default     interface{}   `json:"default"`
+	Min         interface{}   `json:"min,omitempty"`
+	Max         interface{}   `json:"max,omitempty"`
+	Step        interface{}   `json:"step,omitempty"`
+	Options     []interface{} `json:"options,omitempty"` // Allowed values; for lists, allowed items
+	Description string        `json:"description"`
+}
+
+// Cooldown limits how soon a strategy may trade a symbol again after a trade,
+// so a strategy that flips between buy and sell does not churn commissions.
+// When both limits are set, a trade must clear both.
+type Cooldown struct {
+	Ticks    int           // Engine ticks to skip after a trade
+	Duration time.Duration // Minimum time between trades
+}
+
+// IsZero reports whether no cooldown is configured.
+func (c Cooldown) IsZero() bool {
+	return c.Ticks <= 0 && c.Duration <= 0
+}
+
+// CooldownProvider is implemented by strategies that configure a trade
+// cooldown. Every strategy embedding BaseStrategy does, through the
+// "cooldown_ticks" and "cooldown_seconds" parameters.
+type CooldownProvider interface {
+	// Cooldown returns the strategy's trade cooldown.
+	Cooldown() Cooldown
+}
+
+// withCooldownParameters adds the cooldown parameters shared by every
+// strategy to params.
+func withCooldownParameters(params map[string]Parameter) map[string]Parameter {
+	params["cooldown_ticks"] = Parameter{
+		Type:        "int",
+		Default:     0,
+		Min:         0,
+		Step:        1,
+		Description: "Engine ticks to skip trading a symbol after a trade (0 disables)",
+	}
+	params["cooldown_seconds"] = Parameter{
+		Type:        "int",
+		Default:     0,
+		Min:         0,
+		Step:        1,
+		Description: "Seconds to skip trading a symbol after a trade (0 disables)",
+	}
+	return params
 }
 
 // BaseStrategy provides common functionality for strategies.
@@ -137,9 +184,21 @@ func (s *BaseStrategy) GetConfigFloat(key string, defaultValue float64) float64
 	}
 }
 
-// Registry manages available strategies.
+// Cooldown returns the trade cooldown from the "cooldown_ticks" and
+// "cooldown_seconds" config values.
+func (s *BaseStrategy) Cooldown() Cooldown {
+	return Cooldown{
+		Ticks:    s.GetConfigInt("cooldown_ticks", 0),
+		Duration: time.Duration(s.GetConfigInt("cooldown_seconds", 0)) * time.Second,
+	}
+}
+
+// Registry manages available strategies. It is safe for concurrent use, so
+// strategies can be replaced while the engine and API are reading them.
 type Registry struct {
 	strategies map[string]Strategy
+	disabled   map[string]bool // Names the engine skips; survives Replace
+	mu         sync.RWMutex
 }
 
 // NewRegistry creates a new strategy registry.
@@ -149,10 +208,13 @@ type Registry struct {
 func NewRegistry() *Registry {
 	return &Registry{
 		strategies: make(map[string]Strategy),
+		disabled:   make(map[string]bool),
 	}
 }
 
-// Register adds a strategy to the registry.
+// Register adds a strategy to the registry. It fails on duplicate names so
+// misconfigured startup is caught early; use Replace or RegisterOrReplace to
+// swap in a reconfigured strategy.
 //
 // Args:
 //   - strategy: Strategy to register
@@ -160,6 +222,9 @@ func NewRegistry() *Registry {
 // Returns:
 //   - error: Error if strategy name already registered
 func (r *Registry) Register(strategy Strategy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	name := strategy.Name()
 	if _, exists := r.strategies[name]; exists {
 		return fmt.Errorf("strategy already registered: %s", name)
@@ -168,6 +233,36 @@ func (r *Registry) Register(strategy Strategy) error {
 	return nil
 }
 
+// Replace adds a strategy to the registry, overwriting any strategy already
+// registered under the same name.
+//
+// Args:
+//   - strategy: Strategy to register
+//
+// Returns:
+//   - Strategy: The strategy that was replaced, or nil if the name was new
+func (r *Registry) Replace(strategy Strategy) Strategy {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := strategy.Name()
+	previous := r.strategies[name]
+	r.strategies[name] = strategy
+	return previous
+}
+
+// RegisterOrReplace is an idempotent Register: it adds the strategy, or
+// replaces the one registered under the same name.
+//
+// Args:
+//   - strategy: Strategy to register
+//
+// Returns:
+//   - bool: True if an existing strategy was replaced
+func (r *Registry) RegisterOrReplace(strategy Strategy) bool {
+	return r.Replace(strategy) != nil
+}
+
 // Get retrieves a strategy by name.
 //
 // Args:
@@ -177,6 +272,9 @@ func (r *Registry) Register(strategy Strategy) error {
 //   - Strategy: The strategy, or nil if not found
 //   - bool: True if found
 func (r *Registry) Get(name string) (Strategy, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	s, exists := r.strategies[name]
 	return s, exists
 }
@@ -186,6 +284,9 @@ func (r *Registry) Get(name string) (Strategy, bool) {
 // Returns:
 //   - []string: List of strategy names
 func (r *Registry) List() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	names := make([]string, 0, len(r.strategies))
 	for name := range r.strategies {
 		names = append(names, name)
@@ -196,7 +297,66 @@ func (r *Registry) List() []string {
 // All returns all registered strategies.
 //
 // Returns:
-//   - map[string]Strategy: All strategies
+//   - map[string]Strategy: A copy of the registered strategies
 func (r *Registry) All() map[string]Strategy {
-	return r.strategies
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	all := make(map[string]Strategy, len(r.strategies))
+	for name, s := range r.strategies {
+		all[name] = s
+	}
+	return all
+}
+
+// EnableStrategy lets the engine run a strategy disabled by DisableStrategy.
+//
+// Args:
+//   - name: Strategy name
+//
+// Returns:
+//   - bool: True if the strategy is registered
+func (r *Registry) EnableStrategy(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.strategies[name]; !exists {
+		return false
+	}
+	delete(r.disabled, name)
+	return true
+}
+
+// DisableStrategy stops the engine from running a strategy until it is
+// enabled again. The strategy stays registered, so it is still listed and
+// can be backtested. The state is kept in memory only; every strategy is
+// enabled after a restart.
+//
+// Args:
+//   - name: Strategy name
+//
+// Returns:
+//   - bool: True if the strategy is registered
+func (r *Registry) DisableStrategy(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.strategies[name]; !exists {
+		return false
+	}
+	r.disabled[name] = true
+	return true
+}
+
+// Enabled reports whether the engine runs a strategy.
+//
+// Args:
+//   - name: Strategy name
+//
+// Returns:
+//   - bool: False if the strategy was disabled
+func (r *Registry) Enabled(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return !r.disabled[name]
 }
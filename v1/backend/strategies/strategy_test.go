@@ -1,6 +1,7 @@
 package strategies
 
 import (
+	"sync"
 	"testing"
 	"time"
 
@@ -80,6 +81,78 @@ func TestRegistryRegister(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// TestRegistryReplace verifies strategies can be re-registered over an
+// existing name without the strict duplicate check.
+func TestRegistryReplace(t *testing.T) {
+	registry := NewRegistry()
+	original := NewMACrossover()
+	require.NoError(t, registry.Register(original))
+
+	reconfigured := NewMACrossover()
+	require.NoError(t, reconfigured.Init(map[string]interface{}{"short_period": 5}))
+	assert.Same(t, original, registry.Replace(reconfigured))
+
+	found, exists := registry.Get("ma_crossover")
+	require.True(t, exists)
+	assert.Same(t, reconfigured, found)
+
+	assert.Nil(t, registry.Replace(NewRSIStrategy()))
+	assert.True(t, registry.RegisterOrReplace(NewRSIStrategy()))
+	assert.False(t, registry.RegisterOrReplace(NewBollingerBandsStrategy()))
+	assert.Len(t, registry.List(), 3)
+}
+
+// TestRegistryEnableStrategy verifies disabled strategies stay registered and
+// stay disabled when replaced.
+func TestRegistryEnableStrategy(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewMACrossover()))
+	assert.True(t, registry.Enabled("ma_crossover"))
+
+	assert.True(t, registry.DisableStrategy("ma_crossover"))
+	assert.False(t, registry.Enabled("ma_crossover"))
+	_, exists := registry.Get("ma_crossover")
+	assert.True(t, exists)
+
+	registry.Replace(NewMACrossover())
+	assert.False(t, registry.Enabled("ma_crossover"))
+
+	assert.True(t, registry.EnableStrategy("ma_crossover"))
+	assert.True(t, registry.Enabled("ma_crossover"))
+
+	assert.False(t, registry.DisableStrategy("missing"))
+	assert.False(t, registry.EnableStrategy("missing"))
+}
+
+// TestRegistry_ConcurrentAccess verifies reads are safe while strategies are
+// being replaced (run with -race).
+func TestRegistry_ConcurrentAccess(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewMACrossover()))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				registry.Replace(NewMACrossover())
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_, _ = registry.Get("ma_crossover")
+				_ = registry.List()
+				_ = registry.All()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, registry.All(), 1)
+}
+
 // TestRegistryGet verifies strategy retrieval.
 func TestRegistryGet(t *testing.T) {
 	registry := NewRegistry()
@@ -320,3 +393,24 @@ func TestRSIStrategy_Details(t *testing.T) {
 	s.OversoldThreshold = 30.0
 	assert.NoError(t, s.Validate())
 }
+
+// TestBaseStrategy_Cooldown verifies every strategy accepts the cooldown
+// parameters and reports them through CooldownProvider.
+func TestBaseStrategy_Cooldown(t *testing.T) {
+	for _, name := range AvailableStrategies() {
+		s, err := NewStrategyByName(name)
+		require.NoError(t, err)
+		assert.Contains(t, s.GetParameters(), "cooldown_ticks", name)
+		assert.Contains(t, s.GetParameters(), "cooldown_seconds", name)
+
+		cp, ok := s.(CooldownProvider)
+		require.True(t, ok, name)
+		assert.True(t, cp.Cooldown().IsZero(), name)
+	}
+
+	s := NewMACrossover()
+	require.NoError(t, s.Init(map[string]interface{}{"cooldown_ticks": 3, "cooldown_seconds": 90.0}))
+	assert.Equal(t, Cooldown{Ticks: 3, Duration: 90 * time.Second}, s.Cooldown())
+
+	assert.Error(t, s.Init(map[string]interface{}{"cooldown_ticks": -1}))
+}
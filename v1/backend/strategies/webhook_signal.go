@@ -0,0 +1,175 @@
+package strategies
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+)
+
+// WebhookSignalsName is the registry name of the webhook signal strategy.
+const WebhookSignalsName = "webhook_signals"
+
+// ErrSignalQueueFull is returned when a symbol already has the maximum
+// number of ingested signals waiting.
+var ErrSignalQueueFull = errors.New("signal queue is full")
+
+// queuedSignal is an ingested signal waiting for its symbol's next tick.
+type queuedSignal struct {
+	signal     models.Signal
+	receivedAt time.Time
+}
+
+// WebhookSignalStrategy executes signals from an external source instead of
+// computing its own. Signals are queued with Enqueue, typically from the
+// signal ingest endpoint, and OnData returns the oldest queued signal for
+// the bars' symbol, one per tick, so each goes through the engine's normal
+// execution path with its risk checks, cooldowns and throttles. Signals
+// older than max_age_seconds when their tick comes are dropped.
+type WebhookSignalStrategy struct {
+	*BaseStrategy
+	mu      sync.Mutex
+	queues  map[string][]queuedSignal
+	aliases *data.SymbolAliases
+	now     func() time.Time
+}
+
+// NewWebhookSignalStrategy creates a webhook signal strategy with empty queues.
+func NewWebhookSignalStrategy() *WebhookSignalStrategy {
+	return &WebhookSignalStrategy{
+		BaseStrategy: NewBaseStrategy(
+			WebhookSignalsName,
+			"Webhook Signals - Execute buy and sell signals posted by an external system",
+		),
+		queues: make(map[string][]queuedSignal),
+		now:    time.Now,
+	}
+}
+
+// SetSymbolAliases sets the aliases signal and bar symbols are resolved
+// with, so a signal posted under an alias queues for its canonical symbol.
+//
+// Args:
+//   - aliases: Symbol aliases (nil for none)
+func (s *WebhookSignalStrategy) SetSymbolAliases(aliases *data.SymbolAliases) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.aliases = aliases
+}
+
+// Init initializes the strategy.
+func (s *WebhookSignalStrategy) Init(config map[string]interface{}) error {
+	if err := ValidateConfig(config, s.GetParameters()); err != nil {
+		return err
+	}
+	return s.BaseStrategy.Init(config)
+}
+
+// Validate checks if the strategy configuration is valid.
+func (s *WebhookSignalStrategy) Validate() error {
+	if s.GetConfigInt("max_queued", 100) < 1 {
+		return fmt.Errorf("max_queued must be at least 1")
+	}
+	return nil
+}
+
+// GetParameters returns the strategy's parameter definitions.
+func (s *WebhookSignalStrategy) GetParameters() map[string]Parameter {
+	return withCooldownParameters(map[string]Parameter{
+		"max_age_seconds": {
+			Type:        "int",
+			Default:     3600,
+			Description: "Seconds a queued signal stays valid; older ones are dropped (0 keeps them until executed)",
+			Min:         0,
+			Step:        60,
+		},
+		"max_queued": {
+			Type:        "int",
+			Default:     100,
+			Description: "Most signals waiting per symbol",
+			Min:         1,
+			Max:         1000,
+			Step:        1,
+		},
+	})
+}
+
+// Enqueue queues an external signal for its symbol's next tick. The signal
+// is attributed to this strategy, so it shares the strategy's cooldowns.
+//
+// Args:
+//   - signal: A buy or sell; its symbol (or alias) is resolved to canonical form
+//
+// Returns:
+//   - int: Signals now waiting for the symbol, including this one
+//   - error: If the signal is not a buy or sell, or the queue is full
+func (s *WebhookSignalStrategy) Enqueue(signal models.Signal) (int, error) {
+	if signal.Symbol == "" {
+		return 0, fmt.Errorf("signal has no symbol")
+	}
+	if signal.Type != models.SignalBuy && signal.Type != models.SignalSell {
+		return 0, fmt.Errorf("signal type must be %s or %s", models.SignalBuy, models.SignalSell)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	signal.Symbol = s.aliases.Resolve(signal.Symbol)
+	signal.StrategyName = s.Name()
+	queue := s.queues[signal.Symbol]
+	if len(queue) >= s.GetConfigInt("max_queued", 100) {
+		return len(queue), fmt.Errorf("%w for %s (%d waiting)", ErrSignalQueueFull, signal.Symbol, len(queue))
+	}
+	s.queues[signal.Symbol] = append(queue, queuedSignal{signal: signal, receivedAt: s.now()})
+	return len(queue) + 1, nil
+}
+
+// Pending returns the number of queued signals per symbol.
+func (s *WebhookSignalStrategy) Pending() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pending := make(map[string]int, len(s.queues))
+	for symbol, queue := range s.queues {
+		pending[symbol] = len(queue)
+	}
+	return pending
+}
+
+// OnData returns the oldest unexpired signal queued for the bars' symbol,
+// or a hold when there is none.
+func (s *WebhookSignalStrategy) OnData(bars []models.OHLCV) models.Signal {
+	signal := models.Signal{
+		Type:         models.SignalHold,
+		Strength:     models.SignalStrengthWeak,
+		StrategyName: s.Name(),
+		Reason:       "No external signal queued",
+	}
+	if len(bars) == 0 {
+		signal.Reason = "No data available"
+		return signal
+	}
+	maxAge := time.Duration(s.GetConfigInt("max_age_seconds", 3600)) * time.Second
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	symbol := s.aliases.Resolve(bars[len(bars)-1].Symbol)
+	signal.Symbol = symbol
+	queue := s.queues[symbol]
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if maxAge > 0 && now.Sub(next.receivedAt) > maxAge {
+			continue
+		}
+		signal = next.signal
+		break
+	}
+	if len(queue) == 0 {
+		delete(s.queues, symbol)
+	} else {
+		s.queues[symbol] = queue
+	}
+	return signal
+}
@@ -0,0 +1,97 @@
+package strategies
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alexherrero/sherwood/backend/data"
+	"github.com/alexherrero/sherwood/backend/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSignalStrategy_OnData(t *testing.T) {
+	s := NewWebhookSignalStrategy()
+	require.NoError(t, s.Init(map[string]interface{}{}))
+	btc := []models.OHLCV{{Symbol: "BTC-USD", Close: 100}}
+
+	assert.Equal(t, models.SignalHold, s.OnData(btc).Type)
+
+	queued, err := s.Enqueue(models.Signal{Symbol: "BTC-USD", Type: models.SignalBuy, Quantity: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 1, queued)
+	queued, err = s.Enqueue(models.Signal{Symbol: "btc/usd", Type: models.SignalSell, Price: 110})
+	require.NoError(t, err)
+	assert.Equal(t, 2, queued)
+	assert.Equal(t, map[string]int{"BTC-USD": 2}, s.Pending())
+
+	// Other symbols' bars don't consume the queue
+	assert.Equal(t, models.SignalHold, s.OnData([]models.OHLCV{{Symbol: "AAPL", Close: 190}}).Type)
+
+	// One signal per tick, oldest first
+	signal := s.OnData(btc)
+	assert.Equal(t, models.SignalBuy, signal.Type)
+	assert.Equal(t, 2.0, signal.Quantity)
+	assert.Equal(t, WebhookSignalsName, signal.StrategyName)
+	signal = s.OnData(btc)
+	assert.Equal(t, models.SignalSell, signal.Type)
+	assert.Equal(t, 110.0, signal.Price)
+
+	signal = s.OnData(btc)
+	assert.Equal(t, models.SignalHold, signal.Type)
+	assert.Equal(t, "BTC-USD", signal.Symbol)
+	assert.Empty(t, s.Pending())
+}
+
+func TestWebhookSignalStrategy_Aliases(t *testing.T) {
+	s := NewWebhookSignalStrategy()
+	require.NoError(t, s.Init(map[string]interface{}{}))
+	aliases, err := data.ParseSymbolAliases("BITCOIN:BTC-USD", "")
+	require.NoError(t, err)
+	s.SetSymbolAliases(aliases)
+
+	_, err = s.Enqueue(models.Signal{Symbol: "bitcoin", Type: models.SignalBuy})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"BTC-USD": 1}, s.Pending())
+
+	signal := s.OnData([]models.OHLCV{{Symbol: "BTC-USD", Close: 100}})
+	assert.Equal(t, models.SignalBuy, signal.Type)
+	assert.Equal(t, "BTC-USD", signal.Symbol)
+}
+
+func TestWebhookSignalStrategy_Expiry(t *testing.T) {
+	s := NewWebhookSignalStrategy()
+	require.NoError(t, s.Init(map[string]interface{}{"max_age_seconds": 60}))
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	_, err := s.Enqueue(models.Signal{Symbol: "SPY", Type: models.SignalBuy, Reason: "stale"})
+	require.NoError(t, err)
+	now = now.Add(45 * time.Second)
+	_, err = s.Enqueue(models.Signal{Symbol: "SPY", Type: models.SignalSell, Reason: "fresh"})
+	require.NoError(t, err)
+
+	// The first signal is past its age by the next tick and is skipped
+	now = now.Add(30 * time.Second)
+	signal := s.OnData([]models.OHLCV{{Symbol: "SPY", Close: 500}})
+	assert.Equal(t, "fresh", signal.Reason)
+	assert.Empty(t, s.Pending())
+}
+
+func TestWebhookSignalStrategy_Enqueue_Invalid(t *testing.T) {
+	s := NewWebhookSignalStrategy()
+	require.NoError(t, s.Init(map[string]interface{}{"max_queued": 1}))
+
+	_, err := s.Enqueue(models.Signal{Symbol: "SPY", Type: models.SignalHold})
+	assert.Error(t, err)
+	_, err = s.Enqueue(models.Signal{Type: models.SignalBuy})
+	assert.Error(t, err)
+
+	_, err = s.Enqueue(models.Signal{Symbol: "SPY", Type: models.SignalBuy})
+	require.NoError(t, err)
+	_, err = s.Enqueue(models.Signal{Symbol: "SPY", Type: models.SignalBuy})
+	assert.True(t, errors.Is(err, ErrSignalQueueFull))
+
+	assert.Error(t, NewEnsembleStrategy().Init(map[string]interface{}{"strategies": []interface{}{"ma_crossover", WebhookSignalsName}}))
+}
@@ -0,0 +1,71 @@
+// Package ids generates identifiers for orders, backtests and other records.
+package ids
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Generator produces unique identifiers.
+type Generator interface {
+	// NewID returns a new identifier.
+	NewID() string
+}
+
+// timeOrdered generates prefixed UUIDv7 identifiers.
+type timeOrdered struct {
+	prefix string
+}
+
+// NewTimeOrdered returns a generator of globally unique IDs that sort by
+// creation time, such as "paper-01920c5e-8a3b-7c4d-9e2f-0a1b2c3d4e5f". IDs
+// stay unique across restarts, so persisted records are never overwritten
+// by a reused ID.
+//
+// Args:
+//   - prefix: String prepended to every ID (e.g., "paper-")
+//
+// Returns:
+//   - Generator: The generator
+func NewTimeOrdered(prefix string) Generator {
+	return timeOrdered{prefix: prefix}
+}
+
+// NewID returns a new time-ordered identifier.
+func (g timeOrdered) NewID() string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		// NewV7 only fails if the system random source does
+		id = uuid.New()
+	}
+	return g.prefix + id.String()
+}
+
+// Sequential generates prefixed, zero-padded counter identifiers. It is
+// deterministic and intended for tests; IDs repeat after a restart.
+type Sequential struct {
+	prefix string
+	mu     sync.Mutex
+	next   int
+}
+
+// NewSequential returns a generator of IDs such as "paper-000001".
+//
+// Args:
+//   - prefix: String prepended to every ID
+//
+// Returns:
+//   - *Sequential: The generator
+func NewSequential(prefix string) *Sequential {
+	return &Sequential{prefix: prefix}
+}
+
+// NewID returns the next identifier in sequence.
+func (g *Sequential) NewID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.next++
+	return fmt.Sprintf("%s%06d", g.prefix, g.next)
+}
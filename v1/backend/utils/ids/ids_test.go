@@ -0,0 +1,32 @@
+package ids
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeOrdered(t *testing.T) {
+	gen := NewTimeOrdered("paper-")
+
+	generated := make([]string, 100)
+	seen := make(map[string]bool)
+	for i := range generated {
+		id := gen.NewID()
+		assert.True(t, strings.HasPrefix(id, "paper-"))
+		assert.False(t, seen[id], "duplicate id %s", id)
+		seen[id] = true
+		generated[i] = id
+	}
+
+	// IDs sort in the order they were generated
+	assert.True(t, sort.StringsAreSorted(generated))
+}
+
+func TestSequential(t *testing.T) {
+	gen := NewSequential("bt-")
+	assert.Equal(t, "bt-000001", gen.NewID())
+	assert.Equal(t, "bt-000002", gen.NewID())
+}